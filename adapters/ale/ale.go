@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package ale reads and writes Avid Log Exchange (ALE) files.
+//
+// Each ALE row becomes a Clip. All columns are kept in the clip's
+// metadata under the "ALE" key, and Start/End set the clip's source range
+// and its media reference's available range. The heading and column order
+// are kept in the collection's metadata so files round trip.
+//
+// Basic usage:
+//
+//	clips, err := ale.ReadFile("dailies.ale")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	// Add camera metadata to a timeline read from an EDL.
+//	matched := ale.Enrich(timeline, clips)
+package ale
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/adapters"
+)
+
+func init() {
+	gotio.RegisterAdapter(gotio.AdapterInfo{
+		Name:     "ale",
+		Package:  "github.com/Avalanche-io/gotio/adapters/ale",
+		Suffixes: []string{".ale"},
+		CanRead:  true,
+		CanWrite: true,
+		Options: []gotio.AdapterOption{
+			{Name: "rate", Type: "float", Description: "Frame rate, overriding the FPS heading field"},
+			{Name: "drop_frame", Type: "bool", Description: "Drop frame timecode"},
+			{Name: "columns", Type: "string", Description: "Columns written, in order, separated by commas"},
+		},
+	})
+}
+
+// MetadataKey is the metadata key holding ALE columns and heading fields.
+const MetadataKey = "ALE"
+
+// Well-known ALE column names.
+const (
+	ColumnName       = "Name"
+	ColumnTape       = "Tape"
+	ColumnStart      = "Start"
+	ColumnEnd        = "End"
+	ColumnDuration   = "Duration"
+	ColumnSourceFile = "Source File"
+	ColumnASCSOP     = "ASC_SOP"
+	ColumnASCSAT     = "ASC_SAT"
+)
+
+var (
+	// ErrInvalidALE is returned for files that do not follow the ALE layout.
+	ErrInvalidALE = errors.New("ale: invalid file")
+	// ErrUnsupportedDelimiter is returned for FIELD_DELIM values other than TABS.
+	ErrUnsupportedDelimiter = errors.New("ale: only TABS field delimiters are supported")
+)
+
+// Config holds configuration for reading and writing ALE files.
+type Config struct {
+	// Rate overrides the heading's FPS. When writing, zero uses the FPS
+	// from the collection heading or the first clip's rate.
+	Rate float64
+	// DropFrame selects drop frame timecode.
+	DropFrame opentime.IsDropFrameRate
+	// Columns sets the columns written, in order.
+	Columns []string
+}
+
+// Option is a functional option for Read and Write.
+type Option func(*Config)
+
+// WithRate sets the frame rate, overriding the FPS heading field.
+func WithRate(rate float64) Option {
+	return func(c *Config) {
+		c.Rate = rate
+	}
+}
+
+// WithDropFrame sets the drop frame mode for timecodes.
+func WithDropFrame(dropFrame opentime.IsDropFrameRate) Option {
+	return func(c *Config) {
+		c.DropFrame = dropFrame
+	}
+}
+
+// WithColumns sets the columns written, in order.
+func WithColumns(columns ...string) Option {
+	return func(c *Config) {
+		c.Columns = columns
+	}
+}
+
+func newConfig(opts []Option) Config {
+	cfg := Config{DropFrame: opentime.InferFromRate}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// FromOptions returns the functional options set by the adapter options
+// keyed "ale.", such as "ale.rate=25". Unknown options and values of the
+// wrong type are errors.
+func FromOptions(o adapters.Options) ([]Option, error) {
+	if err := o.Check("ale"); err != nil {
+		return nil, err
+	}
+	// Check has validated the value types.
+	o = o.For("ale")
+	var opts []Option
+	if o.Has("rate") {
+		rate, _ := o.Float("rate", 0)
+		opts = append(opts, WithRate(rate))
+	}
+	if o.Has("drop_frame") {
+		dropFrame, _ := o.DropFrame("drop_frame")
+		opts = append(opts, WithDropFrame(dropFrame))
+	}
+	if o.Has("columns") {
+		var columns []string
+		for column := range strings.SplitSeq(o.Text("columns", ""), ",") {
+			columns = append(columns, strings.TrimSpace(column))
+		}
+		opts = append(opts, WithColumns(columns...))
+	}
+	return opts, nil
+}
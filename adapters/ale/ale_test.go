@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package ale
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+const sampleALE = "Heading\n" +
+	"FIELD_DELIM\tTABS\n" +
+	"VIDEO_FORMAT\t1080\n" +
+	"AUDIO_FORMAT\t48khz\n" +
+	"FPS\t24\n" +
+	"\n" +
+	"Column\n" +
+	"Name\tTape\tStart\tEnd\tCamera\tLens\n" +
+	"\n" +
+	"Data\n" +
+	"A001C003\tA001\t01:00:00:00\t01:00:10:00\tALEXA\t50mm\n" +
+	"B001C001\tB001\t02:00:00:00\t02:00:05:00\tVENICE\t\n"
+
+func TestRead(t *testing.T) {
+	collection, err := Read(strings.NewReader(sampleALE))
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	if len(collection.Children()) != 2 {
+		t.Fatalf("expected 2 clips, got %d", len(collection.Children()))
+	}
+
+	clip := collection.Children()[0].(*gotio.Clip)
+	if clip.Name() != "A001C003" {
+		t.Errorf("Name = %q", clip.Name())
+	}
+	sr := clip.SourceRange()
+	if sr == nil || sr.StartTime().Value() != 86400 || sr.Duration().Value() != 240 {
+		t.Errorf("SourceRange = %v, want 86400 +240", sr)
+	}
+	if ar := clip.MediaReference().AvailableRange(); ar == nil || !ar.Duration().Equal(sr.Duration()) {
+		t.Errorf("AvailableRange = %v", ar)
+	}
+	if v, ok := clip.Metadata().Lookup("ALE.Camera"); !ok || v != "ALEXA" {
+		t.Errorf("Camera = %v", v)
+	}
+
+	short, err := Read(strings.NewReader("Heading\nFPS\t24\n\nColumn\nName\tLens\n\nData\nB001C001\n"))
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	if v, _ := short.Children()[0].(*gotio.Clip).Metadata().Lookup("ALE.Lens"); v != "" {
+		t.Errorf("missing trailing column should be empty, got %v", v)
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	collection, err := Read(strings.NewReader(sampleALE))
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, collection); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	if buf.String() != sampleALE {
+		t.Errorf("round trip mismatch:\n%s\nwant:\n%s", buf.String(), sampleALE)
+	}
+
+	if err := Write(&buf, collection, WithColumns("Name", "Lens")); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Name\tLens\n") || !strings.Contains(buf.String(), "A001C003\t50mm\n") {
+		t.Errorf("WithColumns not applied:\n%s", buf.String())
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	cases := map[string]string{
+		"no columns": "Heading\nFPS\t24\n",
+		"no heading": "Name\tStart\n",
+		"commas":     "Heading\nFIELD_DELIM\tCOMMAS\n\nColumn\nName\n\nData\na\n",
+		"bad start":  "Heading\nFPS\t24\n\nColumn\nName\tStart\n\nData\na\tnope\n",
+		"data first": "Heading\nFPS\t24\n\nData\na\n",
+	}
+	for name, input := range cases {
+		if _, err := Read(strings.NewReader(input)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if _, err := Read(strings.NewReader(cases["commas"])); !errors.Is(err, ErrUnsupportedDelimiter) {
+		t.Errorf("expected ErrUnsupportedDelimiter, got %v", err)
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	collection, err := Read(strings.NewReader(sampleALE))
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+
+	timeline := gotio.NewTimeline("cut", nil, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	timeline.Tracks().AppendChild(track)
+
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(86448, 24), opentime.NewRationalTime(24, 24))
+	byName := gotio.NewClip("A001C003", nil, &sr, nil, nil, nil, "", nil)
+	sr2 := opentime.NewTimeRange(opentime.NewRationalTime(172824, 24), opentime.NewRationalTime(24, 24))
+	byReel := gotio.NewClip("shot", nil, &sr2, gotio.AnyDictionary{"cmx_3600": map[string]any{"reel": "B001"}}, nil, nil, "", nil)
+	outside := opentime.NewTimeRange(opentime.NewRationalTime(90000, 24), opentime.NewRationalTime(24, 24))
+	unmatched := gotio.NewClip("A001C003", nil, &outside, nil, nil, nil, "", nil)
+	track.AppendChild(byName)
+	track.AppendChild(byReel)
+	track.AppendChild(unmatched)
+
+	if n := Enrich(timeline, collection); n != 2 {
+		t.Fatalf("Enrich matched %d clips, want 2", n)
+	}
+	if v, _ := byName.Metadata().Lookup("ALE.Lens"); v != "50mm" {
+		t.Errorf("byName Lens = %v", v)
+	}
+	if v, _ := byReel.Metadata().Lookup("ALE.Camera"); v != "VENICE" {
+		t.Errorf("byReel Camera = %v", v)
+	}
+	if ar := byName.MediaReference().AvailableRange(); ar == nil || ar.StartTime().Value() != 86400 {
+		t.Errorf("AvailableRange = %v, want ALE range", ar)
+	}
+	if _, ok := unmatched.Metadata()[MetadataKey]; ok {
+		t.Error("clip outside ALE range should not be enriched")
+	}
+}
+
+func TestCDLColumns(t *testing.T) {
+	input := "Heading\nFIELD_DELIM\tTABS\nFPS\t24\n\nColumn\nName\tASC_SOP\tASC_SAT\n\nData\n" +
+		"A001C003\t(1.1 1.0 1.0)(0.0 0.0 0.02)(1.0 1.0 0.9)\t0.8\n" +
+		"A001C004\t\t\n"
+	collection, err := Read(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	clip := collection.Children()[0].(*gotio.Clip)
+	if len(clip.Effects()) != 1 {
+		t.Fatalf("expected a CDL effect, got %v", clip.Effects())
+	}
+	cdl := clip.Effects()[0].(*gotio.ASCCDL)
+	if cdl.Slope() != [3]float64{1.1, 1, 1} || cdl.Power() != [3]float64{1, 1, 0.9} || cdl.Saturation() != 0.8 {
+		t.Errorf("CDL = %v %v %v", cdl.Slope(), cdl.Power(), cdl.Saturation())
+	}
+	if effects := collection.Children()[1].(*gotio.Clip).Effects(); len(effects) != 0 {
+		t.Errorf("empty columns should not add an effect, got %v", effects)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, collection); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "A001C003\t(1.1 1.0 1.0)(0.0 0.0 0.02)(1.0 1.0 0.9)\t0.8\n") {
+		t.Errorf("unchanged CDL should keep its text:\n%s", buf.String())
+	}
+
+	cdl.SetSaturation(1)
+	written := gotio.NewClip("B001", nil, nil, nil, []gotio.Effect{gotio.NewIdentityASCCDL("")}, nil, "", nil)
+	buf.Reset()
+	out := gotio.NewSerializableCollection("", []gotio.SerializableObject{clip, written}, nil)
+	if err := Write(&buf, out); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "A001C003\t\t\t\t\t1\t(1.1 1 1)(0 0 0.02)(1 1 0.9)\n") {
+		t.Errorf("changed CDL not written:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "B001\t\t\t\t\t1\t(1 1 1)(0 0 0)(1 1 1)\n") {
+		t.Errorf("CDL effect not written:\n%s", buf.String())
+	}
+
+	if _, err := Read(strings.NewReader("Heading\nFPS\t24\n\nColumn\nName\tASC_SOP\n\nData\na\t(1 1)\n")); err == nil {
+		t.Error("expected error for a malformed ASC_SOP")
+	}
+}
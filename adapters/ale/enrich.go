@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package ale
+
+import (
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// Enrich copies ALE columns onto the matching clips of a timeline, such as
+// one read from an EDL, and fills in missing available ranges. A timeline
+// clip matches an ALE clip when its name equals the ALE Name, or its
+// cmx_3600 reel equals the ALE Tape, and its source start lies within the
+// ALE clip's Start/End. It returns the number of clips enriched.
+func Enrich(timeline *gotio.Timeline, ale *gotio.SerializableCollection) int {
+	logged := collectClips(ale)
+
+	matched := 0
+	for _, clip := range timeline.FindClips(nil, false) {
+		source, err := clip.TrimmedRange()
+		if err != nil {
+			continue
+		}
+		entry := findEntry(logged, clip, source.StartTime())
+		if entry == nil {
+			continue
+		}
+		values, _ := entry.Metadata().GetDictionary(MetadataKey)
+
+		if clip.Metadata() == nil {
+			clip.SetMetadata(gotio.AnyDictionary{})
+		}
+		clip.Metadata()[MetadataKey] = gotio.CloneAnyDictionary(values)
+
+		if ref := clip.MediaReference(); ref != nil && ref.AvailableRange() == nil {
+			if available, err := entry.TrimmedRange(); err == nil {
+				ref.SetAvailableRange(&available)
+			}
+		}
+		matched++
+	}
+	return matched
+}
+
+// findEntry returns the ALE clip matching clip, or nil.
+func findEntry(logged []*gotio.Clip, clip *gotio.Clip, start opentime.RationalTime) *gotio.Clip {
+	reel := clipReel(clip)
+	for _, entry := range logged {
+		values, _ := entry.Metadata().GetDictionary(MetadataKey)
+		tape, _ := values.GetString(ColumnTape)
+		nameMatch := clip.Name() != "" && clip.Name() == entry.Name()
+		reelMatch := reel != "" && reel == tape
+		if !nameMatch && !reelMatch {
+			continue
+		}
+		available, err := entry.TrimmedRange()
+		if err != nil {
+			continue
+		}
+		if start.Cmp(available.StartTime()) >= 0 && start.Cmp(available.EndTimeExclusive()) < 0 {
+			return entry
+		}
+	}
+	return nil
+}
+
+// clipReel returns the reel recorded by the cmx_3600 EDL adapter.
+func clipReel(clip *gotio.Clip) string {
+	if cmx, ok := clip.Metadata().GetDictionary("cmx_3600"); ok {
+		reel, _ := cmx.GetString("reel")
+		return reel
+	}
+	return ""
+}
@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package ale
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// Read parses an ALE file into a collection of clips.
+func Read(r io.Reader, opts ...Option) (*gotio.SerializableCollection, error) {
+	cfg := newConfig(opts)
+
+	header := gotio.AnyDictionary{}
+	var columns []string
+	var rows [][]string
+
+	section := ""
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(text)) {
+		case "heading", "column", "data":
+			section = strings.ToLower(strings.TrimSpace(text))
+			continue
+		}
+
+		fields := strings.Split(text, "\t")
+		switch section {
+		case "heading":
+			key := strings.TrimSpace(fields[0])
+			value := ""
+			if len(fields) > 1 {
+				value = strings.TrimSpace(fields[1])
+			}
+			header[key] = value
+		case "column":
+			if columns != nil {
+				return nil, fmt.Errorf("%w: line %d: more than one column row", ErrInvalidALE, line)
+			}
+			for _, field := range fields {
+				columns = append(columns, strings.TrimSpace(field))
+			}
+		case "data":
+			if columns == nil {
+				return nil, fmt.Errorf("%w: line %d: data before column row", ErrInvalidALE, line)
+			}
+			rows = append(rows, fields)
+		default:
+			return nil, fmt.Errorf("%w: line %d: expected Heading section", ErrInvalidALE, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if columns == nil {
+		return nil, fmt.Errorf("%w: no Column section", ErrInvalidALE)
+	}
+	if delim, ok := header["FIELD_DELIM"].(string); ok && !strings.EqualFold(delim, "TABS") {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedDelimiter, delim)
+	}
+
+	rate := cfg.Rate
+	if rate <= 0 {
+		if fps, ok := header["FPS"].(string); ok {
+			rate, _ = strconv.ParseFloat(fps, 64)
+		}
+	}
+	if rate <= 0 {
+		rate = 24
+	}
+
+	columnList := make([]any, len(columns))
+	for i, column := range columns {
+		columnList[i] = column
+	}
+	collection := gotio.NewSerializableCollection("", nil, gotio.AnyDictionary{
+		MetadataKey: gotio.AnyDictionary{"header": header, "columns": columnList},
+	})
+
+	for i, fields := range rows {
+		values := gotio.AnyDictionary{}
+		for j, column := range columns {
+			if j < len(fields) {
+				values[column] = strings.TrimSpace(fields[j])
+			} else {
+				values[column] = ""
+			}
+		}
+		clip, err := rowClip(values, rate)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		collection.AppendChild(clip)
+	}
+
+	return collection, nil
+}
+
+// ReadFile reads an ALE file. The collection is named after the file.
+func ReadFile(path string, opts ...Option) (*gotio.SerializableCollection, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	collection, err := Read(f, opts...)
+	if err != nil {
+		return nil, err
+	}
+	collection.SetName(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	return collection, nil
+}
+
+// rowClip builds the clip for one row of column values.
+func rowClip(values gotio.AnyDictionary, rate float64) (*gotio.Clip, error) {
+	name, _ := values.GetString(ColumnName)
+
+	var sourceRange *opentime.TimeRange
+	start, err := rowTime(values, ColumnStart, rate)
+	if err != nil {
+		return nil, err
+	}
+	if start != nil {
+		var duration *opentime.RationalTime
+		if end, err := rowTime(values, ColumnEnd, rate); err != nil {
+			return nil, err
+		} else if end != nil {
+			d := end.Sub(*start)
+			duration = &d
+		} else if duration, err = rowTime(values, ColumnDuration, rate); err != nil {
+			return nil, err
+		}
+		if duration != nil {
+			tr := opentime.NewTimeRange(*start, *duration)
+			sourceRange = &tr
+		}
+	}
+
+	var ref gotio.MediaReference
+	if source, _ := values.GetString(ColumnSourceFile); source != "" {
+		ref = gotio.NewExternalReference("", source, sourceRange, nil)
+	} else {
+		ref = gotio.NewMissingReference("", sourceRange, nil)
+	}
+
+	var effects []gotio.Effect
+	cdl, err := rowCDL(values)
+	if err != nil {
+		return nil, err
+	}
+	if cdl != nil {
+		effects = append(effects, cdl)
+	}
+
+	metadata := gotio.AnyDictionary{MetadataKey: values}
+	return gotio.NewClip(name, ref, sourceRange, metadata, effects, nil, "", nil), nil
+}
+
+// rowCDL returns the color decision in the ASC_SOP and ASC_SAT columns,
+// or nil if both are empty.
+func rowCDL(values gotio.AnyDictionary) (*gotio.ASCCDL, error) {
+	sop, _ := values.GetString(ColumnASCSOP)
+	sat, _ := values.GetString(ColumnASCSAT)
+	if strings.TrimSpace(sop) == "" && strings.TrimSpace(sat) == "" {
+		return nil, nil
+	}
+	cdl := gotio.NewIdentityASCCDL("")
+	if strings.TrimSpace(sop) != "" {
+		slope, offset, power, err := gotio.ParseASCSOP(sop)
+		if err != nil {
+			return nil, err
+		}
+		cdl.SetSlope(slope)
+		cdl.SetOffset(offset)
+		cdl.SetPower(power)
+	}
+	if strings.TrimSpace(sat) != "" {
+		saturation, err := strconv.ParseFloat(strings.TrimSpace(sat), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s %q: %w", ColumnASCSAT, sat, err)
+		}
+		cdl.SetSaturation(saturation)
+	}
+	return cdl, nil
+}
+
+// rowTime parses a timecode column, returning nil if it is empty.
+func rowTime(values gotio.AnyDictionary, column string, rate float64) (*opentime.RationalTime, error) {
+	value, _ := values.GetString(column)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := opentime.FromTimecode(value, rate)
+	if err != nil {
+		return nil, fmt.Errorf("%s %q: %w", column, value, err)
+	}
+	return &t, nil
+}
@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package ale
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// headingOrder lists the heading fields written first, in order.
+var headingOrder = []string{"FIELD_DELIM", "VIDEO_FORMAT", "AUDIO_FORMAT", "FPS"}
+
+// Write writes the clips in obj as an ALE file. obj may be a collection,
+// a timeline or any composition. The heading and column order stored by
+// Read are reused when obj is a collection read from an ALE file.
+func Write(w io.Writer, obj gotio.SerializableObject, opts ...Option) error {
+	cfg := newConfig(opts)
+	clips := collectClips(obj)
+
+	stored := gotio.AnyDictionary{}
+	if named, ok := obj.(gotio.SerializableObjectWithMetadata); ok {
+		stored, _ = named.Metadata().GetDictionary(MetadataKey)
+	}
+	header, _ := stored.GetDictionary("header")
+
+	rate := cfg.Rate
+	if rate <= 0 {
+		if fps, ok := header.GetString("FPS"); ok {
+			rate, _ = strconv.ParseFloat(fps, 64)
+		}
+	}
+	if rate <= 0 {
+		rate = 24
+		for _, clip := range clips {
+			if tr, err := clip.TrimmedRange(); err == nil && tr.Duration().Rate() > 0 {
+				rate = tr.Duration().Rate()
+				break
+			}
+		}
+	}
+
+	columns := cfg.Columns
+	if columns == nil {
+		columns = storedColumns(stored)
+	}
+	if columns == nil {
+		columns = defaultColumns(clips)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, "Heading\n")
+	heading := gotio.AnyDictionary{
+		"FIELD_DELIM":  "TABS",
+		"VIDEO_FORMAT": "1080",
+		"AUDIO_FORMAT": "48khz",
+	}
+	for key, value := range header {
+		heading[key] = value
+	}
+	heading["FPS"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	for _, key := range headingKeys(heading) {
+		fmt.Fprintf(bw, "%s\t%v\n", key, heading[key])
+	}
+
+	fmt.Fprint(bw, "\nColumn\n")
+	fmt.Fprintf(bw, "%s\n", strings.Join(columns, "\t"))
+
+	fmt.Fprint(bw, "\nData\n")
+	for _, clip := range clips {
+		row, err := clipRow(cfg, clip, rate)
+		if err != nil {
+			return fmt.Errorf("clip %q: %w", clip.Name(), err)
+		}
+		fields := make([]string, len(columns))
+		for i, column := range columns {
+			fields[i] = row[column]
+		}
+		fmt.Fprintf(bw, "%s\n", strings.Join(fields, "\t"))
+	}
+
+	return bw.Flush()
+}
+
+// WriteFile writes an ALE file to path.
+func WriteFile(obj gotio.SerializableObject, path string, opts ...Option) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, obj, opts...); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// collectClips returns the clips in obj in document order.
+func collectClips(obj gotio.SerializableObject) []*gotio.Clip {
+	switch o := obj.(type) {
+	case *gotio.Clip:
+		return []*gotio.Clip{o}
+	case *gotio.SerializableCollection:
+		var clips []*gotio.Clip
+		for _, child := range o.Children() {
+			clips = append(clips, collectClips(child)...)
+		}
+		return clips
+	case *gotio.Timeline:
+		return o.FindClips(nil, false)
+	case gotio.Composition:
+		var clips []*gotio.Clip
+		for _, child := range o.Children() {
+			clips = append(clips, collectClips(child)...)
+		}
+		return clips
+	}
+	return nil
+}
+
+// clipRow returns the column values for a clip.
+func clipRow(cfg Config, clip *gotio.Clip, rate float64) (map[string]string, error) {
+	row := make(map[string]string)
+	if values, ok := clip.Metadata().GetDictionary(MetadataKey); ok {
+		for column, value := range values {
+			row[column] = fmt.Sprint(value)
+		}
+	}
+	row[ColumnName] = clip.Name()
+	if ref, ok := clip.MediaReference().(*gotio.ExternalReference); ok {
+		row[ColumnSourceFile] = ref.TargetURL()
+	}
+	if cdl := clipCDL(clip); cdl != nil {
+		// Keep the text read from the file unless the effect was changed.
+		if read, err := rowCDL(gotio.AnyDictionary{ColumnASCSOP: row[ColumnASCSOP], ColumnASCSAT: row[ColumnASCSAT]}); err != nil || read == nil || !read.IsEquivalentTo(cdl) {
+			row[ColumnASCSOP] = cdl.SOP()
+			row[ColumnASCSAT] = strconv.FormatFloat(cdl.Saturation(), 'f', -1, 64)
+		}
+	}
+
+	tr, err := clip.TrimmedRange()
+	if err != nil {
+		return row, nil
+	}
+	times := map[string]opentime.RationalTime{
+		ColumnStart:    tr.StartTime(),
+		ColumnEnd:      tr.EndTimeExclusive(),
+		ColumnDuration: tr.Duration(),
+	}
+	for column, t := range times {
+		if row[column], err = t.ToNearestTimecode(rate, cfg.DropFrame); err != nil {
+			return nil, err
+		}
+	}
+	return row, nil
+}
+
+// clipCDL returns the clip's first ASCCDL effect, or nil.
+func clipCDL(clip *gotio.Clip) *gotio.ASCCDL {
+	for _, effect := range clip.Effects() {
+		if cdl, ok := effect.(*gotio.ASCCDL); ok {
+			return cdl
+		}
+	}
+	return nil
+}
+
+// storedColumns returns the column order saved by Read.
+func storedColumns(stored gotio.AnyDictionary) []string {
+	switch list := stored["columns"].(type) {
+	case []string:
+		return list
+	case []any:
+		columns := make([]string, 0, len(list))
+		for _, column := range list {
+			if s, ok := column.(string); ok {
+				columns = append(columns, s)
+			}
+		}
+		return columns
+	}
+	return nil
+}
+
+// defaultColumns returns Name, Tape, Start, End and Duration followed by
+// the other ALE columns found on the clips, sorted.
+func defaultColumns(clips []*gotio.Clip) []string {
+	columns := []string{ColumnName, ColumnTape, ColumnStart, ColumnEnd, ColumnDuration}
+	seen := make(map[string]bool)
+	for _, column := range columns {
+		seen[column] = true
+	}
+	var extra []string
+	for _, clip := range clips {
+		if _, ok := clip.MediaReference().(*gotio.ExternalReference); ok && !seen[ColumnSourceFile] {
+			seen[ColumnSourceFile] = true
+			extra = append(extra, ColumnSourceFile)
+		}
+		if clipCDL(clip) != nil && !seen[ColumnASCSOP] {
+			seen[ColumnASCSOP], seen[ColumnASCSAT] = true, true
+			extra = append(extra, ColumnASCSOP, ColumnASCSAT)
+		}
+		values, _ := clip.Metadata().GetDictionary(MetadataKey)
+		for column := range values {
+			if !seen[column] {
+				seen[column] = true
+				extra = append(extra, column)
+			}
+		}
+	}
+	sort.Strings(extra)
+	return append(columns, extra...)
+}
+
+// headingKeys orders heading fields: well-known fields first, then the rest sorted.
+func headingKeys(heading gotio.AnyDictionary) []string {
+	var keys []string
+	for _, key := range headingOrder {
+		if _, ok := heading[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range heading.Keys() {
+		known := false
+		for _, k := range headingOrder {
+			known = known || k == key
+		}
+		if !known {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -15,6 +15,10 @@
 //	defer bridge.Close()
 //
 //	timeline, err := bridge.Read("project.edl")
+//
+// RegisterExternal and DiscoverExternal register adapters run as external
+// processes in any language, speaking the bridge's protocol one request
+// per process.
 package adapters
 
 import (
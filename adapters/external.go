@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package adapters
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+// ExternalPrefix begins the names of executables DiscoverExternal
+// registers as adapters: gotio-adapter-cmx_3600 is the cmx_3600 adapter.
+const ExternalPrefix = "gotio-adapter-"
+
+// ExternalAdapter is a format adapter run as an external process, in any
+// language. Each call starts the command, writes one request line to its
+// standard input in the protocol of the Python bridge, closes it and reads
+// one response line from its standard output:
+//
+//	{"id": 1, "method": "read_from_file", "params": {"filepath": "cut.edl", "adapter": "cmx_3600"}}
+//	{"id": 1, "result": "<OTIO JSON>", "error": null}
+//
+// The methods are "discover", returning a list of adapters as described
+// by FormatInfo, "read_from_file", returning OTIO JSON, and
+// "write_to_file", taking the OTIO JSON as the "data" parameter. Adapter
+// options are passed as the "args" parameter. The bridge script itself
+// serves any Python OTIO adapter this way, passing the arguments on as
+// keyword arguments.
+type ExternalAdapter struct {
+	FormatInfo
+	// Command is the executable and its arguments.
+	Command []string
+}
+
+// discoveredFormat is an entry of the result of the "discover" method.
+type discoveredFormat struct {
+	Name     string   `json:"name"`
+	Suffixes []string `json:"suffixes"`
+	Features struct {
+		Read  bool `json:"read"`
+		Write bool `json:"write"`
+	} `json:"features"`
+	Options []gotio.AdapterOption `json:"options"`
+}
+
+var (
+	externalRegistry = make(map[string]*ExternalAdapter) // name and suffix -> adapter
+	externalLock     sync.RWMutex
+)
+
+// RegisterExternal registers the adapter name served by the command cmd,
+// asking the command which suffixes it handles and whether it reads and
+// writes. The adapter is listed by gotio.Capabilities and found by
+// LookupExternal under its name and suffixes.
+func RegisterExternal(name string, cmd []string) (*ExternalAdapter, error) {
+	if len(cmd) == 0 {
+		return nil, fmt.Errorf("external adapter %s: empty command", name)
+	}
+	adapter := &ExternalAdapter{
+		FormatInfo: FormatInfo{Name: name},
+		Command:    slices.Clone(cmd),
+	}
+	var formats []discoveredFormat
+	if err := adapter.call("discover", nil, &formats); err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(formats, func(f discoveredFormat) bool {
+		return f.Name == name
+	})
+	if i < 0 {
+		return nil, fmt.Errorf("external adapter %s: %w", name, ErrFormatNotFound)
+	}
+	adapter.CanRead = formats[i].Features.Read
+	adapter.CanWrite = formats[i].Features.Write
+	for _, suffix := range formats[i].Suffixes {
+		adapter.Suffixes = append(adapter.Suffixes, normalizeSuffix(suffix))
+	}
+
+	externalLock.Lock()
+	externalRegistry[name] = adapter
+	for _, suffix := range adapter.Suffixes {
+		externalRegistry[suffix] = adapter
+	}
+	externalLock.Unlock()
+
+	gotio.RegisterAdapter(gotio.AdapterInfo{
+		Name:     name,
+		Package:  "external:" + cmd[0],
+		Suffixes: adapter.Suffixes,
+		CanRead:  adapter.CanRead,
+		CanWrite: adapter.CanWrite,
+		Options:  formats[i].Options,
+	})
+	return adapter, nil
+}
+
+// DiscoverExternal registers every executable named ExternalPrefix+name
+// in dirs, or in the directories of PATH if none are given. The first
+// found of a name wins. It returns the adapters registered and the
+// errors of those that could not be.
+func DiscoverExternal(dirs ...string) ([]*ExternalAdapter, error) {
+	if len(dirs) == 0 {
+		dirs = filepath.SplitList(os.Getenv("PATH"))
+	}
+	seen := make(map[string]bool)
+	var found []*ExternalAdapter
+	var errs []error
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), ExternalPrefix)
+			name = strings.TrimSuffix(name, filepath.Ext(name))
+			if !ok || name == "" || seen[name] || !isExecutable(filepath.Join(dir, entry.Name())) {
+				continue
+			}
+			seen[name] = true
+			adapter, err := RegisterExternal(name, []string{filepath.Join(dir, entry.Name())})
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			found = append(found, adapter)
+		}
+	}
+	return found, errors.Join(errs...)
+}
+
+// LookupExternal returns the external adapter registered under the name
+// or suffix format.
+func LookupExternal(format string) (*ExternalAdapter, bool) {
+	externalLock.RLock()
+	defer externalLock.RUnlock()
+	if adapter, ok := externalRegistry[format]; ok {
+		return adapter, true
+	}
+	adapter, ok := externalRegistry[normalizeSuffix(format)]
+	return adapter, ok
+}
+
+// ExternalAdapters returns the registered external adapters, sorted by
+// name.
+func ExternalAdapters() []*ExternalAdapter {
+	externalLock.RLock()
+	defer externalLock.RUnlock()
+	var adapters []*ExternalAdapter
+	for _, key := range slices.Sorted(maps.Keys(externalRegistry)) {
+		if adapter := externalRegistry[key]; adapter.Name == key {
+			adapters = append(adapters, adapter)
+		}
+	}
+	return adapters
+}
+
+// Read reads the file at path with the adapter.
+func (a *ExternalAdapter) Read(path string) (gotio.SerializableObject, error) {
+	return a.ReadWithOptions(path, nil)
+}
+
+// ReadWithOptions reads the file at path with the adapter, passing it
+// the options keyed with its name.
+func (a *ExternalAdapter) ReadWithOptions(path string, opts Options) (gotio.SerializableObject, error) {
+	if !a.CanRead {
+		return nil, fmt.Errorf("%s: format does not support reading", a.Name)
+	}
+	if err := opts.Check(a.Name); err != nil {
+		return nil, err
+	}
+	params := map[string]any{
+		"filepath": path,
+		"adapter":  a.Name,
+		"args":     opts.args(a.Name),
+	}
+	var otioJSON string
+	if err := a.call("read_from_file", params, &otioJSON); err != nil {
+		return nil, err
+	}
+	return gotio.FromJSONString(otioJSON)
+}
+
+// Write writes obj to the file at path with the adapter.
+func (a *ExternalAdapter) Write(obj gotio.SerializableObject, path string) error {
+	return a.WriteWithOptions(obj, path, nil)
+}
+
+// WriteWithOptions writes obj to the file at path with the adapter,
+// passing it the options keyed with its name.
+func (a *ExternalAdapter) WriteWithOptions(obj gotio.SerializableObject, path string, opts Options) error {
+	if !a.CanWrite {
+		return fmt.Errorf("%s: format does not support writing", a.Name)
+	}
+	if err := opts.Check(a.Name); err != nil {
+		return err
+	}
+	otioJSON, err := gotio.ToJSONString(obj, "")
+	if err != nil {
+		return fmt.Errorf("failed to serialize OTIO: %w", err)
+	}
+	params := map[string]any{
+		"filepath": path,
+		"data":     otioJSON,
+		"adapter":  a.Name,
+		"args":     opts.args(a.Name),
+	}
+	var success bool
+	return a.call("write_to_file", params, &success)
+}
+
+// call runs the command for one request and decodes its response.
+func (a *ExternalAdapter) call(method string, params map[string]any, result any) error {
+	if params == nil {
+		params = make(map[string]any)
+	}
+	reqBytes, err := json.Marshal(request{ID: 1, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(a.Command[0], a.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(append(reqBytes, '\n'))
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() > 0 {
+			return fmt.Errorf("external adapter %s: %w: %s", a.Name, err, strings.TrimSpace(stderr.String()))
+		}
+		return fmt.Errorf("external adapter %s: %w", a.Name, err)
+	}
+
+	line, err := bufio.NewReader(&stdout).ReadBytes('\n')
+	if err != nil && len(line) == 0 {
+		return fmt.Errorf("external adapter %s: no response", a.Name)
+	}
+	var resp response
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return fmt.Errorf("external adapter %s: failed to parse response: %w (response: %s)", a.Name, err, string(line))
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("external adapter %s: %s", a.Name, *resp.Error)
+	}
+	if result != nil {
+		if err := json.Unmarshal(resp.Result, result); err != nil {
+			return fmt.Errorf("external adapter %s: failed to parse result: %w", a.Name, err)
+		}
+	}
+	return nil
+}
+
+// normalizeSuffix returns suffix lowercased with a leading dot.
+func normalizeSuffix(suffix string) string {
+	s := strings.ToLower(suffix)
+	if !strings.HasPrefix(s, ".") {
+		s = "." + s
+	}
+	return s
+}
+
+// isExecutable reports whether path is a regular file anyone may execute.
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular() && info.Mode()&0111 != 0
+}
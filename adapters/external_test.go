@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package adapters
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+// TestExternalAdapterProcess is not a test: the tests run the test binary
+// as an external adapter serving the "fake" format, whose files hold just
+// a timeline name. Its "prefix" option is prepended to names read.
+func TestExternalAdapterProcess(t *testing.T) {
+	if os.Getenv("GOTIO_EXTERNAL_ADAPTER_PROCESS") != "1" {
+		t.Skip("run as an external adapter by the other tests")
+	}
+	line, _ := bufio.NewReader(os.Stdin).ReadBytes('\n')
+	var req request
+	json.Unmarshal(line, &req)
+
+	var result any
+	var err error
+	switch req.Method {
+	case "discover":
+		result = []map[string]any{
+			{"name": "fake", "suffixes": []string{"FAKE"}, "features": map[string]bool{"read": true, "write": true},
+				"options": []map[string]string{{"name": "prefix", "type": "string", "description": "Prepended to names read"}}},
+		}
+	case "read_from_file":
+		var name []byte
+		name, err = os.ReadFile(req.Params["filepath"].(string))
+		if prefix, ok := req.Params["args"].(map[string]any)["prefix"].(string); ok {
+			name = append([]byte(prefix), name...)
+		}
+		if err == nil {
+			result, err = gotio.ToJSONString(gotio.NewTimeline(string(name), nil, nil), "")
+		}
+	case "write_to_file":
+		var obj gotio.SerializableObject
+		obj, err = gotio.FromJSONString(req.Params["data"].(string))
+		if err == nil {
+			err = os.WriteFile(req.Params["filepath"].(string), []byte(obj.(*gotio.Timeline).Name()), 0644)
+			result = true
+		}
+	default:
+		err = fmt.Errorf("unknown method: %s", req.Method)
+	}
+
+	resp := map[string]any{"id": req.ID, "result": result, "error": nil}
+	if err != nil {
+		resp["error"] = err.Error()
+	}
+	data, _ := json.Marshal(resp)
+	fmt.Println(string(data))
+	os.Exit(0)
+}
+
+func adapterCommand(t *testing.T) []string {
+	t.Setenv("GOTIO_EXTERNAL_ADAPTER_PROCESS", "1")
+	return []string{os.Args[0], "-test.run=^TestExternalAdapterProcess$"}
+}
+
+func TestRegisterExternal(t *testing.T) {
+	adapter, err := RegisterExternal("fake", adapterCommand(t))
+	if err != nil {
+		t.Fatalf("RegisterExternal error: %v", err)
+	}
+	if !adapter.CanRead || !adapter.CanWrite || len(adapter.Suffixes) != 1 || adapter.Suffixes[0] != ".fake" {
+		t.Errorf("adapter = %+v", adapter.FormatInfo)
+	}
+	if found, ok := LookupExternal("FAKE"); !ok || found != adapter {
+		t.Error("LookupExternal did not find the adapter by suffix")
+	}
+	if found, ok := LookupExternal("fake"); !ok || found != adapter {
+		t.Error("LookupExternal did not find the adapter by name")
+	}
+	registered := false
+	for _, info := range gotio.Capabilities().Adapters {
+		registered = registered || info.Name == "fake"
+	}
+	if !registered {
+		t.Error("external adapter missing from Capabilities")
+	}
+
+	path := filepath.Join(t.TempDir(), "cut.fake")
+	if err := adapter.Write(gotio.NewTimeline("cut", nil, nil), path); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	obj, err := adapter.Read(path)
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	if timeline, ok := obj.(*gotio.Timeline); !ok || timeline.Name() != "cut" {
+		t.Errorf("Read = %v, want the timeline cut", obj)
+	}
+
+	_, err = adapter.Read(filepath.Join(t.TempDir(), "missing.fake"))
+	if err == nil {
+		t.Error("expected the adapter's error reading a missing file")
+	}
+
+	if _, err := RegisterExternal("other", adapterCommand(t)); !errors.Is(err, ErrFormatNotFound) {
+		t.Errorf("expected ErrFormatNotFound for a name the command does not serve, got %v", err)
+	}
+}
+
+func TestDiscoverExternal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script")
+	}
+	cmd := adapterCommand(t)
+	dir := t.TempDir()
+	script := fmt.Sprintf("#!/bin/sh\nexec %q %q\n", cmd[0], cmd[1])
+	if err := os.WriteFile(filepath.Join(dir, ExternalPrefix+"fake"), []byte(script), 0755); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ExternalPrefix+"data"), nil, 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+
+	found, err := DiscoverExternal(dir)
+	if err != nil {
+		t.Fatalf("DiscoverExternal error: %v", err)
+	}
+	if len(found) != 1 || found[0].Name != "fake" || found[0].Command[0] != filepath.Join(dir, ExternalPrefix+"fake") {
+		t.Errorf("found = %v, want the fake script only", found)
+	}
+}
@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package adapters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+var (
+	// ErrUnknownOption is returned for an option the adapter does not list.
+	ErrUnknownOption = errors.New("unknown adapter option")
+	// ErrInvalidOption is returned for an option value of the wrong type.
+	ErrInvalidOption = errors.New("invalid adapter option")
+)
+
+// Options are settings for format adapters, keyed "<adapter>.<option>",
+// such as "xmeml.rate" or "shotlist.delimiter". Values are strings, read
+// with the typed getters. One set of options can be passed to every
+// adapter of a conversion, each reading its own. The options an adapter
+// accepts are listed in its gotio.AdapterInfo.
+//
+// Options implement flag.Value, so a command can take them as repeated
+// flags:
+//
+//	var opts adapters.Options
+//	flag.Var(&opts, "a", "adapter option key=value")
+type Options map[string]string
+
+// ParseOptions parses "key=value" arguments.
+func ParseOptions(args []string) (Options, error) {
+	var o Options
+	for _, arg := range args {
+		if err := o.Set(arg); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// Set adds a "key=value" option.
+func (o *Options) Set(arg string) error {
+	key, value, ok := strings.Cut(arg, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("adapter option %q: want key=value", arg)
+	}
+	if *o == nil {
+		*o = make(Options)
+	}
+	(*o)[key] = value
+	return nil
+}
+
+// String returns the options as "key=value" pairs sorted by key.
+func (o Options) String() string {
+	var pairs []string
+	for _, key := range slices.Sorted(maps.Keys(o)) {
+		pairs = append(pairs, key+"="+o[key])
+	}
+	return strings.Join(pairs, " ")
+}
+
+// UnmarshalJSON reads options from a JSON object whose values are
+// strings, numbers or booleans, as in pipeline configs.
+func (o *Options) UnmarshalJSON(data []byte) error {
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*o = make(Options, len(values))
+	for key, value := range values {
+		switch value := value.(type) {
+		case string:
+			(*o)[key] = value
+		case float64:
+			(*o)[key] = strconv.FormatFloat(value, 'g', -1, 64)
+		case bool:
+			(*o)[key] = strconv.FormatBool(value)
+		default:
+			return fmt.Errorf("adapter option %s: %w: want a string, number or boolean", key, ErrInvalidOption)
+		}
+	}
+	return nil
+}
+
+// For returns the options of the named adapter with the adapter prefix
+// removed, so "xmeml.rate" becomes "rate".
+func (o Options) For(adapter string) Options {
+	sub := make(Options)
+	for key, value := range o {
+		if name, ok := strings.CutPrefix(key, adapter+"."); ok {
+			sub[name] = value
+		}
+	}
+	return sub
+}
+
+// Check checks the options of the named adapter against those it lists:
+// each must be known and hold a value of its type. Options of other
+// adapters are ignored, as are those of an adapter listing none.
+func (o Options) Check(adapter string) error {
+	info, ok := gotio.LookupAdapter(adapter)
+	if !ok || len(info.Options) == 0 {
+		return nil
+	}
+	var errs []error
+	own := o.For(adapter)
+	for _, name := range slices.Sorted(maps.Keys(own)) {
+		key := adapter + "." + name
+		i := slices.IndexFunc(info.Options, func(opt gotio.AdapterOption) bool {
+			return opt.Name == name
+		})
+		if i < 0 {
+			errs = append(errs, fmt.Errorf("%s: %w", key, ErrUnknownOption))
+			continue
+		}
+		var err error
+		switch info.Options[i].Type {
+		case "float":
+			_, err = o.Float(key, 0)
+		case "int":
+			_, err = o.Int(key, 0)
+		case "bool":
+			_, err = o.Bool(key, false)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Has reports whether the option key is set.
+func (o Options) Has(key string) bool {
+	_, ok := o[key]
+	return ok
+}
+
+// Text returns the option key, or def if it is not set.
+func (o Options) Text(key, def string) string {
+	if value, ok := o[key]; ok {
+		return value
+	}
+	return def
+}
+
+// Float returns the option key as a number, or def if it is not set.
+func (o Options) Float(key string, def float64) (float64, error) {
+	value, ok := o[key]
+	if !ok {
+		return def, nil
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return def, fmt.Errorf("%s=%q: %w: want a number", key, value, ErrInvalidOption)
+	}
+	return f, nil
+}
+
+// Int returns the option key as an integer, or def if it is not set.
+func (o Options) Int(key string, def int) (int, error) {
+	value, ok := o[key]
+	if !ok {
+		return def, nil
+	}
+	i, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return def, fmt.Errorf("%s=%q: %w: want an integer", key, value, ErrInvalidOption)
+	}
+	return i, nil
+}
+
+// Bool returns the option key as a boolean, or def if it is not set. An
+// empty value is true, so "-a edl.drop_frame=" turns the option on.
+func (o Options) Bool(key string, def bool) (bool, error) {
+	value, ok := o[key]
+	if !ok {
+		return def, nil
+	}
+	if strings.TrimSpace(value) == "" {
+		return true, nil
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return def, fmt.Errorf("%s=%q: %w: want true or false", key, value, ErrInvalidOption)
+	}
+	return b, nil
+}
+
+// DropFrame returns the boolean option key as a drop frame mode:
+// ForceYes or ForceNo if it is set, InferFromRate if not.
+func (o Options) DropFrame(key string) (opentime.IsDropFrameRate, error) {
+	if !o.Has(key) {
+		return opentime.InferFromRate, nil
+	}
+	drop, err := o.Bool(key, false)
+	if err != nil {
+		return opentime.InferFromRate, err
+	}
+	if drop {
+		return opentime.ForceYes, nil
+	}
+	return opentime.ForceNo, nil
+}
+
+// args returns the options of the named adapter as arguments for an
+// external adapter: values reading as JSON numbers or booleans are
+// passed as such, others as strings.
+func (o Options) args(adapter string) map[string]any {
+	args := make(map[string]any)
+	for name, value := range o.For(adapter) {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			args[name] = f
+		} else if value == "true" || value == "false" {
+			args[name] = value == "true"
+		} else {
+			args[name] = value
+		}
+	}
+	return args
+}
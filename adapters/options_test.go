@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package adapters
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func TestOptions(t *testing.T) {
+	var opts Options
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&opts, "a", "adapter option")
+	if err := fs.Parse([]string{"-a", "edl.rate=25", "-a", "edl.drop_frame=", "-a", "fcpxml.version=1.9", "-a", "edl.name=cut"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if opts.String() != "edl.drop_frame= edl.name=cut edl.rate=25 fcpxml.version=1.9" {
+		t.Errorf("String = %q", opts.String())
+	}
+
+	edl := opts.For("edl")
+	if len(edl) != 3 || edl.Text("name", "") != "cut" || edl.Text("missing", "x") != "x" {
+		t.Errorf("For = %v", edl)
+	}
+	if rate, err := edl.Float("rate", 24); rate != 25 || err != nil {
+		t.Errorf("Float = %v, %v", rate, err)
+	}
+	if rate, err := edl.Float("missing", 24); rate != 24 || err != nil {
+		t.Errorf("Float default = %v, %v", rate, err)
+	}
+	if drop, err := edl.DropFrame("drop_frame"); drop != opentime.ForceYes || err != nil {
+		t.Errorf("DropFrame = %v, %v", drop, err)
+	}
+	if _, err := edl.Int("name", 0); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Int of a name: expected ErrInvalidOption, got %v", err)
+	}
+
+	var decoded Options
+	if err := json.Unmarshal([]byte(`{"xmeml.rate": 25, "xmeml.drop_frame": true, "shotlist.delimiter": ";"}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if decoded.String() != "shotlist.delimiter=; xmeml.drop_frame=true xmeml.rate=25" {
+		t.Errorf("decoded = %q", decoded.String())
+	}
+
+	if _, err := ParseOptions([]string{"no-value"}); err == nil {
+		t.Error("expected an error for an argument without =")
+	}
+}
+
+func TestOptionsCheck(t *testing.T) {
+	gotio.RegisterAdapter(gotio.AdapterInfo{
+		Name: "checked",
+		Options: []gotio.AdapterOption{
+			{Name: "rate", Type: "float"},
+			{Name: "tracks", Type: "int"},
+		},
+	})
+	opts, err := ParseOptions([]string{"checked.rate=24", "checked.tracks=2", "other.anything=1"})
+	if err != nil {
+		t.Fatalf("ParseOptions error: %v", err)
+	}
+	if err := opts.Check("checked"); err != nil {
+		t.Errorf("Check error: %v", err)
+	}
+	if err := opts.Check("unlisted"); err != nil {
+		t.Errorf("Check of an adapter listing no options: %v", err)
+	}
+
+	opts["checked.rate"] = "fast"
+	opts["checked.version"] = "2"
+	err = opts.Check("checked")
+	if !errors.Is(err, ErrInvalidOption) || !errors.Is(err, ErrUnknownOption) {
+		t.Errorf("Check = %v, want invalid rate and unknown version", err)
+	}
+}
+
+func TestExternalAdapterOptions(t *testing.T) {
+	adapter, err := RegisterExternal("fake", adapterCommand(t))
+	if err != nil {
+		t.Fatalf("RegisterExternal error: %v", err)
+	}
+	if info, ok := gotio.LookupAdapter("fake"); !ok || len(info.Options) != 1 || info.Options[0].Name != "prefix" {
+		t.Errorf("LookupAdapter = %+v", info)
+	}
+	path := filepath.Join(t.TempDir(), "cut.fake")
+	if err := os.WriteFile(path, []byte("cut"), 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	obj, err := adapter.ReadWithOptions(path, Options{"fake.prefix": "ep01_"})
+	if err != nil {
+		t.Fatalf("ReadWithOptions error: %v", err)
+	}
+	if obj.(*gotio.Timeline).Name() != "ep01_cut" {
+		t.Errorf("name = %q, want ep01_cut", obj.(*gotio.Timeline).Name())
+	}
+	if _, err := adapter.ReadWithOptions(path, Options{"fake.suffix": "x"}); !errors.Is(err, ErrUnknownOption) {
+		t.Errorf("expected ErrUnknownOption, got %v", err)
+	}
+}
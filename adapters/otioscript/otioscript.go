@@ -0,0 +1,313 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package otioscript reads and writes timelines in otioscript, a small line
+// based text format for describing edits by hand, in tests and fixtures or
+// when passing edits between tools without writing Go.
+//
+// Each line is a command followed by its arguments; "#" starts a comment
+// and arguments containing spaces are double quoted:
+//
+//	timeline "Reel 1" rate 24 start 01:00:00:00
+//	track V1 video
+//	clip sh010 media /plates/sh010.mov in 100 dur 48
+//	marker "fix edge" at 110 color red
+//	transition dissolve 12
+//	clip sh020 media /plates/sh020.mov in 01:00:10:00 out 01:00:12:00
+//	gap 24
+//	track A1 audio
+//	clip music media /audio/music.wav in 0 dur 96
+//
+// Times are frame numbers or timecodes at the timeline rate, 24 unless
+// given. A marker belongs to the clip before it, or to the timeline when it
+// comes before the first track. Transition types are "dissolve", "wipe",
+// "dip" and "crossfade", or any other name, used as the transition type;
+// a single duration is centered on the cut, and "in" and "out" give the
+// offsets on each side instead.
+//
+// Basic usage:
+//
+//	timeline, err := otioscript.ReadFile("edit.otioscript")
+//
+//	err := otioscript.Write(os.Stdout, timeline)
+package otioscript
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/adapters"
+)
+
+func init() {
+	gotio.RegisterAdapter(gotio.AdapterInfo{
+		Name:     "otioscript",
+		Package:  "github.com/Avalanche-io/gotio/adapters/otioscript",
+		Suffixes: []string{".otioscript"},
+		CanRead:  true,
+		CanWrite: true,
+		Options: []gotio.AdapterOption{
+			{Name: "rate", Type: "float", Description: "Rate times are read at when the script gives none, and written at"},
+			{Name: "name", Type: "string", Description: "Name of a timeline read from a script without one"},
+		},
+	})
+}
+
+var (
+	// ErrSyntax is returned for lines that cannot be parsed.
+	ErrSyntax = errors.New("otioscript: syntax error")
+	// ErrUnsupported is returned when writing a timeline holding something
+	// otioscript cannot describe, such as a nested stack.
+	ErrUnsupported = errors.New("otioscript: unsupported content")
+)
+
+// transitionNames maps the transition names of the format to types.
+var transitionNames = map[string]gotio.TransitionType{
+	"dissolve":  gotio.TransitionTypeSMPTEDissolve,
+	"wipe":      gotio.TransitionTypeSMPTEWipe,
+	"dip":       gotio.TransitionTypeDipToColor,
+	"crossfade": gotio.TransitionTypeAudioCrossfade,
+}
+
+// Config holds configuration for reading and writing otioscript.
+type Config struct {
+	// Rate is the rate times are read at when the script does not give
+	// one, and written at when it is not zero. When writing, zero uses the
+	// rate of the timeline's global start time, else of its first clip.
+	Rate float64
+	// Name is the name of a timeline read from a script without one.
+	Name string
+}
+
+// Option is a functional option for Read and Write.
+type Option func(*Config)
+
+// WithRate sets the rate times are read or written at.
+func WithRate(rate float64) Option {
+	return func(c *Config) {
+		c.Rate = rate
+	}
+}
+
+// WithName sets the name of a timeline read from a script without one.
+func WithName(name string) Option {
+	return func(c *Config) {
+		c.Name = name
+	}
+}
+
+func newConfig(opts []Option) Config {
+	var cfg Config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// FromOptions returns the functional options set by the adapter options
+// keyed "otioscript.", such as "otioscript.rate=25". Unknown options and
+// values of the wrong type are errors.
+func FromOptions(o adapters.Options) ([]Option, error) {
+	if err := o.Check("otioscript"); err != nil {
+		return nil, err
+	}
+	// Check has validated the value types.
+	o = o.For("otioscript")
+	var opts []Option
+	if o.Has("rate") {
+		rate, _ := o.Float("rate", 0)
+		opts = append(opts, WithRate(rate))
+	}
+	if o.Has("name") {
+		opts = append(opts, WithName(o.Text("name", "")))
+	}
+	return opts, nil
+}
+
+// Write writes the timeline as otioscript. Times are written as frame
+// numbers, except the global start time, which is written as a timecode.
+func Write(w io.Writer, timeline *gotio.Timeline, opts ...Option) error {
+	cfg := newConfig(opts)
+	rate := writeRate(cfg, timeline)
+	sw := &scriptWriter{w: w, rate: rate}
+
+	sw.line("timeline", quote(timeline.Name()), "rate", formatNumber(rate))
+	if start := timeline.GlobalStartTime(); start != nil {
+		tc, err := start.ToNearestTimecode(rate, opentime.InferFromRate)
+		if err != nil {
+			return err
+		}
+		sw.args = append(sw.args, "start", tc)
+	}
+	sw.flush()
+
+	var children []gotio.Composable
+	if stack := timeline.Tracks(); stack != nil {
+		sw.markers(stack.Markers())
+		children = stack.Children()
+	}
+	for _, child := range children {
+		track, ok := child.(*gotio.Track)
+		if !ok {
+			return fmt.Errorf("%w: %s in the timeline", ErrUnsupported, child.SchemaName())
+		}
+		if err := sw.track(track); err != nil {
+			return err
+		}
+	}
+	sw.flush()
+	return sw.err
+}
+
+// WriteFile writes otioscript to path.
+func WriteFile(timeline *gotio.Timeline, path string, opts ...Option) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, timeline, opts...); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeRate returns the rate times are written at.
+func writeRate(cfg Config, timeline *gotio.Timeline) float64 {
+	if cfg.Rate > 0 {
+		return cfg.Rate
+	}
+	if start := timeline.GlobalStartTime(); start != nil && start.Rate() > 0 {
+		return start.Rate()
+	}
+	for _, clip := range timeline.FindClips(nil, false) {
+		if d, err := clip.Duration(); err == nil && d.Rate() > 0 {
+			return d.Rate()
+		}
+	}
+	return 24
+}
+
+// scriptWriter writes otioscript lines, keeping the first error.
+type scriptWriter struct {
+	w    io.Writer
+	rate float64
+	args []string
+	err  error
+}
+
+// line starts a new line with the given words.
+func (sw *scriptWriter) line(words ...string) {
+	sw.flush()
+	sw.args = words
+}
+
+// flush writes the pending line.
+func (sw *scriptWriter) flush() {
+	if len(sw.args) == 0 || sw.err != nil {
+		sw.args = nil
+		return
+	}
+	_, sw.err = fmt.Fprintln(sw.w, strings.Join(sw.args, " "))
+	sw.args = nil
+}
+
+func (sw *scriptWriter) frames(t opentime.RationalTime) string {
+	return formatNumber(math.Round(t.ValueRescaledTo(sw.rate)*1000) / 1000)
+}
+
+func (sw *scriptWriter) track(track *gotio.Track) error {
+	kind := track.Kind()
+	if kind == gotio.TrackKindVideo || kind == gotio.TrackKindAudio {
+		kind = strings.ToLower(kind)
+	}
+	sw.line("track", quote(track.Name()), quote(kind))
+	for _, child := range track.Children() {
+		switch child := child.(type) {
+		case *gotio.Clip:
+			if err := sw.clip(child); err != nil {
+				return err
+			}
+		case *gotio.Gap:
+			d, err := child.Duration()
+			if err != nil {
+				return err
+			}
+			sw.line("gap", sw.frames(d))
+		case *gotio.Transition:
+			sw.transition(child)
+		default:
+			return fmt.Errorf("%w: %s in track %q", ErrUnsupported, child.SchemaName(), track.Name())
+		}
+	}
+	sw.flush()
+	return nil
+}
+
+func (sw *scriptWriter) clip(clip *gotio.Clip) error {
+	sw.line("clip", quote(clip.Name()))
+	if ref, ok := clip.MediaReference().(*gotio.ExternalReference); ok && ref.TargetURL() != "" {
+		sw.args = append(sw.args, "media", quote(ref.TargetURL()))
+	}
+	source, err := clip.TrimmedRange()
+	if err != nil {
+		return fmt.Errorf("clip %q: %w", clip.Name(), err)
+	}
+	sw.args = append(sw.args, "in", sw.frames(source.StartTime()), "dur", sw.frames(source.Duration()))
+	sw.markers(clip.Markers())
+	return nil
+}
+
+func (sw *scriptWriter) markers(markers []*gotio.Marker) {
+	for _, marker := range markers {
+		marked := marker.MarkedRange()
+		sw.line("marker", quote(marker.Name()), "at", sw.frames(marked.StartTime()))
+		if marked.Duration().Value() != 0 {
+			sw.args = append(sw.args, "dur", sw.frames(marked.Duration()))
+		}
+		if marker.Color() != gotio.MarkerColorGreen {
+			sw.args = append(sw.args, "color", quote(strings.ToLower(string(marker.Color()))))
+		}
+		if marker.Comment() != "" {
+			sw.args = append(sw.args, "comment", quote(marker.Comment()))
+		}
+	}
+}
+
+func (sw *scriptWriter) transition(tr *gotio.Transition) {
+	name := string(tr.TransitionType())
+	for n, t := range transitionNames {
+		if t == tr.TransitionType() {
+			name = n
+		}
+	}
+	sw.line("transition", quote(name))
+	in, out := tr.InOffset(), tr.OutOffset()
+	if in.Equal(out) {
+		sw.args = append(sw.args, sw.frames(in.Add(out)))
+	} else {
+		sw.args = append(sw.args, "in", sw.frames(in), "out", sw.frames(out))
+	}
+	if n, ok := tr.Parameters().GetInt(gotio.TransitionParamWipeNumber); ok {
+		sw.args = append(sw.args, "number", strconv.Itoa(n))
+	}
+}
+
+// quote returns s, quoted if it is empty or holds spaces, quotes or "#".
+func quote(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\"#\\") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
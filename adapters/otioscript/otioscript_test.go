@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package otioscript
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+const testScript = `# A two track edit
+timeline "Reel 1" rate 24 start 01:00:00:00
+marker chapter at 0
+
+track V1 video
+clip sh010 media /plates/sh010.mov in 100 dur 48
+marker "fix edge" at 110 color red comment "soft focus"
+transition dissolve 12
+clip sh020 media "/plates/sh 020.mov" in 01:00:10:00 out 01:00:12:00
+gap 24
+transition wipe in 4 out 8 number 3
+clip in dur 24   # a clip named "in"
+
+track A1 audio
+clip music media /audio/music.wav in 0 dur 96
+`
+
+func TestRead(t *testing.T) {
+	timeline, err := Read(strings.NewReader(testScript))
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	if timeline.Name() != "Reel 1" {
+		t.Errorf("Name = %q, want Reel 1", timeline.Name())
+	}
+	if start := timeline.GlobalStartTime(); start == nil || start.Value() != 86400 || start.Rate() != 24 {
+		t.Errorf("GlobalStartTime = %v, want 86400@24", start)
+	}
+	if markers := timeline.Tracks().Markers(); len(markers) != 1 || markers[0].Name() != "chapter" {
+		t.Errorf("expected the chapter marker on the timeline, got %v", markers)
+	}
+
+	tracks := timeline.Tracks().Children()
+	if len(tracks) != 2 {
+		t.Fatalf("expected 2 tracks, got %d", len(tracks))
+	}
+	v1 := tracks[0].(*gotio.Track)
+	children := v1.Children()
+	if len(children) != 6 {
+		t.Fatalf("expected 6 children in V1, got %d", len(children))
+	}
+
+	sh010 := children[0].(*gotio.Clip)
+	if sr := sh010.SourceRange(); sr.StartTime().Value() != 100 || sr.Duration().Value() != 48 {
+		t.Errorf("sh010 source range = %v", sr)
+	}
+	markers := sh010.Markers()
+	if len(markers) != 1 || markers[0].Name() != "fix edge" || markers[0].Color() != gotio.MarkerColorRed || markers[0].Comment() != "soft focus" {
+		t.Errorf("unexpected sh010 markers %v", markers)
+	}
+
+	dissolve := children[1].(*gotio.Transition)
+	if dissolve.TransitionType() != gotio.TransitionTypeSMPTEDissolve || dissolve.InOffset().Value() != 6 || dissolve.OutOffset().Value() != 6 {
+		t.Errorf("unexpected dissolve %v %v %v", dissolve.TransitionType(), dissolve.InOffset(), dissolve.OutOffset())
+	}
+
+	sh020 := children[2].(*gotio.Clip)
+	if ref, ok := sh020.MediaReference().(*gotio.ExternalReference); !ok || ref.TargetURL() != "/plates/sh 020.mov" {
+		t.Errorf("unexpected sh020 media %v", sh020.MediaReference())
+	}
+	if d, _ := sh020.Duration(); d.Value() != 48 {
+		t.Errorf("sh020 duration = %v, want 48 frames", d)
+	}
+	if gap, ok := children[3].(*gotio.Gap); !ok {
+		t.Errorf("expected a gap, got %T", children[3])
+	} else if d, _ := gap.Duration(); d.Value() != 24 {
+		t.Errorf("gap duration = %v, want 24", d)
+	}
+
+	wipe := children[4].(*gotio.Transition)
+	if wipe.TransitionType() != gotio.TransitionTypeSMPTEWipe || wipe.InOffset().Value() != 4 || wipe.OutOffset().Value() != 8 {
+		t.Errorf("unexpected wipe %v %v %v", wipe.TransitionType(), wipe.InOffset(), wipe.OutOffset())
+	}
+	if err := wipe.ValidateParameters(); err != nil {
+		t.Errorf("wipe parameters: %v", err)
+	}
+	if children[5].Name() != "in" {
+		t.Errorf("expected a clip named in, got %q", children[5].Name())
+	}
+
+	if a1 := tracks[1].(*gotio.Track); a1.Kind() != gotio.TrackKindAudio || len(a1.Children()) != 1 {
+		t.Errorf("unexpected A1 %s with %d children", a1.Kind(), len(a1.Children()))
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+	}{
+		{"unknown command", "splice a b"},
+		{"clip before track", "clip a dur 10"},
+		{"missing duration", "track V1\nclip a in 10"},
+		{"out and dur", "track V1\nclip a in 0 out 10 dur 10"},
+		{"unknown key", "track V1\nclip a dur 10 speed 2"},
+		{"bad time", "track V1\nclip a dur ten"},
+		{"unterminated quote", `track "V1`},
+		{"marker after gap", "track V1\ngap 10\nmarker m at 0"},
+		{"bad color", "track V1\nclip a dur 10\nmarker m color teal"},
+		{"timeline after track", "track V1\ntimeline late"},
+		{"transition without duration", "track V1\ntransition dissolve"},
+	}
+	for _, tt := range tests {
+		_, err := Read(strings.NewReader(tt.script))
+		if err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), "line ") {
+			t.Errorf("%s: expected a line number in %q", tt.name, err)
+		}
+	}
+	if _, err := Read(strings.NewReader("splice")); !errors.Is(err, ErrSyntax) {
+		t.Errorf("expected ErrSyntax, got %v", err)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	timeline, err := Read(strings.NewReader(testScript))
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, timeline); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	again, err := Read(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("Read of written script error: %v\n%s", err, buf.String())
+	}
+	if !again.IsEquivalentTo(timeline) {
+		t.Errorf("round trip changed the timeline:\n%s", buf.String())
+	}
+	for _, want := range []string{`timeline "Reel 1" rate 24 start 01:00:00:00`, `media "/plates/sh 020.mov" in 86640 dur 48`, "transition wipe in 4 out 8 number 3"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestWriteUnsupported(t *testing.T) {
+	timeline := gotio.NewTimeline("nested", nil, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	track.AppendChild(gotio.NewStack("nested", nil, nil, nil, nil, nil))
+	timeline.Tracks().AppendChild(track)
+	if err := Write(&bytes.Buffer{}, timeline); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cut.otioscript")
+	timeline, err := Read(strings.NewReader("track V1\nclip a dur 10"))
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	if err := WriteFile(timeline, path); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	read, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile error: %v", err)
+	}
+	if read.Name() != "cut" || len(read.Tracks().Children()) != 1 {
+		t.Errorf("unexpected timeline %q with %d tracks", read.Name(), len(read.Tracks().Children()))
+	}
+}
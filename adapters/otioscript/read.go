@@ -0,0 +1,385 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package otioscript
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// Read builds a timeline from otioscript.
+func Read(r io.Reader, opts ...Option) (*gotio.Timeline, error) {
+	cfg := newConfig(opts)
+	p := &parser{rate: cfg.Rate, timeline: gotio.NewTimeline(cfg.Name, nil, nil)}
+	if p.rate <= 0 {
+		p.rate = 24
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		words, err := split(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if len(words) == 0 {
+			continue
+		}
+		if err := p.command(words); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p.timeline, nil
+}
+
+// ReadFile reads otioscript from path. Unless the script or WithName
+// names the timeline, it is named after the file.
+func ReadFile(path string, opts ...Option) (*gotio.Timeline, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return Read(f, append([]Option{WithName(name)}, opts...)...)
+}
+
+// parser holds the state of Read.
+type parser struct {
+	rate     float64
+	timeline *gotio.Timeline
+	track    *gotio.Track
+	clip     *gotio.Clip
+	// started is set once a track has been read, after which the timeline
+	// line may not appear.
+	started bool
+}
+
+func (p *parser) command(words []string) error {
+	cmd, args := words[0], words[1:]
+	switch cmd {
+	case "timeline":
+		return p.timelineCommand(args)
+	case "track":
+		return p.trackCommand(args)
+	case "clip", "gap", "transition":
+		if p.track == nil {
+			return fmt.Errorf("%w: %s before the first track", ErrSyntax, cmd)
+		}
+	case "marker":
+		return p.markerCommand(args)
+	default:
+		return fmt.Errorf("%w: unknown command %q", ErrSyntax, cmd)
+	}
+
+	switch cmd {
+	case "clip":
+		return p.clipCommand(args)
+	case "gap":
+		return p.gapCommand(args)
+	default:
+		return p.transitionCommand(args)
+	}
+}
+
+func (p *parser) timelineCommand(args []string) error {
+	if p.started {
+		return fmt.Errorf("%w: timeline after the first track", ErrSyntax)
+	}
+	name, kv, err := splitArgs(args, 1)
+	if err != nil {
+		return err
+	}
+	if len(name) > 0 && name[0] != "" {
+		p.timeline.SetName(name[0])
+	}
+	if value, ok := kv["rate"]; ok {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate <= 0 {
+			return fmt.Errorf("%w: invalid rate %q", ErrSyntax, value)
+		}
+		p.rate = rate
+	}
+	if value, ok := kv["start"]; ok {
+		start, err := p.time(value)
+		if err != nil {
+			return err
+		}
+		p.timeline.SetGlobalStartTime(&start)
+	}
+	return checkKeys(kv, "rate", "start")
+}
+
+func (p *parser) trackCommand(args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("%w: track takes a name and an optional kind", ErrSyntax)
+	}
+	kind := gotio.TrackKindVideo
+	if len(args) == 2 {
+		switch strings.ToLower(args[1]) {
+		case "video":
+		case "audio":
+			kind = gotio.TrackKindAudio
+		default:
+			kind = args[1]
+		}
+	}
+	p.track = gotio.NewTrack(args[0], nil, kind, nil, nil)
+	p.clip = nil
+	p.started = true
+	return p.timeline.Tracks().AppendChild(p.track)
+}
+
+func (p *parser) clipCommand(args []string) error {
+	name, kv, err := splitArgs(args, 1)
+	if err != nil {
+		return err
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("%w: clip needs a name", ErrSyntax)
+	}
+	if err := checkKeys(kv, "media", "in", "out", "dur"); err != nil {
+		return err
+	}
+
+	start := opentime.NewRationalTime(0, p.rate)
+	if value, ok := kv["in"]; ok {
+		if start, err = p.time(value); err != nil {
+			return err
+		}
+	}
+	var duration opentime.RationalTime
+	switch {
+	case kv["out"] != "" && kv["dur"] != "":
+		return fmt.Errorf("%w: clip %q has both out and dur", ErrSyntax, name[0])
+	case kv["out"] != "":
+		out, err := p.time(kv["out"])
+		if err != nil {
+			return err
+		}
+		duration = out.Sub(start)
+	case kv["dur"] != "":
+		if duration, err = p.time(kv["dur"]); err != nil {
+			return err
+		}
+	}
+	if duration.Value() <= 0 {
+		return fmt.Errorf("%w: clip %q needs a positive out or dur", ErrSyntax, name[0])
+	}
+
+	var ref gotio.MediaReference
+	if media := kv["media"]; media != "" {
+		ref = gotio.NewExternalReference("", media, nil, nil)
+	}
+	sourceRange := opentime.NewTimeRange(start, duration)
+	p.clip = gotio.NewClip(name[0], ref, &sourceRange, nil, nil, nil, "", nil)
+	return p.track.AppendChild(p.clip)
+}
+
+func (p *parser) gapCommand(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: gap takes a duration", ErrSyntax)
+	}
+	duration, err := p.time(args[0])
+	if err != nil {
+		return err
+	}
+	p.clip = nil
+	return p.track.AppendChild(gotio.NewGapWithDuration(duration))
+}
+
+func (p *parser) transitionCommand(args []string) error {
+	positional, kv, err := splitArgs(args, 2)
+	if err != nil {
+		return err
+	}
+	if err := checkKeys(kv, "in", "out", "number"); err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return fmt.Errorf("%w: transition needs a type", ErrSyntax)
+	}
+	transitionType, ok := transitionNames[positional[0]]
+	if !ok {
+		transitionType = gotio.TransitionType(positional[0])
+	}
+
+	var in, out opentime.RationalTime
+	switch {
+	case len(positional) == 2:
+		if kv["in"] != "" || kv["out"] != "" {
+			return fmt.Errorf("%w: transition has both a duration and offsets", ErrSyntax)
+		}
+		duration, err := p.time(positional[1])
+		if err != nil {
+			return err
+		}
+		in = opentime.NewRationalTime(duration.Value()/2, duration.Rate())
+		out = in
+	case kv["in"] != "" && kv["out"] != "":
+		if in, err = p.time(kv["in"]); err != nil {
+			return err
+		}
+		if out, err = p.time(kv["out"]); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: transition needs a duration, or in and out", ErrSyntax)
+	}
+
+	transition := gotio.NewTransition("", transitionType, in, out, nil)
+	if value, ok := kv["number"]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%w: invalid wipe number %q", ErrSyntax, value)
+		}
+		transition.SetParameter(gotio.TransitionParamWipeNumber, n)
+	}
+	p.clip = nil
+	return p.track.AppendChild(transition)
+}
+
+func (p *parser) markerCommand(args []string) error {
+	name, kv, err := splitArgs(args, 1)
+	if err != nil {
+		return err
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("%w: marker needs a name", ErrSyntax)
+	}
+	if err := checkKeys(kv, "at", "dur", "color", "comment"); err != nil {
+		return err
+	}
+
+	at := opentime.NewRationalTime(0, p.rate)
+	if value, ok := kv["at"]; ok {
+		if at, err = p.time(value); err != nil {
+			return err
+		}
+	}
+	duration := opentime.NewRationalTime(0, p.rate)
+	if value, ok := kv["dur"]; ok {
+		if duration, err = p.time(value); err != nil {
+			return err
+		}
+	}
+	var color gotio.MarkerColor
+	if value, ok := kv["color"]; ok {
+		if color, err = gotio.ParseMarkerColor(value); err != nil {
+			return err
+		}
+	}
+	marker := gotio.NewMarker(name[0], opentime.NewTimeRange(at, duration), color, kv["comment"], nil)
+
+	var item gotio.Item
+	switch {
+	case p.clip != nil:
+		item = p.clip
+	case !p.started:
+		item = p.timeline.Tracks()
+	default:
+		return fmt.Errorf("%w: marker %q does not follow a clip", ErrSyntax, name[0])
+	}
+	item.SetMarkers(append(item.Markers(), marker))
+	return nil
+}
+
+// time parses a frame number or a timecode at the timeline rate.
+func (p *parser) time(value string) (opentime.RationalTime, error) {
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		return opentime.NewRationalTime(n, p.rate), nil
+	}
+	t, err := opentime.FromTimecode(value, p.rate)
+	if err != nil {
+		return opentime.RationalTime{}, fmt.Errorf("%w: invalid time %q: %v", ErrSyntax, value, err)
+	}
+	return t, nil
+}
+
+// splitArgs returns up to n leading positional arguments and the key value
+// pairs that follow them. A first argument that is a key begins the pairs
+// unless that would leave one over, so names such as "in" can be used.
+func splitArgs(args []string, n int) ([]string, map[string]string, error) {
+	var positional []string
+	for len(positional) < n && len(args) > 0 {
+		if isKey(args[0]) && (len(positional) > 0 || len(args)%2 == 0) {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	if len(args)%2 != 0 {
+		return nil, nil, fmt.Errorf("%w: %q has no value", ErrSyntax, args[len(args)-1])
+	}
+	kv := make(map[string]string, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		if _, dup := kv[args[i]]; dup {
+			return nil, nil, fmt.Errorf("%w: %q given twice", ErrSyntax, args[i])
+		}
+		kv[args[i]] = args[i+1]
+	}
+	return positional, kv, nil
+}
+
+// isKey reports whether word is a key of any command.
+func isKey(word string) bool {
+	switch word {
+	case "rate", "start", "media", "in", "out", "dur", "number", "at", "color", "comment":
+		return true
+	}
+	return false
+}
+
+// checkKeys returns an error for a key not in allowed.
+func checkKeys(kv map[string]string, allowed ...string) error {
+	for key := range kv {
+		ok := false
+		for _, a := range allowed {
+			ok = ok || key == a
+		}
+		if !ok {
+			return fmt.Errorf("%w: unknown key %q", ErrSyntax, key)
+		}
+	}
+	return nil
+}
+
+// split splits a line into words, removing a comment and unquoting double
+// quoted words.
+func split(line string) ([]string, error) {
+	var words []string
+	for {
+		line = strings.TrimLeft(line, " \t")
+		if line == "" || line[0] == '#' {
+			return words, nil
+		}
+		if line[0] != '"' {
+			end := strings.IndexAny(line, " \t")
+			if end < 0 {
+				end = len(line)
+			}
+			words = append(words, line[:end])
+			line = line[end:]
+			continue
+		}
+		quoted, err := strconv.QuotedPrefix(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unterminated quote", ErrSyntax)
+		}
+		word, _ := strconv.Unquote(quoted)
+		words = append(words, word)
+		line = line[len(quoted):]
+	}
+}
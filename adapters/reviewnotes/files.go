@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package reviewnotes
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownFormat is returned for file extensions other than .json and
+// .csv.
+var ErrUnknownFormat = errors.New("reviewnotes: unknown format")
+
+// Column names of the CSV format.
+const (
+	ColumnTimecode = "Timecode"
+	ColumnOut      = "Out"
+	ColumnComment  = "Comment"
+	ColumnAuthor   = "Author"
+	ColumnColor    = "Color"
+	ColumnID       = "ID"
+	ColumnClip     = "Clip"
+)
+
+// Columns is the column order written by WriteCSV.
+var Columns = []string{ColumnTimecode, ColumnOut, ColumnComment, ColumnAuthor, ColumnColor, ColumnID, ColumnClip}
+
+// fieldNames maps the field names review tools use to Note fields, in
+// lower case.
+var fieldNames = map[string]string{
+	"timecode": ColumnTimecode, "tc": ColumnTimecode, "timestamp": ColumnTimecode, "in": ColumnTimecode,
+	"out": ColumnOut, "end": ColumnOut,
+	"comment": ColumnComment, "text": ColumnComment, "body": ColumnComment, "note": ColumnComment,
+	"author": ColumnAuthor, "owner": ColumnAuthor, "user": ColumnAuthor,
+	"color": ColumnColor, "annotation_color": ColumnColor,
+	"id":   ColumnID,
+	"clip": ColumnClip,
+}
+
+// ReadJSON reads notes from JSON: an array of notes, or an object holding
+// one under "comments" or "notes". Fields are matched by name, accepting
+// common alternatives such as "text" for the comment and "owner" for the
+// author; unknown fields are ignored.
+func ReadJSON(r io.Reader) ([]Note, error) {
+	var doc any
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	if obj, ok := doc.(map[string]any); ok {
+		doc = obj["comments"]
+		if doc == nil {
+			doc = obj["notes"]
+		}
+	}
+	items, ok := doc.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected a list of notes", ErrInvalidNote)
+	}
+	notes := make([]Note, 0, len(items))
+	for i, item := range items {
+		fields, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: note %d is not an object", ErrInvalidNote, i+1)
+		}
+		values := make(map[string]string)
+		for key, value := range fields {
+			column, ok := fieldNames[strings.ToLower(key)]
+			if !ok {
+				continue
+			}
+			switch v := value.(type) {
+			case string:
+				values[column] = v
+			case float64:
+				values[column] = strconv.FormatFloat(v, 'f', -1, 64)
+			}
+		}
+		notes = append(notes, noteFromValues(values))
+	}
+	return notes, nil
+}
+
+// ReadCSV reads notes from CSV with a header row. Columns are matched by
+// name as for ReadJSON, so exports with other columns can be read as they
+// are.
+func ReadCSV(r io.Reader) ([]Note, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]string, len(header))
+	for i, name := range header {
+		columns[i] = fieldNames[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))]
+	}
+	var notes []Note
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return notes, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[string]string)
+		for i, value := range record {
+			if i < len(columns) && columns[i] != "" {
+				values[columns[i]] = value
+			}
+		}
+		if values[ColumnTimecode] == "" && values[ColumnComment] == "" {
+			continue
+		}
+		notes = append(notes, noteFromValues(values))
+	}
+}
+
+func noteFromValues(values map[string]string) Note {
+	return Note{
+		ID:       values[ColumnID],
+		Timecode: values[ColumnTimecode],
+		Out:      values[ColumnOut],
+		Comment:  values[ColumnComment],
+		Author:   values[ColumnAuthor],
+		Color:    values[ColumnColor],
+		Clip:     values[ColumnClip],
+	}
+}
+
+// WriteJSON writes notes as an indented JSON array.
+func WriteJSON(w io.Writer, notes []Note) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if notes == nil {
+		notes = []Note{}
+	}
+	return enc.Encode(notes)
+}
+
+// WriteCSV writes notes as CSV in the order of Columns.
+func WriteCSV(w io.Writer, notes []Note) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(Columns); err != nil {
+		return err
+	}
+	for _, note := range notes {
+		if err := cw.Write([]string{note.Timecode, note.Out, note.Comment, note.Author, note.Color, note.ID, note.Clip}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadFile reads a .json or .csv notes file.
+func ReadFile(path string) ([]Note, error) {
+	var read func(io.Reader) ([]Note, error)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		read = ReadJSON
+	case ".csv":
+		read = ReadCSV
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, filepath.Ext(path))
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return read(f)
+}
+
+// WriteFile writes notes as .json or .csv, chosen by the extension of
+// path.
+func WriteFile(notes []Note, path string) error {
+	var write func(io.Writer, []Note) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		write = WriteJSON
+	case ".csv":
+		write = WriteCSV
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, filepath.Ext(path))
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f, notes); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
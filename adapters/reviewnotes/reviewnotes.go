@@ -0,0 +1,297 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package reviewnotes brings notes exported from review tools, such as
+// frame.io comment exports or spreadsheets, into a timeline as markers,
+// and exports a timeline's markers back as notes.
+//
+// A note is placed at its record timecode on the topmost visible video
+// clip there, at the matching time of the clip's media, or on the
+// timeline's stack where no clip is visible. Its author and identifier are
+// kept in the marker's metadata under the "review" key, and its color,
+// a marker color name or "#rrggbb", becomes the marker's color.
+//
+// Notes are read from JSON, an array of notes or an object holding one
+// under "comments" or "notes", or from CSV with a header row:
+//
+//	Timecode,Out,Comment,Author,Color,ID
+//	01:00:04:12,,Sky is too blue,dana,blue,c-101
+//
+// Basic usage:
+//
+//	notes, err := reviewnotes.ReadFile("round3.json")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	markers, err := reviewnotes.Apply(timeline, notes)
+package reviewnotes
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func init() {
+	gotio.RegisterFeature("reviewnotes")
+}
+
+// MetadataKey is the marker metadata key holding a note's author and
+// identifier.
+const MetadataKey = "review"
+
+// ErrInvalidNote is returned for notes whose timecode cannot be parsed.
+var ErrInvalidNote = errors.New("reviewnotes: invalid note")
+
+// Note is one review comment.
+type Note struct {
+	ID string `json:"id,omitempty"`
+	// Timecode is the record timecode of the note.
+	Timecode string `json:"timecode"`
+	// Out is the exclusive record out timecode of a note on a range, and
+	// empty for a note on one frame.
+	Out     string `json:"out,omitempty"`
+	Comment string `json:"comment"`
+	Author  string `json:"author,omitempty"`
+	// Color is a marker color name or "#rrggbb".
+	Color string `json:"color,omitempty"`
+	// Clip is the name of the clip an exported note was on.
+	Clip string `json:"clip,omitempty"`
+}
+
+// Config holds configuration for placing and exporting notes.
+type Config struct {
+	// Rate is the timecode rate. Zero uses the rate of the timeline's
+	// global start time, else 24.
+	Rate float64
+	// DropFrame selects drop frame timecode when exporting.
+	DropFrame opentime.IsDropFrameRate
+	// TrackKind selects the tracks notes are placed on, video by default.
+	TrackKind string
+}
+
+// Option is a functional option for Apply and Notes.
+type Option func(*Config)
+
+// WithRate sets the timecode rate.
+func WithRate(rate float64) Option {
+	return func(c *Config) {
+		c.Rate = rate
+	}
+}
+
+// WithDropFrame sets the drop frame mode of exported timecodes.
+func WithDropFrame(dropFrame opentime.IsDropFrameRate) Option {
+	return func(c *Config) {
+		c.DropFrame = dropFrame
+	}
+}
+
+// WithTrackKind selects the tracks notes are placed on.
+func WithTrackKind(kind string) Option {
+	return func(c *Config) {
+		c.TrackKind = kind
+	}
+}
+
+func newConfig(timeline *gotio.Timeline, opts []Option) Config {
+	cfg := Config{
+		DropFrame: opentime.InferFromRate,
+		TrackKind: gotio.TrackKindVideo,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.Rate <= 0 {
+		cfg.Rate = 24
+		if start := timeline.GlobalStartTime(); start != nil && start.Rate() > 0 {
+			cfg.Rate = start.Rate()
+		}
+	}
+	return cfg
+}
+
+// Apply adds a marker for each note to the timeline and returns the
+// markers in the order of the notes. Notes are checked before any marker
+// is added, so on error the timeline is unchanged.
+func Apply(timeline *gotio.Timeline, notes []Note, opts ...Option) ([]*gotio.Marker, error) {
+	cfg := newConfig(timeline, opts)
+	resolved, err := timeline.ResolvedClips(gotio.WithTrackKinds(cfg.TrackKind))
+	if err != nil {
+		return nil, err
+	}
+	var globalStart opentime.RationalTime
+	if start := timeline.GlobalStartTime(); start != nil {
+		globalStart = *start
+	}
+
+	type placement struct {
+		item   gotio.Item
+		marker *gotio.Marker
+	}
+	placements := make([]placement, 0, len(notes))
+	for i, note := range notes {
+		in, duration, err := noteRange(note, cfg.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("note %d: %w", i+1, err)
+		}
+		var item gotio.Item = timeline.Tracks()
+		start := in.Sub(globalStart)
+		// Resolved clips are sorted by start; the last match is topmost.
+		top := -1
+		for j, rc := range resolved {
+			if rc.GlobalRange.Contains(in) && (top < 0 || rc.TrackIndex >= resolved[top].TrackIndex) {
+				top = j
+			}
+		}
+		if top >= 0 {
+			rc := resolved[top]
+			item = rc.Clip
+			start = rc.MediaRange.StartTime().Add(in.Sub(rc.GlobalRange.StartTime()).RescaledTo(rc.MediaRange.StartTime().Rate()))
+			duration = duration.RescaledTo(start.Rate())
+		}
+		marker, err := noteMarker(note, opentime.NewTimeRange(start, duration))
+		if err != nil {
+			return nil, fmt.Errorf("note %d: %w", i+1, err)
+		}
+		placements = append(placements, placement{item: item, marker: marker})
+	}
+
+	markers := make([]*gotio.Marker, len(placements))
+	for i, p := range placements {
+		p.item.SetMarkers(append(p.item.Markers(), p.marker))
+		markers[i] = p.marker
+	}
+	return markers, nil
+}
+
+// noteRange returns the record time of a note and its duration.
+func noteRange(note Note, rate float64) (opentime.RationalTime, opentime.RationalTime, error) {
+	in, err := opentime.FromTimecode(strings.TrimSpace(note.Timecode), rate)
+	if err != nil {
+		return in, in, fmt.Errorf("%w: timecode %q", ErrInvalidNote, note.Timecode)
+	}
+	duration := opentime.NewRationalTime(0, rate)
+	if note.Out != "" {
+		out, err := opentime.FromTimecode(strings.TrimSpace(note.Out), rate)
+		if err != nil || out.Cmp(in) < 0 {
+			return in, in, fmt.Errorf("%w: out %q", ErrInvalidNote, note.Out)
+		}
+		duration = out.Sub(in)
+	}
+	return in, duration, nil
+}
+
+func noteMarker(note Note, markedRange opentime.TimeRange) (*gotio.Marker, error) {
+	review := gotio.AnyDictionary{}
+	if note.ID != "" {
+		review["id"] = note.ID
+	}
+	if note.Author != "" {
+		review["author"] = note.Author
+	}
+	var metadata gotio.AnyDictionary
+	if len(review) > 0 {
+		metadata = gotio.AnyDictionary{MetadataKey: review}
+	}
+	name := note.Author
+	if name == "" {
+		name = "Note"
+	}
+	marker := gotio.NewMarker(name, markedRange, gotio.MarkerColorRed, note.Comment, metadata)
+	if note.Color != "" {
+		color, err := gotio.ParseColor(note.Color)
+		if err != nil {
+			return nil, fmt.Errorf("%w: color %q", ErrInvalidNote, note.Color)
+		}
+		marker.SetCustomColor(color)
+	}
+	return marker, nil
+}
+
+// Notes returns the markers of the timeline's stack and of the clips on
+// its tracks of the configured kind as notes, in record order.
+func Notes(timeline *gotio.Timeline, opts ...Option) ([]Note, error) {
+	cfg := newConfig(timeline, opts)
+	var globalStart opentime.RationalTime
+	if start := timeline.GlobalStartTime(); start != nil {
+		globalStart = *start
+	}
+
+	type timedNote struct {
+		at   opentime.RationalTime
+		note Note
+	}
+	var timed []timedNote
+	add := func(marker *gotio.Marker, record opentime.RationalTime, clip string) error {
+		if globalStart.Rate() > 0 {
+			record = record.Add(globalStart)
+		}
+		note, err := markerNote(cfg, marker, record)
+		if err != nil {
+			return err
+		}
+		note.Clip = clip
+		timed = append(timed, timedNote{at: record, note: note})
+		return nil
+	}
+
+	for _, marker := range timeline.Tracks().Markers() {
+		if err := add(marker, marker.MarkedRange().StartTime(), ""); err != nil {
+			return nil, err
+		}
+	}
+	for _, clip := range timeline.FindClips(nil, false, gotio.WithTrackKinds(cfg.TrackKind)) {
+		for _, marker := range clip.Markers() {
+			record, err := clip.TransformedTime(marker.MarkedRange().StartTime(), timeline.Tracks())
+			if err != nil {
+				return nil, err
+			}
+			if err := add(marker, record, clip.Name()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	slices.SortStableFunc(timed, func(a, b timedNote) int {
+		return cmp.Compare(a.at.ToSeconds(), b.at.ToSeconds())
+	})
+	notes := make([]Note, len(timed))
+	for i, t := range timed {
+		notes[i] = t.note
+	}
+	return notes, nil
+}
+
+func markerNote(cfg Config, marker *gotio.Marker, record opentime.RationalTime) (Note, error) {
+	in, err := record.ToTimecode(cfg.Rate, cfg.DropFrame)
+	if err != nil {
+		return Note{}, err
+	}
+	note := Note{Timecode: in, Comment: marker.Comment()}
+	if duration := marker.MarkedRange().Duration(); duration.Value() > 0 {
+		if note.Out, err = record.Add(duration).ToTimecode(cfg.Rate, cfg.DropFrame); err != nil {
+			return Note{}, err
+		}
+	}
+	// Markers made from notes are named after the author, or "Note".
+	if marker.Name() != "Note" {
+		note.Author = marker.Name()
+	}
+	if review, ok := marker.Metadata().GetDictionary(MetadataKey); ok {
+		note.ID, _ = review.GetString("id")
+		if author, ok := review.GetString("author"); ok {
+			note.Author = author
+		}
+	}
+	if _, ok := marker.Metadata().GetString(gotio.CustomColorKey); ok {
+		note.Color = strings.ToLower(marker.CustomColor().Hex())
+	} else {
+		note.Color = strings.ToLower(string(marker.Color()))
+	}
+	return note, nil
+}
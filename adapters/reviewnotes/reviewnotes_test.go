@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package reviewnotes
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func frames(start, duration float64) *opentime.TimeRange {
+	r := opentime.NewTimeRange(opentime.NewRationalTime(start, 24), opentime.NewRationalTime(duration, 24))
+	return &r
+}
+
+// reviewTimeline starts at 01:00:00:00 with sh010 and sh020 on V1 and
+// sh020_comp over the second half of sh020 on V2.
+func reviewTimeline() *gotio.Timeline {
+	timeline := gotio.NewTimeline("review", nil, nil)
+	start := opentime.NewRationalTime(86400, 24)
+	timeline.SetGlobalStartTime(&start)
+	v1 := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	v1.AppendChild(gotio.NewClip("sh010", nil, frames(1000, 48), nil, nil, nil, "", nil))
+	v1.AppendChild(gotio.NewClip("sh020", nil, frames(2000, 48), nil, nil, nil, "", nil))
+	v2 := gotio.NewTrack("V2", nil, gotio.TrackKindVideo, nil, nil)
+	v2.AppendChild(gotio.NewGap("", frames(0, 72), nil, nil, nil, nil))
+	v2.AppendChild(gotio.NewClip("sh020_comp", nil, frames(0, 24), nil, nil, nil, "", nil))
+	timeline.Tracks().AppendChild(v1)
+	timeline.Tracks().AppendChild(v2)
+	return timeline
+}
+
+func TestApplyAndExport(t *testing.T) {
+	csvNotes := "Timecode,Out,Comment,Author,Color,ID,Status\n" +
+		"01:00:00:12,01:00:01:00,Sky is too blue,dana,blue,c-1,open\n" +
+		"01:00:03:06,,Edge matte,sam,#ff8800,c-2,open\n" +
+		"01:00:05:00,,Hold longer,,,c-3,done\n"
+	notes, err := ReadCSV(strings.NewReader(csvNotes))
+	if err != nil || len(notes) != 3 || notes[0].Author != "dana" || notes[1].Out != "" {
+		t.Fatalf("ReadCSV = %+v, %v", notes, err)
+	}
+
+	timeline := reviewTimeline()
+	markers, err := Apply(timeline, notes)
+	if err != nil {
+		t.Fatalf("Apply error: %v", err)
+	}
+	clips := timeline.FindClips(nil, false)
+	sh010, comp := clips[0], clips[2]
+	if len(sh010.Markers()) != 1 || sh010.Markers()[0] != markers[0] {
+		t.Fatalf("sh010 markers = %v", sh010.Markers())
+	}
+	if r := markers[0].MarkedRange(); r.StartTime().Value() != 1012 || r.Duration().Value() != 12 || markers[0].Color() != gotio.MarkerColorBlue {
+		t.Errorf("first marker = %v %s", r, markers[0].Color())
+	}
+	if len(comp.Markers()) != 1 || comp.Markers()[0].MarkedRange().StartTime().Value() != 6 {
+		t.Errorf("the note at 3:06 should be on the topmost clip at frame 6: %v", comp.Markers())
+	}
+	if len(timeline.Tracks().Markers()) != 1 {
+		t.Errorf("the note past the end should be on the stack: %v", timeline.Tracks().Markers())
+	}
+
+	exported, err := Notes(timeline)
+	if err != nil {
+		t.Fatalf("Notes error: %v", err)
+	}
+	for i, note := range exported {
+		want := notes[i]
+		if note.Timecode != want.Timecode || note.Out != want.Out || note.Comment != want.Comment || note.Author != want.Author || note.ID != want.ID {
+			t.Errorf("exported note %d = %+v, want %+v", i, note, want)
+		}
+	}
+	if exported[1].Color != "#ff8800" || exported[1].Clip != "sh020_comp" || exported[0].Color != "blue" {
+		t.Errorf("exported colors and clips = %+v", exported)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, exported); err != nil {
+		t.Fatalf("WriteJSON error: %v", err)
+	}
+	back, err := ReadJSON(&buf)
+	if err != nil || len(back) != 3 || back[2] != exported[2] {
+		t.Errorf("JSON round trip = %+v, %v", back, err)
+	}
+}
+
+func TestReadJSONAliases(t *testing.T) {
+	doc := `{"comments": [{"tc": "01:00:00:01", "text": "Too dark", "owner": "kim", "annotation_color": "red", "extra": 1}]}`
+	notes, err := ReadJSON(strings.NewReader(doc))
+	if err != nil || len(notes) != 1 || notes[0] != (Note{Timecode: "01:00:00:01", Comment: "Too dark", Author: "kim", Color: "red"}) {
+		t.Fatalf("ReadJSON = %+v, %v", notes, err)
+	}
+
+	timeline := reviewTimeline()
+	if _, err := Apply(timeline, []Note{{Timecode: "01:00:00:01"}, {Timecode: "later"}}); err == nil {
+		t.Fatal("expected an error for a bad timecode")
+	}
+	if len(timeline.FindClips(nil, false)[0].Markers()) != 0 {
+		t.Error("a failed Apply added markers")
+	}
+}
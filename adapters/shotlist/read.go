@@ -0,0 +1,264 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package shotlist
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// Read builds a single-track timeline from a shot list.
+//
+// Name and Source In are required, along with Source Out or Duration.
+// Timecodes may also be given as plain frame numbers. When Record In is
+// present, shots are placed in record order with gaps between them, and a
+// row without a record in follows the row above it. The earliest record
+// in, wherever its row, becomes the timeline's global start time.
+func Read(r io.Reader, opts ...Option) (*gotio.Timeline, error) {
+	cfg := newConfig(opts)
+	if cfg.Rate <= 0 {
+		cfg.Rate = 24
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma = cfg.Delimiter
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{ColumnName, ColumnSourceIn} {
+		if _, ok := columns[strings.ToLower(required)]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingColumn, required)
+		}
+	}
+	_, hasOut := columns[strings.ToLower(ColumnSourceOut)]
+	_, hasDuration := columns[strings.ToLower(ColumnDuration)]
+	if !hasOut && !hasDuration {
+		return nil, fmt.Errorf("%w: %s or %s", ErrMissingColumn, ColumnSourceOut, ColumnDuration)
+	}
+
+	timeline := gotio.NewTimeline(cfg.Name, nil, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	timeline.Tracks().AppendChild(track)
+
+	// Rows without a record in follow the row above them, so the rows are
+	// read into runs, each starting at a record in, before any is placed
+	var runs []shotRun
+	var globalStart *opentime.RationalTime
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		row := shotRow{cfg: cfg, columns: columns, record: record}
+		if row.empty() {
+			continue
+		}
+
+		clip, recordIn, err := row.clip()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if recordIn != nil || len(runs) == 0 {
+			runs = append(runs, shotRun{recordIn: recordIn, line: line})
+			if recordIn != nil && (globalStart == nil || recordIn.Cmp(*globalStart) < 0) {
+				globalStart = recordIn
+			}
+		}
+		runs[len(runs)-1].clips = append(runs[len(runs)-1].clips, clip)
+	}
+
+	if globalStart != nil {
+		timeline.SetGlobalStartTime(globalStart)
+		slices.SortStableFunc(runs, func(a, b shotRun) int {
+			return a.start(*globalStart).Cmp(b.start(*globalStart))
+		})
+	}
+
+	position := opentime.NewRationalTime(0, cfg.Rate)
+	for _, run := range runs {
+		if run.recordIn != nil {
+			offset := run.recordIn.Sub(*globalStart).Sub(position)
+			if offset.Value() < 0 {
+				return nil, fmt.Errorf("line %d: %w", run.line, ErrOverlap)
+			}
+			if offset.Value() > 0 {
+				gapRange := opentime.NewTimeRange(opentime.NewRationalTime(0, cfg.Rate), offset)
+				track.AppendChild(gotio.NewGap("", &gapRange, nil, nil, nil, nil))
+				position = position.Add(offset)
+			}
+		}
+		for _, clip := range run.clips {
+			track.AppendChild(clip)
+			duration, _ := clip.Duration()
+			position = position.Add(duration)
+		}
+	}
+
+	return timeline, nil
+}
+
+// shotRun is a row with a record in, or the first row, and the rows
+// without a record in below it.
+type shotRun struct {
+	recordIn *opentime.RationalTime
+	line     int
+	clips    []*gotio.Clip
+}
+
+// start returns the record in of the run, or globalStart if it has none.
+func (r shotRun) start(globalStart opentime.RationalTime) opentime.RationalTime {
+	if r.recordIn == nil {
+		return globalStart
+	}
+	return *r.recordIn
+}
+
+// ReadFile reads a shot list from path. Unless WithName is given, the
+// timeline is named after the file.
+func ReadFile(path string, opts ...Option) (*gotio.Timeline, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return Read(f, append([]Option{WithName(name)}, opts...)...)
+}
+
+// shotRow gives access to the fields of one shot list row.
+type shotRow struct {
+	cfg     Config
+	columns map[string]int
+	record  []string
+}
+
+// field returns the trimmed value of column, or "" if it is absent.
+func (r shotRow) field(column string) string {
+	i, ok := r.columns[strings.ToLower(column)]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+	return strings.TrimSpace(r.record[i])
+}
+
+func (r shotRow) empty() bool {
+	for _, value := range r.record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// time parses a timecode or frame number column.
+func (r shotRow) time(column string) (*opentime.RationalTime, error) {
+	value := r.field(column)
+	if value == "" {
+		return nil, nil
+	}
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		t := opentime.NewRationalTime(n, r.cfg.Rate)
+		return &t, nil
+	}
+	t, err := opentime.FromTimecode(value, r.cfg.Rate)
+	if err != nil {
+		return nil, fmt.Errorf("%s %q: %w", column, value, err)
+	}
+	return &t, nil
+}
+
+// clip builds the clip for the row and returns its record in, if any.
+func (r shotRow) clip() (*gotio.Clip, *opentime.RationalTime, error) {
+	sourceIn, err := r.time(ColumnSourceIn)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sourceIn == nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrMissingColumn, ColumnSourceIn)
+	}
+
+	var duration opentime.RationalTime
+	if sourceOut, err := r.time(ColumnSourceOut); err != nil {
+		return nil, nil, err
+	} else if sourceOut != nil {
+		duration = sourceOut.Sub(*sourceIn)
+	} else if d, err := r.time(ColumnDuration); err != nil {
+		return nil, nil, err
+	} else if d != nil {
+		duration = *d
+	}
+	if duration.Value() <= 0 {
+		return nil, nil, fmt.Errorf("shot %q has no duration", r.field(ColumnName))
+	}
+
+	recordIn, err := r.time(ColumnRecordIn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ref gotio.MediaReference
+	if media := r.field(ColumnMedia); media != "" {
+		ref = gotio.NewExternalReference("", media, nil, nil)
+	}
+
+	sourceRange := opentime.NewTimeRange(*sourceIn, duration)
+	clip := gotio.NewClip(r.field(ColumnName), ref, &sourceRange, nil, nil, nil, "", nil)
+
+	markers, err := r.markers()
+	if err != nil {
+		return nil, nil, err
+	}
+	clip.SetMarkers(markers)
+
+	return clip, recordIn, nil
+}
+
+// markers parses the "name@timecode; ..." markers column.
+func (r shotRow) markers() ([]*gotio.Marker, error) {
+	value := r.field(ColumnMarkers)
+	if value == "" {
+		return nil, nil
+	}
+	var markers []*gotio.Marker
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name := entry
+		start := opentime.NewRationalTime(0, r.cfg.Rate)
+		if i := strings.LastIndex(entry, "@"); i >= 0 {
+			name = entry[:i]
+			t, err := opentime.FromTimecode(strings.TrimSpace(entry[i+1:]), r.cfg.Rate)
+			if err != nil {
+				return nil, fmt.Errorf("marker %q: %w", entry, err)
+			}
+			start = t
+		}
+		markedRange := opentime.NewTimeRange(start, opentime.NewRationalTime(0, r.cfg.Rate))
+		markers = append(markers, gotio.NewMarker(strings.TrimSpace(name), markedRange, "", "", nil))
+	}
+	return markers, nil
+}
@@ -0,0 +1,314 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package shotlist reads and writes timelines as CSV shot lists.
+//
+// Each clip becomes one row with its source and record timecodes,
+// duration, media path and markers:
+//
+//	Track,Name,Source In,Source Out,Record In,Record Out,Duration,Media,Markers
+//	V1,sh010,01:00:00:00,01:00:02:00,00:00:00:00,00:00:02:00,48,/plates/sh010.mov,note@01:00:01:00
+//
+// Reading builds a single-track timeline. Columns are matched by header
+// name, so spreadsheets may reorder them or add columns of their own.
+//
+// Basic usage:
+//
+//	if err := shotlist.WriteFile(timeline, "edit.csv"); err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	timeline, err := shotlist.ReadFile("edit.csv", shotlist.WithRate(24))
+package shotlist
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/adapters"
+)
+
+func init() {
+	gotio.RegisterAdapter(gotio.AdapterInfo{
+		Name:     "shotlist",
+		Package:  "github.com/Avalanche-io/gotio/adapters/shotlist",
+		Suffixes: []string{".csv"},
+		CanRead:  true,
+		CanWrite: true,
+		Options: []gotio.AdapterOption{
+			{Name: "rate", Type: "float", Description: "Timecode rate, 24 when reading by default"},
+			{Name: "drop_frame", Type: "bool", Description: "Drop frame timecode"},
+			{Name: "delimiter", Type: "string", Description: `Field separator, one character or "tab"`},
+			{Name: "track_kind", Type: "string", Description: "Kind of the tracks written, Video by default"},
+			{Name: "name", Type: "string", Description: "Name of a timeline read from a shot list"},
+		},
+	})
+}
+
+// Column names used in the header row.
+const (
+	ColumnTrack     = "Track"
+	ColumnName      = "Name"
+	ColumnSourceIn  = "Source In"
+	ColumnSourceOut = "Source Out"
+	ColumnRecordIn  = "Record In"
+	ColumnRecordOut = "Record Out"
+	ColumnDuration  = "Duration"
+	ColumnMedia     = "Media"
+	ColumnMarkers   = "Markers"
+)
+
+// Columns is the column order written by Write.
+var Columns = []string{
+	ColumnTrack, ColumnName,
+	ColumnSourceIn, ColumnSourceOut,
+	ColumnRecordIn, ColumnRecordOut,
+	ColumnDuration, ColumnMedia, ColumnMarkers,
+}
+
+var (
+	// ErrMissingColumn is returned when a required column is absent.
+	ErrMissingColumn = errors.New("shotlist: missing required column")
+	// ErrOverlap is returned when a row's record in precedes the previous row's record out.
+	ErrOverlap = errors.New("shotlist: shot overlaps the previous shot")
+)
+
+// Config holds configuration for reading and writing shot lists.
+type Config struct {
+	// Rate is the timecode rate. When writing, zero uses each time's own
+	// rate. When reading, zero defaults to 24.
+	Rate float64
+	// DropFrame selects drop frame timecode.
+	DropFrame opentime.IsDropFrameRate
+	// Delimiter is the field separator, ',' by default.
+	Delimiter rune
+	// TrackKind selects the tracks written, video by default.
+	TrackKind string
+	// Name is the name of a timeline read from a shot list.
+	Name string
+}
+
+// Option is a functional option for Read and Write.
+type Option func(*Config)
+
+// WithRate sets the timecode rate.
+func WithRate(rate float64) Option {
+	return func(c *Config) {
+		c.Rate = rate
+	}
+}
+
+// WithDropFrame sets the drop frame mode for timecodes.
+func WithDropFrame(dropFrame opentime.IsDropFrameRate) Option {
+	return func(c *Config) {
+		c.DropFrame = dropFrame
+	}
+}
+
+// WithDelimiter sets the field separator, e.g. '\t' for pasting into spreadsheets.
+func WithDelimiter(delimiter rune) Option {
+	return func(c *Config) {
+		c.Delimiter = delimiter
+	}
+}
+
+// WithTrackKind selects which tracks are written.
+func WithTrackKind(kind string) Option {
+	return func(c *Config) {
+		c.TrackKind = kind
+	}
+}
+
+// WithName sets the name of a timeline read from a shot list.
+func WithName(name string) Option {
+	return func(c *Config) {
+		c.Name = name
+	}
+}
+
+func newConfig(opts []Option) Config {
+	cfg := Config{
+		DropFrame: opentime.InferFromRate,
+		Delimiter: ',',
+		TrackKind: gotio.TrackKindVideo,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// FromOptions returns the functional options set by the adapter options
+// keyed "shotlist.", such as "shotlist.rate=25". Unknown options and
+// values of the wrong type are errors.
+func FromOptions(o adapters.Options) ([]Option, error) {
+	if err := o.Check("shotlist"); err != nil {
+		return nil, err
+	}
+	// Check has validated the value types.
+	o = o.For("shotlist")
+	var opts []Option
+	if o.Has("rate") {
+		rate, _ := o.Float("rate", 0)
+		opts = append(opts, WithRate(rate))
+	}
+	if o.Has("drop_frame") {
+		dropFrame, _ := o.DropFrame("drop_frame")
+		opts = append(opts, WithDropFrame(dropFrame))
+	}
+	if o.Has("delimiter") {
+		delimiter := []rune(o.Text("delimiter", ""))
+		if string(delimiter) == "tab" {
+			delimiter = []rune{'\t'}
+		}
+		if len(delimiter) != 1 {
+			return nil, fmt.Errorf("shotlist.delimiter=%q: %w: want one character", o.Text("delimiter", ""), adapters.ErrInvalidOption)
+		}
+		opts = append(opts, WithDelimiter(delimiter[0]))
+	}
+	if o.Has("track_kind") {
+		opts = append(opts, WithTrackKind(o.Text("track_kind", "")))
+	}
+	if o.Has("name") {
+		opts = append(opts, WithName(o.Text("name", "")))
+	}
+	return opts, nil
+}
+
+// Write writes one row per clip in the timeline's tracks of the configured kind.
+func Write(w io.Writer, timeline *gotio.Timeline, opts ...Option) error {
+	cfg := newConfig(opts)
+
+	cw := csv.NewWriter(w)
+	cw.Comma = cfg.Delimiter
+	if err := cw.Write(Columns); err != nil {
+		return err
+	}
+
+	var globalStart opentime.RationalTime
+	if start := timeline.GlobalStartTime(); start != nil {
+		globalStart = *start
+	}
+
+	for _, track := range timeline.Tracks().Children() {
+		track, ok := track.(*gotio.Track)
+		if !ok || track.Kind() != cfg.TrackKind {
+			continue
+		}
+		for i, child := range track.Children() {
+			clip, ok := child.(*gotio.Clip)
+			if !ok {
+				continue
+			}
+			row, err := clipRow(cfg, track, i, clip, globalStart)
+			if err != nil {
+				return fmt.Errorf("clip %q: %w", clip.Name(), err)
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteFile writes a shot list to path.
+func WriteFile(timeline *gotio.Timeline, path string, opts ...Option) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, timeline, opts...); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// clipRow builds the row for the clip at index i of track.
+func clipRow(cfg Config, track *gotio.Track, i int, clip *gotio.Clip, globalStart opentime.RationalTime) ([]string, error) {
+	source, err := clip.TrimmedRange()
+	if err != nil {
+		return nil, err
+	}
+	record, err := track.RangeOfChildAtIndex(i)
+	if err != nil {
+		return nil, err
+	}
+	recordIn := record.StartTime()
+	if globalStart.Rate() > 0 {
+		recordIn = recordIn.Add(globalStart)
+	}
+	recordOut := recordIn.Add(record.Duration())
+
+	row := map[string]string{
+		ColumnTrack:    track.Name(),
+		ColumnName:     clip.Name(),
+		ColumnDuration: strconv.Itoa(frames(cfg, source.Duration())),
+		ColumnMedia:    mediaPath(clip.MediaReference()),
+	}
+	times := map[string]opentime.RationalTime{
+		ColumnSourceIn:  source.StartTime(),
+		ColumnSourceOut: source.EndTimeExclusive(),
+		ColumnRecordIn:  recordIn,
+		ColumnRecordOut: recordOut,
+	}
+	for column, t := range times {
+		if row[column], err = formatTimecode(cfg, t); err != nil {
+			return nil, err
+		}
+	}
+
+	var markers []string
+	for _, marker := range clip.Markers() {
+		tc, err := formatTimecode(cfg, marker.MarkedRange().StartTime())
+		if err != nil {
+			return nil, err
+		}
+		markers = append(markers, marker.Name()+"@"+tc)
+	}
+	row[ColumnMarkers] = strings.Join(markers, "; ")
+
+	result := make([]string, len(Columns))
+	for i, column := range Columns {
+		result[i] = row[column]
+	}
+	return result, nil
+}
+
+// mediaPath returns the location of a media reference, if it has one.
+func mediaPath(ref gotio.MediaReference) string {
+	switch r := ref.(type) {
+	case *gotio.ExternalReference:
+		return r.TargetURL()
+	case *gotio.ImageSequenceReference:
+		return r.AbstractTargetURL("#")
+	}
+	return ""
+}
+
+// timecodeRate returns the rate used to write t.
+func timecodeRate(cfg Config, t opentime.RationalTime) float64 {
+	if cfg.Rate > 0 {
+		return cfg.Rate
+	}
+	return t.Rate()
+}
+
+func formatTimecode(cfg Config, t opentime.RationalTime) (string, error) {
+	return t.ToNearestTimecode(timecodeRate(cfg, t), cfg.DropFrame)
+}
+
+func frames(cfg Config, t opentime.RationalTime) int {
+	return int(math.Round(t.ValueRescaledTo(timecodeRate(cfg, t))))
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package shotlist
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func buildTimeline() *gotio.Timeline {
+	start := opentime.NewRationalTime(86400, 24)
+	timeline := gotio.NewTimeline("edit", &start, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	timeline.Tracks().AppendChild(track)
+
+	sr1 := opentime.NewTimeRange(opentime.NewRationalTime(86400, 24), opentime.NewRationalTime(48, 24))
+	clip1 := gotio.NewClip("sh010", gotio.NewExternalReference("", "/plates/sh010.mov", nil, nil), &sr1, nil, nil, nil, "", nil)
+	clip1.SetMarkers([]*gotio.Marker{
+		gotio.NewMarker("focus", opentime.NewTimeRange(opentime.NewRationalTime(86424, 24), opentime.RationalTime{}), "", "", nil),
+	})
+	track.AppendChild(clip1)
+
+	gapRange := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(24, 24))
+	track.AppendChild(gotio.NewGap("", &gapRange, nil, nil, nil, nil))
+
+	sr2 := opentime.NewTimeRange(opentime.NewRationalTime(100, 24), opentime.NewRationalTime(12, 24))
+	track.AppendChild(gotio.NewClip("sh020", nil, &sr2, nil, nil, nil, "", nil))
+
+	audio := gotio.NewTrack("A1", nil, gotio.TrackKindAudio, nil, nil)
+	audio.AppendChild(gotio.NewClip("dialog", nil, &sr1, nil, nil, nil, "", nil))
+	timeline.Tracks().AppendChild(audio)
+	return timeline
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, buildTimeline()); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{
+		"Track,Name,Source In,Source Out,Record In,Record Out,Duration,Media,Markers",
+		"V1,sh010,01:00:00:00,01:00:02:00,01:00:00:00,01:00:02:00,48,/plates/sh010.mov,focus@01:00:01:00",
+		"V1,sh020,00:00:04:04,00:00:04:16,01:00:03:00,01:00:03:12,12,,",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %d:\n%s", len(want), len(lines), buf.String())
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d:\n got %s\nwant %s", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reel1.csv")
+	if err := WriteFile(buildTimeline(), path, WithDelimiter('\t')); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+
+	timeline, err := ReadFile(path, WithDelimiter('\t'))
+	if err != nil {
+		t.Fatalf("ReadFile error: %v", err)
+	}
+	if timeline.Name() != "reel1" {
+		t.Errorf("Name = %q, want reel1", timeline.Name())
+	}
+	if start := timeline.GlobalStartTime(); start == nil || start.Value() != 86400 {
+		t.Errorf("GlobalStartTime = %v, want 86400", start)
+	}
+
+	children := timeline.VideoTracks()[0].Children()
+	if len(children) != 3 {
+		t.Fatalf("expected clip, gap, clip; got %d children", len(children))
+	}
+	if _, ok := children[1].(*gotio.Gap); !ok {
+		t.Errorf("expected gap at index 1, got %T", children[1])
+	}
+	clip := children[0].(*gotio.Clip)
+	if ref, ok := clip.MediaReference().(*gotio.ExternalReference); !ok || ref.TargetURL() != "/plates/sh010.mov" {
+		t.Errorf("unexpected media reference %v", clip.MediaReference())
+	}
+	if len(clip.Markers()) != 1 || clip.Markers()[0].Name() != "focus" ||
+		clip.Markers()[0].MarkedRange().StartTime().Value() != 86424 {
+		t.Errorf("unexpected markers %v", clip.Markers())
+	}
+	duration, _ := timeline.Duration()
+	if duration.Value() != 84 {
+		t.Errorf("Duration = %v, want 84 frames", duration.Value())
+	}
+}
+
+func TestReadUnsortedRecordIn(t *testing.T) {
+	// sh030 has the earliest record in though it is listed last, and sh020
+	// has none, so it follows sh010
+	csv := "Name,Source In,Duration,Record In\n" +
+		"sh010,0,24,01:00:02:00\n" +
+		"sh020,100,12,\n" +
+		"sh030,200,24,01:00:00:00\n"
+	timeline, err := Read(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	if start := timeline.GlobalStartTime(); start == nil || start.Value() != 86400 {
+		t.Errorf("GlobalStartTime = %v, want 01:00:00:00", start)
+	}
+	var got []string
+	for _, child := range timeline.VideoTracks()[0].Children() {
+		d, _ := child.(gotio.Item).Duration()
+		got = append(got, fmt.Sprintf("%s:%g", child.Name(), d.Value()))
+	}
+	if want := "sh030:24 :24 sh010:24 sh020:12"; strings.Join(got, " ") != want {
+		t.Errorf("children = %v, want %s", got, want)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	cases := map[string]struct {
+		csv  string
+		want error
+	}{
+		"missing column": {"Name,Duration\nsh010,24\n", ErrMissingColumn},
+		"overlap": {
+			"Name,Source In,Duration,Record In\na,0,24,0\nb,0,24,12\n",
+			ErrOverlap,
+		},
+	}
+	for name, tc := range cases {
+		if _, err := Read(strings.NewReader(tc.csv)); !errors.Is(err, tc.want) {
+			t.Errorf("%s: error = %v, want %v", name, err, tc.want)
+		}
+	}
+
+	if _, err := Read(strings.NewReader("Name,Source In,Source Out\nsh010,bad,24\n")); err == nil {
+		t.Error("expected error for invalid timecode")
+	}
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package subtitles
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+// ReadSRT reads SubRip (.srt) cues into a subtitle track.
+func ReadSRT(r io.Reader, opts ...Option) (*gotio.Track, error) {
+	blocks, err := splitBlocks(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var cues []Cue
+	for _, block := range blocks {
+		// The numeric counter is optional in practice.
+		id := ""
+		if !strings.Contains(block[0], "-->") {
+			id, block = block[0], block[1:]
+		}
+		if len(block) == 0 {
+			return nil, fmt.Errorf("%w: cue %q has no timing", ErrInvalidCue, id)
+		}
+		start, end, _, err := parseTiming(block[0])
+		if err != nil {
+			return nil, err
+		}
+		cues = append(cues, Cue{
+			ID:    id,
+			Start: start,
+			End:   end,
+			Text:  strings.Join(block[1:], "\n"),
+		})
+	}
+	return NewTrack(cues, opts...)
+}
+
+// WriteSRT writes a subtitle track as SubRip. Cues are renumbered from 1.
+func WriteSRT(w io.Writer, track *gotio.Track) error {
+	cues, err := Cues(track)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	for i, cue := range cues {
+		if i > 0 {
+			fmt.Fprint(bw, "\n")
+		}
+		fmt.Fprintf(bw, "%d\n%s --> %s\n%s\n",
+			i+1, formatCueTime(cue.Start, ","), formatCueTime(cue.End, ","), cue.Text)
+	}
+	return bw.Flush()
+}
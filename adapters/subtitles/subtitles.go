@@ -0,0 +1,325 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package subtitles reads and writes SRT and WebVTT caption files.
+//
+// Each cue becomes a Clip on a subtitle track, separated by gaps. The cue
+// text, identifier and WebVTT settings are stored in the clip's metadata
+// under the "subtitle" key. Cue times are snapped to frames at the
+// configured rate, so captions conform with picture.
+//
+// Basic usage:
+//
+//	track, err := subtitles.ReadFile("reel1.srt", subtitles.WithRate(24))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	timeline.Tracks().AppendChild(track)
+//
+//	err = subtitles.WriteFile(track, "reel1.vtt")
+package subtitles
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/adapters"
+)
+
+func init() {
+	gotio.RegisterAdapter(gotio.AdapterInfo{
+		Name:     "subtitles",
+		Package:  "github.com/Avalanche-io/gotio/adapters/subtitles",
+		Suffixes: []string{".srt", ".vtt"},
+		CanRead:  true,
+		CanWrite: true,
+		Options: []gotio.AdapterOption{
+			{Name: "rate", Type: "float", Description: "Frame rate cue times are snapped to, 24 by default"},
+			{Name: "name", Type: "string", Description: "Name of a track read from a file"},
+		},
+	})
+}
+
+// TrackKind is the kind of tracks holding subtitles.
+const TrackKind = gotio.TrackKindSubtitle
+
+// MetadataKey is the clip metadata key holding cue fields.
+const MetadataKey = "subtitle"
+
+var (
+	// ErrInvalidCue is returned for cues that cannot be parsed.
+	ErrInvalidCue = errors.New("subtitles: invalid cue")
+	// ErrOverlappingCues is returned when a cue starts before the previous one ends.
+	ErrOverlappingCues = errors.New("subtitles: cues overlap")
+	// ErrUnknownFormat is returned for file extensions other than .srt and .vtt.
+	ErrUnknownFormat = errors.New("subtitles: unknown format")
+)
+
+// Cue is one timed caption.
+type Cue struct {
+	ID       string
+	Start    opentime.RationalTime
+	End      opentime.RationalTime
+	Text     string
+	Settings string // WebVTT cue settings, e.g. "align:start line:0"
+}
+
+// Config holds configuration for reading and writing subtitles.
+type Config struct {
+	// Rate is the frame rate cue times are snapped to, 24 by default.
+	Rate float64
+	// Name is the name of a track read from a file.
+	Name string
+}
+
+// Option is a functional option for reading and writing subtitles.
+type Option func(*Config)
+
+// WithRate sets the frame rate cue times are snapped to.
+func WithRate(rate float64) Option {
+	return func(c *Config) {
+		c.Rate = rate
+	}
+}
+
+// WithName sets the name of a track read from a file.
+func WithName(name string) Option {
+	return func(c *Config) {
+		c.Name = name
+	}
+}
+
+func newConfig(opts []Option) Config {
+	cfg := Config{Rate: 24}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.Rate <= 0 {
+		cfg.Rate = 24
+	}
+	return cfg
+}
+
+// FromOptions returns the functional options set by the adapter options
+// keyed "subtitles.", such as "subtitles.rate=25". Unknown options and
+// values of the wrong type are errors.
+func FromOptions(o adapters.Options) ([]Option, error) {
+	if err := o.Check("subtitles"); err != nil {
+		return nil, err
+	}
+	// Check has validated the value types.
+	o = o.For("subtitles")
+	var opts []Option
+	if o.Has("rate") {
+		rate, _ := o.Float("rate", 0)
+		opts = append(opts, WithRate(rate))
+	}
+	if o.Has("name") {
+		opts = append(opts, WithName(o.Text("name", "")))
+	}
+	return opts, nil
+}
+
+// NewTrack builds a subtitle track from cues, which must be in order and
+// must not overlap.
+func NewTrack(cues []Cue, opts ...Option) (*gotio.Track, error) {
+	cfg := newConfig(opts)
+	track := gotio.NewTrack(cfg.Name, nil, TrackKind, nil, nil)
+
+	position := opentime.NewRationalTime(0, cfg.Rate)
+	for i, cue := range cues {
+		start := snap(cue.Start, cfg.Rate)
+		end := snap(cue.End, cfg.Rate)
+		if end.Value() <= start.Value() {
+			end = opentime.NewRationalTime(start.Value()+1, cfg.Rate)
+		}
+		if start.Value() < position.Value() {
+			return nil, fmt.Errorf("cue %d: %w", i+1, ErrOverlappingCues)
+		}
+		if start.Value() > position.Value() {
+			gapRange := opentime.NewTimeRange(opentime.NewRationalTime(0, cfg.Rate), start.Sub(position))
+			track.AppendChild(gotio.NewGap("", &gapRange, nil, nil, nil, nil))
+		}
+
+		values := gotio.AnyDictionary{"text": cue.Text}
+		if cue.ID != "" {
+			values["id"] = cue.ID
+		}
+		if cue.Settings != "" {
+			values["settings"] = cue.Settings
+		}
+		sourceRange := opentime.NewTimeRange(start, end.Sub(start))
+		name := cue.ID
+		if name == "" {
+			name = strconv.Itoa(i + 1)
+		}
+		track.AppendChild(gotio.NewClip(name, nil, &sourceRange, gotio.AnyDictionary{MetadataKey: values}, nil, nil, "", nil))
+		position = end
+	}
+	return track, nil
+}
+
+// Cues returns the cues of a subtitle track, timed by each clip's
+// position in the track. Clips without subtitle metadata are skipped.
+func Cues(track *gotio.Track) ([]Cue, error) {
+	var cues []Cue
+	for i, child := range track.Children() {
+		clip, ok := child.(*gotio.Clip)
+		if !ok || !clip.Enabled() {
+			continue
+		}
+		values, ok := clip.Metadata().GetDictionary(MetadataKey)
+		if !ok {
+			continue
+		}
+		record, err := track.RangeOfChildAtIndex(i)
+		if err != nil {
+			return nil, err
+		}
+		cue := Cue{
+			Start: record.StartTime(),
+			End:   record.EndTimeExclusive(),
+		}
+		cue.Text, _ = values.GetString("text")
+		cue.ID, _ = values.GetString("id")
+		cue.Settings, _ = values.GetString("settings")
+		cues = append(cues, cue)
+	}
+	return cues, nil
+}
+
+// ReadFile reads an .srt or .vtt file into a subtitle track. Unless
+// WithName is given, the track is named after the file.
+func ReadFile(path string, opts ...Option) (*gotio.Track, error) {
+	read, err := readerFor(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return read(f, append([]Option{WithName(name)}, opts...)...)
+}
+
+// WriteFile writes a subtitle track as .srt or .vtt, chosen by the extension of path.
+func WriteFile(track *gotio.Track, path string) error {
+	var write func(io.Writer, *gotio.Track) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".srt":
+		write = WriteSRT
+	case ".vtt":
+		write = WriteVTT
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, filepath.Ext(path))
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f, track); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func readerFor(path string) (func(io.Reader, ...Option) (*gotio.Track, error), error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".srt":
+		return ReadSRT, nil
+	case ".vtt":
+		return ReadVTT, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, filepath.Ext(path))
+}
+
+// snap rounds t to the nearest frame at rate.
+func snap(t opentime.RationalTime, rate float64) opentime.RationalTime {
+	return opentime.NewRationalTime(math.Round(t.ValueRescaledTo(rate)), rate)
+}
+
+// parseCueTime parses "HH:MM:SS,mmm", "HH:MM:SS.mmm" or "MM:SS.mmm".
+func parseCueTime(s string) (opentime.RationalTime, error) {
+	s = strings.TrimSpace(strings.Replace(s, ",", ".", 1))
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return opentime.RationalTime{}, fmt.Errorf("%w: time %q", ErrInvalidCue, s)
+	}
+	var seconds float64
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil || v < 0 || (i < len(parts)-1 && strings.Contains(part, ".")) {
+			return opentime.RationalTime{}, fmt.Errorf("%w: time %q", ErrInvalidCue, s)
+		}
+		seconds = seconds*60 + v
+	}
+	return opentime.NewRationalTime(seconds, 1), nil
+}
+
+// formatCueTime formats t as HH:MM:SS followed by sep and milliseconds.
+func formatCueTime(t opentime.RationalTime, sep string) string {
+	ms := int64(math.Round(t.ToSeconds() * 1000))
+	if ms < 0 {
+		ms = 0
+	}
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+// parseTiming parses a "start --> end [settings]" line.
+func parseTiming(line string) (start, end opentime.RationalTime, settings string, err error) {
+	before, after, found := strings.Cut(line, "-->")
+	if !found {
+		return start, end, "", fmt.Errorf("%w: %q", ErrInvalidCue, line)
+	}
+	if start, err = parseCueTime(before); err != nil {
+		return start, end, "", err
+	}
+	fields := strings.Fields(after)
+	if len(fields) == 0 {
+		return start, end, "", fmt.Errorf("%w: %q", ErrInvalidCue, line)
+	}
+	if end, err = parseCueTime(fields[0]); err != nil {
+		return start, end, "", err
+	}
+	return start, end, strings.Join(fields[1:], " "), nil
+}
+
+// splitBlocks splits text into blank-line separated blocks of lines.
+func splitBlocks(r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var blocks [][]string
+	var block []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if block != nil {
+				blocks = append(blocks, block)
+				block = nil
+			}
+			continue
+		}
+		block = append(block, strings.TrimRight(line, " \t\r"))
+	}
+	if block != nil {
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
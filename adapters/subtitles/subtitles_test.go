@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package subtitles
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+const sampleSRT = `1
+00:00:01,000 --> 00:00:02,500
+Hello there.
+
+2
+00:00:03,020 --> 00:00:05,000
+Two lines
+of text.
+`
+
+func TestReadSRT(t *testing.T) {
+	track, err := ReadSRT(strings.NewReader(sampleSRT), WithRate(24))
+	if err != nil {
+		t.Fatalf("ReadSRT error: %v", err)
+	}
+	if track.Kind() != TrackKind {
+		t.Errorf("Kind = %s, want %s", track.Kind(), TrackKind)
+	}
+
+	// gap, cue, gap, cue
+	children := track.Children()
+	if len(children) != 4 {
+		t.Fatalf("expected 4 children, got %d", len(children))
+	}
+	if _, ok := children[0].(*gotio.Gap); !ok {
+		t.Errorf("expected leading gap, got %T", children[0])
+	}
+
+	cues, err := Cues(track)
+	if err != nil {
+		t.Fatalf("Cues error: %v", err)
+	}
+	// 2.5s and 3.02s snap to frames 60 and 72 at 24fps.
+	if cues[0].Start.Value() != 24 || cues[0].End.Value() != 60 {
+		t.Errorf("cue 1 = %v-%v, want frames 24-60", cues[0].Start, cues[0].End)
+	}
+	if cues[1].Start.Value() != 72 || cues[1].Text != "Two lines\nof text." {
+		t.Errorf("cue 2 = %v %q", cues[1].Start, cues[1].Text)
+	}
+}
+
+func TestVTTRoundTrip(t *testing.T) {
+	input := "WEBVTT\n\nNOTE a comment\n\nintro\n00:01.000 --> 00:02.000 align:start\nHi\n\n00:00:04.000 --> 00:00:05.000\nBye\n"
+	track, err := ReadVTT(strings.NewReader(input), WithRate(25))
+	if err != nil {
+		t.Fatalf("ReadVTT error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteVTT(&buf, track); err != nil {
+		t.Fatalf("WriteVTT error: %v", err)
+	}
+	want := "WEBVTT\n\nintro\n00:00:01.000 --> 00:00:02.000 align:start\nHi\n\n00:00:04.000 --> 00:00:05.000\nBye\n"
+	if buf.String() != want {
+		t.Errorf("WriteVTT:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestFileConversion(t *testing.T) {
+	dir := t.TempDir()
+	vtt := filepath.Join(dir, "reel1.vtt")
+	track, err := ReadSRT(strings.NewReader(sampleSRT))
+	if err != nil {
+		t.Fatalf("ReadSRT error: %v", err)
+	}
+	if err := WriteFile(track, vtt); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+
+	read, err := ReadFile(vtt)
+	if err != nil {
+		t.Fatalf("ReadFile error: %v", err)
+	}
+	if read.Name() != "reel1" {
+		t.Errorf("Name = %q, want reel1", read.Name())
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSRT(&buf, read); err != nil {
+		t.Fatalf("WriteSRT error: %v", err)
+	}
+	want := "1\n00:00:01,000 --> 00:00:02,500\nHello there.\n\n2\n00:00:03,000 --> 00:00:05,000\nTwo lines\nof text.\n"
+	if buf.String() != want {
+		t.Errorf("WriteSRT:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	if err := WriteFile(track, filepath.Join(dir, "reel1.txt")); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	overlap := "1\n00:00:01,000 --> 00:00:03,000\nA\n\n2\n00:00:02,000 --> 00:00:04,000\nB\n"
+	if _, err := ReadSRT(strings.NewReader(overlap)); !errors.Is(err, ErrOverlappingCues) {
+		t.Errorf("expected ErrOverlappingCues, got %v", err)
+	}
+	if _, err := ReadSRT(strings.NewReader("1\n00:00:xx,000 --> 00:00:02,000\nA\n")); !errors.Is(err, ErrInvalidCue) {
+		t.Errorf("expected ErrInvalidCue, got %v", err)
+	}
+	if _, err := ReadVTT(strings.NewReader("00:01.000 --> 00:02.000\nA\n")); !errors.Is(err, ErrInvalidCue) {
+		t.Errorf("expected missing header error, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package subtitles
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+// ReadVTT reads WebVTT (.vtt) cues into a subtitle track. NOTE, STYLE
+// and REGION blocks are skipped.
+func ReadVTT(r io.Reader, opts ...Option) (*gotio.Track, error) {
+	blocks, err := splitBlocks(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 || !strings.HasPrefix(blocks[0][0], "WEBVTT") {
+		return nil, fmt.Errorf("%w: missing WEBVTT header", ErrInvalidCue)
+	}
+
+	var cues []Cue
+	for _, block := range blocks[1:] {
+		switch first := block[0]; {
+		case strings.HasPrefix(first, "NOTE"), first == "STYLE", first == "REGION":
+			continue
+		}
+		id := ""
+		if !strings.Contains(block[0], "-->") {
+			id, block = block[0], block[1:]
+		}
+		if len(block) == 0 {
+			return nil, fmt.Errorf("%w: cue %q has no timing", ErrInvalidCue, id)
+		}
+		start, end, settings, err := parseTiming(block[0])
+		if err != nil {
+			return nil, err
+		}
+		cues = append(cues, Cue{
+			ID:       id,
+			Start:    start,
+			End:      end,
+			Text:     strings.Join(block[1:], "\n"),
+			Settings: settings,
+		})
+	}
+	return NewTrack(cues, opts...)
+}
+
+// WriteVTT writes a subtitle track as WebVTT, keeping cue identifiers
+// and settings.
+func WriteVTT(w io.Writer, track *gotio.Track) error {
+	cues, err := Cues(track)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, "WEBVTT\n")
+	for _, cue := range cues {
+		fmt.Fprint(bw, "\n")
+		if cue.ID != "" {
+			fmt.Fprintf(bw, "%s\n", cue.ID)
+		}
+		timing := formatCueTime(cue.Start, ".") + " --> " + formatCueTime(cue.End, ".")
+		if cue.Settings != "" {
+			timing += " " + cue.Settings
+		}
+		fmt.Fprintf(bw, "%s\n%s\n", timing, cue.Text)
+	}
+	return bw.Flush()
+}
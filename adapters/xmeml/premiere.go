@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package xmeml
+
+import (
+	"strconv"
+
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/interchange"
+)
+
+func init() {
+	interchange.Register("xmeml", PremiereProfile)
+}
+
+// PremiereProfile keeps the fields Premiere Pro adds to xmeml: clip and
+// sequence labels, logging info and comments, and marker colors. A
+// clip's label also sets its color, and a marker's pproColor its custom
+// color. A clip with a color but no stored label is written with the
+// nearest Premiere label.
+var PremiereProfile = &interchange.Profile{
+	Name:   "premiere",
+	Fields: []string{"labels/", "logginginfo/", "comments/", "pproColor"},
+	Import: func(obj gotio.SerializableObjectWithMetadata, fields interchange.Fields) {
+		switch obj := obj.(type) {
+		case *gotio.Clip:
+			if c, ok := premiereLabels[fields[labelField]]; ok {
+				obj.SetColor(gotio.NewColor(c.R, c.G, c.B, c.A))
+			}
+		case *gotio.Marker:
+			if v, err := strconv.ParseUint(fields[markerColorField], 10, 32); err == nil {
+				obj.SetCustomColor(gotio.NewColorRGB(
+					float64(v>>16&0xff)/255,
+					float64(v>>8&0xff)/255,
+					float64(v&0xff)/255,
+				))
+			}
+		}
+	},
+	Export: func(obj gotio.SerializableObjectWithMetadata, fields interchange.Fields) {
+		clip, ok := obj.(*gotio.Clip)
+		if !ok || clip.Color() == nil || fields[labelField] != "" {
+			return
+		}
+		fields[labelField] = nearestLabel(clip.Color())
+	},
+}
+
+const (
+	labelField       = "labels/label2"
+	markerColorField = "pproColor"
+)
+
+// premiereLabels are Premiere Pro's default label colors, approximately.
+var premiereLabels = map[string]*gotio.Color{
+	"Violet":    hexColor(0xA990DD),
+	"Iris":      hexColor(0x6A8BDB),
+	"Caribbean": hexColor(0x1DD1A1),
+	"Lavender":  hexColor(0xE384E3),
+	"Cerulean":  hexColor(0x1AA6F9),
+	"Forest":    hexColor(0x8EA03F),
+	"Rose":      hexColor(0xF76FA4),
+	"Mango":     hexColor(0xEEA13C),
+	"Purple":    hexColor(0x9349CE),
+	"Blue":      hexColor(0x5C5CF2),
+	"Teal":      hexColor(0x41A8A8),
+	"Magenta":   hexColor(0xE03FA7),
+	"Tan":       hexColor(0xD3B18C),
+	"Green":     hexColor(0x4A9B3E),
+	"Brown":     hexColor(0x8E6134),
+	"Yellow":    hexColor(0xE2E253),
+}
+
+func hexColor(rgb uint32) *gotio.Color {
+	return gotio.NewColorRGB(
+		float64(rgb>>16&0xff)/255,
+		float64(rgb>>8&0xff)/255,
+		float64(rgb&0xff)/255,
+	)
+}
+
+// nearestLabel returns the Premiere label whose color is closest to c.
+func nearestLabel(c *gotio.Color) string {
+	nearest, best := "", 0.0
+	for name, l := range premiereLabels {
+		d := (c.R-l.R)*(c.R-l.R) + (c.G-l.G)*(c.G-l.G) + (c.B-l.B)*(c.B-l.B)
+		if nearest == "" || d < best || (d == best && name < nearest) {
+			nearest, best = name, d
+		}
+	}
+	return nearest
+}
@@ -0,0 +1,352 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package xmeml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/interchange"
+)
+
+// Read parses an xmeml document into a timeline. The first sequence in
+// the document is read, whether at the top level or inside a project or
+// bin.
+func Read(r io.Reader, opts ...Option) (*gotio.Timeline, error) {
+	cfg := newConfig(opts)
+
+	var doc document
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidXMEML, err)
+	}
+	seq := firstSequence(doc.Sequences, append(doc.Projects, doc.Bins...))
+	if seq == nil {
+		return nil, fmt.Errorf("%w: no sequence", ErrInvalidXMEML)
+	}
+
+	rd := &reader{cfg: cfg, files: make(map[string]*file)}
+	rd.collectFiles(seq)
+	return rd.sequence(seq)
+}
+
+// ReadFile reads an xmeml file.
+func ReadFile(path string, opts ...Option) (*gotio.Timeline, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f, opts...)
+}
+
+func firstSequence(sequences []sequence, folders []folder) *sequence {
+	if len(sequences) > 0 {
+		return &sequences[0]
+	}
+	for i := range folders {
+		children := &folders[i].Children
+		if seq := firstSequence(children.Sequences, children.Bins); seq != nil {
+			return seq
+		}
+	}
+	return nil
+}
+
+type reader struct {
+	cfg Config
+	// files maps file ids to their full definitions. Later uses of a
+	// file only carry its id.
+	files map[string]*file
+}
+
+func (rd *reader) collectFiles(seq *sequence) {
+	for _, list := range []*trackList{seq.Media.Video, seq.Media.Audio} {
+		if list == nil {
+			continue
+		}
+		for _, t := range list.Tracks {
+			for _, item := range t.Items {
+				f := item.File
+				if f == nil || f.ID == "" || (f.Name == "" && f.PathURL == "") {
+					continue
+				}
+				if _, ok := rd.files[f.ID]; !ok {
+					rd.files[f.ID] = f
+				}
+			}
+		}
+	}
+}
+
+func (rd *reader) sequence(seq *sequence) (*gotio.Timeline, error) {
+	seqRate := rd.cfg.Rate
+	if seqRate <= 0 {
+		seqRate = frameRate(&seq.Rate, 24)
+	}
+
+	var start *opentime.RationalTime
+	if tc := seq.Timecode; tc != nil {
+		t := opentime.NewRationalTime(float64(tc.Frame), frameRate(&tc.Rate, seqRate))
+		start = &t
+	}
+	timeline := gotio.NewTimeline(seq.Name, start, nil)
+	interchange.Stash(rd.cfg.Profiles, timeline, fields(seq.Extra))
+
+	kinds := []struct {
+		list *trackList
+		kind string
+	}{
+		{seq.Media.Video, gotio.TrackKindVideo},
+		{seq.Media.Audio, gotio.TrackKindAudio},
+	}
+	for _, k := range kinds {
+		if k.list == nil {
+			continue
+		}
+		for i, t := range k.list.Tracks {
+			track, err := rd.track(t, k.kind, seqRate)
+			if err != nil {
+				return nil, fmt.Errorf("%s track %d: %w", strings.ToLower(k.kind), i+1, err)
+			}
+			if err := timeline.Tracks().AppendChild(track); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	markers := make([]*gotio.Marker, len(seq.Markers))
+	for i, m := range seq.Markers {
+		markers[i] = rd.marker(m, 0, seqRate)
+	}
+	timeline.Tracks().SetMarkers(markers)
+	return timeline, nil
+}
+
+// track lays out the items of a track. Clip items place themselves with
+// their start; one whose head is under a transition has a start of -1
+// and begins at the transition's cut point.
+func (rd *reader) track(t track, kind string, seqRate float64) (*gotio.Track, error) {
+	track := gotio.NewTrack("", nil, kind, nil, nil)
+	track.SetEnabled(t.Enabled != "FALSE")
+
+	frames := func(n int64) opentime.RationalTime {
+		return opentime.NewRationalTime(float64(n), seqRate)
+	}
+	var cursor, lastCut int64
+	fill := func(to int64) error {
+		if to > cursor {
+			if err := track.AppendChild(gotio.NewGapWithDuration(frames(to - cursor))); err != nil {
+				return err
+			}
+			cursor = to
+		}
+		return nil
+	}
+
+	for _, item := range t.Items {
+		switch item.XMLName.Local {
+		case transitionItem:
+			cut := item.cutPoint()
+			if err := fill(cut); err != nil {
+				return nil, err
+			}
+			name, transitionType := "", gotio.TransitionTypeCustom
+			if item.Effect != nil {
+				name = item.Effect.Name
+				if isDissolve(item.Effect) {
+					transitionType = gotio.TransitionTypeSMPTEDissolve
+				}
+			}
+			transition := gotio.NewTransition(name, transitionType, frames(cut-item.Start), frames(item.End-cut), nil)
+			if err := track.AppendChild(transition); err != nil {
+				return nil, err
+			}
+			lastCut = cut
+
+		case clipItem, generatorItem:
+			start := item.Start
+			if start < 0 {
+				start = lastCut
+			}
+			if start < cursor {
+				return nil, fmt.Errorf("%w: item %q overlaps the item before it", ErrInvalidXMEML, item.Name)
+			}
+			if err := fill(start); err != nil {
+				return nil, err
+			}
+			clip, err := rd.clip(item, seqRate)
+			if err != nil {
+				return nil, err
+			}
+			if err := track.AppendChild(clip); err != nil {
+				return nil, err
+			}
+			d := clip.SourceRange().Duration()
+			cursor = start + int64(math.Round(d.ValueRescaledTo(seqRate)))
+		}
+	}
+	return track, nil
+}
+
+// isDissolve reports whether a transition effect is a video dissolve or
+// an audio cross fade.
+func isDissolve(e *effect) bool {
+	id := strings.ToLower(e.EffectID)
+	return strings.EqualFold(e.EffectCategory, "Dissolve") ||
+		strings.Contains(id, "dissolve") || strings.Contains(id, "crossfade")
+}
+
+// cutPoint returns the frame of the edit a transition item is aligned to.
+func (item trackItem) cutPoint() int64 {
+	switch item.Alignment {
+	case "start", "start-black":
+		return item.Start
+	case "end", "end-black":
+		return item.End
+	}
+	return item.Start + (item.End-item.Start)/2
+}
+
+func (rd *reader) clip(item trackItem, seqRate float64) (*gotio.Clip, error) {
+	itemRate := frameRate(item.Rate, seqRate)
+	var in, out int64
+	if item.In != nil && item.Out != nil {
+		in, out = *item.In, *item.Out
+	} else {
+		out = item.End - item.Start
+	}
+
+	var ref gotio.MediaReference
+	var mediaStart int64
+	if item.XMLName.Local == generatorItem {
+		name, kind := item.Name, ""
+		parameters := gotio.AnyDictionary{}
+		if item.Effect != nil {
+			name, kind = item.Effect.Name, item.Effect.EffectID
+			parameters = effectParameters(item.Effect)
+		}
+		ref = gotio.NewGeneratorReference(name, kind, parameters, nil, nil)
+	} else if f := rd.file(item.File); f != nil {
+		fileRate := frameRate(f.Rate, itemRate)
+		if f.Timecode != nil {
+			mediaStart = f.Timecode.Frame
+		}
+		var available *opentime.TimeRange
+		if f.Duration > 0 {
+			ar := opentime.NewTimeRange(
+				opentime.NewRationalTime(float64(mediaStart), fileRate),
+				opentime.NewRationalTime(float64(f.Duration), fileRate),
+			)
+			available = &ar
+		}
+		if f.PathURL != "" {
+			ref = gotio.NewExternalReference(f.Name, f.PathURL, available, nil)
+		} else {
+			ref = gotio.NewMissingReference(f.Name, available, nil)
+		}
+	}
+
+	effects, err := readEffects(item.Filters)
+	if err != nil {
+		return nil, fmt.Errorf("item %q: %w", item.Name, err)
+	}
+	markers := make([]*gotio.Marker, len(item.Markers))
+	for i, m := range item.Markers {
+		markers[i] = rd.marker(m, mediaStart, itemRate)
+	}
+
+	sr := opentime.NewTimeRange(
+		opentime.NewRationalTime(float64(mediaStart+in), itemRate),
+		opentime.NewRationalTime(float64(out-in), itemRate),
+	)
+	clip := gotio.NewClip(item.Name, ref, &sr, nil, effects, markers, "", nil)
+	clip.SetEnabled(item.Enabled != "FALSE")
+	interchange.Stash(rd.cfg.Profiles, clip, fields(item.Extra))
+	return clip, nil
+}
+
+// file returns the full definition of f, which may only carry an id.
+func (rd *reader) file(f *file) *file {
+	if f == nil {
+		return nil
+	}
+	if full, ok := rd.files[f.ID]; ok {
+		return full
+	}
+	return f
+}
+
+// readEffects maps filters to effects. A Time Remap filter becomes a
+// LinearTimeWarp, or a FreezeFrame at speed 0.
+func readEffects(filters []filter) ([]gotio.Effect, error) {
+	var effects []gotio.Effect
+	for _, f := range filters {
+		e := f.Effect
+		if !strings.EqualFold(e.EffectID, timeRemapID) {
+			var metadata gotio.AnyDictionary
+			if parameters := effectParameters(&e); len(parameters) > 0 {
+				metadata = gotio.AnyDictionary{MetadataKey: gotio.AnyDictionary{"parameters": parameters}}
+			}
+			effects = append(effects, gotio.NewEffect(e.Name, e.EffectID, metadata))
+			continue
+		}
+
+		speed, reverse := 100.0, false
+		for _, p := range e.Parameters {
+			switch p.ParameterID {
+			case "speed":
+				v, err := strconv.ParseFloat(strings.TrimSpace(p.Value), 64)
+				if err != nil {
+					return nil, fmt.Errorf("%w: time remap speed %q", ErrInvalidXMEML, p.Value)
+				}
+				speed = v
+			case "reverse":
+				reverse = strings.EqualFold(strings.TrimSpace(p.Value), "TRUE")
+			}
+		}
+		if speed == 0 {
+			effects = append(effects, gotio.NewFreezeFrame(e.Name, nil))
+			continue
+		}
+		scalar := speed / 100
+		if reverse {
+			scalar = -scalar
+		}
+		effects = append(effects, gotio.NewLinearTimeWarp(e.Name, "LinearTimeWarp", scalar, nil))
+	}
+	return effects, nil
+}
+
+func effectParameters(e *effect) gotio.AnyDictionary {
+	parameters := gotio.AnyDictionary{}
+	for _, p := range e.Parameters {
+		if p.ParameterID != "" {
+			parameters[p.ParameterID] = p.Value
+		}
+	}
+	return parameters
+}
+
+// marker converts a marker, whose in and out are frames counted from
+// offset. An out of -1 marks a single frame.
+func (rd *reader) marker(m marker, offset int64, frameRate float64) *gotio.Marker {
+	duration := int64(0)
+	if m.Out > m.In {
+		duration = m.Out - m.In
+	}
+	r := opentime.NewTimeRange(
+		opentime.NewRationalTime(float64(offset+m.In), frameRate),
+		opentime.NewRationalTime(float64(duration), frameRate),
+	)
+	marker := gotio.NewMarker(m.Name, r, "", m.Comment, nil)
+	interchange.Stash(rd.cfg.Profiles, marker, fields(m.Extra))
+	return marker
+}
@@ -0,0 +1,392 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package xmeml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/interchange"
+)
+
+// Write writes a timeline as an xmeml document holding one sequence.
+//
+// Each media file is written in full on its first use and by id after
+// that. xmeml aligns a transition to its start, center or end, so a
+// transition whose offsets are both non-zero and unequal is written
+// centered on its cut.
+func Write(w io.Writer, timeline *gotio.Timeline, opts ...Option) error {
+	cfg := newConfig(opts)
+	wr := &writer{cfg: cfg, rate: sequenceRate(timeline, cfg), fileIDs: make(map[string]string)}
+	seq, err := wr.sequence(timeline)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, xml.Header+"<!DOCTYPE xmeml>\n"); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(document{Version: "4", Sequences: []sequence{*seq}}); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// WriteFile writes an xmeml file to path.
+func WriteFile(timeline *gotio.Timeline, path string, opts ...Option) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, timeline, opts...); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// sequenceRate returns the configured rate, or the rate of the timeline's
+// global start time or first clip, or 24.
+func sequenceRate(timeline *gotio.Timeline, cfg Config) float64 {
+	if cfg.Rate > 0 {
+		return cfg.Rate
+	}
+	if start := timeline.GlobalStartTime(); start != nil && start.Rate() > 0 {
+		return start.Rate()
+	}
+	for _, clip := range timeline.FindClips(nil, false) {
+		if tr, err := clip.TrimmedRange(); err == nil && tr.Duration().Rate() > 0 {
+			return tr.Duration().Rate()
+		}
+	}
+	return 24
+}
+
+type writer struct {
+	cfg     Config
+	rate    float64
+	fileIDs map[string]string
+	items   int
+}
+
+func (wr *writer) sequence(timeline *gotio.Timeline) (*sequence, error) {
+	seq := &sequence{ID: "sequence-1", Name: timeline.Name(), Rate: xmlRate(wr.rate)}
+	if d, err := timeline.Duration(); err == nil {
+		seq.Duration = frames(d, wr.rate)
+	}
+	if start := timeline.GlobalStartTime(); start != nil {
+		tc, err := wr.timecode(frames(*start, wr.rate), wr.rate)
+		if err != nil {
+			return nil, err
+		}
+		seq.Timecode = tc
+	}
+
+	lists := []struct {
+		tracks []*gotio.Track
+		list   **trackList
+		kind   string
+	}{
+		{timeline.VideoTracks(), &seq.Media.Video, "video"},
+		{timeline.AudioTracks(), &seq.Media.Audio, "audio"},
+	}
+	for _, l := range lists {
+		if len(l.tracks) == 0 {
+			continue
+		}
+		list := &trackList{}
+		for i, t := range l.tracks {
+			xt, err := wr.track(t, l.kind)
+			if err != nil {
+				return nil, fmt.Errorf("%s track %d: %w", l.kind, i+1, err)
+			}
+			list.Tracks = append(list.Tracks, xt)
+		}
+		*l.list = list
+	}
+
+	for _, m := range timeline.Tracks().Markers() {
+		seq.Markers = append(seq.Markers, wr.marker(m, 0, wr.rate))
+	}
+	seq.Extra = elements(interchange.Restore(wr.cfg.Profiles, timeline))
+	return seq, nil
+}
+
+func (wr *writer) track(t *gotio.Track, kind string) (track, error) {
+	xt := track{Enabled: xmlBool(t.Enabled()), Locked: xmlBool(t.Locked())}
+	children := t.Children()
+	isTransition := func(i int) bool {
+		if i < 0 || i >= len(children) {
+			return false
+		}
+		_, ok := children[i].(*gotio.Transition)
+		return ok
+	}
+
+	var cursor int64
+	for i, child := range children {
+		switch c := child.(type) {
+		case *gotio.Gap:
+			d, err := c.Duration()
+			if err != nil {
+				return xt, err
+			}
+			cursor += frames(d, wr.rate)
+		case *gotio.Transition:
+			xt.Items = append(xt.Items, wr.transition(c, cursor, kind))
+		case *gotio.Clip:
+			item, err := wr.clip(c, kind)
+			if err != nil {
+				return xt, fmt.Errorf("clip %q: %w", c.Name(), err)
+			}
+			duration := *item.Out - *item.In
+			item.Start, item.End = cursor, cursor+duration
+			if isTransition(i - 1) {
+				item.Start = -1
+			}
+			if isTransition(i + 1) {
+				item.End = -1
+			}
+			cursor += duration
+			xt.Items = append(xt.Items, item)
+		default:
+			return xt, fmt.Errorf("%w: %s in a track", ErrUnsupported, child.SchemaName())
+		}
+	}
+	return xt, nil
+}
+
+func (wr *writer) transition(t *gotio.Transition, cut int64, kind string) trackItem {
+	in, out := frames(t.InOffset(), wr.rate), frames(t.OutOffset(), wr.rate)
+	alignment := "center"
+	if in == 0 && out != 0 {
+		alignment = "start"
+	} else if out == 0 && in != 0 {
+		alignment = "end"
+	}
+
+	e := &effect{Name: t.Name(), EffectID: t.Name(), EffectType: "transition", MediaType: kind}
+	if t.TransitionType() == gotio.TransitionTypeSMPTEDissolve {
+		e.EffectCategory = "Dissolve"
+		e.EffectID = "Cross Dissolve"
+		if kind == "audio" {
+			e.EffectID = "KGAudioTransCrossFade3dB"
+		}
+		if e.Name == "" {
+			e.Name = e.EffectID
+		}
+	}
+	r := xmlRate(wr.rate)
+	return trackItem{
+		XMLName:   xml.Name{Local: transitionItem},
+		Rate:      &r,
+		Start:     cut - in,
+		End:       cut + out,
+		Alignment: alignment,
+		Effect:    e,
+	}
+}
+
+// clip converts a clip. The caller places the item on the track.
+func (wr *writer) clip(c *gotio.Clip, kind string) (trackItem, error) {
+	sr, err := c.TrimmedRange()
+	if err != nil {
+		return trackItem{}, err
+	}
+	itemRate := sr.Duration().Rate()
+	if itemRate <= 0 {
+		itemRate = wr.rate
+	}
+	wr.items++
+	r := xmlRate(itemRate)
+	item := trackItem{
+		XMLName: xml.Name{Local: clipItem},
+		ID:      fmt.Sprintf("%s-%d", clipItem, wr.items),
+		Name:    c.Name(),
+		Enabled: xmlBool(c.Enabled()),
+		Rate:    &r,
+	}
+
+	var mediaStart, mediaDuration int64
+	ref := c.MediaReference()
+	if ar := ref.AvailableRange(); ar != nil {
+		mediaStart = frames(ar.StartTime(), itemRate)
+		mediaDuration = frames(ar.Duration(), itemRate)
+	}
+	switch ref := ref.(type) {
+	case *gotio.GeneratorReference:
+		item.XMLName.Local = generatorItem
+		item.ID = fmt.Sprintf("%s-%d", generatorItem, wr.items)
+		item.Effect = &effect{
+			Name:       ref.Name(),
+			EffectID:   ref.GeneratorKind(),
+			EffectType: "generator",
+			MediaType:  kind,
+			Parameters: writeParameters(ref.Parameters()),
+		}
+	case *gotio.ExternalReference:
+		f, err := wr.file(ref.Name(), ref.TargetURL(), ref.AvailableRange(), itemRate)
+		if err != nil {
+			return item, err
+		}
+		item.File = f
+	case *gotio.MissingReference:
+		if ref.Name() != "" {
+			f, err := wr.file(ref.Name(), "", ref.AvailableRange(), itemRate)
+			if err != nil {
+				return item, err
+			}
+			item.File = f
+		}
+	}
+
+	in := frames(sr.StartTime(), itemRate) - mediaStart
+	out := in + frames(sr.Duration(), itemRate)
+	item.In, item.Out = &in, &out
+	item.Duration = mediaDuration
+	if item.Duration == 0 {
+		item.Duration = out - in
+	}
+
+	for _, e := range c.Effects() {
+		item.Filters = append(item.Filters, writeFilter(e))
+	}
+	for _, m := range c.Markers() {
+		item.Markers = append(item.Markers, wr.marker(m, mediaStart, itemRate))
+	}
+	item.Extra = elements(interchange.Restore(wr.cfg.Profiles, c))
+	return item, nil
+}
+
+// file returns the file element for a media file, in full on its first
+// use and as a reference to its id after that.
+func (wr *writer) file(name, url string, available *opentime.TimeRange, frameRate float64) (*file, error) {
+	key := url
+	if key == "" {
+		key = "name:" + name
+	}
+	if id, ok := wr.fileIDs[key]; ok {
+		return &file{ID: id}, nil
+	}
+	id := fmt.Sprintf("file-%d", len(wr.fileIDs)+1)
+	wr.fileIDs[key] = id
+
+	if name == "" {
+		name = path.Base(url)
+	}
+	r := xmlRate(frameRate)
+	f := &file{ID: id, Name: name, PathURL: url, Rate: &r}
+	if available != nil {
+		f.Duration = frames(available.Duration(), frameRate)
+		tc, err := wr.timecode(frames(available.StartTime(), frameRate), frameRate)
+		if err != nil {
+			return nil, err
+		}
+		f.Timecode = tc
+	}
+	return f, nil
+}
+
+func (wr *writer) timecode(frame int64, frameRate float64) (*timecode, error) {
+	s, err := opentime.NewRationalTime(float64(frame), frameRate).ToTimecode(frameRate, wr.cfg.DropFrame)
+	if err != nil {
+		return nil, err
+	}
+	format := "NDF"
+	if strings.Contains(s, ";") {
+		format = "DF"
+	}
+	return &timecode{Rate: xmlRate(frameRate), String: s, Frame: frame, DisplayFormat: format}, nil
+}
+
+// writeFilter converts an effect. Time warps become a Time Remap filter.
+func writeFilter(e gotio.Effect) filter {
+	var speed float64
+	switch e := e.(type) {
+	case *gotio.FreezeFrame:
+		speed = 0
+	case *gotio.LinearTimeWarp:
+		speed = e.TimeScalar() * 100
+	default:
+		var parameters gotio.AnyDictionary
+		if stored, ok := e.Metadata().GetDictionary(MetadataKey); ok {
+			parameters, _ = stored.GetDictionary("parameters")
+		}
+		return filter{Effect: effect{
+			Name:       e.Name(),
+			EffectID:   e.EffectName(),
+			Parameters: writeParameters(parameters),
+		}}
+	}
+
+	name := e.Name()
+	if name == "" {
+		name = "Time Remap"
+	}
+	return filter{Effect: effect{
+		Name:           name,
+		EffectID:       timeRemapID,
+		EffectCategory: "motion",
+		EffectType:     "motion",
+		MediaType:      "video",
+		Parameters: []parameter{
+			{ParameterID: "variablespeed", Name: "variablespeed", Value: "0"},
+			{ParameterID: "speed", Name: "speed", Value: strconv.FormatFloat(math.Abs(speed), 'f', -1, 64)},
+			{ParameterID: "reverse", Name: "reverse", Value: xmlBool(speed < 0)},
+		},
+	}}
+}
+
+func writeParameters(values gotio.AnyDictionary) []parameter {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parameters := make([]parameter, len(keys))
+	for i, key := range keys {
+		parameters[i] = parameter{ParameterID: key, Value: fmt.Sprint(values[key])}
+	}
+	return parameters
+}
+
+// marker converts a marker to frames counted from offset. A marker
+// without duration gets an out of -1.
+func (wr *writer) marker(m *gotio.Marker, offset int64, frameRate float64) marker {
+	r := m.MarkedRange()
+	in := frames(r.StartTime(), frameRate) - offset
+	out := int64(-1)
+	if d := frames(r.Duration(), frameRate); d > 0 {
+		out = in + d
+	}
+	return marker{
+		Name:    m.Name(),
+		Comment: m.Comment(),
+		In:      in,
+		Out:     out,
+		Extra:   elements(interchange.Restore(wr.cfg.Profiles, m)),
+	}
+}
+
+// frames returns the nearest frame count of t at frameRate. Times at an
+// NTSC rate and its rounded form, such as 30000/1001 and 29.97, count the
+// same frames.
+func frames(t opentime.RationalTime, frameRate float64) int64 {
+	if math.Abs(t.Rate()-frameRate) < 0.01 {
+		return int64(math.Round(t.Value()))
+	}
+	return int64(math.Round(t.ValueRescaledTo(frameRate)))
+}
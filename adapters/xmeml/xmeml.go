@@ -0,0 +1,342 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package xmeml reads and writes Final Cut Pro 7 XML (xmeml), the
+// interchange format exported and imported by DaVinci Resolve, Premiere
+// Pro and Final Cut Pro 7.
+//
+// A sequence becomes a Timeline with one Track per video and audio track.
+// Clip items become Clips with their file as an ExternalReference, for
+// every clip that uses the file even though xmeml only writes it in full
+// once. Transition items become Transitions, generator items become Clips
+// with a GeneratorReference, and space between items becomes Gaps. A Time
+// Remap filter becomes a LinearTimeWarp, or a FreezeFrame at speed 0;
+// other filters become Effects named by their effect id. Sequence and
+// clip markers are kept.
+//
+// Elements the adapter has no mapping for are offered to the interchange
+// profiles registered for "xmeml", such as PremiereProfile, which keep
+// the ones they know in metadata and write them back on export.
+//
+// As in the xmeml files themselves, source times count from the start of
+// the media: a clip's source range starts at its file's timecode plus its
+// in point.
+//
+// Basic usage:
+//
+//	timeline, err := xmeml.ReadFile("conform.xml")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = xmeml.WriteFile(timeline, "roundtrip.xml")
+package xmeml
+
+import (
+	"encoding/xml"
+	"errors"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/adapters"
+	"github.com/Avalanche-io/gotio/interchange"
+)
+
+func init() {
+	gotio.RegisterAdapter(gotio.AdapterInfo{
+		Name:     "xmeml",
+		Package:  "github.com/Avalanche-io/gotio/adapters/xmeml",
+		Suffixes: []string{".xml"},
+		CanRead:  true,
+		CanWrite: true,
+		Options: []gotio.AdapterOption{
+			{Name: "rate", Type: "float", Description: "Sequence frame rate"},
+			{Name: "drop_frame", Type: "bool", Description: "Drop frame timecode when writing"},
+		},
+	})
+}
+
+// MetadataKey is the metadata key holding xmeml fields that have no OTIO
+// equivalent, such as effect parameters.
+const MetadataKey = "fcp_xml"
+
+var (
+	// ErrInvalidXMEML is returned for documents that are not xmeml or
+	// whose items cannot be laid out on a track.
+	ErrInvalidXMEML = errors.New("xmeml: invalid document")
+	// ErrUnsupported is returned when writing a timeline xmeml cannot
+	// represent, such as a track holding a nested stack.
+	ErrUnsupported = errors.New("xmeml: unsupported")
+)
+
+// Config holds configuration for reading and writing xmeml.
+type Config struct {
+	// Rate overrides the sequence rate. When writing, zero uses the rate
+	// of the timeline's global start time or of its first clip.
+	Rate float64
+	// DropFrame selects drop frame timecode for written timecodes.
+	DropFrame opentime.IsDropFrameRate
+	// Profiles keep application fields of sequences, clip items and
+	// markers in metadata. Nil uses the profiles registered for "xmeml".
+	Profiles []*interchange.Profile
+}
+
+// Option is a functional option for Read and Write.
+type Option func(*Config)
+
+// WithRate sets the sequence frame rate.
+func WithRate(rate float64) Option {
+	return func(c *Config) {
+		c.Rate = rate
+	}
+}
+
+// WithDropFrame sets the drop frame mode for written timecodes.
+func WithDropFrame(dropFrame opentime.IsDropFrameRate) Option {
+	return func(c *Config) {
+		c.DropFrame = dropFrame
+	}
+}
+
+// WithProfiles sets the interchange profiles used instead of the
+// registered ones. Calling it with no profiles turns them off.
+func WithProfiles(profiles ...*interchange.Profile) Option {
+	return func(c *Config) {
+		c.Profiles = append([]*interchange.Profile{}, profiles...)
+	}
+}
+
+func newConfig(opts []Option) Config {
+	cfg := Config{DropFrame: opentime.InferFromRate}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = interchange.Profiles("xmeml")
+	}
+	return cfg
+}
+
+// FromOptions returns the functional options set by the adapter options
+// keyed "xmeml.", such as "xmeml.rate=25". Unknown options and values of
+// the wrong type are errors.
+func FromOptions(o adapters.Options) ([]Option, error) {
+	if err := o.Check("xmeml"); err != nil {
+		return nil, err
+	}
+	// Check has validated the value types.
+	o = o.For("xmeml")
+	var opts []Option
+	if o.Has("rate") {
+		rate, _ := o.Float("rate", 0)
+		opts = append(opts, WithRate(rate))
+	}
+	if o.Has("drop_frame") {
+		dropFrame, _ := o.DropFrame("drop_frame")
+		opts = append(opts, WithDropFrame(dropFrame))
+	}
+	return opts, nil
+}
+
+// The xmeml elements read and written. Elements not listed are ignored.
+
+type document struct {
+	XMLName   xml.Name   `xml:"xmeml"`
+	Version   string     `xml:"version,attr"`
+	Sequences []sequence `xml:"sequence"`
+	Projects  []folder   `xml:"project"`
+	Bins      []folder   `xml:"bin"`
+}
+
+// folder is a project or bin, which may hold sequences and more bins.
+type folder struct {
+	Children struct {
+		Sequences []sequence `xml:"sequence"`
+		Bins      []folder   `xml:"bin"`
+	} `xml:"children"`
+}
+
+type sequence struct {
+	ID       string    `xml:"id,attr,omitempty"`
+	Name     string    `xml:"name"`
+	Duration int64     `xml:"duration"`
+	Rate     rate      `xml:"rate"`
+	Timecode *timecode `xml:"timecode"`
+	Media    struct {
+		Video *trackList `xml:"video"`
+		Audio *trackList `xml:"audio"`
+	} `xml:"media"`
+	Markers []marker  `xml:"marker"`
+	Extra   []element `xml:",any"`
+}
+
+type rate struct {
+	Timebase int64  `xml:"timebase"`
+	NTSC     string `xml:"ntsc"`
+}
+
+type timecode struct {
+	Rate          rate   `xml:"rate"`
+	String        string `xml:"string"`
+	Frame         int64  `xml:"frame"`
+	DisplayFormat string `xml:"displayformat"`
+}
+
+type trackList struct {
+	Tracks []track `xml:"track"`
+}
+
+type track struct {
+	// Items holds the clip, transition and generator items in order.
+	Items   []trackItem `xml:",any"`
+	Enabled string      `xml:"enabled,omitempty"`
+	Locked  string      `xml:"locked,omitempty"`
+}
+
+// trackItem is a clipitem, transitionitem or generatoritem.
+type trackItem struct {
+	XMLName   xml.Name
+	ID        string    `xml:"id,attr,omitempty"`
+	Name      string    `xml:"name,omitempty"`
+	Enabled   string    `xml:"enabled,omitempty"`
+	Duration  int64     `xml:"duration,omitempty"`
+	Rate      *rate     `xml:"rate"`
+	Start     int64     `xml:"start"`
+	End       int64     `xml:"end"`
+	Alignment string    `xml:"alignment,omitempty"`
+	In        *int64    `xml:"in"`
+	Out       *int64    `xml:"out"`
+	File      *file     `xml:"file"`
+	Effect    *effect   `xml:"effect"`
+	Filters   []filter  `xml:"filter"`
+	Markers   []marker  `xml:"marker"`
+	Extra     []element `xml:",any"`
+}
+
+type file struct {
+	ID       string    `xml:"id,attr"`
+	Name     string    `xml:"name,omitempty"`
+	PathURL  string    `xml:"pathurl,omitempty"`
+	Rate     *rate     `xml:"rate"`
+	Duration int64     `xml:"duration,omitempty"`
+	Timecode *timecode `xml:"timecode"`
+}
+
+type filter struct {
+	Enabled string `xml:"enabled,omitempty"`
+	Effect  effect `xml:"effect"`
+}
+
+type effect struct {
+	Name           string      `xml:"name"`
+	EffectID       string      `xml:"effectid"`
+	EffectCategory string      `xml:"effectcategory,omitempty"`
+	EffectType     string      `xml:"effecttype,omitempty"`
+	MediaType      string      `xml:"mediatype,omitempty"`
+	Parameters     []parameter `xml:"parameter"`
+}
+
+type parameter struct {
+	ParameterID string `xml:"parameterid"`
+	Name        string `xml:"name,omitempty"`
+	Value       string `xml:"value"`
+}
+
+type marker struct {
+	Name    string    `xml:"name"`
+	Comment string    `xml:"comment"`
+	In      int64     `xml:"in"`
+	Out     int64     `xml:"out"`
+	Extra   []element `xml:",any"`
+}
+
+// element is an element without a field of its own, kept for interchange
+// profiles.
+type element struct {
+	XMLName  xml.Name
+	Text     string    `xml:",chardata"`
+	Children []element `xml:",any"`
+}
+
+// fields flattens elements to interchange fields named by their element
+// path, such as "labels/label2". Attributes are not kept.
+func fields(elements []element) interchange.Fields {
+	out := interchange.Fields{}
+	var walk func(prefix string, elements []element)
+	walk = func(prefix string, elements []element) {
+		for _, e := range elements {
+			name := prefix + e.XMLName.Local
+			if len(e.Children) == 0 {
+				out[name] = strings.TrimSpace(e.Text)
+				continue
+			}
+			walk(name+"/", e.Children)
+		}
+	}
+	walk("", elements)
+	return out
+}
+
+// elements is the inverse of fields, with elements sorted by name.
+func elements(f interchange.Fields) []element {
+	var out []element
+	for _, name := range slices.Sorted(maps.Keys(f)) {
+		out = insertElement(out, strings.Split(name, "/"), f[name])
+	}
+	return out
+}
+
+func insertElement(elements []element, path []string, text string) []element {
+	if len(path) == 1 {
+		return append(elements, element{XMLName: xml.Name{Local: path[0]}, Text: text})
+	}
+	if n := len(elements); n > 0 && elements[n-1].XMLName.Local == path[0] {
+		elements[n-1].Children = insertElement(elements[n-1].Children, path[1:], text)
+		return elements
+	}
+	parent := element{XMLName: xml.Name{Local: path[0]}}
+	parent.Children = insertElement(nil, path[1:], text)
+	return append(elements, parent)
+}
+
+// Item element names.
+const (
+	clipItem       = "clipitem"
+	transitionItem = "transitionitem"
+	generatorItem  = "generatoritem"
+)
+
+// timeRemapID is the effect id of the Time Remap filter.
+const timeRemapID = "timeremap"
+
+// frameRate returns the frame rate of r, or fallback if r has none. NTSC
+// rates are exact, such as 30000/1001 for a timebase of 30.
+func frameRate(r *rate, fallback float64) float64 {
+	if r == nil || r.Timebase <= 0 {
+		return fallback
+	}
+	if r.NTSC == "TRUE" {
+		return float64(r.Timebase) * 1000 / 1001
+	}
+	return float64(r.Timebase)
+}
+
+// xmlRate returns the timebase and NTSC flag of a frame rate.
+func xmlRate(frameRate float64) rate {
+	timebase := math.Round(frameRate)
+	ntsc := "FALSE"
+	if math.Abs(frameRate-timebase) > 0.001 {
+		ntsc = "TRUE"
+	}
+	return rate{Timebase: int64(timebase), NTSC: ntsc}
+}
+
+func xmlBool(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
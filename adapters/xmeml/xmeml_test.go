@@ -0,0 +1,317 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package xmeml
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+const ntsc = `<rate><timebase>30</timebase><ntsc>TRUE</ntsc></rate>`
+
+const sampleXMEML = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE xmeml>
+<xmeml version="4">
+  <project>
+    <name>Conform</name>
+    <children>
+      <sequence id="sequence-1">
+        <name>Reel 1</name>
+        <duration>180</duration>
+        ` + ntsc + `
+        <timecode>` + ntsc + `<string>01:00:00;00</string><frame>107892</frame><displayformat>DF</displayformat></timecode>
+        <media>
+          <video>
+            <track>
+              <clipitem id="clipitem-1">
+                <name>A</name>
+                <duration>1000</duration>
+                ` + ntsc + `
+                <start>0</start>
+                <end>-1</end>
+                <in>10</in>
+                <out>70</out>
+                <file id="file-1">
+                  <name>A001.mov</name>
+                  <pathurl>file:///media/A001.mov</pathurl>
+                  ` + ntsc + `
+                  <duration>1000</duration>
+                  <timecode>` + ntsc + `<string>01:00:00;00</string><frame>107892</frame></timecode>
+                </file>
+              </clipitem>
+              <transitionitem>
+                ` + ntsc + `
+                <start>45</start>
+                <end>75</end>
+                <alignment>center</alignment>
+                <effect>
+                  <name>Cross Dissolve</name>
+                  <effectid>Cross Dissolve</effectid>
+                  <effectcategory>Dissolve</effectcategory>
+                  <effecttype>transition</effecttype>
+                  <mediatype>video</mediatype>
+                </effect>
+              </transitionitem>
+              <clipitem id="clipitem-2">
+                <name>B</name>
+                <enabled>FALSE</enabled>
+                ` + ntsc + `
+                <start>-1</start>
+                <end>120</end>
+                <in>100</in>
+                <out>160</out>
+                <file id="file-1"/>
+                <filter>
+                  <effect>
+                    <name>Time Remap</name>
+                    <effectid>timeremap</effectid>
+                    <parameter><parameterid>speed</parameterid><value>50</value></parameter>
+                    <parameter><parameterid>reverse</parameterid><value>FALSE</value></parameter>
+                  </effect>
+                </filter>
+                <filter>
+                  <effect>
+                    <name>Gaussian Blur</name>
+                    <effectid>gaussianblur</effectid>
+                    <parameter><parameterid>radius</parameterid><value>2</value></parameter>
+                  </effect>
+                </filter>
+                <marker><name>fix</name><comment>flash frame</comment><in>110</in><out>-1</out></marker>
+              </clipitem>
+              <generatoritem id="generatoritem-1">
+                <name>Slug</name>
+                ` + ntsc + `
+                <start>150</start>
+                <end>180</end>
+                <in>0</in>
+                <out>30</out>
+                <effect><name>Slug</name><effectid>Slug</effectid><effecttype>generator</effecttype></effect>
+              </generatoritem>
+              <enabled>TRUE</enabled>
+              <locked>FALSE</locked>
+            </track>
+          </video>
+          <audio>
+            <track>
+              <clipitem id="clipitem-3">
+                <name>A</name>
+                ` + ntsc + `
+                <start>0</start>
+                <end>60</end>
+                <in>10</in>
+                <out>70</out>
+                <file id="file-1"/>
+              </clipitem>
+              <outputchannelindex>1</outputchannelindex>
+            </track>
+          </audio>
+        </media>
+        <marker><name>reel break</name><comment></comment><in>179</in><out>-1</out></marker>
+      </sequence>
+    </children>
+  </project>
+</xmeml>
+`
+
+func TestRead(t *testing.T) {
+	timeline, err := Read(strings.NewReader(sampleXMEML))
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	rate := 30000.0 / 1001
+	if timeline.Name() != "Reel 1" {
+		t.Errorf("Name = %q", timeline.Name())
+	}
+	if start := timeline.GlobalStartTime(); start == nil || start.Value() != 107892 || start.Rate() != rate {
+		t.Errorf("GlobalStartTime = %v", start)
+	}
+
+	video := timeline.VideoTracks()
+	if len(video) != 1 {
+		t.Fatalf("expected 1 video track, got %d", len(video))
+	}
+	children := video[0].Children()
+	var kinds []string
+	for _, child := range children {
+		kinds = append(kinds, child.SchemaName())
+	}
+	if got := strings.Join(kinds, " "); got != "Clip Transition Clip Gap Clip" {
+		t.Fatalf("children = %s", got)
+	}
+
+	transition := children[1].(*gotio.Transition)
+	if transition.InOffset().Value() != 15 || transition.OutOffset().Value() != 15 ||
+		transition.TransitionType() != gotio.TransitionTypeSMPTEDissolve {
+		t.Errorf("transition = %v %v %v", transition.InOffset(), transition.OutOffset(), transition.TransitionType())
+	}
+
+	b := children[2].(*gotio.Clip)
+	if sr := b.SourceRange(); sr.StartTime().Value() != 107992 || sr.Duration().Value() != 60 {
+		t.Errorf("B source range = %v", sr)
+	}
+	if ref, ok := b.MediaReference().(*gotio.ExternalReference); !ok || ref.TargetURL() != "file:///media/A001.mov" {
+		t.Errorf("B reference = %v, want the file of A", b.MediaReference())
+	}
+	if b.Enabled() {
+		t.Error("B should be disabled")
+	}
+	effects := b.Effects()
+	if len(effects) != 2 {
+		t.Fatalf("expected 2 effects, got %d", len(effects))
+	}
+	if warp, ok := effects[0].(*gotio.LinearTimeWarp); !ok || warp.TimeScalar() != 0.5 {
+		t.Errorf("effects[0] = %v, want a 0.5 time warp", effects[0])
+	}
+	if v, ok := effects[1].Metadata().Lookup(MetadataKey + ".parameters.radius"); !ok || v != "2" {
+		t.Errorf("blur radius = %v", v)
+	}
+	if markers := b.Markers(); len(markers) != 1 || markers[0].MarkedRange().StartTime().Value() != 108002 {
+		t.Errorf("B markers = %v", markers)
+	}
+
+	if gap := children[3].(*gotio.Gap); gap.SourceRange().Duration().Value() != 30 {
+		t.Errorf("gap = %v", gap.SourceRange())
+	}
+	if _, ok := children[4].(*gotio.Clip).MediaReference().(*gotio.GeneratorReference); !ok {
+		t.Error("generator item should have a GeneratorReference")
+	}
+
+	audio := timeline.AudioTracks()
+	if len(audio) != 1 || len(audio[0].Children()) != 1 {
+		t.Errorf("audio tracks = %v", audio)
+	}
+	if len(timeline.Tracks().Markers()) != 1 {
+		t.Errorf("expected 1 sequence marker, got %d", len(timeline.Tracks().Markers()))
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	timeline, err := Read(strings.NewReader(sampleXMEML))
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	freeze := timeline.VideoTracks()[0].Children()[0].(*gotio.Clip)
+	freeze.SetEffects([]gotio.Effect{gotio.NewFreezeFrame("Time Remap", nil)})
+
+	var buf bytes.Buffer
+	if err := Write(&buf, timeline); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	out := buf.String()
+	if n := strings.Count(out, "<pathurl>"); n != 1 {
+		t.Errorf("file written in full %d times, want 1", n)
+	}
+	for _, want := range []string{"<!DOCTYPE xmeml>", "<string>01:00:00;00</string>", "<start>-1</start>", "<end>-1</end>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output is missing %s", want)
+		}
+	}
+
+	again, err := Read(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("Read of written document error: %v", err)
+	}
+	if path, err := gotio.Difference(timeline, again); err != nil || path != "" {
+		t.Errorf("round trip differs at %q (%v)\n%s", path, err, out)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	if _, err := Read(strings.NewReader(`<fcpxml version="1.9"/>`)); !errors.Is(err, ErrInvalidXMEML) {
+		t.Errorf("expected ErrInvalidXMEML for fcpxml, got %v", err)
+	}
+	if _, err := Read(strings.NewReader(`<xmeml version="4"></xmeml>`)); !errors.Is(err, ErrInvalidXMEML) {
+		t.Errorf("expected ErrInvalidXMEML without a sequence, got %v", err)
+	}
+	overlap := `<xmeml version="4"><sequence><name>s</name><rate><timebase>24</timebase></rate><media><video><track>
+		<clipitem><name>a</name><start>0</start><end>24</end><in>0</in><out>24</out></clipitem>
+		<clipitem><name>b</name><start>12</start><end>36</end><in>0</in><out>24</out></clipitem>
+		</track></video></media></sequence></xmeml>`
+	if _, err := Read(strings.NewReader(overlap)); !errors.Is(err, ErrInvalidXMEML) {
+		t.Errorf("expected ErrInvalidXMEML for overlapping items, got %v", err)
+	}
+
+	nested := gotio.NewTimeline("nested", nil, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	track.AppendChild(gotio.NewStack("inner", nil, nil, nil, nil, nil))
+	nested.Tracks().AppendChild(track)
+	if err := Write(&bytes.Buffer{}, nested, WithRate(24)); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported for a nested stack, got %v", err)
+	}
+}
+
+const premiereXMEML = `<xmeml version="4"><sequence><name>s</name><rate><timebase>25</timebase></rate>
+	<labels><label2>Forest</label2></labels>
+	<media><video><track>
+	<clipitem id="clipitem-1">
+		<name>a</name><start>0</start><end>25</end><in>0</in><out>25</out>
+		<labels><label2>Iris</label2></labels>
+		<logginginfo><scene>12</scene><shottake>3</shottake></logginginfo>
+		<link><linkclipref>clipitem-2</linkclipref></link>
+		<marker><name>m</name><comment></comment><in>5</in><out>-1</out><pproColor>4294901760</pproColor></marker>
+	</clipitem>
+	</track></video></media></sequence></xmeml>`
+
+func TestPremiereProfile(t *testing.T) {
+	timeline, err := Read(strings.NewReader(premiereXMEML))
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	if v, ok := timeline.Metadata().Lookup("premiere.labels/label2"); !ok || v != "Forest" {
+		t.Errorf("sequence label = %v", v)
+	}
+	clip := timeline.FindClips(nil, false)[0]
+	if v, ok := clip.Metadata().Lookup("premiere.logginginfo/scene"); !ok || v != "12" {
+		t.Errorf("scene = %v", v)
+	}
+	if _, ok := clip.Metadata().Lookup("premiere.link/linkclipref"); ok {
+		t.Error("link is not a Premiere profile field")
+	}
+	if clip.Color() == nil || clip.Color().Hex() != "#6A8BDB" {
+		t.Errorf("clip color = %v, want Iris", clip.Color())
+	}
+	if marker := clip.Markers()[0]; marker.Color() != gotio.MarkerColorRed {
+		t.Errorf("marker color = %v, want RED", marker.Color())
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, timeline); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"<label2>Forest</label2>", "<label2>Iris</label2>", "<scene>12</scene>", "<pproColor>4294901760</pproColor>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output is missing %s:\n%s", want, out)
+		}
+	}
+	again, err := Read(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("Read of written document error: %v", err)
+	}
+	if !gotio.Equivalent(timeline, again) {
+		t.Error("Premiere fields did not round trip")
+	}
+
+	clip.SetMetadata(nil)
+	clip.SetColor(gotio.NewColorRGB(0.35, 0.35, 0.95))
+	buf.Reset()
+	if err := Write(&buf, timeline); err != nil {
+		t.Fatalf("Write error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<label2>Blue</label2>") {
+		t.Errorf("expected the nearest label for a blue clip:\n%s", buf.String())
+	}
+
+	plain, err := Read(strings.NewReader(premiereXMEML), WithProfiles())
+	if err != nil {
+		t.Fatalf("Read error: %v", err)
+	}
+	if _, ok := plain.Metadata()["premiere"]; ok || plain.FindClips(nil, false)[0].Color() != nil {
+		t.Error("WithProfiles() should turn profiles off")
+	}
+}
@@ -0,0 +1,329 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// ABRollMetadataKey is the metadata namespace ToABRoll records the
+// transitions at either end of an item under, with TransitionInKey and
+// TransitionOutKey. Each holds the transition's name, transition_type,
+// in_offset and out_offset.
+const ABRollMetadataKey = "ab_roll"
+
+// abItem is an item of an A/B roll and its range in the rolls.
+type abItem struct {
+	item  gotio.Item
+	roll  int
+	start opentime.RationalTime
+	end   opentime.RationalTime
+}
+
+// ToABRoll lays out a track with transitions as two tracks without any,
+// the A and B rolls of an EDL or a hardware switcher. Items joined by a
+// transition alternate between the rolls and overlap for its length: the
+// item before plays on past the cut by the transition's out offset and
+// the item after starts ahead of it by the in offset, both into their
+// media handles. Items joined by a cut stay on the same roll, and gaps
+// leave the rolls empty. A transition from or to a gap extends only the
+// item beside it.
+//
+// The items are copies, recording the transitions at their ends under
+// ABRollMetadataKey so FromABRoll can restore them. Handles are not
+// checked against the media; see AddHandles. The source range of the
+// track itself is not applied.
+func ToABRoll(track *gotio.Track) (a, b *gotio.Track, err error) {
+	if track == nil {
+		return nil, nil, newEditError("to_ab_roll", "track is nil")
+	}
+	children := track.Children()
+	var items []abItem
+	roll := 0
+	for i, child := range children {
+		item, ok := child.(gotio.Item)
+		if !ok {
+			continue
+		}
+		if _, ok := item.(*gotio.Gap); ok {
+			continue
+		}
+		r, err := track.RangeOfChildAtIndex(i)
+		if err != nil {
+			return nil, nil, err
+		}
+		placed := abItem{item: item.Clone().(gotio.Item), start: r.StartTime(), end: r.EndTimeExclusive()}
+
+		var head, tail opentime.RationalTime
+		if i > 0 {
+			if transition, ok := children[i-1].(*gotio.Transition); ok {
+				if i < 2 {
+					return nil, nil, newEditErrorForItem("to_ab_roll", "transition at the start of the track", transition)
+				}
+				if _, ok := children[i-2].(*gotio.Gap); !ok && len(items) > 0 {
+					roll = 1 - roll
+				}
+				head = transition.InOffset()
+				setABRollInfo(placed.item, TransitionInKey, transition)
+			}
+		}
+		if i+1 < len(children) {
+			if transition, ok := children[i+1].(*gotio.Transition); ok {
+				if i+2 >= len(children) {
+					return nil, nil, newEditErrorForItem("to_ab_roll", "transition at the end of the track", transition)
+				}
+				tail = transition.OutOffset()
+				setABRollInfo(placed.item, TransitionOutKey, transition)
+			}
+		}
+		if err := extendItem(placed.item, head, tail); err != nil {
+			return nil, nil, err
+		}
+		if head.Rate() > 0 {
+			placed.start = placed.start.Sub(head)
+		}
+		if tail.Rate() > 0 {
+			placed.end = placed.end.Add(tail)
+		}
+		placed.roll = roll
+		items = append(items, placed)
+	}
+
+	rolls := [2]*gotio.Track{}
+	for i, suffix := range []string{" A", " B"} {
+		rolls[i] = gotio.NewTrack(track.Name()+suffix, nil, track.Kind(), gotio.CloneAnyDictionary(track.Metadata()), nil)
+	}
+	ends := [2]opentime.RationalTime{}
+	for _, placed := range items {
+		roll := rolls[placed.roll]
+		if err := fillTo(roll, &ends[placed.roll], placed.start); err != nil {
+			return nil, nil, newEditErrorForItem("to_ab_roll", "transitions overlap on a roll", placed.item)
+		}
+		if err := roll.AppendChild(placed.item); err != nil {
+			return nil, nil, err
+		}
+		ends[placed.roll] = placed.end
+	}
+	return rolls[0], rolls[1], nil
+}
+
+// FromABRoll lays out A and B rolls as one track, the inverse of ToABRoll.
+// Items of both rolls are placed in order of their start; where an item
+// overlaps the one before, the overlap becomes a transition and both are
+// trimmed back to the cut. The transitions recorded by ToABRoll under
+// ABRollMetadataKey give the cut and the transition's type and name;
+// without a record the cut is centered in the overlap and the transition
+// is a dissolve. A recorded transition to or from a gap is restored as
+// well. Gaps of the rolls are not kept.
+func FromABRoll(a, b *gotio.Track) (*gotio.Track, error) {
+	if a == nil || b == nil {
+		return nil, newEditError("from_ab_roll", "roll is nil")
+	}
+	var items []abItem
+	for roll, track := range []*gotio.Track{a, b} {
+		for i, child := range track.Children() {
+			item, ok := child.(gotio.Item)
+			if !ok {
+				return nil, newEditErrorForItem("from_ab_roll", "roll holds a transition", child)
+			}
+			if _, ok := item.(*gotio.Gap); ok {
+				continue
+			}
+			r, err := track.RangeOfChildAtIndex(i)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, abItem{item: item, roll: roll, start: r.StartTime(), end: r.EndTimeExclusive()})
+		}
+	}
+	slices.SortStableFunc(items, func(x, y abItem) int {
+		return x.start.Cmp(y.start)
+	})
+
+	// heads and tails are the lengths trimmed from each item, before[k]
+	// the transition placed before item k and after[k] a fade after it.
+	heads := make([]opentime.RationalTime, len(items))
+	tails := make([]opentime.RationalTime, len(items))
+	before := make([]*gotio.Transition, len(items))
+	after := make([]*gotio.Transition, len(items))
+	for k, placed := range items {
+		if k > 0 && placed.start.Cmp(items[k-1].end) < 0 {
+			if k > 1 && placed.start.Cmp(items[k-2].end) < 0 {
+				return nil, newEditErrorForItem("from_ab_roll", "more than two items overlap", placed.item)
+			}
+			overlap := items[k-1].end.Sub(placed.start)
+			if placed.end.Cmp(items[k-1].end) <= 0 {
+				return nil, newEditErrorForItem("from_ab_roll", "item is inside the one before it", placed.item)
+			}
+			transition := abRollTransition(placed.item, TransitionInKey)
+			if transition == nil || transition.InOffset().Add(transition.OutOffset()).Cmp(overlap) != 0 {
+				half := opentime.NewRationalTime(overlap.Value()/2, overlap.Rate())
+				transition = gotio.NewTransition("", gotio.TransitionTypeSMPTEDissolve, half, overlap.Sub(half), nil)
+			}
+			before[k] = transition
+			heads[k] = transition.InOffset()
+			tails[k-1] = transition.OutOffset()
+			after[k-1] = nil
+		} else if transition := abRollTransition(placed.item, TransitionInKey); transition != nil {
+			before[k] = transition
+			heads[k] = transition.InOffset()
+		}
+		if transition := abRollTransition(placed.item, TransitionOutKey); transition != nil {
+			after[k] = transition
+			tails[k] = transition.OutOffset()
+		}
+	}
+
+	name := strings.TrimSuffix(a.Name(), " A")
+	result := gotio.NewTrack(name, nil, a.Kind(), gotio.CloneAnyDictionary(a.Metadata()), nil)
+	var cursor opentime.RationalTime
+	for k, placed := range items {
+		cut := placed.start
+		if heads[k].Rate() > 0 {
+			cut = cut.Add(heads[k])
+		}
+		end := placed.end
+		if tails[k].Rate() > 0 {
+			end = end.Sub(tails[k])
+		}
+		if err := fillTo(result, &cursor, cut); err != nil {
+			return nil, newEditErrorForItem("from_ab_roll", "transitions overlap", placed.item)
+		}
+		if before[k] != nil {
+			if err := result.AppendChild(before[k]); err != nil {
+				return nil, err
+			}
+		}
+		item := placed.item.Clone().(gotio.Item)
+		clearABRollInfo(item)
+		if err := trimItem(item, heads[k], tails[k]); err != nil {
+			return nil, err
+		}
+		if err := result.AppendChild(item); err != nil {
+			return nil, err
+		}
+		cursor = end
+		if after[k] != nil {
+			if err := result.AppendChild(after[k]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// fillTo appends a gap to the track from *end to start and moves *end to
+// start. It fails if start is before *end.
+func fillTo(track *gotio.Track, end *opentime.RationalTime, start opentime.RationalTime) error {
+	if end.Rate() <= 0 {
+		*end = opentime.NewRationalTime(0, start.Rate())
+	}
+	switch start.Cmp(*end) {
+	case -1:
+		return newEditErrorAt("fill", "items overlap", start)
+	case 1:
+		gap := start.Sub(*end)
+		sr := opentime.NewTimeRange(opentime.NewRationalTime(0, gap.Rate()), gap)
+		if err := track.AppendChild(gotio.NewGap("", &sr, nil, nil, nil, nil)); err != nil {
+			return err
+		}
+	}
+	*end = start
+	return nil
+}
+
+// extendItem extends the item's source range into its media by head at
+// the start and tail at the end, in record time.
+func extendItem(item gotio.Item, head, tail opentime.RationalTime) error {
+	if head.Value() == 0 && tail.Value() == 0 {
+		return nil
+	}
+	sourceRange, err := item.TrimmedRange()
+	if err != nil {
+		return err
+	}
+	scalar, ok := timeScalar(item.Effects())
+	if !ok {
+		scalar = 1
+	}
+	rate := sourceRange.StartTime().Rate()
+	head = inRate(head, rate)
+	tail = inRate(tail, rate)
+	extended := opentime.NewTimeRange(
+		sourceRange.StartTime().Sub(opentime.NewRationalTime(head.Value()*scalar, rate)),
+		sourceRange.Duration().RescaledTo(rate).Add(head).Add(tail),
+	)
+	item.SetSourceRange(&extended)
+	return nil
+}
+
+// trimItem trims head and tail, in record time, from the item's source
+// range.
+func trimItem(item gotio.Item, head, tail opentime.RationalTime) error {
+	return extendItem(item, opentime.NewRationalTime(-head.Value(), head.Rate()), opentime.NewRationalTime(-tail.Value(), tail.Rate()))
+}
+
+// inRate returns t at rate, treating a time without a rate as zero.
+func inRate(t opentime.RationalTime, rate float64) opentime.RationalTime {
+	if t.Rate() <= 0 {
+		return opentime.NewRationalTime(0, rate)
+	}
+	return t.RescaledTo(rate)
+}
+
+// setABRollInfo records the transition under key in the item's A/B roll
+// metadata.
+func setABRollInfo(item gotio.Item, key string, transition *gotio.Transition) {
+	md := item.Metadata()
+	if md == nil {
+		md = gotio.AnyDictionary{}
+		item.SetMetadata(md)
+	}
+	values, ok := md.GetDictionary(ABRollMetadataKey)
+	if !ok {
+		values = gotio.AnyDictionary{}
+	}
+	values[key] = gotio.AnyDictionary{
+		"name":            transition.Name(),
+		"transition_type": string(transition.TransitionType()),
+		"in_offset":       transition.InOffset(),
+		"out_offset":      transition.OutOffset(),
+	}
+	md[ABRollMetadataKey] = values
+}
+
+// abRollTransition returns the transition recorded under key in the
+// item's A/B roll metadata, or nil.
+func abRollTransition(item gotio.Item, key string) *gotio.Transition {
+	values, ok := item.Metadata().GetDictionary(ABRollMetadataKey)
+	if !ok {
+		return nil
+	}
+	info, ok := values.GetDictionary(key)
+	if !ok {
+		return nil
+	}
+	in, okIn := info.GetRationalTime("in_offset")
+	out, okOut := info.GetRationalTime("out_offset")
+	if !okIn || !okOut {
+		return nil
+	}
+	name, _ := info.GetString("name")
+	transitionType, _ := info.GetString("transition_type")
+	if transitionType == "" {
+		transitionType = string(gotio.TransitionTypeSMPTEDissolve)
+	}
+	return gotio.NewTransition(name, gotio.TransitionType(transitionType), in, out, nil)
+}
+
+// clearABRollInfo removes the item's A/B roll metadata.
+func clearABRollInfo(item gotio.Item) {
+	if md := item.Metadata(); md != nil {
+		delete(md, ABRollMetadataKey)
+	}
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func TestABRoll(t *testing.T) {
+	newClip := func(name string, start, duration float64) *gotio.Clip {
+		sr := opentime.NewTimeRange(opentime.NewRationalTime(start, 24), opentime.NewRationalTime(duration, 24))
+		return gotio.NewClip(name, nil, &sr, nil, nil, nil, "", nil)
+	}
+	six := opentime.NewRationalTime(6, 24)
+	four := opentime.NewRationalTime(4, 24)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	track.AppendChild(newClip("a", 100, 48))
+	track.AppendChild(gotio.NewTransition("mix", gotio.TransitionTypeSMPTEDissolve, six, six, nil))
+	track.AppendChild(newClip("b", 200, 48))
+	track.AppendChild(newClip("c", 300, 24))
+	track.AppendChild(gotio.NewGapWithDuration(opentime.NewRationalTime(24, 24)))
+	track.AppendChild(gotio.NewTransition("fade", gotio.TransitionTypeSMPTEDissolve, four, four, nil))
+	track.AppendChild(newClip("d", 400, 24))
+
+	a, b, err := ToABRoll(track)
+	if err != nil {
+		t.Fatalf("ToABRoll error: %v", err)
+	}
+	if len(a.Children()) != 1 || a.Name() != "V1 A" {
+		t.Fatalf("A roll = %v, want only a", a.Children())
+	}
+	if sr := a.Children()[0].(*gotio.Clip).SourceRange(); sr.StartTime().Value() != 100 || sr.Duration().Value() != 54 {
+		t.Errorf("a on the A roll = %v, want 6 frames past its cut", sr)
+	}
+	// B: gap, b from 6 frames before the mix, c after a cut, gap, d with the fade in.
+	children := b.Children()
+	if len(children) != 5 {
+		t.Fatalf("B roll has %d children, want 5", len(children))
+	}
+	if r, _ := b.RangeOfChildAtIndex(1); r.StartTime().Value() != 42 || r.Duration().Value() != 54 {
+		t.Errorf("b on the B roll at %v, want 42 for 54 frames", r)
+	}
+	if sr := children[1].(*gotio.Clip).SourceRange(); sr.StartTime().Value() != 194 {
+		t.Errorf("b source = %v, want from 194", sr)
+	}
+	if r, _ := b.RangeOfChildAtIndex(4); r.StartTime().Value() != 140 || r.Duration().Value() != 28 {
+		t.Errorf("d on the B roll at %v, want 140 for 28 frames", r)
+	}
+	if len(track.Children()[0].Metadata()) != 0 {
+		t.Error("ToABRoll changed the input track")
+	}
+
+	back, err := FromABRoll(a, b)
+	if err != nil {
+		t.Fatalf("FromABRoll error: %v", err)
+	}
+	if !back.IsEquivalentTo(track) {
+		got, _ := gotio.ToJSONString(back, "  ")
+		t.Errorf("round trip differs:\n%s", got)
+	}
+
+	// An overlap without a record becomes a centered dissolve.
+	a.Children()[0].SetMetadata(nil)
+	b.Children()[1].SetMetadata(nil)
+	back, err = FromABRoll(a, b)
+	if err != nil {
+		t.Fatalf("FromABRoll without records: %v", err)
+	}
+	if tr, ok := back.Children()[1].(*gotio.Transition); !ok || tr.InOffset().Value() != 6 || tr.OutOffset().Value() != 6 || tr.Name() != "" {
+		t.Errorf("unrecorded transition = %v", back.Children()[1])
+	}
+
+	bad := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	bad.AppendChild(gotio.NewTransition("", gotio.TransitionTypeSMPTEDissolve, six, six, nil))
+	bad.AppendChild(newClip("a", 100, 48))
+	if _, _, err := ToABRoll(bad); err == nil {
+		t.Error("expected an error for a transition at the start")
+	}
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// ConformPolicy determines how times are converted to the new rate.
+type ConformPolicy int
+
+const (
+	// ConformExact rescales times exactly, which may leave fractional frames.
+	ConformExact ConformPolicy = iota
+	// ConformNearestFrame rounds times to the nearest whole frame at the new
+	// rate. A range's start and end are snapped and its duration is the
+	// difference, so the range keeps its place in its media. Each item is
+	// rounded on its own, though, so the cuts of a track can drift by up
+	// to half a frame per item before them.
+	ConformNearestFrame
+)
+
+// String returns the string representation of a ConformPolicy.
+func (p ConformPolicy) String() string {
+	switch p {
+	case ConformExact:
+		return "Exact"
+	case ConformNearestFrame:
+		return "NearestFrame"
+	default:
+		return fmt.Sprintf("ConformPolicy(%d)", p)
+	}
+}
+
+// RateAdjustment records a time that was moved by rounding during ConformRate.
+type RateAdjustment struct {
+	// Object is the object that owns the time.
+	Object gotio.SerializableObject
+	// Field names the adjusted value, e.g. "source_range.duration".
+	Field string
+	// Original is the exact time at the new rate, before rounding.
+	Original opentime.RationalTime
+	// Conformed is the time that was stored.
+	Conformed opentime.RationalTime
+}
+
+// Delta returns how far the conformed time moved from the exact time.
+func (a RateAdjustment) Delta() opentime.RationalTime {
+	return opentime.NewRationalTime(a.Conformed.Value()-a.Original.Value(), a.Conformed.Rate())
+}
+
+// ConformReport describes the result of ConformRate.
+type ConformReport struct {
+	Rate        float64
+	Policy      ConformPolicy
+	Adjustments []RateAdjustment
+}
+
+// ConformRate rescales every time in the timeline to newRate, in place.
+// This covers the global start time, source ranges of all items, marker
+// ranges, transition offsets and the available ranges of all media
+// references of each clip. Image sequence frame rates are not changed,
+// and neither are time effects, such as the keyframes of a TimeCurveWarp.
+// The returned report lists every time moved by rounding.
+func ConformRate(timeline *gotio.Timeline, newRate float64, policy ConformPolicy) (*ConformReport, error) {
+	if timeline == nil {
+		return nil, newEditError("conform_rate", "timeline is nil")
+	}
+	if newRate <= 0 || math.IsNaN(newRate) || math.IsInf(newRate, 0) {
+		return nil, newEditError("conform_rate", fmt.Sprintf("invalid rate %g", newRate))
+	}
+
+	c := &rateConformer{
+		report: &ConformReport{Rate: newRate, Policy: policy},
+	}
+
+	if gst := timeline.GlobalStartTime(); gst != nil {
+		conformed := c.time(timeline, "global_start_time", *gst)
+		timeline.SetGlobalStartTime(&conformed)
+	}
+	if timeline.Tracks() != nil {
+		c.composable(timeline.Tracks())
+	}
+	return c.report, nil
+}
+
+// NormalizeRates rescales the items of the track whose rate differs from
+// the track's rate, in place, as ConformRate does for a whole timeline,
+// and tags the track with its rate. The track's rate is its tagged rate,
+// or else the rate of most of its duration. Transitions whose offsets use
+// another rate are rescaled too. The returned report lists every time
+// moved by rounding.
+func NormalizeRates(track *gotio.Track, policy ConformPolicy) (*ConformReport, error) {
+	if track == nil {
+		return nil, newEditError("normalize_rates", "track is nil")
+	}
+	rate, ok := track.Rate()
+	if !ok {
+		return nil, newEditError("normalize_rates", "track has no item with a rate")
+	}
+
+	c := &rateConformer{
+		report: &ConformReport{Rate: rate, Policy: policy},
+	}
+	for _, item := range track.RateOutliers() {
+		c.composable(item)
+	}
+	for _, child := range track.Children() {
+		if transition, ok := child.(*gotio.Transition); ok &&
+			(transition.InOffset().Rate() != rate || transition.OutOffset().Rate() != rate) {
+			c.transition(transition)
+		}
+	}
+	track.SetTaggedRate(rate)
+	return c.report, nil
+}
+
+// rateConformer carries the state of a ConformRate call.
+type rateConformer struct {
+	report *ConformReport
+}
+
+// composable conforms a composable and, for compositions, its children.
+func (c *rateConformer) composable(composable gotio.Composable) {
+	switch obj := composable.(type) {
+	case *gotio.Transition:
+		c.transition(obj)
+		return
+	case gotio.Item:
+		c.item(obj)
+	}
+
+	if clip, ok := composable.(*gotio.Clip); ok {
+		for _, key := range clip.MediaReferenceKeys() {
+			c.mediaReference(clip.MediaReferences()[key])
+		}
+	}
+
+	if comp, ok := composable.(gotio.Composition); ok {
+		for _, child := range comp.Children() {
+			c.composable(child)
+		}
+	}
+}
+
+// item conforms an item's source range and markers.
+func (c *rateConformer) item(item gotio.Item) {
+	if sr := item.SourceRange(); sr != nil {
+		conformed := c.timeRange(item, "source_range", *sr)
+		item.SetSourceRange(&conformed)
+	}
+	for _, marker := range item.Markers() {
+		marker.SetMarkedRange(c.timeRange(marker, "marked_range", marker.MarkedRange()))
+	}
+}
+
+// transition conforms a transition's offsets.
+func (c *rateConformer) transition(transition *gotio.Transition) {
+	transition.SetInOffset(c.time(transition, "in_offset", transition.InOffset()))
+	transition.SetOutOffset(c.time(transition, "out_offset", transition.OutOffset()))
+}
+
+// mediaReference conforms a media reference's available range.
+func (c *rateConformer) mediaReference(ref gotio.MediaReference) {
+	if ref == nil {
+		return
+	}
+	if ar := ref.AvailableRange(); ar != nil {
+		conformed := c.timeRange(ref, "available_range", *ar)
+		ref.SetAvailableRange(&conformed)
+	}
+}
+
+// time conforms a single time, recording any rounding.
+func (c *rateConformer) time(obj gotio.SerializableObject, field string, t opentime.RationalTime) opentime.RationalTime {
+	exact := c.exact(t)
+	conformed := c.snap(exact)
+	c.record(obj, field, exact, conformed)
+	return conformed
+}
+
+// timeRange conforms a time range. When snapping, the start and end are
+// rounded and the duration is the difference between them.
+func (c *rateConformer) timeRange(obj gotio.SerializableObject, field string, tr opentime.TimeRange) opentime.TimeRange {
+	start := c.exact(tr.StartTime())
+	duration := c.exact(tr.Duration())
+	end := opentime.NewRationalTime(start.Value()+duration.Value(), c.report.Rate)
+
+	conformedStart := c.snap(start)
+	conformedDuration := opentime.NewRationalTime(c.snap(end).Value()-conformedStart.Value(), c.report.Rate)
+
+	c.record(obj, field+".start_time", start, conformedStart)
+	c.record(obj, field+".duration", duration, conformedDuration)
+	return opentime.NewTimeRange(conformedStart, conformedDuration)
+}
+
+// exact rescales t to the new rate. Times without a rate are treated as zero.
+func (c *rateConformer) exact(t opentime.RationalTime) opentime.RationalTime {
+	if t.Rate() <= 0 {
+		return opentime.NewRationalTime(0, c.report.Rate)
+	}
+	return t.RescaledTo(c.report.Rate)
+}
+
+// snap applies the conform policy to a time already at the new rate.
+func (c *rateConformer) snap(t opentime.RationalTime) opentime.RationalTime {
+	if c.report.Policy != ConformNearestFrame {
+		return t
+	}
+	return opentime.NewRationalTime(math.Round(t.Value()), t.Rate())
+}
+
+// record adds an adjustment if rounding moved the time.
+func (c *rateConformer) record(obj gotio.SerializableObject, field string, exact, conformed opentime.RationalTime) {
+	if math.Abs(conformed.Value()-exact.Value()) < 1e-9 {
+		return
+	}
+	c.report.Adjustments = append(c.report.Adjustments, RateAdjustment{
+		Object:    obj,
+		Field:     field,
+		Original:  exact,
+		Conformed: conformed,
+	})
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func TestConformRateExact(t *testing.T) {
+	timeline := gotio.NewTimeline("conform", nil, nil)
+	gst := opentime.NewRationalTime(86400, 24)
+	timeline.SetGlobalStartTime(&gst)
+
+	track := createTestTrack([]float64{24, 48}, 24)
+	ar := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(96, 24))
+	clip := track.Children()[0].(*gotio.Clip)
+	clip.SetMediaReference(gotio.NewExternalReference("", "/media/a.mov", &ar, nil))
+	clip.SetMarkers([]*gotio.Marker{
+		gotio.NewMarker("m", opentime.NewTimeRange(opentime.NewRationalTime(12, 24), opentime.NewRationalTime(0, 24)), gotio.MarkerColorRed, "", nil),
+	})
+	timeline.Tracks().AppendChild(track)
+
+	report, err := ConformRate(timeline, 48, ConformExact)
+	if err != nil {
+		t.Fatalf("ConformRate error: %v", err)
+	}
+	if len(report.Adjustments) != 0 {
+		t.Errorf("expected no adjustments, got %d", len(report.Adjustments))
+	}
+
+	if timeline.GlobalStartTime().Rate() != 48 || timeline.GlobalStartTime().Value() != 172800 {
+		t.Errorf("GlobalStartTime = %v, want 172800@48", timeline.GlobalStartTime())
+	}
+	sr := clip.SourceRange()
+	if sr.Duration().Rate() != 48 || sr.Duration().Value() != 48 {
+		t.Errorf("clip duration = %v, want 48@48", sr.Duration())
+	}
+	if clip.MediaReference().AvailableRange().Duration().Value() != 192 {
+		t.Errorf("available duration = %v, want 192", clip.MediaReference().AvailableRange().Duration())
+	}
+	if clip.Markers()[0].MarkedRange().StartTime().Value() != 24 {
+		t.Errorf("marker start = %v, want 24", clip.Markers()[0].MarkedRange().StartTime())
+	}
+	dur, _ := timeline.Duration()
+	if dur.Rate() != 48 || dur.Value() != 144 {
+		t.Errorf("timeline duration = %v, want 144@48", dur)
+	}
+}
+
+func TestConformRateNearestFrame(t *testing.T) {
+	timeline := gotio.NewTimeline("conform", nil, nil)
+	track := createTestTrack([]float64{10, 10, 10}, 24)
+	timeline.Tracks().AppendChild(track)
+	transition := gotio.NewTransition("", gotio.TransitionTypeSMPTEDissolve,
+		opentime.NewRationalTime(3, 24), opentime.NewRationalTime(3, 24), nil)
+	track.InsertChild(1, transition)
+
+	report, err := ConformRate(timeline, 25, ConformNearestFrame)
+	if err != nil {
+		t.Fatalf("ConformRate error: %v", err)
+	}
+	if report.Policy != ConformNearestFrame || report.Rate != 25 {
+		t.Errorf("unexpected report header %v/%v", report.Policy, report.Rate)
+	}
+
+	// 10@24 is 10.4167@25; every clip snaps to 10 frames
+	for _, child := range track.Children() {
+		item, ok := child.(gotio.Item)
+		if !ok {
+			continue
+		}
+		d := item.SourceRange().Duration()
+		if d.Rate() != 25 || d.Value() != 10 {
+			t.Errorf("%s duration = %v, want 10@25", item.Name(), d)
+		}
+	}
+	if transition.InOffset().Value() != 3 || transition.InOffset().Rate() != 25 {
+		t.Errorf("in offset = %v, want 3@25", transition.InOffset())
+	}
+
+	if len(report.Adjustments) == 0 {
+		t.Fatal("expected rounding adjustments")
+	}
+	adj := report.Adjustments[0]
+	if adj.Field != "source_range.duration" {
+		t.Errorf("first adjustment field = %s, want source_range.duration", adj.Field)
+	}
+	if delta := adj.Delta().Value(); delta > -0.41 || delta < -0.42 {
+		t.Errorf("first adjustment delta = %v, want about -0.4167", delta)
+	}
+}
+
+func TestConformRateInvalid(t *testing.T) {
+	if _, err := ConformRate(nil, 24, ConformExact); err == nil {
+		t.Error("expected error for nil timeline")
+	}
+	if _, err := ConformRate(gotio.NewTimeline("", nil, nil), 0, ConformExact); err == nil {
+		t.Error("expected error for zero rate")
+	}
+	if ConformNearestFrame.String() != "NearestFrame" || ConformPolicy(9).String() != "ConformPolicy(9)" {
+		t.Error("unexpected ConformPolicy strings")
+	}
+}
+
+func TestNormalizeRates(t *testing.T) {
+	track := createTestTrack([]float64{48, 24}, 24)
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(10, 25), opentime.NewRationalTime(25, 25))
+	outlier := gotio.NewClip("pal", nil, &sr, nil, nil, nil, "", nil)
+	track.AppendChild(outlier)
+	track.InsertChild(1, gotio.NewTransition("", gotio.TransitionTypeSMPTEDissolve,
+		opentime.NewRationalTime(6, 30), opentime.NewRationalTime(6, 24), nil))
+
+	report, err := NormalizeRates(track, ConformNearestFrame)
+	if err != nil {
+		t.Fatalf("NormalizeRates error: %v", err)
+	}
+	if report.Rate != 24 {
+		t.Errorf("report rate = %v, want 24", report.Rate)
+	}
+	if rate, ok := track.TaggedRate(); !ok || rate != 24 {
+		t.Errorf("TaggedRate = %v, want 24", rate)
+	}
+	if got := outlier.SourceRange(); got.StartTime().Rate() != 24 || got.StartTime().Value() != 10 || got.Duration().Value() != 24 {
+		t.Errorf("outlier range = %v, want 10 for 24 at 24", got)
+	}
+	transition := track.Children()[1].(*gotio.Transition)
+	if transition.InOffset().Rate() != 24 || transition.InOffset().Value() != 5 {
+		t.Errorf("in offset = %v, want 5@24", transition.InOffset())
+	}
+	if len(report.Adjustments) != 2 {
+		t.Errorf("expected the start at 9.6 and offset at 4.8 frames rounded, got %v", report.Adjustments)
+	}
+	if len(track.RateOutliers()) != 0 {
+		t.Error("expected no outliers after normalizing")
+	}
+
+	if _, err := NormalizeRates(gotio.NewTrack("", nil, gotio.TrackKindVideo, nil, nil), ConformExact); err == nil {
+		t.Error("expected error for a track without rates")
+	}
+}
@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// CrossfadeConfig holds configuration for AddAudioCrossfades.
+type CrossfadeConfig struct {
+	// GaplessOnly limits crossfades to cuts between two clips, leaving
+	// cuts to and from gaps without a fade.
+	GaplessOnly bool
+	// SkipMarker skips cuts next to an item carrying a marker with this
+	// name. Empty skips none.
+	SkipMarker string
+	// TransitionType is the type of the inserted transitions.
+	TransitionType gotio.TransitionType
+	// OverrideLocks adds crossfades at locked cuts too.
+	OverrideLocks bool
+}
+
+// CrossfadeOption is a functional option for AddAudioCrossfades.
+type CrossfadeOption func(*CrossfadeConfig)
+
+// WithCrossfadeGaplessOnly sets whether only cuts between two clips get a
+// crossfade.
+func WithCrossfadeGaplessOnly(gapless bool) CrossfadeOption {
+	return func(c *CrossfadeConfig) {
+		c.GaplessOnly = gapless
+	}
+}
+
+// WithCrossfadeSkipMarker skips cuts next to items with a marker of the
+// given name.
+func WithCrossfadeSkipMarker(name string) CrossfadeOption {
+	return func(c *CrossfadeConfig) {
+		c.SkipMarker = name
+	}
+}
+
+// WithCrossfadeTransitionType sets the type of the inserted transitions.
+func WithCrossfadeTransitionType(transitionType gotio.TransitionType) CrossfadeOption {
+	return func(c *CrossfadeConfig) {
+		c.TransitionType = transitionType
+	}
+}
+
+// WithCrossfadeOverrideLocks sets whether locked cuts get crossfades.
+func WithCrossfadeOverrideLocks(override bool) CrossfadeOption {
+	return func(c *CrossfadeConfig) {
+		c.OverrideLocks = override
+	}
+}
+
+// AddAudioCrossfades inserts a transition of the given duration, centered
+// on the cut, at every cut of the track where a clip meets a gap or a clip
+// of other media. Cuts that continue the same media, cuts that already
+// have a transition, and locked cuts are left alone. Each half of a fade
+// is shortened to the media handles of the clip it extends and to the
+// length of the item it overlaps; cuts with no room for either half are
+// skipped. Returns the number of crossfades inserted.
+func AddAudioCrossfades(track *gotio.Track, duration opentime.RationalTime, opts ...CrossfadeOption) (int, error) {
+	if track == nil {
+		return 0, newEditError("add_audio_crossfades", "track is nil")
+	}
+	if duration.Value() <= 0 {
+		return 0, newEditErrorAt("add_audio_crossfades", "duration must be positive", duration)
+	}
+	if track.Kind() != gotio.TrackKindAudio {
+		return 0, newEditError("add_audio_crossfades", "track is not an audio track")
+	}
+	cfg := CrossfadeConfig{TransitionType: gotio.TransitionTypeSMPTEDissolve}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	half := opentime.NewRationalTime(duration.Value()/2, duration.Rate())
+	added := 0
+	// Walk backwards so inserting does not move the cuts still to visit.
+	for i := len(track.Children()) - 1; i > 0; i-- {
+		children := track.Children()
+		before, ok := children[i-1].(gotio.Item)
+		if !ok {
+			continue
+		}
+		after, ok := children[i].(gotio.Item)
+		if !ok || !wantsCrossfade(before, after, cfg) {
+			continue
+		}
+		cut, err := track.RangeOfChildAtIndex(i)
+		if err != nil {
+			return added, err
+		}
+		if err := checkLocks(track, cut.StartTime(), cut.StartTime(), cfg.OverrideLocks); err != nil {
+			continue
+		}
+
+		// The fade overlaps the end of before by in and the start of after
+		// by out, so after plays ahead of its head by in and before past its
+		// tail by out.
+		in, err := fadeLength(half, before, after, true)
+		if err != nil {
+			return added, err
+		}
+		out, err := fadeLength(half, after, before, false)
+		if err != nil {
+			return added, err
+		}
+		if in.Value() <= 0 && out.Value() <= 0 {
+			continue
+		}
+		transition := gotio.NewTransition("", cfg.TransitionType, in, out, nil)
+		if err := track.InsertChild(i, transition); err != nil {
+			return added, err
+		}
+		added++
+	}
+	return added, nil
+}
+
+// wantsCrossfade reports whether the cut between two adjacent items gets a
+// crossfade.
+func wantsCrossfade(before, after gotio.Item, cfg CrossfadeConfig) bool {
+	beforeClip, beforeIsClip := before.(*gotio.Clip)
+	afterClip, afterIsClip := after.(*gotio.Clip)
+	if !beforeIsClip && !afterIsClip {
+		return false
+	}
+	if cfg.GaplessOnly && !(beforeIsClip && afterIsClip) {
+		return false
+	}
+	if cfg.SkipMarker != "" && (hasMarkerNamed(before, cfg.SkipMarker) || hasMarkerNamed(after, cfg.SkipMarker)) {
+		return false
+	}
+	if beforeIsClip && afterIsClip && isThroughEdit(beforeClip, afterClip) {
+		return false
+	}
+	return true
+}
+
+// isThroughEdit reports whether after continues the media of before
+// without a jump.
+func isThroughEdit(before, after *gotio.Clip) bool {
+	beforeURL, ok := mediaTarget(before.MediaReference())
+	if !ok {
+		return false
+	}
+	if afterURL, ok := mediaTarget(after.MediaReference()); !ok || afterURL != beforeURL {
+		return false
+	}
+	beforeRange, err := before.TrimmedRange()
+	if err != nil {
+		return false
+	}
+	afterRange, err := after.TrimmedRange()
+	if err != nil {
+		return false
+	}
+	return afterRange.StartTime().Equal(beforeRange.EndTimeExclusive())
+}
+
+// mediaTarget returns the URL of a clip's media, and false if it has none.
+func mediaTarget(ref gotio.MediaReference) (string, bool) {
+	switch r := ref.(type) {
+	case *gotio.ExternalReference:
+		return r.TargetURL(), r.TargetURL() != ""
+	case *gotio.ImageSequenceReference:
+		return r.TargetURLBase(), r.TargetURLBase() != ""
+	}
+	return "", false
+}
+
+// hasMarkerNamed reports whether the item carries a marker with the name.
+func hasMarkerNamed(item gotio.Item, name string) bool {
+	for _, marker := range item.Markers() {
+		if marker.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fadeLength returns how much of half the fade can overlap item: no more
+// than its duration, nor than the handle of the clip extended into it,
+// which is at that clip's head if head is set and its tail otherwise.
+func fadeLength(half opentime.RationalTime, item, extended gotio.Item, head bool) (opentime.RationalTime, error) {
+	length := half
+	duration, err := item.Duration()
+	if err != nil {
+		return length, err
+	}
+	if duration.Cmp(length) < 0 {
+		length = duration.RescaledTo(half.Rate())
+	}
+	clip, ok := extended.(*gotio.Clip)
+	if !ok {
+		return length, nil
+	}
+	available, err := clip.AvailableRange()
+	if err != nil {
+		return length, nil
+	}
+	trimmed, err := clip.TrimmedRange()
+	if err != nil {
+		return length, err
+	}
+	handle := available.EndTimeExclusive().Sub(trimmed.EndTimeExclusive())
+	if head {
+		handle = trimmed.StartTime().Sub(available.StartTime())
+	}
+	if handle.Value() < 0 {
+		handle = opentime.NewRationalTime(0, handle.Rate())
+	}
+	if handle.Cmp(length) < 0 {
+		length = handle.RescaledTo(half.Rate())
+	}
+	return length, nil
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func TestAddAudioCrossfades(t *testing.T) {
+	available := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(100, 24))
+	newClip := func(name, url string, start float64) *gotio.Clip {
+		sr := opentime.NewTimeRange(opentime.NewRationalTime(start, 24), opentime.NewRationalTime(40, 24))
+		return gotio.NewClip(name, gotio.NewExternalReference("", url, &available, nil), &sr, nil, nil, nil, "", nil)
+	}
+	newTrack := func() *gotio.Track {
+		marked := newClip("d", "/media/d.wav", 10)
+		marked.SetMarkers([]*gotio.Marker{gotio.NewMarker("no_crossfade", opentime.TimeRange{}, gotio.MarkerColorRed, "", nil)})
+		track := gotio.NewTrack("A1", nil, gotio.TrackKindAudio, nil, nil)
+		track.AppendChild(newClip("a", "/media/a.wav", 10))
+		track.AppendChild(newClip("b", "/media/b.wav", 0))
+		track.AppendChild(newClip("c", "/media/b.wav", 40))
+		track.AppendChild(gotio.NewGapWithDuration(opentime.NewRationalTime(24, 24)))
+		track.AppendChild(marked)
+		track.AppendChild(newClip("e", "/media/e.wav", 10))
+		return track
+	}
+	fade := opentime.NewRationalTime(8, 24)
+
+	track := newTrack()
+	n, err := AddAudioCrossfades(track, fade, WithCrossfadeSkipMarker("no_crossfade"))
+	if err != nil || n != 2 {
+		t.Fatalf("AddAudioCrossfades = %d, %v; want 2", n, err)
+	}
+	children := track.Children()
+	// b has no media before its head, so its fade only overlaps it.
+	if tr, ok := children[1].(*gotio.Transition); !ok || tr.InOffset().Value() != 0 || tr.OutOffset().Value() != 4 {
+		t.Errorf("a|b transition = %v", children[1])
+	}
+	if _, ok := children[3].(*gotio.Transition); ok {
+		t.Error("through edit b|c should not get a crossfade")
+	}
+	if tr, ok := children[4].(*gotio.Transition); !ok || tr.InOffset().Value() != 4 || tr.OutOffset().Value() != 4 {
+		t.Errorf("c|gap transition = %v", children[4])
+	}
+	if len(children) != 8 {
+		t.Errorf("expected 8 children, got %d", len(children))
+	}
+
+	// Running again finds every cut already faded.
+	if n, err := AddAudioCrossfades(track, fade, WithCrossfadeSkipMarker("no_crossfade")); err != nil || n != 0 {
+		t.Errorf("second pass = %d, %v; want 0", n, err)
+	}
+
+	track = newTrack()
+	if n, err := AddAudioCrossfades(track, fade, WithCrossfadeGaplessOnly(true)); err != nil || n != 2 {
+		t.Errorf("gapless only = %d, %v; want a|b and d|e", n, err)
+	}
+
+	video := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	if _, err := AddAudioCrossfades(video, fade); err == nil {
+		t.Error("expected error for a video track")
+	}
+	if _, err := AddAudioCrossfades(newTrack(), opentime.NewRationalTime(0, 24)); err == nil {
+		t.Error("expected error for a zero duration")
+	}
+}
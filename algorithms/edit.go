@@ -66,6 +66,7 @@ var (
 	ErrInvalidTime         = &EditError{Message: "time is outside composition bounds"}
 	ErrInvalidRange        = &EditError{Message: "range is outside composition bounds"}
 	ErrNegativeDuration    = &EditError{Message: "operation would result in negative duration"}
+	ErrLocked              = &EditError{Message: "edit touches a locked track or region"}
 )
 
 // newEditError creates a new EditError for a specific operation.
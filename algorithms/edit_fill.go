@@ -21,12 +21,16 @@ import (
 //   - composition: The composition to modify (usually a Track)
 //   - trackTime: Time in the track where the gap exists
 //   - referencePoint: How to fit the clip to the gap
+//   - opts: Optional configuration (override locks)
 func Fill(
 	item gotio.Item,
 	composition gotio.Composition,
 	trackTime opentime.RationalTime,
 	referencePoint ReferencePoint,
+	opts ...EditOption,
 ) error {
+	config := newEditConfig(opts)
+
 	// Find the item at trackTime
 	gapItem, gapIndex, gapRange, err := itemAtTime(composition, trackTime)
 	if err != nil {
@@ -43,6 +47,10 @@ func Fill(
 		return newEditError("fill", "item at time is not a gap")
 	}
 
+	if err := checkLocks(composition, gapRange.StartTime(), gapRange.EndTimeExclusive(), config.OverrideLocks); err != nil {
+		return err
+	}
+
 	// Get gap duration
 	gapDuration, err := gap.Duration()
 	if err != nil {
@@ -68,7 +76,7 @@ func Fill(
 	case ReferencePointSource:
 		// Use clip's natural duration - perform an overwrite
 		overwriteRange := opentime.NewTimeRange(gapRange.StartTime(), clipRange.Duration())
-		return Overwrite(clonedItem, composition, overwriteRange)
+		return Overwrite(clonedItem, composition, overwriteRange, WithOverrideLocks(config.OverrideLocks))
 
 	case ReferencePointSequence:
 		// Trim clip to fit gap exactly
@@ -12,6 +12,7 @@ import (
 type InsertConfig struct {
 	RemoveTransitions bool
 	FillTemplate      gotio.Item
+	OverrideLocks     bool
 }
 
 // InsertOption is a functional option for Insert.
@@ -31,6 +32,14 @@ func WithInsertFillTemplate(template gotio.Item) InsertOption {
 	}
 }
 
+// WithInsertOverrideLocks sets whether to insert into locked tracks and
+// before locked regions, which the insert would move.
+func WithInsertOverrideLocks(override bool) InsertOption {
+	return func(c *InsertConfig) {
+		c.OverrideLocks = override
+	}
+}
+
 // Insert inserts an item at a specific time, growing the composition.
 // The composition is modified in place.
 //
@@ -58,6 +67,10 @@ func Insert(
 		opt(config)
 	}
 
+	if err := checkLocksToEnd(composition, time, config.OverrideLocks); err != nil {
+		return err
+	}
+
 	// Clone the item
 	clonedItem := item.Clone().(gotio.Item)
 
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// EditConfig holds configuration for the edits without options of their
+// own: Fill, Ripple, Slide, Slip, AddHandles and TrimHandles.
+type EditConfig struct {
+	OverrideLocks bool
+}
+
+// EditOption is a functional option for Fill, Ripple, Slide, Slip,
+// AddHandles and TrimHandles.
+type EditOption func(*EditConfig)
+
+// WithEditOverrideLocks sets whether to edit locked tracks and regions.
+func WithEditOverrideLocks(override bool) EditOption {
+	return func(c *EditConfig) {
+		c.OverrideLocks = override
+	}
+}
+
+func newEditConfig(opts []EditOption) *EditConfig {
+	config := &EditConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// checkLocks returns ErrLocked if changing the span from start to end of
+// composition, in its own time, touches a locked track or region. Only
+// tracks carry locks. A span with no duration is a cut point.
+func checkLocks(composition gotio.Composition, start, end opentime.RationalTime, override bool) error {
+	track, ok := composition.(*gotio.Track)
+	if override || !ok {
+		return nil
+	}
+	if end.Cmp(start) < 0 {
+		start, end = end, start
+	}
+	if track.IsRangeLocked(opentime.RangeFromStartEndTime(start, end)) {
+		return ErrLocked
+	}
+	return nil
+}
+
+// checkLocksToEnd checks the span from start to the end of composition,
+// for edits that move everything after start.
+func checkLocksToEnd(composition gotio.Composition, start opentime.RationalTime, override bool) error {
+	if override {
+		return nil
+	}
+	end, err := compositionDuration(composition)
+	if err != nil {
+		return err
+	}
+	if end.Rate() <= 0 || end.Cmp(start) < 0 {
+		end = start
+	}
+	return checkLocks(composition, start, end, override)
+}
+
+// checkItemLocks checks the span of item in composition, moved at its head
+// by deltaIn and at its tail by deltaOut, or from its head to the end of
+// composition when ripple is set.
+func checkItemLocks(
+	item gotio.Item,
+	composition gotio.Composition,
+	deltaIn, deltaOut opentime.RationalTime,
+	ripple, override bool,
+) error {
+	if override || composition == nil {
+		return nil
+	}
+	if _, ok := composition.(*gotio.Track); !ok {
+		return nil
+	}
+	index, err := composition.IndexOfChild(item)
+	if err != nil {
+		return nil
+	}
+	r, err := composition.RangeOfChildAtIndex(index)
+	if err != nil {
+		return err
+	}
+	start := r.StartTime()
+	if deltaIn.Rate() > 0 {
+		start = minRationalTime(start, start.Add(deltaIn))
+	}
+	if ripple {
+		return checkLocksToEnd(composition, start, override)
+	}
+	end := r.EndTimeExclusive()
+	if deltaOut.Rate() > 0 {
+		end = maxRationalTime(end, end.Add(deltaOut))
+	}
+	return checkLocks(composition, start, end, override)
+}
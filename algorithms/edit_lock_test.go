@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func TestEditsRespectLockRegions(t *testing.T) {
+	rt := func(v float64) opentime.RationalTime { return opentime.NewRationalTime(v, 24) }
+	tr := func(start, duration float64) opentime.TimeRange {
+		return opentime.NewTimeRange(rt(start), rt(duration))
+	}
+	newClip := func() gotio.Item {
+		sr := tr(0, 12)
+		return gotio.NewClip("X", nil, &sr, nil, nil, nil, "", nil)
+	}
+
+	// Track: [A:24][B:24][C:24] with B locked.
+	tests := []struct {
+		name   string
+		locked bool
+		edit   func(track *gotio.Track, override bool) error
+	}{
+		{"overwrite before", false, func(track *gotio.Track, o bool) error {
+			return Overwrite(newClip(), track, tr(0, 12), WithOverrideLocks(o))
+		}},
+		{"overwrite across", true, func(track *gotio.Track, o bool) error {
+			return Overwrite(newClip(), track, tr(20, 10), WithOverrideLocks(o))
+		}},
+		{"insert before", true, func(track *gotio.Track, o bool) error {
+			return Insert(newClip(), track, rt(12), WithInsertOverrideLocks(o))
+		}},
+		{"insert after", false, func(track *gotio.Track, o bool) error {
+			return Insert(newClip(), track, rt(48), WithInsertOverrideLocks(o))
+		}},
+		{"slice inside", true, func(track *gotio.Track, o bool) error {
+			return Slice(track, rt(36), WithSliceOverrideLocks(o))
+		}},
+		{"slice outside", false, func(track *gotio.Track, o bool) error {
+			return Slice(track, rt(12), WithSliceOverrideLocks(o))
+		}},
+		{"remove with fill", false, func(track *gotio.Track, o bool) error {
+			return Remove(track, rt(0), WithRemoveOverrideLocks(o))
+		}},
+		{"remove without fill", true, func(track *gotio.Track, o bool) error {
+			return Remove(track, rt(0), WithFill(false), WithRemoveOverrideLocks(o))
+		}},
+		{"remove range", true, func(track *gotio.Track, o bool) error {
+			return RemoveRange(track, tr(40, 12), WithRemoveOverrideLocks(o))
+		}},
+		{"trim into", true, func(track *gotio.Track, o bool) error {
+			return Trim(track.Children()[0].(gotio.Item), track, rt(0), rt(6), WithTrimOverrideLocks(o))
+		}},
+		{"roll", true, func(track *gotio.Track, o bool) error {
+			return Roll(track.Children()[2].(gotio.Item), track, rt(-6), rt(0), WithRollOverrideLocks(o))
+		}},
+		{"slip locked", true, func(track *gotio.Track, o bool) error {
+			return Slip(track.Children()[1].(gotio.Item), rt(2), WithEditOverrideLocks(o))
+		}},
+		{"slip unlocked", false, func(track *gotio.Track, o bool) error {
+			return Slip(track.Children()[2].(gotio.Item), rt(2), WithEditOverrideLocks(o))
+		}},
+		{"ripple before", true, func(track *gotio.Track, o bool) error {
+			return Ripple(track.Children()[0].(gotio.Item), rt(0), rt(-6), WithEditOverrideLocks(o))
+		}},
+		{"ripple after", false, func(track *gotio.Track, o bool) error {
+			return Ripple(track.Children()[2].(gotio.Item), rt(0), rt(-6), WithEditOverrideLocks(o))
+		}},
+		{"slide into", true, func(track *gotio.Track, o bool) error {
+			return Slide(track.Children()[2].(gotio.Item), track, rt(-6), WithEditOverrideLocks(o))
+		}},
+		{"slide away", false, func(track *gotio.Track, o bool) error {
+			return Slide(track.Children()[2].(gotio.Item), track, rt(6), WithEditOverrideLocks(o))
+		}},
+	}
+	for _, tt := range tests {
+		track := createTestTrack([]float64{24, 24, 24}, 24)
+		track.AddLockRegion(gotio.LockRegion{Range: tr(24, 24), Reason: "approved"})
+		err := tt.edit(track, false)
+		if tt.locked && err != ErrLocked {
+			t.Errorf("%s: expected ErrLocked, got %v", tt.name, err)
+		}
+		if !tt.locked && err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+
+		track = createTestTrack([]float64{24, 24, 24}, 24)
+		track.AddLockRegion(gotio.LockRegion{Range: tr(24, 24)})
+		if err := tt.edit(track, true); err != nil {
+			t.Errorf("%s: override failed: %v", tt.name, err)
+		}
+	}
+}
+
+func TestEditsRespectLockedTrack(t *testing.T) {
+	track := createTestTrack([]float64{24, 24}, 24)
+	track.SetLocked(true)
+	before := len(track.Children())
+	if err := Slice(track, opentime.NewRationalTime(12, 24)); err != ErrLocked {
+		t.Errorf("expected ErrLocked, got %v", err)
+	}
+	if len(track.Children()) != before {
+		t.Error("locked track was modified")
+	}
+
+	// Stacks carry no locks.
+	stack := gotio.NewStack("stack", nil, nil, nil, nil, nil)
+	stack.AppendChild(createTestTrack([]float64{24}, 24))
+	if err := checkLocks(stack, opentime.NewRationalTime(0, 24), opentime.NewRationalTime(24, 24), false); err != nil {
+		t.Errorf("unexpected error for a stack: %v", err)
+	}
+}
@@ -12,6 +12,7 @@ import (
 type OverwriteConfig struct {
 	RemoveTransitions bool
 	FillTemplate      gotio.Item
+	OverrideLocks     bool
 }
 
 // OverwriteOption is a functional option for Overwrite.
@@ -31,6 +32,13 @@ func WithFillTemplate(template gotio.Item) OverwriteOption {
 	}
 }
 
+// WithOverrideLocks sets whether to overwrite locked tracks and regions.
+func WithOverrideLocks(override bool) OverwriteOption {
+	return func(c *OverwriteConfig) {
+		c.OverrideLocks = override
+	}
+}
+
 // Overwrite replaces content in a time range with a new item.
 // The composition is modified in place.
 //
@@ -43,7 +51,7 @@ func WithFillTemplate(template gotio.Item) OverwriteOption {
 //   - item: The item to insert (will be cloned)
 //   - composition: The composition to modify (usually a Track)
 //   - timeRange: The time range to overwrite
-//   - opts: Optional configuration (remove transitions, fill template)
+//   - opts: Optional configuration (remove transitions, fill template, override locks)
 func Overwrite(
 	item gotio.Item,
 	composition gotio.Composition,
@@ -58,6 +66,10 @@ func Overwrite(
 		opt(config)
 	}
 
+	if err := checkLocks(composition, timeRange.StartTime(), timeRange.EndTimeExclusive(), config.OverrideLocks); err != nil {
+		return err
+	}
+
 	// Clone the item to avoid modifying the original
 	clonedItem := item.Clone().(gotio.Item)
 
@@ -10,8 +10,9 @@ import (
 
 // RemoveConfig holds configuration for the Remove operation.
 type RemoveConfig struct {
-	Fill         bool
-	FillTemplate gotio.Item
+	Fill          bool
+	FillTemplate  gotio.Item
+	OverrideLocks bool
 }
 
 // RemoveOption is a functional option for Remove.
@@ -31,6 +32,14 @@ func WithRemoveFillTemplate(template gotio.Item) RemoveOption {
 	}
 }
 
+// WithRemoveOverrideLocks sets whether to remove from locked tracks and
+// regions, including regions a removal without fill would move.
+func WithRemoveOverrideLocks(override bool) RemoveOption {
+	return func(c *RemoveConfig) {
+		c.OverrideLocks = override
+	}
+}
+
 // Remove removes an item at a specific time and optionally fills the space.
 // The composition is modified in place.
 //
@@ -41,7 +50,7 @@ func WithRemoveFillTemplate(template gotio.Item) RemoveOption {
 // Parameters:
 //   - composition: The composition to modify (usually a Track)
 //   - time: Time where the item to remove exists
-//   - opts: Optional configuration (fill, template)
+//   - opts: Optional configuration (fill, template, override locks)
 func Remove(
 	composition gotio.Composition,
 	time opentime.RationalTime,
@@ -56,7 +65,7 @@ func Remove(
 	}
 
 	// Find the item at time
-	item, itemIndex, _, err := itemAtTime(composition, time)
+	item, itemIndex, itemRange, err := itemAtTime(composition, time)
 	if err != nil {
 		return err
 	}
@@ -65,6 +74,15 @@ func Remove(
 		return newEditErrorAt("remove", "no item at time", time)
 	}
 
+	if config.Fill {
+		err = checkLocks(composition, itemRange.StartTime(), itemRange.EndTimeExclusive(), config.OverrideLocks)
+	} else {
+		err = checkLocksToEnd(composition, itemRange.StartTime(), config.OverrideLocks)
+	}
+	if err != nil {
+		return err
+	}
+
 	// Get item's duration for filling
 	itemDuration, err := item.Duration()
 	if err != nil {
@@ -97,7 +115,7 @@ func Remove(
 // Parameters:
 //   - composition: The composition to modify (usually a Track)
 //   - timeRange: Range of items to remove
-//   - opts: Optional configuration (fill, template)
+//   - opts: Optional configuration (fill, template, override locks)
 func RemoveRange(
 	composition gotio.Composition,
 	timeRange opentime.TimeRange,
@@ -121,6 +139,15 @@ func RemoveRange(
 		return nil
 	}
 
+	if config.Fill {
+		err = checkLocks(composition, timeRange.StartTime(), timeRange.EndTimeExclusive(), config.OverrideLocks)
+	} else {
+		err = checkLocksToEnd(composition, timeRange.StartTime(), config.OverrideLocks)
+	}
+	if err != nil {
+		return err
+	}
+
 	rangeStart := timeRange.StartTime()
 	rangeEnd := timeRange.EndTimeExclusive()
 	firstRange := ranges[0]
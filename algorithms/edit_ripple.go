@@ -22,15 +22,22 @@ import (
 //   - item: The item to adjust
 //   - deltaIn: Adjustment to source_range start
 //   - deltaOut: Adjustment to source_range end (duration change)
+//   - opts: Optional configuration (override locks)
 func Ripple(
 	item gotio.Item,
 	deltaIn opentime.RationalTime,
 	deltaOut opentime.RationalTime,
+	opts ...EditOption,
 ) error {
 	if deltaIn.Value() == 0 && deltaOut.Value() == 0 {
 		return nil
 	}
 
+	config := newEditConfig(opts)
+	if err := checkItemLocks(item, item.Parent(), deltaIn, deltaOut, true, config.OverrideLocks); err != nil {
+		return err
+	}
+
 	// Get current source range
 	sourceRange, err := itemSourceRange(item)
 	if err != nil {
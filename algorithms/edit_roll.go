@@ -8,6 +8,33 @@ import (
 	"github.com/Avalanche-io/gotio"
 )
 
+// RollConfig holds configuration for the Roll operation.
+type RollConfig struct {
+	PreserveTransitions bool
+	OverrideLocks       bool
+}
+
+// RollOption is a functional option for Roll.
+type RollOption func(*RollConfig)
+
+// WithRollPreserveTransitions sets whether to keep transitions at the rolled
+// edit points. When enabled, the item across a transition is adjusted and the
+// transition offsets are shrunk to fit; a transition is only removed when its
+// offsets can no longer be satisfied.
+func WithRollPreserveTransitions(preserve bool) RollOption {
+	return func(c *RollConfig) {
+		c.PreserveTransitions = preserve
+	}
+}
+
+// WithRollOverrideLocks sets whether to roll edit points in locked tracks
+// and regions.
+func WithRollOverrideLocks(override bool) RollOption {
+	return func(c *RollConfig) {
+		c.OverrideLocks = override
+	}
+}
+
 // Roll moves an edit point, adjusting both adjacent items.
 // All affected items are modified in place.
 //
@@ -21,12 +48,20 @@ import (
 //   - composition: The composition containing the item
 //   - deltaIn: Amount to roll the in-point (positive = roll right)
 //   - deltaOut: Amount to roll the out-point (positive = roll right)
+//   - opts: Optional configuration
 func Roll(
 	item gotio.Item,
 	composition gotio.Composition,
 	deltaIn opentime.RationalTime,
 	deltaOut opentime.RationalTime,
+	opts ...RollOption,
 ) error {
+	// Apply options
+	config := &RollConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
 	if deltaIn.Value() == 0 && deltaOut.Value() == 0 {
 		return nil
 	}
@@ -37,6 +72,10 @@ func Roll(
 		return newEditErrorForItem("roll", "item not in composition", item)
 	}
 
+	if err := checkItemLocks(item, composition, deltaIn, deltaOut, false, config.OverrideLocks); err != nil {
+		return err
+	}
+
 	// Get current source range
 	sourceRange, err := itemSourceRange(item)
 	if err != nil {
@@ -45,7 +84,7 @@ func Roll(
 
 	// Handle deltaIn (roll in-point with previous item)
 	if deltaIn.Value() != 0 {
-		if err := rollInPoint(item, composition, itemIndex, sourceRange, deltaIn); err != nil {
+		if err := rollInPoint(item, composition, itemIndex, sourceRange, deltaIn, config); err != nil {
 			return err
 		}
 		// Update source range for deltaOut processing
@@ -56,11 +95,20 @@ func Roll(
 
 	// Handle deltaOut (roll out-point with next item)
 	if deltaOut.Value() != 0 {
-		if err := rollOutPoint(item, composition, itemIndex, sourceRange, deltaOut); err != nil {
+		if err := rollOutPoint(item, composition, itemIndex, sourceRange, deltaOut, config); err != nil {
 			return err
 		}
 	}
 
+	if config.PreserveTransitions {
+		return fitTransitionsAround(
+			composition,
+			item,
+			getPreviousItemAcrossTransition(composition, itemIndex),
+			getNextItemAcrossTransition(composition, itemIndex),
+		)
+	}
+
 	return nil
 }
 
@@ -71,8 +119,12 @@ func rollInPoint(
 	itemIndex int,
 	sourceRange opentime.TimeRange,
 	deltaIn opentime.RationalTime,
+	config *RollConfig,
 ) error {
 	prevItem := getPreviousItem(composition, itemIndex)
+	if config.PreserveTransitions {
+		prevItem = getPreviousItemAcrossTransition(composition, itemIndex)
+	}
 	if prevItem == nil {
 		// No previous item - can only roll if we're trimming head (positive delta)
 		if deltaIn.Value() > 0 {
@@ -149,8 +201,12 @@ func rollOutPoint(
 	itemIndex int,
 	sourceRange opentime.TimeRange,
 	deltaOut opentime.RationalTime,
+	config *RollConfig,
 ) error {
 	nextItem := getNextItem(composition, itemIndex)
+	if config.PreserveTransitions {
+		nextItem = getNextItemAcrossTransition(composition, itemIndex)
+	}
 	if nextItem == nil {
 		// No next item - can only roll if we're extending tail (positive delta)
 		if deltaOut.Value() > 0 {
@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"slices"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// Selection is a contiguous run of items in a composition, edited as a
+// unit by TrimSelection, SlideSelection, SlipSelection and RollSelection.
+// Transitions between the items belong to the selection.
+type Selection struct {
+	composition gotio.Composition
+	items       []gotio.Item
+}
+
+// NewSelection returns the selection of items in composition. The items
+// may be given in any order, but must be children of composition with
+// nothing but transitions between them.
+func NewSelection(composition gotio.Composition, items ...gotio.Item) (Selection, error) {
+	if len(items) == 0 {
+		return Selection{}, newEditError("select", "no items selected")
+	}
+	var indices []int
+	for _, item := range items {
+		index, err := composition.IndexOfChild(item)
+		if err != nil {
+			return Selection{}, newEditErrorForItem("select", "item not in composition", item)
+		}
+		indices = append(indices, index)
+	}
+	slices.Sort(indices)
+	indices = slices.Compact(indices)
+
+	children := composition.Children()
+	sel := Selection{composition: composition}
+	for i := indices[0]; i <= indices[len(indices)-1]; i++ {
+		item, ok := children[i].(gotio.Item)
+		if !ok {
+			continue
+		}
+		if _, found := slices.BinarySearch(indices, i); !found {
+			return Selection{}, newEditErrorForItem("select", "selection is not contiguous", item)
+		}
+		sel.items = append(sel.items, item)
+	}
+	return sel, nil
+}
+
+// SelectRange returns the selection of the items of composition that
+// overlap timeRange, in the composition's time.
+func SelectRange(composition gotio.Composition, timeRange opentime.TimeRange) (Selection, error) {
+	items, _, _, err := itemsInRange(composition, timeRange)
+	if err != nil {
+		return Selection{}, err
+	}
+	if len(items) == 0 {
+		return Selection{}, newEditErrorAt("select", "no items in range", timeRange.StartTime())
+	}
+	return Selection{composition: composition, items: items}, nil
+}
+
+// Composition returns the composition holding the selection.
+func (s Selection) Composition() gotio.Composition {
+	return s.composition
+}
+
+// Items returns the selected items in composition order.
+func (s Selection) Items() []gotio.Item {
+	return slices.Clone(s.items)
+}
+
+// First returns the first selected item, or nil for an empty selection.
+func (s Selection) First() gotio.Item {
+	if len(s.items) == 0 {
+		return nil
+	}
+	return s.items[0]
+}
+
+// Last returns the last selected item, or nil for an empty selection.
+func (s Selection) Last() gotio.Item {
+	if len(s.items) == 0 {
+		return nil
+	}
+	return s.items[len(s.items)-1]
+}
+
+// TrimSelection trims the selection as one item: deltaIn trims the head
+// of the first item and deltaOut the tail of the last, with the items
+// before and after compensating as in Trim. Items inside the selection
+// are unchanged.
+func TrimSelection(
+	sel Selection,
+	deltaIn opentime.RationalTime,
+	deltaOut opentime.RationalTime,
+	opts ...TrimOption,
+) error {
+	first, last := sel.First(), sel.Last()
+	if first == nil {
+		return newEditError("trim", "empty selection")
+	}
+	if first == last {
+		return Trim(first, sel.composition, deltaIn, deltaOut, opts...)
+	}
+
+	config := &TrimConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	var none opentime.RationalTime
+	if err := checkItemLocks(first, sel.composition, deltaIn, none, false, config.OverrideLocks); err != nil {
+		return err
+	}
+	if err := checkItemLocks(last, sel.composition, none, deltaOut, false, config.OverrideLocks); err != nil {
+		return err
+	}
+
+	// Check the tail before the head is trimmed, so an edit that would
+	// fail leaves the selection unchanged
+	if deltaOut.Value() != 0 {
+		lastRange, err := itemSourceRange(last)
+		if err != nil {
+			return err
+		}
+		if lastRange.Duration().Add(deltaOut).Value() <= 0 {
+			return ErrNegativeDuration
+		}
+	}
+
+	if err := Trim(first, sel.composition, deltaIn, none, opts...); err != nil {
+		return err
+	}
+	return Trim(last, sel.composition, none, deltaOut, opts...)
+}
+
+// SlideSelection moves the selection as one item by adjusting the
+// duration of the item before it, as Slide does; the selection and what
+// follows move together.
+func SlideSelection(sel Selection, delta opentime.RationalTime, opts ...EditOption) error {
+	first := sel.First()
+	if first == nil {
+		return newEditError("slide", "empty selection")
+	}
+	return Slide(first, sel.composition, delta, opts...)
+}
+
+// SlipSelection slips every selected item but gaps by the same delta, so
+// the selection shows later or earlier source media as a unit. The delta
+// is clamped to what the available range of every item allows, keeping
+// the items in sync.
+func SlipSelection(sel Selection, delta opentime.RationalTime, opts ...EditOption) error {
+	if len(sel.items) == 0 {
+		return newEditError("slip", "empty selection")
+	}
+	if delta.Value() == 0 {
+		return nil
+	}
+
+	config := newEditConfig(opts)
+	var none opentime.RationalTime
+	var items []gotio.Item
+	var ranges []opentime.TimeRange
+	for _, item := range sel.items {
+		if _, ok := item.(*gotio.Gap); ok {
+			continue
+		}
+		if err := checkItemLocks(item, sel.composition, none, none, false, config.OverrideLocks); err != nil {
+			return err
+		}
+		sourceRange, err := itemSourceRange(item)
+		if err != nil {
+			return err
+		}
+		items = append(items, item)
+		ranges = append(ranges, sourceRange)
+	}
+
+	// Clamp the delta to the media of every item, stopping short of
+	// turning it around for an item already outside its media
+	requested := delta
+	for i, item := range items {
+		available, err := item.AvailableRange()
+		if err != nil {
+			continue
+		}
+		if delta.Value() < 0 {
+			delta = maxRationalTime(delta, available.StartTime().Sub(ranges[i].StartTime()))
+		} else {
+			delta = minRationalTime(delta, available.EndTimeExclusive().Sub(ranges[i].EndTimeExclusive()))
+		}
+	}
+	if delta.Value()*requested.Value() <= 0 {
+		return nil
+	}
+
+	for i, item := range items {
+		newRange := opentime.NewTimeRange(ranges[i].StartTime().Add(delta), ranges[i].Duration())
+		item.SetSourceRange(&newRange)
+	}
+	return nil
+}
+
+// RollSelection rolls the edit points at the edges of the selection:
+// deltaIn the one before the first item and deltaOut the one after the
+// last, as Roll does. Items inside the selection are unchanged.
+func RollSelection(
+	sel Selection,
+	deltaIn opentime.RationalTime,
+	deltaOut opentime.RationalTime,
+	opts ...RollOption,
+) error {
+	first, last := sel.First(), sel.Last()
+	if first == nil {
+		return newEditError("roll", "empty selection")
+	}
+	if first == last {
+		return Roll(first, sel.composition, deltaIn, deltaOut, opts...)
+	}
+
+	config := &RollConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	var none opentime.RationalTime
+	if err := checkItemLocks(first, sel.composition, deltaIn, none, false, config.OverrideLocks); err != nil {
+		return err
+	}
+	if err := checkItemLocks(last, sel.composition, none, deltaOut, false, config.OverrideLocks); err != nil {
+		return err
+	}
+
+	// The out-point roll clamps rather than fails, so roll the in-point
+	// first
+	if err := Roll(first, sel.composition, deltaIn, none, opts...); err != nil {
+		return err
+	}
+	return Roll(last, sel.composition, none, deltaOut, opts...)
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// sourceRanges returns the source start and duration, in frames, of each
+// item of track.
+func sourceRanges(t *testing.T, track *gotio.Track) [][2]float64 {
+	t.Helper()
+	var got [][2]float64
+	for _, child := range track.Children() {
+		sr, err := itemSourceRange(child.(gotio.Item))
+		if err != nil {
+			t.Fatalf("itemSourceRange error: %v", err)
+		}
+		got = append(got, [2]float64{sr.StartTime().Value(), sr.Duration().Value()})
+	}
+	return got
+}
+
+func TestNewSelection(t *testing.T) {
+	track := createTestTrack([]float64{24, 24, 24, 24}, 24)
+	children := track.Children()
+	a, b, c := children[0].(gotio.Item), children[1].(gotio.Item), children[2].(gotio.Item)
+
+	sel, err := NewSelection(track, c, b)
+	if err != nil {
+		t.Fatalf("NewSelection error: %v", err)
+	}
+	if sel.First() != b || sel.Last() != c || len(sel.Items()) != 2 {
+		t.Errorf("selection = %v, want B and C in order", sel.Items())
+	}
+	if _, err := NewSelection(track, a, c); err == nil {
+		t.Error("expected an error for a selection with a hole")
+	}
+	if _, err := NewSelection(track); err == nil {
+		t.Error("expected an error for an empty selection")
+	}
+
+	sel, err = SelectRange(track, opentime.NewTimeRange(opentime.NewRationalTime(30, 24), opentime.NewRationalTime(30, 24)))
+	if err != nil {
+		t.Fatalf("SelectRange error: %v", err)
+	}
+	if sel.First() != b || sel.Last() != c {
+		t.Errorf("SelectRange = %v, want B and C", sel.Items())
+	}
+}
+
+func TestSelectionEdits(t *testing.T) {
+	rt := func(v float64) opentime.RationalTime { return opentime.NewRationalTime(v, 24) }
+	selectBC := func(track *gotio.Track) Selection {
+		sel, err := NewSelection(track, track.Children()[1].(gotio.Item), track.Children()[2].(gotio.Item))
+		if err != nil {
+			t.Fatalf("NewSelection error: %v", err)
+		}
+		return sel
+	}
+	check := func(name string, track *gotio.Track, want [][2]float64) {
+		t.Helper()
+		got := sourceRanges(t, track)
+		if len(got) != len(want) {
+			t.Fatalf("%s: ranges = %v, want %v", name, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: ranges = %v, want %v", name, got, want)
+				return
+			}
+		}
+	}
+
+	track := createTestTrackWithAvailableRange([]float64{24, 24, 24, 24}, 48, 24)
+	if err := TrimSelection(selectBC(track), rt(6), rt(6)); err != nil {
+		t.Fatalf("TrimSelection error: %v", err)
+	}
+	check("trim", track, [][2]float64{{0, 30}, {6, 18}, {0, 30}, {6, 18}})
+	if err := TrimSelection(selectBC(track), rt(0), rt(-30)); err != ErrNegativeDuration {
+		t.Errorf("TrimSelection past the tail = %v, want ErrNegativeDuration", err)
+	}
+
+	track = createTestTrackWithAvailableRange([]float64{24, 24, 24, 24}, 48, 24)
+	if err := RollSelection(selectBC(track), rt(6), rt(6)); err != nil {
+		t.Fatalf("RollSelection error: %v", err)
+	}
+	check("roll", track, [][2]float64{{0, 30}, {6, 18}, {0, 30}, {6, 18}})
+
+	// C can slip forward 4 frames before running out of media, so B slips
+	// no further
+	track = createTestTrackWithAvailableRange([]float64{24, 24, 24, 24}, 48, 24)
+	cRange := opentime.NewTimeRange(rt(20), rt(24))
+	track.Children()[2].(gotio.Item).SetSourceRange(&cRange)
+	if err := SlipSelection(selectBC(track), rt(10)); err != nil {
+		t.Fatalf("SlipSelection error: %v", err)
+	}
+	check("slip", track, [][2]float64{{0, 24}, {4, 24}, {24, 24}, {0, 24}})
+
+	// Slide the block over the gap before it
+	track = createTestTrackWithAvailableRange([]float64{24, 24, 24}, 48, 24)
+	if err := track.InsertChild(1, gotio.NewGapWithDuration(rt(12))); err != nil {
+		t.Fatalf("InsertChild error: %v", err)
+	}
+	sel, err := NewSelection(track, track.Children()[2].(gotio.Item), track.Children()[3].(gotio.Item))
+	if err != nil {
+		t.Fatalf("NewSelection error: %v", err)
+	}
+	if err := SlideSelection(sel, rt(-8)); err != nil {
+		t.Fatalf("SlideSelection error: %v", err)
+	}
+	check("slide", track, [][2]float64{{0, 24}, {0, 4}, {0, 24}, {0, 24}})
+	if r, err := track.RangeOfChild(sel.Last()); err != nil || r.StartTime().Value() != 52 {
+		t.Errorf("last selected item starts at %v, %v, want 52", r.StartTime(), err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 // SliceConfig holds configuration for the Slice operation.
 type SliceConfig struct {
 	RemoveTransitions bool
+	OverrideLocks     bool
 }
 
 // SliceOption is a functional option for Slice.
@@ -23,6 +24,14 @@ func WithSliceRemoveTransitions(remove bool) SliceOption {
 	}
 }
 
+// WithSliceOverrideLocks sets whether to cut inside locked tracks and
+// regions.
+func WithSliceOverrideLocks(override bool) SliceOption {
+	return func(c *SliceConfig) {
+		c.OverrideLocks = override
+	}
+}
+
 // Slice cuts an item at a specific time, creating two items.
 // The composition is modified in place.
 //
@@ -48,6 +57,10 @@ func Slice(
 		opt(config)
 	}
 
+	if err := checkLocks(composition, time, time, config.OverrideLocks); err != nil {
+		return err
+	}
+
 	// Get composition duration
 	compDuration, err := compositionDuration(composition)
 	if err != nil {
@@ -21,15 +21,22 @@ import (
 //   - item: The item to slide
 //   - composition: The composition containing the item
 //   - delta: Amount to slide (positive = right, negative = left)
+//   - opts: Optional configuration (override locks)
 func Slide(
 	item gotio.Item,
 	composition gotio.Composition,
 	delta opentime.RationalTime,
+	opts ...EditOption,
 ) error {
 	if delta.Value() == 0 {
 		return nil
 	}
 
+	config := newEditConfig(opts)
+	if err := checkItemLocks(item, composition, delta, opentime.RationalTime{}, true, config.OverrideLocks); err != nil {
+		return err
+	}
+
 	// Find the item's index
 	itemIndex, err := composition.IndexOfChild(item)
 	if err != nil {
@@ -19,11 +19,17 @@ import (
 // Parameters:
 //   - item: The item to slip
 //   - delta: Amount to move source start (positive = forward in source)
-func Slip(item gotio.Item, delta opentime.RationalTime) error {
+//   - opts: Optional configuration (override locks)
+func Slip(item gotio.Item, delta opentime.RationalTime, opts ...EditOption) error {
 	if delta.Value() == 0 {
 		return nil
 	}
 
+	config := newEditConfig(opts)
+	if err := checkItemLocks(item, item.Parent(), opentime.RationalTime{}, opentime.RationalTime{}, false, config.OverrideLocks); err != nil {
+		return err
+	}
+
 	// Get current source range
 	sourceRange, err := itemSourceRange(item)
 	if err != nil {
@@ -1073,6 +1073,167 @@ func TestInsertRemovesTransitions(t *testing.T) {
 	}
 }
 
+// createTestTrackWithHandles creates [clip1][transition][clip2] where both
+// clips reference 60 frames of media and use 36 frames of it, leaving 12
+// frames of handle on either side of the cut.
+func createTestTrackWithHandles(inOffset, outOffset float64) *gotio.Track {
+	track := gotio.NewTrack("test", nil, gotio.TrackKindVideo, nil, nil)
+	ar := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(60, 24))
+
+	sr1 := opentime.NewTimeRange(opentime.NewRationalTime(12, 24), opentime.NewRationalTime(36, 24))
+	ref1 := gotio.NewExternalReference("", "file://clip1.mov", &ar, nil)
+	track.AppendChild(gotio.NewClip("clip1", ref1, &sr1, nil, nil, nil, "", nil))
+
+	transition := gotio.NewTransition(
+		"cross_dissolve",
+		gotio.TransitionTypeSMPTEDissolve,
+		opentime.NewRationalTime(inOffset, 24),
+		opentime.NewRationalTime(outOffset, 24),
+		nil,
+	)
+	track.AppendChild(transition)
+
+	sr2 := opentime.NewTimeRange(opentime.NewRationalTime(12, 24), opentime.NewRationalTime(36, 24))
+	ref2 := gotio.NewExternalReference("", "file://clip2.mov", &ar, nil)
+	track.AppendChild(gotio.NewClip("clip2", ref2, &sr2, nil, nil, nil, "", nil))
+
+	return track
+}
+
+func TestTrimPreserveTransitions(t *testing.T) {
+	track := createTestTrackWithTransitions()
+	clip2 := track.Children()[2].(gotio.Item)
+
+	// Trim clip2's head; clip1 extends across the transition
+	err := Trim(clip2, track, opentime.NewRationalTime(12, 24), opentime.RationalTime{},
+		WithTrimPreserveTransitions(true))
+	if err != nil {
+		t.Fatalf("Trim failed: %v", err)
+	}
+
+	children := track.Children()
+	if len(children) != 3 {
+		t.Fatalf("expected 3 children, got %d", len(children))
+	}
+	tr, ok := children[1].(*gotio.Transition)
+	if !ok {
+		t.Fatal("expected transition to be preserved")
+	}
+	if tr.InOffset().Value() != 6 || tr.OutOffset().Value() != 6 {
+		t.Errorf("expected offsets 6/6, got %v/%v", tr.InOffset().Value(), tr.OutOffset().Value())
+	}
+
+	clip1 := children[0].(gotio.Item)
+	if clip1.SourceRange().Duration().Value() != 60 {
+		t.Errorf("expected clip1 duration 60, got %v", clip1.SourceRange().Duration().Value())
+	}
+	if clip2.SourceRange().StartTime().Value() != 12 {
+		t.Errorf("expected clip2 start 12, got %v", clip2.SourceRange().StartTime().Value())
+	}
+
+	dur, _ := track.Duration()
+	if dur.Value() != 96 {
+		t.Errorf("expected track duration 96, got %v", dur.Value())
+	}
+}
+
+func TestTrimPreserveTransitionsShrinksOffset(t *testing.T) {
+	track := createTestTrackWithHandles(6, 6)
+	clip2 := track.Children()[2].(gotio.Item)
+
+	// Extending clip2's head uses up its head handle, which the in offset needs
+	err := Trim(clip2, track, opentime.NewRationalTime(-10, 24), opentime.RationalTime{},
+		WithTrimPreserveTransitions(true))
+	if err != nil {
+		t.Fatalf("Trim failed: %v", err)
+	}
+
+	tr, ok := track.Children()[1].(*gotio.Transition)
+	if !ok {
+		t.Fatal("expected transition to be preserved")
+	}
+	if tr.InOffset().Value() != 2 {
+		t.Errorf("expected in offset 2, got %v", tr.InOffset().Value())
+	}
+	if tr.OutOffset().Value() != 6 {
+		t.Errorf("expected out offset 6, got %v", tr.OutOffset().Value())
+	}
+}
+
+func TestTrimPreserveTransitionsRemovesUnsatisfiable(t *testing.T) {
+	track := createTestTrackWithHandles(6, 0)
+	clip2 := track.Children()[2].(gotio.Item)
+
+	// No head handle remains, so the transition cannot be kept
+	err := Trim(clip2, track, opentime.NewRationalTime(-12, 24), opentime.RationalTime{},
+		WithTrimPreserveTransitions(true))
+	if err != nil {
+		t.Fatalf("Trim failed: %v", err)
+	}
+
+	children := track.Children()
+	if len(children) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(children))
+	}
+	for _, child := range children {
+		if _, ok := child.(*gotio.Transition); ok {
+			t.Error("expected transition to be removed")
+		}
+	}
+}
+
+func TestRollPreserveTransitions(t *testing.T) {
+	track := createTestTrackWithTransitions()
+	clip1 := track.Children()[0].(gotio.Item)
+
+	// Roll the cut left until clip1 is shorter than the in offset
+	err := Roll(clip1, track, opentime.RationalTime{}, opentime.NewRationalTime(-44, 24),
+		WithRollPreserveTransitions(true))
+	if err != nil {
+		t.Fatalf("Roll failed: %v", err)
+	}
+
+	children := track.Children()
+	if len(children) != 3 {
+		t.Fatalf("expected 3 children, got %d", len(children))
+	}
+	if clip1.SourceRange().Duration().Value() != 4 {
+		t.Errorf("expected clip1 duration 4, got %v", clip1.SourceRange().Duration().Value())
+	}
+	clip2 := children[2].(gotio.Item)
+	if clip2.SourceRange().Duration().Value() != 92 {
+		t.Errorf("expected clip2 duration 92, got %v", clip2.SourceRange().Duration().Value())
+	}
+
+	tr := children[1].(*gotio.Transition)
+	if tr.InOffset().Value() != 4 {
+		t.Errorf("expected in offset 4, got %v", tr.InOffset().Value())
+	}
+	if tr.OutOffset().Value() != 6 {
+		t.Errorf("expected out offset 6, got %v", tr.OutOffset().Value())
+	}
+}
+
+func TestRollPreserveTransitionsShrinksOutOffset(t *testing.T) {
+	track := createTestTrackWithHandles(6, 6)
+	clip1 := track.Children()[0].(gotio.Item)
+
+	// Rolling right consumes clip1's tail handle
+	err := Roll(clip1, track, opentime.RationalTime{}, opentime.NewRationalTime(8, 24),
+		WithRollPreserveTransitions(true))
+	if err != nil {
+		t.Fatalf("Roll failed: %v", err)
+	}
+
+	tr := track.Children()[1].(*gotio.Transition)
+	if tr.OutOffset().Value() != 4 {
+		t.Errorf("expected out offset 4, got %v", tr.OutOffset().Value())
+	}
+	if tr.InOffset().Value() != 6 {
+		t.Errorf("expected in offset 6, got %v", tr.InOffset().Value())
+	}
+}
+
 // ============================================================================
 // Remove Range Tests
 // ============================================================================
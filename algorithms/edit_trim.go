@@ -10,7 +10,9 @@ import (
 
 // TrimConfig holds configuration for the Trim operation.
 type TrimConfig struct {
-	FillTemplate gotio.Item
+	FillTemplate        gotio.Item
+	PreserveTransitions bool
+	OverrideLocks       bool
 }
 
 // TrimOption is a functional option for Trim.
@@ -23,6 +25,24 @@ func WithTrimFillTemplate(template gotio.Item) TrimOption {
 	}
 }
 
+// WithTrimPreserveTransitions sets whether to keep transitions next to the
+// trimmed item. When enabled, the item across a transition is adjusted and the
+// transition offsets are shrunk to fit; a transition is only removed when its
+// offsets can no longer be satisfied.
+func WithTrimPreserveTransitions(preserve bool) TrimOption {
+	return func(c *TrimConfig) {
+		c.PreserveTransitions = preserve
+	}
+}
+
+// WithTrimOverrideLocks sets whether to trim items in locked tracks and
+// regions.
+func WithTrimOverrideLocks(override bool) TrimOption {
+	return func(c *TrimConfig) {
+		c.OverrideLocks = override
+	}
+}
+
 // Trim adjusts an item's in/out points without affecting composition duration.
 // Adjacent items are adjusted to compensate.
 // The item and adjacent items are modified in place.
@@ -62,6 +82,10 @@ func Trim(
 		return newEditErrorForItem("trim", "item not in composition", item)
 	}
 
+	if err := checkItemLocks(item, composition, deltaIn, deltaOut, false, config.OverrideLocks); err != nil {
+		return err
+	}
+
 	// Get current source range
 	sourceRange, err := itemSourceRange(item)
 	if err != nil {
@@ -86,6 +110,19 @@ func Trim(
 		}
 	}
 
+	if config.PreserveTransitions {
+		// Head trims may insert a gap, so look the item up again
+		if itemIndex, err = composition.IndexOfChild(item); err != nil {
+			return err
+		}
+		return fitTransitionsAround(
+			composition,
+			item,
+			getPreviousItemAcrossTransition(composition, itemIndex),
+			getNextItemAcrossTransition(composition, itemIndex),
+		)
+	}
+
 	return nil
 }
 
@@ -123,6 +160,9 @@ func trimHead(
 
 	// Adjust previous item to compensate
 	prevItem := getPreviousItem(composition, itemIndex)
+	if config.PreserveTransitions {
+		prevItem = getPreviousItemAcrossTransition(composition, itemIndex)
+	}
 	if prevItem != nil {
 		var prevRange opentime.TimeRange
 		if sr := prevItem.SourceRange(); sr != nil {
@@ -187,6 +227,9 @@ func trimTail(
 
 	// Adjust next item to compensate
 	nextItem := getNextItem(composition, itemIndex)
+	if config.PreserveTransitions {
+		nextItem = getNextItemAcrossTransition(composition, itemIndex)
+	}
 	if nextItem != nil {
 		var nextRange opentime.TimeRange
 		if sr := nextItem.SourceRange(); sr != nil {
@@ -208,8 +251,10 @@ func trimTail(
 		if _, isGap := nextItem.(*gotio.Gap); isGap {
 			if newNextDuration.Value() <= 0 {
 				// Gap is eliminated - remove it
-				// Note: Recalculate itemIndex since our item might have shifted
-				composition.RemoveChild(itemIndex + 1)
+				// Note: Recalculate the gap's index since a transition may sit between
+				if gapIndex, err := composition.IndexOfChild(nextItem); err == nil {
+					composition.RemoveChild(gapIndex)
+				}
 				return nil
 			}
 		} else {
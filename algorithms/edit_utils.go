@@ -4,6 +4,8 @@
 package algorithms
 
 import (
+	"sort"
+
 	"github.com/Avalanche-io/gotio/opentime"
 	"github.com/Avalanche-io/gotio"
 )
@@ -333,3 +335,163 @@ func adjustItemStartTime(item gotio.Item, delta opentime.RationalTime) error {
 	item.SetSourceRange(&newRange)
 	return nil
 }
+
+// getPreviousItemAcrossTransition returns the item before the given index.
+// A transition directly before the index is stepped over, so the outgoing
+// item of that transition is returned instead of nil.
+func getPreviousItemAcrossTransition(comp gotio.Composition, index int) gotio.Item {
+	children := comp.Children()
+	if index > 0 && index <= len(children) {
+		if _, ok := children[index-1].(*gotio.Transition); ok {
+			return getPreviousItem(comp, index-1)
+		}
+	}
+	return getPreviousItem(comp, index)
+}
+
+// getNextItemAcrossTransition returns the item after the given index.
+// A transition directly after the index is stepped over, so the incoming
+// item of that transition is returned instead of nil.
+func getNextItemAcrossTransition(comp gotio.Composition, index int) gotio.Item {
+	children := comp.Children()
+	if index >= 0 && index < len(children)-1 {
+		if _, ok := children[index+1].(*gotio.Transition); ok {
+			return getNextItem(comp, index+1)
+		}
+	}
+	return getNextItem(comp, index)
+}
+
+// fitTransitionsAround adjusts the transitions adjacent to the given items so
+// their offsets fit the items' new durations and media handles.
+// Transitions whose offsets can no longer be satisfied are removed.
+func fitTransitionsAround(comp gotio.Composition, items ...gotio.Item) error {
+	children := comp.Children()
+	seen := make(map[int]bool)
+	var indices []int
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		index, err := comp.IndexOfChild(item)
+		if err != nil {
+			continue
+		}
+		for _, i := range []int{index - 1, index + 1} {
+			if i < 0 || i >= len(children) || seen[i] {
+				continue
+			}
+			if _, ok := children[i].(*gotio.Transition); ok {
+				seen[i] = true
+				indices = append(indices, i)
+			}
+		}
+	}
+
+	// Remove in reverse order to maintain valid indices
+	sort.Sort(sort.Reverse(sort.IntSlice(indices)))
+	for _, index := range indices {
+		if fitTransition(comp, index) {
+			continue
+		}
+		if err := comp.RemoveChild(index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fitTransition clamps the offsets of the transition at index.
+// The in offset overlaps the tail of the outgoing item and uses the head
+// handle of the incoming item; the out offset overlaps the head of the
+// incoming item and uses the tail handle of the outgoing item.
+// Returns false if the transition can no longer be satisfied.
+func fitTransition(comp gotio.Composition, index int) bool {
+	tr, ok := comp.Children()[index].(*gotio.Transition)
+	if !ok {
+		return true
+	}
+	prev := getPreviousItem(comp, index)
+	next := getNextItem(comp, index)
+	if prev == nil || next == nil {
+		return false
+	}
+
+	prevDuration, err := prev.Duration()
+	if err != nil {
+		return false
+	}
+	nextDuration, err := next.Duration()
+	if err != nil {
+		return false
+	}
+
+	// Items between two transitions share their duration with both
+	if index >= 2 {
+		if before, ok := comp.Children()[index-2].(*gotio.Transition); ok {
+			prevDuration = prevDuration.Sub(before.OutOffset())
+		}
+	}
+	if index+2 < len(comp.Children()) {
+		if after, ok := comp.Children()[index+2].(*gotio.Transition); ok {
+			nextDuration = nextDuration.Sub(after.InOffset())
+		}
+	}
+
+	inOffset := minRationalTime(tr.InOffset(), prevDuration)
+	if handle, ok := headHandle(next); ok {
+		inOffset = minRationalTime(inOffset, handle)
+	}
+	outOffset := minRationalTime(tr.OutOffset(), nextDuration)
+	if handle, ok := tailHandle(prev); ok {
+		outOffset = minRationalTime(outOffset, handle)
+	}
+
+	if inOffset.Value() < 0 {
+		inOffset = opentime.NewRationalTime(0, inOffset.Rate())
+	}
+	if outOffset.Value() < 0 {
+		outOffset = opentime.NewRationalTime(0, outOffset.Rate())
+	}
+	if isZeroOrNegative(inOffset) && isZeroOrNegative(outOffset) {
+		return false
+	}
+
+	tr.SetInOffset(inOffset)
+	tr.SetOutOffset(outOffset)
+	return true
+}
+
+// headHandle returns the media available before a clip's source start.
+// Returns false if the item has no known available range.
+func headHandle(item gotio.Item) (opentime.RationalTime, bool) {
+	if _, ok := item.(*gotio.Clip); !ok {
+		return opentime.RationalTime{}, false
+	}
+	sr := item.SourceRange()
+	if sr == nil {
+		return opentime.RationalTime{}, false
+	}
+	ar, err := item.AvailableRange()
+	if err != nil {
+		return opentime.RationalTime{}, false
+	}
+	return sr.StartTime().Sub(ar.StartTime()), true
+}
+
+// tailHandle returns the media available after a clip's source end.
+// Returns false if the item has no known available range.
+func tailHandle(item gotio.Item) (opentime.RationalTime, bool) {
+	if _, ok := item.(*gotio.Clip); !ok {
+		return opentime.RationalTime{}, false
+	}
+	sr := item.SourceRange()
+	if sr == nil {
+		return opentime.RationalTime{}, false
+	}
+	ar, err := item.AvailableRange()
+	if err != nil {
+		return opentime.RationalTime{}, false
+	}
+	return ar.EndTimeExclusive().Sub(sr.EndTimeExclusive()), true
+}
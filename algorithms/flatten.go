@@ -0,0 +1,455 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// FlattenMetadataKey is the metadata namespace flattening records
+// transitions under.
+const FlattenMetadataKey = "flatten"
+
+// Keys under FlattenMetadataKey for the transitions at either end of an
+// item. Each holds the transition's name, transition_type, in_offset and
+// out_offset, and for clips the source_range of media seen during it.
+const (
+	TransitionInKey  = "transition_in"
+	TransitionOutKey = "transition_out"
+)
+
+// FlattenConfig holds options for FlattenStack, FlattenTracks and
+// FlattenTimelineVideoTracks.
+type FlattenConfig struct {
+	// BakeTimeEffects replaces nested stacks and tracks with the clips and
+	// gaps they show, so the flattened track holds no nested compositions.
+	// The LinearTimeWarp and FreezeFrame effects of a nested composition
+	// are combined with those of each clip in it and set on the clip, and
+	// the clip's source range starts at the first frame of media it shows
+	// and lasts as long as it plays in the flattened track. Compositions
+	// with other time effects or reversed time are kept nested.
+	BakeTimeEffects bool
+	// ResolveTransitions records each transition in the metadata of the
+	// items either side of it, under FlattenMetadataKey, with the media
+	// range each clip plays during the transition. An item trimmed at that
+	// end by a track above loses the record.
+	ResolveTransitions bool
+	// RespectEnabled leaves out tracks that are not enabled in their
+	// hierarchy and replaces disabled items with gaps, so only what plays
+	// is flattened. Without it, disabled items of the bottom track and of
+	// nested compositions are kept.
+	RespectEnabled bool
+}
+
+// FlattenOption is a functional option for flattening.
+type FlattenOption func(*FlattenConfig)
+
+// WithBakeTimeEffects sets whether time effects of nested compositions are
+// baked into the clips they hold.
+func WithBakeTimeEffects(bake bool) FlattenOption {
+	return func(c *FlattenConfig) {
+		c.BakeTimeEffects = bake
+	}
+}
+
+// WithResolveTransitions sets whether transitions are recorded in the
+// metadata of the items around them.
+func WithResolveTransitions(resolve bool) FlattenOption {
+	return func(c *FlattenConfig) {
+		c.ResolveTransitions = resolve
+	}
+}
+
+// WithRespectEnabled sets flattening to skip disabled tracks and items.
+func WithRespectEnabled() FlattenOption {
+	return func(c *FlattenConfig) {
+		c.RespectEnabled = true
+	}
+}
+
+func newFlattenConfig(opts []FlattenOption) FlattenConfig {
+	var cfg FlattenConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// prepare returns a copy of the track with nested compositions baked and
+// transitions recorded as configured, or the track itself if there is
+// nothing to do.
+func (cfg *FlattenConfig) prepare(track *gotio.Track) *gotio.Track {
+	if !cfg.BakeTimeEffects && !cfg.ResolveTransitions && !cfg.RespectEnabled && len(track.Effects()) == 0 {
+		return track
+	}
+	var result *gotio.Track
+	if cfg.BakeTimeEffects {
+		result = cfg.bakeTrack(track)
+	} else {
+		result = track.Clone().(*gotio.Track)
+		if cfg.RespectEnabled {
+			disableToGaps(result)
+		}
+		carryTrackEffects(result)
+	}
+	if cfg.ResolveTransitions {
+		recordTransitions(result)
+	}
+	return result
+}
+
+// trim trims an item of a lower track to the part of it left visible,
+// dropping the record of a transition at a trimmed end.
+func (cfg *FlattenConfig) trim(item gotio.Item, originalRange, newRange opentime.TimeRange) {
+	scalar := 1.0
+	if cfg.BakeTimeEffects {
+		// Baked clips run through their media at their time scalar.
+		if s, ok := timeScalar(item.Effects()); ok {
+			scalar = s
+		}
+	}
+	trimItemToRangeScaled(item, originalRange, newRange, scalar)
+
+	if cfg.ResolveTransitions {
+		if newRange.StartTime().Cmp(originalRange.StartTime()) != 0 {
+			clearTransitionInfo(item, TransitionInKey)
+		}
+		if newRange.EndTimeExclusive().Cmp(originalRange.EndTimeExclusive()) != 0 {
+			clearTransitionInfo(item, TransitionOutKey)
+		}
+	}
+}
+
+// bakeTrack returns a copy of the track with its nested compositions
+// replaced by the items they show, and its own time effects baked into
+// them as a nested composition's would be.
+func (cfg *FlattenConfig) bakeTrack(track *gotio.Track) *gotio.Track {
+	if scalar, ok := timeScalar(track.Effects()); ok && scalar != 1 {
+		if duration, err := track.Duration(); err == nil {
+			if items, ok := cfg.expandComposition(track, duration); ok {
+				result := gotio.NewTrack(track.Name(), nil, track.Kind(), gotio.CloneAnyDictionary(track.Metadata()), nil)
+				for _, item := range items {
+					result.AppendChild(item)
+				}
+				return result
+			}
+		}
+	}
+	result := cfg.expandTrack(track)
+	for _, effect := range track.Effects() {
+		result.SetEffects(append(result.Effects(), effect.Clone().(gotio.Effect)))
+	}
+	carryTrackEffects(result)
+	return result
+}
+
+// carryTrackEffects moves the effects of the track that do not change time
+// onto each clip and nested composition in it, after their own effects.
+func carryTrackEffects(track *gotio.Track) {
+	var kept, carried []gotio.Effect
+	for _, effect := range track.Effects() {
+		if gotio.IsTimeEffect(effect) {
+			kept = append(kept, effect)
+		} else {
+			carried = append(carried, effect)
+		}
+	}
+	if len(carried) == 0 {
+		return
+	}
+	track.SetEffects(kept)
+	for _, child := range track.Children() {
+		item, ok := child.(gotio.Item)
+		if !ok {
+			continue
+		}
+		if _, ok := item.(*gotio.Clip); !ok {
+			if _, ok := item.(gotio.Composition); !ok {
+				continue
+			}
+		}
+		effects := item.Effects()
+		for _, effect := range carried {
+			effects = append(effects, effect.Clone().(gotio.Effect))
+		}
+		item.SetEffects(effects)
+	}
+}
+
+// expandTrack returns a copy of the track with its nested compositions
+// replaced by the items they show.
+func (cfg *FlattenConfig) expandTrack(track *gotio.Track) *gotio.Track {
+	result := gotio.NewTrack(
+		track.Name(),
+		track.SourceRange(),
+		track.Kind(),
+		gotio.CloneAnyDictionary(track.Metadata()),
+		nil,
+	)
+	for i, child := range track.Children() {
+		childRange, err := track.RangeOfChildAtIndex(i)
+		if err != nil {
+			result.AppendChild(child.Clone().(gotio.Composable))
+			continue
+		}
+		if item, ok := child.(gotio.Item); ok && cfg.RespectEnabled && !item.Enabled() {
+			result.AppendChild(newGapLike(item, childRange.Duration()))
+			continue
+		}
+		comp, ok := child.(gotio.Composition)
+		if !ok || !comp.Enabled() {
+			result.AppendChild(child.Clone().(gotio.Composable))
+			continue
+		}
+		items, ok := cfg.expandComposition(comp, childRange.Duration())
+		if !ok {
+			result.AppendChild(child.Clone().(gotio.Composable))
+			continue
+		}
+		for _, item := range items {
+			result.AppendChild(item)
+		}
+	}
+	return result
+}
+
+// expandComposition returns the clips and gaps a nested composition shows
+// over duration, with its time effects and other effects carried onto the
+// clips. Returns false if the composition cannot be baked.
+func (cfg *FlattenConfig) expandComposition(comp gotio.Composition, duration opentime.RationalTime) ([]gotio.Composable, bool) {
+	scalar, ok := timeScalar(comp.Effects())
+	if !ok || scalar < 0 {
+		return nil, false
+	}
+	trimmed, err := comp.TrimmedRange()
+	if err != nil {
+		return nil, false
+	}
+
+	var inner *gotio.Track
+	switch c := comp.(type) {
+	case *gotio.Stack:
+		innerOpts := []FlattenOption{WithBakeTimeEffects(true)}
+		if cfg.RespectEnabled {
+			innerOpts = append(innerOpts, WithRespectEnabled())
+		}
+		inner, err = FlattenStack(c, innerOpts...)
+		if err != nil {
+			return nil, false
+		}
+	case *gotio.Track:
+		inner = cfg.expandTrack(c)
+	default:
+		return nil, false
+	}
+
+	// The part of the composition's content that plays, in its own time.
+	contentDuration := opentime.NewRationalTime(duration.Value()*scalar, duration.Rate())
+	if scalar == 0 {
+		contentDuration = opentime.NewRationalTime(1, trimmed.StartTime().Rate())
+	}
+	window := opentime.NewTimeRange(trimmed.StartTime(), contentDuration.RescaledTo(trimmed.StartTime().Rate()))
+	toRecord := func(d opentime.RationalTime) opentime.RationalTime {
+		if scalar == 0 {
+			return duration
+		}
+		return opentime.NewRationalTime(d.Value()/scalar, d.Rate()).RescaledTo(duration.Rate())
+	}
+
+	var result []gotio.Composable
+	played := opentime.NewRationalTime(0, duration.Rate())
+	for i, child := range inner.Children() {
+		item, ok := child.(gotio.Item)
+		if !ok {
+			continue
+		}
+		childRange, err := inner.RangeOfChildAtIndex(i)
+		if err != nil {
+			continue
+		}
+		if !childRange.Intersects(window, opentime.DefaultEpsilon) {
+			continue
+		}
+		visible := intersectRanges(childRange, window)
+		record := toRecord(visible.Duration())
+
+		var baked gotio.Composable
+		switch c := item.(type) {
+		case *gotio.Clip:
+			clip := c.Clone().(*gotio.Clip)
+			sourceRange, err := clip.TrimmedRange()
+			if err != nil {
+				return nil, false
+			}
+			clipScalar, ok := timeScalar(clip.Effects())
+			if !ok {
+				return nil, false
+			}
+			offset := visible.StartTime().Sub(childRange.StartTime())
+			start := sourceRange.StartTime().Add(
+				opentime.NewRationalTime(offset.Value()*clipScalar, offset.Rate()).RescaledTo(sourceRange.StartTime().Rate()))
+			newRange := opentime.NewTimeRange(start, record.RescaledTo(sourceRange.Duration().Rate()))
+			clip.SetSourceRange(&newRange)
+			clip.SetEffects(append(withTimeScalar(clip.Effects(), clipScalar*scalar), cloneNonTimeEffects(comp.Effects())...))
+			baked = clip
+		default:
+			gapRange := opentime.NewTimeRange(opentime.NewRationalTime(0, record.Rate()), record)
+			baked = gotio.NewGap(item.Name(), &gapRange, nil, nil, nil, nil)
+		}
+		result = append(result, baked)
+		played = played.Add(record)
+		if scalar == 0 {
+			break
+		}
+	}
+
+	// Content shorter than the composition leaves a gap at the end.
+	if rest := duration.Sub(played); rest.ToSeconds() > opentime.DefaultEpsilon {
+		gapRange := opentime.NewTimeRange(opentime.NewRationalTime(0, rest.Rate()), rest)
+		result = append(result, gotio.NewGap("", &gapRange, nil, nil, nil, nil))
+	}
+	return result, true
+}
+
+// disableToGaps replaces the disabled items of the track with gaps of the
+// same duration.
+func disableToGaps(track *gotio.Track) {
+	for i, child := range track.Children() {
+		item, ok := child.(gotio.Item)
+		if !ok || item.Enabled() {
+			continue
+		}
+		duration, err := item.Duration()
+		if err != nil {
+			continue
+		}
+		track.SetChild(i, newGapLike(item, duration))
+	}
+}
+
+// newGapLike returns a gap standing in for item over duration.
+func newGapLike(item gotio.Item, duration opentime.RationalTime) *gotio.Gap {
+	gapRange := opentime.NewTimeRange(opentime.NewRationalTime(0, duration.Rate()), duration)
+	return gotio.NewGap(item.Name(), &gapRange, nil, nil, nil, nil)
+}
+
+// timeScalar returns the combined speed of the LinearTimeWarp and
+// FreezeFrame effects, and false if there are other time effects.
+func timeScalar(effects []gotio.Effect) (float64, bool) {
+	scalar := 1.0
+	for _, effect := range effects {
+		switch e := effect.(type) {
+		case interface{ TimeScalar() float64 }:
+			scalar *= e.TimeScalar()
+		case *gotio.TimeEffectImpl:
+			return 0, false
+		}
+	}
+	return scalar, true
+}
+
+// withTimeScalar returns the effects with their time warps replaced by a
+// single effect of the given speed.
+func withTimeScalar(effects []gotio.Effect, scalar float64) []gotio.Effect {
+	result := cloneNonTimeEffects(effects)
+	switch {
+	case scalar == 0:
+		result = append(result, gotio.NewFreezeFrame("", nil))
+	case scalar != 1:
+		result = append(result, gotio.NewLinearTimeWarp("", "LinearTimeWarp", scalar, nil))
+	}
+	return result
+}
+
+// cloneNonTimeEffects returns copies of the effects that do not change time.
+func cloneNonTimeEffects(effects []gotio.Effect) []gotio.Effect {
+	var result []gotio.Effect
+	for _, effect := range effects {
+		if _, ok := effect.(interface{ TimeScalar() float64 }); ok {
+			continue
+		}
+		result = append(result, effect.Clone().(gotio.Effect))
+	}
+	return result
+}
+
+// recordTransitions records each transition of the track on the items
+// either side of it.
+func recordTransitions(track *gotio.Track) {
+	children := track.Children()
+	for i, child := range children {
+		transition, ok := child.(*gotio.Transition)
+		if !ok {
+			continue
+		}
+		if i > 0 {
+			if item, ok := children[i-1].(gotio.Item); ok {
+				setTransitionInfo(item, TransitionOutKey, transition)
+			}
+		}
+		if i+1 < len(children) {
+			if item, ok := children[i+1].(gotio.Item); ok {
+				setTransitionInfo(item, TransitionInKey, transition)
+			}
+		}
+	}
+}
+
+// setTransitionInfo stores the transition under key in the item's flatten
+// metadata.
+func setTransitionInfo(item gotio.Item, key string, transition *gotio.Transition) {
+	info := gotio.AnyDictionary{
+		"name":            transition.Name(),
+		"transition_type": string(transition.TransitionType()),
+		"in_offset":       transition.InOffset(),
+		"out_offset":      transition.OutOffset(),
+	}
+	if clip, ok := item.(*gotio.Clip); ok {
+		if sourceRange, err := clip.TrimmedRange(); err == nil {
+			scalar, ok := timeScalar(clip.Effects())
+			if !ok {
+				scalar = 1
+			}
+			// Offset into the clip, in its own time, of the cut.
+			cut := opentime.NewRationalTime(0, sourceRange.Duration().Rate())
+			if key == TransitionOutKey {
+				cut = sourceRange.Duration()
+			}
+			rate := sourceRange.StartTime().Rate()
+			from := cut.Sub(transition.InOffset()).RescaledTo(rate)
+			length := transition.InOffset().Add(transition.OutOffset()).RescaledTo(rate)
+			info["source_range"] = opentime.NewTimeRange(
+				sourceRange.StartTime().Add(opentime.NewRationalTime(from.Value()*scalar, rate)),
+				opentime.NewRationalTime(length.Value()*scalar, rate),
+			)
+		}
+	}
+
+	md := item.Metadata()
+	if md == nil {
+		md = gotio.AnyDictionary{}
+		item.SetMetadata(md)
+	}
+	values, ok := md.GetDictionary(FlattenMetadataKey)
+	if !ok {
+		values = gotio.AnyDictionary{}
+	}
+	values[key] = info
+	md[FlattenMetadataKey] = values
+}
+
+// clearTransitionInfo removes the transition stored under key from the
+// item's flatten metadata.
+func clearTransitionInfo(item gotio.Item, key string) {
+	md := item.Metadata()
+	values, ok := md.GetDictionary(FlattenMetadataKey)
+	if !ok {
+		return
+	}
+	delete(values, key)
+	if len(values) == 0 {
+		delete(md, FlattenMetadataKey)
+	}
+}
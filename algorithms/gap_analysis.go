@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"math"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// gapEpsilon is the tolerance, in seconds, used when comparing gap boundaries.
+const gapEpsilon = 1e-9
+
+// GapRange is a stretch of empty time on a track.
+type GapRange struct {
+	// Track is the track the gap is on.
+	Track *gotio.Track
+	// Range is the empty time, in the track's presentation time.
+	Range opentime.TimeRange
+	// Implicit is true for time not covered by any child, such as the end of
+	// a track that is shorter than its timeline.
+	Implicit bool
+}
+
+// TrackCoverage summarizes the filled and empty time of a track.
+type TrackCoverage struct {
+	Track  *gotio.Track
+	Filled opentime.RationalTime
+	Empty  opentime.RationalTime
+	Gaps   []GapRange
+}
+
+// Ratio returns the fraction of the track's time that is filled, from 0 to 1.
+func (c TrackCoverage) Ratio() float64 {
+	total := c.Filled.ToSecondsOrZero() + c.Empty.ToSecondsOrZero()
+	if total <= 0 {
+		return 0
+	}
+	return c.Filled.ToSecondsOrZero() / total
+}
+
+// Coverage summarizes the filled and empty time of every track in a timeline.
+type Coverage struct {
+	Duration opentime.RationalTime
+	Tracks   []TrackCoverage
+}
+
+// FindTrackGaps returns the empty ranges of a track in its presentation time.
+// Adjacent gaps are merged. If the track's source range extends past its
+// children, the remainder is reported as an implicit gap.
+func FindTrackGaps(track *gotio.Track) ([]GapRange, error) {
+	if track == nil {
+		return nil, nil
+	}
+	duration, err := track.Duration()
+	if err != nil {
+		return nil, err
+	}
+	return trackGaps(track, duration)
+}
+
+// FindGaps returns the empty ranges of every track of the given kind in the
+// timeline, in timeline time. An empty kind matches all tracks. Tracks that
+// are shorter than the timeline report an implicit gap at their end.
+func FindGaps(timeline *gotio.Timeline, kind string) ([]GapRange, error) {
+	if timeline == nil {
+		return nil, nil
+	}
+	duration, err := timeline.Duration()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []GapRange
+	for _, track := range timelineTracks(timeline) {
+		if kind != "" && track.Kind() != kind {
+			continue
+		}
+		gaps, err := trackGaps(track, duration)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, gaps...)
+	}
+	return result, nil
+}
+
+// CoverageReport returns the filled and empty time of every track in the
+// timeline, measured against the timeline's duration.
+func CoverageReport(timeline *gotio.Timeline) (*Coverage, error) {
+	if timeline == nil {
+		return &Coverage{}, nil
+	}
+	duration, err := timeline.Duration()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Coverage{Duration: duration}
+	for _, track := range timelineTracks(timeline) {
+		gaps, err := trackGaps(track, duration)
+		if err != nil {
+			return nil, err
+		}
+		empty := opentime.NewRationalTime(0, duration.Rate())
+		for _, gap := range gaps {
+			empty = addTime(empty, gap.Range.Duration())
+		}
+		report.Tracks = append(report.Tracks, TrackCoverage{
+			Track:  track,
+			Filled: subtractTime(duration, empty),
+			Empty:  empty,
+			Gaps:   gaps,
+		})
+	}
+	return report, nil
+}
+
+// trackGaps returns the gaps of a track up to end, in presentation time.
+func trackGaps(track *gotio.Track, end opentime.RationalTime) ([]GapRange, error) {
+	var sourceStart opentime.RationalTime
+	if sr := track.SourceRange(); sr != nil {
+		sourceStart = sr.StartTime()
+	}
+
+	var gaps []GapRange
+	var contentEnd opentime.RationalTime
+	for i, child := range track.Children() {
+		if !child.Visible() {
+			continue
+		}
+		childRange, err := track.TrimmedRangeOfChildAtIndex(i)
+		if err != nil {
+			return nil, err
+		}
+		if isZeroOrNegative(childRange.Duration()) {
+			continue
+		}
+		start := subtractTime(childRange.StartTime(), sourceStart)
+		contentEnd = start.Add(childRange.Duration())
+
+		if _, ok := child.(*gotio.Gap); !ok {
+			continue
+		}
+		gaps = appendGap(gaps, GapRange{
+			Track: track,
+			Range: opentime.NewTimeRange(start, childRange.Duration()),
+		})
+	}
+
+	if remaining := subtractTime(end, contentEnd); remaining.ToSecondsOrZero() > gapEpsilon {
+		start := contentEnd
+		if start.Rate() <= 0 {
+			start = opentime.NewRationalTime(0, remaining.Rate())
+		}
+		gaps = append(gaps, GapRange{
+			Track:    track,
+			Range:    opentime.NewTimeRange(start, remaining),
+			Implicit: true,
+		})
+	}
+	return gaps, nil
+}
+
+// appendGap appends gap, merging it into the last gap if they are adjacent.
+func appendGap(gaps []GapRange, gap GapRange) []GapRange {
+	if len(gaps) > 0 {
+		last := &gaps[len(gaps)-1]
+		if last.Implicit == gap.Implicit &&
+			math.Abs(subtractTime(gap.Range.StartTime(), last.Range.EndTimeExclusive()).ToSecondsOrZero()) <= gapEpsilon {
+			last.Range = opentime.NewTimeRange(last.Range.StartTime(), last.Range.Duration().Add(gap.Range.Duration()))
+			return gaps
+		}
+	}
+	return append(gaps, gap)
+}
+
+// addTime returns a + b, treating times without a rate as zero.
+func addTime(a, b opentime.RationalTime) opentime.RationalTime {
+	if a.Rate() <= 0 {
+		return b
+	}
+	return a.Add(b)
+}
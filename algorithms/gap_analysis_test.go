@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// createGapTimeline creates a timeline with a 96 frame video track containing
+// two adjacent gaps and a 48 frame audio track.
+func createGapTimeline() (*gotio.Timeline, *gotio.Track, *gotio.Track) {
+	video := createTestTrack([]float64{24}, 24)
+	video.AppendChild(gotio.NewGapWithDuration(opentime.NewRationalTime(12, 24)))
+	video.AppendChild(gotio.NewGapWithDuration(opentime.NewRationalTime(12, 24)))
+	video.AppendChild(createTestTrack([]float64{48}, 24).Children()[0].Clone().(gotio.Composable))
+
+	audio := createTestTrack([]float64{48}, 24)
+	audio.SetKind(gotio.TrackKindAudio)
+
+	timeline := gotio.NewTimeline("gaps", nil, nil)
+	timeline.Tracks().AppendChild(video)
+	timeline.Tracks().AppendChild(audio)
+	return timeline, video, audio
+}
+
+func TestFindTrackGaps(t *testing.T) {
+	_, video, _ := createGapTimeline()
+
+	gaps, err := FindTrackGaps(video)
+	if err != nil {
+		t.Fatalf("FindTrackGaps error: %v", err)
+	}
+	if len(gaps) != 1 {
+		t.Fatalf("expected adjacent gaps to merge into 1, got %d", len(gaps))
+	}
+	if gaps[0].Range.StartTime().Value() != 24 || gaps[0].Range.Duration().Value() != 24 {
+		t.Errorf("gap range = %v, want 24 frames at 24", gaps[0].Range)
+	}
+	if gaps[0].Implicit || gaps[0].Track != video {
+		t.Error("expected explicit gap on the video track")
+	}
+
+	// A source range past the end of the content is an implicit gap
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(36, 24), opentime.NewRationalTime(72, 24))
+	video.SetSourceRange(&sr)
+	gaps, err = FindTrackGaps(video)
+	if err != nil {
+		t.Fatalf("FindTrackGaps error: %v", err)
+	}
+	if len(gaps) != 2 {
+		t.Fatalf("expected 2 gaps with source range, got %d", len(gaps))
+	}
+	if gaps[0].Range.StartTime().Value() != 0 || gaps[0].Range.Duration().Value() != 12 {
+		t.Errorf("trimmed gap range = %v, want 0-12", gaps[0].Range)
+	}
+	if !gaps[1].Implicit || gaps[1].Range.StartTime().Value() != 60 || gaps[1].Range.Duration().Value() != 12 {
+		t.Errorf("implicit gap = %v (implicit %v), want 60-72", gaps[1].Range, gaps[1].Implicit)
+	}
+
+	if gaps, err := FindTrackGaps(nil); gaps != nil || err != nil {
+		t.Error("expected nil result for nil track")
+	}
+}
+
+func TestFindGaps(t *testing.T) {
+	timeline, video, audio := createGapTimeline()
+
+	gaps, err := FindGaps(timeline, "")
+	if err != nil {
+		t.Fatalf("FindGaps error: %v", err)
+	}
+	if len(gaps) != 2 {
+		t.Fatalf("expected 2 gaps, got %d", len(gaps))
+	}
+	if gaps[0].Track != video || gaps[1].Track != audio {
+		t.Error("expected gaps in track order")
+	}
+	if !gaps[1].Implicit || gaps[1].Range.StartTime().Value() != 48 || gaps[1].Range.Duration().Value() != 48 {
+		t.Errorf("audio gap = %v, want implicit 48-96", gaps[1].Range)
+	}
+
+	gaps, err = FindGaps(timeline, gotio.TrackKindAudio)
+	if err != nil {
+		t.Fatalf("FindGaps error: %v", err)
+	}
+	if len(gaps) != 1 || gaps[0].Track != audio {
+		t.Errorf("expected only the audio gap, got %d gaps", len(gaps))
+	}
+}
+
+func TestCoverageReport(t *testing.T) {
+	timeline, _, _ := createGapTimeline()
+
+	report, err := CoverageReport(timeline)
+	if err != nil {
+		t.Fatalf("CoverageReport error: %v", err)
+	}
+	if report.Duration.Value() != 96 {
+		t.Errorf("Duration = %v, want 96", report.Duration.Value())
+	}
+	if len(report.Tracks) != 2 {
+		t.Fatalf("expected 2 tracks, got %d", len(report.Tracks))
+	}
+
+	video := report.Tracks[0]
+	if video.Filled.Value() != 72 || video.Empty.Value() != 24 {
+		t.Errorf("video filled/empty = %v/%v, want 72/24", video.Filled.Value(), video.Empty.Value())
+	}
+	if video.Ratio() != 0.75 {
+		t.Errorf("video ratio = %v, want 0.75", video.Ratio())
+	}
+	audio := report.Tracks[1]
+	if audio.Filled.Value() != 48 || audio.Empty.Value() != 48 {
+		t.Errorf("audio filled/empty = %v/%v, want 48/48", audio.Filled.Value(), audio.Empty.Value())
+	}
+
+	empty, err := CoverageReport(nil)
+	if err != nil || len(empty.Tracks) != 0 {
+		t.Error("expected empty report for nil timeline")
+	}
+	if (TrackCoverage{}).Ratio() != 0 {
+		t.Error("expected zero ratio for empty coverage")
+	}
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"math"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// HandlesMetadataKey is the metadata key AddHandles records the handles
+// added to a clip under, as "head" and "tail" times. It is prefixed with
+// the library name so it cannot collide with a "handles" key written by
+// another tool.
+const HandlesMetadataKey = "gotio_handles"
+
+// HandleShortfall is a clip whose media could not give it the handles
+// asked for.
+type HandleShortfall struct {
+	Clip *gotio.Clip
+	// Head and Tail are the frames missing at each end.
+	Head, Tail float64
+}
+
+// AddHandles extends the source range of every clip in the timeline by
+// frames at each end, at the clip's rate, as far as its available range
+// allows. Clips without an available range are left unchanged. The
+// handles added are recorded in each clip's metadata under
+// HandlesMetadataKey for TrimHandles. Returns the clips that got less
+// than asked for.
+//
+// Extending a clip moves what follows it in its track, so AddHandles
+// returns ErrLocked, changing nothing, if that touches a locked track or
+// region, unless WithEditOverrideLocks is set.
+func AddHandles(timeline *gotio.Timeline, frames float64, opts ...EditOption) ([]HandleShortfall, error) {
+	if timeline == nil {
+		return nil, newEditError("add_handles", "timeline is nil")
+	}
+	if frames < 0 {
+		return nil, newEditError("add_handles", "frames is negative")
+	}
+	config := newEditConfig(opts)
+
+	type handles struct {
+		clip        *gotio.Clip
+		sourceRange opentime.TimeRange
+		head, tail  float64
+	}
+	var shortfalls []HandleShortfall
+	var extend []handles
+	for _, clip := range timeline.FindClips(nil, false) {
+		sourceRange, err := clip.TrimmedRange()
+		if err != nil {
+			return nil, err
+		}
+		rate := sourceRange.StartTime().Rate()
+		head, tail := 0.0, 0.0
+		if available, err := clip.AvailableRange(); err == nil {
+			before := sourceRange.StartTime().Sub(available.StartTime()).RescaledTo(rate).Value()
+			after := available.EndTimeExclusive().Sub(sourceRange.EndTimeExclusive()).RescaledTo(rate).Value()
+			head = math.Max(0, math.Min(frames, before))
+			tail = math.Max(0, math.Min(frames, after))
+		}
+		if head < frames || tail < frames {
+			shortfalls = append(shortfalls, HandleShortfall{Clip: clip, Head: frames - head, Tail: frames - tail})
+		}
+		if head == 0 && tail == 0 {
+			continue
+		}
+		if err := checkHandleLocks(clip, config.OverrideLocks); err != nil {
+			return nil, err
+		}
+		extend = append(extend, handles{clip: clip, sourceRange: sourceRange, head: head, tail: tail})
+	}
+
+	for _, h := range extend {
+		rate := h.sourceRange.StartTime().Rate()
+		extended := opentime.NewTimeRange(
+			h.sourceRange.StartTime().Sub(opentime.NewRationalTime(h.head, rate)),
+			h.sourceRange.Duration().Add(opentime.NewRationalTime(h.head+h.tail, h.sourceRange.Duration().Rate())),
+		)
+		h.clip.SetSourceRange(&extended)
+
+		addedHead, addedTail := opentime.NewRationalTime(h.head, rate), opentime.NewRationalTime(h.tail, rate)
+		if previousHead, previousTail, ok := recordedHandles(h.clip); ok {
+			addedHead, addedTail = previousHead.Add(addedHead), previousTail.Add(addedTail)
+		}
+		md := h.clip.Metadata()
+		if md == nil {
+			md = gotio.AnyDictionary{}
+			h.clip.SetMetadata(md)
+		}
+		md[HandlesMetadataKey] = gotio.AnyDictionary{"head": addedHead, "tail": addedTail}
+	}
+	return shortfalls, nil
+}
+
+// TrimHandles removes the handles recorded by AddHandles from every clip
+// in the timeline and deletes the record. Returns the number of clips
+// trimmed. Like AddHandles, it returns ErrLocked, changing nothing, if a
+// trim touches a locked track or region, unless WithEditOverrideLocks is
+// set.
+func TrimHandles(timeline *gotio.Timeline, opts ...EditOption) (int, error) {
+	if timeline == nil {
+		return 0, newEditError("trim_handles", "timeline is nil")
+	}
+	config := newEditConfig(opts)
+
+	var trims []*gotio.Clip
+	var inner []opentime.TimeRange
+	for _, clip := range timeline.FindClips(nil, false) {
+		head, tail, ok := recordedHandles(clip)
+		if !ok {
+			continue
+		}
+		sourceRange, err := clip.TrimmedRange()
+		if err != nil {
+			return 0, err
+		}
+		duration := sourceRange.Duration().Sub(head).Sub(tail)
+		if duration.Value() < 0 {
+			return 0, newEditErrorAt("trim_handles", "handles are longer than the clip", sourceRange.Duration())
+		}
+		if err := checkHandleLocks(clip, config.OverrideLocks); err != nil {
+			return 0, err
+		}
+		trims = append(trims, clip)
+		inner = append(inner, opentime.NewTimeRange(
+			sourceRange.StartTime().Add(head.RescaledTo(sourceRange.StartTime().Rate())),
+			duration.RescaledTo(sourceRange.Duration().Rate()),
+		))
+	}
+
+	for i, clip := range trims {
+		clip.SetSourceRange(&inner[i])
+		delete(clip.Metadata(), HandlesMetadataKey)
+	}
+	return len(trims), nil
+}
+
+// checkHandleLocks checks the span from the head of clip to the end of its
+// track, which changing the clip's duration moves.
+func checkHandleLocks(clip *gotio.Clip, override bool) error {
+	var none opentime.RationalTime
+	return checkItemLocks(clip, clip.Parent(), none, none, true, override)
+}
+
+// recordedHandles returns the head and tail handles recorded on the clip.
+func recordedHandles(clip *gotio.Clip) (head, tail opentime.RationalTime, ok bool) {
+	values, ok := clip.Metadata().GetDictionary(HandlesMetadataKey)
+	if !ok {
+		return head, tail, false
+	}
+	head, headOK := values.GetRationalTime("head")
+	tail, tailOK := values.GetRationalTime("tail")
+	return head, tail, headOK && tailOK
+}
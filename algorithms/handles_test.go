@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func TestAddAndTrimHandles(t *testing.T) {
+	available := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(100, 24))
+	newClip := func(name string, start float64, ref gotio.MediaReference) *gotio.Clip {
+		sr := opentime.NewTimeRange(opentime.NewRationalTime(start, 24), opentime.NewRationalTime(40, 24))
+		return gotio.NewClip(name, ref, &sr, nil, nil, nil, "", nil)
+	}
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	track.AppendChild(newClip("short", 10, gotio.NewExternalReference("", "/media/a.mov", &available, nil)))
+	track.AppendChild(newClip("full", 20, gotio.NewExternalReference("", "/media/b.mov", &available, nil)))
+	track.AppendChild(newClip("offline", 20, nil))
+	timeline := gotio.NewTimeline("pull", nil, nil)
+	timeline.Tracks().AppendChild(track)
+
+	shortfalls, err := AddHandles(timeline, 12)
+	if err != nil {
+		t.Fatalf("AddHandles error: %v", err)
+	}
+	if len(shortfalls) != 2 {
+		t.Fatalf("expected 2 shortfalls, got %+v", shortfalls)
+	}
+	if s := shortfalls[0]; s.Clip.Name() != "short" || s.Head != 2 || s.Tail != 0 {
+		t.Errorf("unexpected shortfall %+v", s)
+	}
+	if s := shortfalls[1]; s.Clip.Name() != "offline" || s.Head != 12 || s.Tail != 12 {
+		t.Errorf("unexpected shortfall %+v", s)
+	}
+
+	clips := timeline.FindClips(nil, false)
+	for i, want := range [][2]float64{{0, 62}, {8, 64}, {20, 40}} {
+		sr := clips[i].SourceRange()
+		if sr.StartTime().Value() != want[0] || sr.Duration().Value() != want[1] {
+			t.Errorf("%s: source range %v, want start %v duration %v", clips[i].Name(), sr, want[0], want[1])
+		}
+	}
+
+	// The record survives a round trip through JSON.
+	data, err := gotio.ToJSONBytes(timeline)
+	if err != nil {
+		t.Fatalf("ToJSONBytes error: %v", err)
+	}
+	obj, err := gotio.FromJSONBytes(data)
+	if err != nil {
+		t.Fatalf("FromJSONBytes error: %v", err)
+	}
+	timeline = obj.(*gotio.Timeline)
+
+	n, err := TrimHandles(timeline)
+	if err != nil || n != 2 {
+		t.Fatalf("TrimHandles = %d, %v", n, err)
+	}
+	for i, clip := range timeline.FindClips(nil, false) {
+		sr := clip.SourceRange()
+		if want := []float64{10, 20, 20}[i]; sr.StartTime().Value() != want || sr.Duration().Value() != 40 {
+			t.Errorf("%s: source range %v after trimming", clip.Name(), sr)
+		}
+		if _, ok := clip.Metadata()[HandlesMetadataKey]; ok {
+			t.Errorf("%s: handles record not removed", clip.Name())
+		}
+	}
+
+	if _, err := AddHandles(timeline, -1); err == nil {
+		t.Error("expected an error for negative frames")
+	}
+	if _, err := TrimHandles(nil); err == nil {
+		t.Error("expected an error for a nil timeline")
+	}
+}
+
+func TestHandlesRespectLocks(t *testing.T) {
+	available := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(100, 24))
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(20, 24), opentime.NewRationalTime(40, 24))
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	track.AppendChild(gotio.NewClip("a", gotio.NewExternalReference("", "/media/a.mov", &available, nil), &sr, nil, nil, nil, "", nil))
+	track.AppendChild(gotio.NewClip("b", gotio.NewExternalReference("", "/media/b.mov", &available, nil), &sr, nil, nil, nil, "", nil))
+	timeline := gotio.NewTimeline("pull", nil, nil)
+	timeline.Tracks().AppendChild(track)
+
+	// Extending the first clip moves the second, which is locked
+	track.AddLockRegion(gotio.LockRegion{Range: opentime.NewTimeRange(opentime.NewRationalTime(40, 24), opentime.NewRationalTime(40, 24))})
+	if _, err := AddHandles(timeline, 8); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected ErrLocked, got %v", err)
+	}
+	for _, clip := range timeline.FindClips(nil, false) {
+		if got := clip.SourceRange(); *got != sr {
+			t.Errorf("%s: source range changed to %v", clip.Name(), got)
+		}
+		if _, ok := clip.Metadata()[HandlesMetadataKey]; ok {
+			t.Errorf("%s: handles recorded", clip.Name())
+		}
+	}
+
+	if _, err := AddHandles(timeline, 8, WithEditOverrideLocks(true)); err != nil {
+		t.Fatalf("AddHandles with override error: %v", err)
+	}
+	if n, err := TrimHandles(timeline); !errors.Is(err, ErrLocked) || n != 0 {
+		t.Fatalf("expected ErrLocked, got %d, %v", n, err)
+	}
+	if n, err := TrimHandles(timeline, WithEditOverrideLocks(true)); err != nil || n != 2 {
+		t.Fatalf("TrimHandles with override = %d, %v", n, err)
+	}
+	for _, clip := range timeline.FindClips(nil, false) {
+		if got := clip.SourceRange(); *got != sr {
+			t.Errorf("%s: source range %v after trimming", clip.Name(), got)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// PlaceholderConfig holds configuration for FillPlaceholders.
+type PlaceholderConfig struct {
+	// Trim fits clips longer than their placeholder by trimming their
+	// tails. By default a clip must match the placeholder's duration.
+	Trim bool
+	// AllowUnfilled leaves placeholders without a clip in place instead of
+	// failing.
+	AllowUnfilled bool
+}
+
+// PlaceholderOption is a functional option for FillPlaceholders.
+type PlaceholderOption func(*PlaceholderConfig)
+
+// WithPlaceholderTrim sets whether clips longer than their placeholder
+// are trimmed to fit it.
+func WithPlaceholderTrim(trim bool) PlaceholderOption {
+	return func(c *PlaceholderConfig) {
+		c.Trim = trim
+	}
+}
+
+// WithAllowUnfilled sets whether placeholders without a clip are left in
+// place.
+func WithAllowUnfilled(allow bool) PlaceholderOption {
+	return func(c *PlaceholderConfig) {
+		c.AllowUnfilled = allow
+	}
+}
+
+// Placeholders returns the placeholder gaps of the timeline by slot, in
+// timeline order.
+func Placeholders(timeline *gotio.Timeline) map[string][]*gotio.Gap {
+	slots := make(map[string][]*gotio.Gap)
+	for _, gap := range timeline.FindGaps(nil, false) {
+		if slot, ok := gap.PlaceholderSlot(); ok {
+			slots[slot] = append(slots[slot], gap)
+		}
+	}
+	return slots
+}
+
+// FillPlaceholders replaces each placeholder gap of the template timeline
+// with a copy of the clip for its slot in fills. A slot may appear more
+// than once. Every placeholder is checked before any is replaced, so on
+// error the timeline is unchanged: each needs a clip, unless
+// WithAllowUnfilled is set, whose duration matches the placeholder's, or
+// exceeds it with WithPlaceholderTrim. Clips for slots the timeline does
+// not have are ignored.
+func FillPlaceholders(timeline *gotio.Timeline, fills map[string]*gotio.Clip, opts ...PlaceholderOption) error {
+	if timeline == nil {
+		return newEditError("fill_placeholders", "timeline is nil")
+	}
+	cfg := PlaceholderConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	type fill struct {
+		gap  *gotio.Gap
+		clip *gotio.Clip
+	}
+	var planned []fill
+	var unfilled []string
+	for _, gap := range timeline.FindGaps(nil, false) {
+		slot, ok := gap.PlaceholderSlot()
+		if !ok {
+			continue
+		}
+		clip := fills[slot]
+		if clip == nil {
+			if !cfg.AllowUnfilled && !slices.Contains(unfilled, slot) {
+				unfilled = append(unfilled, slot)
+			}
+			continue
+		}
+		want, err := gap.Duration()
+		if err != nil {
+			return newEditErrorForItem("fill_placeholders", fmt.Sprintf("slot %q: %v", slot, err), gap)
+		}
+		have, err := clip.Duration()
+		if err != nil {
+			return newEditErrorForItem("fill_placeholders", fmt.Sprintf("slot %q: clip %q: %v", slot, clip.Name(), err), gap)
+		}
+		if cmp := have.Cmp(want); cmp < 0 || cmp > 0 && !cfg.Trim {
+			return newEditErrorForItem("fill_placeholders", fmt.Sprintf("slot %q: clip %q lasts %v, placeholder needs %v", slot, clip.Name(), have, want), gap)
+		}
+		planned = append(planned, fill{gap: gap, clip: clip})
+	}
+	if len(unfilled) > 0 {
+		return newEditError("fill_placeholders", "no clip for slots "+strings.Join(unfilled, ", "))
+	}
+
+	for _, f := range planned {
+		clip := f.clip.Clone().(*gotio.Clip)
+		want, _ := f.gap.Duration()
+		trimmed, err := clip.TrimmedRange()
+		if err != nil {
+			return err
+		}
+		if trimmed.Duration().Cmp(want) > 0 {
+			sourceRange := opentime.NewTimeRange(trimmed.StartTime(), want.RescaledToRate(trimmed.StartTime()))
+			clip.SetSourceRange(&sourceRange)
+		}
+
+		parent := f.gap.Parent()
+		index, err := parent.IndexOfChild(f.gap)
+		if err != nil {
+			return err
+		}
+		if err := parent.SetChild(index, clip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
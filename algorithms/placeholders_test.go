@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func promoTemplate() *gotio.Timeline {
+	timeline := gotio.NewTimeline("promo", nil, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	track.AppendChild(gotio.NewPlaceholder("hero", opentime.NewRationalTime(48, 24)))
+	track.AppendChild(gotio.NewGapWithDuration(opentime.NewRationalTime(12, 24)))
+	track.AppendChild(gotio.NewPlaceholder("endcard", opentime.NewRationalTime(24, 24)))
+	track.AppendChild(gotio.NewPlaceholder("hero", opentime.NewRationalTime(48, 24)))
+	timeline.Tracks().AppendChild(track)
+	return timeline
+}
+
+func shot(name string, frames float64) *gotio.Clip {
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(100, 24), opentime.NewRationalTime(frames, 24))
+	return gotio.NewClip(name, nil, &sr, nil, nil, nil, "", nil)
+}
+
+func TestFillPlaceholders(t *testing.T) {
+	timeline := promoTemplate()
+	if slots := Placeholders(timeline); len(slots["hero"]) != 2 || len(slots["endcard"]) != 1 || len(slots) != 2 {
+		t.Fatalf("Placeholders = %v", slots)
+	}
+
+	fills := map[string]*gotio.Clip{"hero": shot("hero_v2", 48), "endcard": shot("card", 30)}
+	if err := FillPlaceholders(timeline, fills); err == nil {
+		t.Fatal("expected error for a clip longer than its placeholder")
+	}
+	if err := FillPlaceholders(timeline, map[string]*gotio.Clip{"hero": shot("hero_v2", 48)}); err == nil {
+		t.Fatal("expected error for an unfilled slot")
+	}
+	if err := FillPlaceholders(timeline, map[string]*gotio.Clip{"hero": shot("hero_v2", 40), "endcard": shot("card", 24)}); err == nil {
+		t.Fatal("expected error for a clip shorter than its placeholder")
+	}
+	if len(timeline.FindClips(nil, false)) != 0 {
+		t.Fatal("a failed fill changed the timeline")
+	}
+
+	if err := FillPlaceholders(timeline, fills, WithPlaceholderTrim(true)); err != nil {
+		t.Fatalf("FillPlaceholders error: %v", err)
+	}
+	children := timeline.Tracks().Children()[0].(*gotio.Track).Children()
+	names := []string{"hero_v2", "", "card", "hero_v2"}
+	for i, child := range children {
+		if child.Name() != names[i] {
+			t.Errorf("child %d = %q, want %q", i, child.Name(), names[i])
+		}
+	}
+	if children[0] == children[3] || children[0] == fills["hero"] {
+		t.Error("each placeholder should get its own copy of the clip")
+	}
+	card := children[2].(*gotio.Clip)
+	if sr := card.SourceRange(); sr.StartTime().Value() != 100 || sr.Duration().Value() != 24 {
+		t.Errorf("trimmed card range = %v, want 100 for 24", sr)
+	}
+	if fills["endcard"].SourceRange().Duration().Value() != 30 {
+		t.Error("the fill clip itself was trimmed")
+	}
+	if d, _ := timeline.Duration(); d.Value() != 132 {
+		t.Errorf("duration = %v, want the template's 132", d)
+	}
+}
+
+func TestFillPlaceholdersAllowUnfilled(t *testing.T) {
+	timeline := promoTemplate()
+	if err := FillPlaceholders(timeline, map[string]*gotio.Clip{"endcard": shot("card", 24), "extra": shot("x", 1)}, WithAllowUnfilled(true)); err != nil {
+		t.Fatalf("FillPlaceholders error: %v", err)
+	}
+	if slots := Placeholders(timeline); len(slots["hero"]) != 2 || len(slots) != 1 {
+		t.Errorf("remaining placeholders = %v, want the hero slots", slots)
+	}
+
+	gap := gotio.NewPlaceholder("hero", opentime.NewRationalTime(1, 24))
+	gap.SetPlaceholderSlot("")
+	if _, ok := gap.PlaceholderSlot(); ok || len(gap.Metadata()) != 0 {
+		t.Errorf("unmarked gap metadata = %v", gap.Metadata())
+	}
+}
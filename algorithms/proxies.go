@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"log/slog"
+
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/metrics"
+)
+
+// ProxyMediaKey is the media reference key proxies are stored under by
+// default.
+const ProxyMediaKey = "proxy"
+
+// ProxyMapping returns the proxy media reference for a clip given its
+// active reference, or nil if the clip has no proxy.
+type ProxyMapping func(clip *gotio.Clip, original gotio.MediaReference) gotio.MediaReference
+
+// ProxyConfig holds configuration for AttachProxies.
+type ProxyConfig struct {
+	// Key is the media reference key proxies are stored under.
+	Key string
+	// Activate switches each clip given a proxy to it.
+	Activate bool
+	// Logger receives an event for each clip given a proxy. Nil discards
+	// them.
+	Logger *slog.Logger
+}
+
+// ProxyOption is a functional option for AttachProxies.
+type ProxyOption func(*ProxyConfig)
+
+// WithProxyKey sets the media reference key proxies are stored under.
+func WithProxyKey(key string) ProxyOption {
+	return func(c *ProxyConfig) {
+		c.Key = key
+	}
+}
+
+// WithActivateProxies sets whether clips are switched to their proxies.
+func WithActivateProxies(activate bool) ProxyOption {
+	return func(c *ProxyConfig) {
+		c.Activate = activate
+	}
+}
+
+// WithProxyLogger sets the logger AttachProxies reports to.
+func WithProxyLogger(logger *slog.Logger) ProxyOption {
+	return func(c *ProxyConfig) {
+		c.Logger = logger
+	}
+}
+
+// AttachProxies calls mapping for every clip in the timeline and stores
+// the references it returns under the proxy key, replacing any already
+// there. The active references are unchanged unless WithActivateProxies
+// is set. Returns the number of clips given a proxy.
+func AttachProxies(timeline *gotio.Timeline, mapping ProxyMapping, opts ...ProxyOption) (int, error) {
+	if timeline == nil {
+		return 0, newEditError("attach_proxies", "timeline is nil")
+	}
+	cfg := ProxyConfig{Key: ProxyMediaKey}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.Key == "" {
+		return 0, newEditError("attach_proxies", "proxy key is empty")
+	}
+	logger := loggerOrDiscard(cfg.Logger)
+
+	attached := 0
+	clips := timeline.FindClips(nil, false)
+	for _, clip := range clips {
+		proxy := mapping(clip, clip.MediaReference())
+		if proxy == nil {
+			logger.Debug("clip has no proxy", "clip", clip.Name())
+			continue
+		}
+		if err := clip.AddMediaReference(cfg.Key, proxy); err != nil {
+			return attached, err
+		}
+		if cfg.Activate {
+			if err := clip.SetActiveMediaReferenceKey(cfg.Key); err != nil {
+				return attached, err
+			}
+		}
+		logger.Info("proxy attached", "clip", clip.Name(), "key", cfg.Key, "active", cfg.Activate)
+		attached++
+	}
+	metrics.Add(metrics.ClipsProcessed, float64(len(clips)), metrics.Operation("attach_proxies"))
+	return attached, nil
+}
+
+// SwitchMediaReferences makes key the active media reference key of every
+// clip in the timeline that has a reference under it, such as ProxyMediaKey
+// to cut with proxies or gotio.DefaultMediaKey to go back to the originals.
+// Clips without a reference under key keep their active reference and are
+// returned.
+func SwitchMediaReferences(timeline *gotio.Timeline, key string) ([]*gotio.Clip, error) {
+	if timeline == nil {
+		return nil, newEditError("switch_media_references", "timeline is nil")
+	}
+	var unswitched []*gotio.Clip
+	for _, clip := range timeline.FindClips(nil, false) {
+		if _, ok := clip.MediaReferences()[key]; !ok {
+			unswitched = append(unswitched, clip)
+			continue
+		}
+		if err := clip.SetActiveMediaReferenceKey(key); err != nil {
+			return unswitched, err
+		}
+	}
+	return unswitched, nil
+}
+
+// loggerOrDiscard returns logger, or a logger that discards everything if
+// it is nil.
+func loggerOrDiscard(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return logger
+}
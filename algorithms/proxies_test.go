@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+func TestProxyRoundTrip(t *testing.T) {
+	timeline := gotio.NewTimeline("proxies", nil, nil)
+	track := createTestTrack([]float64{24, 24, 24}, 24)
+	clips := track.Children()
+	for i, name := range []string{"/media/a.mov", "/media/b.mov"} {
+		clips[i].(*gotio.Clip).SetMediaReference(gotio.NewExternalReference("", name, nil, nil))
+	}
+	timeline.Tracks().AppendChild(track)
+
+	n, err := AttachProxies(timeline, func(clip *gotio.Clip, original gotio.MediaReference) gotio.MediaReference {
+		ref, ok := original.(*gotio.ExternalReference)
+		if !ok {
+			return nil
+		}
+		return gotio.NewExternalReference("", strings.Replace(ref.TargetURL(), "/media/", "/proxies/", 1), nil, nil)
+	})
+	if err != nil {
+		t.Fatalf("AttachProxies error: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 proxies attached, got %d", n)
+	}
+	a := clips[0].(*gotio.Clip)
+	if a.ActiveMediaReferenceKey() != gotio.DefaultMediaKey {
+		t.Errorf("expected active key unchanged, got %q", a.ActiveMediaReferenceKey())
+	}
+
+	unswitched, err := SwitchMediaReferences(timeline, ProxyMediaKey)
+	if err != nil {
+		t.Fatalf("SwitchMediaReferences error: %v", err)
+	}
+	if len(unswitched) != 1 || unswitched[0] != clips[2] {
+		t.Errorf("expected the clip without a proxy to be returned, got %v", unswitched)
+	}
+	if got := a.MediaReference().(*gotio.ExternalReference).TargetURL(); got != "/proxies/a.mov" {
+		t.Errorf("active reference = %q, want proxy", got)
+	}
+
+	if unswitched, _ := SwitchMediaReferences(timeline, gotio.DefaultMediaKey); len(unswitched) != 0 {
+		t.Errorf("expected every clip to switch back, got %v", unswitched)
+	}
+	if got := a.MediaReference().(*gotio.ExternalReference).TargetURL(); got != "/media/a.mov" {
+		t.Errorf("active reference = %q, want original", got)
+	}
+
+	n, err = AttachProxies(timeline, func(clip *gotio.Clip, original gotio.MediaReference) gotio.MediaReference {
+		return gotio.NewExternalReference("", "/offline/"+clip.Name(), nil, nil)
+	}, WithProxyKey("offline"), WithActivateProxies(true))
+	if err != nil || n != 3 {
+		t.Fatalf("AttachProxies = %d, %v", n, err)
+	}
+	if a.ActiveMediaReferenceKey() != "offline" {
+		t.Errorf("expected proxies to be activated, got %q", a.ActiveMediaReferenceKey())
+	}
+
+	if _, err := AttachProxies(timeline, nil, WithProxyKey("")); err == nil {
+		t.Error("expected an error for an empty key")
+	}
+	if _, err := SwitchMediaReferences(nil, ProxyMediaKey); err == nil {
+		t.Error("expected an error for a nil timeline")
+	}
+}
+
+func TestAttachProxiesLogger(t *testing.T) {
+	timeline := gotio.NewTimeline("proxies", nil, nil)
+	timeline.Tracks().AppendChild(createTestTrack([]float64{24, 24}, 24))
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	n, err := AttachProxies(timeline, func(clip *gotio.Clip, original gotio.MediaReference) gotio.MediaReference {
+		return gotio.NewExternalReference("", "/proxies/"+clip.Name(), nil, nil)
+	}, WithProxyLogger(logger))
+	if err != nil || n != 2 {
+		t.Fatalf("AttachProxies = %d, %v", n, err)
+	}
+	if got := strings.Count(buf.String(), "proxy attached"); got != 2 {
+		t.Errorf("expected 2 events, got %d:\n%s", got, buf.String())
+	}
+}
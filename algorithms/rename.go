@@ -0,0 +1,309 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/metrics"
+)
+
+// OriginalNameMetadataKey is the clip metadata key RenameClips records the
+// name a clip had before its first rename under, with WithKeepOriginalName.
+const OriginalNameMetadataKey = "original_name"
+
+// RenameFunc returns the new name of a clip, or "" to leave it unchanged.
+// NameTemplate.Expand and the function of RenameFromCSV are RenameFuncs.
+type RenameFunc func(clip *gotio.Clip) (string, error)
+
+// RenameConfig holds options for RenameClips.
+type RenameConfig struct {
+	// KeepOriginalName records the name of each renamed clip under
+	// OriginalNameMetadataKey, unless an earlier rename recorded one.
+	KeepOriginalName bool
+}
+
+// RenameOption is a functional option for RenameClips.
+type RenameOption func(*RenameConfig)
+
+// WithKeepOriginalName sets whether the name a clip had before it was
+// first renamed is kept in its metadata.
+func WithKeepOriginalName(keep bool) RenameOption {
+	return func(c *RenameConfig) {
+		c.KeepOriginalName = keep
+	}
+}
+
+// RenameClips calls rename for every clip in the timeline and gives the
+// clip the name it returns. Every name is worked out before any clip is
+// renamed, so an error from rename leaves the timeline unchanged. Returns
+// the number of clips renamed.
+func RenameClips(timeline *gotio.Timeline, rename RenameFunc, opts ...RenameOption) (int, error) {
+	if timeline == nil {
+		return 0, newEditError("rename", "timeline is nil")
+	}
+	var cfg RenameConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	clips := timeline.FindClips(nil, false)
+	names := make([]string, len(clips))
+	for i, clip := range clips {
+		name, err := rename(clip)
+		if err != nil {
+			return 0, err
+		}
+		names[i] = name
+	}
+
+	renamed := 0
+	for i, clip := range clips {
+		if names[i] == "" || names[i] == clip.Name() {
+			continue
+		}
+		if cfg.KeepOriginalName {
+			md := clip.Metadata()
+			if md == nil {
+				md = gotio.AnyDictionary{}
+				clip.SetMetadata(md)
+			}
+			if _, ok := md[OriginalNameMetadataKey]; !ok {
+				md[OriginalNameMetadataKey] = clip.Name()
+			}
+		}
+		clip.SetName(names[i])
+		renamed++
+	}
+	metrics.Add(metrics.ClipsProcessed, float64(len(clips)), metrics.Operation("rename"))
+	return renamed, nil
+}
+
+// NameTemplate is a template of clip names such as
+// "{scene}_{shot:04}_{take}", parsed by ParseNameTemplate.
+//
+// A token names a value of the clip: {name} is its current name, {track}
+// the name of the track holding it, and any other token a value of its
+// metadata at a dot-separated path, as in {cmx_3600.reel}. A token may end
+// in a format: ":upper" or ":lower" to change case, or ":0N" to pad a
+// value starting with a digit with zeros to N characters. Whole numbers
+// are written without a decimal point. "{{" and "}}" are literal braces.
+type NameTemplate struct {
+	template string
+	parts    []templatePart
+}
+
+// templatePart is literal text, or a token with its format.
+type templatePart struct {
+	literal string
+	token   string
+	format  string
+}
+
+// ParseNameTemplate parses template, checking its tokens and formats.
+func ParseNameTemplate(template string) (*NameTemplate, error) {
+	t := &NameTemplate{template: template}
+	var literal strings.Builder
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		switch {
+		case (c == '{' || c == '}') && i+1 < len(template) && template[i+1] == c:
+			literal.WriteByte(c)
+			i++
+		case c == '}':
+			return nil, fmt.Errorf("name template %q: unmatched } at %d", template, i)
+		case c == '{':
+			end := strings.IndexByte(template[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("name template %q: unclosed { at %d", template, i)
+			}
+			token, format, _ := strings.Cut(template[i+1:i+end], ":")
+			if token == "" {
+				return nil, fmt.Errorf("name template %q: empty token at %d", template, i)
+			}
+			if !validFormat(format) {
+				return nil, fmt.Errorf("name template %q: unknown format %q", template, format)
+			}
+			if literal.Len() > 0 {
+				t.parts = append(t.parts, templatePart{literal: literal.String()})
+				literal.Reset()
+			}
+			t.parts = append(t.parts, templatePart{token: token, format: format})
+			i += end
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	if literal.Len() > 0 {
+		t.parts = append(t.parts, templatePart{literal: literal.String()})
+	}
+	return t, nil
+}
+
+// validFormat reports whether format is one a token can end in.
+func validFormat(format string) bool {
+	switch format {
+	case "", "upper", "lower":
+		return true
+	}
+	if len(format) < 2 || format[0] != '0' {
+		return false
+	}
+	_, err := strconv.Atoi(format[1:])
+	return err == nil
+}
+
+// String returns the template as parsed.
+func (t *NameTemplate) String() string {
+	return t.template
+}
+
+// Expand returns the template filled in with the values of clip. A token
+// the clip has no value for is an error, naming the clip and the token.
+func (t *NameTemplate) Expand(clip *gotio.Clip) (string, error) {
+	var b strings.Builder
+	for _, part := range t.parts {
+		if part.token == "" {
+			b.WriteString(part.literal)
+			continue
+		}
+		value, ok := tokenValue(clip, part.token)
+		if !ok {
+			return "", newEditErrorForItem("rename", fmt.Sprintf("no value for {%s} in %q", part.token, t.template), clip)
+		}
+		b.WriteString(formatToken(value, part.format))
+	}
+	return b.String(), nil
+}
+
+// tokenValue returns the value of token for clip as text.
+func tokenValue(clip *gotio.Clip, token string) (string, bool) {
+	switch token {
+	case "name":
+		return clip.Name(), true
+	case "track":
+		if track, ok := clip.Parent().(*gotio.Track); ok {
+			return track.Name(), true
+		}
+		return "", false
+	}
+	value, ok := clip.Metadata().Lookup(token)
+	if !ok || value == nil {
+		return "", false
+	}
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return strconv.FormatInt(int64(v), 10), true
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case int, int64, bool:
+		return fmt.Sprint(v), true
+	}
+	return "", false
+}
+
+// formatToken applies a token format to value.
+func formatToken(value, format string) string {
+	switch format {
+	case "":
+		return value
+	case "upper":
+		return strings.ToUpper(value)
+	case "lower":
+		return strings.ToLower(value)
+	}
+	width, _ := strconv.Atoi(format[1:])
+	if value == "" || value[0] < '0' || value[0] > '9' || len(value) >= width {
+		return value
+	}
+	return strings.Repeat("0", width-len(value)) + value
+}
+
+// SetMetadataFromTemplate stores the template filled in with the values of
+// each clip in the timeline in the clip's metadata, at a dot-separated
+// path such as "vfx.shot_id". As with RenameClips, a clip without a value
+// for a token leaves the timeline unchanged. Returns the number of clips
+// set.
+func SetMetadataFromTemplate(timeline *gotio.Timeline, path string, template *NameTemplate) (int, error) {
+	if timeline == nil {
+		return 0, newEditError("set_metadata", "timeline is nil")
+	}
+	clips := timeline.FindClips(nil, false)
+	metadata := make([]gotio.AnyDictionary, len(clips))
+	for i, clip := range clips {
+		value, err := template.Expand(clip)
+		if err != nil {
+			return 0, err
+		}
+		if metadata[i], err = clip.Metadata().WithValue(path, value); err != nil {
+			return 0, err
+		}
+	}
+	for i, clip := range clips {
+		clip.SetMetadata(metadata[i])
+	}
+	return len(clips), nil
+}
+
+// RenameFromCSV reads a table of renames as CSV with a header row, and
+// returns the RenameFunc giving each clip named in the "name" column the
+// name in the "new_name" column on the same row. Clips not in the table
+// keep their names. A name listed twice is an error.
+func RenameFromCSV(r io.Reader) (RenameFunc, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("rename CSV is empty")
+		}
+		return nil, err
+	}
+	nameColumn, newNameColumn := -1, -1
+	for i, column := range header {
+		switch strings.ToLower(strings.TrimSpace(column)) {
+		case "name":
+			nameColumn = i
+		case "new_name":
+			newNameColumn = i
+		}
+	}
+	if nameColumn < 0 || newNameColumn < 0 {
+		return nil, fmt.Errorf("rename CSV needs name and new_name columns, got %q", header)
+	}
+
+	names := map[string]string{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if max(nameColumn, newNameColumn) >= len(record) {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("rename CSV line %d: missing columns", line)
+		}
+		name, newName := record[nameColumn], strings.TrimSpace(record[newNameColumn])
+		if _, ok := names[name]; ok {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("rename CSV line %d: %q is listed twice", line, name)
+		}
+		names[name] = newName
+	}
+	return func(clip *gotio.Clip) (string, error) {
+		return names[clip.Name()], nil
+	}, nil
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+// renameTimeline returns a timeline of clips with scene, shot and take
+// metadata as a lined script gives them.
+func renameTimeline() *gotio.Timeline {
+	timeline := gotio.NewTimeline("cut", nil, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	for i, md := range []gotio.AnyDictionary{
+		{"scene": "12a", "shot": 10.0, "take": 3.0},
+		{"scene": "12a", "shot": "20", "take": 1.0, "vfx": gotio.AnyDictionary{"vendor": "dneg"}},
+		{"scene": "14", "shot": "B", "take": 2.0},
+	} {
+		clip := shot("A001C00"+string(rune('1'+i)), 24)
+		clip.SetMetadata(md)
+		track.AppendChild(clip)
+	}
+	timeline.Tracks().AppendChild(track)
+	return timeline
+}
+
+func clipNames(timeline *gotio.Timeline) []string {
+	var names []string
+	for _, clip := range timeline.FindClips(nil, false) {
+		names = append(names, clip.Name())
+	}
+	return names
+}
+
+func TestRenameClipsWithTemplate(t *testing.T) {
+	tmpl, err := ParseNameTemplate("{scene:upper}_{shot:04}_t{take}")
+	if err != nil {
+		t.Fatalf("ParseNameTemplate error: %v", err)
+	}
+	timeline := renameTimeline()
+	n, err := RenameClips(timeline, tmpl.Expand, WithKeepOriginalName(true))
+	if err != nil {
+		t.Fatalf("RenameClips error: %v", err)
+	}
+	want := []string{"12A_0010_t3", "12A_0020_t1", "14_B_t2"}
+	if got := clipNames(timeline); n != 3 || strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("RenameClips = %d, %v, want %v", n, got, want)
+	}
+
+	// A second rename keeps the first original name
+	lower, _ := ParseNameTemplate("{name:lower}")
+	RenameClips(timeline, lower.Expand, WithKeepOriginalName(true))
+	clip := timeline.FindClips(nil, false)[0]
+	if original, _ := clip.Metadata().GetString(OriginalNameMetadataKey); clip.Name() != "12a_0010_t3" || original != "A001C001" {
+		t.Errorf("clip = %q, original %q", clip.Name(), original)
+	}
+
+	vendor, _ := ParseNameTemplate("{track}/{{{vfx.vendor}}}")
+	if _, err := RenameClips(timeline, vendor.Expand); err == nil || !strings.Contains(err.Error(), "{vfx.vendor}") {
+		t.Errorf("expected an error naming the missing token, got %v", err)
+	}
+	if name := timeline.FindClips(nil, false)[1].Name(); name != "12a_0020_t1" {
+		t.Errorf("clip renamed by a failed rename: %q", name)
+	}
+	if name, err := vendor.Expand(timeline.FindClips(nil, false)[1]); err != nil || name != "V1/{dneg}" {
+		t.Errorf("Expand = %q, %v, want V1/{dneg}", name, err)
+	}
+
+	for _, bad := range []string{"{scene", "scene}", "{}", "{shot:4}", "{shot:title}"} {
+		if _, err := ParseNameTemplate(bad); err == nil {
+			t.Errorf("ParseNameTemplate(%q) expected an error", bad)
+		}
+	}
+}
+
+func TestSetMetadataFromTemplate(t *testing.T) {
+	timeline := renameTimeline()
+	tmpl, _ := ParseNameTemplate("sc{scene}_sh{shot:03}")
+	if n, err := SetMetadataFromTemplate(timeline, "vfx.shot_id", tmpl); err != nil || n != 3 {
+		t.Fatalf("SetMetadataFromTemplate = %d, %v", n, err)
+	}
+	clip := timeline.FindClips(nil, false)[1]
+	if id, _ := clip.Metadata().Lookup("vfx.shot_id"); id != "sc12a_sh020" {
+		t.Errorf("shot_id = %v", id)
+	}
+	if vendor, _ := clip.Metadata().Lookup("vfx.vendor"); vendor != "dneg" {
+		t.Errorf("vendor = %v, want it kept", vendor)
+	}
+}
+
+func TestRenameFromCSV(t *testing.T) {
+	rename, err := RenameFromCSV(strings.NewReader("new_name,name,notes\n010_0010,A001C001,hero\n010_0030,A001C003,\n"))
+	if err != nil {
+		t.Fatalf("RenameFromCSV error: %v", err)
+	}
+	timeline := renameTimeline()
+	if n, err := RenameClips(timeline, rename); err != nil || n != 2 {
+		t.Fatalf("RenameClips = %d, %v", n, err)
+	}
+	if got := strings.Join(clipNames(timeline), " "); got != "010_0010 A001C002 010_0030" {
+		t.Errorf("names = %s", got)
+	}
+
+	for _, bad := range []string{"", "name\nA001C001\n", "name,new_name\na,b\na,c\n"} {
+		if _, err := RenameFromCSV(strings.NewReader(bad)); err == nil {
+			t.Errorf("RenameFromCSV(%q) expected an error", bad)
+		}
+	}
+}
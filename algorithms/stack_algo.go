@@ -4,6 +4,8 @@
 package algorithms
 
 import (
+	"slices"
+
 	"github.com/Avalanche-io/gotio/opentime"
 	"github.com/Avalanche-io/gotio"
 )
@@ -11,7 +13,7 @@ import (
 // FlattenStack flattens a stack (multitrack composition) down to a single track.
 // Tracks are composited in order (later tracks on top of earlier tracks).
 // Overlapping segments are handled by trimming away overlaps from lower tracks.
-func FlattenStack(stack *gotio.Stack) (*gotio.Track, error) {
+func FlattenStack(stack *gotio.Stack, opts ...FlattenOption) (*gotio.Track, error) {
 	children := stack.Children()
 	if len(children) == 0 {
 		return gotio.NewTrack("Flattened", nil, gotio.TrackKindVideo, nil, nil), nil
@@ -25,26 +27,44 @@ func FlattenStack(stack *gotio.Stack) (*gotio.Track, error) {
 		}
 	}
 
-	return FlattenTracks(tracks)
+	return FlattenTracks(tracks, opts...)
 }
 
 // FlattenTracks flattens multiple tracks down to a single track.
 // Later tracks take priority over earlier tracks (later tracks are "on top").
-func FlattenTracks(tracks []*gotio.Track) (*gotio.Track, error) {
+// Items keep their place in time; time not covered by any track is filled
+// with gaps. Effects on the tracks themselves apply to everything in them:
+// they are carried onto each clip and nested composition of the track, and
+// with BakeTimeEffects a track's time effects are baked as those of a
+// nested composition are. See FlattenConfig for handling nested
+// compositions and transitions.
+func FlattenTracks(tracks []*gotio.Track, opts ...FlattenOption) (*gotio.Track, error) {
+	cfg := newFlattenConfig(opts)
+	if cfg.RespectEnabled {
+		tracks = slices.DeleteFunc(slices.Clone(tracks), func(track *gotio.Track) bool {
+			return !track.EnabledInHierarchy()
+		})
+	}
 	if len(tracks) == 0 {
 		return gotio.NewTrack("Flattened", nil, gotio.TrackKindVideo, nil, nil), nil
 	}
 
 	if len(tracks) == 1 {
+		if prepared := cfg.prepare(tracks[0]); prepared != tracks[0] {
+			return prepared, nil
+		}
 		return tracks[0].Clone().(*gotio.Track), nil
 	}
 
 	// Start with the first track
-	result := tracks[0].Clone().(*gotio.Track)
+	result := cfg.prepare(tracks[0])
+	if result == tracks[0] {
+		result = result.Clone().(*gotio.Track)
+	}
 
 	// For each subsequent track, composite it on top
 	for i := 1; i < len(tracks); i++ {
-		composited, err := compositeTrackOnTop(result, tracks[i])
+		composited, err := compositeTrackOnTop(result, cfg.prepare(tracks[i]), opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -55,12 +75,20 @@ func FlattenTracks(tracks []*gotio.Track) (*gotio.Track, error) {
 	return result, nil
 }
 
+// compositePiece is part of an item placed in a composited track.
+type compositePiece struct {
+	rng  opentime.TimeRange
+	item gotio.Composable
+}
+
 // compositeTrackOnTop composites the top track onto the base track.
 // Items from the top track take priority over items from the base track.
-func compositeTrackOnTop(base, top *gotio.Track) (*gotio.Track, error) {
+func compositeTrackOnTop(base, top *gotio.Track, opts ...FlattenOption) (*gotio.Track, error) {
+	cfg := newFlattenConfig(opts)
+	var pieces []compositePiece
+
 	// Get time ranges for all items in the top track
 	topRanges := make([]opentime.TimeRange, 0)
-	topItems := make([]gotio.Composable, 0)
 
 	for i, child := range top.Children() {
 		// Skip non-visible items
@@ -84,7 +112,7 @@ func compositeTrackOnTop(base, top *gotio.Track) (*gotio.Track, error) {
 		}
 
 		topRanges = append(topRanges, childRange)
-		topItems = append(topItems, child)
+		pieces = append(pieces, compositePiece{childRange, child.Clone().(gotio.Composable)})
 	}
 
 	// Build result track with base items trimmed around top items
@@ -123,15 +151,28 @@ func compositeTrackOnTop(base, top *gotio.Track) (*gotio.Track, error) {
 			// Clone and trim the item
 			cloned := child.Clone().(gotio.Composable)
 			if item, ok := cloned.(gotio.Item); ok {
-				trimItemToRange(item, childRange, r)
+				cfg.trim(item, childRange, r)
 			}
-			result.AppendChild(cloned)
+			pieces = append(pieces, compositePiece{r, cloned})
 		}
 	}
 
-	// Add all top items
-	for _, item := range topItems {
-		result.AppendChild(item.Clone().(gotio.Composable))
+	// Place the pieces in time order, filling holes with gaps
+	slices.SortStableFunc(pieces, func(a, b compositePiece) int {
+		return a.rng.StartTime().Cmp(b.rng.StartTime())
+	})
+	var cursor opentime.RationalTime
+	for i, piece := range pieces {
+		start := piece.rng.StartTime()
+		if i == 0 {
+			cursor = opentime.NewRationalTime(0, start.Rate())
+		}
+		if hole := start.Sub(cursor); hole.ToSeconds() > opentime.DefaultEpsilon {
+			gapRange := opentime.NewTimeRange(opentime.NewRationalTime(0, hole.Rate()), hole)
+			result.AppendChild(gotio.NewGap("", &gapRange, nil, nil, nil, nil))
+		}
+		result.AppendChild(piece.item)
+		cursor = piece.rng.EndTimeExclusive()
 	}
 
 	return result, nil
@@ -175,6 +216,12 @@ func subtractRange(a, b opentime.TimeRange) []opentime.TimeRange {
 
 // trimItemToRange trims an item to a sub-range within its original range.
 func trimItemToRange(item gotio.Item, originalRange, newRange opentime.TimeRange) {
+	trimItemToRangeScaled(item, originalRange, newRange, 1)
+}
+
+// trimItemToRangeScaled trims an item whose media runs at scalar times the
+// speed of its parent to a sub-range within its original range.
+func trimItemToRangeScaled(item gotio.Item, originalRange, newRange opentime.TimeRange, scalar float64) {
 	var itemSourceRange opentime.TimeRange
 	if sr := item.SourceRange(); sr != nil {
 		itemSourceRange = *sr
@@ -188,6 +235,7 @@ func trimItemToRange(item gotio.Item, originalRange, newRange opentime.TimeRange
 
 	// Calculate offset from original range start
 	offsetFromStart := newRange.StartTime().Sub(originalRange.StartTime())
+	offsetFromStart = opentime.NewRationalTime(offsetFromStart.Value()*scalar, offsetFromStart.Rate())
 
 	// Calculate new source range
 	newSourceStart := itemSourceRange.StartTime().Add(offsetFromStart.RescaledTo(itemSourceRange.StartTime().Rate()))
@@ -196,3 +196,198 @@ func TestSubtractRange(t *testing.T) {
 		t.Errorf("Expected 2 results, got %d", len(result))
 	}
 }
+
+func TestFlattenTracksKeepsTimeOrder(t *testing.T) {
+	track1 := createTestTrack([]float64{96}, 24)
+	track2 := gotio.NewTrack("track2", nil, gotio.TrackKindVideo, nil, nil)
+	gapRange := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(24, 24))
+	track2.AppendChild(gotio.NewGap("", &gapRange, nil, nil, nil, nil))
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(24, 24))
+	track2.AppendChild(gotio.NewClip("top", nil, &sr, nil, nil, nil, "", nil))
+
+	result, err := FlattenTracks([]*gotio.Track{track1, track2})
+	if err != nil {
+		t.Fatalf("FlattenTracks error: %v", err)
+	}
+	var names []string
+	for _, child := range result.Children() {
+		names = append(names, child.Name())
+	}
+	if len(names) != 3 || names[0] != "clip_A" || names[1] != "top" || names[2] != "clip_A" {
+		t.Fatalf("children = %v, want [clip_A top clip_A]", names)
+	}
+	if start := result.Children()[2].(*gotio.Clip).SourceRange().StartTime().Value(); start != 48 {
+		t.Errorf("trailing piece starts at %v, want 48", start)
+	}
+}
+
+func TestFlattenBakeTimeEffects(t *testing.T) {
+	// A nested stack played at double speed for 24 frames shows 48 frames
+	// of its content, starting 10 frames in.
+	inner := gotio.NewTrack("inner", nil, gotio.TrackKindVideo, nil, nil)
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(100, 24), opentime.NewRationalTime(100, 24))
+	inner.AppendChild(gotio.NewClip("source", nil, &sr, nil, nil, nil, "", nil))
+	nestedRange := opentime.NewTimeRange(opentime.NewRationalTime(10, 24), opentime.NewRationalTime(24, 24))
+	warp := gotio.NewLinearTimeWarp("", "LinearTimeWarp", 2, nil)
+	nested := gotio.NewStack("nested", &nestedRange, nil, []gotio.Effect{warp}, nil, nil)
+	nested.AppendChild(inner)
+
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	track.AppendChild(nested)
+
+	result, err := FlattenTracks([]*gotio.Track{track}, WithBakeTimeEffects(true))
+	if err != nil {
+		t.Fatalf("FlattenTracks error: %v", err)
+	}
+	if len(result.Children()) != 1 {
+		t.Fatalf("expected 1 child, got %d", len(result.Children()))
+	}
+	clip, ok := result.Children()[0].(*gotio.Clip)
+	if !ok {
+		t.Fatalf("expected a clip, got %T", result.Children()[0])
+	}
+	got := clip.SourceRange()
+	if got.StartTime().Value() != 110 || got.Duration().Value() != 24 {
+		t.Errorf("source range = %v, want start 110 duration 24", got)
+	}
+	if scalar, _ := timeScalar(clip.Effects()); scalar != 2 {
+		t.Errorf("time scalar = %v, want 2", scalar)
+	}
+	if _, ok := track.Children()[0].(*gotio.Stack); !ok {
+		t.Error("expected the input track to be left unchanged")
+	}
+
+	// A freeze frame holds the first frame for the whole duration.
+	nested.SetEffects([]gotio.Effect{gotio.NewFreezeFrame("", nil)})
+	result, err = FlattenTracks([]*gotio.Track{track}, WithBakeTimeEffects(true))
+	if err != nil {
+		t.Fatalf("FlattenTracks error: %v", err)
+	}
+	clip = result.Children()[0].(*gotio.Clip)
+	if got := clip.SourceRange(); got.StartTime().Value() != 110 || got.Duration().Value() != 24 {
+		t.Errorf("frozen source range = %v, want start 110 duration 24", got)
+	}
+	if _, ok := clip.Effects()[0].(*gotio.FreezeFrame); !ok || len(clip.Effects()) != 1 {
+		t.Errorf("expected a single freeze frame, got %v", clip.Effects())
+	}
+}
+
+func TestFlattenResolveTransitions(t *testing.T) {
+	track := createTestTrack([]float64{48, 48}, 24)
+	offset := opentime.NewRationalTime(6, 24)
+	track.InsertChild(1, gotio.NewTransition("dissolve", gotio.TransitionTypeSMPTEDissolve, offset, offset, nil))
+
+	result, err := FlattenTracks([]*gotio.Track{track}, WithResolveTransitions(true))
+	if err != nil {
+		t.Fatalf("FlattenTracks error: %v", err)
+	}
+	first := result.Children()[0].(*gotio.Clip)
+	v, ok := first.Metadata().Lookup(FlattenMetadataKey + "." + TransitionOutKey + ".source_range")
+	if !ok {
+		t.Fatalf("expected the outgoing transition to be recorded, got %v", first.Metadata())
+	}
+	if r := v.(opentime.TimeRange); r.StartTime().Value() != 42 || r.Duration().Value() != 12 {
+		t.Errorf("outgoing source range = %v, want start 42 duration 12", r)
+	}
+	second := result.Children()[2].(*gotio.Clip)
+	v, ok = second.Metadata().Lookup(FlattenMetadataKey + "." + TransitionInKey + ".source_range")
+	if !ok {
+		t.Fatalf("expected the incoming transition to be recorded, got %v", second.Metadata())
+	}
+	if r := v.(opentime.TimeRange); r.StartTime().Value() != -6 || r.Duration().Value() != 12 {
+		t.Errorf("incoming source range = %v, want start -6 duration 12", r)
+	}
+	if _, ok := track.Children()[0].(*gotio.Clip).Metadata()[FlattenMetadataKey]; ok {
+		t.Error("expected the input track to be left unchanged")
+	}
+
+	// A track above covering the cut drops the records of trimmed ends.
+	top := gotio.NewTrack("top", nil, gotio.TrackKindVideo, nil, nil)
+	gapRange := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(40, 24))
+	top.AppendChild(gotio.NewGap("", &gapRange, nil, nil, nil, nil))
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(16, 24))
+	top.AppendChild(gotio.NewClip("cover", nil, &sr, nil, nil, nil, "", nil))
+
+	result, err = FlattenTracks([]*gotio.Track{track, top}, WithResolveTransitions(true))
+	if err != nil {
+		t.Fatalf("FlattenTracks error: %v", err)
+	}
+	for _, clip := range result.FindClips(nil, false) {
+		if _, ok := clip.Metadata()[FlattenMetadataKey]; ok {
+			t.Errorf("expected no transition record on %s, got %v", clip.Name(), clip.Metadata())
+		}
+	}
+}
+
+func TestFlattenRespectEnabled(t *testing.T) {
+	bottom := createTestTrack([]float64{24, 24}, 24)
+	bottom.Children()[1].(*gotio.Clip).SetEnabled(false)
+	top := createTestTrack([]float64{48}, 24)
+	top.SetEnabled(false)
+
+	result, err := FlattenTracks([]*gotio.Track{bottom, top})
+	if err != nil {
+		t.Fatalf("FlattenTracks error: %v", err)
+	}
+	if clips := result.FindClips(nil, false); len(clips) != 1 || clips[0].Name() != top.Children()[0].Name() {
+		t.Errorf("expected the disabled top track to cover everything without the option, got %d clips", len(clips))
+	}
+
+	result, err = FlattenTracks([]*gotio.Track{bottom, top}, WithRespectEnabled())
+	if err != nil {
+		t.Fatalf("FlattenTracks error: %v", err)
+	}
+	children := result.Children()
+	if len(children) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(children))
+	}
+	if _, ok := children[1].(*gotio.Gap); !ok {
+		t.Errorf("expected the disabled clip to become a gap, got %T", children[1])
+	}
+	if d, _ := result.Duration(); d.Value() != 48 {
+		t.Errorf("Duration = %v, want 48", d)
+	}
+}
+
+func TestFlattenTrackEffects(t *testing.T) {
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(48, 24))
+	base := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	base.AppendChild(gotio.NewClip("base", nil, &sr, nil, nil, nil, "", nil))
+	top := gotio.NewTrack("V2", nil, gotio.TrackKindVideo, nil, nil)
+	top.AppendChild(gotio.NewClip("top", nil, &sr, nil, nil, nil, "", nil))
+	top.SetEffects([]gotio.Effect{gotio.NewEffect("reformat", "Reformat", nil)})
+
+	// The effect of the top track carries onto its clip.
+	result, err := FlattenTracks([]*gotio.Track{base, top})
+	if err != nil {
+		t.Fatalf("FlattenTracks error: %v", err)
+	}
+	clip := result.Children()[0].(*gotio.Clip)
+	if clip.Name() != "top" || len(clip.Effects()) != 1 || clip.Effects()[0].EffectName() != "Reformat" {
+		t.Errorf("expected top with the track's effect, got %s %v", clip.Name(), clip.Effects())
+	}
+	if len(top.Children()[0].(*gotio.Clip).Effects()) != 0 {
+		t.Error("expected the input clip to be left unchanged")
+	}
+
+	// A double speed track plays its 48 frames of content in 24, leaving
+	// the rest of its duration empty.
+	top.SetEffects([]gotio.Effect{gotio.NewLinearTimeWarp("", "LinearTimeWarp", 2, nil)})
+	result, err = FlattenTracks([]*gotio.Track{top}, WithBakeTimeEffects(true))
+	if err != nil {
+		t.Fatalf("FlattenTracks error: %v", err)
+	}
+	if len(result.Effects()) != 0 || len(result.Children()) != 2 {
+		t.Fatalf("expected a baked clip and a gap and no track effects, got %v %v", result.Children(), result.Effects())
+	}
+	if _, ok := result.Children()[1].(*gotio.Gap); !ok {
+		t.Errorf("expected a gap after the clip, got %T", result.Children()[1])
+	}
+	clip = result.Children()[0].(*gotio.Clip)
+	if got := clip.SourceRange(); got.StartTime().Value() != 0 || got.Duration().Value() != 24 {
+		t.Errorf("source range = %v, want start 0 duration 24", got)
+	}
+	if scalar, _ := timeScalar(clip.Effects()); scalar != 2 {
+		t.Errorf("time scalar = %v, want 2", scalar)
+	}
+}
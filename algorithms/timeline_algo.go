@@ -61,49 +61,47 @@ func TimelineTrimmedToRange(timeline *gotio.Timeline, trimRange opentime.TimeRan
 
 // TimelineAudioTracks returns all audio tracks from a timeline.
 func TimelineAudioTracks(timeline *gotio.Timeline) []*gotio.Track {
-	tracks := timeline.Tracks()
-	if tracks == nil {
-		return nil
-	}
-
-	var audioTracks []*gotio.Track
-	for _, child := range tracks.Children() {
-		track, ok := child.(*gotio.Track)
-		if !ok {
-			continue
-		}
-		if track.Kind() == gotio.TrackKindAudio {
-			audioTracks = append(audioTracks, track)
-		}
-	}
-
-	return audioTracks
+	return TimelineTracksOfKind(timeline, gotio.TrackKindAudio)
 }
 
 // TimelineVideoTracks returns all video tracks from a timeline.
 func TimelineVideoTracks(timeline *gotio.Timeline) []*gotio.Track {
+	return TimelineTracksOfKind(timeline, gotio.TrackKindVideo)
+}
+
+// TimelineTracksOfKind returns the tracks of a timeline of the given kind,
+// such as gotio.TrackKindSubtitle.
+func TimelineTracksOfKind(timeline *gotio.Timeline, kind string) []*gotio.Track {
 	tracks := timeline.Tracks()
 	if tracks == nil {
 		return nil
 	}
 
-	var videoTracks []*gotio.Track
+	var result []*gotio.Track
 	for _, child := range tracks.Children() {
 		track, ok := child.(*gotio.Track)
 		if !ok {
 			continue
 		}
-		if track.Kind() == gotio.TrackKindVideo {
-			videoTracks = append(videoTracks, track)
+		if track.Kind() == kind {
+			result = append(result, track)
 		}
 	}
 
-	return videoTracks
+	return result
 }
 
 // FlattenTimelineVideoTracks flattens all video tracks in a timeline to a single track.
-// Audio tracks are preserved unchanged.
-func FlattenTimelineVideoTracks(timeline *gotio.Timeline) (*gotio.Timeline, error) {
+// Tracks of other kinds are preserved unchanged.
+func FlattenTimelineVideoTracks(timeline *gotio.Timeline, opts ...FlattenOption) (*gotio.Timeline, error) {
+	return FlattenTimelineTracksOfKind(timeline, gotio.TrackKindVideo, opts...)
+}
+
+// FlattenTimelineTracksOfKind flattens the tracks of the given kind in a
+// timeline to a single track, in place of the first of them. Tracks of
+// other kinds, such as subtitle and data tracks, and other children are
+// preserved unchanged, in order.
+func FlattenTimelineTracksOfKind(timeline *gotio.Timeline, kind string, opts ...FlattenOption) (*gotio.Timeline, error) {
 	// Clone the timeline
 	cloned := timeline.Clone().(*gotio.Timeline)
 
@@ -112,37 +110,20 @@ func FlattenTimelineVideoTracks(timeline *gotio.Timeline) (*gotio.Timeline, erro
 		return cloned, nil
 	}
 
-	// Separate video and audio tracks
-	var videoTracks []*gotio.Track
-	var audioTracks []*gotio.Track
+	// Separate the tracks to flatten from the rest
+	var kindTracks []*gotio.Track
+	first := -1
 	var otherChildren []gotio.Composable
 
 	for _, child := range tracks.Children() {
-		track, ok := child.(*gotio.Track)
-		if !ok {
-			otherChildren = append(otherChildren, child)
+		if track, ok := child.(*gotio.Track); ok && track.Kind() == kind {
+			if first < 0 {
+				first = len(otherChildren)
+			}
+			kindTracks = append(kindTracks, track)
 			continue
 		}
-
-		switch track.Kind() {
-		case gotio.TrackKindVideo:
-			videoTracks = append(videoTracks, track)
-		case gotio.TrackKindAudio:
-			audioTracks = append(audioTracks, track)
-		default:
-			otherChildren = append(otherChildren, track)
-		}
-	}
-
-	// Flatten video tracks
-	var flattenedVideo *gotio.Track
-	if len(videoTracks) > 0 {
-		var err error
-		flattenedVideo, err = FlattenTracks(videoTracks)
-		if err != nil {
-			return nil, err
-		}
-		flattenedVideo.SetKind(gotio.TrackKindVideo)
+		otherChildren = append(otherChildren, child)
 	}
 
 	// Create new tracks stack
@@ -155,20 +136,19 @@ func FlattenTimelineVideoTracks(timeline *gotio.Timeline) (*gotio.Timeline, erro
 		nil,
 	)
 
-	// Add flattened video track
-	if flattenedVideo != nil {
-		newTracks.AppendChild(flattenedVideo)
-	}
-
-	// Add audio tracks
-	for _, track := range audioTracks {
-		newTracks.AppendChild(track.Clone().(gotio.Composable))
-	}
-
-	// Add other children
-	for _, child := range otherChildren {
+	for i, child := range otherChildren {
+		if i == first {
+			if err := appendFlattened(newTracks, kindTracks, kind, opts); err != nil {
+				return nil, err
+			}
+		}
 		newTracks.AppendChild(child.Clone().(gotio.Composable))
 	}
+	if first == len(otherChildren) {
+		if err := appendFlattened(newTracks, kindTracks, kind, opts); err != nil {
+			return nil, err
+		}
+	}
 
 	// Create result timeline
 	result := gotio.NewTimeline(
@@ -180,3 +160,13 @@ func FlattenTimelineVideoTracks(timeline *gotio.Timeline) (*gotio.Timeline, erro
 
 	return result, nil
 }
+
+// appendFlattened appends tracks flattened to one track of the kind.
+func appendFlattened(stack *gotio.Stack, tracks []*gotio.Track, kind string, opts []FlattenOption) error {
+	flattened, err := FlattenTracks(tracks, opts...)
+	if err != nil {
+		return err
+	}
+	flattened.SetKind(kind)
+	return stack.AppendChild(flattened)
+}
@@ -4,6 +4,7 @@
 package algorithms
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/Avalanche-io/gotio/opentime"
@@ -176,3 +177,37 @@ func TestFlattenTimelineVideoTracksNoVideo(t *testing.T) {
 		t.Errorf("Expected 1 audio track, got %d", len(audioTracks))
 	}
 }
+
+func TestFlattenTimelineKeepsOtherKinds(t *testing.T) {
+	timeline := gotio.NewTimeline("test", nil, nil)
+	for _, kind := range []string{gotio.TrackKindVideo, gotio.TrackKindSubtitle, gotio.TrackKindVideo, "Telemetry", gotio.TrackKindAudio} {
+		track := gotio.NewTrack(kind, nil, kind, nil, nil)
+		sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(24, 24))
+		track.AppendChild(gotio.NewClip(kind+"_clip", nil, &sr, nil, nil, nil, "", nil))
+		timeline.Tracks().AppendChild(track)
+	}
+
+	result, err := FlattenTimelineVideoTracks(timeline)
+	if err != nil {
+		t.Fatalf("FlattenTimelineVideoTracks error: %v", err)
+	}
+	var kinds []string
+	for _, child := range result.Tracks().Children() {
+		kinds = append(kinds, child.(*gotio.Track).Kind())
+	}
+	want := []string{gotio.TrackKindVideo, gotio.TrackKindSubtitle, "Telemetry", gotio.TrackKindAudio}
+	if !slices.Equal(kinds, want) {
+		t.Errorf("kinds = %v, want %v", kinds, want)
+	}
+
+	result, err = FlattenTimelineTracksOfKind(timeline, gotio.TrackKindSubtitle)
+	if err != nil {
+		t.Fatalf("FlattenTimelineTracksOfKind error: %v", err)
+	}
+	if n := len(TimelineTracksOfKind(result, gotio.TrackKindSubtitle)); n != 1 {
+		t.Errorf("subtitle tracks = %d, want 1", n)
+	}
+	if n := len(TimelineVideoTracks(result)); n != 2 {
+		t.Errorf("video tracks = %d, want 2 left unflattened", n)
+	}
+}
@@ -0,0 +1,391 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// GlobalStartTimePolicy determines how the global start times of combined
+// timelines are reconciled.
+type GlobalStartTimePolicy int
+
+const (
+	// GlobalStartTimeFirst uses the first timeline's global start time and
+	// ignores the others.
+	GlobalStartTimeFirst GlobalStartTimePolicy = iota
+	// GlobalStartTimeRespect places each timeline at its own global start
+	// time relative to the earliest one, filling the difference with gaps.
+	GlobalStartTimeRespect
+	// GlobalStartTimeNone leaves the result without a global start time.
+	GlobalStartTimeNone
+)
+
+// String returns the string representation of a GlobalStartTimePolicy.
+func (p GlobalStartTimePolicy) String() string {
+	switch p {
+	case GlobalStartTimeFirst:
+		return "First"
+	case GlobalStartTimeRespect:
+		return "Respect"
+	case GlobalStartTimeNone:
+		return "None"
+	default:
+		return fmt.Sprintf("GlobalStartTimePolicy(%d)", p)
+	}
+}
+
+// MetadataMergePolicy determines how the metadata of combined timelines is merged.
+type MetadataMergePolicy int
+
+const (
+	// MetadataMergeFirst keeps only the first timeline's metadata.
+	MetadataMergeFirst MetadataMergePolicy = iota
+	// MetadataMergeOverlay merges all metadata, later timelines overriding earlier keys.
+	MetadataMergeOverlay
+	// MetadataMergeNone leaves the result without metadata.
+	MetadataMergeNone
+)
+
+// String returns the string representation of a MetadataMergePolicy.
+func (p MetadataMergePolicy) String() string {
+	switch p {
+	case MetadataMergeFirst:
+		return "First"
+	case MetadataMergeOverlay:
+		return "Overlay"
+	case MetadataMergeNone:
+		return "None"
+	default:
+		return fmt.Sprintf("MetadataMergePolicy(%d)", p)
+	}
+}
+
+// CombineConfig holds configuration for ConcatenateTimelines and StackTimelines.
+type CombineConfig struct {
+	Name            string
+	GlobalStartTime GlobalStartTimePolicy
+	Metadata        MetadataMergePolicy
+	// Logger receives events for skipped children and padded tracks. Nil
+	// discards them.
+	Logger *slog.Logger
+}
+
+// CombineOption is a functional option for ConcatenateTimelines and StackTimelines.
+type CombineOption func(*CombineConfig)
+
+// WithCombinedName sets the name of the resulting timeline.
+// By default the first timeline's name is used.
+func WithCombinedName(name string) CombineOption {
+	return func(c *CombineConfig) {
+		c.Name = name
+	}
+}
+
+// WithGlobalStartTimePolicy sets how global start times are reconciled.
+func WithGlobalStartTimePolicy(policy GlobalStartTimePolicy) CombineOption {
+	return func(c *CombineConfig) {
+		c.GlobalStartTime = policy
+	}
+}
+
+// WithMetadataMergePolicy sets how timeline metadata is merged.
+func WithMetadataMergePolicy(policy MetadataMergePolicy) CombineOption {
+	return func(c *CombineConfig) {
+		c.Metadata = policy
+	}
+}
+
+// WithCombineLogger sets the logger the combine operations report to.
+func WithCombineLogger(logger *slog.Logger) CombineOption {
+	return func(c *CombineConfig) {
+		c.Logger = logger
+	}
+}
+
+// ConcatenateTimelines returns a new timeline with the given timelines played
+// one after another. Tracks are matched by kind and position: the Nth video
+// track of each timeline continues the Nth video track of the result. Tracks
+// that are shorter than their timeline, or missing from it, are padded with
+// gaps so every timeline starts at the same offset on all tracks.
+// Non-track children of the timelines' stacks are skipped.
+func ConcatenateTimelines(timelines []*gotio.Timeline, opts ...CombineOption) (*gotio.Timeline, error) {
+	config := &CombineConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	timelines = nonNilTimelines(timelines)
+	result := newCombinedTimeline(timelines, config)
+	if len(timelines) == 0 {
+		return result, nil
+	}
+
+	logger := loggerOrDiscard(config.Logger)
+	earliest := earliestGlobalStartTime(timelines)
+	var offset opentime.RationalTime
+	var tracks []*gotio.Track
+	byKind := make(map[string][]*gotio.Track)
+
+	for _, timeline := range timelines {
+		duration, err := timeline.Duration()
+		if err != nil {
+			return nil, err
+		}
+		if timeline.Tracks() != nil {
+			for _, child := range timeline.Tracks().Children() {
+				if _, ok := child.(*gotio.Track); !ok {
+					logger.Warn("non-track child skipped", "timeline", timeline.Name(), "child", child.Name(), "schema", child.SchemaName())
+				}
+			}
+		}
+
+		if config.GlobalStartTime == GlobalStartTimeRespect && timeline.GlobalStartTime() != nil {
+			start := subtractTime(*timeline.GlobalStartTime(), earliest)
+			if start.Cmp(offset) < 0 {
+				return nil, newEditErrorAt("concatenate", "timeline overlaps the previous timeline", start)
+			}
+			padTracks(tracks, subtractTime(start, offset))
+			offset = start
+		}
+
+		used := make(map[*gotio.Track]bool)
+		counts := make(map[string]int)
+		for _, track := range timelineTracks(timeline) {
+			kind := track.Kind()
+			n := counts[kind]
+			counts[kind]++
+
+			if n >= len(byKind[kind]) {
+				out := gotio.NewTrack(track.Name(), nil, kind, gotio.CloneAnyDictionary(track.Metadata()), nil)
+				padTrack(out, offset)
+				byKind[kind] = append(byKind[kind], out)
+				tracks = append(tracks, out)
+			}
+			out := byKind[kind][n]
+			used[out] = true
+
+			trackDuration, err := appendTrackContents(out, track, offset)
+			if err != nil {
+				return nil, err
+			}
+			padTrack(out, subtractTime(duration, trackDuration))
+		}
+
+		for _, out := range tracks {
+			if !used[out] {
+				logger.Debug("track padded", "timeline", timeline.Name(), "track", out.Name(), "duration", duration.ToSeconds())
+				padTrack(out, duration)
+			}
+		}
+
+		offset = offset.Add(duration)
+	}
+
+	for _, track := range tracks {
+		if err := result.Tracks().AppendChild(track); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// StackTimelines returns a new timeline with the tracks of the given timelines
+// layered in a single Stack. Tracks of later timelines are placed above the
+// tracks of earlier ones. Non-track children of the timelines' stacks are
+// layered unchanged.
+func StackTimelines(timelines []*gotio.Timeline, opts ...CombineOption) (*gotio.Timeline, error) {
+	config := &CombineConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	timelines = nonNilTimelines(timelines)
+	result := newCombinedTimeline(timelines, config)
+	earliest := earliestGlobalStartTime(timelines)
+
+	for _, timeline := range timelines {
+		if timeline.Tracks() == nil {
+			continue
+		}
+
+		var offset opentime.RationalTime
+		if config.GlobalStartTime == GlobalStartTimeRespect && timeline.GlobalStartTime() != nil {
+			offset = subtractTime(*timeline.GlobalStartTime(), earliest)
+		}
+
+		for _, child := range timeline.Tracks().Children() {
+			track, ok := child.(*gotio.Track)
+			if !ok {
+				if err := result.Tracks().AppendChild(child.Clone().(gotio.Composable)); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			out := gotio.NewTrack(track.Name(), nil, track.Kind(), gotio.CloneAnyDictionary(track.Metadata()), nil)
+			padTrack(out, offset)
+			if _, err := appendTrackContents(out, track, offset); err != nil {
+				return nil, err
+			}
+			if err := result.Tracks().AppendChild(out); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// nonNilTimelines returns the timelines with nil entries removed.
+func nonNilTimelines(timelines []*gotio.Timeline) []*gotio.Timeline {
+	var result []*gotio.Timeline
+	for _, timeline := range timelines {
+		if timeline != nil {
+			result = append(result, timeline)
+		}
+	}
+	return result
+}
+
+// newCombinedTimeline creates the empty result timeline for a combine operation.
+func newCombinedTimeline(timelines []*gotio.Timeline, config *CombineConfig) *gotio.Timeline {
+	name := config.Name
+	if name == "" && len(timelines) > 0 {
+		name = timelines[0].Name()
+	}
+
+	var globalStartTime *opentime.RationalTime
+	switch config.GlobalStartTime {
+	case GlobalStartTimeFirst:
+		if len(timelines) > 0 && timelines[0].GlobalStartTime() != nil {
+			gst := *timelines[0].GlobalStartTime()
+			globalStartTime = &gst
+		}
+	case GlobalStartTimeRespect:
+		for _, timeline := range timelines {
+			if timeline.GlobalStartTime() != nil {
+				gst := earliestGlobalStartTime(timelines)
+				globalStartTime = &gst
+				break
+			}
+		}
+	}
+
+	var metadata gotio.AnyDictionary
+	switch config.Metadata {
+	case MetadataMergeFirst:
+		if len(timelines) > 0 {
+			metadata = gotio.CloneAnyDictionary(timelines[0].Metadata())
+		}
+	case MetadataMergeOverlay:
+		metadata = make(gotio.AnyDictionary)
+		for _, timeline := range timelines {
+			for k, v := range timeline.Metadata() {
+				metadata[k] = v
+			}
+		}
+	}
+
+	return gotio.NewTimeline(name, globalStartTime, metadata)
+}
+
+// subtractTime returns a - b, treating times without a rate as zero.
+func subtractTime(a, b opentime.RationalTime) opentime.RationalTime {
+	if b.Rate() <= 0 {
+		return a
+	}
+	if a.Rate() <= 0 {
+		return b.Neg()
+	}
+	return a.Sub(b)
+}
+
+// earliestGlobalStartTime returns the earliest global start time of the
+// timelines. Timelines without a global start time are ignored.
+func earliestGlobalStartTime(timelines []*gotio.Timeline) opentime.RationalTime {
+	var earliest *opentime.RationalTime
+	for _, timeline := range timelines {
+		gst := timeline.GlobalStartTime()
+		if gst == nil {
+			continue
+		}
+		if earliest == nil || gst.Cmp(*earliest) < 0 {
+			earliest = gst
+		}
+	}
+	if earliest == nil {
+		return opentime.RationalTime{}
+	}
+	return *earliest
+}
+
+// timelineTracks returns the tracks directly under a timeline's stack.
+func timelineTracks(timeline *gotio.Timeline) []*gotio.Track {
+	if timeline.Tracks() == nil {
+		return nil
+	}
+	var tracks []*gotio.Track
+	for _, child := range timeline.Tracks().Children() {
+		if track, ok := child.(*gotio.Track); ok {
+			tracks = append(tracks, track)
+		}
+	}
+	return tracks
+}
+
+// appendTrackContents appends clones of the track's children to out, applying
+// the track's source range if it has one. Track markers are shifted by offset.
+// Returns the duration of the appended contents.
+func appendTrackContents(out, track *gotio.Track, offset opentime.RationalTime) (opentime.RationalTime, error) {
+	source := track
+	if sr := track.SourceRange(); sr != nil {
+		trimmed, err := TrackTrimmedToRange(track, *sr)
+		if err != nil {
+			return opentime.RationalTime{}, err
+		}
+		source = trimmed
+	}
+
+	for _, child := range source.Children() {
+		if err := out.AppendChild(child.Clone().(gotio.Composable)); err != nil {
+			return opentime.RationalTime{}, err
+		}
+	}
+
+	// Markers are in the track's internal time, so remove its source offset
+	var sourceStart opentime.RationalTime
+	if sr := track.SourceRange(); sr != nil {
+		sourceStart = sr.StartTime()
+	}
+	for _, marker := range track.Markers() {
+		shifted := marker.Clone().(*gotio.Marker)
+		markedRange := shifted.MarkedRange()
+		start := subtractTime(markedRange.StartTime(), sourceStart).Add(offset)
+		shifted.SetMarkedRange(opentime.NewTimeRange(start, markedRange.Duration()))
+		out.SetMarkers(append(out.Markers(), shifted))
+	}
+
+	return track.Duration()
+}
+
+// padTracks appends a gap of the given duration to each track.
+func padTracks(tracks []*gotio.Track, duration opentime.RationalTime) {
+	for _, track := range tracks {
+		padTrack(track, duration)
+	}
+}
+
+// padTrack appends a gap of the given duration to the track.
+// Nothing is appended for zero or negative durations.
+func padTrack(track *gotio.Track, duration opentime.RationalTime) {
+	if isZeroOrNegative(duration) {
+		return
+	}
+	track.AppendChild(gotio.NewGapWithDuration(duration))
+}
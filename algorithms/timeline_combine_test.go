@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// createCombineTimeline creates a timeline with one video track per entry of
+// videoDurations and, if audioDuration > 0, a single audio track.
+func createCombineTimeline(name string, videoDurations []float64, audioDuration float64) *gotio.Timeline {
+	timeline := gotio.NewTimeline(name, nil, gotio.AnyDictionary{"scene": name})
+	for _, dur := range videoDurations {
+		track := createTestTrack([]float64{dur}, 24)
+		track.SetKind(gotio.TrackKindVideo)
+		timeline.Tracks().AppendChild(track)
+	}
+	if audioDuration > 0 {
+		track := createTestTrack([]float64{audioDuration}, 24)
+		track.SetKind(gotio.TrackKindAudio)
+		timeline.Tracks().AppendChild(track)
+	}
+	return timeline
+}
+
+func TestConcatenateTimelines(t *testing.T) {
+	first := createCombineTimeline("sc010", []float64{48}, 24)
+	second := createCombineTimeline("sc020", []float64{24, 12}, 0)
+
+	result, err := ConcatenateTimelines([]*gotio.Timeline{first, second})
+	if err != nil {
+		t.Fatalf("ConcatenateTimelines error: %v", err)
+	}
+
+	if result.Name() != "sc010" {
+		t.Errorf("Name = %s, want sc010", result.Name())
+	}
+	dur, _ := result.Duration()
+	if dur.Value() != 72 {
+		t.Errorf("Duration = %v, want 72", dur.Value())
+	}
+
+	video := TimelineVideoTracks(result)
+	if len(video) != 2 {
+		t.Fatalf("expected 2 video tracks, got %d", len(video))
+	}
+	audio := TimelineAudioTracks(result)
+	if len(audio) != 1 {
+		t.Fatalf("expected 1 audio track, got %d", len(audio))
+	}
+
+	// Every track spans the full result and the second scene starts at 48
+	for _, track := range append(video, audio...) {
+		trackDur, _ := track.Duration()
+		if trackDur.Value() != 72 {
+			t.Errorf("track %s duration = %v, want 72", track.Name(), trackDur.Value())
+		}
+	}
+	second0, err := video[0].RangeOfChildAtIndex(1)
+	if err != nil {
+		t.Fatalf("RangeOfChildAtIndex error: %v", err)
+	}
+	if second0.StartTime().Value() != 48 {
+		t.Errorf("second scene starts at %v, want 48", second0.StartTime().Value())
+	}
+
+	// The second video track only exists in the second timeline
+	if _, ok := video[1].Children()[0].(*gotio.Gap); !ok {
+		t.Error("expected new track to be padded with a leading gap")
+	}
+
+	// Inputs are not modified
+	if len(first.Tracks().Children()[0].(*gotio.Track).Children()) != 1 {
+		t.Error("expected input timeline to be unchanged")
+	}
+}
+
+func TestConcatenateTimelinesMetadata(t *testing.T) {
+	first := createCombineTimeline("sc010", []float64{24}, 0)
+	second := createCombineTimeline("sc020", []float64{24}, 0)
+	second.Metadata()["reel"] = "R1"
+
+	result, err := ConcatenateTimelines(
+		[]*gotio.Timeline{first, second},
+		WithMetadataMergePolicy(MetadataMergeOverlay),
+		WithCombinedName("reel1"),
+	)
+	if err != nil {
+		t.Fatalf("ConcatenateTimelines error: %v", err)
+	}
+	if result.Name() != "reel1" {
+		t.Errorf("Name = %s, want reel1", result.Name())
+	}
+	if result.Metadata()["scene"] != "sc020" || result.Metadata()["reel"] != "R1" {
+		t.Errorf("unexpected merged metadata: %v", result.Metadata())
+	}
+
+	result, err = ConcatenateTimelines(
+		[]*gotio.Timeline{first, second},
+		WithMetadataMergePolicy(MetadataMergeNone),
+	)
+	if err != nil {
+		t.Fatalf("ConcatenateTimelines error: %v", err)
+	}
+	if len(result.Metadata()) != 0 {
+		t.Errorf("expected no metadata, got %v", result.Metadata())
+	}
+}
+
+func TestConcatenateTimelinesRespectGlobalStart(t *testing.T) {
+	first := createCombineTimeline("sc010", []float64{24}, 0)
+	gst1 := opentime.NewRationalTime(86400, 24)
+	first.SetGlobalStartTime(&gst1)
+
+	second := createCombineTimeline("sc020", []float64{24}, 0)
+	gst2 := opentime.NewRationalTime(86448, 24)
+	second.SetGlobalStartTime(&gst2)
+
+	result, err := ConcatenateTimelines(
+		[]*gotio.Timeline{first, second},
+		WithGlobalStartTimePolicy(GlobalStartTimeRespect),
+	)
+	if err != nil {
+		t.Fatalf("ConcatenateTimelines error: %v", err)
+	}
+	if result.GlobalStartTime() == nil || result.GlobalStartTime().Value() != 86400 {
+		t.Errorf("GlobalStartTime = %v, want 86400", result.GlobalStartTime())
+	}
+	dur, _ := result.Duration()
+	if dur.Value() != 72 {
+		t.Errorf("Duration = %v, want 72 (with a 24 frame gap)", dur.Value())
+	}
+
+	// Overlapping timelines cannot be placed
+	gst2 = opentime.NewRationalTime(86410, 24)
+	second.SetGlobalStartTime(&gst2)
+	_, err = ConcatenateTimelines(
+		[]*gotio.Timeline{first, second},
+		WithGlobalStartTimePolicy(GlobalStartTimeRespect),
+	)
+	if err == nil {
+		t.Error("expected error for overlapping timelines")
+	}
+}
+
+func TestStackTimelines(t *testing.T) {
+	first := createCombineTimeline("plate", []float64{48}, 48)
+	second := createCombineTimeline("comp", []float64{24}, 0)
+	gst1 := opentime.NewRationalTime(0, 24)
+	first.SetGlobalStartTime(&gst1)
+	gst2 := opentime.NewRationalTime(12, 24)
+	second.SetGlobalStartTime(&gst2)
+
+	result, err := StackTimelines([]*gotio.Timeline{first, second})
+	if err != nil {
+		t.Fatalf("StackTimelines error: %v", err)
+	}
+	if len(result.Tracks().Children()) != 3 {
+		t.Fatalf("expected 3 tracks, got %d", len(result.Tracks().Children()))
+	}
+	if result.Tracks().Children()[2].Name() != "test_track" {
+		t.Errorf("unexpected top track %s", result.Tracks().Children()[2].Name())
+	}
+	dur, _ := result.Duration()
+	if dur.Value() != 48 {
+		t.Errorf("Duration = %v, want 48", dur.Value())
+	}
+
+	result, err = StackTimelines(
+		[]*gotio.Timeline{first, second},
+		WithGlobalStartTimePolicy(GlobalStartTimeRespect),
+	)
+	if err != nil {
+		t.Fatalf("StackTimelines error: %v", err)
+	}
+	top := result.Tracks().Children()[2].(*gotio.Track)
+	if _, ok := top.Children()[0].(*gotio.Gap); !ok {
+		t.Fatal("expected offset track to start with a gap")
+	}
+	topDur, _ := top.Duration()
+	if topDur.Value() != 36 {
+		t.Errorf("offset track duration = %v, want 36", topDur.Value())
+	}
+}
+
+func TestCombineTimelinesEmpty(t *testing.T) {
+	result, err := ConcatenateTimelines(nil)
+	if err != nil {
+		t.Fatalf("ConcatenateTimelines error: %v", err)
+	}
+	if len(result.Tracks().Children()) != 0 {
+		t.Error("expected no tracks")
+	}
+
+	result, err = StackTimelines([]*gotio.Timeline{nil})
+	if err != nil {
+		t.Fatalf("StackTimelines error: %v", err)
+	}
+	if len(result.Tracks().Children()) != 0 {
+		t.Error("expected no tracks")
+	}
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"slices"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// SplitTimeline cuts the timeline at the given global times, such as reel
+// breaks, and returns the parts in order. Boundaries are in the timeline's
+// global time, offset by its global start time, and must fall strictly
+// inside the timeline. Clips crossing a boundary are split with adjusted
+// source ranges and transitions crossing one are removed. Markers go to
+// the part they start in. Each part keeps the timeline's name and
+// metadata and starts at the global time it was cut from. Non-track
+// children of the timeline's stack are skipped.
+func SplitTimeline(timeline *gotio.Timeline, boundaries []opentime.RationalTime) ([]*gotio.Timeline, error) {
+	if timeline == nil {
+		return nil, newEditError("split_timeline", "timeline is nil")
+	}
+	duration, err := timeline.Duration()
+	if err != nil {
+		return nil, err
+	}
+
+	start := opentime.NewRationalTime(0, duration.Rate())
+	if gst := timeline.GlobalStartTime(); gst != nil {
+		start = *gst
+	}
+	sorted := slices.Clone(boundaries)
+	slices.SortFunc(sorted, func(a, b opentime.RationalTime) int { return a.Cmp(b) })
+	cuts := []opentime.RationalTime{opentime.NewRationalTime(0, duration.Rate())}
+	for _, boundary := range sorted {
+		local := subtractTime(boundary, start)
+		if local.Cmp(cuts[len(cuts)-1]) <= 0 || local.Cmp(duration) >= 0 {
+			return nil, newEditErrorAt("split_timeline", "boundary is repeated or not inside the timeline", boundary)
+		}
+		cuts = append(cuts, local)
+	}
+	cuts = append(cuts, duration)
+
+	stack := timeline.Tracks()
+	parts := make([]*gotio.Timeline, len(cuts)-1)
+	for i := range parts {
+		partStart := start.Add(cuts[i].RescaledTo(start.Rate()))
+		parts[i] = gotio.NewTimeline(timeline.Name(), &partStart, gotio.CloneAnyDictionary(timeline.Metadata()))
+		parts[i].SetTracks(gotio.NewStack(stack.Name(), nil, gotio.CloneAnyDictionary(stack.Metadata()), nil, nil, nil))
+	}
+
+	for _, child := range stack.Children() {
+		track, ok := child.(*gotio.Track)
+		if !ok {
+			continue
+		}
+		pieces, err := splitTrack(track, cuts)
+		if err != nil {
+			return nil, err
+		}
+		for i, piece := range pieces {
+			if err := parts[i].Tracks().AppendChild(piece); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for i, markers := range splitMarkers(stack.Markers(), opentime.NewRationalTime(0, duration.Rate()), cuts) {
+		parts[i].Tracks().SetMarkers(markers)
+	}
+	return parts, nil
+}
+
+// splitTrack returns the parts of the track between consecutive cuts,
+// which are in the time of the track's parent.
+func splitTrack(track *gotio.Track, cuts []opentime.RationalTime) ([]*gotio.Track, error) {
+	cut := track.Clone().(*gotio.Track)
+	origin := opentime.NewRationalTime(0, cuts[0].Rate())
+	if sr := cut.SourceRange(); sr != nil {
+		origin = sr.StartTime()
+		cut.SetSourceRange(nil)
+	}
+	for _, t := range cuts {
+		if err := Slice(cut, origin.Add(t.RescaledTo(origin.Rate())), WithSliceOverrideLocks(true)); err != nil {
+			return nil, err
+		}
+	}
+
+	// The part each child starts in, or -1 outside the cuts.
+	children := cut.Children()
+	partOf := make([]int, len(children))
+	for i, child := range children {
+		partOf[i] = -1
+		if _, ok := child.(*gotio.Transition); ok {
+			continue
+		}
+		childRange, err := cut.RangeOfChildAtIndex(i)
+		if err != nil {
+			return nil, err
+		}
+		partOf[i] = cutIndex(subtractTime(childRange.StartTime(), origin), cuts)
+	}
+	// A transition stays only if the items either side of it do.
+	for i, child := range children {
+		if _, ok := child.(*gotio.Transition); ok && i > 0 && i+1 < len(children) && partOf[i-1] == partOf[i+1] {
+			partOf[i] = partOf[i-1]
+		}
+	}
+
+	pieces := make([]*gotio.Track, len(cuts)-1)
+	for i := range pieces {
+		pieces[i] = gotio.NewTrack(track.Name(), nil, track.Kind(), gotio.CloneAnyDictionary(track.Metadata()), nil)
+		pieces[i].SetEnabled(track.Enabled())
+	}
+	for i, child := range children {
+		if partOf[i] < 0 {
+			continue
+		}
+		if err := pieces[partOf[i]].AppendChild(child.Clone().(gotio.Composable)); err != nil {
+			return nil, err
+		}
+	}
+	for i, markers := range splitMarkers(cut.Markers(), origin, cuts) {
+		pieces[i].SetMarkers(markers)
+	}
+	return pieces, nil
+}
+
+// splitMarkers returns copies of the markers of each part, moved to the
+// part's time. Marked ranges are relative to origin.
+func splitMarkers(markers []*gotio.Marker, origin opentime.RationalTime, cuts []opentime.RationalTime) [][]*gotio.Marker {
+	result := make([][]*gotio.Marker, len(cuts)-1)
+	for _, marker := range markers {
+		markedRange := marker.MarkedRange()
+		part := cutIndex(subtractTime(markedRange.StartTime(), origin), cuts)
+		if part < 0 {
+			continue
+		}
+		moved := marker.Clone().(*gotio.Marker)
+		offset := origin.Add(cuts[part].RescaledTo(origin.Rate()))
+		moved.SetMarkedRange(opentime.NewTimeRange(subtractTime(markedRange.StartTime(), offset), markedRange.Duration()))
+		result[part] = append(result[part], moved)
+	}
+	return result
+}
+
+// cutIndex returns the index of the part between consecutive cuts that t
+// falls in, or -1 if it is outside them.
+func cutIndex(t opentime.RationalTime, cuts []opentime.RationalTime) int {
+	seconds := t.ToSeconds()
+	for i := 0; i+1 < len(cuts); i++ {
+		if seconds >= cuts[i].ToSeconds()-opentime.DefaultEpsilon && seconds < cuts[i+1].ToSeconds()-opentime.DefaultEpsilon {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package algorithms
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func TestSplitTimeline(t *testing.T) {
+	start := opentime.NewRationalTime(86400, 24)
+	timeline := gotio.NewTimeline("feature", &start, gotio.AnyDictionary{"title": "feature"})
+	video := createTestTrack([]float64{48, 48, 48}, 24)
+	video.InsertChild(1, gotio.NewTransition("", gotio.TransitionTypeSMPTEDissolve,
+		opentime.NewRationalTime(6, 24), opentime.NewRationalTime(6, 24), nil))
+	audio := createTestTrack([]float64{144}, 24)
+	audio.SetKind(gotio.TrackKindAudio)
+	timeline.Tracks().AppendChild(video)
+	timeline.Tracks().AppendChild(audio)
+	marked := opentime.NewTimeRange(opentime.NewRationalTime(100, 24), opentime.NewRationalTime(0, 24))
+	timeline.Tracks().SetMarkers([]*gotio.Marker{gotio.NewMarker("reel note", marked, gotio.MarkerColorRed, "", nil)})
+
+	parts, err := SplitTimeline(timeline, []opentime.RationalTime{
+		opentime.NewRationalTime(86496, 24),
+		opentime.NewRationalTime(86472, 24),
+	})
+	if err != nil {
+		t.Fatalf("SplitTimeline error: %v", err)
+	}
+	if len(parts) != 3 {
+		t.Fatalf("expected 3 parts, got %d", len(parts))
+	}
+
+	for i, want := range []struct {
+		start, duration float64
+		video           int
+		audioIn         float64
+	}{
+		{86400, 72, 3, 0},
+		{86472, 24, 1, 72},
+		{86496, 48, 1, 96},
+	} {
+		part := parts[i]
+		if part.Name() != "feature" || part.Metadata()["title"] != "feature" {
+			t.Errorf("part %d: name and metadata not kept", i)
+		}
+		if got := part.GlobalStartTime().Value(); got != want.start {
+			t.Errorf("part %d: global start = %v, want %v", i, got, want.start)
+		}
+		if d, _ := part.Duration(); d.Value() != want.duration {
+			t.Errorf("part %d: duration = %v, want %v", i, d.Value(), want.duration)
+		}
+		if n := len(part.VideoTracks()[0].Children()); n != want.video {
+			t.Errorf("part %d: %d video children, want %d", i, n, want.video)
+		}
+		audioClip := part.AudioTracks()[0].Children()[0].(*gotio.Clip)
+		if got := audioClip.SourceRange().StartTime().Value(); got != want.audioIn {
+			t.Errorf("part %d: audio source start = %v, want %v", i, got, want.audioIn)
+		}
+	}
+
+	if _, ok := parts[0].VideoTracks()[0].Children()[1].(*gotio.Transition); !ok {
+		t.Error("expected the transition inside the first part to be kept")
+	}
+	b := parts[1].VideoTracks()[0].Children()[0].(*gotio.Clip)
+	if b.Name() != "clip_B" || b.SourceRange().StartTime().Value() != 24 {
+		t.Errorf("expected the second half of clip_B, got %s at %v", b.Name(), b.SourceRange().StartTime().Value())
+	}
+	markers := parts[2].Tracks().Markers()
+	if len(markers) != 1 || markers[0].MarkedRange().StartTime().Value() != 4 {
+		t.Errorf("expected the marker in the last part at 4, got %v", markers)
+	}
+	if d, _ := timeline.Duration(); d.Value() != 144 {
+		t.Error("the original timeline should be unchanged")
+	}
+
+	for _, bad := range [][]opentime.RationalTime{
+		{start},
+		{opentime.NewRationalTime(86400+144, 24)},
+		{opentime.NewRationalTime(24, 24)},
+		{opentime.NewRationalTime(86424, 24), opentime.NewRationalTime(86424, 24)},
+	} {
+		if _, err := SplitTimeline(timeline, bad); err == nil {
+			t.Errorf("expected an error for boundaries %v", bad)
+		}
+	}
+	if _, err := SplitTimeline(nil, nil); err == nil {
+		t.Error("expected an error for a nil timeline")
+	}
+}
@@ -11,6 +11,10 @@ import (
 	"github.com/Avalanche-io/gotio"
 )
 
+func init() {
+	gotio.RegisterFeature("algorithms")
+}
+
 // TrackTrimmedToRange returns a new track trimmed to the given time range.
 // Items outside the range are removed, items on the ends are trimmed.
 // This never expands the track, only shortens it.
@@ -3,9 +3,160 @@
 
 package gotio
 
+import (
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/internal/jsonenc"
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
 // AnyDictionary is a map of string keys to any values.
 type AnyDictionary map[string]any
 
+func init() {
+	// Nested dictionaries are stored as AnyDictionary, which the
+	// encoder does not see as a plain map[string]any.
+	jsonenc.Register(jsonenc.TypeInfo{
+		GoType: reflect.TypeOf(AnyDictionary{}),
+		Encode: func(enc *jsonenc.Encoder, v any) error {
+			return jsonenc.EncodeMap(enc, v.(AnyDictionary))
+		},
+	})
+}
+
+// Keys returns the keys of the dictionary in sorted order.
+func (d AnyDictionary) Keys() []string {
+	keys := make([]string, 0, len(d))
+	for k := range d {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Lookup returns the value at a dot-separated path such as "a.b.c",
+// descending through nested dictionaries.
+func (d AnyDictionary) Lookup(path string) (any, bool) {
+	var current any = d
+	for _, key := range strings.Split(path, ".") {
+		m, ok := asDictionary(current)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// GetString returns the string stored under key.
+func (d AnyDictionary) GetString(key string) (string, bool) {
+	s, ok := d[key].(string)
+	return s, ok
+}
+
+// GetBool returns the bool stored under key.
+func (d AnyDictionary) GetBool(key string) (bool, bool) {
+	b, ok := d[key].(bool)
+	return b, ok
+}
+
+// GetInt returns the integer stored under key. Decoded JSON numbers are
+// float64, so whole floats are accepted as well.
+func (d AnyDictionary) GetInt(key string) (int, bool) {
+	return asInt(d[key])
+}
+
+// asInt converts an integer or a whole float64, as decoded from JSON, to int.
+func asInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case float64:
+		if v == math.Trunc(v) {
+			return int(v), true
+		}
+	}
+	return 0, false
+}
+
+// GetFloat64 returns the number stored under key.
+func (d AnyDictionary) GetFloat64(key string) (float64, bool) {
+	switch v := d[key].(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// GetDictionary returns the nested dictionary stored under key.
+func (d AnyDictionary) GetDictionary(key string) (AnyDictionary, bool) {
+	return asDictionary(d[key])
+}
+
+// GetRationalTime returns the RationalTime stored under key, either as a
+// value or in its serialized form.
+func (d AnyDictionary) GetRationalTime(key string) (opentime.RationalTime, bool) {
+	switch v := d[key].(type) {
+	case opentime.RationalTime:
+		return v, true
+	case *opentime.RationalTime:
+		if v != nil {
+			return *v, true
+		}
+	default:
+		if m, ok := asDictionary(v); ok && m["OTIO_SCHEMA"] == "RationalTime.1" {
+			return *decodeSonicRationalTime(nil, map[string]any(m)), true
+		}
+	}
+	return opentime.RationalTime{}, false
+}
+
+// GetTimeRange returns the TimeRange stored under key, either as a value
+// or in its serialized form.
+func (d AnyDictionary) GetTimeRange(key string) (opentime.TimeRange, bool) {
+	switch v := d[key].(type) {
+	case opentime.TimeRange:
+		return v, true
+	case *opentime.TimeRange:
+		if v != nil {
+			return *v, true
+		}
+	default:
+		if m, ok := asDictionary(v); ok && m["OTIO_SCHEMA"] == "TimeRange.1" {
+			if tr := decodeSonicTimeRange(nil, map[string]any(m)); tr != nil {
+				return *tr, true
+			}
+		}
+	}
+	return opentime.TimeRange{}, false
+}
+
+// asDictionary converts nested map values to an AnyDictionary.
+func asDictionary(v any) (AnyDictionary, bool) {
+	switch m := v.(type) {
+	case AnyDictionary:
+		return m, true
+	case map[string]any:
+		return m, true
+	}
+	return nil, false
+}
+
 // CloneAnyDictionary creates a shallow copy of an AnyDictionary.
 func CloneAnyDictionary(d AnyDictionary) AnyDictionary {
 	if d == nil {
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func TestAnyDictionaryGetters(t *testing.T) {
+	cut := opentime.NewTimeRange(opentime.NewRationalTime(10, 24), opentime.NewRationalTime(20, 24))
+	d := AnyDictionary{
+		"name":  "sh010",
+		"take":  float64(3),
+		"ratio": 1.5,
+		"hero":  true,
+		"cut":   cut,
+		"start": map[string]any{"OTIO_SCHEMA": "RationalTime.1", "value": float64(86400), "rate": float64(24)},
+		"studio": map[string]any{
+			"vfx": AnyDictionary{"vendor": "A"},
+		},
+	}
+
+	if s, ok := d.GetString("name"); !ok || s != "sh010" {
+		t.Errorf("GetString = %q, %v", s, ok)
+	}
+	if n, ok := d.GetInt("take"); !ok || n != 3 {
+		t.Errorf("GetInt = %d, %v", n, ok)
+	}
+	if _, ok := d.GetInt("ratio"); ok {
+		t.Error("GetInt should reject fractional numbers")
+	}
+	if f, ok := d.GetFloat64("ratio"); !ok || f != 1.5 {
+		t.Errorf("GetFloat64 = %v, %v", f, ok)
+	}
+	if b, ok := d.GetBool("hero"); !ok || !b {
+		t.Errorf("GetBool = %v, %v", b, ok)
+	}
+	if tr, ok := d.GetTimeRange("cut"); !ok || !tr.StartTime().Equal(cut.StartTime()) {
+		t.Errorf("GetTimeRange = %v, %v", tr, ok)
+	}
+	if rt, ok := d.GetRationalTime("start"); !ok || rt.Value() != 86400 {
+		t.Errorf("GetRationalTime = %v, %v", rt, ok)
+	}
+	if _, ok := d.GetString("take"); ok {
+		t.Error("GetString should reject numbers")
+	}
+
+	if v, ok := d.Lookup("studio.vfx.vendor"); !ok || v != "A" {
+		t.Errorf("Lookup = %v, %v", v, ok)
+	}
+	for _, path := range []string{"studio.missing", "name.sub", "studio.vfx.vendor.x"} {
+		if _, ok := d.Lookup(path); ok {
+			t.Errorf("Lookup(%q) should fail", path)
+		}
+	}
+	if keys := d.Keys(); keys[0] != "cut" || keys[len(keys)-1] != "take" {
+		t.Errorf("Keys not sorted: %v", keys)
+	}
+}
+
+func TestToJSONBytesCanonical(t *testing.T) {
+	makeClip := func() *Clip {
+		md := AnyDictionary{}
+		for _, k := range []string{"z", "m", "a", "q", "c", "x", "b"} {
+			md[k] = AnyDictionary{"rate": float64(24), "k": k, "nested": map[string]any{"y": 1, "b": 2}}
+		}
+		return NewClip("shot", nil, nil, md, nil, nil, "", nil)
+	}
+
+	first, err := ToJSONBytesCanonical(makeClip())
+	if err != nil {
+		t.Fatalf("ToJSONBytesCanonical error: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		data, err := ToJSONBytesCanonical(makeClip())
+		if err != nil {
+			t.Fatalf("ToJSONBytesCanonical error: %v", err)
+		}
+		if string(data) != string(first) {
+			t.Fatalf("canonical output differs:\n%s\n%s", first, data)
+		}
+	}
+
+	out := string(first)
+	if !strings.Contains(out, `"a":{"k":"a","nested":{"b":2,"y":1},"rate":24.0}`) {
+		t.Errorf("expected sorted keys and stable floats, got %s", out)
+	}
+	if strings.Index(out, `"a":`) > strings.Index(out, `"z":`) {
+		t.Errorf("metadata keys not sorted: %s", out)
+	}
+
+	obj, err := FromJSONBytes(first)
+	if err != nil {
+		t.Fatalf("FromJSONBytes error: %v", err)
+	}
+	if v, ok := obj.(*Clip).Metadata().Lookup("q.nested.y"); !ok || v != float64(1) {
+		t.Errorf("round trip lost nested metadata: %v", v)
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package assets connects timelines to studio asset management. An asset
+// management system implements two small interfaces, AssetResolver to map
+// asset versions to media references and back, and VersionProvider to
+// choose the version a timeline should show, and ApplyAssetVersions swaps
+// plate versions throughout a timeline with no other knowledge of gotio.
+//
+// Basic usage:
+//
+//	pins := assets.Pinned(map[string]string{"sh010_plate": "v004"})
+//	changes, err := assets.ApplyAssetVersions(ctx, timeline, db, pins)
+//	for _, c := range changes {
+//		log.Printf("%s: %s -> %s", c.Clip.Name(), c.From.Version, c.To.Version)
+//	}
+package assets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+func init() {
+	gotio.RegisterFeature("assets")
+}
+
+// MetadataKey is the clip metadata key recording the asset a clip shows,
+// as a dictionary with "id" and "version" strings.
+const MetadataKey = "asset"
+
+// Asset is one version of a managed asset.
+type Asset struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+// AssetResolver maps asset versions to media references and back.
+type AssetResolver interface {
+	// ResolveAsset returns the media reference of a version of an asset.
+	ResolveAsset(ctx context.Context, asset Asset) (gotio.MediaReference, error)
+	// IdentifyAsset returns the asset version a media reference points
+	// at, and false if it is not managed media.
+	IdentifyAsset(ctx context.Context, ref gotio.MediaReference) (Asset, bool, error)
+}
+
+// VersionProvider chooses the version of an asset a timeline should show.
+type VersionProvider interface {
+	// Version returns the version to use for the asset currently shown
+	// at the given version. Returning the current version, or "", keeps
+	// it.
+	Version(ctx context.Context, current Asset) (string, error)
+}
+
+// VersionFunc adapts a function to the VersionProvider interface.
+type VersionFunc func(ctx context.Context, current Asset) (string, error)
+
+// Version calls f.
+func (f VersionFunc) Version(ctx context.Context, current Asset) (string, error) {
+	return f(ctx, current)
+}
+
+// Pinned returns a VersionProvider choosing versions by asset ID from a
+// map, and keeping the current version of assets not in it.
+func Pinned(versions map[string]string) VersionProvider {
+	return VersionFunc(func(_ context.Context, current Asset) (string, error) {
+		return versions[current.ID], nil
+	})
+}
+
+// Change is a clip whose asset version was replaced.
+type Change struct {
+	Clip *gotio.Clip
+	From Asset
+	To   Asset
+}
+
+// ClipAsset returns the asset a clip shows: the one recorded in its
+// metadata, else the one its active media reference is identified as.
+func ClipAsset(ctx context.Context, clip *gotio.Clip, resolver AssetResolver) (Asset, bool, error) {
+	if md, ok := clip.Metadata().GetDictionary(MetadataKey); ok {
+		id, _ := md.GetString("id")
+		version, _ := md.GetString("version")
+		if id != "" {
+			return Asset{ID: id, Version: version}, true, nil
+		}
+	}
+	return resolver.IdentifyAsset(ctx, clip.MediaReference())
+}
+
+// ApplyAssetVersions replaces the media of every clip showing a managed
+// asset with the version versions chooses, and records the asset in the
+// clip's metadata under MetadataKey. Only the active media reference is
+// replaced. All versions are resolved before any clip is changed, so on
+// error the timeline is unchanged.
+func ApplyAssetVersions(ctx context.Context, timeline *gotio.Timeline, resolver AssetResolver, versions VersionProvider) ([]Change, error) {
+	type planned struct {
+		change Change
+		ref    gotio.MediaReference
+	}
+	var plan []planned
+	for _, clip := range timeline.FindClips(nil, false) {
+		current, ok, err := ClipAsset(ctx, clip, resolver)
+		if err != nil {
+			return nil, fmt.Errorf("assets: clip %q: %w", clip.Name(), err)
+		}
+		if !ok {
+			continue
+		}
+		version, err := versions.Version(ctx, current)
+		if err != nil {
+			return nil, fmt.Errorf("assets: clip %q: asset %s: %w", clip.Name(), current.ID, err)
+		}
+		if version == "" || version == current.Version {
+			continue
+		}
+		next := Asset{ID: current.ID, Version: version}
+		ref, err := resolver.ResolveAsset(ctx, next)
+		if err != nil {
+			return nil, fmt.Errorf("assets: clip %q: asset %s version %s: %w", clip.Name(), next.ID, next.Version, err)
+		}
+		plan = append(plan, planned{change: Change{Clip: clip, From: current, To: next}, ref: ref})
+	}
+
+	changes := make([]Change, 0, len(plan))
+	for _, p := range plan {
+		clip := p.change.Clip
+		clip.SetMediaReference(p.ref)
+		if err := clip.SetMetadataValue(MetadataKey, gotio.AnyDictionary{"id": p.change.To.ID, "version": p.change.To.Version}); err != nil {
+			return changes, err
+		}
+		changes = append(changes, p.change)
+	}
+	return changes, nil
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+// plateDB keeps plates at /plates/<id>_<version>.exr.
+type plateDB struct {
+	missing string
+}
+
+func (db plateDB) ResolveAsset(_ context.Context, asset Asset) (gotio.MediaReference, error) {
+	if asset.Version == db.missing {
+		return nil, errors.New("no such version")
+	}
+	return gotio.NewExternalReference("", fmt.Sprintf("/plates/%s_%s.exr", asset.ID, asset.Version), nil, nil), nil
+}
+
+func (db plateDB) IdentifyAsset(_ context.Context, ref gotio.MediaReference) (Asset, bool, error) {
+	ext, ok := ref.(*gotio.ExternalReference)
+	if !ok {
+		return Asset{}, false, nil
+	}
+	name, ok := strings.CutPrefix(strings.TrimSuffix(ext.TargetURL(), ".exr"), "/plates/")
+	if !ok {
+		return Asset{}, false, nil
+	}
+	id, version, _ := strings.Cut(name, "_")
+	return Asset{ID: id, Version: version}, true, nil
+}
+
+func plateTimeline() *gotio.Timeline {
+	timeline := gotio.NewTimeline("plates", nil, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	track.AppendChild(gotio.NewClip("sh010", gotio.NewExternalReference("", "/plates/sh010_v001.exr", nil, nil), nil, nil, nil, nil, "", nil))
+	track.AppendChild(gotio.NewClip("sh020", gotio.NewExternalReference("", "/plates/sh020_v002.exr", nil, nil), nil, nil, nil, nil, "", nil))
+	track.AppendChild(gotio.NewClip("card", gotio.NewExternalReference("", "/gfx/card.png", nil, nil), nil, nil, nil, nil, "", nil))
+	timeline.Tracks().AppendChild(track)
+	return timeline
+}
+
+func TestApplyAssetVersions(t *testing.T) {
+	ctx := context.Background()
+	timeline := plateTimeline()
+	changes, err := ApplyAssetVersions(ctx, timeline, plateDB{}, Pinned(map[string]string{"sh010": "v003", "sh020": "v002", "card": "v9"}))
+	if err != nil {
+		t.Fatalf("ApplyAssetVersions error: %v", err)
+	}
+	if len(changes) != 1 || changes[0].From.Version != "v001" || changes[0].To.Version != "v003" {
+		t.Fatalf("changes = %+v, want sh010 v001 to v003", changes)
+	}
+	clip := changes[0].Clip
+	if url := clip.MediaReference().(*gotio.ExternalReference).TargetURL(); url != "/plates/sh010_v003.exr" {
+		t.Errorf("media = %s", url)
+	}
+	if asset, ok, _ := ClipAsset(ctx, clip, plateDB{}); !ok || asset != (Asset{ID: "sh010", Version: "v003"}) {
+		t.Errorf("ClipAsset = %+v, %v", asset, ok)
+	}
+
+	// A version that cannot be resolved leaves the timeline unchanged.
+	bump := VersionFunc(func(_ context.Context, current Asset) (string, error) {
+		return current.Version + "1", nil
+	})
+	if _, err := ApplyAssetVersions(ctx, timeline, plateDB{missing: "v0021"}, bump); err == nil {
+		t.Fatal("expected an error for a missing version")
+	}
+	if v, _ := clip.MetadataValue("asset.version"); v != "v003" {
+		t.Errorf("a failed apply changed sh010 to %v", v)
+	}
+}
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"fmt"
+)
+
+// AudioMetadataKey is the metadata namespace holding audio channel
+// information on tracks and clips.
+const AudioMetadataKey = "audio"
+
+// ChannelLabel names the speaker a channel is assigned to.
+type ChannelLabel string
+
+// Channel labels.
+const (
+	ChannelMono          ChannelLabel = "M"
+	ChannelLeft          ChannelLabel = "L"
+	ChannelRight         ChannelLabel = "R"
+	ChannelCenter        ChannelLabel = "C"
+	ChannelLFE           ChannelLabel = "LFE"
+	ChannelLeftSurround  ChannelLabel = "Ls"
+	ChannelRightSurround ChannelLabel = "Rs"
+)
+
+// Common channel layouts.
+var (
+	LayoutMono     = []ChannelLabel{ChannelMono}
+	LayoutStereo   = []ChannelLabel{ChannelLeft, ChannelRight}
+	LayoutSurround = []ChannelLabel{ChannelLeft, ChannelRight, ChannelCenter, ChannelLFE, ChannelLeftSurround, ChannelRightSurround}
+)
+
+// AudioChannels describes the channels of an audio track.
+type AudioChannels struct {
+	// Count is the number of channels.
+	Count int
+	// Labels optionally assigns a speaker to each channel. If set, it
+	// has Count entries.
+	Labels []ChannelLabel
+}
+
+// AudioChannels returns the channel information stored on the track.
+func (t *Track) AudioChannels() (AudioChannels, bool) {
+	values, ok := t.Metadata().GetDictionary(AudioMetadataKey)
+	if !ok {
+		return AudioChannels{}, false
+	}
+	count, ok := values.GetInt("channel_count")
+	if !ok {
+		return AudioChannels{}, false
+	}
+	info := AudioChannels{Count: count}
+	if labels, ok := values["channels"].([]any); ok {
+		for _, label := range labels {
+			s, _ := label.(string)
+			info.Labels = append(info.Labels, ChannelLabel(s))
+		}
+	} else if labels, ok := values["channels"].([]string); ok {
+		for _, s := range labels {
+			info.Labels = append(info.Labels, ChannelLabel(s))
+		}
+	}
+	return info, true
+}
+
+// SetAudioChannels stores channel information on the track. Other keys
+// in the audio namespace are kept.
+func (t *Track) SetAudioChannels(info AudioChannels) error {
+	if info.Count <= 0 {
+		return fmt.Errorf("%w: %d channels", ErrInvalidAudioChannels, info.Count)
+	}
+	if len(info.Labels) > 0 && len(info.Labels) != info.Count {
+		return fmt.Errorf("%w: %d labels for %d channels", ErrInvalidAudioChannels, len(info.Labels), info.Count)
+	}
+
+	values := audioNamespace(t)
+	values["channel_count"] = info.Count
+	delete(values, "channels")
+	if len(info.Labels) > 0 {
+		labels := make([]any, len(info.Labels))
+		for i, label := range info.Labels {
+			labels[i] = string(label)
+		}
+		values["channels"] = labels
+	}
+	return nil
+}
+
+// SourceChannels returns the clip's source channel mapping: entry i is
+// the 1-based channel of the media that feeds channel i+1 of the track.
+func (c *Clip) SourceChannels() ([]int, bool) {
+	values, ok := c.Metadata().GetDictionary(AudioMetadataKey)
+	if !ok {
+		return nil, false
+	}
+	var channels []int
+	switch v := values["source_channels"].(type) {
+	case []any:
+		for _, ch := range v {
+			n, ok := asInt(ch)
+			if !ok {
+				return nil, false
+			}
+			channels = append(channels, n)
+		}
+	case []int:
+		channels = append(channels, v...)
+	default:
+		return nil, false
+	}
+	return channels, true
+}
+
+// SetSourceChannels stores the clip's source channel mapping. Channels
+// are 1-based; entry i feeds channel i+1 of the track. A nil mapping
+// removes it.
+func (c *Clip) SetSourceChannels(channels []int) error {
+	if channels == nil {
+		if values, ok := c.Metadata().GetDictionary(AudioMetadataKey); ok {
+			delete(values, "source_channels")
+		}
+		return nil
+	}
+	mapping := make([]any, len(channels))
+	for i, ch := range channels {
+		if ch < 1 {
+			return fmt.Errorf("%w: source channel %d", ErrInvalidAudioChannels, ch)
+		}
+		mapping[i] = ch
+	}
+	audioNamespace(c)["source_channels"] = mapping
+	return nil
+}
+
+// audioNamespace returns the audio dictionary of obj, creating it if needed.
+func audioNamespace(obj SerializableObjectWithMetadata) AnyDictionary {
+	md := obj.Metadata()
+	if md == nil {
+		md = AnyDictionary{}
+		obj.SetMetadata(md)
+	}
+	if values, ok := md.GetDictionary(AudioMetadataKey); ok {
+		md[AudioMetadataKey] = values
+		return values
+	}
+	values := AnyDictionary{}
+	md[AudioMetadataKey] = values
+	return values
+}
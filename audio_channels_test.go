@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAudioChannelsRoundTrip(t *testing.T) {
+	track := NewTrack("A1", nil, TrackKindAudio, AnyDictionary{AudioMetadataKey: AnyDictionary{"mix": "M&E"}}, nil)
+	if _, ok := track.AudioChannels(); ok {
+		t.Error("expected no channel information on a new track")
+	}
+	if err := track.SetAudioChannels(AudioChannels{Count: 6, Labels: LayoutSurround}); err != nil {
+		t.Fatalf("SetAudioChannels error: %v", err)
+	}
+
+	clip := NewClip("dialog", nil, nil, nil, nil, nil, "", nil)
+	clip.SetMetadata(nil)
+	if err := clip.SetSourceChannels([]int{3, 4}); err != nil {
+		t.Fatalf("SetSourceChannels error: %v", err)
+	}
+	track.AppendChild(clip)
+
+	data, err := ToJSONString(track, "")
+	if err != nil {
+		t.Fatalf("ToJSONString error: %v", err)
+	}
+	obj, err := FromJSONString(data)
+	if err != nil {
+		t.Fatalf("FromJSONString error: %v", err)
+	}
+	decoded := obj.(*Track)
+
+	info, ok := decoded.AudioChannels()
+	if !ok || info.Count != 6 || len(info.Labels) != 6 || info.Labels[3] != ChannelLFE {
+		t.Errorf("AudioChannels = %+v, %v", info, ok)
+	}
+	if mix, _ := decoded.Metadata().Lookup(AudioMetadataKey + ".mix"); mix != "M&E" {
+		t.Errorf("expected other audio keys to be kept, got %v", mix)
+	}
+	channels, ok := decoded.Children()[0].(*Clip).SourceChannels()
+	if !ok || len(channels) != 2 || channels[0] != 3 || channels[1] != 4 {
+		t.Errorf("SourceChannels = %v, %v", channels, ok)
+	}
+}
+
+func TestAudioChannelsInvalid(t *testing.T) {
+	track := NewTrack("A1", nil, TrackKindAudio, nil, nil)
+	if err := track.SetAudioChannels(AudioChannels{Count: 0}); !errors.Is(err, ErrInvalidAudioChannels) {
+		t.Errorf("expected ErrInvalidAudioChannels for no channels, got %v", err)
+	}
+	if err := track.SetAudioChannels(AudioChannels{Count: 1, Labels: LayoutStereo}); !errors.Is(err, ErrInvalidAudioChannels) {
+		t.Errorf("expected ErrInvalidAudioChannels for mismatched labels, got %v", err)
+	}
+
+	clip := NewClip("c", nil, nil, nil, nil, nil, "", nil)
+	if err := clip.SetSourceChannels([]int{0}); !errors.Is(err, ErrInvalidAudioChannels) {
+		t.Errorf("expected ErrInvalidAudioChannels for channel 0, got %v", err)
+	}
+	clip.SetSourceChannels([]int{1})
+	clip.SetSourceChannels(nil)
+	if _, ok := clip.SourceChannels(); ok {
+		t.Error("expected mapping to be removed")
+	}
+}
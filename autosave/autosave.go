@@ -0,0 +1,394 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package autosave keeps a crash-safe autosave of a timeline being edited
+// by writing small deltas instead of whole documents.
+//
+// The first save writes the full timeline as a base; each later save that
+// finds a change writes a JSON Patch from the previous state, anchored by
+// the content hashes of the states before and after it. Every MaxDeltas
+// deltas the base is rewritten and the deltas are dropped. Files are
+// written to a temporary name and renamed into place, so a crash never
+// leaves a partial file behind.
+//
+// Basic usage:
+//
+//	if rec, err := autosave.Recover(dir); err == nil {
+//		timeline = rec.Timeline
+//	}
+//	w, err := autosave.NewWriter(dir)
+//	go w.Run(ctx, 30*time.Second, editor.Snapshot)
+//	...
+//	w.Discard() // after the user saves
+package autosave
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/patch"
+)
+
+func init() {
+	gotio.RegisterFeature("autosave")
+}
+
+// DefaultMaxDeltas is the number of deltas written before the base is
+// rewritten.
+const DefaultMaxDeltas = 50
+
+// ErrNoAutosave is returned by Recover for a directory without an
+// autosave.
+var ErrNoAutosave = errors.New("autosave: no autosave found")
+
+const (
+	basePrefix  = "base-"
+	baseSuffix  = ".otio"
+	deltaPrefix = "delta-"
+	deltaSuffix = ".json"
+)
+
+// delta is the file format of one delta.
+type delta struct {
+	Seq   int             `json:"seq"`
+	Time  time.Time       `json:"time"`
+	Base  string          `json:"base"`
+	Hash  string          `json:"hash"`
+	Patch json.RawMessage `json:"patch"`
+}
+
+// Config holds configuration for a Writer.
+type Config struct {
+	// MaxDeltas is the number of deltas written before the base is
+	// rewritten.
+	MaxDeltas int
+}
+
+// Option is a functional option for NewWriter.
+type Option func(*Config)
+
+// WithMaxDeltas sets the number of deltas written before the base is
+// rewritten.
+func WithMaxDeltas(n int) Option {
+	return func(c *Config) {
+		c.MaxDeltas = n
+	}
+}
+
+// Writer writes the autosave of one timeline. Its methods may be called
+// from several goroutines.
+type Writer struct {
+	dir string
+	cfg Config
+
+	mu     sync.Mutex
+	seq    int // seq of the newest file
+	deltas int // deltas since the base
+	last   *gotio.Timeline
+	hash   string
+}
+
+// NewWriter returns a Writer saving to dir, creating it if needed. The
+// first Save replaces any autosave already in dir, so Recover it first.
+func NewWriter(dir string, opts ...Option) (*Writer, error) {
+	cfg := Config{MaxDeltas: DefaultMaxDeltas}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	w := &Writer{dir: dir, cfg: cfg}
+	// Number new files after any already there, so the first base
+	// supersedes them.
+	files, err := listFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) > 0 {
+		w.seq = files[len(files)-1].seq
+	}
+	return w, nil
+}
+
+// Save records the current state of the timeline: a new base on the first
+// save and every MaxDeltas deltas, otherwise a delta from the last saved
+// state. Nothing is written if the timeline has not changed. The caller
+// must keep the timeline from changing during the call.
+func (w *Writer) Save(timeline *gotio.Timeline) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	hash, err := gotio.ContentHash(timeline)
+	if err != nil {
+		return err
+	}
+	if w.last != nil && hash == w.hash {
+		return nil
+	}
+	w.seq++
+	if w.last == nil || w.deltas >= w.cfg.MaxDeltas {
+		if err := w.writeBase(timeline); err != nil {
+			return err
+		}
+	} else if err := w.writeDelta(timeline, hash); err != nil {
+		return err
+	}
+	w.last = timeline.Clone().(*gotio.Timeline)
+	w.hash = hash
+	return nil
+}
+
+// writeBase writes the timeline as the base at w.seq and removes the
+// files it supersedes.
+func (w *Writer) writeBase(timeline *gotio.Timeline) error {
+	data, err := gotio.ToJSONBytes(timeline)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(w.dir, fileName(basePrefix, w.seq, baseSuffix)), data); err != nil {
+		return err
+	}
+	w.deltas = 0
+	return w.removeBefore(w.seq)
+}
+
+func (w *Writer) writeDelta(timeline *gotio.Timeline, hash string) error {
+	ops, err := patch.Diff(w.last, timeline)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(delta{Seq: w.seq, Time: time.Now().UTC(), Base: w.hash, Hash: hash, Patch: ops})
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(w.dir, fileName(deltaPrefix, w.seq, deltaSuffix)), data); err != nil {
+		return err
+	}
+	w.deltas++
+	return nil
+}
+
+// removeBefore removes the autosave files older than seq.
+func (w *Writer) removeBefore(seq int) error {
+	files, err := listFiles(w.dir)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.seq < seq {
+			if err := os.Remove(filepath.Join(w.dir, f.name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Run saves the timeline returned by snapshot every interval until ctx is
+// done, then saves once more. Snapshot is called from Run's goroutine and
+// must return a timeline no one changes until the next call, such as a
+// clone taken under the editor's lock. Errors stop Run.
+func (w *Writer) Run(ctx context.Context, interval time.Duration, snapshot func() *gotio.Timeline) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := w.Save(snapshot()); err != nil {
+				return err
+			}
+			return ctx.Err()
+		case <-ticker.C:
+			if err := w.Save(snapshot()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Discard removes the autosave, for example after the timeline has been
+// saved. The next Save writes a new base.
+func (w *Writer) Discard() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.last = nil
+	w.hash = ""
+	return w.removeBefore(w.seq + 1)
+}
+
+// Recovery is a timeline recovered from an autosave.
+type Recovery struct {
+	Timeline *gotio.Timeline
+	// Saved is the time of the last delta applied, or the modification
+	// time of the base if none was.
+	Saved time.Time
+	// Deltas is the number of deltas applied to the base.
+	Deltas int
+	// Skipped names the delta files left unapplied because one could not
+	// be read or did not follow from the state before it.
+	Skipped []string
+}
+
+// Recover reconstructs the latest state of the autosave in dir from its
+// newest base and the deltas after it. Deltas are applied in order up to
+// the first that is unreadable or whose base hash does not match the
+// state before it; the rest are listed in Recovery.Skipped. Returns
+// ErrNoAutosave if dir holds no base.
+func Recover(dir string) (*Recovery, error) {
+	files, err := listFiles(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNoAutosave
+		}
+		return nil, err
+	}
+	var base *file
+	for i := len(files) - 1; i >= 0; i-- {
+		if files[i].base {
+			base = &files[i]
+			break
+		}
+	}
+	if base == nil {
+		return nil, ErrNoAutosave
+	}
+
+	path := filepath.Join(dir, base.name)
+	obj, err := gotio.FromJSONFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("autosave: %s: %w", base.name, err)
+	}
+	timeline, ok := obj.(*gotio.Timeline)
+	if !ok {
+		return nil, fmt.Errorf("autosave: %s: expected Timeline, got %T", base.name, obj)
+	}
+	rec := &Recovery{Timeline: timeline}
+	if info, err := os.Stat(path); err == nil {
+		rec.Saved = info.ModTime()
+	}
+	hash, err := gotio.ContentHash(timeline)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range files {
+		if f.base || f.seq <= base.seq {
+			continue
+		}
+		if len(rec.Skipped) == 0 {
+			next, nextHash, saved, err := applyDelta(filepath.Join(dir, f.name), rec.Timeline, hash)
+			if err == nil {
+				rec.Timeline, hash, rec.Saved = next, nextHash, saved
+				rec.Deltas++
+				continue
+			}
+		}
+		rec.Skipped = append(rec.Skipped, f.name)
+	}
+	return rec, nil
+}
+
+// applyDelta applies the delta at path to timeline, whose content hash is
+// hash, and returns the result and its hash.
+func applyDelta(path string, timeline *gotio.Timeline, hash string) (*gotio.Timeline, string, time.Time, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+	var d delta
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, "", time.Time{}, err
+	}
+	if d.Base != hash {
+		return nil, "", time.Time{}, errors.New("delta does not follow the previous state")
+	}
+	next, err := patch.Apply(timeline, d.Patch)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+	nextHash, err := gotio.ContentHash(next)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+	if nextHash != d.Hash {
+		return nil, "", time.Time{}, errors.New("delta result does not match its hash")
+	}
+	return next, nextHash, d.Time, nil
+}
+
+// file is an autosave file in a directory.
+type file struct {
+	name string
+	seq  int
+	base bool
+}
+
+// listFiles returns the autosave files in dir by sequence number.
+func listFiles(dir string) ([]file, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []file
+	for _, entry := range entries {
+		name := entry.Name()
+		for _, kind := range []struct {
+			prefix, suffix string
+			base           bool
+		}{{basePrefix, baseSuffix, true}, {deltaPrefix, deltaSuffix, false}} {
+			digits, ok := strings.CutPrefix(name, kind.prefix)
+			if !ok || !strings.HasSuffix(digits, kind.suffix) {
+				continue
+			}
+			if seq, err := strconv.Atoi(strings.TrimSuffix(digits, kind.suffix)); err == nil {
+				files = append(files, file{name: name, seq: seq, base: kind.base})
+			}
+		}
+	}
+	slices.SortFunc(files, func(a, b file) int { return a.seq - b.seq })
+	return files, nil
+}
+
+func fileName(prefix string, seq int, suffix string) string {
+	return fmt.Sprintf("%s%06d%s", prefix, seq, suffix)
+}
+
+// writeFileAtomic writes data to a temporary file in the directory of path
+// and renames it into place.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".autosave-*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
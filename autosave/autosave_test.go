@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package autosave
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+func editTimeline() (*gotio.Timeline, *gotio.Track) {
+	timeline := gotio.NewTimeline("edit", nil, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	timeline.Tracks().AppendChild(track)
+	return timeline, track
+}
+
+func autosaveFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := listFiles(dir)
+	if err != nil {
+		t.Fatalf("listFiles error: %v", err)
+	}
+	var names []string
+	for _, f := range files {
+		names = append(names, f.name)
+	}
+	return names
+}
+
+func TestSaveAndRecover(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Recover(dir); !errors.Is(err, ErrNoAutosave) {
+		t.Fatalf("Recover of an empty dir: got %v, want ErrNoAutosave", err)
+	}
+	w, err := NewWriter(dir, WithMaxDeltas(3))
+	if err != nil {
+		t.Fatalf("NewWriter error: %v", err)
+	}
+	timeline, track := editTimeline()
+	for i, name := range []string{"a", "b", "c"} {
+		if i > 0 {
+			track.AppendChild(gotio.NewClip(name, nil, nil, nil, nil, nil, "", nil))
+		}
+		if err := w.Save(timeline); err != nil {
+			t.Fatalf("Save error: %v", err)
+		}
+	}
+	if err := w.Save(timeline); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+	if files := autosaveFiles(t, dir); len(files) != 3 || files[0] != "base-000001.otio" || files[2] != "delta-000003.json" {
+		t.Fatalf("files = %v, want a base and two deltas", files)
+	}
+
+	rec, err := Recover(dir)
+	if err != nil {
+		t.Fatalf("Recover error: %v", err)
+	}
+	if rec.Deltas != 2 || len(rec.Skipped) != 0 || !rec.Timeline.IsEquivalentTo(timeline) {
+		t.Errorf("recovery = %+v", rec)
+	}
+
+	// A corrupt delta stops recovery at the state before it.
+	track.Children()[0].SetName("renamed")
+	w.Save(timeline)
+	os.WriteFile(filepath.Join(dir, "delta-000003.json"), []byte(`{"seq":`), 0o644)
+	rec, err = Recover(dir)
+	if err != nil {
+		t.Fatalf("Recover error: %v", err)
+	}
+	if rec.Deltas != 1 || len(rec.Skipped) != 2 || len(rec.Timeline.FindClips(nil, false)) != 1 {
+		t.Errorf("recovery past a corrupt delta = %d deltas, skipped %v", rec.Deltas, rec.Skipped)
+	}
+}
+
+func TestRebaseAndDiscard(t *testing.T) {
+	dir := t.TempDir()
+	timeline, track := editTimeline()
+	w, _ := NewWriter(dir, WithMaxDeltas(2))
+	for i := range 4 {
+		track.AppendChild(gotio.NewGap("", nil, nil, nil, nil, nil))
+		if err := w.Save(timeline); err != nil {
+			t.Fatalf("Save %d error: %v", i, err)
+		}
+	}
+	if files := autosaveFiles(t, dir); len(files) != 1 || files[0] != "base-000004.otio" {
+		t.Fatalf("files after rebase = %v", files)
+	}
+	rec, err := Recover(dir)
+	if err != nil || len(rec.Timeline.Tracks().Children()[0].(*gotio.Track).Children()) != 4 {
+		t.Fatalf("Recover after rebase = %+v, %v", rec, err)
+	}
+
+	// A new writer supersedes the files already there.
+	fresh, _ := editTimeline()
+	w2, _ := NewWriter(dir)
+	if err := w2.Save(fresh); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+	if rec, _ := Recover(dir); rec.Deltas != 0 || len(rec.Timeline.Tracks().Children()[0].(*gotio.Track).Children()) != 0 {
+		t.Errorf("recovered an older autosave: %+v", rec)
+	}
+
+	if err := w2.Discard(); err != nil {
+		t.Fatalf("Discard error: %v", err)
+	}
+	if _, err := Recover(dir); !errors.Is(err, ErrNoAutosave) {
+		t.Errorf("Recover after Discard: got %v, want ErrNoAutosave", err)
+	}
+}
@@ -1,4 +1,4 @@
-module github.com/mrjoshuak/gotio/benchmarks/go-json-bench
+module github.com/Avalanche-io/gotio/benchmarks/go-json-bench
 
 go 1.23.0
 
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package benchmarks measures gotio's JSON encoder on the fixtures in
+// testdata, the files the go-json-bench and cpp-json-bench programs read,
+// so its throughput can be set beside theirs:
+//
+//	go test -bench . -benchmem ./benchmarks
+package benchmarks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+// fixture is a testdata file, decoded both as a gotio object and as plain
+// JSON values.
+type fixture struct {
+	name  string
+	obj   gotio.SerializableObject
+	value any
+}
+
+func loadFixtures(b *testing.B) []fixture {
+	b.Helper()
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil || len(paths) == 0 {
+		b.Skipf("no fixtures in testdata: %v", err)
+	}
+	fixtures := make([]fixture, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			b.Fatalf("ReadFile error: %v", err)
+		}
+		obj, err := gotio.FromJSONBytes(data)
+		if err != nil {
+			b.Fatalf("%s: FromJSONBytes error: %v", path, err)
+		}
+		var value any
+		if err := json.Unmarshal(data, &value); err != nil {
+			b.Fatalf("%s: Unmarshal error: %v", path, err)
+		}
+		fixtures = append(fixtures, fixture{name: filepath.Base(path), obj: obj, value: value})
+	}
+	return fixtures
+}
+
+// encoders are the encoders compared, each writing compact JSON.
+var encoders = []struct {
+	name   string
+	encode func(f fixture) ([]byte, error)
+}{
+	{"jsonenc", func(f fixture) ([]byte, error) { return gotio.ToJSONBytes(f.obj) }},
+	{"encoding-json", func(f fixture) ([]byte, error) { return json.Marshal(f.value) }},
+}
+
+// BenchmarkMarshalFiles encodes every fixture once per iteration, as the
+// "Marshal (files)" rows of go-json-bench do.
+func BenchmarkMarshalFiles(b *testing.B) {
+	fixtures := loadFixtures(b)
+	for _, enc := range encoders {
+		b.Run(enc.name, func(b *testing.B) {
+			var size int64
+			for _, f := range fixtures {
+				data, err := enc.encode(f)
+				if err != nil {
+					b.Fatalf("%s: %v", f.name, err)
+				}
+				size += int64(len(data))
+			}
+			b.SetBytes(size)
+			b.ReportAllocs()
+			for b.Loop() {
+				for _, f := range fixtures {
+					enc.encode(f)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkMarshalBySize encodes one fixture of each size.
+func BenchmarkMarshalBySize(b *testing.B) {
+	fixtures := loadFixtures(b)
+	for _, size := range []string{"small", "medium", "standard", "large", "xlarge"} {
+		var f *fixture
+		for i := range fixtures {
+			if strings.HasPrefix(fixtures[i].name, "timeline_"+size+"_") {
+				f = &fixtures[i]
+				break
+			}
+		}
+		if f == nil {
+			continue
+		}
+		for _, enc := range encoders {
+			b.Run(size+"/"+enc.name, func(b *testing.B) {
+				data, err := enc.encode(*f)
+				if err != nil {
+					b.Fatalf("%s: %v", f.name, err)
+				}
+				b.SetBytes(int64(len(data)))
+				b.ReportAllocs()
+				for b.Loop() {
+					enc.encode(*f)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package bundle
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// WithFailIfExists sets whether writing a bundle fails, rather than
+// replacing it, when one is already at the target path.
+func WithFailIfExists(fail bool) Option {
+	return func(c *Config) {
+		c.FailIfExists = fail
+	}
+}
+
+// stagePattern returns the pattern of the temporary sibling a bundle is
+// written to before it is moved into place. The name is hidden and lacks
+// the bundle's extension, so IsOTIOD and IsOTIOZ reject it.
+func stagePattern(path string) string {
+	return "." + filepath.Base(path) + ".tmp-*"
+}
+
+// checkTarget returns an error matching fs.ErrExist if path exists and the
+// configuration asks to fail rather than replace it.
+func checkTarget(path string, cfg Config) error {
+	if !cfg.FailIfExists {
+		return nil
+	}
+	if _, err := os.Lstat(path); err == nil {
+		return existsError(path)
+	}
+	return nil
+}
+
+func existsError(path string) error {
+	return &BundleError{
+		Operation: "write",
+		Path:      path,
+		Message:   "bundle already exists",
+		Cause:     fs.ErrExist,
+	}
+}
+
+// commitFile moves the staged file into place at path. Without overwrite
+// it links rather than renames where the file system allows, so a file
+// created at path since the check is never replaced.
+func commitFile(stage, path string, overwrite bool) error {
+	if overwrite {
+		return commitError(path, os.Rename(stage, path))
+	}
+	err := os.Link(stage, path)
+	switch {
+	case err == nil:
+		return commitError(path, os.Remove(stage))
+	case errors.Is(err, fs.ErrExist):
+		return existsError(path)
+	}
+	if _, err := os.Lstat(path); err == nil {
+		return existsError(path)
+	}
+	return commitError(path, os.Rename(stage, path))
+}
+
+// commitDir moves the staged directory into place at path. An existing
+// bundle is moved aside first and restored if the move fails.
+func commitDir(stage, path string, overwrite bool) error {
+	backup := stage + ".old"
+	if overwrite {
+		if err := os.Rename(path, backup); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return commitError(path, err)
+		}
+	} else if _, err := os.Lstat(path); err == nil {
+		return existsError(path)
+	}
+	if err := os.Rename(stage, path); err != nil {
+		os.Rename(backup, path)
+		return commitError(path, err)
+	}
+	return commitError(path, os.RemoveAll(backup))
+}
+
+func commitError(path string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &BundleError{
+		Operation: "write",
+		Path:      path,
+		Message:   "failed to move bundle into place",
+		Cause:     err,
+	}
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package bundle
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/mediaresolver"
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// mediaTimeline returns a timeline with a clip of each media path.
+func mediaTimeline(name string, media ...string) *gotio.Timeline {
+	timeline := gotio.NewTimeline(name, nil, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	ar := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(48, 24))
+	for _, path := range media {
+		ref := gotio.NewExternalReference("", path, &ar, nil)
+		track.AppendChild(gotio.NewClip(filepath.Base(path), ref, &ar, nil, nil, nil, "", nil))
+	}
+	timeline.Tracks().AppendChild(track)
+	return timeline
+}
+
+// assertOnly fails unless dir holds exactly the named entries.
+func assertOnly(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir error: %v", err)
+	}
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+	if len(got) != len(names) {
+		t.Fatalf("directory holds %v, want %v", got, names)
+	}
+	for i := range names {
+		if got[i] != names[i] {
+			t.Fatalf("directory holds %v, want %v", got, names)
+		}
+	}
+}
+
+func TestWriteOverwrite(t *testing.T) {
+	writers := map[string]func(*gotio.Timeline, string, MediaReferencePolicy, ...Option) error{
+		"out.otiod": WriteOTIOD,
+		"out.otioz": WriteOTIOZ,
+	}
+	for name, write := range writers {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, name)
+
+			if err := write(mediaTimeline("first"), path, AllMissing); err != nil {
+				t.Fatalf("first write error: %v", err)
+			}
+			if err := write(mediaTimeline("second"), path, AllMissing); err != nil {
+				t.Fatalf("overwrite error: %v", err)
+			}
+			err := write(mediaTimeline("third"), path, AllMissing, WithFailIfExists(true))
+			var bundleErr *BundleError
+			if !errors.Is(err, fs.ErrExist) || !errors.As(err, &bundleErr) {
+				t.Fatalf("expected a BundleError matching fs.ErrExist, got %v", err)
+			}
+			assertOnly(t, dir, name)
+
+			var timeline *gotio.Timeline
+			if filepath.Ext(name) == ".otiod" {
+				timeline, err = ReadOTIOD(path, false)
+			} else {
+				timeline, err = ReadOTIOZ(path)
+			}
+			if err != nil {
+				t.Fatalf("read error: %v", err)
+			}
+			if timeline.Name() != "second" {
+				t.Errorf("timeline = %q, want the overwriting second", timeline.Name())
+			}
+		})
+	}
+}
+
+func TestWriteCleansUpOnError(t *testing.T) {
+	writers := map[string]func(*gotio.Timeline, string, MediaReferencePolicy, ...Option) error{
+		"out.otiod": WriteOTIOD,
+		"out.otioz": WriteOTIOZ,
+	}
+	for name, write := range writers {
+		t.Run(name, func(t *testing.T) {
+			// The resolver remembers the media after it is removed, so the
+			// bundle fails copying it rather than preparing.
+			media := filepath.Join(t.TempDir(), "removed.mov")
+			if err := os.WriteFile(media, []byte("media"), 0644); err != nil {
+				t.Fatalf("WriteFile error: %v", err)
+			}
+			resolver := mediaresolver.New()
+			if !resolver.Exists(context.Background(), media) {
+				t.Fatal("expected the resolver to find the media")
+			}
+			os.Remove(media)
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, name)
+			err := write(mediaTimeline("broken", media), path, ErrorIfNotFile, WithResolver(resolver))
+			var bundleErr *BundleError
+			if !errors.As(err, &bundleErr) || bundleErr.Message != "failed to copy media file" && bundleErr.Message != "failed to open media file" {
+				t.Fatalf("expected an error copying the media, got %v", err)
+			}
+			assertOnly(t, dir)
+			if IsOTIOD(path) || IsOTIOZ(path) {
+				t.Error("expected no bundle at the target")
+			}
+		})
+	}
+}
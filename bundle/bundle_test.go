@@ -5,13 +5,20 @@ package bundle
 
 import (
 	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/absfs/memfs"
 	"github.com/Avalanche-io/gotio/opentime"
 	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/mediaresolver"
 )
 
 // createTestTimeline creates a simple timeline for testing.
@@ -77,6 +84,18 @@ func TestPrepareForBundleAllMissing(t *testing.T) {
 	}
 }
 
+func TestPrepareForBundleLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	if _, _, err := PrepareForBundle(createTestTimeline(), MissingIfNotFile, WithLogger(logger)); err != nil {
+		t.Fatalf("PrepareForBundle failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "level=WARN") || !strings.Contains(out, "clip=test_clip") {
+		t.Errorf("expected a warning for the missing media, got %q", out)
+	}
+}
+
 func TestPrepareForBundleMissingIfNotFile(t *testing.T) {
 	timeline := createTestTimeline()
 
@@ -455,6 +474,36 @@ func TestPrepareForBundleWithRealFile(t *testing.T) {
 	}
 }
 
+func TestPrepareForBundleWithResolver(t *testing.T) {
+	tmpDir := t.TempDir()
+	mediaPath := filepath.Join(tmpDir, "test.mov")
+	if err := os.WriteFile(mediaPath, []byte("fake media data"), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	timeline := gotio.NewTimeline("test", nil, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	ar := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(48, 24))
+	for _, name := range []string{"a", "b", "c"} {
+		ref := gotio.NewExternalReference("", mediaPath, &ar, nil)
+		track.AppendChild(gotio.NewClip(name, ref, &ar, nil, nil, nil, "", nil))
+	}
+	timeline.Tracks().AppendChild(track)
+
+	resolver := mediaresolver.New()
+	size, err := WriteOTIOZDryRun(timeline, ErrorIfNotFile, WithResolver(resolver))
+	if err != nil {
+		t.Fatalf("WriteOTIOZDryRun failed: %v", err)
+	}
+	if size < int64(len("fake media data")) {
+		t.Errorf("expected size to include media, got %d", size)
+	}
+	// One stat for the shared media; the other clips and the size come from the cache.
+	if lookups, hits := resolver.Stats(); lookups != 1 || hits != 3 {
+		t.Errorf("expected 1 lookup and 3 cache hits, got %d and %d", lookups, hits)
+	}
+}
+
 func TestOTIOZWithRealMedia(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "otioz_media_test")
 	if err != nil {
@@ -2046,3 +2095,309 @@ func TestCopyFileFSCreateError(t *testing.T) {
 		t.Error("Expected error from copyFileFS with error filesystem")
 	}
 }
+
+func TestReadBundleDecodeLimits(t *testing.T) {
+	tmpDir := t.TempDir()
+	timeline := createTestTimeline()
+
+	zipPath := filepath.Join(tmpDir, "test.otioz")
+	if err := WriteOTIOZ(timeline, zipPath, AllMissing); err != nil {
+		t.Fatalf("WriteOTIOZ failed: %v", err)
+	}
+	if _, err := ReadOTIOZ(zipPath, WithDecodeOptions(gotio.WithMaxBytes(64))); !errors.Is(err, gotio.ErrDecodeLimit) {
+		t.Errorf("expected ReadOTIOZ to refuse large content, got %v", err)
+	}
+	if _, err := ReadOTIOZ(zipPath, WithDecodeOptions(gotio.WithMaxChildren(100))); err != nil {
+		t.Errorf("ReadOTIOZ failed: %v", err)
+	}
+
+	dirPath := filepath.Join(tmpDir, "test.otiod")
+	if err := WriteOTIOD(timeline, dirPath, AllMissing); err != nil {
+		t.Fatalf("WriteOTIOD failed: %v", err)
+	}
+	if _, err := ReadOTIOD(dirPath, false, WithDecodeOptions(gotio.WithMaxNestingDepth(2))); !errors.Is(err, gotio.ErrDecodeLimit) {
+		t.Errorf("expected ReadOTIOD to refuse deep content, got %v", err)
+	}
+}
+
+// writeTestZip writes a bundle with a valid content.otio and the given
+// extra entries, which are symlinks when their mode says so.
+func writeTestZip(t *testing.T, path string, entries []testZipEntry) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create zip: %v", err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	entries = append([]testZipEntry{{name: "content.otio", data: `{"OTIO_SCHEMA": "Timeline.1", "name": "test"}`}}, entries...)
+	for _, e := range entries {
+		header := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		header.SetMode(0644 | e.mode)
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			t.Fatalf("CreateHeader failed: %v", err)
+		}
+		w.Write([]byte(e.data))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip: %v", err)
+	}
+}
+
+type testZipEntry struct {
+	name string
+	data string
+	mode os.FileMode
+}
+
+func TestReadOTIOZWithExtractionPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   testZipEntry
+		policy  ExtractionPolicy
+		wantErr error
+		check   string
+	}{
+		{"traversal", testZipEntry{name: "../escaped.txt", data: "x"}, ExtractionPolicy{}, ErrUnsafeEntry, ""},
+		{"absolute", testZipEntry{name: "/tmp/escaped.txt", data: "x"}, ExtractionPolicy{}, ErrUnsafeEntry, ""},
+		{"symlink rejected", testZipEntry{name: "media/link", data: "../content.otio", mode: os.ModeSymlink}, ExtractionPolicy{}, ErrUnsafeEntry, ""},
+		{"symlink skipped", testZipEntry{name: "media/link", data: "/etc/passwd", mode: os.ModeSymlink}, ExtractionPolicy{Symlinks: SymlinksSkip}, nil, ""},
+		{"local symlink", testZipEntry{name: "media/link", data: "../content.otio", mode: os.ModeSymlink}, ExtractionPolicy{Symlinks: SymlinksAllowLocal}, nil, "media/link"},
+		{"escaping symlink", testZipEntry{name: "media/link", data: "../../outside", mode: os.ModeSymlink}, ExtractionPolicy{Symlinks: SymlinksAllowLocal}, ErrUnsafeEntry, ""},
+		{"absolute symlink", testZipEntry{name: "media/link", data: "/etc/passwd", mode: os.ModeSymlink}, ExtractionPolicy{Symlinks: SymlinksAllowLocal}, ErrUnsafeEntry, ""},
+		{"unclean symlink", testZipEntry{name: "media/link", data: "sub/../../content.otio", mode: os.ModeSymlink}, ExtractionPolicy{Symlinks: SymlinksAllowLocal}, ErrUnsafeEntry, ""},
+		{"too large", testZipEntry{name: "media/big.mov", data: string(make([]byte, 4096))}, ExtractionPolicy{MaxBytes: 1024}, ErrExtractionTooLarge, ""},
+		{"within limit", testZipEntry{name: "media/small.mov", data: "small"}, ExtractionPolicy{MaxBytes: 1024}, nil, "media/small.mov"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			zipPath := filepath.Join(tmpDir, "test.otioz")
+			writeTestZip(t, zipPath, []testZipEntry{tt.entry})
+
+			extractDir := filepath.Join(tmpDir, "a", "b", "extract")
+			_, err := ReadOTIOZWithExtraction(zipPath, extractDir, WithExtractionPolicy(tt.policy))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				if tt.wantErr == ErrUnsafeEntry && !filepath.IsAbs(tt.entry.name) {
+					if _, err := os.Lstat(filepath.Join(extractDir, tt.entry.name)); err == nil {
+						t.Errorf("%s was written where it resolves", tt.entry.name)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadOTIOZWithExtraction failed: %v", err)
+			}
+			if tt.check != "" {
+				if _, err := os.Stat(filepath.Join(extractDir, tt.check)); err != nil {
+					t.Errorf("expected %s to be extracted: %v", tt.check, err)
+				}
+			} else if _, err := os.Lstat(filepath.Join(extractDir, tt.entry.name)); err == nil {
+				t.Errorf("expected %s to be skipped", tt.entry.name)
+			}
+		})
+	}
+}
+
+func TestReadOTIOZWithExtractionSymlinkChain(t *testing.T) {
+	tmpDir := t.TempDir()
+	zipPath := filepath.Join(tmpDir, "test.otioz")
+	// Each symlink is local on its own, but q/q/l is really l, so
+	// q/q/l/evil.txt would land two directories above extractDir
+	writeTestZip(t, zipPath, []testZipEntry{
+		{name: "q", data: ".", mode: os.ModeSymlink},
+		{name: "q/q/l", data: "../..", mode: os.ModeSymlink},
+		{name: "q/q/l/evil.txt", data: "x"},
+	})
+
+	extractDir := filepath.Join(tmpDir, "a", "b", "extract")
+	_, err := ReadOTIOZWithExtraction(zipPath, extractDir, WithExtractionPolicy(ExtractionPolicy{Symlinks: SymlinksAllowLocal}))
+	if !errors.Is(err, ErrUnsafeEntry) {
+		t.Fatalf("expected ErrUnsafeEntry, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "q/q/l is inside symlink q") {
+		t.Errorf("error %q does not name the symlink", err)
+	}
+	for _, path := range []string{filepath.Join(tmpDir, "a", "evil.txt"), filepath.Join(extractDir, "l")} {
+		if _, err := os.Lstat(path); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("expected nothing at %s, got %v", path, err)
+		}
+	}
+}
+
+func TestReadOTIOZWithExtractionTraversal(t *testing.T) {
+	tmpDir := t.TempDir()
+	zipPath := filepath.Join(tmpDir, "test.otioz")
+	writeTestZip(t, zipPath, []testZipEntry{{name: "../escaped.txt", data: "x"}})
+
+	// The entry resolves to the parent of the extraction directory
+	extractDir := filepath.Join(tmpDir, "extract")
+	escaped := filepath.Join(tmpDir, "escaped.txt")
+	if resolved := filepath.Join(extractDir, "../escaped.txt"); resolved != escaped {
+		t.Fatalf("entry resolves to %s, want %s", resolved, escaped)
+	}
+
+	_, err := ReadOTIOZWithExtraction(zipPath, extractDir)
+	var bundleErr *BundleError
+	if !errors.As(err, &bundleErr) || !errors.Is(err, ErrUnsafeEntry) {
+		t.Fatalf("expected a BundleError wrapping ErrUnsafeEntry, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "../escaped.txt is outside the extraction directory") {
+		t.Errorf("error %q does not name the traversal", err)
+	}
+	if _, err := os.Lstat(escaped); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected no file at %s, got %v", escaped, err)
+	}
+}
+
+func FuzzReadOTIOZ(f *testing.F) {
+	tmpDir := f.TempDir()
+	zipPath := filepath.Join(tmpDir, "seed.otioz")
+	if err := WriteOTIOZ(createTestTimeline(), zipPath, AllMissing); err != nil {
+		f.Fatalf("WriteOTIOZ failed: %v", err)
+	}
+	seed, err := os.ReadFile(zipPath)
+	if err != nil {
+		f.Fatalf("failed to read seed: %v", err)
+	}
+	f.Add(seed)
+	f.Add([]byte("PK\x05\x06" + string(make([]byte, 18))))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		path := filepath.Join(t.TempDir(), "fuzz.otioz")
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		ReadOTIOZ(path, WithDecodeOptions(gotio.WithMaxBytes(1<<20), gotio.WithMaxChildren(1000)))
+	})
+}
+
+// countingReaderAt counts the bytes read through it.
+type countingReaderAt struct {
+	r    io.ReaderAt
+	read int64
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n, err := c.r.ReadAt(p, off)
+	c.read += int64(n)
+	return n, err
+}
+
+func TestOpenOTIOZ(t *testing.T) {
+	tmpDir := t.TempDir()
+	media := bytes.Repeat([]byte("0123456789"), 100000)
+	mediaPath := filepath.Join(tmpDir, "big.mov")
+	if err := os.WriteFile(mediaPath, media, 0644); err != nil {
+		t.Fatalf("failed to create media: %v", err)
+	}
+	timeline := gotio.NewTimeline("remote", nil, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	ar := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(48, 24))
+	track.AppendChild(gotio.NewClip("clip", gotio.NewExternalReference("", mediaPath, &ar, nil), &ar, nil, nil, nil, "", nil))
+	timeline.Tracks().AppendChild(track)
+	bundlePath := filepath.Join(tmpDir, "remote.otioz")
+	if err := WriteOTIOZ(timeline, bundlePath, ErrorIfNotFile); err != nil {
+		t.Fatalf("WriteOTIOZ failed: %v", err)
+	}
+	data, err := os.ReadFile(bundlePath)
+	if err != nil {
+		t.Fatalf("failed to read bundle: %v", err)
+	}
+
+	// Reading the timeline does not touch the media.
+	counter := &countingReaderAt{r: bytes.NewReader(data)}
+	read, err := ReadOTIOZFrom(counter, int64(len(data)))
+	if err != nil {
+		t.Fatalf("ReadOTIOZFrom failed: %v", err)
+	}
+	if read.Name() != "remote" {
+		t.Errorf("expected remote, got %s", read.Name())
+	}
+	if counter.read >= int64(len(media)) {
+		t.Errorf("read %d bytes for the timeline, bundle media is %d", counter.read, len(media))
+	}
+
+	var last int64
+	br, err := OpenOTIOZ(bytes.NewReader(data), int64(len(data)), WithProgress(func(name string, read, total int64) {
+		last = read
+	}))
+	if err != nil {
+		t.Fatalf("OpenOTIOZ failed: %v", err)
+	}
+	members := br.Media()
+	if len(members) != 1 || members[0].Name != "media/big.mov" || members[0].Size != int64(len(media)) || !members[0].Stored {
+		t.Fatalf("unexpected media members %+v", members)
+	}
+
+	rc, err := br.OpenMedia("./media/big.mov")
+	if err != nil {
+		t.Fatalf("OpenMedia failed: %v", err)
+	}
+	got, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil || !bytes.Equal(got, media) {
+		t.Fatalf("OpenMedia read %d bytes, err %v", len(got), err)
+	}
+	if last != int64(len(media)) {
+		t.Errorf("expected progress to reach %d, got %d", len(media), last)
+	}
+
+	rc, err = br.OpenMediaAt("media/big.mov", 999995)
+	if err != nil {
+		t.Fatalf("OpenMediaAt failed: %v", err)
+	}
+	got, _ = io.ReadAll(rc)
+	rc.Close()
+	if string(got) != "56789" {
+		t.Errorf("expected resumed read 56789, got %q", got)
+	}
+
+	if _, err := br.OpenMedia("media/missing.mov"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+	if _, err := br.OpenMediaAt("media/big.mov", int64(len(media))+1); err == nil {
+		t.Error("expected an error for an offset past the end")
+	}
+}
+
+func TestOpenOTIOZCompressedAndUnsafe(t *testing.T) {
+	zipPath := filepath.Join(t.TempDir(), "test.otioz")
+	writeTestZip(t, zipPath, []testZipEntry{
+		{name: "media/a.wav", data: "compressed media"},
+		{name: "media/../../escaped.wav", data: "x"},
+		{name: "media/link", data: "/etc/passwd", mode: os.ModeSymlink},
+	})
+	f, err := os.Open(zipPath)
+	if err != nil {
+		t.Fatalf("failed to open zip: %v", err)
+	}
+	defer f.Close()
+	info, _ := f.Stat()
+
+	br, err := OpenOTIOZ(f, info.Size())
+	if err != nil {
+		t.Fatalf("OpenOTIOZ failed: %v", err)
+	}
+	members := br.Media()
+	if len(members) != 1 || members[0].Name != "media/a.wav" || members[0].Stored {
+		t.Fatalf("unexpected media members %+v", members)
+	}
+	rc, err := br.OpenMediaAt("media/a.wav", 11)
+	if err != nil {
+		t.Fatalf("OpenMediaAt failed: %v", err)
+	}
+	got, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(got) != "media" {
+		t.Errorf("expected media, got %q", got)
+	}
+
+	if _, err := OpenOTIOZ(bytes.NewReader([]byte("not a zip")), 9); err == nil {
+		t.Error("expected an error for invalid zip data")
+	}
+}
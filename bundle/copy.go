@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package bundle
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultConcurrency is the number of media files copied at once when
+// WithConcurrency is not given.
+const DefaultConcurrency = 4
+
+// Media is read ahead of the zip writer in chunks of prefetchChunkSize
+// bytes, at most prefetchChunks per file.
+const (
+	prefetchChunkSize = 1 << 20
+	prefetchChunks    = 8
+)
+
+// WithConcurrency sets the number of media files WriteOTIOD and
+// WriteOTIOZ copy at once. Copying over network mounts is bound by
+// latency, which copies in parallel hide. One copies serially; zero or
+// less uses DefaultConcurrency.
+func WithConcurrency(n int) Option {
+	return func(c *Config) {
+		c.Concurrency = n
+	}
+}
+
+// copyMedia calls copy for each source, at most n at once. After a copy
+// fails no more are started; the errors of all that failed are returned
+// joined, in the order of sources.
+func copyMedia(sources []string, n int, copy func(source string) error) error {
+	errs := make([]error, len(sources))
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, n)
+	for i, source := range sources {
+		sem <- struct{}{}
+		if failed.Load() {
+			break
+		}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			if err := copy(source); err != nil {
+				errs[i] = err
+				failed.Store(true)
+			}
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// prefetched is a media file read ahead of the zip writer.
+type prefetched struct {
+	chunks chan []byte
+	// err is the error reading the file, valid once chunks is closed.
+	err error
+}
+
+// prefetchMedia starts reading the sources, at most n at once and in
+// order, so a writer taking them one at a time in that order does not
+// wait on the latency of each open and first read. Cancelling ctx stops
+// the reads; those not started fail with its error.
+func prefetchMedia(ctx context.Context, sources []string, n int) []*prefetched {
+	files := make([]*prefetched, len(sources))
+	for i := range files {
+		files[i] = &prefetched{chunks: make(chan []byte, prefetchChunks)}
+	}
+	go func() {
+		sem := make(chan struct{}, n)
+		for i, source := range sources {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				for _, file := range files[i:] {
+					file.err = ctx.Err()
+					close(file.chunks)
+				}
+				return
+			}
+			go func() {
+				defer func() { <-sem }()
+				files[i].read(ctx, source)
+			}()
+		}
+	}()
+	return files
+}
+
+// read reads the file at path into chunks and closes it.
+func (p *prefetched) read(ctx context.Context, path string) {
+	defer close(p.chunks)
+	f, err := os.Open(path)
+	if err != nil {
+		p.err = err
+		return
+	}
+	defer f.Close()
+	for {
+		buf := make([]byte, prefetchChunkSize)
+		n, err := io.ReadFull(f, buf)
+		if n > 0 {
+			select {
+			case p.chunks <- buf[:n]:
+			case <-ctx.Done():
+				p.err = ctx.Err()
+				return
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return
+		}
+		if err != nil {
+			p.err = err
+			return
+		}
+	}
+}
+
+// writeTo writes the file to w as it is read.
+func (p *prefetched) writeTo(w io.Writer) (int64, error) {
+	var written int64
+	for chunk := range p.chunks {
+		n, err := w.Write(chunk)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, p.err
+}
+
+// drain discards the rest of the file and returns its read error, if it
+// is not that of a cancelled read.
+func (p *prefetched) drain() error {
+	for range p.chunks {
+	}
+	if errors.Is(p.err, context.Canceled) {
+		return nil
+	}
+	return p.err
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWriteParallelMedia(t *testing.T) {
+	src := t.TempDir()
+	var media []string
+	contents := make(map[string][]byte)
+	for i := range 12 {
+		path := filepath.Join(src, fmt.Sprintf("shot%02d.mov", 11-i))
+		// One file spans several read ahead chunks.
+		data := bytes.Repeat([]byte{byte('a' + i)}, 100+i)
+		if i == 5 {
+			data = bytes.Repeat([]byte("frame"), prefetchChunkSize)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("WriteFile error: %v", err)
+		}
+		media = append(media, path)
+		contents[filepath.Base(path)] = data
+	}
+	timeline := mediaTimeline("parallel", media...)
+
+	dir := t.TempDir()
+	otioz := filepath.Join(dir, "out.otioz")
+	if err := WriteOTIOZ(timeline, otioz, ErrorIfNotFile, WithConcurrency(3)); err != nil {
+		t.Fatalf("WriteOTIOZ error: %v", err)
+	}
+	zr, err := zip.OpenReader(otioz)
+	if err != nil {
+		t.Fatalf("OpenReader error: %v", err)
+	}
+	defer zr.Close()
+	var members []string
+	for _, f := range zr.File {
+		members = append(members, f.Name)
+		want, ok := contents[filepath.Base(f.Name)]
+		if !ok {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Open %s error: %v", f.Name, err)
+		}
+		var got bytes.Buffer
+		got.ReadFrom(rc)
+		rc.Close()
+		if !bytes.Equal(got.Bytes(), want) {
+			t.Errorf("%s holds %d bytes, want %d", f.Name, got.Len(), len(want))
+		}
+	}
+	if len(members) != 15 || !slices.IsSorted(members[3:]) {
+		t.Errorf("members = %v, want version, manifest, content and sorted media", members)
+	}
+
+	otiod := filepath.Join(dir, "out.otiod")
+	if err := WriteOTIOD(timeline, otiod, ErrorIfNotFile, WithConcurrency(5)); err != nil {
+		t.Fatalf("WriteOTIOD error: %v", err)
+	}
+	for name, want := range contents {
+		got, err := os.ReadFile(filepath.Join(otiod, "media", name))
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("%s: %d bytes, %v", name, len(got), err)
+		}
+	}
+}
+
+func TestCopyMediaErrors(t *testing.T) {
+	sources := []string{"a", "b", "c"}
+	var started sync.WaitGroup
+	started.Add(len(sources))
+	err := copyMedia(sources, len(sources), func(source string) error {
+		// Fail only once every copy has started.
+		started.Done()
+		started.Wait()
+		if source == "b" {
+			return nil
+		}
+		return errors.New(source + " failed")
+	})
+	if err == nil || err.Error() != "a failed\nc failed" {
+		t.Errorf("err = %v, want the failures of a and c", err)
+	}
+
+	var calls atomic.Int32
+	err = copyMedia([]string{"a", "b", "c", "d"}, 1, func(source string) error {
+		calls.Add(1)
+		return errors.New(source + " failed")
+	})
+	if calls.Load() != 1 || err == nil || err.Error() != "a failed" {
+		t.Errorf("serial copy made %d calls, err %v; want it to stop at the first failure", calls.Load(), err)
+	}
+}
+
+func TestPrefetchMediaErrors(t *testing.T) {
+	dir := t.TempDir()
+	present := filepath.Join(dir, "present.mov")
+	if err := os.WriteFile(present, []byte("media"), 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	sources := []string{present, filepath.Join(dir, "missing1.mov"), filepath.Join(dir, "missing2.mov")}
+	files := prefetchMedia(context.Background(), sources, len(sources))
+
+	var buf bytes.Buffer
+	if n, err := files[0].writeTo(&buf); n != 5 || err != nil {
+		t.Errorf("writeTo = %d, %v", n, err)
+	}
+	err := drainMedia(sources[1:], files[1:])
+	var bundleErr *BundleError
+	if !errors.As(err, &bundleErr) || bundleErr.Path != sources[1] || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("drainMedia = %v, want errors for both missing files", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	files = prefetchMedia(ctx, sources, 1)
+	if err := drainMedia(sources, files); err != nil && !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("cancelled reads reported %v", err)
+	}
+}
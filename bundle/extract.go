@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package bundle
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Errors returned, as the cause of a BundleError, when extraction refuses
+// a bundle.
+var (
+	// ErrUnsafeEntry is returned for an entry that would be written
+	// outside the extraction directory, such as "../../etc/passwd", an
+	// absolute path or a path through an extracted symlink, or for a
+	// symlink the policy does not allow.
+	ErrUnsafeEntry = errors.New("unsafe bundle entry")
+	// ErrExtractionTooLarge is returned when the extracted files would
+	// exceed ExtractionPolicy.MaxBytes.
+	ErrExtractionTooLarge = errors.New("bundle extraction too large")
+)
+
+// SymlinkPolicy says how extraction treats symbolic link entries.
+type SymlinkPolicy int
+
+const (
+	// SymlinksReject fails the extraction on any symlink.
+	SymlinksReject SymlinkPolicy = iota
+	// SymlinksSkip leaves symlinks out.
+	SymlinksSkip
+	// SymlinksAllowLocal creates symlinks whose targets are relative and
+	// stay inside the extraction directory, and rejects the rest. Later
+	// entries may not be written through a symlink.
+	SymlinksAllowLocal
+)
+
+// String returns the policy name.
+func (p SymlinkPolicy) String() string {
+	switch p {
+	case SymlinksReject:
+		return "Reject"
+	case SymlinksSkip:
+		return "Skip"
+	case SymlinksAllowLocal:
+		return "AllowLocal"
+	default:
+		return fmt.Sprintf("SymlinkPolicy(%d)", p)
+	}
+}
+
+// ExtractionPolicy controls what ReadOTIOZWithExtraction writes to disk.
+// Entries that would land outside the extraction directory are always
+// rejected.
+type ExtractionPolicy struct {
+	// Symlinks says how symbolic link entries are treated.
+	Symlinks SymlinkPolicy
+	// MaxBytes limits the total uncompressed size of the extracted files.
+	// Zero means no limit.
+	MaxBytes int64
+}
+
+// WithExtractionPolicy sets the policy for extracting bundles.
+func WithExtractionPolicy(policy ExtractionPolicy) Option {
+	return func(c *Config) {
+		c.Extraction = policy
+	}
+}
+
+// extractor writes the entries of a zip archive under a directory. Every
+// write goes through root, so no entry can escape the directory even if
+// the checks below miss a path.
+type extractor struct {
+	root     *os.Root
+	policy   ExtractionPolicy
+	progress ProgressFunc
+	written  int64
+}
+
+// extract writes one entry. It returns false if the entry was skipped.
+func (x *extractor) extract(f *zip.File) (bool, error) {
+	name := filepath.FromSlash(f.Name)
+	if !filepath.IsLocal(name) {
+		return false, fmt.Errorf("%w: %s is outside the extraction directory", ErrUnsafeEntry, f.Name)
+	}
+	if err := x.checkParents(f.Name, name); err != nil {
+		return false, err
+	}
+
+	switch mode := f.Mode(); {
+	case mode.IsDir():
+		return true, x.root.MkdirAll(name, 0755)
+	case mode&os.ModeSymlink != 0:
+		return x.extractSymlink(f, name)
+	case !mode.IsRegular():
+		return false, fmt.Errorf("%w: %s is not a regular file", ErrUnsafeEntry, f.Name)
+	}
+
+	if err := x.root.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return false, err
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return false, err
+	}
+	defer rc.Close()
+	dest, err := x.root.Create(name)
+	if err != nil {
+		return false, err
+	}
+	defer dest.Close()
+
+	// The sizes in the archive can lie, so the limit is enforced on the
+	// bytes actually written.
+	var src io.Reader = rc
+	if x.policy.MaxBytes > 0 {
+		src = io.LimitReader(rc, x.policy.MaxBytes-x.written+1)
+	}
+	if x.progress != nil {
+		src = &progressReader{rc: io.NopCloser(src), name: f.Name, total: int64(f.UncompressedSize64), fn: x.progress}
+	}
+	n, err := io.Copy(dest, src)
+	x.written += n
+	if err != nil {
+		return false, err
+	}
+	if x.policy.MaxBytes > 0 && x.written > x.policy.MaxBytes {
+		return false, fmt.Errorf("%w: more than %d bytes", ErrExtractionTooLarge, x.policy.MaxBytes)
+	}
+	return true, nil
+}
+
+// checkParents refuses an entry whose parent directories include a
+// symlink extracted earlier, since the symlink checks assume an entry's
+// directory is where its name says.
+func (x *extractor) checkParents(entry, name string) error {
+	dir := filepath.Dir(name)
+	if dir == "." {
+		return nil
+	}
+	prefix := ""
+	for _, part := range strings.Split(dir, string(filepath.Separator)) {
+		prefix = filepath.Join(prefix, part)
+		info, err := x.root.Lstat(prefix)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("%w: %s is inside symlink %s", ErrUnsafeEntry, entry, filepath.ToSlash(prefix))
+		}
+	}
+	return nil
+}
+
+// extractSymlink creates a symlink entry as the policy allows.
+func (x *extractor) extractSymlink(f *zip.File, name string) (bool, error) {
+	switch x.policy.Symlinks {
+	case SymlinksSkip:
+		return false, nil
+	case SymlinksAllowLocal:
+	default:
+		return false, fmt.Errorf("%w: %s is a symlink", ErrUnsafeEntry, f.Name)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return false, err
+	}
+	defer rc.Close()
+	target, err := io.ReadAll(io.LimitReader(rc, 4096))
+	if err != nil {
+		return false, err
+	}
+	linkTarget := filepath.FromSlash(string(target))
+	// A target like "link/.." resolves through whatever link is, so only
+	// clean targets, whose ".." lead and climb real directories, are
+	// checked lexically.
+	resolved := filepath.Join(filepath.Dir(name), linkTarget)
+	if filepath.IsAbs(linkTarget) || linkTarget != filepath.Clean(linkTarget) || !filepath.IsLocal(resolved) {
+		return false, fmt.Errorf("%w: symlink %s points outside the extraction directory", ErrUnsafeEntry, f.Name)
+	}
+	if err := x.root.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return false, err
+	}
+	return true, x.root.Symlink(linkTarget, name)
+}
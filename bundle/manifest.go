@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package bundle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+// MediaManifest maps absolute source paths to the external references that point to them.
+type MediaManifest map[string][]*gotio.ExternalReference
+
+// MediaFiles maps the absolute source paths of a manifest to what is
+// known of the files at them.
+type MediaFiles map[string]*MediaFile
+
+// MediaFile is what is known of a media file of a manifest.
+type MediaFile struct {
+	// Size is the size of the file in bytes, or -1 if unknown.
+	Size int64
+	// ModTime is the modification time of the file, if known.
+	ModTime time.Time
+	// Hash is the hex SHA-256 of the content, set by StatMedia with
+	// WithChecksums.
+	Hash string
+}
+
+// WithChecksums sets whether StatMedia, and so the bundle writers, hash
+// the media of a manifest, so identical files at different paths are
+// copied once and rewriting a bundle reuses only media whose content
+// matches.
+func WithChecksums(checksums bool) Option {
+	return func(c *Config) {
+		c.Checksums = checksums
+	}
+}
+
+// StatMedia returns the size and modification time of each file in the
+// manifest, and its hash if WithChecksums is set, reading several files at
+// once as set by WithConcurrency. A file that cannot be statted has size
+// -1 and no time.
+func StatMedia(manifest MediaManifest, opts ...Option) (MediaFiles, error) {
+	cfg := newConfig(opts)
+	files := make(MediaFiles, len(manifest))
+	for path := range manifest {
+		file := &MediaFile{Size: -1}
+		if info, err := os.Stat(path); err == nil {
+			file.Size, file.ModTime = info.Size(), info.ModTime()
+		}
+		files[path] = file
+	}
+	if !cfg.Checksums {
+		return files, nil
+	}
+	err := copyMedia(slices.Sorted(maps.Keys(files)), cfg.Concurrency, func(path string) error {
+		hash, err := hashFile(path)
+		if err != nil {
+			return &BundleError{
+				Operation: "hash",
+				Path:      path,
+				Message:   "failed to hash media file",
+				Cause:     err,
+			}
+		}
+		files[path].Hash = hash
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// Dedupe merges the entries of the manifest that are the same file at
+// different paths, such as a file URL and a plain path through a symlink.
+// The references of an entry merged away move to the entry whose path
+// sorts first. It returns the number of entries removed.
+func (m MediaManifest) Dedupe() int {
+	type statted struct {
+		path string
+		info os.FileInfo
+	}
+	bySize := make(map[int64][]statted)
+	removed := 0
+	for _, path := range slices.Sorted(maps.Keys(m)) {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		same := false
+		for _, other := range bySize[info.Size()] {
+			if os.SameFile(other.info, info) {
+				m.merge(other.path, path)
+				same = true
+				break
+			}
+		}
+		if same {
+			removed++
+			continue
+		}
+		bySize[info.Size()] = append(bySize[info.Size()], statted{path, info})
+	}
+	return removed
+}
+
+// DedupeContent merges the entries of the manifest whose files have the
+// same size and Hash in files, removing the entries merged away from both.
+// The references of an entry merged away move to the entry whose path
+// sorts first. It returns the number of entries removed.
+func (m MediaManifest) DedupeContent(files MediaFiles) int {
+	byHash := make(map[string]string)
+	removed := 0
+	for _, path := range slices.Sorted(maps.Keys(m)) {
+		file := files[path]
+		if file == nil || file.Hash == "" {
+			continue
+		}
+		key := strconv.FormatInt(file.Size, 10) + ":" + file.Hash
+		if kept, ok := byHash[key]; ok {
+			m.merge(kept, path)
+			delete(files, path)
+			removed++
+			continue
+		}
+		byHash[key] = path
+	}
+	return removed
+}
+
+// merge moves the references of the entry at from to the entry at into
+// and removes the entry at from.
+func (m MediaManifest) merge(into, from string) {
+	m[into] = append(m[into], m[from]...)
+	delete(m, from)
+}
+
+// dedupeMedia merges the entries of manifest that are the same file, or
+// with checksums the same content, and returns the files of those left.
+func dedupeMedia(manifest MediaManifest, cfg Config) (MediaFiles, error) {
+	manifest.Dedupe()
+	files, err := StatMedia(manifest, WithConcurrency(cfg.Concurrency), WithChecksums(cfg.Checksums))
+	if err != nil {
+		return nil, err
+	}
+	manifest.DedupeContent(files)
+	return files, nil
+}
+
+// reuseMedia links existing, the media of the bundle being replaced, to
+// dst if it matches file by size and modification time, and by hash if
+// file has one. It reports whether it did.
+func reuseMedia(existing, dst string, file *MediaFile) bool {
+	if file == nil || file.ModTime.IsZero() {
+		return false
+	}
+	info, err := os.Stat(existing)
+	if err != nil || !info.Mode().IsRegular() || info.Size() != file.Size || !info.ModTime().Equal(file.ModTime) {
+		return false
+	}
+	if file.Hash != "" {
+		if hash, err := hashFile(existing); err != nil || hash != file.Hash {
+			return false
+		}
+	}
+	return os.Link(existing, dst) == nil
+}
+
+// hashFile returns the hex SHA-256 of the file at path.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
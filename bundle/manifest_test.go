@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestManifestDedupe(t *testing.T) {
+	src := t.TempDir()
+	shot := filepath.Join(src, "shot.mov")
+	copied := filepath.Join(src, "copy.mov")
+	linked := filepath.Join(src, "linked.mov")
+	for _, path := range []string{shot, copied} {
+		if err := os.WriteFile(path, []byte("frames"), 0644); err != nil {
+			t.Fatalf("WriteFile error: %v", err)
+		}
+	}
+	if err := os.Symlink(shot, linked); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	timeline := mediaTimeline("dedupe", "file://"+filepath.ToSlash(shot), linked, shot, copied)
+
+	_, manifest, err := PrepareForBundle(timeline, ErrorIfNotFile)
+	if err != nil {
+		t.Fatalf("PrepareForBundle error: %v", err)
+	}
+	if len(manifest) != 3 || len(manifest[shot]) != 2 {
+		t.Fatalf("manifest = %v, want file URL and path merged", manifest)
+	}
+	if n := manifest.Dedupe(); n != 1 || len(manifest) != 2 || len(manifest[linked]) != 3 {
+		t.Errorf("Dedupe = %d, manifest = %v, want symlink merged into %s", n, manifest, linked)
+	}
+
+	files, err := StatMedia(manifest)
+	if err != nil {
+		t.Fatalf("StatMedia error: %v", err)
+	}
+	if file := files[linked]; file.Size != 6 || file.ModTime.IsZero() || file.Hash != "" {
+		t.Errorf("file = %+v, want size and time without hash", file)
+	}
+	if n := manifest.DedupeContent(files); n != 0 {
+		t.Errorf("DedupeContent = %d without hashes, want 0", n)
+	}
+
+	files, err = StatMedia(manifest, WithChecksums(true))
+	if err != nil {
+		t.Fatalf("StatMedia error: %v", err)
+	}
+	if files[copied].Hash != files[linked].Hash || len(files[linked].Hash) != 64 {
+		t.Errorf("hashes %q and %q, want equal SHA-256", files[copied].Hash, files[linked].Hash)
+	}
+	if n := manifest.DedupeContent(files); n != 1 || len(manifest) != 1 || len(manifest[copied]) != 4 || len(files) != 1 {
+		t.Errorf("DedupeContent = %d, manifest = %v, want all merged into %s", n, manifest, copied)
+	}
+}
+
+func TestWriteOTIODReusesMedia(t *testing.T) {
+	src := t.TempDir()
+	kept := filepath.Join(src, "kept.mov")
+	changed := filepath.Join(src, "changed.mov")
+	for _, path := range []string{kept, changed} {
+		if err := os.WriteFile(path, []byte("take 1"), 0644); err != nil {
+			t.Fatalf("WriteFile error: %v", err)
+		}
+	}
+	timeline := mediaTimeline("delivery", kept, changed)
+	path := filepath.Join(t.TempDir(), "delivery.otiod")
+	if err := WriteOTIOD(timeline, path, ErrorIfNotFile); err != nil {
+		t.Fatalf("WriteOTIOD error: %v", err)
+	}
+	before := make(map[string]os.FileInfo)
+	for _, name := range []string{"kept.mov", "changed.mov"} {
+		info, err := os.Stat(filepath.Join(path, "media", name))
+		if err != nil {
+			t.Fatalf("Stat error: %v", err)
+		}
+		before[name] = info
+	}
+
+	if err := os.WriteFile(changed, []byte("take 2"), 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(changed, later, later); err != nil {
+		t.Fatalf("Chtimes error: %v", err)
+	}
+	if err := WriteOTIOD(timeline, path, ErrorIfNotFile, WithChecksums(true)); err != nil {
+		t.Fatalf("WriteOTIOD overwrite error: %v", err)
+	}
+	for name, want := range map[string]string{"kept.mov": "take 1", "changed.mov": "take 2"} {
+		media := filepath.Join(path, "media", name)
+		got, err := os.ReadFile(media)
+		if err != nil || string(got) != want {
+			t.Errorf("%s = %q, %v, want %q", name, got, err, want)
+		}
+		info, err := os.Stat(media)
+		if err != nil {
+			t.Fatalf("Stat error: %v", err)
+		}
+		if reused := os.SameFile(before[name], info); reused != (name == "kept.mov") {
+			t.Errorf("%s reused = %v", name, reused)
+		}
+	}
+}
@@ -5,15 +5,23 @@ package bundle
 
 import (
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/metrics"
 )
 
-// ReadOTIOD reads a .otiod bundle directory and returns the timeline.
-func ReadOTIOD(path string, absolutePaths bool) (*gotio.Timeline, error) {
+// ReadOTIOD reads a .otiod bundle directory and returns the timeline. With
+// absolutePaths, relative references are made absolute under the bundle,
+// or under the media root of a lightweight bundle given by WithMediaRoot.
+func ReadOTIOD(path string, absolutePaths bool, opts ...Option) (*gotio.Timeline, error) {
+	cfg := newConfig(opts)
+
 	// Check if directory exists
 	info, err := os.Stat(path)
 	if err != nil {
@@ -32,9 +40,13 @@ func ReadOTIOD(path string, absolutePaths bool) (*gotio.Timeline, error) {
 		}
 	}
 
+	if _, err := checkBundleInfo(os.DirFS(path), path, cfg); err != nil {
+		return nil, err
+	}
+
 	// Read content.otio
 	contentPath := filepath.Join(path, "content.otio")
-	data, err := os.ReadFile(contentPath)
+	f, err := os.Open(contentPath)
 	if err != nil {
 		return nil, &BundleError{
 			Operation: "read",
@@ -43,9 +55,10 @@ func ReadOTIOD(path string, absolutePaths bool) (*gotio.Timeline, error) {
 			Cause:     err,
 		}
 	}
+	defer f.Close()
 
 	// Parse OTIO
-	obj, err := gotio.FromJSONBytes(data)
+	obj, err := gotio.FromJSONReader(f, cfg.DecodeOptions...)
 	if err != nil {
 		return nil, &BundleError{
 			Operation: "read",
@@ -66,44 +79,112 @@ func ReadOTIOD(path string, absolutePaths bool) (*gotio.Timeline, error) {
 
 	// Convert to absolute paths if requested
 	if absolutePaths {
-		ConvertToAbsolutePaths(timeline, path)
+		root := path
+		if cfg.MediaRoot != "" {
+			root = cfg.MediaRoot
+		}
+		ConvertToAbsolutePaths(timeline, root)
 	}
 
 	return timeline, nil
 }
 
 // WriteOTIOD writes a timeline and its media to a .otiod bundle directory.
+// With WithMediaRoot it writes a lightweight bundle of the timeline alone,
+// referencing media under the root by relative paths; media outside the
+// root is an error under ErrorIfNotFile and otherwise keeps its absolute
+// path.
 func WriteOTIOD(
 	timeline *gotio.Timeline,
 	path string,
 	policy MediaReferencePolicy,
+	opts ...Option,
 ) error {
+	cfg := newConfig(opts)
+	if err := checkTarget(path, cfg); err != nil {
+		return err
+	}
+
 	// Prepare timeline and manifest
-	prepared, manifest, err := PrepareForBundle(timeline, policy)
+	prepared, manifest, err := PrepareForBundle(timeline, policy, WithResolver(cfg.Resolver), WithLogger(cfg.Logger))
 	if err != nil {
 		return err
 	}
 
-	// Verify unique basenames
-	if err := VerifyUniqueBasenames(manifest); err != nil {
+	var files MediaFiles
+	if cfg.MediaRoot != "" {
+		// Reference the media where it is
+		if err := relinkToMediaRoot(manifest, policy, cfg); err != nil {
+			return err
+		}
+	} else {
+		// Copy media reached through different paths once
+		if files, err = dedupeMedia(manifest, cfg); err != nil {
+			return err
+		}
+
+		// Verify unique basenames
+		if err := VerifyUniqueBasenames(manifest); err != nil {
+			return err
+		}
+
+		// Relink to bundle paths
+		RelinkToBundle(manifest)
+	}
+
+	// Create the bundle directory as a temporary sibling of the target
+	parent := filepath.Dir(path)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return &BundleError{
+			Operation: "write",
+			Path:      path,
+			Message:   "failed to create bundle directory",
+			Cause:     err,
+		}
+	}
+	stage, err := os.MkdirTemp(parent, stagePattern(path))
+	if err != nil {
+		return &BundleError{
+			Operation: "write",
+			Path:      path,
+			Message:   "failed to create bundle directory",
+			Cause:     err,
+		}
+	}
+
+	if err := writeOTIODContents(stage, path, prepared, manifest, files, cfg); err != nil {
+		os.RemoveAll(stage)
+		return err
+	}
+	if err := commitDir(stage, path, !cfg.FailIfExists); err != nil {
+		os.RemoveAll(stage)
 		return err
 	}
 
-	// Relink to bundle paths
-	RelinkToBundle(manifest)
+	cfg.Logger.Info("bundle written", "path", path, "media", len(manifest))
+	return nil
+}
 
-	// Create bundle directory
-	if err := os.MkdirAll(path, 0755); err != nil {
+// writeOTIODContents writes the content and media of a bundle into dir,
+// logging media under its final path. files tells which media of the
+// bundle at path can be reused.
+func writeOTIODContents(dir, path string, prepared *gotio.Timeline, manifest MediaManifest, files MediaFiles, cfg Config) error {
+	if err := os.Chmod(dir, 0755); err != nil {
 		return &BundleError{
 			Operation: "write",
-			Path:      path,
+			Path:      dir,
 			Message:   "failed to create bundle directory",
 			Cause:     err,
 		}
 	}
 
+	// A lightweight bundle holds no media
+	if cfg.MediaRoot != "" {
+		manifest = nil
+	}
+
 	// Create media directory
-	mediaDir := filepath.Join(path, "media")
+	mediaDir := filepath.Join(dir, "media")
 	if err := os.MkdirAll(mediaDir, 0755); err != nil {
 		return &BundleError{
 			Operation: "write",
@@ -113,6 +194,29 @@ func WriteOTIOD(
 		}
 	}
 
+	// Write version.txt and manifest.json
+	manifestData, err := newBundleInfo(cfg).marshal()
+	if err != nil {
+		return err
+	}
+	infoFiles := []struct {
+		name string
+		data []byte
+	}{
+		{"version.txt", []byte(BundleVersion)},
+		{"manifest.json", manifestData},
+	}
+	for _, file := range infoFiles {
+		if err := os.WriteFile(filepath.Join(dir, file.name), file.data, 0644); err != nil {
+			return &BundleError{
+				Operation: "write",
+				Path:      filepath.Join(dir, file.name),
+				Message:   "failed to write " + file.name,
+				Cause:     err,
+			}
+		}
+	}
+
 	// Write content.otio
 	contentData, err := gotio.ToJSONBytesIndent(prepared, "    ")
 	if err != nil {
@@ -124,7 +228,7 @@ func WriteOTIOD(
 		}
 	}
 
-	contentPath := filepath.Join(path, "content.otio")
+	contentPath := filepath.Join(dir, "content.otio")
 	if err := os.WriteFile(contentPath, contentData, 0644); err != nil {
 		return &BundleError{
 			Operation: "write",
@@ -135,11 +239,19 @@ func WriteOTIOD(
 	}
 
 	// Copy media files
-	for sourcePath := range manifest {
+	return copyMedia(slices.Sorted(maps.Keys(manifest)), cfg.Concurrency, func(sourcePath string) error {
 		basename := filepath.Base(sourcePath)
 		destPath := filepath.Join(mediaDir, basename)
+		file := files[sourcePath]
 
-		if err := copyFile(sourcePath, destPath); err != nil {
+		// Reuse identical media of the bundle being replaced
+		if !cfg.FailIfExists && reuseMedia(filepath.Join(path, "media", basename), destPath, file) {
+			cfg.Logger.Info("media reused", "source", sourcePath, "destination", filepath.Join(path, "media", basename), "bytes", file.Size)
+			return nil
+		}
+
+		n, err := copyFileN(sourcePath, destPath)
+		if err != nil {
 			return &BundleError{
 				Operation: "write",
 				Path:      sourcePath,
@@ -147,22 +259,36 @@ func WriteOTIOD(
 				Cause:     err,
 			}
 		}
-	}
-
-	return nil
+		// Keep the source time so a later rewrite can reuse the copy; one
+		// whose time cannot be set is copied again.
+		if file != nil && !file.ModTime.IsZero() {
+			os.Chtimes(destPath, time.Time{}, file.ModTime)
+		}
+		metrics.Add(metrics.BundleBytesCopied, float64(n), metrics.Format("otiod"))
+		cfg.Logger.Info("media copied", "source", sourcePath, "destination", filepath.Join(path, "media", basename), "bytes", n)
+		return nil
+	})
 }
 
 // WriteOTIODDryRun calculates the total size of a .otiod bundle without writing.
 func WriteOTIODDryRun(
 	timeline *gotio.Timeline,
 	policy MediaReferencePolicy,
+	opts ...Option,
 ) (int64, error) {
+	cfg := newConfig(opts)
+
 	// Prepare timeline and manifest
-	prepared, manifest, err := PrepareForBundle(timeline, policy)
+	prepared, manifest, err := PrepareForBundle(timeline, policy, WithResolver(cfg.Resolver), WithLogger(cfg.Logger))
 	if err != nil {
 		return 0, err
 	}
 
+	// Copy media reached through different paths once
+	if _, err := dedupeMedia(manifest, cfg); err != nil {
+		return 0, err
+	}
+
 	// Verify unique basenames
 	if err := VerifyUniqueBasenames(manifest); err != nil {
 		return 0, err
@@ -177,16 +303,50 @@ func WriteOTIODDryRun(
 	}
 	total += int64(len(contentData))
 
-	// Size of media files
-	mediaSize, err := TotalMediaSize(manifest)
+	// Size of version.txt and manifest.json
+	manifestData, err := newBundleInfo(cfg).marshal()
 	if err != nil {
 		return 0, err
 	}
-	total += mediaSize
+	total += int64(len(BundleVersion) + len(manifestData))
+
+	// Size of media files
+	if cfg.MediaRoot == "" {
+		mediaSize, err := TotalMediaSize(manifest, WithResolver(cfg.Resolver))
+		if err != nil {
+			return 0, err
+		}
+		total += mediaSize
+	}
 
 	return total, nil
 }
 
+// relinkToMediaRoot points the references in the manifest at their media
+// relative to the configured media root.
+func relinkToMediaRoot(manifest MediaManifest, policy MediaReferencePolicy, cfg Config) error {
+	outside, err := relinkRelative(manifest, cfg.MediaRoot)
+	if err != nil {
+		return &BundleError{
+			Operation: "prepare",
+			Path:      cfg.MediaRoot,
+			Message:   "invalid media root",
+			Cause:     err,
+		}
+	}
+	for _, path := range outside {
+		if policy == ErrorIfNotFile {
+			return &BundleError{
+				Operation: "prepare",
+				Path:      path,
+				Message:   "media file outside media root " + cfg.MediaRoot,
+			}
+		}
+		cfg.Logger.Warn("media outside media root kept absolute", "path", path, "root", cfg.MediaRoot)
+	}
+	return nil
+}
+
 // IsOTIOD checks if a path is a valid .otiod bundle directory.
 func IsOTIOD(path string) bool {
 	info, err := os.Stat(path)
@@ -210,18 +370,23 @@ func IsOTIOD(path string) bool {
 
 // copyFile copies a file from src to dst.
 func copyFile(src, dst string) error {
+	_, err := copyFileN(src, dst)
+	return err
+}
+
+// copyFileN copies src to dst and returns the number of bytes copied.
+func copyFileN(src, dst string) (int64, error) {
 	srcFile, err := os.Open(src)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer srcFile.Close()
 
 	dstFile, err := os.Create(dst)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer dstFile.Close()
 
-	_, err = io.Copy(dstFile, srcFile)
-	return err
+	return io.Copy(dstFile, srcFile)
 }
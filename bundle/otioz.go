@@ -5,17 +5,23 @@ package bundle
 
 import (
 	"archive/zip"
-	"io"
+	"context"
+	"errors"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/metrics"
 )
 
 // ReadOTIOZ reads a .otioz bundle and returns the timeline.
 // This only reads the content.otio file; media files are not extracted.
-func ReadOTIOZ(path string) (*gotio.Timeline, error) {
+func ReadOTIOZ(path string, opts ...Option) (*gotio.Timeline, error) {
+	cfg := newConfig(opts)
+
 	r, err := zip.OpenReader(path)
 	if err != nil {
 		return nil, &BundleError{
@@ -27,71 +33,19 @@ func ReadOTIOZ(path string) (*gotio.Timeline, error) {
 	}
 	defer r.Close()
 
-	// Find content.otio
-	var contentFile *zip.File
-	for _, f := range r.File {
-		if f.Name == "content.otio" {
-			contentFile = f
-			break
-		}
-	}
-
-	if contentFile == nil {
-		return nil, &BundleError{
-			Operation: "read",
-			Path:      path,
-			Message:   "missing content.otio",
-		}
-	}
-
-	// Read content.otio
-	rc, err := contentFile.Open()
-	if err != nil {
-		return nil, &BundleError{
-			Operation: "read",
-			Path:      path,
-			Message:   "failed to open content.otio",
-			Cause:     err,
-		}
-	}
-	defer rc.Close()
-
-	data, err := io.ReadAll(rc)
-	if err != nil {
-		return nil, &BundleError{
-			Operation: "read",
-			Path:      path,
-			Message:   "failed to read content.otio",
-			Cause:     err,
-		}
-	}
-
-	// Parse OTIO
-	obj, err := gotio.FromJSONBytes(data)
-	if err != nil {
-		return nil, &BundleError{
-			Operation: "read",
-			Path:      path,
-			Message:   "failed to parse content.otio",
-			Cause:     err,
-		}
-	}
-
-	timeline, ok := obj.(*gotio.Timeline)
-	if !ok {
-		return nil, &BundleError{
-			Operation: "read",
-			Path:      path,
-			Message:   "content.otio does not contain a Timeline",
-		}
+	if _, err := checkBundleInfo(r, path, cfg); err != nil {
+		return nil, err
 	}
-
-	return timeline, nil
+	return readContent(&r.Reader, path, cfg)
 }
 
 // ReadOTIOZWithExtraction reads a .otioz bundle and extracts all contents to a directory.
 // Returns the timeline with media references pointing to extracted files.
-func ReadOTIOZWithExtraction(bundlePath, extractDir string) (*gotio.Timeline, error) {
+// Entries that would be written outside extractDir are rejected; see
+// ExtractionPolicy for symlinks and size limits.
+func ReadOTIOZWithExtraction(bundlePath, extractDir string, opts ...Option) (*gotio.Timeline, error) {
+	cfg := newConfig(opts)
+
 	r, err := zip.OpenReader(bundlePath)
 	if err != nil {
 		return nil, &BundleError{
@@ -103,6 +57,10 @@ func ReadOTIOZWithExtraction(bundlePath, extractDir string) (*gotio.Timeline, er
 	}
 	defer r.Close()
 
+	if _, err := checkBundleInfo(r, bundlePath, cfg); err != nil {
+		return nil, err
+	}
+
 	// Create extraction directory
 	if err := os.MkdirAll(extractDir, 0755); err != nil {
 		return nil, &BundleError{
@@ -116,35 +74,43 @@ func ReadOTIOZWithExtraction(bundlePath, extractDir string) (*gotio.Timeline, er
 	var timeline *gotio.Timeline
 
 	// Extract all files
+	root, err := os.OpenRoot(extractDir)
+	if err != nil {
+		return nil, &BundleError{
+			Operation: "extract",
+			Path:      extractDir,
+			Message:   "failed to open extraction directory",
+			Cause:     err,
+		}
+	}
+	defer root.Close()
+	x := &extractor{root: root, policy: cfg.Extraction, progress: cfg.Progress}
 	for _, f := range r.File {
-		destPath := filepath.Join(extractDir, f.Name)
-
-		// Create directories
-		if f.FileInfo().IsDir() {
-			if err := os.MkdirAll(destPath, 0755); err != nil {
-				return nil, err
+		extracted, err := x.extract(f)
+		if err != nil {
+			return nil, &BundleError{
+				Operation: "extract",
+				Path:      bundlePath,
+				Message:   "failed to extract " + f.Name,
+				Cause:     err,
 			}
-			continue
 		}
-
-		// Ensure parent directory exists
-		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
-			return nil, err
+		if !extracted {
+			cfg.Logger.Warn("bundle entry skipped", "bundle", bundlePath, "entry", f.Name)
+			continue
 		}
-
-		// Extract file
-		if err := extractZipFile(f, destPath); err != nil {
-			return nil, err
+		if f.FileInfo().IsDir() {
+			continue
 		}
 
 		// Parse content.otio
 		if f.Name == "content.otio" {
-			data, err := os.ReadFile(destPath)
+			content, err := root.Open(filepath.FromSlash(f.Name))
 			if err != nil {
 				return nil, err
 			}
-
-			obj, err := gotio.FromJSONBytes(data)
+			obj, err := gotio.FromJSONReader(content, cfg.DecodeOptions...)
+			content.Close()
 			if err != nil {
 				return nil, err
 			}
@@ -180,13 +146,24 @@ func WriteOTIOZ(
 	timeline *gotio.Timeline,
 	path string,
 	policy MediaReferencePolicy,
+	opts ...Option,
 ) error {
+	cfg := newConfig(opts)
+	if err := checkTarget(path, cfg); err != nil {
+		return err
+	}
+
 	// Prepare timeline and manifest
-	prepared, manifest, err := PrepareForBundle(timeline, policy)
+	prepared, manifest, err := PrepareForBundle(timeline, policy, WithResolver(cfg.Resolver), WithLogger(cfg.Logger))
 	if err != nil {
 		return err
 	}
 
+	// Copy media reached through different paths once
+	if _, err := dedupeMedia(manifest, cfg); err != nil {
+		return err
+	}
+
 	// Verify unique basenames
 	if err := VerifyUniqueBasenames(manifest); err != nil {
 		return err
@@ -195,8 +172,8 @@ func WriteOTIOZ(
 	// Relink to bundle paths
 	RelinkToBundle(manifest)
 
-	// Create output file
-	f, err := os.Create(path)
+	// Create the output file as a temporary sibling of the target
+	f, err := os.CreateTemp(filepath.Dir(path), stagePattern(path))
 	if err != nil {
 		return &BundleError{
 			Operation: "write",
@@ -205,10 +182,41 @@ func WriteOTIOZ(
 			Cause:     err,
 		}
 	}
-	defer f.Close()
+	stage := f.Name()
+
+	err = writeOTIOZContents(f, path, prepared, manifest, cfg)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = &BundleError{
+			Operation: "write",
+			Path:      path,
+			Message:   "failed to write file",
+			Cause:     closeErr,
+		}
+	}
+	if err == nil {
+		err = commitFile(stage, path, !cfg.FailIfExists)
+	}
+	if err != nil {
+		os.Remove(stage)
+		return err
+	}
+
+	cfg.Logger.Info("bundle written", "path", path, "media", len(manifest))
+	return nil
+}
+
+// writeOTIOZContents writes the zip archive of a bundle to f.
+func writeOTIOZContents(f *os.File, path string, prepared *gotio.Timeline, manifest MediaManifest, cfg Config) error {
+	if err := f.Chmod(0644); err != nil {
+		return &BundleError{
+			Operation: "write",
+			Path:      path,
+			Message:   "failed to create file",
+			Cause:     err,
+		}
+	}
 
 	w := zip.NewWriter(f)
-	defer w.Close()
 
 	// Write version.txt (deflated)
 	versionWriter, err := w.Create("version.txt")
@@ -219,6 +227,19 @@ func WriteOTIOZ(
 		return err
 	}
 
+	// Write manifest.json (deflated)
+	manifestData, err := newBundleInfo(cfg).marshal()
+	if err != nil {
+		return err
+	}
+	manifestWriter, err := w.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	if _, err := manifestWriter.Write(manifestData); err != nil {
+		return err
+	}
+
 	// Write content.otio (deflated)
 	contentData, err := gotio.ToJSONBytesIndent(prepared, "    ")
 	if err != nil {
@@ -238,8 +259,32 @@ func WriteOTIOZ(
 		return err
 	}
 
-	// Write media files (stored, no compression)
-	for sourcePath := range manifest {
+	// Write media files (stored, no compression) in source order, read
+	// ahead in parallel
+	if err := writeOTIOZMedia(w, slices.Sorted(maps.Keys(manifest)), cfg); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return &BundleError{
+			Operation: "write",
+			Path:      path,
+			Message:   "failed to write file",
+			Cause:     err,
+		}
+	}
+	return nil
+}
+
+// writeOTIOZMedia writes the media files to w, one member each, in the
+// order of sources. After a file fails no more are written; the errors of
+// all files that failed to be read are returned joined.
+func writeOTIOZMedia(w *zip.Writer, sources []string, cfg Config) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	files := prefetchMedia(ctx, sources, cfg.Concurrency)
+
+	for i, sourcePath := range sources {
 		basename := filepath.Base(sourcePath)
 		bundlePath := "media/" + basename
 		// Use forward slashes
@@ -253,46 +298,62 @@ func WriteOTIOZ(
 
 		mediaWriter, err := w.CreateHeader(header)
 		if err != nil {
-			return err
+			cancel()
+			return errors.Join(err, drainMedia(sources[i:], files[i:]))
 		}
 
-		// Copy media file
-		mediaFile, err := os.Open(sourcePath)
+		n, err := files[i].writeTo(mediaWriter)
 		if err != nil {
-			return &BundleError{
+			cancel()
+			return errors.Join(&BundleError{
 				Operation: "write",
 				Path:      sourcePath,
-				Message:   "failed to open media file",
+				Message:   "failed to copy media file",
 				Cause:     err,
-			}
+			}, drainMedia(sources[i+1:], files[i+1:]))
 		}
+		metrics.Add(metrics.BundleBytesCopied, float64(n), metrics.Format("otioz"))
+		cfg.Logger.Info("media copied", "source", sourcePath, "member", bundlePath, "bytes", n)
+	}
+	return nil
+}
 
-		_, copyErr := io.Copy(mediaWriter, mediaFile)
-		mediaFile.Close()
-		if copyErr != nil {
-			return &BundleError{
+// drainMedia waits for the reads of files to stop and returns their
+// errors.
+func drainMedia(sources []string, files []*prefetched) error {
+	var errs []error
+	for i, file := range files {
+		if err := file.drain(); err != nil {
+			errs = append(errs, &BundleError{
 				Operation: "write",
-				Path:      sourcePath,
+				Path:      sources[i],
 				Message:   "failed to copy media file",
-				Cause:     copyErr,
-			}
+				Cause:     err,
+			})
 		}
 	}
-
-	return nil
+	return errors.Join(errs...)
 }
 
 // WriteOTIOZDryRun calculates the total size of a .otioz bundle without writing.
 func WriteOTIOZDryRun(
 	timeline *gotio.Timeline,
 	policy MediaReferencePolicy,
+	opts ...Option,
 ) (int64, error) {
+	cfg := newConfig(opts)
+
 	// Prepare timeline and manifest
-	prepared, manifest, err := PrepareForBundle(timeline, policy)
+	prepared, manifest, err := PrepareForBundle(timeline, policy, WithResolver(cfg.Resolver), WithLogger(cfg.Logger))
 	if err != nil {
 		return 0, err
 	}
 
+	// Copy media reached through different paths once
+	if _, err := dedupeMedia(manifest, cfg); err != nil {
+		return 0, err
+	}
+
 	// Verify unique basenames
 	if err := VerifyUniqueBasenames(manifest); err != nil {
 		return 0, err
@@ -307,11 +368,16 @@ func WriteOTIOZDryRun(
 	}
 	total += int64(len(contentData))
 
-	// Size of version.txt
+	// Size of version.txt and manifest.json
 	total += int64(len(BundleVersion))
+	manifestData, err := newBundleInfo(cfg).marshal()
+	if err != nil {
+		return 0, err
+	}
+	total += int64(len(manifestData))
 
 	// Size of media files
-	mediaSize, err := TotalMediaSize(manifest)
+	mediaSize, err := TotalMediaSize(manifest, WithResolver(cfg.Resolver))
 	if err != nil {
 		return 0, err
 	}
@@ -323,24 +389,6 @@ func WriteOTIOZDryRun(
 	return total, nil
 }
 
-// extractZipFile extracts a single file from a zip archive.
-func extractZipFile(f *zip.File, destPath string) error {
-	rc, err := f.Open()
-	if err != nil {
-		return err
-	}
-	defer rc.Close()
-
-	dest, err := os.Create(destPath)
-	if err != nil {
-		return err
-	}
-	defer dest.Close()
-
-	_, err = io.Copy(dest, rc)
-	return err
-}
-
 // IsOTIOZ checks if a path is a valid .otioz bundle file.
 func IsOTIOZ(path string) bool {
 	info, err := os.Stat(path)
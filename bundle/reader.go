@@ -0,0 +1,249 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package bundle
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+// ProgressFunc is called as a bundle member is read, with the member name,
+// the bytes read so far and the member's uncompressed size.
+type ProgressFunc func(name string, read, total int64)
+
+// WithProgress sets a function called as media members are streamed from an
+// OTIOZReader and as files are written by ReadOTIOZWithExtraction.
+func WithProgress(fn ProgressFunc) Option {
+	return func(c *Config) {
+		c.Progress = fn
+	}
+}
+
+// MediaMember describes a media file stored in a .otioz bundle.
+type MediaMember struct {
+	// Name is the path of the member in the bundle, such as "media/a.mov",
+	// matching the target URLs of the bundled timeline.
+	Name string
+	// Size is the uncompressed size in bytes.
+	Size int64
+	// Stored reports whether the member is uncompressed, so that
+	// OpenMediaAt can seek to an offset without reading what comes before.
+	Stored bool
+}
+
+// OTIOZReader is an open .otioz bundle. The timeline is decoded when the
+// bundle is opened; media members are read only when opened.
+type OTIOZReader struct {
+	r        io.ReaderAt
+	info     BundleInfo
+	timeline *gotio.Timeline
+	media    []MediaMember
+	files    map[string]*zip.File
+	progress ProgressFunc
+}
+
+// ReadOTIOZFrom reads the timeline of a .otioz bundle of the given size
+// from r, reading only the zip directory and content.otio. Any
+// io.ReaderAt works, such as one issuing range requests to object storage.
+func ReadOTIOZFrom(r io.ReaderAt, size int64, opts ...Option) (*gotio.Timeline, error) {
+	br, err := OpenOTIOZ(r, size, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return br.Timeline(), nil
+}
+
+// OpenOTIOZ opens a .otioz bundle of the given size from r and decodes its
+// timeline. Media members can then be streamed one at a time. r must stay
+// readable while the OTIOZReader is in use.
+func OpenOTIOZ(r io.ReaderAt, size int64, opts ...Option) (*OTIOZReader, error) {
+	cfg := newConfig(opts)
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, &BundleError{
+			Operation: "read",
+			Message:   "failed to open zip",
+			Cause:     err,
+		}
+	}
+
+	info, err := checkBundleInfo(zr, "", cfg)
+	if err != nil {
+		return nil, err
+	}
+	timeline, err := readContent(zr, "", cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	br := &OTIOZReader{
+		r:        r,
+		info:     info,
+		timeline: timeline,
+		files:    make(map[string]*zip.File),
+		progress: cfg.Progress,
+	}
+	for _, f := range zr.File {
+		if !strings.HasPrefix(f.Name, "media/") || !f.Mode().IsRegular() || !fs.ValidPath(f.Name) {
+			continue
+		}
+		br.files[f.Name] = f
+		br.media = append(br.media, MediaMember{
+			Name:   f.Name,
+			Size:   int64(f.UncompressedSize64),
+			Stored: f.Method == zip.Store,
+		})
+	}
+	return br, nil
+}
+
+// Info returns the version and manifest of the bundle.
+func (br *OTIOZReader) Info() BundleInfo {
+	return br.info
+}
+
+// Timeline returns the bundle's timeline. Its media references are relative
+// to the bundle, as written.
+func (br *OTIOZReader) Timeline() *gotio.Timeline {
+	return br.timeline
+}
+
+// Media returns the media members of the bundle in archive order.
+func (br *OTIOZReader) Media() []MediaMember {
+	return append([]MediaMember(nil), br.media...)
+}
+
+// OpenMedia opens a media member for reading. The name is as returned by
+// Media; a target URL such as "./media/a.mov" is accepted too.
+func (br *OTIOZReader) OpenMedia(name string) (io.ReadCloser, error) {
+	return br.OpenMediaAt(name, 0)
+}
+
+// OpenMediaAt opens a media member for reading from offset, to resume an
+// interrupted transfer. Stored members are read from the offset directly;
+// compressed members are decompressed and the leading bytes discarded.
+func (br *OTIOZReader) OpenMediaAt(name string, offset int64) (io.ReadCloser, error) {
+	name = path.Clean(strings.TrimPrefix(name, "./"))
+	f, ok := br.files[name]
+	if !ok {
+		return nil, &BundleError{
+			Operation: "read",
+			Path:      name,
+			Message:   "no such media member",
+			Cause:     fs.ErrNotExist,
+		}
+	}
+	size := int64(f.UncompressedSize64)
+	if offset < 0 || offset > size {
+		return nil, &BundleError{
+			Operation: "read",
+			Path:      name,
+			Message:   fmt.Sprintf("offset %d outside member of %d bytes", offset, size),
+		}
+	}
+
+	var rc io.ReadCloser
+	if f.Method == zip.Store {
+		dataOffset, err := f.DataOffset()
+		if err != nil {
+			return nil, &BundleError{Operation: "read", Path: name, Message: "failed to locate member", Cause: err}
+		}
+		rc = io.NopCloser(io.NewSectionReader(br.r, dataOffset+offset, size-offset))
+	} else {
+		zrc, err := f.Open()
+		if err != nil {
+			return nil, &BundleError{Operation: "read", Path: name, Message: "failed to open member", Cause: err}
+		}
+		if _, err := io.CopyN(io.Discard, zrc, offset); err != nil {
+			zrc.Close()
+			return nil, &BundleError{Operation: "read", Path: name, Message: "failed to skip to offset", Cause: err}
+		}
+		rc = zrc
+	}
+
+	if br.progress == nil {
+		return rc, nil
+	}
+	return &progressReader{rc: rc, name: name, read: offset, total: size, fn: br.progress}, nil
+}
+
+// progressReader reports the bytes read through it.
+type progressReader struct {
+	rc    io.ReadCloser
+	name  string
+	read  int64
+	total int64
+	fn    ProgressFunc
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.rc.Read(b)
+	if n > 0 {
+		p.read += int64(n)
+		p.fn(p.name, p.read, p.total)
+	}
+	return n, err
+}
+
+func (p *progressReader) Close() error {
+	return p.rc.Close()
+}
+
+// readContent decodes the content.otio of an opened bundle. bundlePath is
+// used in errors and may be empty.
+func readContent(zr *zip.Reader, bundlePath string, cfg Config) (*gotio.Timeline, error) {
+	var contentFile *zip.File
+	for _, f := range zr.File {
+		if f.Name == "content.otio" {
+			contentFile = f
+			break
+		}
+	}
+
+	if contentFile == nil {
+		return nil, &BundleError{
+			Operation: "read",
+			Path:      bundlePath,
+			Message:   "missing content.otio",
+		}
+	}
+
+	rc, err := contentFile.Open()
+	if err != nil {
+		return nil, &BundleError{
+			Operation: "read",
+			Path:      bundlePath,
+			Message:   "failed to open content.otio",
+			Cause:     err,
+		}
+	}
+	defer rc.Close()
+
+	obj, err := gotio.FromJSONReader(rc, cfg.DecodeOptions...)
+	if err != nil {
+		return nil, &BundleError{
+			Operation: "read",
+			Path:      bundlePath,
+			Message:   "failed to parse content.otio",
+			Cause:     err,
+		}
+	}
+
+	timeline, ok := obj.(*gotio.Timeline)
+	if !ok {
+		return nil, &BundleError{
+			Operation: "read",
+			Path:      bundlePath,
+			Message:   "content.otio does not contain a Timeline",
+		}
+	}
+	return timeline, nil
+}
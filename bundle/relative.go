@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package bundle
+
+import (
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+// WithMediaRoot makes WriteOTIOD write a lightweight bundle: media is left
+// where it is, under root, and referenced by paths relative to root rather
+// than copied into the bundle. ReadOTIOD with the same option resolves the
+// references against root, which may be mounted elsewhere on the reading
+// machine.
+func WithMediaRoot(root string) Option {
+	return func(c *Config) {
+		c.MediaRoot = root
+	}
+}
+
+// ConvertToRelativePaths is the inverse of ConvertToAbsolutePaths. It
+// rewrites the external references to absolute paths and file URLs under
+// root as slash-separated paths relative to root. References outside root
+// and to other URLs are left unchanged.
+func ConvertToRelativePaths(timeline *gotio.Timeline, root string) error {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	for _, clip := range timeline.FindClips(nil, false) {
+		extRef, ok := clip.MediaReference().(*gotio.ExternalReference)
+		if !ok {
+			continue
+		}
+		if rel, ok := relativeTo(absRoot, extRef.TargetURL()); ok {
+			extRef.SetTargetURL(rel)
+		}
+	}
+	return nil
+}
+
+// relinkRelative points the references in the manifest at their media by
+// paths relative to root, and returns the source paths outside root, which
+// are left unchanged.
+func relinkRelative(manifest MediaManifest, root string) ([]string, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	var outside []string
+	for absPath, refs := range manifest {
+		rel, ok := relativeTo(absRoot, absPath)
+		if !ok {
+			outside = append(outside, absPath)
+			continue
+		}
+		for _, ref := range refs {
+			ref.SetTargetURL(rel)
+		}
+	}
+	return outside, nil
+}
+
+// relativeTo returns the absolute path or file URL target relative to
+// absRoot, and false if target is neither or is outside absRoot.
+func relativeTo(absRoot, target string) (string, bool) {
+	if target == "" {
+		return "", false
+	}
+	localPath := target
+	if u, err := url.Parse(target); err == nil && u.Scheme == "file" {
+		localPath, err = urlToAbsPath(target)
+		if err != nil {
+			return "", false
+		}
+	}
+	if !filepath.IsAbs(localPath) {
+		return "", false
+	}
+	rel, err := filepath.Rel(absRoot, localPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// isRelativePath reports whether target is a relative path rather than an
+// absolute path or a URL.
+func isRelativePath(target string) bool {
+	if target == "" || path.IsAbs(target) || filepath.IsAbs(target) {
+		return false
+	}
+	u, err := url.Parse(target)
+	return err != nil || u.Scheme == ""
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package bundle
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+func targetURLs(timeline *gotio.Timeline) []string {
+	var urls []string
+	for _, clip := range timeline.FindClips(nil, false) {
+		if ref, ok := clip.MediaReference().(*gotio.ExternalReference); ok {
+			urls = append(urls, ref.TargetURL())
+		}
+	}
+	return urls
+}
+
+func TestWriteOTIODMediaRoot(t *testing.T) {
+	root := t.TempDir()
+	shot := filepath.Join(root, "seq", "shot.mov")
+	if err := os.MkdirAll(filepath.Dir(shot), 0755); err != nil {
+		t.Fatalf("MkdirAll error: %v", err)
+	}
+	if err := os.WriteFile(shot, []byte("media"), 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	outside := filepath.Join(t.TempDir(), "outside.mov")
+	if err := os.WriteFile(outside, []byte("media"), 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "light.otiod")
+	err := WriteOTIOD(mediaTimeline("light", shot, outside), path, ErrorIfNotFile, WithMediaRoot(root))
+	var bundleErr *BundleError
+	if !errors.As(err, &bundleErr) || bundleErr.Path != outside {
+		t.Fatalf("expected an error for media outside the root, got %v", err)
+	}
+
+	if err := WriteOTIOD(mediaTimeline("light", shot, outside), path, MissingIfNotFile, WithMediaRoot(root)); err != nil {
+		t.Fatalf("WriteOTIOD error: %v", err)
+	}
+	assertOnly(t, filepath.Join(path, "media"))
+
+	timeline, err := ReadOTIOD(path, false)
+	if err != nil {
+		t.Fatalf("ReadOTIOD error: %v", err)
+	}
+	if urls := targetURLs(timeline); len(urls) != 2 || urls[0] != "seq/shot.mov" || urls[1] != outside {
+		t.Errorf("target URLs = %v, want seq/shot.mov and %s", urls, outside)
+	}
+
+	// The reader resolves against its own mount of the root.
+	mount := t.TempDir()
+	timeline, err = ReadOTIOD(path, true, WithMediaRoot(mount))
+	if err != nil {
+		t.Fatalf("ReadOTIOD error: %v", err)
+	}
+	if urls := targetURLs(timeline); urls[0] != filepath.Join(mount, "seq", "shot.mov") || urls[1] != outside {
+		t.Errorf("absolute target URLs = %v", urls)
+	}
+
+	size, err := WriteOTIODDryRun(mediaTimeline("light", shot), MissingIfNotFile, WithMediaRoot(root))
+	if err != nil {
+		t.Fatalf("WriteOTIODDryRun error: %v", err)
+	}
+	full, err := WriteOTIODDryRun(mediaTimeline("light", shot), MissingIfNotFile)
+	if err != nil {
+		t.Fatalf("WriteOTIODDryRun error: %v", err)
+	}
+	if full-size != int64(len("media")) {
+		t.Errorf("dry run sizes %d and %d should differ by the media", size, full)
+	}
+}
+
+func TestConvertToRelativePaths(t *testing.T) {
+	root := t.TempDir()
+	inside := filepath.Join(root, "a", "shot.mov")
+	outside := filepath.Join(t.TempDir(), "shot.mov")
+	timeline := mediaTimeline("rel", inside, "file://"+filepath.ToSlash(inside), outside, "https://example.com/shot.mov")
+
+	if err := ConvertToRelativePaths(timeline, root); err != nil {
+		t.Fatalf("ConvertToRelativePaths error: %v", err)
+	}
+	want := []string{"a/shot.mov", "a/shot.mov", outside, "https://example.com/shot.mov"}
+	urls := targetURLs(timeline)
+	for i := range want {
+		if urls[i] != want[i] {
+			t.Errorf("relative target URL %d = %q, want %q", i, urls[i], want[i])
+		}
+	}
+
+	if err := ConvertToAbsolutePaths(timeline, root); err != nil {
+		t.Fatalf("ConvertToAbsolutePaths error: %v", err)
+	}
+	want = []string{inside, inside, outside, "https://example.com/shot.mov"}
+	urls = targetURLs(timeline)
+	for i := range want {
+		if urls[i] != want[i] {
+			t.Errorf("absolute target URL %d = %q, want %q", i, urls[i], want[i])
+		}
+	}
+}
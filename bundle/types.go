@@ -4,12 +4,59 @@
 // Package bundle provides support for OTIO file bundles (.otioz and .otiod).
 // These formats package timelines with their associated media files for
 // distribution, archiving, and interchange.
+//
+// Bundles from untrusted sources should be read with limits: WithDecodeOptions
+// bounds the content.otio, and WithExtractionPolicy controls symlinks and
+// the size of what ReadOTIOZWithExtraction writes. Entries that would be
+// written outside the extraction directory are always rejected.
+//
+// WriteOTIOD and WriteOTIOZ write to a temporary sibling of the target and
+// rename it into place once complete, so a bundle at the target path is
+// never half written, and remove the temporary on error. A bundle already
+// at the target is replaced unless WithFailIfExists is set. They copy
+// several media files at once, DefaultConcurrency unless WithConcurrency
+// says otherwise; .otioz members are still written one after another, in
+// sorted order, with the files read ahead of the zip writer. Media
+// reached through different paths, such as a file URL and a symlink, is
+// merged by MediaManifest.Dedupe and copied once, as is media of the same
+// content when WithChecksums is set.
+//
+// Rewriting a .otiod bundle links the media of the bundle being replaced
+// instead of copying it again where the source file has the same size and
+// modification time, and the same content when WithChecksums is set,
+// which makes iterative deliveries incremental.
+// Media copied into a .otiod keeps the modification time of its source
+// for this comparison.
+//
+// WriteOTIOD with WithMediaRoot writes a lightweight bundle that leaves the
+// media in place and references it by paths relative to a root, such as a
+// facility's shared storage, so the manifest stays portable between
+// machines that mount the root in different places. ConvertToRelativePaths
+// and ConvertToAbsolutePaths move any timeline between the two forms.
+//
+// Bundles carry the version.txt of the bundle spec and a manifest.json
+// recording the version, the creator (WithCreator) and the time written,
+// read back with ReadBundleInfo. Readers refuse bundles of another major
+// version with ErrUnsupportedVersion and warn about later minor versions,
+// reading what they understand.
+//
+// ReadOTIOZFrom and OpenOTIOZ read a bundle from any io.ReaderAt, such as
+// one issuing range requests to object storage, fetching only the zip
+// directory and content.otio until media members are opened.
 package bundle
 
 import (
 	"fmt"
+	"log/slog"
+
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/mediaresolver"
 )
 
+func init() {
+	gotio.RegisterFeature("bundle")
+}
+
 // MediaReferencePolicy determines how media references are handled when writing bundles.
 type MediaReferencePolicy int
 
@@ -36,6 +83,85 @@ func (p MediaReferencePolicy) String() string {
 	}
 }
 
+// Config holds configuration for preparing and writing bundles.
+type Config struct {
+	// Resolver checks that media files exist and gets their sizes. If nil,
+	// each operation uses a new resolver, so every file is statted once.
+	Resolver *mediaresolver.Resolver
+	// DecodeOptions limit the content.otio accepted when reading a bundle.
+	// The content is read no further than its MaxBytes limit.
+	DecodeOptions []gotio.DecodeOption
+	// Extraction controls what ReadOTIOZWithExtraction writes to disk.
+	Extraction ExtractionPolicy
+	// Progress, if set, is called as media is read from an OTIOZReader and
+	// as files are extracted.
+	Progress ProgressFunc
+	// Logger, if set, receives an event for each media file copied into a
+	// bundle, each reference replaced by a MissingReference and each entry
+	// skipped on extraction.
+	Logger *slog.Logger
+	// MediaRoot, if set, makes WriteOTIOD reference media by paths relative
+	// to it instead of copying it into the bundle, and ReadOTIOD resolve
+	// relative references against it.
+	MediaRoot string
+	// FailIfExists makes writing to a path that exists fail with an error
+	// matching fs.ErrExist. Without it, a bundle already at the target
+	// path is replaced.
+	FailIfExists bool
+	// Concurrency is the number of media files copied at once.
+	Concurrency int
+	// Checksums makes StatMedia hash the media of a manifest.
+	Checksums bool
+	// Creator is recorded in the manifest.json of bundles written.
+	Creator string
+}
+
+// Option is a functional option for bundle operations.
+type Option func(*Config)
+
+// WithResolver sets the resolver used to look up media files. Share a
+// resolver between operations to reuse its cache.
+func WithResolver(resolver *mediaresolver.Resolver) Option {
+	return func(c *Config) {
+		c.Resolver = resolver
+	}
+}
+
+// WithDecodeOptions sets the limits on the content.otio accepted when
+// reading a bundle.
+func WithDecodeOptions(opts ...gotio.DecodeOption) Option {
+	return func(c *Config) {
+		c.DecodeOptions = append(c.DecodeOptions, opts...)
+	}
+}
+
+// WithLogger sets the logger bundle operations report what they do to.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Config) {
+		c.Logger = logger
+	}
+}
+
+func newConfig(opts []Option) Config {
+	var cfg Config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.Resolver == nil {
+		cfg.Resolver = mediaresolver.New()
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.New(slog.DiscardHandler)
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = DefaultConcurrency
+	}
+	if cfg.Creator == "" {
+		cfg.Creator = DefaultCreator
+	}
+	return cfg
+}
+
 // BundleVersion is the current version of the bundle format.
 const BundleVersion = "1.0.0"
 
@@ -4,23 +4,27 @@
 package bundle
 
 import (
+	"context"
+	"io/fs"
+	"log/slog"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/metrics"
 )
 
-// MediaManifest maps absolute source paths to the external references that point to them.
-type MediaManifest map[string][]*gotio.ExternalReference
-
 // PrepareForBundle processes a timeline for bundling according to the media policy.
 // It returns a cloned timeline with adjusted media references and a manifest of media files to include.
 func PrepareForBundle(
 	timeline *gotio.Timeline,
 	policy MediaReferencePolicy,
+	opts ...Option,
 ) (*gotio.Timeline, MediaManifest, error) {
+	cfg := newConfig(opts)
+
 	// Clone the timeline to avoid modifying the original
 	cloned := timeline.Clone().(*gotio.Timeline)
 	manifest := make(MediaManifest)
@@ -45,6 +49,8 @@ func PrepareForBundle(
 				},
 			)
 			clip.SetMediaReference(replaceMissingRef)
+			cfg.Logger.Debug("reference replaced by missing reference",
+				"clip", clip.Name(), "url", getTargetURL(ref), "reason", "AllMissing policy")
 			continue
 		}
 
@@ -71,13 +77,13 @@ func PrepareForBundle(
 				}
 			}
 			// MissingIfNotFile - replace with missing reference
-			replaceMissing(clip, ref, "invalid URL: "+err.Error())
+			replaceMissing(cfg.Logger, clip, ref, "invalid URL: "+err.Error())
 			continue
 		}
 
 		// Check if file exists
-		info, err := os.Stat(absPath)
-		if err != nil || info.IsDir() {
+		info, err := cfg.Resolver.Resolve(context.Background(), absPath)
+		if err != nil || !info.Exists {
 			if policy == ErrorIfNotFile {
 				return nil, nil, &BundleError{
 					Operation: "prepare",
@@ -86,7 +92,7 @@ func PrepareForBundle(
 					Cause:     err,
 				}
 			}
-			replaceMissing(clip, ref, "file not found")
+			replaceMissing(cfg.Logger, clip, ref, "file not found")
 			continue
 		}
 
@@ -94,6 +100,7 @@ func PrepareForBundle(
 		manifest[absPath] = append(manifest[absPath], extRef)
 	}
 
+	metrics.Add(metrics.ClipsProcessed, float64(len(clips)), metrics.Operation("prepare_for_bundle"))
 	return cloned, manifest, nil
 }
 
@@ -130,7 +137,9 @@ func RelinkToBundle(manifest MediaManifest) {
 	}
 }
 
-// ConvertToAbsolutePaths converts relative bundle paths to absolute paths.
+// ConvertToAbsolutePaths converts relative paths, such as the media/ paths
+// of a bundle, to absolute paths under bundleRoot. Absolute paths and URLs
+// are left unchanged.
 func ConvertToAbsolutePaths(timeline *gotio.Timeline, bundleRoot string) error {
 	clips := timeline.FindClips(nil, false)
 
@@ -150,9 +159,8 @@ func ConvertToAbsolutePaths(timeline *gotio.Timeline, bundleRoot string) error {
 			continue
 		}
 
-		// Check if it's a relative path (starts with "media/")
-		if strings.HasPrefix(targetURL, "media/") {
-			absPath := filepath.Join(bundleRoot, targetURL)
+		if isRelativePath(targetURL) {
+			absPath := filepath.Join(bundleRoot, filepath.FromSlash(targetURL))
 			extRef.SetTargetURL(absPath)
 		}
 	}
@@ -199,7 +207,7 @@ func getTargetURL(ref gotio.MediaReference) string {
 }
 
 // replaceMissing replaces a clip's media reference with a MissingReference.
-func replaceMissing(clip *gotio.Clip, original gotio.MediaReference, reason string) {
+func replaceMissing(logger *slog.Logger, clip *gotio.Clip, original gotio.MediaReference, reason string) {
 	missing := gotio.NewMissingReference(
 		original.Name(),
 		original.AvailableRange(),
@@ -209,18 +217,24 @@ func replaceMissing(clip *gotio.Clip, original gotio.MediaReference, reason stri
 		},
 	)
 	clip.SetMediaReference(missing)
+	logger.Warn("reference replaced by missing reference",
+		"clip", clip.Name(), "url", getTargetURL(original), "reason", reason)
 }
 
 // TotalMediaSize calculates the total size of all media files in the manifest.
-func TotalMediaSize(manifest MediaManifest) (int64, error) {
+func TotalMediaSize(manifest MediaManifest, opts ...Option) (int64, error) {
+	cfg := newConfig(opts)
 	var total int64
 
 	for path := range manifest {
-		info, err := os.Stat(path)
+		info, err := cfg.Resolver.Resolve(context.Background(), path)
 		if err != nil {
 			return 0, err
 		}
-		total += info.Size()
+		if !info.Exists {
+			return 0, &os.PathError{Op: "stat", Path: path, Err: fs.ErrNotExist}
+		}
+		total += info.Size
 	}
 
 	return total, nil
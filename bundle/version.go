@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package bundle
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedVersion is returned for a bundle whose format version has
+// a major version other than that of BundleVersion, which this package
+// cannot read.
+var ErrUnsupportedVersion = errors.New("unsupported bundle version")
+
+// DefaultCreator is the creator recorded in bundles when WithCreator is
+// not given.
+const DefaultCreator = "gotio"
+
+// maxInfoSize bounds the bytes read from version.txt and manifest.json.
+const maxInfoSize = 64 << 10
+
+// BundleInfo describes how a bundle was written. WriteOTIOD and WriteOTIOZ
+// record it in manifest.json, next to the version.txt of the bundle spec.
+type BundleInfo struct {
+	// Version is the bundle format version, as in version.txt.
+	Version string `json:"bundle_version"`
+	// Creator names the program that wrote the bundle.
+	Creator string `json:"creator,omitempty"`
+	// Created is when the bundle was written.
+	Created time.Time `json:"created,omitzero"`
+}
+
+// WithCreator sets the creator recorded in the manifest.json of bundles
+// written, such as the name and version of a delivery tool.
+func WithCreator(creator string) Option {
+	return func(c *Config) {
+		c.Creator = creator
+	}
+}
+
+// ReadBundleInfo reads the version and manifest of the .otiod or .otioz
+// bundle at path, without checking the version. Bundles written before
+// version.txt or manifest.json read as version 1.0.0 with no creator.
+func ReadBundleInfo(path string) (BundleInfo, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return BundleInfo{}, &BundleError{
+			Operation: "read",
+			Path:      path,
+			Message:   "bundle not found",
+			Cause:     err,
+		}
+	}
+	if info.IsDir() {
+		return readBundleInfo(os.DirFS(path), path)
+	}
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return BundleInfo{}, &BundleError{
+			Operation: "read",
+			Path:      path,
+			Message:   "failed to open zip",
+			Cause:     err,
+		}
+	}
+	defer r.Close()
+	return readBundleInfo(r, path)
+}
+
+// newBundleInfo returns the info recorded in a bundle written now.
+func newBundleInfo(cfg Config) BundleInfo {
+	return BundleInfo{
+		Version: BundleVersion,
+		Creator: cfg.Creator,
+		Created: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// marshal returns the info as the contents of manifest.json.
+func (info BundleInfo) marshal() ([]byte, error) {
+	return json.MarshalIndent(info, "", "    ")
+}
+
+// readBundleInfo reads version.txt and manifest.json from the bundle
+// fsys. The version of version.txt wins over that of the manifest.
+func readBundleInfo(fsys fs.FS, path string) (BundleInfo, error) {
+	var info BundleInfo
+	data, err := readInfoFile(fsys, "manifest.json")
+	if err == nil {
+		err = json.Unmarshal(data, &info)
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return BundleInfo{}, &BundleError{
+			Operation: "read",
+			Path:      path,
+			Message:   "failed to read manifest.json",
+			Cause:     err,
+		}
+	}
+	data, err = readInfoFile(fsys, "version.txt")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return BundleInfo{}, &BundleError{
+			Operation: "read",
+			Path:      path,
+			Message:   "failed to read version.txt",
+			Cause:     err,
+		}
+	}
+	if version := strings.TrimSpace(string(data)); version != "" {
+		info.Version = version
+	}
+	if info.Version == "" {
+		info.Version = "1.0.0"
+	}
+	return info, nil
+}
+
+// readInfoFile reads the named file of fsys, up to maxInfoSize bytes.
+func readInfoFile(fsys fs.FS, name string) ([]byte, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(io.LimitReader(f, maxInfoSize))
+}
+
+// checkBundleInfo reads the info of the bundle fsys and checks that its
+// version can be read. A bundle of a later minor version is read, with a
+// warning, as far as its content is understood.
+func checkBundleInfo(fsys fs.FS, path string, cfg Config) (BundleInfo, error) {
+	info, err := readBundleInfo(fsys, path)
+	if err != nil {
+		return BundleInfo{}, err
+	}
+	major, minor, ok := parseVersion(info.Version)
+	supportedMajor, supportedMinor, _ := parseVersion(BundleVersion)
+	if !ok || major != supportedMajor {
+		return BundleInfo{}, &BundleError{
+			Operation: "read",
+			Path:      path,
+			Message:   "bundle version " + info.Version + " is not " + strconv.Itoa(supportedMajor) + ".x",
+			Cause:     ErrUnsupportedVersion,
+		}
+	}
+	if minor > supportedMinor {
+		cfg.Logger.Warn("bundle version newer than supported",
+			"path", path, "version", info.Version, "supported", BundleVersion, "creator", info.Creator)
+	}
+	return info, nil
+}
+
+// parseVersion returns the major and minor numbers of a version such as
+// "1.0.0".
+func parseVersion(version string) (major, minor int, ok bool) {
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
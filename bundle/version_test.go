@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBundleInfo(t *testing.T) {
+	dir := t.TempDir()
+	timeline := createTestTimeline()
+	otioz := filepath.Join(dir, "cut.otioz")
+	otiod := filepath.Join(dir, "cut.otiod")
+	if err := WriteOTIOZ(timeline, otioz, AllMissing, WithCreator("delivery 2.1")); err != nil {
+		t.Fatalf("WriteOTIOZ error: %v", err)
+	}
+	if err := WriteOTIOD(timeline, otiod, AllMissing); err != nil {
+		t.Fatalf("WriteOTIOD error: %v", err)
+	}
+
+	for path, creator := range map[string]string{otioz: "delivery 2.1", otiod: DefaultCreator} {
+		info, err := ReadBundleInfo(path)
+		if err != nil {
+			t.Fatalf("ReadBundleInfo(%s) error: %v", path, err)
+		}
+		if info.Version != BundleVersion || info.Creator != creator || info.Created.IsZero() {
+			t.Errorf("ReadBundleInfo(%s) = %+v", path, info)
+		}
+	}
+
+	f, err := os.Open(otioz)
+	if err != nil {
+		t.Fatalf("Open error: %v", err)
+	}
+	defer f.Close()
+	stat, _ := f.Stat()
+	br, err := OpenOTIOZ(f, stat.Size())
+	if err != nil {
+		t.Fatalf("OpenOTIOZ error: %v", err)
+	}
+	if br.Info().Creator != "delivery 2.1" {
+		t.Errorf("Info = %+v", br.Info())
+	}
+
+	// A bundle written before the manifest reads as 1.0.0
+	legacy := filepath.Join(dir, "legacy.otiod")
+	if err := os.MkdirAll(legacy, 0755); err != nil {
+		t.Fatalf("MkdirAll error: %v", err)
+	}
+	content, _ := os.ReadFile(filepath.Join(otiod, "content.otio"))
+	if err := os.WriteFile(filepath.Join(legacy, "content.otio"), content, 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	if info, err := ReadBundleInfo(legacy); err != nil || info.Version != "1.0.0" || info.Creator != "" {
+		t.Errorf("legacy ReadBundleInfo = %+v, %v", info, err)
+	}
+	if _, err := ReadOTIOD(legacy, false); err != nil {
+		t.Errorf("ReadOTIOD of a legacy bundle error: %v", err)
+	}
+}
+
+func TestBundleVersionCheck(t *testing.T) {
+	dir := t.TempDir()
+	content, err := os.ReadFile(writeTestOTIOD(t, dir))
+	if err != nil {
+		t.Fatalf("ReadFile error: %v", err)
+	}
+	writeZip := func(version string) string {
+		path := filepath.Join(dir, "v"+version+".otioz")
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatalf("Create error: %v", err)
+		}
+		defer f.Close()
+		w := zip.NewWriter(f)
+		for name, data := range map[string][]byte{"version.txt": []byte(version), "content.otio": content} {
+			member, _ := w.Create(name)
+			member.Write(data)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close error: %v", err)
+		}
+		return path
+	}
+
+	// A later minor version is read with a warning
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	if _, err := ReadOTIOZ(writeZip("1.3.0"), WithLogger(logger)); err != nil {
+		t.Errorf("ReadOTIOZ of 1.3.0 error: %v", err)
+	}
+	if !strings.Contains(logs.String(), "bundle version newer than supported") {
+		t.Errorf("expected a warning, got %q", logs.String())
+	}
+
+	future := writeZip("2.0.0")
+	if _, err := ReadOTIOZ(future); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("ReadOTIOZ of 2.0.0 = %v, want ErrUnsupportedVersion", err)
+	}
+	if _, err := ReadOTIOZWithExtraction(future, filepath.Join(dir, "extract")); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("ReadOTIOZWithExtraction of 2.0.0 = %v, want ErrUnsupportedVersion", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "extract")); err == nil {
+		t.Error("a bundle of an unsupported version was extracted")
+	}
+
+	otiod := filepath.Join(dir, "future.otiod")
+	os.MkdirAll(otiod, 0755)
+	os.WriteFile(filepath.Join(otiod, "content.otio"), content, 0644)
+	os.WriteFile(filepath.Join(otiod, "version.txt"), []byte("2.0.0\n"), 0644)
+	if _, err := ReadOTIOD(otiod, false); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("ReadOTIOD of 2.0.0 = %v, want ErrUnsupportedVersion", err)
+	}
+}
+
+// writeTestOTIOD writes the test timeline as a .otiod bundle under dir and
+// returns the path of its content.otio.
+func writeTestOTIOD(t *testing.T, dir string) string {
+	t.Helper()
+	path := filepath.Join(dir, "content.otiod")
+	if err := WriteOTIOD(createTestTimeline(), path, AllMissing); err != nil {
+		t.Fatalf("WriteOTIOD error: %v", err)
+	}
+	return filepath.Join(path, "content.otio")
+}
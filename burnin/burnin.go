@@ -0,0 +1,250 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package burnin produces per-frame annotation data for burn-in and QC
+// tools: the record timecode of every frame of a timeline, the clip seen
+// on that frame with its source timecode, and the markers that hit it.
+//
+// The clip for a frame is the topmost enabled clip of the configured
+// track kind, so gaps in an upper track show the track below.
+//
+// Basic usage:
+//
+//	for frame, err := range burnin.Frames(timeline) {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Println(frame.RecordTC, frame.ClipName, frame.SourceTC)
+//	}
+//
+//	err := burnin.WriteCSV(os.Stdout, timeline, burnin.WithRate(24))
+package burnin
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func init() {
+	gotio.RegisterFeature("burnin")
+}
+
+// Frame is the annotation for one frame of a timeline.
+type Frame struct {
+	// Index counts frames from the start of the timeline.
+	Index    int    `json:"frame"`
+	RecordTC string `json:"record_tc"`
+	// Clip is the clip seen on the frame, or nil over a gap.
+	Clip      *gotio.Clip `json:"-"`
+	ClipName  string      `json:"clip,omitempty"`
+	TrackName string      `json:"track,omitempty"`
+	SourceTC  string      `json:"source_tc,omitempty"`
+	// Markers names the markers on the clip or the timeline that hit the frame.
+	Markers []string `json:"markers,omitempty"`
+}
+
+// Columns is the header row written by WriteCSV.
+var Columns = []string{"Frame", "Record TC", "Track", "Clip", "Source TC", "Markers"}
+
+// Config holds configuration for frame annotation.
+type Config struct {
+	// Rate is the frame rate frames are stepped at. Zero uses the rate of
+	// the timeline's global start time, else of its first clip, else 24.
+	Rate float64
+	// DropFrame selects drop frame timecode.
+	DropFrame opentime.IsDropFrameRate
+	// TrackKind selects the tracks clips are taken from, video by default.
+	TrackKind string
+}
+
+// Option is a functional option for frame annotation.
+type Option func(*Config)
+
+// WithRate sets the frame rate frames are stepped at.
+func WithRate(rate float64) Option {
+	return func(c *Config) {
+		c.Rate = rate
+	}
+}
+
+// WithDropFrame sets the drop frame mode for timecodes.
+func WithDropFrame(dropFrame opentime.IsDropFrameRate) Option {
+	return func(c *Config) {
+		c.DropFrame = dropFrame
+	}
+}
+
+// WithTrackKind selects the tracks clips are taken from.
+func WithTrackKind(kind string) Option {
+	return func(c *Config) {
+		c.TrackKind = kind
+	}
+}
+
+func newConfig(opts []Option) Config {
+	cfg := Config{
+		DropFrame: opentime.InferFromRate,
+		TrackKind: gotio.TrackKindVideo,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Frames returns an iterator over the frames of the timeline, in order.
+// An error stops the iteration.
+func Frames(timeline *gotio.Timeline, opts ...Option) iter.Seq2[Frame, error] {
+	return func(yield func(Frame, error) bool) {
+		cfg := newConfig(opts)
+		resolved, err := timeline.ResolvedClips(gotio.WithTrackKinds(cfg.TrackKind))
+		if err != nil {
+			yield(Frame{}, err)
+			return
+		}
+		duration, err := timeline.Duration()
+		if err != nil {
+			yield(Frame{}, err)
+			return
+		}
+
+		rate := cfg.Rate
+		globalStart := timeline.GlobalStartTime()
+		if rate <= 0 && globalStart != nil {
+			rate = globalStart.Rate()
+		}
+		if rate <= 0 && len(resolved) > 0 {
+			rate = resolved[0].GlobalRange.StartTime().Rate()
+		}
+		if rate <= 0 {
+			rate = 24
+		}
+		start := opentime.NewRationalTime(0, rate)
+		if globalStart != nil {
+			start = globalStart.RescaledTo(rate)
+		}
+
+		var timelineMarkers []*gotio.Marker
+		if tracks := timeline.Tracks(); tracks != nil {
+			timelineMarkers = tracks.Markers()
+		}
+
+		var active []gotio.ResolvedClip
+		next := 0
+		count := duration.ToFramesAtRate(rate)
+		for i := range count {
+			offset := opentime.NewRationalTime(float64(i), rate)
+			record := start.Add(offset)
+
+			for next < len(resolved) && resolved[next].GlobalRange.StartTime().Cmp(record) <= 0 {
+				active = append(active, resolved[next])
+				next++
+			}
+			active = slices.DeleteFunc(active, func(rc gotio.ResolvedClip) bool {
+				return rc.GlobalRange.EndTimeExclusive().Cmp(record) <= 0
+			})
+
+			frame := Frame{Index: i}
+			if frame.RecordTC, err = record.ToNearestTimecode(rate, cfg.DropFrame); err != nil {
+				yield(Frame{}, err)
+				return
+			}
+			if top := topClip(active); top != nil {
+				sourceRate := top.MediaRange.StartTime().Rate()
+				source := top.MediaRange.StartTime().Add(record.Sub(top.GlobalRange.StartTime()).RescaledTo(sourceRate))
+				frame.Clip = top.Clip
+				frame.ClipName = top.Clip.Name()
+				frame.TrackName = top.TrackName
+				if frame.SourceTC, err = source.ToNearestTimecode(sourceRate, cfg.DropFrame); err != nil {
+					yield(Frame{}, err)
+					return
+				}
+				frame.Markers = markerHits(frame.Markers, top.Clip.Markers(), source, rate)
+			}
+			frame.Markers = markerHits(frame.Markers, timelineMarkers, offset, rate)
+
+			if !yield(frame, nil) {
+				return
+			}
+		}
+	}
+}
+
+// topClip returns the active clip composited on top, preferring the
+// later of clips on the same track.
+func topClip(active []gotio.ResolvedClip) *gotio.ResolvedClip {
+	var top *gotio.ResolvedClip
+	for i := range active {
+		if top == nil || active[i].TrackIndex >= top.TrackIndex {
+			top = &active[i]
+		}
+	}
+	return top
+}
+
+// markerHits appends the names of the markers that hit the frame starting
+// at t. Zero duration markers hit the frame they start in.
+func markerHits(names []string, markers []*gotio.Marker, t opentime.RationalTime, rate float64) []string {
+	frame := opentime.NewTimeRange(t, opentime.NewRationalTime(1, rate))
+	for _, marker := range markers {
+		marked := marker.MarkedRange()
+		var hit bool
+		if marked.Duration().Value() == 0 {
+			hit = frame.Contains(marked.StartTime())
+		} else {
+			hit = frame.Intersects(marked, opentime.DefaultEpsilon)
+		}
+		if hit {
+			names = append(names, marker.Name())
+		}
+	}
+	return names
+}
+
+// WriteCSV writes one row per frame with the Columns header.
+func WriteCSV(w io.Writer, timeline *gotio.Timeline, opts ...Option) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(Columns); err != nil {
+		return err
+	}
+	for frame, err := range Frames(timeline, opts...) {
+		if err != nil {
+			return err
+		}
+		row := []string{
+			strconv.Itoa(frame.Index),
+			frame.RecordTC,
+			frame.TrackName,
+			frame.ClipName,
+			frame.SourceTC,
+			strings.Join(frame.Markers, "; "),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes one JSON object per frame, one per line.
+func WriteJSON(w io.Writer, timeline *gotio.Timeline, opts ...Option) error {
+	enc := json.NewEncoder(w)
+	for frame, err := range Frames(timeline, opts...) {
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(frame); err != nil {
+			return err
+		}
+	}
+	return nil
+}
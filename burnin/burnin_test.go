@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package burnin
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func frames(start, duration float64) *opentime.TimeRange {
+	r := opentime.NewTimeRange(opentime.NewRationalTime(start, 24), opentime.NewRationalTime(duration, 24))
+	return &r
+}
+
+// testTimeline builds
+//
+//	V2: gap[0,6) top[6,12)
+//	V1: a[0,24) gap[24,36) b[36,48)
+//
+// starting at 01:00:00:00, with a marker on a at source frame 115 and a
+// timeline marker at frame 40.
+func testTimeline() *gotio.Timeline {
+	start := opentime.NewRationalTime(86400, 24)
+	timeline := gotio.NewTimeline("cut", &start, nil)
+
+	note := gotio.NewMarker("note", *frames(115, 0), "", "", nil)
+	v1 := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	v1.AppendChild(gotio.NewClip("a", nil, frames(100, 24), nil, nil, []*gotio.Marker{note}, "", nil))
+	v1.AppendChild(gotio.NewGap("", frames(0, 12), nil, nil, nil, nil))
+	v1.AppendChild(gotio.NewClip("b", nil, frames(0, 12), nil, nil, nil, "", nil))
+
+	v2 := gotio.NewTrack("V2", nil, gotio.TrackKindVideo, nil, nil)
+	v2.AppendChild(gotio.NewGap("", frames(0, 6), nil, nil, nil, nil))
+	v2.AppendChild(gotio.NewClip("top", nil, frames(0, 6), nil, nil, nil, "", nil))
+
+	timeline.Tracks().AppendChild(v1)
+	timeline.Tracks().AppendChild(v2)
+	timeline.Tracks().SetMarkers([]*gotio.Marker{gotio.NewMarker("chapter", *frames(40, 1), "", "", nil)})
+	return timeline
+}
+
+func TestFrames(t *testing.T) {
+	var all []Frame
+	for frame, err := range Frames(testTimeline()) {
+		if err != nil {
+			t.Fatalf("Frames error: %v", err)
+		}
+		all = append(all, frame)
+	}
+	if len(all) != 48 {
+		t.Fatalf("expected 48 frames, got %d", len(all))
+	}
+
+	tests := []struct {
+		index    int
+		recordTC string
+		clip     string
+		sourceTC string
+		markers  string
+	}{
+		{0, "01:00:00:00", "a", "00:00:04:04", ""},
+		{6, "01:00:00:06", "top", "00:00:00:00", ""},
+		{15, "01:00:00:15", "a", "00:00:04:19", "note"},
+		{30, "01:00:01:06", "", "", ""},
+		{40, "01:00:01:16", "b", "00:00:00:04", "chapter"},
+	}
+	for _, tt := range tests {
+		f := all[tt.index]
+		if f.RecordTC != tt.recordTC || f.ClipName != tt.clip || f.SourceTC != tt.sourceTC || strings.Join(f.Markers, ",") != tt.markers {
+			t.Errorf("frame %d = %+v, want %s %q %q %q", tt.index, f, tt.recordTC, tt.clip, tt.sourceTC, tt.markers)
+		}
+	}
+}
+
+func TestWriteCSVAndJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, testTimeline()); err != nil {
+		t.Fatalf("WriteCSV error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 49 {
+		t.Fatalf("expected header and 48 rows, got %d lines", len(lines))
+	}
+	if lines[0] != "Frame,Record TC,Track,Clip,Source TC,Markers" {
+		t.Errorf("header = %q", lines[0])
+	}
+	if lines[16] != "15,01:00:00:15,V1,a,00:00:04:19,note" {
+		t.Errorf("row 15 = %q", lines[16])
+	}
+
+	buf.Reset()
+	if err := WriteJSON(&buf, testTimeline(), WithRate(48)); err != nil {
+		t.Fatalf("WriteJSON error: %v", err)
+	}
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 96 {
+		t.Fatalf("expected 96 frames at 48fps, got %d", len(lines))
+	}
+	var frame Frame
+	if err := json.Unmarshal([]byte(lines[13]), &frame); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if frame.Index != 13 || frame.ClipName != "top" || frame.RecordTC != "01:00:00:13" {
+		t.Errorf("frame 13 at 48fps = %+v", frame)
+	}
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// AdapterInfo describes a file format adapter linked into the program.
+type AdapterInfo struct {
+	Name     string   `json:"name"`
+	Package  string   `json:"package"`
+	Suffixes []string `json:"suffixes"`
+	CanRead  bool     `json:"can_read"`
+	CanWrite bool     `json:"can_write"`
+	// Options are the settings the adapter accepts. An adapter listing
+	// none accepts any, unchecked.
+	Options []AdapterOption `json:"options,omitempty"`
+}
+
+// AdapterOption documents a setting of a format adapter, given as
+// "<adapter>.<name>", such as "xmeml.rate".
+type AdapterOption struct {
+	Name string `json:"name"`
+	// Type is "string", "float", "int" or "bool".
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// CapabilityReport lists what a build of gotio supports, as returned by
+// Capabilities.
+type CapabilityReport struct {
+	// Schemas are the built-in and custom schemas that can be decoded.
+	Schemas []Schema `json:"schemas"`
+	// SchemaAliases maps legacy schema names to their current names.
+	SchemaAliases map[string]string `json:"schema_aliases"`
+	// Adapters are the format adapters linked into the program.
+	Adapters []AdapterInfo `json:"adapters"`
+	// Features are the optional packages linked into the program, such
+	// as "bundle" and "algorithms".
+	Features []string `json:"features"`
+	// TrackKinds are the built-in and registered track kinds.
+	TrackKinds []string `json:"track_kinds"`
+}
+
+var (
+	adapterRegistry = make(map[string]AdapterInfo)
+	featureRegistry = make(map[string]bool)
+	capabilityLock  sync.RWMutex
+)
+
+// RegisterAdapter records a format adapter for Capabilities. Adapter
+// packages call it from init, so only adapters linked into the program
+// are listed.
+func RegisterAdapter(info AdapterInfo) {
+	capabilityLock.Lock()
+	defer capabilityLock.Unlock()
+	adapterRegistry[info.Name] = info
+}
+
+// LookupAdapter returns the adapter registered under name.
+func LookupAdapter(name string) (AdapterInfo, bool) {
+	capabilityLock.RLock()
+	defer capabilityLock.RUnlock()
+	info, ok := adapterRegistry[name]
+	info.Suffixes = slices.Clone(info.Suffixes)
+	info.Options = slices.Clone(info.Options)
+	return info, ok
+}
+
+// RegisterFeature records an optional package for Capabilities.
+func RegisterFeature(name string) {
+	capabilityLock.Lock()
+	defer capabilityLock.Unlock()
+	featureRegistry[name] = true
+}
+
+// Capabilities reports the schemas, adapters, features and track kinds
+// available in this program, so pipeline tools can check a build before
+// dispatching work to it. Lists are sorted by name.
+func Capabilities() CapabilityReport {
+	var report CapabilityReport
+
+	schemaLock.RLock()
+	for name, version := range schemaVersions {
+		report.Schemas = append(report.Schemas, Schema{Name: name, Version: version})
+	}
+	report.SchemaAliases = maps.Clone(schemaAliases)
+	schemaLock.RUnlock()
+
+	schemaDecodersMu.RLock()
+	for _, schema := range schemaCodecs {
+		report.Schemas = append(report.Schemas, schema)
+	}
+	schemaDecodersMu.RUnlock()
+
+	slices.SortFunc(report.Schemas, func(a, b Schema) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Version, b.Version))
+	})
+	report.Schemas = slices.Compact(report.Schemas)
+	report.TrackKinds = TrackKinds()
+
+	capabilityLock.RLock()
+	defer capabilityLock.RUnlock()
+	for _, name := range slices.Sorted(maps.Keys(adapterRegistry)) {
+		info := adapterRegistry[name]
+		info.Suffixes = slices.Clone(info.Suffixes)
+		info.Options = slices.Clone(info.Options)
+		report.Adapters = append(report.Adapters, info)
+	}
+	report.Features = slices.Sorted(maps.Keys(featureRegistry))
+	return report
+}
+
+// HasFeature reports whether the named optional package is linked into the program.
+func HasFeature(name string) bool {
+	capabilityLock.RLock()
+	defer capabilityLock.RUnlock()
+	return featureRegistry[name]
+}
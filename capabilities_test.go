@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCapabilities(t *testing.T) {
+	RegisterAdapter(AdapterInfo{Name: "test_format", Suffixes: []string{".tst"}, CanRead: true})
+	RegisterFeature("test_feature")
+	RegisterSchemaCodec(Schema{Name: "CapabilityShot", Version: 2}, nil, nil, nil)
+
+	report := Capabilities()
+	if !slices.Contains(report.Schemas, ClipSchema) || !slices.Contains(report.Schemas, TimelineSchema) {
+		t.Errorf("expected built-in schemas with versions, got %v", report.Schemas)
+	}
+	if !slices.Contains(report.Schemas, Schema{Name: "CapabilityShot", Version: 2}) {
+		t.Error("expected custom schema codec to be listed")
+	}
+	if report.SchemaAliases["Sequence"] != "Track" {
+		t.Errorf("SchemaAliases = %v", report.SchemaAliases)
+	}
+	if !slices.IsSortedFunc(report.Schemas, func(a, b Schema) int { return strings.Compare(a.Name, b.Name) }) {
+		t.Error("expected schemas sorted by name")
+	}
+
+	i := slices.IndexFunc(report.Adapters, func(a AdapterInfo) bool { return a.Name == "test_format" })
+	if i < 0 || !report.Adapters[i].CanRead || report.Adapters[i].CanWrite {
+		t.Errorf("Adapters = %+v", report.Adapters)
+	}
+	if !slices.Contains(report.Features, "test_feature") || !HasFeature("test_feature") {
+		t.Errorf("Features = %v", report.Features)
+	}
+	if HasFeature("no_such_feature") {
+		t.Error("unexpected feature")
+	}
+}
@@ -5,6 +5,7 @@ package gotio
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/Avalanche-io/gotio/opentime"
 )
@@ -32,6 +33,21 @@ func NewClip(
 	markers []*Marker,
 	activeMediaReferenceKey string,
 	color *Color,
+) *Clip {
+	return initClip(new(Clip), name, mediaReference, sourceRange, metadata, effects, markers, activeMediaReferenceKey, color)
+}
+
+// initClip initializes clip in place, for clips allocated by a decodeArena.
+func initClip(
+	clip *Clip,
+	name string,
+	mediaReference MediaReference,
+	sourceRange *opentime.TimeRange,
+	metadata AnyDictionary,
+	effects []Effect,
+	markers []*Marker,
+	activeMediaReferenceKey string,
+	color *Color,
 ) *Clip {
 	if activeMediaReferenceKey == "" {
 		activeMediaReferenceKey = DefaultMediaKey
@@ -44,7 +60,7 @@ func NewClip(
 		mediaReferences[activeMediaReferenceKey] = NewMissingReference("", nil, nil)
 	}
 
-	clip := &Clip{
+	*clip = Clip{
 		ItemBase:                NewItemBase(name, sourceRange, metadata, effects, markers, true, color),
 		mediaReferences:         mediaReferences,
 		activeMediaReferenceKey: activeMediaReferenceKey,
@@ -63,24 +79,93 @@ func (c *Clip) SetMediaReference(mediaReference MediaReference) {
 	if mediaReference == nil {
 		mediaReference = NewMissingReference("", nil, nil)
 	}
+	if c.mediaReferences == nil {
+		c.mediaReferences = make(map[string]MediaReference)
+	}
 	c.mediaReferences[c.activeMediaReferenceKey] = mediaReference
 }
 
-// MediaReferences returns all media references.
+// Color returns the clip color, or nil if it has none.
+func (c *Clip) Color() *Color {
+	return c.color
+}
+
+// SetColor sets the clip color to a copy of color. Standard colors can be
+// set with a MarkerColor, as in SetColor(MarkerColorRed.ToColor()).
+func (c *Clip) SetColor(color *Color) {
+	c.color = cloneColor(color)
+}
+
+// MediaReferences returns all media references, keyed by name.
+// The returned map is owned by the clip; use the setters to modify it.
 func (c *Clip) MediaReferences() map[string]MediaReference {
 	return c.mediaReferences
 }
 
+// MediaReferenceKeys returns the media reference keys in sorted order.
+func (c *Clip) MediaReferenceKeys() []string {
+	keys := make([]string, 0, len(c.mediaReferences))
+	for k := range c.mediaReferences {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // SetMediaReferences sets all media references and the active key.
+// Keys must be non-empty and activeKey must be one of them.
+// Nil references are replaced with MissingReferences.
 func (c *Clip) SetMediaReferences(refs map[string]MediaReference, activeKey string) error {
+	if activeKey == "" {
+		return ErrEmptyMediaReferenceKey
+	}
 	if _, ok := refs[activeKey]; !ok {
 		return ErrMediaReferenceNotFound
 	}
-	c.mediaReferences = refs
+	newRefs := make(map[string]MediaReference, len(refs))
+	for k, ref := range refs {
+		if k == "" {
+			return ErrEmptyMediaReferenceKey
+		}
+		if ref == nil {
+			ref = NewMissingReference("", nil, nil)
+		}
+		newRefs[k] = ref
+	}
+	c.mediaReferences = newRefs
 	c.activeMediaReferenceKey = activeKey
 	return nil
 }
 
+// AddMediaReference adds or replaces the media reference stored under key.
+// The active key is unchanged. A nil reference is stored as a MissingReference.
+func (c *Clip) AddMediaReference(key string, ref MediaReference) error {
+	if key == "" {
+		return ErrEmptyMediaReferenceKey
+	}
+	if ref == nil {
+		ref = NewMissingReference("", nil, nil)
+	}
+	if c.mediaReferences == nil {
+		c.mediaReferences = make(map[string]MediaReference)
+	}
+	c.mediaReferences[key] = ref
+	return nil
+}
+
+// RemoveMediaReference removes the media reference stored under key.
+// The active media reference cannot be removed.
+func (c *Clip) RemoveMediaReference(key string) error {
+	if _, ok := c.mediaReferences[key]; !ok {
+		return ErrMediaReferenceNotFound
+	}
+	if key == c.activeMediaReferenceKey {
+		return ErrActiveMediaReference
+	}
+	delete(c.mediaReferences, key)
+	return nil
+}
+
 // ActiveMediaReferenceKey returns the active media reference key.
 func (c *Clip) ActiveMediaReferenceKey() string {
 	return c.activeMediaReferenceKey
@@ -106,6 +106,100 @@ func TestClipMediaReferences(t *testing.T) {
 	}
 }
 
+func TestClipAddMediaReference(t *testing.T) {
+	ar := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(100, 24))
+	hiRes := NewExternalReference("", "/path/hires.mov", &ar, nil)
+	proxy := NewExternalReference("", "/path/proxy.mov", &ar, nil)
+
+	clip := NewClip("clip", hiRes, nil, nil, nil, nil, "", nil)
+	if err := clip.AddMediaReference("proxy", proxy); err != nil {
+		t.Fatalf("AddMediaReference error: %v", err)
+	}
+	if clip.ActiveMediaReferenceKey() != DefaultMediaKey {
+		t.Errorf("AddMediaReference changed active key to %s", clip.ActiveMediaReferenceKey())
+	}
+	keys := clip.MediaReferenceKeys()
+	if len(keys) != 2 || keys[0] != DefaultMediaKey || keys[1] != "proxy" {
+		t.Errorf("MediaReferenceKeys = %v, want [%s proxy]", keys, DefaultMediaKey)
+	}
+
+	if err := clip.SetActiveMediaReferenceKey("proxy"); err != nil {
+		t.Fatalf("SetActiveMediaReferenceKey error: %v", err)
+	}
+	if clip.MediaReference() != MediaReference(proxy) {
+		t.Error("expected proxy to be the active media reference")
+	}
+
+	if err := clip.AddMediaReference("", proxy); err != ErrEmptyMediaReferenceKey {
+		t.Errorf("expected ErrEmptyMediaReferenceKey, got %v", err)
+	}
+	if err := clip.AddMediaReference("plate", nil); err != nil {
+		t.Fatalf("AddMediaReference error: %v", err)
+	}
+	if _, ok := clip.MediaReferences()["plate"].(*MissingReference); !ok {
+		t.Error("expected nil reference to be stored as MissingReference")
+	}
+	if err := clip.SetActiveMediaReferenceKey("missing"); err != ErrMediaReferenceNotFound {
+		t.Errorf("expected ErrMediaReferenceNotFound, got %v", err)
+	}
+}
+
+func TestClipAddMediaReferenceZeroValue(t *testing.T) {
+	var clip Clip
+	proxy := NewExternalReference("", "/path/proxy.mov", nil, nil)
+	if err := clip.AddMediaReference("proxy", proxy); err != nil {
+		t.Fatalf("AddMediaReference error: %v", err)
+	}
+	if clip.MediaReferences()["proxy"] != MediaReference(proxy) {
+		t.Error("expected proxy to be stored on a zero-value clip")
+	}
+}
+
+func TestClipRemoveMediaReference(t *testing.T) {
+	clip := NewClip("clip", nil, nil, nil, nil, nil, "", nil)
+	clip.AddMediaReference("proxy", NewExternalReference("", "/path/proxy.mov", nil, nil))
+
+	if err := clip.RemoveMediaReference(DefaultMediaKey); err != ErrActiveMediaReference {
+		t.Errorf("expected ErrActiveMediaReference, got %v", err)
+	}
+	if err := clip.RemoveMediaReference("nope"); err != ErrMediaReferenceNotFound {
+		t.Errorf("expected ErrMediaReferenceNotFound, got %v", err)
+	}
+	if err := clip.RemoveMediaReference("proxy"); err != nil {
+		t.Fatalf("RemoveMediaReference error: %v", err)
+	}
+	if len(clip.MediaReferences()) != 1 {
+		t.Errorf("expected 1 media reference, got %d", len(clip.MediaReferences()))
+	}
+}
+
+func TestClipSetMediaReferencesValidation(t *testing.T) {
+	clip := NewClip("clip", nil, nil, nil, nil, nil, "", nil)
+	ref := NewExternalReference("", "/path/main.mov", nil, nil)
+
+	if err := clip.SetMediaReferences(map[string]MediaReference{"main": ref}, ""); err != ErrEmptyMediaReferenceKey {
+		t.Errorf("expected ErrEmptyMediaReferenceKey for empty active key, got %v", err)
+	}
+	if err := clip.SetMediaReferences(map[string]MediaReference{"main": ref, "": ref}, "main"); err != ErrEmptyMediaReferenceKey {
+		t.Errorf("expected ErrEmptyMediaReferenceKey for empty key, got %v", err)
+	}
+	if clip.ActiveMediaReferenceKey() != DefaultMediaKey {
+		t.Error("expected failed SetMediaReferences to leave the clip unchanged")
+	}
+
+	refs := map[string]MediaReference{"main": ref, "proxy": nil}
+	if err := clip.SetMediaReferences(refs, "main"); err != nil {
+		t.Fatalf("SetMediaReferences error: %v", err)
+	}
+	if _, ok := clip.MediaReferences()["proxy"].(*MissingReference); !ok {
+		t.Error("expected nil reference to be stored as MissingReference")
+	}
+	refs["other"] = ref
+	if len(clip.MediaReferences()) != 2 {
+		t.Error("expected clip not to alias the caller's map")
+	}
+}
+
 func TestClipSchema(t *testing.T) {
 	clip := NewClip("clip", nil, nil, nil, nil, nil, "", nil)
 
@@ -254,3 +348,32 @@ func TestClipWithMediaReferencesJSON(t *testing.T) {
 		t.Errorf("ActiveMediaReferenceKey = %s, want main", clip2.ActiveMediaReferenceKey())
 	}
 }
+
+func TestClipColor(t *testing.T) {
+	clip := NewClip("shot", nil, nil, nil, nil, nil, "", nil)
+	if clip.Color() != nil {
+		t.Errorf("expected no color, got %v", clip.Color())
+	}
+	clip.SetColor(MarkerColorOrange.ToColor())
+	if mc, ok := clip.Color().MarkerColor(); !ok || mc != MarkerColorOrange {
+		t.Errorf("Color = %v, want ORANGE", clip.Color())
+	}
+	clip.Color().R = 0
+	if ColorOrange.R != 1.0 {
+		t.Error("SetColor should copy the color")
+	}
+
+	custom, _ := ParseColor("#102030")
+	clip.SetColor(custom)
+	data, err := ToJSONBytes(clip)
+	if err != nil {
+		t.Fatalf("ToJSONBytes error: %v", err)
+	}
+	obj, err := FromJSONBytes(data)
+	if err != nil {
+		t.Fatalf("FromJSONBytes error: %v", err)
+	}
+	if got := obj.(*Clip).Color(); got == nil || got.Hex() != "#102030" {
+		t.Errorf("Color after round trip = %v, want #102030", got)
+	}
+}
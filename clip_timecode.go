@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// TimecodeRange is an in and out timecode, the out point exclusive as in
+// an EDL.
+type TimecodeRange struct {
+	In  string `json:"in"`
+	Out string `json:"out"`
+}
+
+// String returns the range as "in - out".
+func (r TimecodeRange) String() string {
+	return r.In + " - " + r.Out
+}
+
+// RecordRange returns the clip's trimmed range in the timeline's time,
+// including its global start time: the record in and out of an EDL event.
+// Returns ErrNoCommonAncestor if the clip is not in the timeline.
+func (c *Clip) RecordRange(timeline *Timeline) (opentime.TimeRange, error) {
+	if timeline == nil {
+		return opentime.TimeRange{}, ErrNoCommonAncestor
+	}
+	return timeline.RecordRangeOfChild(c)
+}
+
+// RecordRangeInTimeline returns the record in and out timecodes of the
+// clip in the timeline at rate. A rate of zero uses the rate of the record
+// range.
+func (c *Clip) RecordRangeInTimeline(timeline *Timeline, rate float64, drop opentime.IsDropFrameRate) (TimecodeRange, error) {
+	record, err := c.RecordRange(timeline)
+	if err != nil {
+		return TimecodeRange{}, err
+	}
+	return timecodeRange(record, rate, drop)
+}
+
+// SourceTimecodeRange returns the source in and out timecodes of the clip,
+// those of its trimmed range, at rate. A rate of zero uses the rate of the
+// trimmed range.
+func (c *Clip) SourceTimecodeRange(rate float64, drop opentime.IsDropFrameRate) (TimecodeRange, error) {
+	trimmed, err := c.TrimmedRange()
+	if err != nil {
+		return TimecodeRange{}, err
+	}
+	return timecodeRange(trimmed, rate, drop)
+}
+
+func timecodeRange(tr opentime.TimeRange, rate float64, drop opentime.IsDropFrameRate) (TimecodeRange, error) {
+	if rate <= 0 {
+		rate = tr.StartTime().Rate()
+	}
+	in, out, err := opentime.RangeToTimecodes(tr, rate, drop)
+	if err != nil {
+		return TimecodeRange{}, err
+	}
+	return TimecodeRange{In: in, Out: out}, nil
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func TestClipTimecodeRanges(t *testing.T) {
+	timeline := NewTimeline("edl", nil, nil)
+	hour := opentime.NewRationalTime(86400, 24)
+	timeline.SetGlobalStartTime(&hour)
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	track.AppendChild(NewGap("", searchTestRange(0, 48), nil, nil, nil, nil))
+	nested := NewStack("nested", searchTestRange(24, 24), nil, nil, nil, nil)
+	inner := NewTrack("inner", nil, TrackKindVideo, nil, nil)
+	clip := NewClip("A", nil, searchTestRange(86400+100, 72), nil, nil, nil, "", nil)
+	inner.AppendChild(clip)
+	nested.AppendChild(inner)
+	track.AppendChild(nested)
+	timeline.Tracks().AppendChild(track)
+
+	source, err := clip.SourceTimecodeRange(0, opentime.InferFromRate)
+	if err != nil || source.String() != "01:00:04:04 - 01:00:07:04" {
+		t.Errorf("SourceTimecodeRange = %v, %v", source, err)
+	}
+	// The clip starts 24 frames before the nested stack's trimmed range,
+	// which sits after two seconds of gap.
+	record, err := clip.RecordRangeInTimeline(timeline, 24, opentime.InferFromRate)
+	if err != nil || record.In != "01:00:01:00" || record.Out != "01:00:04:00" {
+		t.Errorf("RecordRangeInTimeline = %v, %v", record, err)
+	}
+	if tc, _ := clip.SourceTimecodeRange(48, opentime.ForceNo); tc.In != "01:00:04:08" {
+		t.Errorf("SourceTimecodeRange at 48 = %v", tc)
+	}
+
+	// Tracks of a stack all start at its start.
+	v2 := NewTrack("V2", nil, TrackKindVideo, nil, nil)
+	over := NewClip("over", nil, searchTestRange(0, 24), nil, nil, nil, "", nil)
+	v2.AppendChild(over)
+	timeline.Tracks().AppendChild(v2)
+	if record, err := over.RecordRangeInTimeline(timeline, 0, opentime.ForceNo); err != nil || record.In != "01:00:00:00" {
+		t.Errorf("RecordRangeInTimeline on V2 = %v, %v", record, err)
+	}
+
+	if _, err := clip.RecordRangeInTimeline(NewTimeline("other", nil, nil), 24, opentime.ForceNo); !errors.Is(err, ErrNoCommonAncestor) {
+		t.Errorf("clip of another timeline: got %v, want ErrNoCommonAncestor", err)
+	}
+}
@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// otioconvert converts timelines between the formats gotio reads and
+// writes, chosen by file suffix: .otio JSON, .otioscript, .csv shot lists
+// and .xml Final Cut Pro 7 XML. Other suffixes are handled by external
+// adapters, executables named gotio-adapter-<name> on PATH, such as the
+// Python OTIO adapters through the bridge script.
+//
+// Usage:
+//
+//	go run ./cmd/otioconvert edit.otioscript edit.otio
+//	go run ./cmd/otioconvert -rate 25 edit.otio edit.csv
+//	go run ./cmd/otioconvert -a shotlist.delimiter=tab -a xmeml.drop_frame=true cut.xml cut.csv
+//
+// Each -a flag sets an option of the adapter reading or writing, keyed
+// with its name; otiopluginfo lists the options of each adapter.
+// An output of "-" writes OTIO JSON to standard output.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/adapters"
+	"github.com/Avalanche-io/gotio/adapters/otioscript"
+	"github.com/Avalanche-io/gotio/adapters/shotlist"
+	"github.com/Avalanche-io/gotio/adapters/xmeml"
+)
+
+func main() {
+	rate := flag.Float64("rate", 0, "Frame rate for formats that need one")
+	var opts adapters.Options
+	flag.Var(&opts, "a", "Adapter option `key=value`, such as xmeml.rate=25 (repeatable)")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: otioconvert [-rate R] [-a key=value]... input output")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if _, err := adapters.DiscoverExternal(); err != nil {
+		fmt.Fprintf(os.Stderr, "otioconvert: %v\n", err)
+	}
+	if err := convert(flag.Arg(0), flag.Arg(1), *rate, opts, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "otioconvert: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// convert reads the timeline at input and writes it to output, or to
+// stdout if output is "-". Adapter options configure the adapters of
+// both files.
+func convert(input, output string, rate float64, opts adapters.Options, stdout io.Writer) error {
+	timeline, err := readTimeline(input, rate, opts)
+	if err != nil {
+		return err
+	}
+	if output == "-" {
+		data, err := gotio.ToJSONBytesIndent(timeline, "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(stdout, string(data))
+		return err
+	}
+	return writeTimeline(timeline, output, rate, opts)
+}
+
+func readTimeline(path string, rate float64, opts adapters.Options) (*gotio.Timeline, error) {
+	switch suffix(path) {
+	case ".otio":
+		obj, err := gotio.FromJSONFile(path)
+		if err != nil {
+			return nil, err
+		}
+		timeline, ok := obj.(*gotio.Timeline)
+		if !ok {
+			return nil, fmt.Errorf("%s holds a %s, not a Timeline", path, obj.SchemaName())
+		}
+		return timeline, nil
+	case ".otioscript":
+		options, err := otioscript.FromOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		return otioscript.ReadFile(path, append([]otioscript.Option{otioscript.WithRate(rate)}, options...)...)
+	case ".csv":
+		options, err := shotlist.FromOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		return shotlist.ReadFile(path, append([]shotlist.Option{shotlist.WithRate(rate)}, options...)...)
+	case ".xml":
+		options, err := xmeml.FromOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		return xmeml.ReadFile(path, append([]xmeml.Option{xmeml.WithRate(rate)}, options...)...)
+	}
+	adapter, ok := adapters.LookupExternal(suffix(path))
+	if !ok {
+		return nil, fmt.Errorf("cannot read %s: unknown suffix", path)
+	}
+	obj, err := adapter.ReadWithOptions(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	timeline, ok := obj.(*gotio.Timeline)
+	if !ok {
+		return nil, fmt.Errorf("%s holds a %s, not a Timeline", path, obj.SchemaName())
+	}
+	return timeline, nil
+}
+
+func writeTimeline(timeline *gotio.Timeline, path string, rate float64, opts adapters.Options) error {
+	switch suffix(path) {
+	case ".otio":
+		return gotio.ToJSONFile(timeline, path, "    ")
+	case ".otioscript":
+		options, err := otioscript.FromOptions(opts)
+		if err != nil {
+			return err
+		}
+		return otioscript.WriteFile(timeline, path, append([]otioscript.Option{otioscript.WithRate(rate)}, options...)...)
+	case ".csv":
+		options, err := shotlist.FromOptions(opts)
+		if err != nil {
+			return err
+		}
+		return shotlist.WriteFile(timeline, path, append([]shotlist.Option{shotlist.WithRate(rate)}, options...)...)
+	case ".xml":
+		options, err := xmeml.FromOptions(opts)
+		if err != nil {
+			return err
+		}
+		return xmeml.WriteFile(timeline, path, append([]xmeml.Option{xmeml.WithRate(rate)}, options...)...)
+	}
+	adapter, ok := adapters.LookupExternal(suffix(path))
+	if !ok {
+		return fmt.Errorf("cannot write %s: unknown suffix", path)
+	}
+	return adapter.WriteWithOptions(timeline, path, opts)
+}
+
+func suffix(path string) string {
+	return strings.ToLower(filepath.Ext(path))
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/adapters"
+)
+
+func TestConvert(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "cut.otioscript")
+	err := os.WriteFile(script, []byte("timeline cut rate 25\ntrack V1\nclip sh010 media /plates/sh010.mov in 0 dur 50\n"), 0644)
+	if err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	otio := filepath.Join(dir, "cut.otio")
+	if err := convert(script, otio, 0, nil, nil); err != nil {
+		t.Fatalf("convert to otio error: %v", err)
+	}
+	obj, err := gotio.FromJSONFile(otio)
+	if err != nil {
+		t.Fatalf("FromJSONFile error: %v", err)
+	}
+	timeline := obj.(*gotio.Timeline)
+	if clips := timeline.FindClips(nil, false); len(clips) != 1 || clips[0].Name() != "sh010" {
+		t.Fatalf("unexpected clips %v", clips)
+	}
+
+	csv := filepath.Join(dir, "cut.csv")
+	if err := convert(otio, csv, 0, nil, nil); err != nil {
+		t.Fatalf("convert to csv error: %v", err)
+	}
+	data, _ := os.ReadFile(csv)
+	if !strings.Contains(string(data), "sh010,00:00:00:00,00:00:02:00") {
+		t.Errorf("unexpected shot list:\n%s", data)
+	}
+
+	opts, err := adapters.ParseOptions([]string{"shotlist.delimiter=tab", "shotlist.rate=50"})
+	if err != nil {
+		t.Fatalf("ParseOptions error: %v", err)
+	}
+	if err := convert(otio, csv, 0, opts, nil); err != nil {
+		t.Fatalf("convert to csv with options error: %v", err)
+	}
+	data, _ = os.ReadFile(csv)
+	if !strings.Contains(string(data), "sh010\t00:00:00:00\t00:00:02:00") {
+		t.Errorf("options not applied:\n%s", data)
+	}
+	opts["shotlist.quote"] = "always"
+	if err := convert(otio, csv, 0, opts, nil); !errors.Is(err, adapters.ErrUnknownOption) {
+		t.Errorf("expected ErrUnknownOption, got %v", err)
+	}
+
+	xml := filepath.Join(dir, "cut.xml")
+	if err := convert(otio, xml, 0, nil, nil); err != nil {
+		t.Fatalf("convert to xml error: %v", err)
+	}
+	data, _ = os.ReadFile(xml)
+	if !strings.Contains(string(data), "<pathurl>/plates/sh010.mov</pathurl>") {
+		t.Errorf("unexpected xmeml:\n%s", data)
+	}
+
+	var stdout bytes.Buffer
+	if err := convert(script, "-", 0, nil, &stdout); err != nil {
+		t.Fatalf("convert to stdout error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), `"OTIO_SCHEMA": "Timeline.1"`) {
+		t.Errorf("expected OTIO JSON on stdout, got:\n%s", stdout.String())
+	}
+
+	if err := convert(script, filepath.Join(dir, "cut.edl"), 0, nil, nil); err == nil {
+		t.Error("expected an error for an unknown suffix")
+	}
+}
@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// otiofmt writes timelines as canonical .otio, so they can be kept in git
+// and diffed: metadata keys sorted, every float written the same way, rates
+// within a millionth of a whole number snapped to it, empty metadata
+// dictionaries dropped, and four-space indentation. Formatting a formatted
+// file leaves it unchanged, and the key order of the input does not change
+// the output. Inputs are read by suffix as by otioconvert.
+//
+// Usage:
+//
+//	go run ./cmd/otiofmt edit.xml > edit.otio
+//	go run ./cmd/otiofmt -w timelines/*.otio
+//	go run ./cmd/otiofmt -check timelines/*.otio
+//
+// Without flags the formatted timelines are written to standard output.
+// With -w each .otio file is rewritten in place. With -check nothing is
+// written; the files not already formatted are listed and the exit status
+// is 1 if there are any. The exit status is 2 when a file cannot be read
+// or written.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/adapters"
+	"github.com/Avalanche-io/gotio/pipeline"
+)
+
+func main() {
+	rate := flag.Float64("rate", 0, "Frame rate for formats that need one")
+	write := flag.Bool("w", false, "Rewrite .otio files in place")
+	check := flag.Bool("check", false, "List files not formatted and exit 1 if there are any")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: otiofmt [-rate R] [-w | -check] file...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 || (*write && *check) {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if _, err := adapters.DiscoverExternal(); err != nil {
+		fmt.Fprintf(os.Stderr, "otiofmt: %v\n", err)
+	}
+	mode := modePrint
+	switch {
+	case *write:
+		mode = modeWrite
+	case *check:
+		mode = modeCheck
+	}
+	unformatted, err := run(flag.Args(), *rate, mode, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "otiofmt: %v\n", err)
+		os.Exit(2)
+	}
+	if unformatted {
+		os.Exit(1)
+	}
+}
+
+// mode is what run does with each formatted file.
+type mode int
+
+const (
+	modePrint mode = iota
+	modeWrite
+	modeCheck
+)
+
+// run formats each file and prints, rewrites or checks it. In check mode
+// it reports whether any file was not formatted.
+func run(files []string, rate float64, m mode, w io.Writer) (bool, error) {
+	unformatted := false
+	for _, path := range files {
+		if m != modePrint && !isOTIO(path) {
+			return false, fmt.Errorf("%s: -w and -check need .otio files", path)
+		}
+		formatted, err := formatFile(path, rate)
+		if err != nil {
+			return false, err
+		}
+		switch m {
+		case modePrint:
+			if _, err := w.Write(formatted); err != nil {
+				return false, err
+			}
+		case modeWrite, modeCheck:
+			original, err := os.ReadFile(path)
+			if err != nil {
+				return false, err
+			}
+			if bytes.Equal(original, formatted) {
+				continue
+			}
+			if m == modeCheck {
+				unformatted = true
+				fmt.Fprintln(w, path)
+				continue
+			}
+			if err := os.WriteFile(path, formatted, 0644); err != nil {
+				return false, err
+			}
+		}
+	}
+	return unformatted, nil
+}
+
+// formatFile reads the timeline at path and returns it formatted.
+func formatFile(path string, rate float64) ([]byte, error) {
+	timeline, err := pipeline.ReadFile(path, rate)
+	if err != nil {
+		return nil, err
+	}
+	return format(timeline)
+}
+
+// format returns the canonical .otio form of the timeline, ending in a
+// newline. It drops empty metadata dictionaries from the timeline.
+func format(timeline *gotio.Timeline) ([]byte, error) {
+	stripMetadata(timeline)
+	data, err := gotio.ToJSONBytesWithOptions(timeline,
+		gotio.WithCanonical(true),
+		gotio.WithIntegralRates(),
+		gotio.WithIndent("    "),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// stripMetadata drops the empty dictionaries, at any depth, from the
+// metadata of the timeline and everything in it.
+func stripMetadata(timeline *gotio.Timeline) {
+	strip := func(obj gotio.SerializableObjectWithMetadata) {
+		if obj != nil {
+			stripEmpty(obj.Metadata())
+		}
+	}
+	strip(timeline)
+	if timeline.Tracks() == nil {
+		return
+	}
+	objects := append([]gotio.Composable{timeline.Tracks()}, timeline.FindChildren(nil, false, nil)...)
+	for _, c := range objects {
+		strip(c)
+		item, ok := c.(gotio.Item)
+		if !ok {
+			continue
+		}
+		for _, marker := range item.Markers() {
+			strip(marker)
+		}
+		for _, effect := range item.Effects() {
+			strip(effect)
+		}
+		if clip, ok := c.(*gotio.Clip); ok {
+			for _, ref := range clip.MediaReferences() {
+				strip(ref)
+			}
+		}
+	}
+}
+
+// stripEmpty deletes the entries of d that are empty dictionaries once
+// their own empty dictionaries are deleted.
+func stripEmpty(d gotio.AnyDictionary) {
+	for key, value := range d {
+		var nested gotio.AnyDictionary
+		switch v := value.(type) {
+		case gotio.AnyDictionary:
+			nested = v
+		case map[string]any:
+			nested = v
+		default:
+			continue
+		}
+		stripEmpty(nested)
+		if len(nested) == 0 {
+			delete(d, key)
+		}
+	}
+}
+
+func isOTIO(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".otio")
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// unformatted is a timeline as another tool might write it, with keys in
+// its own order, a rate a hair off 24 and an empty metadata namespace.
+const unformatted = `{"OTIO_SCHEMA": "Timeline.1", "name": "cut", "metadata": {"zeta": 1, "alpha": {"b": 2, "a": 1}, "empty": {"nested": {}}},
+"tracks": {"OTIO_SCHEMA": "Stack.1", "name": "tracks", "enabled": true, "children": [
+  {"OTIO_SCHEMA": "Track.1", "name": "V1", "kind": "Video", "enabled": true, "children": [
+    {"OTIO_SCHEMA": "Clip.2", "name": "sh010", "enabled": true,
+     "source_range": {"OTIO_SCHEMA": "TimeRange.1", "start_time": {"OTIO_SCHEMA": "RationalTime.1", "value": 0, "rate": 24.0000000001}, "duration": {"OTIO_SCHEMA": "RationalTime.1", "value": 48, "rate": 24}},
+     "metadata": {"cmx_3600": {}, "shot": "010"}}
+  ]}
+]}}`
+
+// reordered is unformatted with the metadata keys in another order.
+const reordered = `{"OTIO_SCHEMA": "Timeline.1", "metadata": {"alpha": {"a": 1, "b": 2}, "zeta": 1}, "name": "cut",
+"tracks": {"OTIO_SCHEMA": "Stack.1", "enabled": true, "name": "tracks", "children": [
+  {"OTIO_SCHEMA": "Track.1", "kind": "Video", "name": "V1", "enabled": true, "children": [
+    {"OTIO_SCHEMA": "Clip.2", "metadata": {"shot": "010"}, "name": "sh010", "enabled": true,
+     "source_range": {"OTIO_SCHEMA": "TimeRange.1", "duration": {"OTIO_SCHEMA": "RationalTime.1", "rate": 24, "value": 48}, "start_time": {"OTIO_SCHEMA": "RationalTime.1", "rate": 24, "value": 0}}}
+  ]}
+]}}`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	return path
+}
+
+func TestFormat(t *testing.T) {
+	dir := t.TempDir()
+	a := writeFile(t, dir, "a.otio", unformatted)
+	b := writeFile(t, dir, "b.otio", reordered)
+
+	first, err := formatFile(a, 0)
+	if err != nil {
+		t.Fatalf("formatFile error: %v", err)
+	}
+	second, err := formatFile(b, 0)
+	if err != nil {
+		t.Fatalf("formatFile error: %v", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Errorf("key order changed the output:\n%s\n---\n%s", first, second)
+	}
+	out := string(first)
+	if strings.Contains(out, "cmx_3600") || strings.Contains(out, "empty") || strings.Contains(out, "24.0000000001") {
+		t.Errorf("defaults not normalized:\n%s", out)
+	}
+	if strings.Index(out, `"alpha"`) > strings.Index(out, `"zeta"`) {
+		t.Errorf("metadata keys not sorted:\n%s", out)
+	}
+
+	formatted := writeFile(t, dir, "formatted.otio", out)
+	again, err := formatFile(formatted, 0)
+	if err != nil || !bytes.Equal(again, first) {
+		t.Errorf("formatting is not idempotent (%v):\n%s", err, again)
+	}
+}
+
+func TestRunModes(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "cut.otio", unformatted)
+
+	var out bytes.Buffer
+	unformattedFound, err := run([]string{path}, 0, modeCheck, &out)
+	if err != nil || !unformattedFound || strings.TrimSpace(out.String()) != path {
+		t.Fatalf("check = %v, %q, %v", unformattedFound, out.String(), err)
+	}
+
+	if _, err := run([]string{path}, 0, modeWrite, &out); err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+	out.Reset()
+	if unformattedFound, err := run([]string{path}, 0, modeCheck, &out); err != nil || unformattedFound || out.Len() != 0 {
+		t.Errorf("check after write = %v, %q, %v", unformattedFound, out.String(), err)
+	}
+
+	out.Reset()
+	if _, err := run([]string{path}, 0, modePrint, &out); err != nil || !strings.HasPrefix(out.String(), "{\n") {
+		t.Errorf("print = %q, %v", out.String(), err)
+	}
+
+	script := writeFile(t, dir, "cut.otioscript", "timeline cut rate 25\ntrack V1\nclip sh010 media /plates/sh010.mov in 0 dur 50\n")
+	if _, err := run([]string{script}, 0, modeCheck, &out); err == nil {
+		t.Error("expected -check to refuse a file that is not .otio")
+	}
+	out.Reset()
+	if _, err := run([]string{script}, 0, modePrint, &out); err != nil || !strings.Contains(out.String(), `"sh010"`) {
+		t.Errorf("print of an otioscript = %v", err)
+	}
+}
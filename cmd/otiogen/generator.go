@@ -14,8 +14,9 @@ import (
 
 // Generator generates encoder/decoder code for OTIO types.
 type Generator struct {
-	encoderTmpl *template.Template
-	decoderTmpl *template.Template
+	encoderTmpl   *template.Template
+	decoderTmpl   *template.Template
+	userCodecTmpl *template.Template
 }
 
 // NewGenerator creates a new code generator.
@@ -39,9 +40,15 @@ func NewGenerator() (*Generator, error) {
 		return nil, fmt.Errorf("parse decoder template: %w", err)
 	}
 
+	userCodecTmpl, err := template.New("usercodec").Funcs(funcMap).Parse(userCodecTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parse user codec template: %w", err)
+	}
+
 	return &Generator{
-		encoderTmpl: encoderTmpl,
-		decoderTmpl: decoderTmpl,
+		encoderTmpl:   encoderTmpl,
+		decoderTmpl:   decoderTmpl,
+		userCodecTmpl: userCodecTmpl,
 	}, nil
 }
 
@@ -12,6 +12,14 @@
 //   - internal/jsonenc/gen_otio.go      - OTIO leaf type encoders
 //   - internal/jsondec/gen_opentime.go  - opentime type decoders
 //   - internal/jsondec/gen_otio.go      - OTIO leaf type decoders
+//
+// To generate codecs for third-party schema types, pass a JSON or YAML
+// schema description (see SchemaDescription) and the file to write:
+//
+//	go run ./cmd/otiogen -schema studio_schemas.json -out studio/otio_codecs.go
+//
+// The generated file belongs in the package that defines the types and
+// registers each type with gotio.RegisterSchemaCodec.
 package main
 
 import (
@@ -23,9 +31,17 @@ import (
 
 func main() {
 	outputDir := flag.String("output", ".", "Output directory (project root)")
+	schemaFile := flag.String("schema", "", "Schema description file for third-party types")
+	outFile := flag.String("out", "", "Output file for third-party codecs (with -schema)")
 	flag.Parse()
 
-	if err := run(*outputDir); err != nil {
+	var err error
+	if *schemaFile != "" {
+		err = runSchema(*schemaFile, *outFile)
+	} else {
+		err = run(*outputDir)
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "otiogen: %v\n", err)
 		os.Exit(1)
 	}
@@ -91,3 +107,31 @@ func run(outputDir string) error {
 	fmt.Println("Done!")
 	return nil
 }
+
+func runSchema(schemaFile, outFile string) error {
+	if outFile == "" {
+		return fmt.Errorf("-out is required with -schema")
+	}
+
+	desc, err := LoadSchemaDescription(schemaFile)
+	if err != nil {
+		return err
+	}
+
+	gen, err := NewGenerator()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Generating codecs for %d types...\n", len(desc.Types))
+	code, err := gen.GenerateUserCodecs(desc)
+	if err != nil {
+		return fmt.Errorf("generate codecs: %w", err)
+	}
+	if err := os.WriteFile(outFile, code, 0644); err != nil {
+		return fmt.Errorf("write codecs: %w", err)
+	}
+
+	fmt.Println("Done!")
+	return nil
+}
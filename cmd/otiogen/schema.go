@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+
+	"github.com/Avalanche-io/gotio/pipeline"
+)
+
+// SchemaDescription describes third-party schema types to generate codecs for.
+//
+// Example:
+//
+//	{
+//	  "package": "studio",
+//	  "types": [{
+//	    "name": "ShotInfo",
+//	    "schema": "ShotInfo",
+//	    "version": 1,
+//	    "fields": [
+//	      {"name": "Name", "json": "name", "type": "string", "getter": "Name", "setter": "SetName"},
+//	      {"name": "shot", "json": "shot", "type": "string"},
+//	      {"name": "cutRange", "json": "cut_range", "type": "*TimeRange"}
+//	    ]
+//	  }]
+//	}
+type SchemaDescription struct {
+	Package string                  `json:"package"`
+	Types   []SchemaTypeDescription `json:"types"`
+}
+
+// SchemaTypeDescription describes one schema type.
+type SchemaTypeDescription struct {
+	Name        string                   `json:"name"`        // Go type name
+	Schema      string                   `json:"schema"`      // OTIO_SCHEMA name, defaults to Name
+	Version     int                      `json:"version"`     // OTIO_SCHEMA version, defaults to 1
+	Constructor string                   `json:"constructor"` // func() *Name, defaults to &Name{}
+	Fields      []SchemaFieldDescription `json:"fields"`
+}
+
+// SchemaFieldDescription describes one field of a schema type.
+// Fields are accessed directly unless a getter and setter are given.
+type SchemaFieldDescription struct {
+	Name   string `json:"name"`   // Go struct field name
+	JSON   string `json:"json"`   // JSON key
+	Type   string `json:"type"`   // one of schemaFieldTypes
+	Getter string `json:"getter"` // optional getter method
+	Setter string `json:"setter"` // optional setter method
+}
+
+// schemaFieldTypes maps supported field types to the SchemaWriter and
+// SchemaFields methods used to encode and decode them.
+var schemaFieldTypes = map[string]struct {
+	write, read string
+	pointer     bool // field is a pointer
+	value       bool // field is a value but read returns a pointer
+	fallible    bool // read returns an error
+}{
+	"string":               {write: "WriteString", read: "String"},
+	"int":                  {write: "WriteInt", read: "Int"},
+	"float64":              {write: "WriteFloat64", read: "Float64"},
+	"bool":                 {write: "WriteBool", read: "Bool"},
+	"RationalTime":         {write: "WriteRationalTime", read: "RationalTime", value: true},
+	"*RationalTime":        {write: "WriteRationalTime", read: "RationalTime", pointer: true},
+	"TimeRange":            {write: "WriteTimeRange", read: "TimeRange", value: true},
+	"*TimeRange":           {write: "WriteTimeRange", read: "TimeRange", pointer: true},
+	"AnyDictionary":        {write: "WriteMetadata", read: "Metadata"},
+	"SerializableObject":   {write: "WriteObject", read: "Object", fallible: true},
+	"[]SerializableObject": {write: "WriteObjects", read: "Objects", fallible: true},
+}
+
+// LoadSchemaDescription reads a schema description file, as YAML if its
+// suffix is .yaml or .yml and as JSON otherwise.
+func LoadSchemaDescription(path string) (*SchemaDescription, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if pipeline.IsYAML(path) {
+		if data, err = pipeline.YAMLToJSON(data); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	}
+	var desc SchemaDescription
+	if err := json.Unmarshal(data, &desc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if err := desc.normalize(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &desc, nil
+}
+
+// normalize fills defaults and validates the description.
+func (d *SchemaDescription) normalize() error {
+	if d.Package == "" {
+		return fmt.Errorf("package is required")
+	}
+	seen := make(map[string]bool)
+	for i := range d.Types {
+		t := &d.Types[i]
+		if t.Name == "" {
+			return fmt.Errorf("type %d: name is required", i)
+		}
+		if seen[t.Name] {
+			return fmt.Errorf("type %s: defined more than once", t.Name)
+		}
+		seen[t.Name] = true
+		if t.Schema == "" {
+			t.Schema = t.Name
+		}
+		if t.Version == 0 {
+			t.Version = 1
+		}
+		for _, f := range t.Fields {
+			if f.Name == "" || f.JSON == "" {
+				return fmt.Errorf("type %s: fields need a name and json key", t.Name)
+			}
+			if _, ok := schemaFieldTypes[f.Type]; !ok {
+				return fmt.Errorf("type %s: field %s has unsupported type %q", t.Name, f.Name, f.Type)
+			}
+			if (f.Getter == "") != (f.Setter == "") {
+				return fmt.Errorf("type %s: field %s needs both a getter and a setter", t.Name, f.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// userCodecType is the template view of a schema type.
+type userCodecType struct {
+	SchemaTypeDescription
+	Encode []string
+	Decode []string
+}
+
+// GenerateUserCodecs generates encoders and decoders for the described types.
+// The generated code belongs in the package that defines the types and
+// registers itself with gotio.RegisterSchemaCodec.
+func (g *Generator) GenerateUserCodecs(desc *SchemaDescription) ([]byte, error) {
+	data := struct {
+		Package string
+		Types   []userCodecType
+	}{Package: desc.Package}
+
+	for _, t := range desc.Types {
+		view := userCodecType{SchemaTypeDescription: t}
+		for _, f := range t.Fields {
+			view.Encode = append(view.Encode, encodeFieldStmt(f))
+			view.Decode = append(view.Decode, decodeFieldStmt(f))
+		}
+		data.Types = append(data.Types, view)
+	}
+
+	var buf bytes.Buffer
+	if err := g.userCodecTmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// encodeFieldStmt returns the statement that writes a field.
+func encodeFieldStmt(f SchemaFieldDescription) string {
+	ft := schemaFieldTypes[f.Type]
+	value := "t." + f.Name
+	if f.Getter != "" {
+		value = "t." + f.Getter + "()"
+	}
+	switch {
+	case ft.value:
+		return fmt.Sprintf("{\nv := %s\nif err := w.%s(%q, &v); err != nil {\nreturn err\n}\n}", value, ft.write, f.JSON)
+	case ft.pointer, f.Type == "AnyDictionary", ft.fallible:
+		return fmt.Sprintf("if err := w.%s(%q, %s); err != nil {\nreturn err\n}", ft.write, f.JSON, value)
+	default:
+		return fmt.Sprintf("w.%s(%q, %s)", ft.write, f.JSON, value)
+	}
+}
+
+// decodeFieldStmt returns the statement that reads a field.
+func decodeFieldStmt(f SchemaFieldDescription) string {
+	ft := schemaFieldTypes[f.Type]
+	assign := func(value string) string {
+		if f.Setter != "" {
+			return fmt.Sprintf("t.%s(%s)", f.Setter, value)
+		}
+		return fmt.Sprintf("t.%s = %s", f.Name, value)
+	}
+	read := fmt.Sprintf("fields.%s(%q)", ft.read, f.JSON)
+	switch {
+	case ft.value:
+		return fmt.Sprintf("if v := %s; v != nil {\n%s\n}", read, assign("*v"))
+	case ft.fallible:
+		return fmt.Sprintf("{\nv, err := %s\nif err != nil {\nreturn nil, err\n}\n%s\n}", read, assign("v"))
+	default:
+		return assign(read)
+	}
+}
+
+const userCodecTemplate = `// Code generated by otiogen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"github.com/Avalanche-io/gotio"
+)
+
+{{range .Types}}
+// encode{{.Name}} writes the fields of a {{.Name}}.
+func encode{{.Name}}(w *gotio.SchemaWriter, obj gotio.SerializableObject) error {
+	t := obj.(*{{.Name}})
+	{{- range .Encode}}
+	{{.}}
+	{{- end}}
+	return nil
+}
+
+// decode{{.Name}} builds a {{.Name}} from its decoded fields.
+func decode{{.Name}}(fields gotio.SchemaFields) (gotio.SerializableObject, error) {
+	{{- if .Constructor}}
+	t := {{.Constructor}}()
+	{{- else}}
+	t := &{{.Name}}{}
+	{{- end}}
+	{{- range .Decode}}
+	{{.}}
+	{{- end}}
+	return t, nil
+}
+
+func init() {
+	gotio.RegisterSchemaCodec(
+		gotio.Schema{Name: "{{.Schema}}", Version: {{.Version}}},
+		(*{{.Name}})(nil),
+		encode{{.Name}},
+		decode{{.Name}},
+	)
+}
+{{end}}
+`
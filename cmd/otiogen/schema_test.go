@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateUserCodecs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schemas.json")
+	desc := `{
+		"package": "studio",
+		"types": [{
+			"name": "ShotInfo",
+			"version": 2,
+			"constructor": "NewShotInfo",
+			"fields": [
+				{"name": "Name", "json": "name", "type": "string", "getter": "Name", "setter": "SetName"},
+				{"name": "take", "json": "take", "type": "int"},
+				{"name": "cut", "json": "cut", "type": "TimeRange"},
+				{"name": "start", "json": "start", "type": "*RationalTime"},
+				{"name": "plate", "json": "plate", "type": "SerializableObject"}
+			]
+		}]
+	}`
+	if err := os.WriteFile(path, []byte(desc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	schemas, err := LoadSchemaDescription(path)
+	if err != nil {
+		t.Fatalf("LoadSchemaDescription error: %v", err)
+	}
+	if schemas.Types[0].Schema != "ShotInfo" {
+		t.Errorf("Schema = %s, want default ShotInfo", schemas.Types[0].Schema)
+	}
+
+	gen, err := NewGenerator()
+	if err != nil {
+		t.Fatalf("NewGenerator error: %v", err)
+	}
+	code, err := gen.GenerateUserCodecs(schemas)
+	if err != nil {
+		t.Fatalf("GenerateUserCodecs error: %v", err)
+	}
+
+	src := string(code)
+	for _, want := range []string{
+		"package studio",
+		`w.WriteString("name", t.Name())`,
+		`t.SetName(fields.String("name"))`,
+		`w.WriteInt("take", t.take)`,
+		`if v := fields.TimeRange("cut"); v != nil {`,
+		`t.start = fields.RationalTime("start")`,
+		"t := NewShotInfo()",
+		`gotio.Schema{Name: "ShotInfo", Version: 2}`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated code missing %q:\n%s", want, src)
+		}
+	}
+}
+
+func TestLoadSchemaDescriptionYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schemas.yaml")
+	desc := `package: studio
+types:
+  - name: ShotInfo
+    version: 2
+    fields:
+      - {name: shot, json: shot, type: string}
+      - {name: cutRange, json: cut_range, type: "*TimeRange"}
+`
+	if err := os.WriteFile(path, []byte(desc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := LoadSchemaDescription(path)
+	if err != nil {
+		t.Fatalf("LoadSchemaDescription error: %v", err)
+	}
+	if typ := schemas.Types[0]; schemas.Package != "studio" || typ.Version != 2 || len(typ.Fields) != 2 || typ.Fields[1].Type != "*TimeRange" {
+		t.Errorf("unexpected description %+v", schemas)
+	}
+
+	if err := os.WriteFile(path, []byte("package: &p studio\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSchemaDescription(path); err == nil {
+		t.Error("expected an error for an anchor")
+	}
+}
+
+func TestLoadSchemaDescriptionErrors(t *testing.T) {
+	cases := map[string]string{
+		"no package":   `{"types": []}`,
+		"bad type":     `{"package": "p", "types": [{"name": "T", "fields": [{"name": "a", "json": "a", "type": "chan int"}]}]}`,
+		"half getter":  `{"package": "p", "types": [{"name": "T", "fields": [{"name": "a", "json": "a", "type": "int", "getter": "A"}]}]}`,
+		"duplicate":    `{"package": "p", "types": [{"name": "T"}, {"name": "T"}]}`,
+		"invalid json": `{`,
+	}
+	for name, desc := range cases {
+		path := filepath.Join(t.TempDir(), "schemas.json")
+		if err := os.WriteFile(path, []byte(desc), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadSchemaDescription(path); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -31,7 +31,7 @@ type Field struct {
 // TypeDef represents an OTIO type to generate encoders/decoders for.
 type TypeDef struct {
 	Name          string  // Go type name (e.g., "RationalTime", "Clip")
-	Package       string  // Package name (e.g., "opentime", "opentimelineio")
+	Package       string  // Package name (e.g., "opentime", "gotio")
 	SchemaName    string  // OTIO_SCHEMA name (e.g., "RationalTime")
 	SchemaVersion int     // OTIO_SCHEMA version
 	Fields        []Field // Fields to encode/decode
@@ -77,11 +77,11 @@ var openTimeTypes = []TypeDef{
 	},
 }
 
-// otioLeafTypes defines the leaf types in the opentimelineio package.
+// otioLeafTypes defines the leaf types in the gotio package.
 var otioLeafTypes = []TypeDef{
 	{
 		Name:          "Marker",
-		Package:       "opentimelineio",
+		Package:       "gotio",
 		SchemaName:    "Marker",
 		SchemaVersion: 2,
 		IsLeaf:        true,
@@ -95,7 +95,7 @@ var otioLeafTypes = []TypeDef{
 	},
 	{
 		Name:          "ExternalReference",
-		Package:       "opentimelineio",
+		Package:       "gotio",
 		SchemaName:    "ExternalReference",
 		SchemaVersion: 1,
 		IsLeaf:        true,
@@ -109,7 +109,7 @@ var otioLeafTypes = []TypeDef{
 	},
 	{
 		Name:          "MissingReference",
-		Package:       "opentimelineio",
+		Package:       "gotio",
 		SchemaName:    "MissingReference",
 		SchemaVersion: 1,
 		IsLeaf:        true,
@@ -122,7 +122,7 @@ var otioLeafTypes = []TypeDef{
 	},
 	{
 		Name:          "GeneratorReference",
-		Package:       "opentimelineio",
+		Package:       "gotio",
 		SchemaName:    "GeneratorReference",
 		SchemaVersion: 1,
 		IsLeaf:        true,
@@ -137,7 +137,7 @@ var otioLeafTypes = []TypeDef{
 	},
 	{
 		Name:          "LinearTimeWarp",
-		Package:       "opentimelineio",
+		Package:       "gotio",
 		SchemaName:    "LinearTimeWarp",
 		SchemaVersion: 1,
 		IsLeaf:        true,
@@ -150,7 +150,7 @@ var otioLeafTypes = []TypeDef{
 	},
 	{
 		Name:          "FreezeFrame",
-		Package:       "opentimelineio",
+		Package:       "gotio",
 		SchemaName:    "FreezeFrame",
 		SchemaVersion: 1,
 		IsLeaf:        true,
@@ -163,7 +163,7 @@ var otioLeafTypes = []TypeDef{
 	},
 	{
 		Name:          "Transition",
-		Package:       "opentimelineio",
+		Package:       "gotio",
 		SchemaName:    "Transition",
 		SchemaVersion: 1,
 		IsLeaf:        true,
@@ -177,7 +177,7 @@ var otioLeafTypes = []TypeDef{
 	},
 	{
 		Name:          "Gap",
-		Package:       "opentimelineio",
+		Package:       "gotio",
 		SchemaName:    "Gap",
 		SchemaVersion: 1,
 		IsLeaf:        true,
@@ -197,7 +197,7 @@ var otioLeafTypes = []TypeDef{
 var otioContainerTypes = []TypeDef{
 	{
 		Name:          "Clip",
-		Package:       "opentimelineio",
+		Package:       "gotio",
 		SchemaName:    "Clip",
 		SchemaVersion: 2,
 		IsLeaf:        false,
@@ -215,7 +215,7 @@ var otioContainerTypes = []TypeDef{
 	},
 	{
 		Name:          "Track",
-		Package:       "opentimelineio",
+		Package:       "gotio",
 		SchemaName:    "Track",
 		SchemaVersion: 1,
 		IsLeaf:        false,
@@ -232,7 +232,7 @@ var otioContainerTypes = []TypeDef{
 	},
 	{
 		Name:          "Stack",
-		Package:       "opentimelineio",
+		Package:       "gotio",
 		SchemaName:    "Stack",
 		SchemaVersion: 1,
 		IsLeaf:        false,
@@ -248,7 +248,7 @@ var otioContainerTypes = []TypeDef{
 	},
 	{
 		Name:          "Timeline",
-		Package:       "opentimelineio",
+		Package:       "gotio",
 		SchemaName:    "Timeline",
 		SchemaVersion: 1,
 		IsLeaf:        false,
@@ -261,7 +261,7 @@ var otioContainerTypes = []TypeDef{
 	},
 	{
 		Name:          "SerializableCollection",
-		Package:       "opentimelineio",
+		Package:       "gotio",
 		SchemaName:    "SerializableCollection",
 		SchemaVersion: 1,
 		IsLeaf:        false,
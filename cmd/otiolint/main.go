@@ -0,0 +1,287 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// otiolint checks .otio timelines with the validate package and exits
+// non-zero when a timeline has issues at or above the failing severity,
+// so deliveries can be gated on timeline QC in CI.
+//
+// Usage:
+//
+//	go run ./cmd/otiolint edit.otio reel2.otio
+//	go run ./cmd/otiolint -config lint.json -format sarif edit.otio > lint.sarif
+//
+// The config file is JSON. Rules are named as in the validate package and
+// set to "off", "on", or a severity that replaces the rule's own:
+//
+//	{
+//	  "min_severity": "warning",
+//	  "fail_on": "error",
+//	  "rules": {
+//	    "missing_media": "off",
+//	    "rate_mismatch": "error",
+//	    "media_exists": "on"
+//	  },
+//	  "record_timecode": true
+//	}
+//
+// The default rules are on and media_exists is off. With record_timecode,
+// or the -timecode flag, each issue gives the record timecode of its
+// object, counted from the timeline's global start time. Output is text,
+// json or sarif. The exit status is 0 when no issue reaches fail_on (default
+// "error"), 1 when one does, and 2 when a file cannot be read.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/validate"
+)
+
+func main() {
+	configPath := flag.String("config", "", "JSON file enabling, disabling and re-ranking rules")
+	format := flag.String("format", "text", "Output format: text, json or sarif")
+	timecode := flag.Bool("timecode", false, "Give the record timecode of each issue's object")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: otiolint [-config file] [-format text|json|sarif] [-timecode] timeline.otio...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var cfg lintConfig
+	if *configPath != "" {
+		var err error
+		if cfg, err = loadConfig(*configPath); err != nil {
+			fmt.Fprintf(os.Stderr, "otiolint: %v\n", err)
+			os.Exit(2)
+		}
+	}
+	cfg.RecordTimecode = cfg.RecordTimecode || *timecode
+	failed, err := lint(flag.Args(), cfg, *format, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "otiolint: %v\n", err)
+		os.Exit(2)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// lintConfig is the config file layout.
+type lintConfig struct {
+	MinSeverity string            `json:"min_severity"`
+	FailOn      string            `json:"fail_on"`
+	Rules       map[string]string `json:"rules"`
+	// RecordTimecode adds the record timecode of each issue's object.
+	RecordTimecode bool `json:"record_timecode"`
+}
+
+func loadConfig(path string) (lintConfig, error) {
+	var cfg lintConfig
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// result is one issue found in a file.
+type result struct {
+	File     string `json:"file"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Object   string `json:"object,omitempty"`
+	Record   string `json:"record,omitempty"`
+	Fixable  bool   `json:"fixable"`
+
+	severity validate.Severity
+}
+
+// lint checks each file and writes the issues found in format. It reports
+// whether any issue reached the failing severity.
+func lint(files []string, cfg lintConfig, format string, w io.Writer) (bool, error) {
+	rules, err := configuredRules(cfg.Rules)
+	if err != nil {
+		return false, err
+	}
+	minSeverity, err := parseSeverity(cfg.MinSeverity, validate.SeverityInfo)
+	if err != nil {
+		return false, fmt.Errorf("min_severity: %w", err)
+	}
+	failOn, err := parseSeverity(cfg.FailOn, validate.SeverityError)
+	if err != nil {
+		return false, fmt.Errorf("fail_on: %w", err)
+	}
+
+	var results []result
+	failed := false
+	for _, path := range files {
+		timeline, err := readTimeline(path)
+		if err != nil {
+			return false, err
+		}
+		issues := validate.Validate(timeline, validate.WithRules(rules...), validate.WithMinSeverity(minSeverity))
+		for _, issue := range issues {
+			r := result{
+				File:     path,
+				Rule:     issue.Rule,
+				Severity: issue.Severity.String(),
+				Message:  issue.Message,
+				Object:   objectPath(issue.Object),
+				Fixable:  issue.Fixable(),
+				severity: issue.Severity,
+			}
+			if cfg.RecordTimecode && issue.Object != nil {
+				if tc, err := timeline.RecordTimecodeRange(issue.Object, 0, opentime.InferFromRate); err == nil {
+					r.Record = tc.String()
+				}
+			}
+			results = append(results, r)
+			failed = failed || issue.Severity >= failOn
+		}
+	}
+
+	switch format {
+	case "text":
+		err = writeText(w, results)
+	case "json":
+		err = writeJSON(w, results)
+	case "sarif":
+		err = writeSARIF(w, results, rules)
+	default:
+		err = fmt.Errorf("unknown format %q", format)
+	}
+	return failed, err
+}
+
+func parseSeverity(s string, fallback validate.Severity) (validate.Severity, error) {
+	if s == "" {
+		return fallback, nil
+	}
+	return validate.ParseSeverity(s)
+}
+
+// configuredRules returns the rules turned on by settings, which map rule
+// names to "off", "on" or a severity.
+func configuredRules(settings map[string]string) ([]validate.Rule, error) {
+	type entry struct {
+		rule validate.Rule
+		on   bool
+	}
+	var entries []*entry
+	byName := make(map[string]*entry)
+	add := func(rule validate.Rule, on bool) {
+		e := &entry{rule, on}
+		entries = append(entries, e)
+		byName[rule.Name()] = e
+	}
+	for _, rule := range validate.DefaultRules() {
+		add(rule, true)
+	}
+	add(validate.MediaExistsRule(nil), false)
+
+	for name, setting := range settings {
+		e, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+		switch setting {
+		case "off":
+			e.on = false
+		case "on":
+			e.on = true
+		default:
+			severity, err := validate.ParseSeverity(setting)
+			if err != nil {
+				return nil, fmt.Errorf("rule %s: %w", name, err)
+			}
+			e.rule, e.on = withSeverity(e.rule, severity), true
+		}
+	}
+
+	var rules []validate.Rule
+	for _, e := range entries {
+		if e.on {
+			rules = append(rules, e.rule)
+		}
+	}
+	return rules, nil
+}
+
+// withSeverity returns rule with the severity of its issues replaced.
+func withSeverity(rule validate.Rule, severity validate.Severity) validate.Rule {
+	return validate.NewRule(rule.Name(), func(composition gotio.Composition) []*validate.Issue {
+		issues := rule.Check(composition)
+		for _, issue := range issues {
+			issue.Severity = severity
+		}
+		return issues
+	})
+}
+
+func readTimeline(path string) (*gotio.Timeline, error) {
+	obj, err := gotio.FromJSONFile(path)
+	if err != nil {
+		return nil, err
+	}
+	timeline, ok := obj.(*gotio.Timeline)
+	if !ok {
+		return nil, fmt.Errorf("%s holds a %s, not a Timeline", path, obj.SchemaName())
+	}
+	return timeline, nil
+}
+
+// objectPath names an object by the names of the compositions holding it,
+// such as "V1/shot_010". The timeline's top-level stack is left out.
+func objectPath(obj gotio.Composable) string {
+	if obj == nil {
+		return ""
+	}
+	var names []string
+	for c := obj; c != nil && c.Parent() != nil; c = c.Parent() {
+		name := c.Name()
+		if name == "" {
+			name = c.SchemaName()
+		}
+		names = append([]string{name}, names...)
+	}
+	return strings.Join(names, "/")
+}
+
+func writeText(w io.Writer, results []result) error {
+	for _, r := range results {
+		object := ""
+		if r.Object != "" && r.Record != "" {
+			object = " " + r.Object + " (" + r.Record + "):"
+		} else if r.Object != "" {
+			object = " " + r.Object + ":"
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s [%s]%s %s\n", r.File, r.Severity, r.Rule, object, r.Message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, results []result) error {
+	if results == nil {
+		results = []result{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// writeTimeline writes a timeline starting at 01:00:00:00 with one clip
+// missing its media.
+func writeTimeline(t *testing.T) string {
+	t.Helper()
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(24, 24))
+	clip := gotio.NewClip("sh010", gotio.NewMissingReference("", nil, nil), &sr, nil, nil, nil, "", nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	track.AppendChild(clip)
+	hour := opentime.NewRationalTime(86400, 24)
+	timeline := gotio.NewTimeline("cut", &hour, nil)
+	timeline.Tracks().AppendChild(track)
+
+	path := filepath.Join(t.TempDir(), "cut.otio")
+	if err := gotio.ToJSONFile(timeline, path, "  "); err != nil {
+		t.Fatalf("ToJSONFile error: %v", err)
+	}
+	return path
+}
+
+func TestLint(t *testing.T) {
+	path := writeTimeline(t)
+
+	var out bytes.Buffer
+	failed, err := lint([]string{path}, lintConfig{}, "text", &out)
+	if err != nil {
+		t.Fatalf("lint error: %v", err)
+	}
+	if failed {
+		t.Error("a warning should not fail by default")
+	}
+	if want := "warning [missing_media] V1/sh010: missing media reference"; !strings.Contains(out.String(), want) {
+		t.Errorf("output missing %q:\n%s", want, out.String())
+	}
+
+	out.Reset()
+	if _, err := lint([]string{path}, lintConfig{RecordTimecode: true}, "text", &out); err != nil {
+		t.Fatalf("lint error: %v", err)
+	}
+	if want := "V1/sh010 (01:00:00:00 - 01:00:01:00):"; !strings.Contains(out.String(), want) {
+		t.Errorf("output missing %q:\n%s", want, out.String())
+	}
+
+	out.Reset()
+	cfg := lintConfig{Rules: map[string]string{"missing_media": "error"}}
+	failed, err = lint([]string{path}, cfg, "json", &out)
+	if err != nil {
+		t.Fatalf("lint error: %v", err)
+	}
+	var results []result
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if !failed || len(results) != 1 || results[0].Severity != "error" {
+		t.Errorf("expected one failing error, got %v (failed %v)", results, failed)
+	}
+
+	out.Reset()
+	cfg = lintConfig{Rules: map[string]string{"missing_media": "off"}}
+	if _, err := lint([]string{path}, cfg, "text", &out); err != nil || out.Len() != 0 {
+		t.Errorf("expected no output with missing_media off, got %q (%v)", out.String(), err)
+	}
+
+	out.Reset()
+	cfg = lintConfig{FailOn: "warning"}
+	failed, err = lint([]string{path}, cfg, "sarif", &out)
+	if err != nil {
+		t.Fatalf("lint error: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF: %v", err)
+	}
+	if !failed || log.Version != "2.1.0" || len(log.Runs[0].Results) != 1 {
+		t.Fatalf("unexpected SARIF:\n%s", out.String())
+	}
+	if r := log.Runs[0].Results[0]; r.RuleID != "missing_media" || r.Level != "warning" ||
+		r.Locations[0].PhysicalLocation.ArtifactLocation.URI != path {
+		t.Errorf("unexpected SARIF result %+v", r)
+	}
+}
+
+func TestLintConfigErrors(t *testing.T) {
+	path := writeTimeline(t)
+	for _, cfg := range []lintConfig{
+		{Rules: map[string]string{"no_such_rule": "on"}},
+		{Rules: map[string]string{"duration": "fatal"}},
+		{FailOn: "fatal"},
+	} {
+		if _, err := lint([]string{path}, cfg, "text", &bytes.Buffer{}); err == nil {
+			t.Errorf("expected an error for %+v", cfg)
+		}
+	}
+	if _, err := lint([]string{path}, lintConfig{}, "xml", &bytes.Buffer{}); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+
+	config := filepath.Join(t.TempDir(), "lint.json")
+	os.WriteFile(config, []byte(`{"fail_on": "warning", "rules": {"media_exists": "on"}}`), 0644)
+	cfg, err := loadConfig(config)
+	if err != nil || cfg.FailOn != "warning" || cfg.Rules["media_exists"] != "on" {
+		t.Errorf("loadConfig = %+v, %v", cfg, err)
+	}
+}
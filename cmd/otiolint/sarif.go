@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/Avalanche-io/gotio/validate"
+)
+
+// The subset of SARIF 2.1.0 that CI systems read for code scanning.
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+	} `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// sarifLevels maps severities to SARIF result levels.
+var sarifLevels = map[validate.Severity]string{
+	validate.SeverityInfo:    "note",
+	validate.SeverityWarning: "warning",
+	validate.SeverityError:   "error",
+}
+
+func writeSARIF(w io.Writer, results []result, rules []validate.Rule) error {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: "otiolint", Rules: []sarifRule{}}},
+		Results: []sarifResult{},
+	}
+	for _, rule := range rules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: rule.Name()})
+	}
+	for _, r := range results {
+		var location sarifLocation
+		location.PhysicalLocation.ArtifactLocation.URI = r.File
+		if r.Object != "" {
+			location.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: r.Object}}
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    r.Rule,
+			Level:     sarifLevels[r.severity],
+			Message:   sarifMessage{Text: r.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// otiopluginfo prints the schemas, adapters with their options, features
+// and track kinds of this gotio build.
+//
+// Usage:
+//
+//	go run ./cmd/otiopluginfo
+//	go run ./cmd/otiopluginfo -json
+//
+// Pipeline tools can run it to check what a build supports before
+// dispatching work to it. Programs embedding gotio can call
+// gotio.Capabilities directly instead.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Avalanche-io/gotio"
+	_ "github.com/Avalanche-io/gotio/adapters/ale"
+	_ "github.com/Avalanche-io/gotio/adapters/otioscript"
+	_ "github.com/Avalanche-io/gotio/adapters/reviewnotes"
+	_ "github.com/Avalanche-io/gotio/adapters/shotlist"
+	_ "github.com/Avalanche-io/gotio/adapters/subtitles"
+	_ "github.com/Avalanche-io/gotio/adapters/xmeml"
+	_ "github.com/Avalanche-io/gotio/algorithms"
+	_ "github.com/Avalanche-io/gotio/assets"
+	_ "github.com/Avalanche-io/gotio/autosave"
+	_ "github.com/Avalanche-io/gotio/bundle"
+	_ "github.com/Avalanche-io/gotio/burnin"
+	_ "github.com/Avalanche-io/gotio/debug"
+	_ "github.com/Avalanche-io/gotio/edit"
+	_ "github.com/Avalanche-io/gotio/estimate"
+	_ "github.com/Avalanche-io/gotio/mediainfo"
+	_ "github.com/Avalanche-io/gotio/medialinker"
+	_ "github.com/Avalanche-io/gotio/mediaresolver"
+	_ "github.com/Avalanche-io/gotio/patch"
+	_ "github.com/Avalanche-io/gotio/pipeline"
+	_ "github.com/Avalanche-io/gotio/qc"
+	_ "github.com/Avalanche-io/gotio/render"
+	_ "github.com/Avalanche-io/gotio/reports"
+	_ "github.com/Avalanche-io/gotio/stats"
+	_ "github.com/Avalanche-io/gotio/validate"
+	_ "github.com/Avalanche-io/gotio/viz"
+)
+
+func main() {
+	asJSON := flag.Bool("json", false, "Print the report as JSON")
+	flag.Parse()
+
+	if err := printReport(os.Stdout, gotio.Capabilities(), *asJSON); err != nil {
+		fmt.Fprintf(os.Stderr, "otiopluginfo: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func printReport(w io.Writer, report gotio.CapabilityReport, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "Schemas:")
+	for _, schema := range report.Schemas {
+		fmt.Fprintf(tw, "  %s\n", schema)
+	}
+	fmt.Fprintln(tw, "\nSchema aliases:")
+	for _, alias := range slices.Sorted(maps.Keys(report.SchemaAliases)) {
+		fmt.Fprintf(tw, "  %s\t-> %s\n", alias, report.SchemaAliases[alias])
+	}
+	fmt.Fprintln(tw, "\nAdapters:")
+	for _, adapter := range report.Adapters {
+		var modes []string
+		if adapter.CanRead {
+			modes = append(modes, "read")
+		}
+		if adapter.CanWrite {
+			modes = append(modes, "write")
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", adapter.Name, strings.Join(adapter.Suffixes, " "), strings.Join(modes, ", "))
+		for _, opt := range adapter.Options {
+			fmt.Fprintf(tw, "    %s.%s\t%s\t%s\n", adapter.Name, opt.Name, opt.Type, opt.Description)
+		}
+	}
+	fmt.Fprintln(tw, "\nFeatures:")
+	for _, feature := range report.Features {
+		fmt.Fprintf(tw, "  %s\n", feature)
+	}
+	fmt.Fprintln(tw, "\nTrack kinds:")
+	for _, kind := range report.TrackKinds {
+		fmt.Fprintf(tw, "  %s\n", kind)
+	}
+	return tw.Flush()
+}
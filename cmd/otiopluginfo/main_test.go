@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+func TestPrintReport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printReport(&buf, gotio.Capabilities(), false); err != nil {
+		t.Fatalf("printReport error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Clip.2", "Sequence", "shotlist", ".srt .vtt", "read, write", "bundle", "algorithms"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := printReport(&buf, gotio.Capabilities(), true); err != nil {
+		t.Fatalf("printReport error: %v", err)
+	}
+	var report gotio.CapabilityReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(report.Adapters) != 5 {
+		t.Errorf("expected 5 adapters, got %d", len(report.Adapters))
+	}
+}
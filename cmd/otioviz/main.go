@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// otioviz draws a timeline as an SVG picture or a self-contained HTML page,
+// with a lane per track, clips as named blocks, and transitions and markers
+// drawn over them. Inputs are read by suffix as by otioconvert.
+//
+// Usage:
+//
+//	go run ./cmd/otioviz edit.otio > edit.svg
+//	go run ./cmd/otioviz -o review.html edit.xml
+//	go run ./cmd/otioviz -html -scale 20 edit.edl > edit.html
+//
+// The drawing is SVG unless -html is given or the -o file ends in .html.
+// Timelines are fitted to -width pixels unless -scale sets the pixels per
+// second.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/adapters"
+	"github.com/Avalanche-io/gotio/pipeline"
+	"github.com/Avalanche-io/gotio/viz"
+)
+
+func main() {
+	rate := flag.Float64("rate", 0, "Frame rate for formats that need one")
+	asHTML := flag.Bool("html", false, "Write an HTML page instead of SVG")
+	output := flag.String("o", "", "Output file (default standard output)")
+	width := flag.Int("width", viz.DefaultWidth, "Width in pixels to fit the timeline to")
+	scale := flag.Float64("scale", 0, "Pixels per second, overriding -width")
+	laneHeight := flag.Int("lane", viz.DefaultLaneHeight, "Height in pixels of a track lane")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: otioviz [-rate R] [-html] [-o out] [-width px | -scale px] file")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if _, err := adapters.DiscoverExternal(); err != nil {
+		fmt.Fprintf(os.Stderr, "otioviz: %v\n", err)
+	}
+	if strings.EqualFold(filepath.Ext(*output), ".html") {
+		*asHTML = true
+	}
+	var buf bytes.Buffer
+	err := run(flag.Arg(0), *rate, *asHTML, &buf,
+		viz.WithWidth(*width), viz.WithScale(*scale), viz.WithLaneHeight(*laneHeight))
+	if err == nil {
+		if *output == "" {
+			_, err = buf.WriteTo(os.Stdout)
+		} else {
+			err = os.WriteFile(*output, buf.Bytes(), 0644)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "otioviz: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run reads the timeline at path and draws it to w as HTML or SVG.
+func run(path string, rate float64, asHTML bool, w io.Writer, opts ...viz.Option) error {
+	timeline, err := pipeline.ReadFile(path, rate)
+	if err != nil {
+		return err
+	}
+	if asHTML {
+		return viz.WriteHTML(w, timeline, opts...)
+	}
+	return viz.WriteSVG(w, timeline, opts...)
+}
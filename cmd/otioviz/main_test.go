@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const edit = `{"OTIO_SCHEMA": "Timeline.1", "name": "cut",
+"tracks": {"OTIO_SCHEMA": "Stack.1", "name": "tracks", "children": [
+  {"OTIO_SCHEMA": "Track.1", "name": "V1", "kind": "Video", "children": [
+    {"OTIO_SCHEMA": "Clip.2", "name": "sh010",
+     "source_range": {"OTIO_SCHEMA": "TimeRange.1", "start_time": {"OTIO_SCHEMA": "RationalTime.1", "value": 0, "rate": 24}, "duration": {"OTIO_SCHEMA": "RationalTime.1", "value": 48, "rate": 24}}}
+  ]}
+]}}`
+
+func TestRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cut.otio")
+	if err := os.WriteFile(path, []byte(edit), 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+
+	var svg, page bytes.Buffer
+	if err := run(path, 0, false, &svg); err != nil {
+		t.Fatalf("run error: %v", err)
+	}
+	if !strings.HasPrefix(svg.String(), "<svg") || !strings.Contains(svg.String(), ">sh010<") {
+		t.Errorf("unexpected SVG:\n%s", svg.String())
+	}
+	if err := run(path, 0, true, &page); err != nil {
+		t.Fatalf("run error: %v", err)
+	}
+	if !strings.HasPrefix(page.String(), "<!DOCTYPE html>") {
+		t.Errorf("unexpected page:\n%s", page.String())
+	}
+
+	if err := run(filepath.Join(t.TempDir(), "missing.otio"), 0, false, &svg); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// otiowatch watches a folder for timeline files and conforms each one as
+// it arrives: it converts the file to OTIO, validates it, relinks its
+// media, optionally bundles it, and writes the results and a status JSON
+// file to an output folder.
+//
+// Usage:
+//
+//	go run ./cmd/otiowatch -config watch.json
+//	go run ./cmd/otiowatch -config watch.json -once
+//
+// The config file is JSON, or YAML if its suffix is .yaml or .yml:
+//
+//	{
+//	  "watch": "/incoming",
+//	  "output": "/conformed",
+//	  "interval": "5s",
+//	  "rate": 24,
+//	  "fail_on": "error",
+//	  "search_paths": ["/media/plates"],
+//	  "extensions": [".mov", ".exr"],
+//	  "bundle": "otioz",
+//	  "retries": 3,
+//	  "retry_delay": "10s"
+//	}
+//
+// Files with the suffixes otioconvert reads are picked up once their size
+// and modification time stop changing between two polls. For an input
+// named cut.xml the output folder gets cut.otio, cut.otioz when bundling,
+// and cut.status.json. A file is processed again only when it changes.
+// Validation failures at or above fail_on ("off" never fails) are final;
+// other failures are retried. With -once, the files present are processed
+// and the command exits, with status 1 if any failed.
+//
+// A "steps" list replaces the pipeline built from rate, fail_on,
+// search_paths, extensions and bundle with the steps of the pipeline
+// package, in the layout of pipeline.Config. Output paths are named after
+// each input with "{name}":
+//
+//	"steps": [
+//	  {"step": "read", "rate": 24},
+//	  {"step": "validate", "fail_on": "error", "fix": true},
+//	  {"step": "conform_rate", "rate": 25, "policy": "nearest_frame"},
+//	  {"step": "handles", "frames": 8},
+//	  {"step": "write", "path": "/conformed/{name}.otio"}
+//	]
+//
+// In YAML:
+//
+//	watch: /incoming
+//	output: /conformed
+//	interval: 5s
+//	steps:
+//	  - step: read
+//	    rate: 24
+//	  - step: write
+//	    path: /conformed/{name}.otio
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Avalanche-io/gotio/pipeline"
+	"github.com/Avalanche-io/gotio/validate"
+)
+
+func main() {
+	configPath := flag.String("config", "", "JSON or YAML config file")
+	once := flag.Bool("once", false, "Process the files present and exit")
+	flag.Parse()
+	if *configPath == "" || flag.NArg() != 0 {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: otiowatch -config file [-once]")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		logger.Error("cannot load config", "path", *configPath, "error", err)
+		os.Exit(2)
+	}
+	w := newWatcher(cfg, logger)
+
+	if *once {
+		if failed := w.scan(false); failed > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	logger.Info("watching", "folder", cfg.Watch, "output", cfg.Output, "interval", time.Duration(cfg.Interval))
+	ticker := time.NewTicker(time.Duration(cfg.Interval))
+	defer ticker.Stop()
+	for {
+		w.scan(true)
+		select {
+		case <-ctx.Done():
+			logger.Info("stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchConfig is the config file layout.
+type watchConfig struct {
+	Watch       string   `json:"watch"`
+	Output      string   `json:"output"`
+	Interval    duration `json:"interval"`
+	Rate        float64  `json:"rate"`
+	FailOn      string   `json:"fail_on"`
+	SearchPaths []string `json:"search_paths"`
+	Extensions  []string `json:"extensions"`
+	Bundle      string   `json:"bundle"`
+	Retries     int      `json:"retries"`
+	RetryDelay  duration `json:"retry_delay"`
+	// Steps, if set, replace the pipeline built from the settings above.
+	Steps []json.RawMessage `json:"steps"`
+}
+
+// duration is a time.Duration written as a string such as "5s".
+type duration time.Duration
+
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = duration(v)
+	return nil
+}
+
+func loadConfig(path string) (watchConfig, error) {
+	cfg := watchConfig{
+		Interval:   duration(2 * time.Second),
+		FailOn:     "error",
+		Retries:    3,
+		RetryDelay: duration(5 * time.Second),
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if pipeline.IsYAML(path) {
+		if data, err = pipeline.YAMLToJSON(data); err != nil {
+			return cfg, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	if cfg.Watch == "" || cfg.Output == "" {
+		return cfg, fmt.Errorf("%s: watch and output folders are required", path)
+	}
+	switch cfg.Bundle {
+	case "", "otioz", "otiod":
+	default:
+		return cfg, fmt.Errorf("%s: bundle must be otioz or otiod, not %q", path, cfg.Bundle)
+	}
+	if cfg.FailOn != "off" {
+		if _, err := validate.ParseSeverity(cfg.FailOn); err != nil {
+			return cfg, fmt.Errorf("%s: fail_on: %w", path, err)
+		}
+	}
+	if cfg.Interval <= 0 {
+		return cfg, fmt.Errorf("%s: interval must be positive", path)
+	}
+	if _, err := (pipeline.Config{Steps: cfg.Steps}).Build(); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func newTestWatcher(t *testing.T) *watcher {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := watchConfig{
+		Watch:       filepath.Join(dir, "in"),
+		Output:      filepath.Join(dir, "out"),
+		FailOn:      "error",
+		SearchPaths: []string{filepath.Join(dir, "media")},
+		Extensions:  []string{".mov"},
+		Retries:     1,
+	}
+	for _, d := range []string{cfg.Watch, cfg.SearchPaths[0]} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.WriteFile(filepath.Join(cfg.SearchPaths[0], "sh010.mov"), []byte("media"), 0644)
+	return newWatcher(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func readStatus(t *testing.T, w *watcher, input string) status {
+	t.Helper()
+	data, err := os.ReadFile(w.output(input, ".status.json"))
+	if err != nil {
+		t.Fatalf("no status for %s: %v", input, err)
+	}
+	var st status
+	if err := json.Unmarshal(data, &st); err != nil {
+		t.Fatalf("invalid status: %v", err)
+	}
+	return st
+}
+
+func TestScan(t *testing.T) {
+	w := newTestWatcher(t)
+	script := filepath.Join(w.cfg.Watch, "cut.otioscript")
+	os.WriteFile(script, []byte("timeline cut rate 24\ntrack V1\nclip sh010 media /plates/sh010.mov in 0 dur 48\n"), 0644)
+	broken := filepath.Join(w.cfg.Watch, "broken.otio")
+	os.WriteFile(broken, []byte("{not json"), 0644)
+	os.WriteFile(filepath.Join(w.cfg.Watch, "notes.txt"), []byte("ignored"), 0644)
+
+	if failed := w.scan(true); failed != 0 {
+		t.Fatalf("first stable scan processed files, %d failed", failed)
+	}
+	if _, err := os.Stat(w.cfg.Output); !os.IsNotExist(err) {
+		t.Fatal("files should wait for a second poll")
+	}
+	if failed := w.scan(true); failed != 1 {
+		t.Errorf("expected 1 failed file, got %d", failed)
+	}
+
+	st := readStatus(t, w, script)
+	if st.State != stateDone || st.Attempts != 1 || st.Relinked != 1 || len(st.Outputs) != 1 {
+		t.Errorf("unexpected status %+v", st)
+	}
+	obj, err := gotio.FromJSONFile(st.Outputs[0])
+	if err != nil {
+		t.Fatalf("output is not OTIO: %v", err)
+	}
+	clip := obj.(*gotio.Timeline).FindClips(nil, false)[0]
+	if ref := clip.MediaReference().(*gotio.ExternalReference); !strings.HasSuffix(ref.TargetURL(), filepath.Join("media", "sh010.mov")) {
+		t.Errorf("clip not relinked: %s", ref.TargetURL())
+	}
+
+	st = readStatus(t, w, broken)
+	if st.State != stateFailed || st.Attempts != 2 || st.Error == "" {
+		t.Errorf("expected a failure after one retry, got %+v", st)
+	}
+	if _, err := os.Stat(w.output(broken, ".otio")); !os.IsNotExist(err) {
+		t.Error("a failed file should have no outputs")
+	}
+
+	if failed := w.scan(true); failed != 0 {
+		t.Errorf("processed files should be skipped, %d failed", failed)
+	}
+}
+
+func TestScanValidationFailure(t *testing.T) {
+	w := newTestWatcher(t)
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(-24, 24))
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	track.AppendChild(gotio.NewClip("sh010", nil, &sr, nil, nil, nil, "", nil))
+	timeline := gotio.NewTimeline("cut", nil, nil)
+	timeline.Tracks().AppendChild(track)
+	input := filepath.Join(w.cfg.Watch, "cut.otio")
+	if err := gotio.ToJSONFile(timeline, input, "  "); err != nil {
+		t.Fatal(err)
+	}
+
+	if failed := w.scan(false); failed != 1 {
+		t.Fatalf("expected the invalid timeline to fail, %d failed", failed)
+	}
+	st := readStatus(t, w, input)
+	if st.Attempts != 1 || len(st.Issues) == 0 || !strings.Contains(st.Error, "validation") {
+		t.Errorf("validation failures should not be retried, got %+v", st)
+	}
+
+	w.cfg.FailOn = "off"
+	os.Remove(w.output(input, ".status.json"))
+	if failed := w.scan(false); failed != 0 {
+		t.Errorf("fail_on off should pass, %d failed", failed)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "watch.json")
+	os.WriteFile(path, []byte(`{"watch": "in", "output": "out", "interval": "1m", "bundle": "otioz"}`), 0644)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig error: %v", err)
+	}
+	if time.Duration(cfg.Interval) != time.Minute || cfg.Retries != 3 || cfg.FailOn != "error" {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+
+	for _, bad := range []string{
+		`{"watch": "in"}`,
+		`{"watch": "in", "output": "out", "bundle": "zip"}`,
+		`{"watch": "in", "output": "out", "fail_on": "fatal"}`,
+		`{"watch": "in", "output": "out", "interval": "soon"}`,
+		`{"watch": "in", "output": "out", "steps": [{"step": "transcode"}]}`,
+	} {
+		os.WriteFile(path, []byte(bad), 0644)
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("expected an error for %s", bad)
+		}
+	}
+
+	path = filepath.Join(dir, "watch.yaml")
+	os.WriteFile(path, []byte("watch: in\noutput: out\nretries: 1\nsteps:\n  - step: read\n    rate: 24\n"), 0644)
+	cfg, err = loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig YAML error: %v", err)
+	}
+	if cfg.Watch != "in" || cfg.Retries != 1 || len(cfg.Steps) != 1 {
+		t.Errorf("unexpected YAML config %+v", cfg)
+	}
+}
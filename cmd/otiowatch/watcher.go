@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Avalanche-io/gotio/pipeline"
+)
+
+// status is written next to the outputs of each processed file.
+type status struct {
+	Input    string    `json:"input"`
+	State    string    `json:"state"`
+	Attempts int       `json:"attempts"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	Outputs  []string  `json:"outputs,omitempty"`
+	Issues   []string  `json:"issues,omitempty"`
+	Relinked int       `json:"relinked"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Status states.
+const (
+	stateDone   = "done"
+	stateFailed = "failed"
+)
+
+// fileState is the size and modification time of a file at a poll.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+type watcher struct {
+	cfg    watchConfig
+	logger *slog.Logger
+	// seen holds the state of each input at the previous poll, so a file
+	// is only processed once it has stopped changing.
+	seen map[string]fileState
+}
+
+func newWatcher(cfg watchConfig, logger *slog.Logger) *watcher {
+	return &watcher{cfg: cfg, logger: logger, seen: make(map[string]fileState)}
+}
+
+// scan processes the inputs that are new or changed since they were last
+// processed. If stable is set, an input is only processed once it is
+// unchanged since the previous scan. It returns the number of inputs that
+// failed.
+func (w *watcher) scan(stable bool) int {
+	entries, err := os.ReadDir(w.cfg.Watch)
+	if err != nil {
+		w.logger.Error("cannot read watch folder", "folder", w.cfg.Watch, "error", err)
+		return 0
+	}
+
+	failed := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !readable(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(w.cfg.Watch, entry.Name())
+		current := fileState{info.Size(), info.ModTime()}
+		previous, ok := w.seen[path]
+		w.seen[path] = current
+		if stable && (!ok || previous != current) {
+			continue
+		}
+		if w.processed(path, current.modTime) {
+			continue
+		}
+		if st := w.process(path); st.State == stateFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// processed reports whether path has a status file written after it was
+// last modified.
+func (w *watcher) processed(path string, modTime time.Time) bool {
+	data, err := os.ReadFile(w.output(path, ".status.json"))
+	if err != nil {
+		return false
+	}
+	var st status
+	return json.Unmarshal(data, &st) == nil && st.Finished.After(modTime)
+}
+
+// process runs the pipeline on path, retrying failures other than
+// validation, and writes its status file.
+func (w *watcher) process(path string) status {
+	st := status{Input: path, Started: time.Now()}
+	logger := w.logger.With("input", path)
+	logger.Info("processing")
+
+	var err error
+	for st.Attempts = 1; ; st.Attempts++ {
+		st.Outputs, st.Issues, st.Relinked = nil, nil, 0
+		err = w.conform(path, &st)
+		if err == nil || errors.Is(err, pipeline.ErrInvalid) || st.Attempts > w.cfg.Retries {
+			break
+		}
+		logger.Warn("attempt failed, retrying", "attempt", st.Attempts, "error", err)
+		time.Sleep(time.Duration(w.cfg.RetryDelay))
+	}
+
+	st.State, st.Finished = stateDone, time.Now()
+	if err != nil {
+		st.State, st.Error = stateFailed, err.Error()
+		logger.Error("failed", "attempts", st.Attempts, "error", err)
+	} else {
+		logger.Info("done", "outputs", st.Outputs, "issues", len(st.Issues), "relinked", st.Relinked)
+	}
+	if err := w.writeStatus(path, st); err != nil {
+		logger.Error("cannot write status", "error", err)
+	}
+	return st
+}
+
+// conform runs the pipeline on one input.
+func (w *watcher) conform(path string, st *status) error {
+	p, err := w.pipeline()
+	if err != nil {
+		return err
+	}
+	state, err := p.Run(context.Background(), path)
+	for _, issue := range state.Issues {
+		st.Issues = append(st.Issues, issue.String())
+	}
+	st.Relinked, st.Outputs = state.Relinked, state.Outputs
+	return err
+}
+
+// pipeline returns the steps of the config, or if it has none the steps
+// its settings describe: read, validate, relink if there are search paths,
+// write and bundle if set.
+func (w *watcher) pipeline() (*pipeline.Pipeline, error) {
+	if len(w.cfg.Steps) > 0 {
+		return pipeline.Config{Steps: w.cfg.Steps}.Build()
+	}
+	p := pipeline.New(
+		&pipeline.Read{Rate: w.cfg.Rate},
+		&pipeline.Validate{FailOn: w.cfg.FailOn},
+	)
+	if len(w.cfg.SearchPaths) > 0 {
+		p.Then(&pipeline.Relink{SearchPaths: w.cfg.SearchPaths, Extensions: w.cfg.Extensions})
+	}
+	p.Then(&pipeline.Write{Path: filepath.Join(w.cfg.Output, "{name}.otio")})
+	if w.cfg.Bundle != "" {
+		p.Then(&pipeline.Bundle{Path: filepath.Join(w.cfg.Output, "{name}."+w.cfg.Bundle)})
+	}
+	return p, nil
+}
+
+func (w *watcher) writeStatus(path string, st status) error {
+	if err := os.MkdirAll(w.cfg.Output, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(w.output(path, ".status.json"), append(data, '\n'), 0644)
+}
+
+// output returns the path in the output folder for input with its suffix
+// replaced by suffix.
+func (w *watcher) output(input, suffix string) string {
+	base := filepath.Base(input)
+	return filepath.Join(w.cfg.Output, strings.TrimSuffix(base, filepath.Ext(base))+suffix)
+}
+
+// readable reports whether the file has a suffix the pipeline reads.
+func readable(path string) bool {
+	return pipeline.Readable(path)
+}
@@ -0,0 +1,447 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// pane is what the right side of the screen shows about the selection.
+type pane int
+
+const (
+	paneTiming pane = iota
+	paneMetadata
+)
+
+// helpLine lists the keys on the status line.
+const helpLine = "↑↓ move  → open  ← close  tab timing/metadata  / search  n/N next/prev  q quit"
+
+// row is a line of the tree: a composable, or a marker of the item owner.
+type row struct {
+	obj   any
+	owner gotio.Item
+	depth int
+}
+
+// browser is the state of the inspector, driven by key presses and drawn
+// by render, so it can be tested without a terminal.
+type browser struct {
+	timeline *gotio.Timeline
+	expanded map[any]bool
+	rows     []row
+	cursor   int
+	top      int
+	pane     pane
+
+	searching bool
+	query     string
+	matches   []row
+	match     int
+	status    string
+}
+
+func newBrowser(timeline *gotio.Timeline) *browser {
+	b := &browser{timeline: timeline, expanded: map[any]bool{}}
+	b.rebuild()
+	return b
+}
+
+// rebuild lists the rows shown with the current expansion, keeping the
+// cursor on the same object where it is still shown.
+func (b *browser) rebuild() {
+	var selected any
+	if b.cursor < len(b.rows) {
+		selected = b.rows[b.cursor].obj
+	}
+	b.rows = b.rows[:0]
+	var walk func(children []gotio.Composable, depth int)
+	walk = func(children []gotio.Composable, depth int) {
+		for _, child := range children {
+			b.rows = append(b.rows, row{obj: child, depth: depth})
+			if !b.expanded[child] {
+				continue
+			}
+			if comp, ok := child.(gotio.Composition); ok {
+				walk(comp.Children(), depth+1)
+			}
+			if item, ok := child.(gotio.Item); ok {
+				for _, marker := range item.Markers() {
+					b.rows = append(b.rows, row{obj: marker, owner: item, depth: depth + 1})
+				}
+			}
+		}
+	}
+	if b.timeline.Tracks() != nil {
+		walk(b.timeline.Tracks().Children(), 0)
+	}
+	b.cursor = min(b.cursor, max(len(b.rows)-1, 0))
+	for i, r := range b.rows {
+		if r.obj == selected {
+			b.cursor = i
+		}
+	}
+}
+
+// expandable reports whether obj has rows under it.
+func expandable(obj any) bool {
+	if comp, ok := obj.(gotio.Composition); ok && len(comp.Children()) > 0 {
+		return true
+	}
+	item, ok := obj.(gotio.Item)
+	return ok && len(item.Markers()) > 0
+}
+
+// handle applies a key press and reports whether the browser should quit.
+func (b *browser) handle(key string) bool {
+	if b.searching {
+		b.handleSearch(key)
+		return false
+	}
+	b.status = ""
+	page := 10
+	switch key {
+	case "q", "ctrl-c":
+		return true
+	case "up", "k":
+		b.move(-1)
+	case "down", "j":
+		b.move(1)
+	case "pgup":
+		b.move(-page)
+	case "pgdown", " ":
+		b.move(page)
+	case "home", "g":
+		b.cursor = 0
+	case "end", "G":
+		b.cursor = max(len(b.rows)-1, 0)
+	case "right", "l", "enter":
+		b.open()
+	case "left", "h":
+		b.close()
+	case "tab", "m":
+		b.pane = 1 - b.pane
+	case "/":
+		b.searching, b.query = true, ""
+	case "n":
+		b.next(1)
+	case "N":
+		b.next(-1)
+	}
+	return false
+}
+
+func (b *browser) move(delta int) {
+	b.cursor = max(0, min(b.cursor+delta, len(b.rows)-1))
+}
+
+// open expands the selected row, or moves into it if it is expanded.
+func (b *browser) open() {
+	if len(b.rows) == 0 {
+		return
+	}
+	obj := b.rows[b.cursor].obj
+	if !expandable(obj) {
+		return
+	}
+	if !b.expanded[obj] {
+		b.expanded[obj] = true
+		b.rebuild()
+		return
+	}
+	b.move(1)
+}
+
+// close collapses the selected row, or moves to its parent if it is not
+// expanded.
+func (b *browser) close() {
+	if len(b.rows) == 0 {
+		return
+	}
+	r := b.rows[b.cursor]
+	if b.expanded[r.obj] {
+		delete(b.expanded, r.obj)
+		b.rebuild()
+		return
+	}
+	for i := b.cursor - 1; i >= 0; i-- {
+		if b.rows[i].depth < r.depth {
+			b.cursor = i
+			return
+		}
+	}
+}
+
+func (b *browser) handleSearch(key string) {
+	switch key {
+	case "esc", "ctrl-c":
+		b.searching = false
+	case "enter":
+		b.searching = false
+		b.search()
+	case "backspace":
+		if len(b.query) > 0 {
+			_, size := utf8.DecodeLastRuneInString(b.query)
+			b.query = b.query[:len(b.query)-size]
+		}
+	default:
+		if utf8.RuneCountInString(key) == 1 {
+			b.query += key
+		}
+	}
+}
+
+// search finds the objects matching the query with FindChildren and
+// selects the first. A query of key=value matches metadata at a dotted
+// path; any other query matches names, ignoring case.
+func (b *browser) search() {
+	b.matches, b.match = nil, 0
+	if b.query == "" || b.timeline.Tracks() == nil {
+		return
+	}
+	matches := queryMatcher(b.query)
+	for _, child := range b.timeline.FindChildren(nil, false, nil) {
+		if matches(child) {
+			b.matches = append(b.matches, row{obj: child})
+		}
+		if item, ok := child.(gotio.Item); ok {
+			for _, marker := range item.Markers() {
+				if matches(marker) {
+					b.matches = append(b.matches, row{obj: marker, owner: item})
+				}
+			}
+		}
+	}
+	if len(b.matches) == 0 {
+		b.status = fmt.Sprintf("no match for %q", b.query)
+		return
+	}
+	b.reveal(b.matches[0])
+}
+
+// queryMatcher returns the test of objects against query.
+func queryMatcher(query string) func(obj gotio.SerializableObjectWithMetadata) bool {
+	if key, value, ok := strings.Cut(query, "="); ok {
+		return func(obj gotio.SerializableObjectWithMetadata) bool {
+			v, found := obj.Metadata().Lookup(strings.TrimSpace(key))
+			return found && fmt.Sprint(v) == strings.TrimSpace(value)
+		}
+	}
+	query = strings.ToLower(query)
+	return func(obj gotio.SerializableObjectWithMetadata) bool {
+		return strings.Contains(strings.ToLower(obj.Name()), query)
+	}
+}
+
+// next selects the match delta after the current one, wrapping around.
+func (b *browser) next(delta int) {
+	if len(b.matches) == 0 {
+		b.status = "no search"
+		return
+	}
+	b.match = (b.match + delta + len(b.matches)) % len(b.matches)
+	b.reveal(b.matches[b.match])
+}
+
+// reveal expands the ancestors of the match and selects it.
+func (b *browser) reveal(m row) {
+	var c gotio.Composable
+	if m.owner != nil {
+		c = m.owner
+		b.expanded[c] = true
+	} else {
+		c = m.obj.(gotio.Composable)
+	}
+	for p := c.Parent(); p != nil && p != gotio.Composition(b.timeline.Tracks()); p = p.Parent() {
+		b.expanded[p] = true
+	}
+	b.rebuild()
+	for i, r := range b.rows {
+		if r.obj == m.obj {
+			b.cursor = i
+		}
+	}
+	b.status = fmt.Sprintf("match %d of %d for %q", b.match+1, len(b.matches), b.query)
+}
+
+// render returns the screen as lines of at most width columns: a header,
+// the tree beside the pane of the selection, and a status line.
+func (b *browser) render(height, width int) []string {
+	height, width = max(height, 4), max(width, 20)
+	body := height - 2
+	if b.cursor < b.top {
+		b.top = b.cursor
+	}
+	if b.cursor >= b.top+body {
+		b.top = b.cursor - body + 1
+	}
+
+	lines := []string{reverse + fit(b.header(), width) + plain}
+	treeWidth := max(width*2/5, 16)
+	detailWidth := width - treeWidth - 3
+	var detail []string
+	if len(b.rows) > 0 {
+		detail = b.details(b.rows[b.cursor])
+	}
+	for i := range body {
+		var left string
+		if n := b.top + i; n < len(b.rows) {
+			left = fit(b.label(b.rows[n]), treeWidth)
+			if n == b.cursor {
+				left = reverse + left + plain
+			}
+		} else {
+			left = fit("", treeWidth)
+		}
+		var right string
+		if i < len(detail) {
+			right = fit(detail[i], detailWidth)
+		}
+		lines = append(lines, left+" │ "+strings.TrimRight(right, " "))
+	}
+
+	status := b.status
+	switch {
+	case b.searching:
+		status = "/" + b.query
+	case status == "":
+		status = helpLine
+	}
+	return append(lines, bold+fit(status, width)+plain)
+}
+
+func (b *browser) header() string {
+	text := "otitui  " + b.timeline.Name()
+	if r, err := b.timeline.RecordRange(); err == nil {
+		text += "  " + formatRange(r)
+	}
+	if b.timeline.Tracks() != nil {
+		text += fmt.Sprintf("  %d tracks", len(b.timeline.Tracks().Children()))
+	}
+	return text
+}
+
+// label returns the tree text of r.
+func (b *browser) label(r row) string {
+	prefix := "  "
+	if expandable(r.obj) {
+		prefix = "▸ "
+		if b.expanded[r.obj] {
+			prefix = "▾ "
+		}
+	}
+	obj := r.obj.(gotio.SerializableObjectWithMetadata)
+	kind := obj.SchemaName()
+	if track, ok := r.obj.(*gotio.Track); ok {
+		kind = track.Kind()
+	}
+	text := strings.Repeat("  ", r.depth) + prefix + obj.Name() + " [" + kind + "]"
+	if item, ok := r.obj.(gotio.Item); ok && !item.Enabled() {
+		text += " (disabled)"
+	}
+	return text
+}
+
+// details returns the lines of the pane about r.
+func (b *browser) details(r row) []string {
+	obj := r.obj.(gotio.SerializableObjectWithMetadata)
+	if b.pane == paneMetadata {
+		lines := []string{"Metadata of " + obj.Name(), ""}
+		if len(obj.Metadata()) == 0 {
+			return append(lines, "(none)")
+		}
+		data, err := json.MarshalIndent(obj.Metadata(), "", "  ")
+		if err != nil {
+			return append(lines, fmt.Sprint(obj.Metadata()))
+		}
+		return append(lines, strings.Split(string(data), "\n")...)
+	}
+
+	lines := []string{obj.SchemaName() + " " + obj.Name(), ""}
+	add := func(label, value string) {
+		lines = append(lines, fmt.Sprintf("%-11s %s", label+":", value))
+	}
+	addRange := func(label string, r opentime.TimeRange, err error) {
+		if err == nil {
+			add(label, formatRange(r))
+		}
+	}
+
+	if marker, ok := r.obj.(*gotio.Marker); ok {
+		add("Color", string(marker.Color()))
+		addRange("Marked", marker.MarkedRange(), nil)
+		if marker.Comment() != "" {
+			add("Comment", marker.Comment())
+		}
+		return lines
+	}
+
+	c := r.obj.(gotio.Composable)
+	record, err := b.timeline.RecordRangeOfChild(c)
+	addRange("Record", record, err)
+	if parent := c.Parent(); parent != nil {
+		inParent, err := parent.RangeOfChild(c)
+		addRange("In parent", inParent, err)
+	}
+	if item, ok := c.(gotio.Item); ok {
+		add("Enabled", fmt.Sprint(item.Enabled()))
+		if sr := item.SourceRange(); sr != nil {
+			addRange("Source", *sr, nil)
+		}
+		trimmed, err := item.TrimmedRange()
+		addRange("Trimmed", trimmed, err)
+		available, err := item.AvailableRange()
+		addRange("Available", available, err)
+		for _, effect := range item.Effects() {
+			add("Effect", effect.Name()+" ["+effect.SchemaName()+"]")
+		}
+		if n := len(item.Markers()); n > 0 {
+			add("Markers", fmt.Sprint(n))
+		}
+	}
+	switch c := c.(type) {
+	case *gotio.Clip:
+		if ref := c.MediaReference(); ref != nil {
+			media := ref.SchemaName()
+			if ext, ok := ref.(*gotio.ExternalReference); ok {
+				media = ext.TargetURL()
+			}
+			add("Media", c.ActiveMediaReferenceKey()+": "+media)
+		}
+	case *gotio.Transition:
+		add("Type", string(c.TransitionType()))
+		add("In offset", fmt.Sprintf("%g frames", c.InOffset().Value()))
+		add("Out offset", fmt.Sprintf("%g frames", c.OutOffset().Value()))
+	case gotio.Composition:
+		add("Children", fmt.Sprint(len(c.Children())))
+	}
+	return lines
+}
+
+// formatRange returns r as in and out timecodes with its duration.
+func formatRange(r opentime.TimeRange) string {
+	timecode := func(t opentime.RationalTime) string {
+		if tc, err := t.ToTimecodeAuto(); err == nil {
+			return tc
+		}
+		return t.ToTimeString()
+	}
+	return fmt.Sprintf("%s - %s (%g frames)", timecode(r.StartTime()), timecode(r.EndTimeExclusive()), r.Duration().Value())
+}
+
+// fit pads or truncates s to width columns, counting a rune as a column.
+func fit(s string, width int) string {
+	n := utf8.RuneCountInString(s)
+	if n <= width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	runes := []rune(s)
+	return string(runes[:max(width-1, 0)]) + "…"
+}
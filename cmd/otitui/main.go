@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// otitui browses a timeline in the terminal, for inspecting edits on
+// machines without a display such as render nodes. The tracks are listed
+// on the left and open into their clips, gaps, transitions, nested stacks
+// and markers; the right shows the timing of the selection, or with tab
+// its metadata. Inputs are read by suffix as by otioconvert.
+//
+// Usage:
+//
+//	go run ./cmd/otitui edit.otio
+//	go run ./cmd/otitui -rate 25 edit.edl
+//
+// Keys:
+//
+//	up, down, k, j    move the selection
+//	right, enter, l   open the selection, or move into it
+//	left, h           close the selection, or move to its parent
+//	tab               switch between the timing and metadata panes
+//	/                 search names, or metadata with key.path=value
+//	n, N              move to the next or previous match
+//	q                 quit
+//
+// Standard input must be a terminal; it is put in raw mode with stty.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/adapters"
+	"github.com/Avalanche-io/gotio/pipeline"
+)
+
+func main() {
+	rate := flag.Float64("rate", 0, "Frame rate for formats that need one")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: otitui [-rate R] file")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if _, err := adapters.DiscoverExternal(); err != nil {
+		fmt.Fprintf(os.Stderr, "otitui: %v\n", err)
+	}
+	timeline, err := pipeline.ReadFile(flag.Arg(0), *rate)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "otitui: %v\n", err)
+		os.Exit(1)
+	}
+	term, err := openTerminal()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "otitui: %v\n", err)
+		os.Exit(1)
+	}
+	err = run(newBrowser(timeline), term.in, os.Stdout, term.size)
+	term.close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "otitui: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run draws the browser to w and applies the keys read from in until the
+// browser quits or in ends. size returns the rows and columns to draw.
+func run(b *browser, in *bufio.Reader, w io.Writer, size func() (int, int)) error {
+	if _, err := io.WriteString(w, enterScreen); err != nil {
+		return err
+	}
+	defer io.WriteString(w, leaveScreen)
+	for {
+		rows, cols := size()
+		screen := home + strings.Join(b.render(rows, cols), clearLine+"\r\n") + clearLine + clearBelow
+		if _, err := io.WriteString(w, screen); err != nil {
+			return err
+		}
+		key, err := readKey(in)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if b.handle(key) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/pipeline"
+)
+
+const edit = `{"OTIO_SCHEMA": "Timeline.1", "name": "cut",
+"tracks": {"OTIO_SCHEMA": "Stack.1", "name": "tracks", "children": [
+  {"OTIO_SCHEMA": "Track.1", "name": "V1", "kind": "Video", "children": [
+    {"OTIO_SCHEMA": "Clip.2", "name": "sh010", "metadata": {"vfx": {"shot": "010"}},
+     "source_range": {"OTIO_SCHEMA": "TimeRange.1", "start_time": {"OTIO_SCHEMA": "RationalTime.1", "value": 0, "rate": 24}, "duration": {"OTIO_SCHEMA": "RationalTime.1", "value": 48, "rate": 24}}},
+    {"OTIO_SCHEMA": "Clip.2", "name": "sh020",
+     "source_range": {"OTIO_SCHEMA": "TimeRange.1", "start_time": {"OTIO_SCHEMA": "RationalTime.1", "value": 0, "rate": 24}, "duration": {"OTIO_SCHEMA": "RationalTime.1", "value": 24, "rate": 24}},
+     "markers": [{"OTIO_SCHEMA": "Marker.2", "name": "fix grade", "color": "RED",
+       "marked_range": {"OTIO_SCHEMA": "TimeRange.1", "start_time": {"OTIO_SCHEMA": "RationalTime.1", "value": 12, "rate": 24}, "duration": {"OTIO_SCHEMA": "RationalTime.1", "value": 0, "rate": 24}}}]}
+  ]},
+  {"OTIO_SCHEMA": "Track.1", "name": "A1", "kind": "Audio", "children": []}
+]}}`
+
+func readTestTimeline(t *testing.T) *gotio.Timeline {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cut.otio")
+	if err := os.WriteFile(path, []byte(edit), 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	timeline, err := pipeline.ReadFile(path, 0)
+	if err != nil {
+		t.Fatalf("ReadFile error: %v", err)
+	}
+	return timeline
+}
+
+// screen returns the rendered screen of b as one string.
+func screen(b *browser) string {
+	return strings.Join(b.render(20, 100), "\n")
+}
+
+func TestBrowserNavigation(t *testing.T) {
+	b := newBrowser(readTestTimeline(t))
+	if len(b.rows) != 2 {
+		t.Fatalf("rows = %d, want the 2 tracks", len(b.rows))
+	}
+	for _, key := range []string{"enter", "down", "tab"} {
+		b.handle(key)
+	}
+	out := screen(b)
+	if !strings.Contains(out, "▾ V1 [Video]") || !strings.Contains(out, "sh020 [Clip]") {
+		t.Errorf("V1 not opened:\n%s", out)
+	}
+	if !strings.Contains(out, `"shot": "010"`) {
+		t.Errorf("metadata pane missing the metadata of sh010:\n%s", out)
+	}
+
+	b.handle("tab")
+	out = screen(b)
+	if !strings.Contains(out, "Record:     00:00:00:00 - 00:00:02:00 (48 frames)") {
+		t.Errorf("timing pane missing the record range:\n%s", out)
+	}
+
+	// Left moves to the track and closes it
+	b.handle("left")
+	b.handle("left")
+	if len(b.rows) != 2 || b.cursor != 0 {
+		t.Errorf("rows = %d, cursor = %d after closing V1", len(b.rows), b.cursor)
+	}
+	if !b.handle("q") {
+		t.Error("q did not quit")
+	}
+}
+
+func TestBrowserSearch(t *testing.T) {
+	b := newBrowser(readTestTimeline(t))
+	for _, key := range []string{"/", "G", "R", "A", "D", "E", "enter"} {
+		b.handle(key)
+	}
+	if r := b.rows[b.cursor]; r.owner == nil || r.obj.(*gotio.Marker).Name() != "fix grade" {
+		t.Fatalf("search selected %v, want the marker", r.obj)
+	}
+	if out := screen(b); !strings.Contains(out, "match 1 of 1") || !strings.Contains(out, "Color:      RED") {
+		t.Errorf("unexpected screen:\n%s", out)
+	}
+
+	for _, key := range []string{"/", "v", "f", "x", ".", "s", "h", "o", "t", "=", "0", "1", "0", "enter"} {
+		b.handle(key)
+	}
+	if r := b.rows[b.cursor]; r.obj.(gotio.Composable).Name() != "sh010" {
+		t.Errorf("metadata search selected %v, want sh010", r.obj)
+	}
+
+	for _, key := range []string{"/", "z", "z", "enter"} {
+		b.handle(key)
+	}
+	if !strings.Contains(screen(b), `no match for "zz"`) {
+		t.Error("expected no match")
+	}
+}
+
+func TestRun(t *testing.T) {
+	b := newBrowser(readTestTimeline(t))
+	// Open, down, then quit
+	in := bufio.NewReader(strings.NewReader("\r\x1b[Bq"))
+	var out bytes.Buffer
+	if err := run(b, in, &out, func() (int, int) { return 10, 60 }); err != nil {
+		t.Fatalf("run error: %v", err)
+	}
+	if !b.expanded[b.rows[0].obj] || b.rows[b.cursor].obj.(gotio.Composable).Name() != "sh010" {
+		t.Errorf("keys not applied: cursor %d", b.cursor)
+	}
+	if !strings.HasPrefix(out.String(), enterScreen) || !strings.HasSuffix(out.String(), leaveScreen) {
+		t.Error("screen not entered and left")
+	}
+}
+
+func TestReadKey(t *testing.T) {
+	// Home and end as vt220 sends them, then F5 and ctrl-up, whose
+	// sequences must be read whole so "x" is a key of its own
+	in := bufio.NewReader(strings.NewReader("\x1b[1~\x1b[4~\x1b[15~\x1b[1;5Ax\x1bOF"))
+	want := []string{"home", "end", "unknown", "up", "x", "end"}
+	for i, w := range want {
+		key, err := readKey(in)
+		if err != nil || key != w {
+			t.Fatalf("key %d = %q, %v, want %q", i, key, err, w)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ANSI sequences for drawing full screen.
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	leaveScreen = "\x1b[?25h\x1b[?1049l"
+	home        = "\x1b[H"
+	clearLine   = "\x1b[K"
+	clearBelow  = "\x1b[J"
+	reverse     = "\x1b[7m"
+	bold        = "\x1b[1m"
+	plain       = "\x1b[0m"
+)
+
+// errNotTerminal is returned when standard input is not a terminal.
+var errNotTerminal = errors.New("standard input is not a terminal")
+
+// terminal is the controlling terminal in raw mode.
+type terminal struct {
+	saved string
+	in    *bufio.Reader
+}
+
+// openTerminal puts standard input in raw mode with stty, which every
+// render node has, and returns the terminal to restore with close.
+func openTerminal() (*terminal, error) {
+	if stat, err := os.Stdin.Stat(); err != nil || stat.Mode()&os.ModeCharDevice == 0 {
+		return nil, errNotTerminal
+	}
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty("raw", "-echo"); err != nil {
+		return nil, err
+	}
+	return &terminal{saved: strings.TrimSpace(saved), in: bufio.NewReader(os.Stdin)}, nil
+}
+
+// close restores the terminal mode saved by openTerminal.
+func (t *terminal) close() error {
+	_, err := stty(t.saved)
+	return err
+}
+
+// size returns the rows and columns of the terminal, or 24 by 80 if they
+// cannot be read.
+func (t *terminal) size() (rows, cols int) {
+	out, err := stty("size")
+	if err == nil {
+		if _, err := fmt.Sscan(out, &rows, &cols); err == nil && rows > 0 && cols > 0 {
+			return rows, cols
+		}
+	}
+	return 24, 80
+}
+
+// stty runs stty on standard input and returns its output.
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("stty %s: %w", strings.Join(args, " "), err)
+	}
+	return string(out), nil
+}
+
+// readKey reads one key press from r: a printable character, or a name
+// such as "up", "enter" or "esc" for keys that send control codes.
+// Escape sequences for other keys are read whole and returned as
+// "unknown".
+func readKey(r *bufio.Reader) (string, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return "", err
+	}
+	switch c {
+	case '\r', '\n':
+		return "enter", nil
+	case '\t':
+		return "tab", nil
+	case 0x7f, 0x08:
+		return "backspace", nil
+	case 0x03:
+		return "ctrl-c", nil
+	case 0x1b:
+		// A lone escape arrives without the rest of a sequence
+		if r.Buffered() < 2 {
+			return "esc", nil
+		}
+		if next, _ := r.Peek(1); next[0] != '[' && next[0] != 'O' {
+			return "esc", nil
+		}
+		r.ReadByte()
+		return readSequence(r)
+	}
+	return string(c), nil
+}
+
+// readSequence reads the rest of an escape sequence after its "ESC [" or
+// "ESC O": parameter and intermediate bytes up to a final byte in
+// 0x40-0x7E. Modifiers such as the ";5" of ctrl-up are ignored.
+func readSequence(r *bufio.Reader) (string, error) {
+	var params []byte
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		if c < 0x40 || c > 0x7e {
+			params = append(params, c)
+			continue
+		}
+		first, _, _ := strings.Cut(string(params), ";")
+		switch {
+		case c == 'A':
+			return "up", nil
+		case c == 'B':
+			return "down", nil
+		case c == 'C':
+			return "right", nil
+		case c == 'D':
+			return "left", nil
+		case c == 'H', c == '~' && (first == "1" || first == "7"):
+			return "home", nil
+		case c == 'F', c == '~' && (first == "4" || first == "8"):
+			return "end", nil
+		case c == '~' && first == "5":
+			return "pgup", nil
+		case c == '~' && first == "6":
+			return "pgdown", nil
+		}
+		return "unknown", nil
+	}
+}
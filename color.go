@@ -3,6 +3,22 @@
 
 package gotio
 
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidColor is returned for color names and hex strings that cannot
+// be parsed.
+var ErrInvalidColor = errors.New("invalid color")
+
+// CustomColorKey is the metadata key a marker's exact color is kept under,
+// as a hex string, when it is not one of the standard marker colors.
+const CustomColorKey = "custom_color"
+
 // Color represents an RGBA color.
 type Color struct {
 	R float64 `json:"r"`
@@ -53,6 +69,105 @@ const (
 	MarkerColorWhite   MarkerColor = "WHITE"
 )
 
+// MarkerColors returns the standard marker colors.
+func MarkerColors() []MarkerColor {
+	return []MarkerColor{
+		MarkerColorPink, MarkerColorRed, MarkerColorOrange, MarkerColorYellow,
+		MarkerColorGreen, MarkerColorCyan, MarkerColorBlue, MarkerColorPurple,
+		MarkerColorMagenta, MarkerColorBlack, MarkerColorWhite,
+	}
+}
+
+// IsValid reports whether mc is one of the standard marker colors.
+func (mc MarkerColor) IsValid() bool {
+	for _, c := range MarkerColors() {
+		if mc == c {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseMarkerColor returns the standard marker color named s, ignoring
+// case and surrounding space.
+func ParseMarkerColor(s string) (MarkerColor, error) {
+	mc := MarkerColor(strings.ToUpper(strings.TrimSpace(s)))
+	if !mc.IsValid() {
+		return "", fmt.Errorf("%w: unknown marker color %q", ErrInvalidColor, s)
+	}
+	return mc, nil
+}
+
+// ParseColor parses a standard color name, such as "red", or a hex string
+// of the form "#RRGGBB" or "#RRGGBBAA".
+func ParseColor(s string) (*Color, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "#") {
+		mc, err := ParseMarkerColor(s)
+		if err != nil {
+			return nil, err
+		}
+		return cloneColor(mc.ToColor()), nil
+	}
+	hex := s[1:]
+	if len(hex) != 6 && len(hex) != 8 {
+		return nil, fmt.Errorf("%w: %q is not #RRGGBB or #RRGGBBAA", ErrInvalidColor, s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q is not a hex color", ErrInvalidColor, s)
+	}
+	if len(hex) == 6 {
+		v = v<<8 | 0xff
+	}
+	return NewColor(
+		float64(v>>24&0xff)/255,
+		float64(v>>16&0xff)/255,
+		float64(v>>8&0xff)/255,
+		float64(v&0xff)/255,
+	), nil
+}
+
+// Hex returns the color as "#RRGGBB", or "#RRGGBBAA" if it is not opaque.
+// Components are clamped to [0, 1].
+func (c *Color) Hex() string {
+	hex := fmt.Sprintf("#%02X%02X%02X", colorByte(c.R), colorByte(c.G), colorByte(c.B))
+	if a := colorByte(c.A); a != 0xff {
+		hex += fmt.Sprintf("%02X", a)
+	}
+	return hex
+}
+
+// colorByte converts a color component to a byte.
+func colorByte(v float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, v)) * 255))
+}
+
+// MarkerColor returns the standard marker color c matches at 8 bits per
+// component, and false if there is none.
+func (c *Color) MarkerColor() (MarkerColor, bool) {
+	hex := c.Hex()
+	for _, mc := range MarkerColors() {
+		if mc.ToColor().Hex() == hex {
+			return mc, true
+		}
+	}
+	return "", false
+}
+
+// NearestMarkerColor returns the standard marker color closest to c.
+func (c *Color) NearestMarkerColor() MarkerColor {
+	nearest, best := MarkerColorGreen, math.Inf(1)
+	for _, mc := range MarkerColors() {
+		s := mc.ToColor()
+		d := (c.R-s.R)*(c.R-s.R) + (c.G-s.G)*(c.G-s.G) + (c.B-s.B)*(c.B-s.B)
+		if d < best {
+			nearest, best = mc, d
+		}
+	}
+	return nearest
+}
+
 // ToColor converts a MarkerColor to a Color.
 func (mc MarkerColor) ToColor() *Color {
 	switch mc {
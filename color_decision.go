@@ -0,0 +1,363 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ASCCDLSchema is the schema for ASCCDL.
+var ASCCDLSchema = Schema{Name: "ASC_CDL", Version: 1}
+
+// LUTSchema is the schema for LUT.
+var LUTSchema = Schema{Name: "LUT", Version: 1}
+
+// CDLMetadataKey is the metadata key under which EDL readers store a
+// clip's color decision, as {"asc_sop": {"slope", "offset", "power"},
+// "asc_sat": saturation}.
+const CDLMetadataKey = "cdl"
+
+// ASCCDL is an effect holding an ASC Color Decision List correction: a
+// slope, offset and power per red, green and blue channel, followed by a
+// saturation.
+type ASCCDL struct {
+	EffectBase
+	slope      [3]float64
+	offset     [3]float64
+	power      [3]float64
+	saturation float64
+}
+
+// NewASCCDL creates a new ASCCDL.
+func NewASCCDL(name string, slope, offset, power [3]float64, saturation float64, metadata AnyDictionary) *ASCCDL {
+	return &ASCCDL{
+		EffectBase: NewEffectBase(name, ASCCDLSchema.Name, metadata),
+		slope:      slope,
+		offset:     offset,
+		power:      power,
+		saturation: saturation,
+	}
+}
+
+// NewIdentityASCCDL creates an ASCCDL that leaves colors unchanged.
+func NewIdentityASCCDL(name string) *ASCCDL {
+	return NewASCCDL(name, [3]float64{1, 1, 1}, [3]float64{}, [3]float64{1, 1, 1}, 1, nil)
+}
+
+// Slope returns the slope of each channel.
+func (c *ASCCDL) Slope() [3]float64 {
+	return c.slope
+}
+
+// SetSlope sets the slope of each channel.
+func (c *ASCCDL) SetSlope(slope [3]float64) {
+	c.slope = slope
+}
+
+// Offset returns the offset of each channel.
+func (c *ASCCDL) Offset() [3]float64 {
+	return c.offset
+}
+
+// SetOffset sets the offset of each channel.
+func (c *ASCCDL) SetOffset(offset [3]float64) {
+	c.offset = offset
+}
+
+// Power returns the power of each channel.
+func (c *ASCCDL) Power() [3]float64 {
+	return c.power
+}
+
+// SetPower sets the power of each channel.
+func (c *ASCCDL) SetPower(power [3]float64) {
+	c.power = power
+}
+
+// Saturation returns the saturation.
+func (c *ASCCDL) Saturation() float64 {
+	return c.saturation
+}
+
+// SetSaturation sets the saturation.
+func (c *ASCCDL) SetSaturation(saturation float64) {
+	c.saturation = saturation
+}
+
+// Apply returns rgb corrected by the CDL: out = clamp(in*slope+offset)^power
+// per channel, then saturation around Rec. 709 luma.
+func (c *ASCCDL) Apply(rgb [3]float64) [3]float64 {
+	var out [3]float64
+	for i, v := range rgb {
+		v = math.Max(0, math.Min(1, v*c.slope[i]+c.offset[i]))
+		out[i] = math.Pow(v, c.power[i])
+	}
+	luma := 0.2126*out[0] + 0.7152*out[1] + 0.0722*out[2]
+	for i := range out {
+		out[i] = luma + c.saturation*(out[i]-luma)
+	}
+	return out
+}
+
+// SOP returns the slope, offset and power as written in EDL comments and
+// ALE columns, e.g. "(1 1 1)(0 0 0)(1 1 1)".
+func (c *ASCCDL) SOP() string {
+	var b strings.Builder
+	for _, values := range [][3]float64{c.slope, c.offset, c.power} {
+		fmt.Fprintf(&b, "(%s %s %s)", formatCDLValue(values[0]), formatCDLValue(values[1]), formatCDLValue(values[2]))
+	}
+	return b.String()
+}
+
+func formatCDLValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// ParseASCSOP parses a slope, offset and power written as three groups
+// of three numbers, e.g. "(1.1 1 1)(0 0 0.02)(1 1 1)".
+func ParseASCSOP(s string) (slope, offset, power [3]float64, err error) {
+	fields := strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(s))
+	if len(fields) != 9 {
+		return slope, offset, power, fmt.Errorf("ASC_SOP %q: expected 9 values, got %d", s, len(fields))
+	}
+	groups := []*[3]float64{&slope, &offset, &power}
+	for i, field := range fields {
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return slope, offset, power, fmt.Errorf("ASC_SOP %q: %w", s, err)
+		}
+		groups[i/3][i%3] = v
+	}
+	return slope, offset, power, nil
+}
+
+// ASCCDLFromMetadata returns the color decision stored in md under
+// CDLMetadataKey, and false if there is none. A missing saturation is 1.
+func ASCCDLFromMetadata(md AnyDictionary) (*ASCCDL, bool) {
+	cdl, ok := md.GetDictionary(CDLMetadataKey)
+	if !ok {
+		return nil, false
+	}
+	sop, ok := cdl.GetDictionary("asc_sop")
+	if !ok {
+		return nil, false
+	}
+	result := NewIdentityASCCDL("")
+	for key, target := range map[string]*[3]float64{"slope": &result.slope, "offset": &result.offset, "power": &result.power} {
+		if values, ok := float3(sop[key]); ok {
+			*target = values
+		}
+	}
+	if sat, ok := cdl.GetFloat64("asc_sat"); ok {
+		result.saturation = sat
+	}
+	return result, true
+}
+
+// CDLMetadata returns the correction in the form ASCCDLFromMetadata reads,
+// to store under CDLMetadataKey.
+func (c *ASCCDL) CDLMetadata() AnyDictionary {
+	list := func(v [3]float64) []any { return []any{v[0], v[1], v[2]} }
+	return AnyDictionary{
+		"asc_sop": AnyDictionary{"slope": list(c.slope), "offset": list(c.offset), "power": list(c.power)},
+		"asc_sat": c.saturation,
+	}
+}
+
+// float3 converts a decoded list of three numbers.
+func float3(v any) ([3]float64, bool) {
+	var result [3]float64
+	switch list := v.(type) {
+	case [3]float64:
+		return list, true
+	case []float64:
+		if len(list) != 3 {
+			return result, false
+		}
+		copy(result[:], list)
+		return result, true
+	case []any:
+		if len(list) != 3 {
+			return result, false
+		}
+		for i, item := range list {
+			f, ok := AnyDictionary{"v": item}.GetFloat64("v")
+			if !ok {
+				return result, false
+			}
+			result[i] = f
+		}
+		return result, true
+	}
+	return result, false
+}
+
+// SchemaName returns the schema name.
+func (c *ASCCDL) SchemaName() string {
+	return ASCCDLSchema.Name
+}
+
+// SchemaVersion returns the schema version.
+func (c *ASCCDL) SchemaVersion() int {
+	return ASCCDLSchema.Version
+}
+
+// Clone creates a deep copy.
+func (c *ASCCDL) Clone() SerializableObject {
+	clone := *c
+	clone.metadata = CloneAnyDictionary(c.metadata)
+	return &clone
+}
+
+// IsEquivalentTo returns true if equivalent.
+func (c *ASCCDL) IsEquivalentTo(other SerializableObject) bool {
+	o, ok := other.(*ASCCDL)
+	if !ok {
+		return false
+	}
+	return c.name == o.name && c.effectName == o.effectName && c.slope == o.slope &&
+		c.offset == o.offset && c.power == o.power && c.saturation == o.saturation
+}
+
+// ascCDLJSON is the JSON representation.
+type ascCDLJSON struct {
+	Schema     string        `json:"OTIO_SCHEMA"`
+	Name       string        `json:"name"`
+	Metadata   AnyDictionary `json:"metadata"`
+	EffectName string        `json:"effect_name"`
+	Slope      [3]float64    `json:"slope"`
+	Offset     [3]float64    `json:"offset"`
+	Power      [3]float64    `json:"power"`
+	Saturation float64       `json:"saturation"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *ASCCDL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&ascCDLJSON{
+		Schema:     ASCCDLSchema.String(),
+		Name:       c.name,
+		Metadata:   c.metadata,
+		EffectName: c.effectName,
+		Slope:      c.slope,
+		Offset:     c.offset,
+		Power:      c.power,
+		Saturation: c.saturation,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *ASCCDL) UnmarshalJSON(data []byte) error {
+	j := ascCDLJSON{Slope: [3]float64{1, 1, 1}, Power: [3]float64{1, 1, 1}, Saturation: 1}
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	c.name = j.Name
+	c.metadata = j.Metadata
+	if c.metadata == nil {
+		c.metadata = make(AnyDictionary)
+	}
+	c.effectName = j.EffectName
+	c.slope, c.offset, c.power, c.saturation = j.Slope, j.Offset, j.Power, j.Saturation
+	return nil
+}
+
+// LUT is an effect applying a lookup table file, such as a .cube or
+// .3dl, to the item it is on.
+type LUT struct {
+	EffectBase
+	targetURL string
+}
+
+// NewLUT creates a new LUT.
+func NewLUT(name, targetURL string, metadata AnyDictionary) *LUT {
+	return &LUT{
+		EffectBase: NewEffectBase(name, LUTSchema.Name, metadata),
+		targetURL:  targetURL,
+	}
+}
+
+// TargetURL returns the URL of the lookup table file.
+func (l *LUT) TargetURL() string {
+	return l.targetURL
+}
+
+// SetTargetURL sets the URL of the lookup table file.
+func (l *LUT) SetTargetURL(url string) {
+	l.targetURL = url
+}
+
+// SchemaName returns the schema name.
+func (l *LUT) SchemaName() string {
+	return LUTSchema.Name
+}
+
+// SchemaVersion returns the schema version.
+func (l *LUT) SchemaVersion() int {
+	return LUTSchema.Version
+}
+
+// Clone creates a deep copy.
+func (l *LUT) Clone() SerializableObject {
+	clone := *l
+	clone.metadata = CloneAnyDictionary(l.metadata)
+	return &clone
+}
+
+// IsEquivalentTo returns true if equivalent.
+func (l *LUT) IsEquivalentTo(other SerializableObject) bool {
+	o, ok := other.(*LUT)
+	if !ok {
+		return false
+	}
+	return l.name == o.name && l.effectName == o.effectName && l.targetURL == o.targetURL
+}
+
+// lutJSON is the JSON representation.
+type lutJSON struct {
+	Schema     string        `json:"OTIO_SCHEMA"`
+	Name       string        `json:"name"`
+	Metadata   AnyDictionary `json:"metadata"`
+	EffectName string        `json:"effect_name"`
+	TargetURL  string        `json:"target_url"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (l *LUT) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&lutJSON{
+		Schema:     LUTSchema.String(),
+		Name:       l.name,
+		Metadata:   l.metadata,
+		EffectName: l.effectName,
+		TargetURL:  l.targetURL,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *LUT) UnmarshalJSON(data []byte) error {
+	var j lutJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	l.name = j.Name
+	l.metadata = j.Metadata
+	if l.metadata == nil {
+		l.metadata = make(AnyDictionary)
+	}
+	l.effectName = j.EffectName
+	l.targetURL = j.TargetURL
+	return nil
+}
+
+func init() {
+	RegisterSchema(ASCCDLSchema, func() SerializableObject {
+		return NewIdentityASCCDL("")
+	})
+	RegisterSchema(LUTSchema, func() SerializableObject {
+		return NewLUT("", "", nil)
+	})
+}
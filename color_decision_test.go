@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"math"
+	"testing"
+)
+
+func TestASCCDLRoundTrip(t *testing.T) {
+	cdl := NewASCCDL("grade", [3]float64{1.1, 1, 0.9}, [3]float64{0, 0.01, -0.02}, [3]float64{1, 1.2, 1}, 0.8, nil)
+	lut := NewLUT("show", "file:///luts/show.cube", nil)
+	clip := NewClip("shot", nil, nil, nil, []Effect{cdl, lut}, nil, "", nil)
+
+	for name, encode := range map[string]func(SerializableObject) (string, error){
+		"fast":   func(o SerializableObject) (string, error) { return ToJSONString(o, "") },
+		"stdlib": func(o SerializableObject) (string, error) { b, err := o.(*Clip).MarshalJSON(); return string(b), err },
+	} {
+		data, err := encode(clip)
+		if err != nil {
+			t.Fatalf("%s: encode error: %v", name, err)
+		}
+		decoded, err := FromJSONString(data)
+		if err != nil {
+			t.Fatalf("%s: decode error: %v", name, err)
+		}
+		effects := decoded.(*Clip).Effects()
+		if len(effects) != 2 || !effects[0].IsEquivalentTo(cdl) || !effects[1].IsEquivalentTo(lut) {
+			t.Errorf("%s: effects = %v", name, effects)
+		}
+	}
+
+	decoded, err := FromJSONString(`{"OTIO_SCHEMA": "ASC_CDL.1", "name": "partial", "slope": [2, 2, 2]}`)
+	if err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	partial := decoded.(*ASCCDL)
+	if partial.Slope() != [3]float64{2, 2, 2} || partial.Power() != [3]float64{1, 1, 1} || partial.Saturation() != 1 {
+		t.Errorf("missing values should be the identity, got %v %v %v", partial.Slope(), partial.Power(), partial.Saturation())
+	}
+}
+
+func TestASCCDLApply(t *testing.T) {
+	identity := NewIdentityASCCDL("")
+	if got := identity.Apply([3]float64{0.2, 0.5, 0.8}); got != [3]float64{0.2, 0.5, 0.8} {
+		t.Errorf("identity Apply = %v", got)
+	}
+
+	cdl := NewASCCDL("", [3]float64{2, 1, 1}, [3]float64{0, 0, 0.1}, [3]float64{1, 2, 1}, 0, nil)
+	got := cdl.Apply([3]float64{0.75, 0.5, 0.95})
+	luma := 0.2126*1 + 0.7152*0.25 + 0.0722*1
+	for i, v := range got {
+		if math.Abs(v-luma) > 1e-12 {
+			t.Errorf("channel %d = %v, want luma %v", i, v, luma)
+		}
+	}
+}
+
+func TestParseASCSOP(t *testing.T) {
+	slope, offset, power, err := ParseASCSOP("(1.1 1 1)(0 0 0.02)( 1 1 0.9 )")
+	if err != nil {
+		t.Fatalf("ParseASCSOP error: %v", err)
+	}
+	cdl := NewASCCDL("", slope, offset, power, 1, nil)
+	if cdl.SOP() != "(1.1 1 1)(0 0 0.02)(1 1 0.9)" {
+		t.Errorf("SOP = %q", cdl.SOP())
+	}
+	for _, bad := range []string{"", "(1 1 1)(0 0 0)", "(1 1 1)(0 0 0)(1 1 x)"} {
+		if _, _, _, err := ParseASCSOP(bad); err == nil {
+			t.Errorf("ParseASCSOP(%q): expected error", bad)
+		}
+	}
+}
+
+func TestASCCDLFromMetadata(t *testing.T) {
+	cdl := NewASCCDL("", [3]float64{1.1, 1, 1}, [3]float64{0, 0, 0.02}, [3]float64{1, 1, 0.9}, 0.7, nil)
+	got, ok := ASCCDLFromMetadata(AnyDictionary{CDLMetadataKey: cdl.CDLMetadata()})
+	if !ok || !got.IsEquivalentTo(cdl) {
+		t.Errorf("ASCCDLFromMetadata = %v, %v", got, ok)
+	}
+
+	// The form read back from JSON.
+	decoded, err := FromJSONString(`{"OTIO_SCHEMA": "Clip.2", "name": "c", "metadata": {"cdl": {"asc_sop": {"slope": [1.1, 1, 1], "offset": [0, 0, 0.02], "power": [1, 1, 0.9]}, "asc_sat": 0.7}}}`)
+	if err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if got, ok := ASCCDLFromMetadata(decoded.(*Clip).Metadata()); !ok || !got.IsEquivalentTo(cdl) {
+		t.Errorf("ASCCDLFromMetadata from JSON = %v, %v", got, ok)
+	}
+	if _, ok := ASCCDLFromMetadata(AnyDictionary{}); ok {
+		t.Error("expected no CDL in empty metadata")
+	}
+}
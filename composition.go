@@ -70,20 +70,39 @@ type Composition interface {
 	// ChildrenInRange returns all children within the given range.
 	ChildrenInRange(searchRange opentime.TimeRange) ([]Composable, error)
 
-	// FindChildren finds children matching the given type.
-	FindChildren(searchRange *opentime.TimeRange, shallowSearch bool, filter func(Composable) bool) []Composable
+	// FindChildren finds children matching the given filter.
+	FindChildren(searchRange *opentime.TimeRange, shallowSearch bool, filter func(Composable) bool, opts ...SearchOption) []Composable
 
 	// FindClips finds all clips.
-	FindClips(searchRange *opentime.TimeRange, shallowSearch bool) []*Clip
+	FindClips(searchRange *opentime.TimeRange, shallowSearch bool, opts ...SearchOption) []*Clip
 }
 
 // CompositionSchema is the schema for Composition.
 var CompositionSchema = Schema{Name: "Composition", Version: 1}
 
+// ReparentPolicy controls what a composition does when it is given a
+// child that already has a parent.
+type ReparentPolicy int
+
+const (
+	// ReparentAllow inserts the child and makes the composition its
+	// parent, leaving it among the children of its former parent too.
+	ReparentAllow ReparentPolicy = 0
+	// ReparentError rejects the child with ErrChildAlreadyParented.
+	ReparentError ReparentPolicy = 1
+	// ReparentClone inserts a deep copy of the child and leaves the
+	// original in its parent.
+	ReparentClone ReparentPolicy = 2
+)
+
 // CompositionBase is the base implementation of Composition.
 type CompositionBase struct {
 	ItemBase
-	children []Composable
+	children       []Composable
+	reparentPolicy ReparentPolicy
+	// generation counts changes to the children of the composition and
+	// its descendants.
+	generation uint64
 }
 
 // NewCompositionBase creates a new CompositionBase.
@@ -101,6 +120,42 @@ func NewCompositionBase(
 	}
 }
 
+// Generation returns a counter that changes whenever a child is added to,
+// removed from or replaced in the composition or any composition nested
+// in it, so caches built from its contents can tell they are stale.
+func (c *CompositionBase) Generation() uint64 {
+	return c.generation
+}
+
+// touch records a change to the children of c in c and its ancestors.
+func (c *CompositionBase) touch() {
+	// slow follows the parents at half speed, so a cycle of parents in a
+	// corrupted tree ends the walk when comp catches up with it.
+	slow := c
+	for comp, step := c, 0; comp != nil; step++ {
+		comp.generation++
+		comp = comp.parentBase()
+		if step%2 == 1 {
+			slow = slow.parentBase()
+		}
+		if comp == slow {
+			break
+		}
+	}
+}
+
+// parentBase returns the CompositionBase of the parent of c, or nil.
+func (c *CompositionBase) parentBase() *CompositionBase {
+	if parent, ok := c.parent.(interface{ compositionBase() *CompositionBase }); ok {
+		return parent.compositionBase()
+	}
+	return nil
+}
+
+func (c *CompositionBase) compositionBase() *CompositionBase {
+	return c
+}
+
 // CompositionKind returns the kind of composition.
 func (c *CompositionBase) CompositionKind() string {
 	return "Composition"
@@ -117,6 +172,7 @@ func (c *CompositionBase) ClearChildren() {
 		child.SetParent(nil)
 	}
 	c.children = make([]Composable, 0)
+	c.touch()
 }
 
 // SetChildren sets the children.
@@ -125,39 +181,92 @@ func (c *CompositionBase) SetChildren(children []Composable) error {
 	if children == nil {
 		return nil
 	}
+	appendChild := c.AppendChild
+	if self, ok := c.Self().(Composition); ok {
+		appendChild = self.AppendChild
+	}
 	for _, child := range children {
-		if err := c.AppendChild(child); err != nil {
+		if err := appendChild(child); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// ReparentPolicy returns the policy for children that already have a parent.
+func (c *CompositionBase) ReparentPolicy() ReparentPolicy {
+	return c.reparentPolicy
+}
+
+// SetReparentPolicy sets the policy for children that already have a
+// parent. The default, ReparentAllow, lets an item be shared between
+// compositions, which does not serialize correctly; ReparentError and
+// ReparentClone prevent it.
+func (c *CompositionBase) SetReparentPolicy(policy ReparentPolicy) {
+	c.reparentPolicy = policy
+}
+
+// adoptChild returns the child to insert for the reparent policy: the
+// child itself if it has no parent or under ReparentAllow, or a clone
+// under ReparentClone.
+func (c *CompositionBase) adoptChild(child Composable) (Composable, error) {
+	if child.Parent() == nil || c.reparentPolicy == ReparentAllow {
+		return child, nil
+	}
+	if c.reparentPolicy == ReparentClone {
+		if clone, ok := child.Clone().(Composable); ok {
+			return clone, nil
+		}
+	}
+	return nil, ErrChildAlreadyParented
+}
+
 // InsertChild inserts a child at the given index.
 // Note: The concrete composition type should call this and then set itself as parent.
 func (c *CompositionBase) InsertChild(index int, child Composable) error {
 	if index < 0 || index > len(c.children) {
 		return &IndexError{Index: index, Size: len(c.children)}
 	}
+	child, err := c.adoptChild(child)
+	if err != nil {
+		return err
+	}
 	// Use setParentRaw to set 'c' as parent - concrete types will override as needed
 	if cb, ok := child.(interface{ setParentRaw(any) }); ok {
 		cb.setParentRaw(c)
 	}
 	c.children = append(c.children[:index], append([]Composable{child}, c.children[index:]...)...)
+	c.touch()
 	return nil
 }
 
 // SetChild sets the child at the given index.
 func (c *CompositionBase) SetChild(index int, child Composable) error {
+	_, err := c.ReplaceChild(index, child)
+	return err
+}
+
+// ReplaceChild replaces the child at the given index and returns the
+// detached previous child.
+func (c *CompositionBase) ReplaceChild(index int, child Composable) (Composable, error) {
 	if index < 0 || index >= len(c.children) {
-		return &IndexError{Index: index, Size: len(c.children)}
+		return nil, &IndexError{Index: index, Size: len(c.children)}
 	}
-	c.children[index].SetParent(nil)
+	old := c.children[index]
+	if old == child {
+		return old, nil
+	}
+	child, err := c.adoptChild(child)
+	if err != nil {
+		return nil, err
+	}
+	old.SetParent(nil)
 	if cb, ok := child.(interface{ setParentRaw(any) }); ok {
 		cb.setParentRaw(c)
 	}
 	c.children[index] = child
-	return nil
+	c.touch()
+	return old, nil
 }
 
 // RemoveChild removes the child at the given index.
@@ -167,6 +276,7 @@ func (c *CompositionBase) RemoveChild(index int) error {
 	}
 	c.children[index].SetParent(nil)
 	c.children = append(c.children[:index], c.children[index+1:]...)
+	c.touch()
 	return nil
 }
 
@@ -221,6 +331,9 @@ func (c *CompositionBase) RangeOfChild(child Composable) (opentime.TimeRange, er
 	if err != nil {
 		return opentime.TimeRange{}, err
 	}
+	if self, ok := c.Self().(Composition); ok {
+		return self.RangeOfChildAtIndex(index)
+	}
 	return c.RangeOfChildAtIndex(index)
 }
 
@@ -355,58 +468,6 @@ func (c *CompositionBase) ChildrenInRange(searchRange opentime.TimeRange) ([]Com
 	return result, nil
 }
 
-// FindChildren finds children matching the given filter.
-func (c *CompositionBase) FindChildren(searchRange *opentime.TimeRange, shallowSearch bool, filter func(Composable) bool) []Composable {
-	var result []Composable
-	var children []Composable
-
-	if searchRange != nil {
-		children, _ = c.ChildrenInRange(*searchRange)
-	} else {
-		children = c.children
-	}
-
-	for _, child := range children {
-		if filter == nil || filter(child) {
-			result = append(result, child)
-		}
-
-		if !shallowSearch {
-			if comp, ok := child.(Composition); ok {
-				var childRange *opentime.TimeRange
-				if searchRange != nil {
-					// Transform search range to child's coordinate system
-					r, err := c.RangeOfChild(child)
-					if err == nil {
-						transformed := opentime.NewTimeRange(
-							searchRange.StartTime().Sub(r.StartTime()),
-							searchRange.Duration(),
-						)
-						childRange = &transformed
-					}
-				}
-				childResults := comp.FindChildren(childRange, false, filter)
-				result = append(result, childResults...)
-			}
-		}
-	}
-
-	return result
-}
-
-// FindClips finds all clips.
-func (c *CompositionBase) FindClips(searchRange *opentime.TimeRange, shallowSearch bool) []*Clip {
-	children := c.FindChildren(searchRange, shallowSearch, func(child Composable) bool {
-		_, ok := child.(*Clip)
-		return ok
-	})
-	result := make([]*Clip, len(children))
-	for i, child := range children {
-		result[i] = child.(*Clip)
-	}
-	return result
-}
-
 // Duration returns the duration of the composition.
 func (c *CompositionBase) Duration() (opentime.RationalTime, error) {
 	if c.sourceRange != nil {
@@ -0,0 +1,239 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"slices"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// SearchConfig holds options for FindChildren, FindClips and the other
+// Find methods of compositions and timelines.
+//
+// The search walks children depth first, returning a composition before
+// its own children. A search range is given in the coordinates of the
+// composition being searched and is narrowed to each nested composition's
+// trimmed range on the way down, so items trimmed out of view are not
+// found. Every layer of a nested stack is searched. A transition matches
+// the range it spans around its cut point, from the in offset before the
+// cut to the out offset after it. The LinearTimeWarp and FreezeFrame
+// effects of a nested composition scale the range into its time, so a
+// stack played at double speed is searched over twice the range.
+type SearchConfig struct {
+	// MaxDepth limits how deep the search goes. Direct children are at
+	// depth 1; zero means no limit. A shallow search is a depth of 1.
+	MaxDepth int
+	// TrackKinds, if not empty, skips tracks of other kinds along with
+	// everything in them.
+	TrackKinds []string
+	// RespectEnabled skips disabled items along with everything in them,
+	// and finds nothing in a composition that is not enabled in its
+	// hierarchy.
+	RespectEnabled bool
+	// IncludeDisabled makes ResolvedClips, which leaves out disabled items
+	// by default, resolve them too. Searches find disabled items unless
+	// RespectEnabled is set.
+	IncludeDisabled bool
+}
+
+// SearchOption is a functional option for searching compositions.
+type SearchOption func(*SearchConfig)
+
+// WithMaxDepth limits how deep a search goes. Direct children are at depth 1.
+func WithMaxDepth(depth int) SearchOption {
+	return func(c *SearchConfig) {
+		c.MaxDepth = depth
+	}
+}
+
+// WithTrackKinds limits a search to tracks of the given kinds.
+func WithTrackKinds(kinds ...string) SearchOption {
+	return func(c *SearchConfig) {
+		c.TrackKinds = append(c.TrackKinds, kinds...)
+	}
+}
+
+// WithRespectEnabled skips disabled items and their contents in a search.
+func WithRespectEnabled() SearchOption {
+	return func(c *SearchConfig) {
+		c.RespectEnabled = true
+	}
+}
+
+// WithIncludeDisabled makes ResolvedClips include disabled items and
+// their contents.
+func WithIncludeDisabled() SearchOption {
+	return func(c *SearchConfig) {
+		c.IncludeDisabled = true
+	}
+}
+
+func newSearchConfig(shallowSearch bool, opts []SearchOption) SearchConfig {
+	var cfg SearchConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if shallowSearch {
+		cfg.MaxDepth = 1
+	}
+	return cfg
+}
+
+// childRanger is the part of a composition the search needs.
+type childRanger interface {
+	Children() []Composable
+	RangeOfChildAtIndex(index int) (opentime.TimeRange, error)
+}
+
+// searchChildren appends the matching descendants of comp to result.
+func searchChildren(
+	comp childRanger,
+	searchRange *opentime.TimeRange,
+	depth int,
+	cfg *SearchConfig,
+	filter func(Composable) bool,
+	result []Composable,
+) []Composable {
+	for i, child := range comp.Children() {
+		if track, ok := child.(*Track); ok && len(cfg.TrackKinds) > 0 && !slices.Contains(cfg.TrackKinds, track.Kind()) {
+			continue
+		}
+		if item, ok := child.(Item); ok && cfg.RespectEnabled && !item.Enabled() {
+			continue
+		}
+
+		var childRange opentime.TimeRange
+		if searchRange != nil {
+			r, err := comp.RangeOfChildAtIndex(i)
+			if err != nil {
+				continue
+			}
+			if transition, ok := child.(*Transition); ok {
+				r = opentime.NewTimeRange(
+					r.StartTime().Sub(transition.InOffset()),
+					transition.InOffset().Add(transition.OutOffset()),
+				)
+			}
+			if !searchRange.Intersects(r, opentime.DefaultEpsilon) {
+				continue
+			}
+			childRange = r
+		}
+
+		if filter == nil || filter(child) {
+			result = append(result, child)
+		}
+		if cfg.MaxDepth > 0 && depth >= cfg.MaxDepth {
+			continue
+		}
+		nested, ok := child.(Composition)
+		if !ok {
+			continue
+		}
+
+		var nestedRange *opentime.TimeRange
+		if searchRange != nil {
+			trimmed, err := nested.TrimmedRange()
+			if err != nil {
+				continue
+			}
+			// Intersect with the child's range, then map into its coordinates.
+			visible, ok := intersectRange(*searchRange, childRange)
+			if !ok {
+				continue
+			}
+			r := contentRange(trimmed.StartTime(), visible.StartTime().Sub(childRange.StartTime()), visible.Duration(), effectsTimeScalar(nested.Effects()))
+			nestedRange = &r
+		}
+		result = searchChildren(nested, nestedRange, depth+1, cfg, filter, result)
+	}
+	return result
+}
+
+// contentRange returns the range of a composition's own time played over
+// duration from offset into it, starting at its trimmed start and moving
+// at scalar speed. A freeze plays the single frame at the offset.
+func contentRange(trimmedStart, offset, duration opentime.RationalTime, scalar float64) opentime.TimeRange {
+	if scalar == 1 {
+		return opentime.NewTimeRange(trimmedStart.Add(offset), duration)
+	}
+	from := trimmedStart.Add(scaleTime(offset, scalar))
+	if scalar == 0 {
+		return opentime.NewTimeRange(from, opentime.NewRationalTime(1, from.Rate()))
+	}
+	to := trimmedStart.Add(scaleTime(offset.Add(duration), scalar))
+	if scalar < 0 {
+		from, to = to, from
+	}
+	return opentime.NewTimeRange(from, to.Sub(from))
+}
+
+// scaleTime returns t multiplied by scalar.
+func scaleTime(t opentime.RationalTime, scalar float64) opentime.RationalTime {
+	return opentime.NewRationalTime(t.Value()*scalar, t.Rate())
+}
+
+// searchDisabled reports whether comp is excluded from a search by being
+// disabled in its hierarchy.
+func searchDisabled(comp childRanger, cfg *SearchConfig) bool {
+	item, ok := comp.(Item)
+	return ok && cfg.RespectEnabled && !item.EnabledInHierarchy()
+}
+
+// findOfType returns the descendants of comp that are of type T.
+func findOfType[T Composable](comp childRanger, searchRange *opentime.TimeRange, shallowSearch bool, opts []SearchOption) []T {
+	cfg := newSearchConfig(shallowSearch, opts)
+	if searchDisabled(comp, &cfg) {
+		return nil
+	}
+	children := searchChildren(comp, searchRange, 1, &cfg, func(child Composable) bool {
+		_, ok := child.(T)
+		return ok
+	}, nil)
+	result := make([]T, len(children))
+	for i, child := range children {
+		result[i] = child.(T)
+	}
+	return result
+}
+
+// searchSelf returns the concrete composition, so its own child ranges
+// are used rather than the sequential default.
+func (c *CompositionBase) searchSelf() childRanger {
+	if self, ok := c.Self().(Composition); ok {
+		return self
+	}
+	return c
+}
+
+// FindChildren finds children matching the given filter. See SearchConfig
+// for how the search range, depth and track kinds apply.
+func (c *CompositionBase) FindChildren(searchRange *opentime.TimeRange, shallowSearch bool, filter func(Composable) bool, opts ...SearchOption) []Composable {
+	cfg := newSearchConfig(shallowSearch, opts)
+	if searchDisabled(c.searchSelf(), &cfg) {
+		return nil
+	}
+	return searchChildren(c.searchSelf(), searchRange, 1, &cfg, filter, nil)
+}
+
+// FindClips finds all clips.
+func (c *CompositionBase) FindClips(searchRange *opentime.TimeRange, shallowSearch bool, opts ...SearchOption) []*Clip {
+	return findOfType[*Clip](c.searchSelf(), searchRange, shallowSearch, opts)
+}
+
+// FindGaps finds all gaps.
+func (c *CompositionBase) FindGaps(searchRange *opentime.TimeRange, shallowSearch bool, opts ...SearchOption) []*Gap {
+	return findOfType[*Gap](c.searchSelf(), searchRange, shallowSearch, opts)
+}
+
+// FindTransitions finds all transitions.
+func (c *CompositionBase) FindTransitions(searchRange *opentime.TimeRange, shallowSearch bool, opts ...SearchOption) []*Transition {
+	return findOfType[*Transition](c.searchSelf(), searchRange, shallowSearch, opts)
+}
+
+// FindStacks finds all nested stacks.
+func (c *CompositionBase) FindStacks(searchRange *opentime.TimeRange, shallowSearch bool, opts ...SearchOption) []*Stack {
+	return findOfType[*Stack](c.searchSelf(), searchRange, shallowSearch, opts)
+}
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func searchTestClip(name string, frames float64) *Clip {
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(frames, 24))
+	return NewClip(name, nil, &sr, nil, nil, nil, "", nil)
+}
+
+func searchTestRange(start, frames float64) *opentime.TimeRange {
+	r := opentime.NewTimeRange(opentime.NewRationalTime(start, 24), opentime.NewRationalTime(frames, 24))
+	return &r
+}
+
+// searchTestTimeline builds
+//
+//	V1: a[0,24) (dissolve at 24) b[24,48) stack[48,72){ V: c[0,24) e[24,48) }
+//	A1: gap[0,24) d[24,48)
+func searchTestTimeline(t *testing.T) *Timeline {
+	t.Helper()
+	timeline := NewTimeline("search", nil, nil)
+
+	v1 := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	v1.AppendChild(searchTestClip("a", 24))
+	v1.AppendChild(NewTransition("dissolve", TransitionTypeSMPTEDissolve,
+		opentime.NewRationalTime(6, 24), opentime.NewRationalTime(6, 24), nil))
+	v1.AppendChild(searchTestClip("b", 24))
+
+	nestedTrack := NewTrack("nested", nil, TrackKindVideo, nil, nil)
+	nestedTrack.AppendChild(searchTestClip("c", 24))
+	nestedTrack.AppendChild(searchTestClip("e", 24))
+	nested := NewStack("nested", searchTestRange(0, 24), nil, nil, nil, nil)
+	nested.AppendChild(nestedTrack)
+	v1.AppendChild(nested)
+
+	a1 := NewTrack("A1", nil, TrackKindAudio, nil, nil)
+	a1.AppendChild(NewGap("", searchTestRange(0, 24), nil, nil, nil, nil))
+	a1.AppendChild(searchTestClip("d", 24))
+
+	if err := timeline.Tracks().SetChildren([]Composable{v1, a1}); err != nil {
+		t.Fatalf("SetChildren error: %v", err)
+	}
+	return timeline
+}
+
+func clipNames(clips []*Clip) []string {
+	names := make([]string, len(clips))
+	for i, clip := range clips {
+		names[i] = clip.Name()
+	}
+	return names
+}
+
+func TestFindClipsOptions(t *testing.T) {
+	timeline := searchTestTimeline(t)
+
+	tests := []struct {
+		name        string
+		searchRange *opentime.TimeRange
+		shallow     bool
+		opts        []SearchOption
+		want        []string
+	}{
+		{"all", nil, false, nil, []string{"a", "b", "c", "e", "d"}},
+		{"shallow", nil, true, nil, []string{}},
+		{"depth 2", nil, false, []SearchOption{WithMaxDepth(2)}, []string{"a", "b", "d"}},
+		{"video only", nil, false, []SearchOption{WithTrackKinds(TrackKindVideo)}, []string{"a", "b", "c", "e"}},
+		{"range", searchTestRange(30, 10), false, nil, []string{"b", "d"}},
+		{"nested range", searchTestRange(50, 40), false, []SearchOption{WithTrackKinds(TrackKindVideo)}, []string{"c"}},
+	}
+	for _, tt := range tests {
+		got := clipNames(timeline.FindClips(tt.searchRange, tt.shallow, tt.opts...))
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: FindClips = %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: FindClips = %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestFindOtherTypes(t *testing.T) {
+	timeline := searchTestTimeline(t)
+
+	if got := timeline.FindTransitions(searchTestRange(20, 2), false); len(got) != 1 {
+		t.Errorf("expected the dissolve around the cut, got %d", len(got))
+	}
+	if got := timeline.FindTransitions(searchTestRange(31, 10), false); len(got) != 0 {
+		t.Errorf("expected no transition after the out offset, got %d", len(got))
+	}
+	if got := timeline.FindGaps(nil, false); len(got) != 1 {
+		t.Errorf("expected 1 gap, got %d", len(got))
+	}
+	if got := timeline.FindGaps(nil, false, WithTrackKinds(TrackKindVideo)); len(got) != 0 {
+		t.Errorf("expected no video gaps, got %d", len(got))
+	}
+	if got := timeline.FindStacks(nil, false); len(got) != 1 || got[0].Name() != "nested" {
+		t.Errorf("FindStacks = %v, want the nested stack", got)
+	}
+
+	v1 := timeline.Tracks().Children()[0].(*Track)
+	if got := v1.FindChildren(nil, true, nil); len(got) != 4 {
+		t.Errorf("expected 4 direct children, got %d", len(got))
+	}
+}
+
+func TestFindRespectEnabled(t *testing.T) {
+	timeline := searchTestTimeline(t)
+	v1 := timeline.Tracks().Children()[0].(*Track)
+	b := v1.Children()[2].(*Clip)
+	nested := v1.Children()[3].(*Stack)
+	c := nested.Children()[0].(*Track).Children()[0].(*Clip)
+
+	b.SetEnabled(false)
+	nested.SetEnabled(false)
+	if b.EnabledInHierarchy() || c.EnabledInHierarchy() || !c.Enabled() {
+		t.Error("expected b and c to be disabled in the hierarchy")
+	}
+	if got := clipNames(timeline.FindClips(nil, false)); len(got) != 5 {
+		t.Errorf("expected disabled clips without the option, got %v", got)
+	}
+	got := clipNames(timeline.FindClips(nil, false, WithRespectEnabled()))
+	if len(got) != 2 || got[0] != "a" || got[1] != "d" {
+		t.Errorf("FindClips = %v, want [a d]", got)
+	}
+	if got := nested.FindClips(nil, false, WithRespectEnabled()); len(got) != 0 {
+		t.Errorf("expected nothing in a disabled stack, got %v", clipNames(got))
+	}
+
+	nested.SetEnabled(true)
+	timeline.Tracks().SetEnabled(false)
+	if c.EnabledInHierarchy() {
+		t.Error("expected a disabled timeline stack to disable c")
+	}
+	if got := timeline.FindClips(nil, false, WithRespectEnabled()); len(got) != 0 {
+		t.Errorf("expected nothing under a disabled stack, got %v", clipNames(got))
+	}
+}
+
+func TestFindThroughTimeEffects(t *testing.T) {
+	timeline := searchTestTimeline(t)
+	v1 := timeline.Tracks().Children()[0].(*Track)
+	nested := v1.Children()[3].(*Stack)
+
+	// The last half of the stack shows c at normal speed, and e at double.
+	if got := clipNames(v1.FindClips(searchTestRange(60, 12), false)); len(got) != 1 || got[0] != "c" {
+		t.Errorf("FindClips = %v, want [c]", got)
+	}
+	nested.SetEffects([]Effect{NewLinearTimeWarp("", "", 2, nil)})
+	if got := clipNames(v1.FindClips(searchTestRange(60, 12), false)); len(got) != 1 || got[0] != "e" {
+		t.Errorf("FindClips at double speed = %v, want [e]", got)
+	}
+	if got := clipNames(v1.FindClips(searchTestRange(48, 24), false)); len(got) != 2 {
+		t.Errorf("FindClips over the stack at double speed = %v, want [c e]", got)
+	}
+
+	// A freeze shows the first frame throughout.
+	nested.SetEffects([]Effect{NewFreezeFrame("", nil)})
+	if got := clipNames(v1.FindClips(searchTestRange(60, 12), false)); len(got) != 1 || got[0] != "c" {
+		t.Errorf("FindClips in a freeze = %v, want [c]", got)
+	}
+}
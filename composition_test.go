@@ -4,6 +4,7 @@
 package gotio
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/Avalanche-io/gotio/opentime"
@@ -368,3 +369,96 @@ func TestCompositionSourceRange(t *testing.T) {
 		t.Errorf("Trimmed duration = %v, want 24", trimmed.Duration().Value())
 	}
 }
+
+func TestCompositionSingleParent(t *testing.T) {
+	v1 := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	v2 := NewTrack("V2", nil, TrackKindVideo, nil, nil)
+	clip := NewClip("clip", nil, nil, nil, nil, nil, "", nil)
+
+	if err := v1.AppendChild(clip); err != nil {
+		t.Fatalf("AppendChild error: %v", err)
+	}
+	if clip.Parent() != v1 {
+		t.Error("expected V1 to be the parent")
+	}
+
+	// By default an item may be given a second parent, as it always could
+	shared := NewTrack("shared", nil, TrackKindVideo, nil, nil)
+	if err := shared.AppendChild(clip); err != nil || clip.Parent() != shared {
+		t.Fatalf("AppendChild of a parented clip = %v, parent %v", err, clip.Parent())
+	}
+	clip.SetParent(v1)
+
+	v1.SetReparentPolicy(ReparentError)
+	v2.SetReparentPolicy(ReparentError)
+	if err := v2.AppendChild(clip); !errors.Is(err, ErrChildAlreadyParented) {
+		t.Errorf("AppendChild to second track error = %v, want ErrChildAlreadyParented", err)
+	}
+	if err := v1.AppendChild(clip); !errors.Is(err, ErrChildAlreadyParented) {
+		t.Errorf("AppendChild twice error = %v, want ErrChildAlreadyParented", err)
+	}
+	if len(v2.Children()) != 0 || len(v1.Children()) != 1 {
+		t.Errorf("children = %d, %d, want 1, 0", len(v1.Children()), len(v2.Children()))
+	}
+
+	stack := NewStack("stack", nil, nil, nil, nil, nil)
+	stack.SetReparentPolicy(ReparentClone)
+	if err := stack.AppendChild(v1); err != nil {
+		t.Fatalf("AppendChild error: %v", err)
+	}
+	if err := stack.AppendChild(v1); err != nil {
+		t.Fatalf("AppendChild with ReparentClone error: %v", err)
+	}
+	if stack.Children()[1] == v1 || stack.Children()[1].Parent() != stack {
+		t.Error("expected a clone parented to the stack")
+	}
+	if v1.Parent() != stack {
+		t.Error("original should stay in the stack")
+	}
+}
+
+func TestCompositionReplaceChild(t *testing.T) {
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	a := NewClip("a", nil, nil, nil, nil, nil, "", nil)
+	b := NewClip("b", nil, nil, nil, nil, nil, "", nil)
+	track.AppendChild(a)
+
+	old, err := track.ReplaceChild(0, b)
+	if err != nil {
+		t.Fatalf("ReplaceChild error: %v", err)
+	}
+	if old != a || a.Parent() != nil {
+		t.Error("expected the replaced child to be returned detached")
+	}
+	if track.Children()[0] != b || b.Parent() != track {
+		t.Error("expected b to be the child of the track")
+	}
+	if _, err := track.ReplaceChild(0, b); err != nil {
+		t.Errorf("ReplaceChild with the same child error: %v", err)
+	}
+	if _, err := track.ReplaceChild(1, a); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("ReplaceChild error = %v, want ErrIndexOutOfRange", err)
+	}
+
+	if err := track.RemoveChild(0); err != nil {
+		t.Fatalf("RemoveChild error: %v", err)
+	}
+	if b.Parent() != nil {
+		t.Error("expected removed child to be detached")
+	}
+	other := NewTrack("V2", nil, TrackKindVideo, nil, nil)
+	if err := other.AppendChild(b); err != nil {
+		t.Errorf("AppendChild of a removed child error: %v", err)
+	}
+}
+
+func TestTrackSetChildrenParent(t *testing.T) {
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	clip := NewClip("clip", nil, nil, nil, nil, nil, "", nil)
+	if err := track.SetChildren([]Composable{clip}); err != nil {
+		t.Fatalf("SetChildren error: %v", err)
+	}
+	if clip.Parent() != track {
+		t.Errorf("Parent = %T, want the track", clip.Parent())
+	}
+}
@@ -0,0 +1,374 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package conformance checks that gotio reads and writes OpenTimelineIO
+// files as the reference implementation does.
+//
+// Documents are compared structurally rather than byte by byte: key order,
+// whitespace and number formatting such as 24 against 24.0 are ignored,
+// and everything else that differs is reported as a Divergence with its
+// path in the document.
+//
+//	report, err := conformance.CheckCorpus("otio/tests/sample_data")
+//	report.WriteText(os.Stdout)
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+// DefaultTolerance is the relative difference below which numbers are
+// equal.
+const DefaultTolerance = 1e-9
+
+// Kind is the kind of a divergence.
+type Kind string
+
+const (
+	// Missing is a value in the reference that gotio did not write.
+	Missing Kind = "missing"
+	// Extra is a value gotio wrote that is not in the reference.
+	Extra Kind = "extra"
+	// Changed is a value written differently.
+	Changed Kind = "changed"
+)
+
+// Divergence is one difference between a reference document and gotio's.
+type Divergence struct {
+	// Path locates the value, such as tracks.children[0].source_range.
+	Path string
+	Kind Kind
+	// Reference and Got are the values in each document, nil if absent.
+	Reference any
+	Got       any
+}
+
+func (d Divergence) String() string {
+	switch d.Kind {
+	case Missing:
+		return fmt.Sprintf("%s: missing, reference has %s", d.Path, describe(d.Reference))
+	case Extra:
+		return fmt.Sprintf("%s: extra %s", d.Path, describe(d.Got))
+	}
+	return fmt.Sprintf("%s: reference has %s, got %s", d.Path, describe(d.Reference), describe(d.Got))
+}
+
+// describe formats a value for a divergence message, eliding objects and
+// arrays.
+func describe(v any) string {
+	switch v := v.(type) {
+	case map[string]any:
+		if schema, ok := v["OTIO_SCHEMA"].(string); ok {
+			return schema
+		}
+		return fmt.Sprintf("object of %d keys", len(v))
+	case []any:
+		return fmt.Sprintf("array of %d", len(v))
+	case json.Number:
+		return v.String()
+	case string:
+		return strconv.Quote(v)
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// Config holds options for comparing documents.
+type Config struct {
+	// Tolerance is the relative difference below which numbers are equal.
+	Tolerance float64
+	// IgnoredKeys are object keys left out of the comparison at any depth.
+	IgnoredKeys []string
+	// StrictNulls reports a null in one document where the other has no
+	// key. By default they are equal, since the reference writes unset
+	// optional fields as null and gotio may leave them out.
+	StrictNulls bool
+	// ReferenceDir, if set, holds the reference implementation's output
+	// for each file of a corpus, under the same name. CheckCorpus
+	// compares against it instead of the input file.
+	ReferenceDir string
+}
+
+// Option is a functional option for comparisons.
+type Option func(*Config)
+
+// WithTolerance sets the relative difference below which numbers are equal.
+func WithTolerance(tolerance float64) Option {
+	return func(c *Config) {
+		c.Tolerance = tolerance
+	}
+}
+
+// WithIgnoredKeys leaves the given object keys out of the comparison.
+func WithIgnoredKeys(keys ...string) Option {
+	return func(c *Config) {
+		c.IgnoredKeys = append(c.IgnoredKeys, keys...)
+	}
+}
+
+// WithStrictNulls sets whether a null and a missing key differ.
+func WithStrictNulls(strict bool) Option {
+	return func(c *Config) {
+		c.StrictNulls = strict
+	}
+}
+
+// WithReferenceDir sets the directory of reference output for a corpus.
+func WithReferenceDir(dir string) Option {
+	return func(c *Config) {
+		c.ReferenceDir = dir
+	}
+}
+
+func newConfig(opts []Option) Config {
+	cfg := Config{Tolerance: DefaultTolerance}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Compare returns the divergences of got from reference, in document
+// order.
+func Compare(reference, got []byte, opts ...Option) ([]Divergence, error) {
+	cfg := newConfig(opts)
+	ref, err := parse(reference)
+	if err != nil {
+		return nil, fmt.Errorf("conformance: reference: %w", err)
+	}
+	out, err := parse(got)
+	if err != nil {
+		return nil, fmt.Errorf("conformance: got: %w", err)
+	}
+	c := comparer{cfg: cfg, ignored: make(map[string]bool)}
+	for _, key := range cfg.IgnoredKeys {
+		c.ignored[key] = true
+	}
+	c.compare("$", ref, out)
+	return c.divergences, nil
+}
+
+// RoundTrip reads data with gotio, writes it back and returns the
+// divergences of the output from data.
+func RoundTrip(data []byte, opts ...Option) ([]Divergence, error) {
+	out, err := roundTrip(data)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(data, out, opts...)
+}
+
+func roundTrip(data []byte) ([]byte, error) {
+	obj, err := gotio.FromJSONBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("conformance: read: %w", err)
+	}
+	out, err := gotio.ToJSONBytes(obj)
+	if err != nil {
+		return nil, fmt.Errorf("conformance: write: %w", err)
+	}
+	return out, nil
+}
+
+// parse decodes a document, keeping numbers exact and reading the
+// Infinity and NaN literals of the reference as null.
+func parse(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(gotio.SanitizeJSON(data)))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+type comparer struct {
+	cfg         Config
+	ignored     map[string]bool
+	divergences []Divergence
+}
+
+func (c *comparer) add(path string, kind Kind, ref, got any) {
+	c.divergences = append(c.divergences, Divergence{Path: path, Kind: kind, Reference: ref, Got: got})
+}
+
+func (c *comparer) compare(path string, ref, got any) {
+	switch r := ref.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			c.add(path, Changed, ref, got)
+			return
+		}
+		c.compareObjects(path, r, g)
+	case []any:
+		g, ok := got.([]any)
+		if !ok {
+			c.add(path, Changed, ref, got)
+			return
+		}
+		for i := range max(len(r), len(g)) {
+			elem := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(g):
+				c.add(elem, Missing, r[i], nil)
+			case i >= len(r):
+				c.add(elem, Extra, nil, g[i])
+			default:
+				c.compare(elem, r[i], g[i])
+			}
+		}
+	case json.Number:
+		g, ok := got.(json.Number)
+		if !ok || !c.numbersEqual(r, g) {
+			c.add(path, Changed, ref, got)
+		}
+	default:
+		if ref != got {
+			c.add(path, Changed, ref, got)
+		}
+	}
+}
+
+func (c *comparer) compareObjects(path string, ref, got map[string]any) {
+	keys := make([]string, 0, len(ref)+len(got))
+	for key := range ref {
+		keys = append(keys, key)
+	}
+	for key := range got {
+		if _, ok := ref[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		if c.ignored[key] {
+			continue
+		}
+		child := path + "." + key
+		r, inRef := ref[key]
+		g, inGot := got[key]
+		switch {
+		case !inGot && (inRef && (r != nil || c.cfg.StrictNulls)):
+			c.add(child, Missing, r, nil)
+		case !inRef && (g != nil || c.cfg.StrictNulls):
+			c.add(child, Extra, nil, g)
+		case inRef && inGot:
+			c.compare(child, r, g)
+		}
+	}
+}
+
+// numbersEqual compares numbers within the relative tolerance.
+func (c *comparer) numbersEqual(a, b json.Number) bool {
+	if a == b {
+		return true
+	}
+	x, errX := a.Float64()
+	y, errY := b.Float64()
+	if errX != nil || errY != nil {
+		return false
+	}
+	return math.Abs(x-y) <= c.cfg.Tolerance*math.Max(1, math.Max(math.Abs(x), math.Abs(y)))
+}
+
+// FileResult is the outcome of checking one file of a corpus.
+type FileResult struct {
+	Name string
+	// Err is set if the file could not be read, decoded or written.
+	Err         error
+	Divergences []Divergence
+}
+
+// Passed reports whether the file round-tripped without divergences.
+func (r FileResult) Passed() bool {
+	return r.Err == nil && len(r.Divergences) == 0
+}
+
+// Report is the outcome of checking a corpus.
+type Report struct {
+	Files []FileResult
+}
+
+// Failed returns the results of the files that did not pass.
+func (r Report) Failed() []FileResult {
+	var failed []FileResult
+	for _, file := range r.Files {
+		if !file.Passed() {
+			failed = append(failed, file)
+		}
+	}
+	return failed
+}
+
+// WriteText writes a line per file followed by its divergences, and a
+// summary.
+func (r Report) WriteText(w io.Writer) error {
+	for _, file := range r.Files {
+		switch {
+		case file.Err != nil:
+			fmt.Fprintf(w, "FAIL %s: %v\n", file.Name, file.Err)
+		case len(file.Divergences) > 0:
+			fmt.Fprintf(w, "FAIL %s: %d divergences\n", file.Name, len(file.Divergences))
+			for _, d := range file.Divergences {
+				fmt.Fprintf(w, "    %s\n", d)
+			}
+		default:
+			fmt.Fprintf(w, "ok   %s\n", file.Name)
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d of %d files conform\n", len(r.Files)-len(r.Failed()), len(r.Files))
+	return err
+}
+
+// CheckCorpus round-trips every .otio file in dir through gotio and
+// compares the output with the reference: the file of the same name in
+// the configured reference directory, or else the input itself, which
+// for the OpenTimelineIO sample data is reference output.
+func CheckCorpus(dir string, opts ...Option) (Report, error) {
+	cfg := newConfig(opts)
+	files, err := filepath.Glob(filepath.Join(dir, "*.otio"))
+	if err != nil {
+		return Report{}, err
+	}
+	if len(files) == 0 {
+		return Report{}, fmt.Errorf("conformance: no .otio files in %s", dir)
+	}
+
+	var report Report
+	for _, file := range files {
+		result := FileResult{Name: filepath.Base(file)}
+		result.Divergences, result.Err = checkFile(file, cfg, opts)
+		report.Files = append(report.Files, result)
+	}
+	return report, nil
+}
+
+func checkFile(file string, cfg Config, opts []Option) ([]Divergence, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	reference := data
+	if cfg.ReferenceDir != "" {
+		if reference, err = os.ReadFile(filepath.Join(cfg.ReferenceDir, filepath.Base(file))); err != nil {
+			return nil, err
+		}
+	}
+	out, err := roundTrip(data)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(reference, out, opts...)
+}
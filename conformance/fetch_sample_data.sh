@@ -0,0 +1,63 @@
+#!/bin/bash
+# Fetch the OpenTimelineIO sample data and write reference output for it
+# with the Python implementation, for TestReferenceCorpus.
+#
+# Usage: conformance/fetch_sample_data.sh [dir]
+#
+# dir, conformance/sample_data by default, gets input/ with the .otio
+# sample files and reference/ with each file as read and written again
+# by opentimelineio. Files the reference implementation cannot read are
+# left out and listed. OTIO_VERSION picks the release, v0.17.0 by
+# default. Requires git, python3 and network access.
+
+set -euo pipefail
+
+SCRIPT_DIR="$(cd "$(dirname "$0")" && pwd)"
+DEST="${1:-$SCRIPT_DIR/sample_data}"
+OTIO_VERSION="${OTIO_VERSION:-v0.17.0}"
+
+WORK="$(mktemp -d)"
+trap 'rm -rf "$WORK"' EXIT
+
+echo "Fetching OpenTimelineIO $OTIO_VERSION sample data..."
+git clone --quiet --depth 1 --branch "$OTIO_VERSION" --filter=blob:none --sparse \
+    https://github.com/AcademySoftwareFoundation/OpenTimelineIO "$WORK/otio"
+git -C "$WORK/otio" sparse-checkout set tests/sample_data
+
+echo "Installing opentimelineio ${OTIO_VERSION#v}..."
+python3 -m venv "$WORK/venv"
+"$WORK/venv/bin/pip" install --quiet "opentimelineio==${OTIO_VERSION#v}"
+
+rm -rf "$DEST"
+mkdir -p "$DEST/input" "$DEST/reference"
+cp "$WORK/otio/LICENSE.txt" "$DEST/" 2>/dev/null || true
+
+"$WORK/venv/bin/python" - "$WORK/otio/tests/sample_data" "$DEST" <<'PY'
+import glob
+import os
+import shutil
+import sys
+
+import opentimelineio as otio
+
+src, dest = sys.argv[1], sys.argv[2]
+written, skipped = 0, []
+for path in sorted(glob.glob(os.path.join(src, "*.otio"))):
+    name = os.path.basename(path)
+    try:
+        obj = otio.adapters.read_from_file(path)
+        otio.adapters.write_to_file(obj, os.path.join(dest, "reference", name))
+    except Exception as e:
+        skipped.append("%s: %s" % (name, e))
+        continue
+    shutil.copy(path, os.path.join(dest, "input", name))
+    written += 1
+
+print("Wrote reference output for %d files" % written)
+for line in skipped:
+    print("Skipped " + line)
+if written == 0:
+    sys.exit("no sample files could be read")
+PY
+
+echo "Sample data in $DEST"
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/Avalanche-io/gotio/internal/jsonenc"
+)
+
+// HashConfig holds options for ContentHash.
+type HashConfig struct {
+	// ExcludeMetadataKeys are metadata keys left out of the hash, at any
+	// depth of any object's metadata. Use it for volatile values such as
+	// save times or session ids that should not count as a change.
+	ExcludeMetadataKeys []string
+}
+
+// HashOption is a functional option for ContentHash.
+type HashOption func(*HashConfig)
+
+// WithExcludedMetadataKeys leaves the given metadata keys out of the hash.
+func WithExcludedMetadataKeys(keys ...string) HashOption {
+	return func(c *HashConfig) {
+		c.ExcludeMetadataKeys = append(c.ExcludeMetadataKeys, keys...)
+	}
+}
+
+// ContentHash returns the hex encoded SHA-256 digest of the object's
+// canonical serialization. Equal objects hash the same across runs and
+// processes, so the hash can serve as a cache key or for change detection
+// and deduplication. Any object can be hashed, including a single clip or
+// track of a larger timeline; its parent is not part of the hash.
+func ContentHash(obj SerializableObject, opts ...HashOption) (string, error) {
+	var cfg HashConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	h := sha256.New()
+	enc := jsonenc.NewEncoder(h)
+	enc.SetCanonical(true)
+	enc.SetOmittedMapKeys(cfg.ExcludeMetadataKeys...)
+	defer enc.Release()
+
+	if err := jsonenc.EncodeValue(enc, obj); err != nil {
+		return "", err
+	}
+	if err := enc.Flush(); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"testing"
+)
+
+func TestContentHash(t *testing.T) {
+	makeTimeline := func(saved string) *Timeline {
+		md := AnyDictionary{"saved_at": saved, "vendor": AnyDictionary{"session": saved, "shot": "010"}}
+		for _, k := range []string{"z", "a", "m", "c"} {
+			md[k] = float64(24)
+		}
+		clip := NewClip("shot", nil, nil, md, nil, nil, "", nil)
+		track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+		if err := track.AppendChild(clip); err != nil {
+			t.Fatalf("AppendChild error: %v", err)
+		}
+		timeline := NewTimeline("edit", nil, nil)
+		if err := timeline.Tracks().AppendChild(track); err != nil {
+			t.Fatalf("AppendChild error: %v", err)
+		}
+		return timeline
+	}
+
+	first, err := ContentHash(makeTimeline("monday"))
+	if err != nil {
+		t.Fatalf("ContentHash error: %v", err)
+	}
+	if len(first) != 64 {
+		t.Errorf("expected a hex SHA-256 digest, got %q", first)
+	}
+	for i := 0; i < 10; i++ {
+		if h, _ := ContentHash(makeTimeline("monday")); h != first {
+			t.Fatalf("hash differs between runs: %s != %s", h, first)
+		}
+	}
+	if h, _ := ContentHash(makeTimeline("tuesday")); h == first {
+		t.Error("expected volatile metadata to change the hash by default")
+	}
+
+	exclude := WithExcludedMetadataKeys("saved_at", "session")
+	monday, err := ContentHash(makeTimeline("monday"), exclude)
+	if err != nil {
+		t.Fatalf("ContentHash error: %v", err)
+	}
+	if tuesday, _ := ContentHash(makeTimeline("tuesday"), exclude); tuesday != monday {
+		t.Error("expected excluded keys to be left out of the hash")
+	}
+
+	changed := makeTimeline("monday")
+	changed.Tracks().Children()[0].(*Track).SetName("V2")
+	if h, _ := ContentHash(changed, exclude); h == monday {
+		t.Error("expected a renamed track to change the hash")
+	}
+
+	// A subtree hashes the same on its own as inside the timeline.
+	clip := makeTimeline("monday").FindClips(nil, false)[0]
+	inTree, err := ContentHash(clip)
+	if err != nil {
+		t.Fatalf("ContentHash error: %v", err)
+	}
+	alone, _ := ContentHash(clip.Clone())
+	if inTree != alone {
+		t.Error("expected a subtree hash to ignore its parent")
+	}
+}
@@ -0,0 +1,411 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package debug finds corruption in object graphs built in code, which
+// otherwise shows up much later as wrong times or failed serialization.
+//
+// Basic usage:
+//
+//	report := debug.CheckOwnership(timeline)
+//	if !report.OK() {
+//		log.Print(report)
+//	}
+//
+// Or clone what is shared so the timeline is a tree again:
+//
+//	report := debug.CheckOwnership(timeline, debug.WithFix(true))
+package debug
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+func init() {
+	gotio.RegisterFeature("debug")
+}
+
+// ProblemKind identifies an ownership problem.
+type ProblemKind int
+
+const (
+	// SharedChild is a child found in more than one place in the tree.
+	SharedChild ProblemKind = iota
+	// Cycle is a composition found inside itself.
+	Cycle
+	// WrongParent is a child whose Parent is not the composition holding
+	// it.
+	WrongParent
+	// SharedObject is a marker, effect or media reference held by more
+	// than one item.
+	SharedObject
+	// SharedMetadata is a metadata dictionary, or a dictionary nested in
+	// one, held by more than one object or key.
+	SharedMetadata
+)
+
+// String returns the string representation of a ProblemKind.
+func (k ProblemKind) String() string {
+	switch k {
+	case SharedChild:
+		return "SharedChild"
+	case Cycle:
+		return "Cycle"
+	case WrongParent:
+		return "WrongParent"
+	case SharedObject:
+		return "SharedObject"
+	case SharedMetadata:
+		return "SharedMetadata"
+	default:
+		return fmt.Sprintf("ProblemKind(%d)", k)
+	}
+}
+
+// Problem is one ownership problem.
+type Problem struct {
+	Kind ProblemKind
+	// Path locates the problem, as child indices and names from the
+	// timeline's tracks, such as "tracks/0:V1/2:sh010".
+	Path string
+	// Other is the path the shared object was first found at.
+	Other string
+	// Object is the object at fault.
+	Object gotio.SerializableObject
+	// Fixed is set if the problem was fixed.
+	Fixed bool
+}
+
+// String returns a description of the problem.
+func (p Problem) String() string {
+	s := fmt.Sprintf("%s: %s", p.Path, p.Kind)
+	if p.Other != "" {
+		s += " with " + p.Other
+	}
+	if p.Fixed {
+		s += " (fixed)"
+	}
+	return s
+}
+
+// Report is the result of CheckOwnership.
+type Report struct {
+	Problems []Problem
+	// Fixed counts the problems fixed.
+	Fixed int
+}
+
+// OK reports whether no problem was found.
+func (r Report) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// String returns the problems, one per line.
+func (r Report) String() string {
+	lines := make([]string, len(r.Problems))
+	for i, p := range r.Problems {
+		lines[i] = p.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Config holds configuration for CheckOwnership.
+type Config struct {
+	// Fix repairs the problems found: shared children, markers, effects,
+	// media references and dictionaries are replaced with copies where
+	// found again, a composition found inside itself is removed there,
+	// and wrong parents are set.
+	Fix bool
+}
+
+// Option is a functional option for CheckOwnership.
+type Option func(*Config)
+
+// WithFix sets whether problems are repaired.
+func WithFix(fix bool) Option {
+	return func(c *Config) {
+		c.Fix = fix
+	}
+}
+
+// CheckOwnership walks the timeline and reports where it is not a tree:
+// children in more than one place, compositions inside themselves,
+// children with the wrong parent, and markers, effects, media references
+// and metadata dictionaries held in more than one place, where editing one
+// edits the others.
+//
+// CheckOwnership does not lock the timeline; nothing may modify it during
+// the check.
+func CheckOwnership(timeline *gotio.Timeline, opts ...Option) Report {
+	var cfg Config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	c := &checker{
+		cfg:    cfg,
+		seen:   make(map[gotio.SerializableObject]string),
+		maps:   make(map[uintptr]string),
+		inside: make(map[gotio.Composition]bool),
+	}
+	c.checkMetadata(timeline, "timeline")
+	if tracks := timeline.Tracks(); tracks != nil {
+		c.seen[tracks] = "tracks"
+		c.checkObject(tracks, "tracks")
+		c.walk(tracks, "tracks")
+	}
+	return c.report
+}
+
+type checker struct {
+	cfg    Config
+	report Report
+	// seen holds the path each object was first found at.
+	seen map[gotio.SerializableObject]string
+	// maps holds the path each dictionary was first found at.
+	maps map[uintptr]string
+	// inside holds the compositions being walked.
+	inside map[gotio.Composition]bool
+}
+
+// add records a problem, fixed if fix is set and succeeds.
+func (c *checker) add(kind ProblemKind, path, other string, obj gotio.SerializableObject, fix func() bool) {
+	p := Problem{Kind: kind, Path: path, Other: other, Object: obj}
+	if c.cfg.Fix && fix != nil && fix() {
+		p.Fixed = true
+		c.report.Fixed++
+	}
+	c.report.Problems = append(c.report.Problems, p)
+}
+
+func (c *checker) walk(composition gotio.Composition, path string) {
+	c.inside[composition] = true
+	defer delete(c.inside, composition)
+
+	for i := 0; i < len(composition.Children()); i++ {
+		child := composition.Children()[i]
+		childPath := fmt.Sprintf("%s/%d:%s", path, i, child.Name())
+
+		if nested, ok := child.(gotio.Composition); ok && c.inside[nested] {
+			removed := false
+			c.add(Cycle, childPath, c.seen[nested], child, func() bool {
+				parent := child.Parent()
+				if err := composition.RemoveChild(i); err != nil {
+					return false
+				}
+				child.SetParent(parent)
+				removed = true
+				return true
+			})
+			if removed {
+				i--
+			}
+			continue
+		}
+
+		if first, ok := c.seen[child]; ok {
+			var clone gotio.Composable
+			c.add(SharedChild, childPath, first, child, func() bool {
+				clone, _ = child.Clone().(gotio.Composable)
+				if clone == nil {
+					return false
+				}
+				parent := child.Parent()
+				if err := composition.SetChild(i, clone); err != nil {
+					return false
+				}
+				child.SetParent(parent)
+				return true
+			})
+			if clone == nil {
+				continue
+			}
+			child = clone
+		}
+
+		if child.Parent() != composition {
+			c.add(WrongParent, childPath, "", child, func() bool {
+				child.SetParent(composition)
+				return true
+			})
+		}
+		c.seen[child] = childPath
+		c.checkObject(child, childPath)
+		if nested, ok := child.(gotio.Composition); ok {
+			c.walk(nested, childPath)
+		}
+	}
+}
+
+// checkObject checks the metadata of an item and what it holds.
+func (c *checker) checkObject(obj gotio.Composable, path string) {
+	c.checkMetadata(obj, path)
+	item, ok := obj.(gotio.Item)
+	if !ok {
+		return
+	}
+
+	markers := item.Markers()
+	for j, marker := range markers {
+		markerPath := fmt.Sprintf("%s/markers/%d", path, j)
+		if first, ok := c.seen[marker]; ok {
+			c.add(SharedObject, markerPath, first, marker, func() bool {
+				markers = append([]*gotio.Marker(nil), markers...)
+				markers[j] = marker.Clone().(*gotio.Marker)
+				item.SetMarkers(markers)
+				return true
+			})
+			if markers[j] == marker {
+				continue
+			}
+			marker = markers[j]
+		}
+		c.seen[marker] = markerPath
+		c.checkMetadata(marker, markerPath)
+	}
+
+	effects := item.Effects()
+	for j, effect := range effects {
+		effectPath := fmt.Sprintf("%s/effects/%d", path, j)
+		if first, ok := c.seen[effect]; ok {
+			c.add(SharedObject, effectPath, first, effect, func() bool {
+				clone, ok := effect.Clone().(gotio.Effect)
+				if !ok {
+					return false
+				}
+				effects = append([]gotio.Effect(nil), effects...)
+				effects[j] = clone
+				item.SetEffects(effects)
+				return true
+			})
+			if effects[j] == effect {
+				continue
+			}
+			effect = effects[j]
+		}
+		c.seen[effect] = effectPath
+		c.checkMetadata(effect, effectPath)
+	}
+
+	clip, ok := obj.(*gotio.Clip)
+	if !ok {
+		return
+	}
+	for _, key := range clip.MediaReferenceKeys() {
+		ref := clip.MediaReferences()[key]
+		refPath := fmt.Sprintf("%s/media/%s", path, key)
+		if first, ok := c.seen[ref]; ok {
+			c.add(SharedObject, refPath, first, ref, func() bool {
+				clone, ok := ref.Clone().(gotio.MediaReference)
+				if !ok {
+					return false
+				}
+				refs := make(map[string]gotio.MediaReference, len(clip.MediaReferences()))
+				for k, r := range clip.MediaReferences() {
+					refs[k] = r
+				}
+				refs[key] = clone
+				return clip.SetMediaReferences(refs, clip.ActiveMediaReferenceKey()) == nil
+			})
+			if clip.MediaReferences()[key] == ref {
+				continue
+			}
+			ref = clip.MediaReferences()[key]
+		}
+		c.seen[ref] = refPath
+		c.checkMetadata(ref, refPath)
+	}
+}
+
+// checkMetadata checks the metadata dictionary of obj and the
+// dictionaries nested in it.
+func (c *checker) checkMetadata(obj gotio.SerializableObjectWithMetadata, path string) {
+	metadata := obj.Metadata()
+	if metadata == nil {
+		return
+	}
+	metadataPath := path + "/metadata"
+	if first, ok := c.maps[mapID(metadata)]; ok {
+		copied := false
+		c.add(SharedMetadata, metadataPath, first, obj, func() bool {
+			obj.SetMetadata(metadata.DeepCopy())
+			copied = true
+			return true
+		})
+		if !copied {
+			return
+		}
+		metadata = obj.Metadata()
+	}
+	c.maps[mapID(metadata)] = metadataPath
+	c.checkValues(obj, metadata, metadataPath)
+}
+
+// checkValues checks the dictionaries nested in a dictionary or list.
+func (c *checker) checkValues(obj gotio.SerializableObject, value any, path string) {
+	switch v := value.(type) {
+	case gotio.AnyDictionary:
+		for _, key := range sortedKeys(v) {
+			if child, copied := c.checkValue(obj, v[key], path+"/"+key); copied {
+				v[key] = child
+			}
+		}
+	case map[string]any:
+		for _, key := range sortedKeys(v) {
+			if child, copied := c.checkValue(obj, v[key], path+"/"+key); copied {
+				v[key] = child
+			}
+		}
+	case []any:
+		for i := range v {
+			if child, copied := c.checkValue(obj, v[i], fmt.Sprintf("%s/%d", path, i)); copied {
+				v[i] = child
+			}
+		}
+	}
+}
+
+// checkValue checks a value nested in metadata. It returns the value, or
+// a copy and true if the value is a shared dictionary that was copied.
+func (c *checker) checkValue(obj gotio.SerializableObject, value any, path string) (any, bool) {
+	var dict gotio.AnyDictionary
+	switch v := value.(type) {
+	case gotio.AnyDictionary:
+		dict = v
+	case map[string]any:
+		dict = v
+	default:
+		c.checkValues(obj, value, path)
+		return value, false
+	}
+	copied := false
+	if first, ok := c.maps[mapID(dict)]; ok {
+		c.add(SharedMetadata, path, first, obj, func() bool {
+			dict, copied = dict.DeepCopy(), true
+			return true
+		})
+		if !copied {
+			return value, false
+		}
+	}
+	c.maps[mapID(dict)] = path
+	c.checkValues(obj, dict, path)
+	return dict, copied
+}
+
+// mapID returns the identity of a map.
+func mapID(m any) uintptr {
+	return reflect.ValueOf(m).Pointer()
+}
+
+func sortedKeys[M ~map[string]any](m M) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
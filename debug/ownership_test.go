@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package debug
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func kinds(report Report) map[ProblemKind]int {
+	counts := make(map[ProblemKind]int)
+	for _, p := range report.Problems {
+		counts[p.Kind]++
+	}
+	return counts
+}
+
+func TestCheckOwnership(t *testing.T) {
+	timeline := gotio.NewTimeline("corrupt", nil, nil)
+	v1 := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	v2 := gotio.NewTrack("V2", nil, gotio.TrackKindVideo, nil, nil)
+	timeline.Tracks().AppendChild(v1)
+	timeline.Tracks().AppendChild(v2)
+
+	shared := gotio.NewClip("shared", gotio.NewExternalReference("", "/media/a.mov", nil, nil), nil, nil, nil, nil, "", nil)
+	v1.AppendChild(shared)
+	shared.SetParent(nil)
+	v2.AppendChild(shared)
+
+	lost := gotio.NewClip("lost", shared.MediaReference(), nil, nil, nil, nil, "", nil)
+	v1.AppendChild(lost)
+	lost.SetParent(v2)
+
+	marker := gotio.NewMarker("note", opentime.TimeRange{}, "", "", nil)
+	lost.SetMarkers([]*gotio.Marker{marker})
+	other := gotio.NewGap("", nil, nil, nil, nil, nil)
+	other.SetMarkers([]*gotio.Marker{marker})
+	v2.AppendChild(other)
+
+	metadata := gotio.AnyDictionary{"shot": gotio.AnyDictionary{"id": "010"}}
+	v1.SetMetadata(metadata)
+	v2.SetMetadata(gotio.AnyDictionary{"copy": metadata["shot"]})
+	other.SetMetadata(metadata)
+
+	report := CheckOwnership(timeline)
+	want := map[ProblemKind]int{SharedChild: 1, WrongParent: 2, SharedObject: 2, SharedMetadata: 2}
+	got := kinds(report)
+	for kind, n := range want {
+		if got[kind] != n {
+			t.Errorf("%d %s problems, want %d:\n%s", got[kind], kind, n, report)
+		}
+	}
+	if report.Fixed != 0 || shared.Parent() != v2 {
+		t.Errorf("a check without WithFix changed the timeline")
+	}
+
+	report = CheckOwnership(timeline, WithFix(true))
+	if report.Fixed != len(report.Problems) {
+		t.Errorf("fixed %d of %d problems:\n%s", report.Fixed, len(report.Problems), report)
+	}
+	if report := CheckOwnership(timeline); !report.OK() {
+		t.Errorf("problems left after fixing:\n%s", report)
+	}
+	if v2.Children()[0] == shared || shared.Parent() != v1 || lost.Parent() != v1 {
+		t.Error("the shared clip was not cloned under its second parent")
+	}
+	if _, err := gotio.ToJSONString(timeline, ""); err != nil {
+		t.Errorf("fixed timeline does not serialize: %v", err)
+	}
+}
+
+func TestCheckOwnershipCycle(t *testing.T) {
+	timeline := gotio.NewTimeline("cycle", nil, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	nested := gotio.NewStack("nested", nil, nil, nil, nil, nil)
+	inner := gotio.NewTrack("inner", nil, gotio.TrackKindVideo, nil, nil)
+	nested.AppendChild(inner)
+	track.AppendChild(nested)
+	timeline.Tracks().AppendChild(track)
+	nested.SetParent(nil)
+	inner.AppendChild(nested)
+	nested.SetParent(track)
+
+	report := CheckOwnership(timeline, WithFix(true))
+	if got := kinds(report); got[Cycle] != 1 || len(report.Problems) != 1 || !report.Problems[0].Fixed {
+		t.Fatalf("expected one fixed cycle:\n%s", report)
+	}
+	if len(inner.Children()) != 0 || nested.Parent() != track {
+		t.Error("the cycle was not cut inside the composition")
+	}
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import "github.com/Avalanche-io/gotio/opentime"
+
+// arenaSlabSize is the number of values in each slab of a decodeArena.
+const arenaSlabSize = 512
+
+// decodeArena allocates the many small values of a decoded document, its
+// times, ranges, clips and gaps, from shared slabs. A slab is freed by the
+// garbage collector only when nothing allocated from it is reachable.
+//
+// A nil arena allocates each value on its own.
+type decodeArena struct {
+	times  []opentime.RationalTime
+	ranges []opentime.TimeRange
+	clips  []Clip
+	gaps   []Gap
+}
+
+// arenaAlloc appends v to the current slab, starting a new slab when it
+// is full, and returns a pointer to the stored value.
+func arenaAlloc[T any](slab *[]T, v T) *T {
+	if len(*slab) == cap(*slab) {
+		*slab = make([]T, 0, arenaSlabSize)
+	}
+	*slab = append(*slab, v)
+	return &(*slab)[len(*slab)-1]
+}
+
+func (a *decodeArena) rationalTime(rt opentime.RationalTime) *opentime.RationalTime {
+	if a == nil {
+		return &rt
+	}
+	return arenaAlloc(&a.times, rt)
+}
+
+func (a *decodeArena) timeRange(tr opentime.TimeRange) *opentime.TimeRange {
+	if a == nil {
+		return &tr
+	}
+	return arenaAlloc(&a.ranges, tr)
+}
+
+func (a *decodeArena) clip() *Clip {
+	if a == nil {
+		return new(Clip)
+	}
+	return arenaAlloc(&a.clips, Clip{})
+}
+
+func (a *decodeArena) gap() *Gap {
+	if a == nil {
+		return new(Gap)
+	}
+	return arenaAlloc(&a.gaps, Gap{})
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func TestDecodeWithArena(t *testing.T) {
+	timeline := createBenchmarkTimeline(2, 1, 600)
+	data, err := ToJSONBytes(timeline)
+	if err != nil {
+		t.Fatalf("ToJSONBytes error: %v", err)
+	}
+
+	obj, err := FromJSONBytesWithOptions(data, WithArena())
+	if err != nil {
+		t.Fatalf("FromJSONBytesWithOptions error: %v", err)
+	}
+	decoded := obj.(*Timeline)
+	if !decoded.IsEquivalentTo(timeline) {
+		t.Fatal("arena decoded timeline is not equivalent")
+	}
+
+	// Objects from the arena behave like any other: parents and self
+	// references point at the slab values, and edits stay local.
+	clips := decoded.FindClips(nil, false)
+	if len(clips) != 1800 {
+		t.Fatalf("expected 1800 clips, got %d", len(clips))
+	}
+	first, second := clips[0], clips[1]
+	if first.Parent() == nil || first.Parent().Children()[0] != first {
+		t.Error("arena clip is not its parent's child")
+	}
+	*first.SourceRange() = opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(48, 24))
+	if second.SourceRange().Duration().Value() != 24 {
+		t.Errorf("editing one clip changed its neighbour to %v", second.SourceRange())
+	}
+
+	fromReader, err := FromJSONReader(bytes.NewReader(data), WithArena())
+	if err != nil || !fromReader.IsEquivalentTo(timeline) {
+		t.Errorf("FromJSONReader with arena = %v", err)
+	}
+
+	heap := testing.AllocsPerRun(3, func() { FromJSONBytes(data) })
+	arena := testing.AllocsPerRun(3, func() { FromJSONBytesWithOptions(data, WithArena()) })
+	if arena >= heap {
+		t.Errorf("arena decode made %.0f allocations, no fewer than %.0f without", arena, heap)
+	}
+}
+
+func BenchmarkDecodeArena(b *testing.B) {
+	data, err := ToJSONBytes(createBenchmarkTimeline(4, 4, 500))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("Heap", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			FromJSONBytes(data)
+		}
+	})
+	b.Run("Arena", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			FromJSONBytesWithOptions(data, WithArena())
+		}
+	})
+}
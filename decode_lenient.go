@@ -0,0 +1,337 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DecodeWarning reports a problem in a document that lenient decoding
+// repaired or skipped.
+type DecodeWarning struct {
+	// Path locates the value by its keys and indexes from the root, such
+	// as "tracks/children/0/source_range".
+	Path    string
+	Message string
+}
+
+func (w DecodeWarning) String() string {
+	if w.Path == "" {
+		return w.Message
+	}
+	return w.Path + ": " + w.Message
+}
+
+// WithLenient repairs common defects of files from other exporters
+// instead of misreading them. See FromJSONBytesLenient.
+func WithLenient() DecodeOption {
+	return func(c *DecodeConfig) {
+		c.Lenient = true
+	}
+}
+
+// FromJSONBytesLenient parses JSON bytes into a SerializableObject,
+// repairing what third-party exporters commonly get wrong and returning a
+// warning for each repair:
+//
+//   - objects without OTIO_SCHEMA are read as the schema their place or
+//     fields imply, and times and ranges in metadata gain theirs so the
+//     AnyDictionary getters find them
+//   - numbers and booleans written as strings are converted
+//   - null children, markers and effects are read as empty
+//   - items and compositions without "enabled" are enabled
+//   - Clip.1 and Sequence.1 are read as Clip.2 and Track.1
+//
+// Children whose schema cannot be inferred are skipped with a warning.
+// Decode limits apply as for FromJSONBytesWithOptions.
+func FromJSONBytesLenient(data []byte, opts ...DecodeOption) (SerializableObject, []DecodeWarning, error) {
+	cfg := NewDecodeConfig(opts...)
+	cfg.Lenient = true
+	obj, warnings, _, err := cfg.decodeWithWarnings(data)
+	return obj, warnings, err
+}
+
+// fromJSONBytesLenient parses JSON using sonic, repairing the document
+// before decoding it.
+func fromJSONBytesLenient(data []byte, a *decodeArena) (SerializableObject, []DecodeWarning, error) {
+	m, err := parseSonicObject(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := &repairer{}
+	r.object(m, "", "")
+	obj, err := decodeSonicObject(a, m)
+	return obj, r.warnings, err
+}
+
+// repairer rewrites a parsed document in place, collecting a warning for
+// each change.
+type repairer struct {
+	warnings []DecodeWarning
+}
+
+func (r *repairer) warn(path, format string, args ...any) {
+	r.warnings = append(r.warnings, DecodeWarning{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// enabledSchemas are the schemas with an "enabled" field.
+var enabledSchemas = map[string]bool{"Clip.2": true, "Gap.1": true, "Track.1": true, "Stack.1": true}
+
+// numberKeys are the fields of schema objects holding numbers.
+var numberKeys = map[string]bool{"time_scalar": true, "start_frame": true, "frame_step": true, "frame_zero_padding": true, "rate": true}
+
+// object repairs the schema object m at path. want is the schema assumed
+// if OTIO_SCHEMA is missing, "" to infer it from the fields.
+func (r *repairer) object(m map[string]any, path, want string) {
+	schema, _ := m["OTIO_SCHEMA"].(string)
+	if schema == "" {
+		if want == "" {
+			want = inferSchema(m)
+		}
+		if want == "" {
+			r.warn(path, "object without OTIO_SCHEMA skipped")
+			return
+		}
+		schema = want
+		m["OTIO_SCHEMA"] = schema
+		r.warn(path, "missing OTIO_SCHEMA, read as %s", schema)
+	}
+	switch schema {
+	case "Clip.1":
+		if ref, ok := m["media_reference"]; ok {
+			if _, ok := m["media_references"]; !ok {
+				m["media_references"] = map[string]any{DefaultMediaKey: ref}
+				m["active_media_reference_key"] = DefaultMediaKey
+			}
+			delete(m, "media_reference")
+		}
+		schema = "Clip.2"
+		m["OTIO_SCHEMA"] = schema
+		r.warn(path, "Clip.1 read as Clip.2")
+	case "Sequence.1":
+		schema = "Track.1"
+		m["OTIO_SCHEMA"] = schema
+		r.warn(path, "Sequence.1 read as Track.1")
+	}
+	if _, ok := m["enabled"]; !ok && enabledSchemas[schema] {
+		m["enabled"] = true
+		r.warn(path, "missing enabled, read as true")
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		at := joinPath(path, key)
+		switch key {
+		case "metadata", "parameters":
+			r.metadata(m[key], at)
+		case "source_range", "available_range", "marked_range":
+			r.timeRange(m[key], at)
+		case "global_start_time", "in_offset", "out_offset":
+			r.rationalTime(m[key], at)
+		case "children":
+			r.list(m, key, at, "")
+		case "markers":
+			r.list(m, key, at, "Marker.2")
+		case "effects":
+			r.list(m, key, at, "Effect.1")
+		case "tracks":
+			if tracks, ok := m[key].(map[string]any); ok {
+				r.object(tracks, at, "Stack.1")
+			}
+		case "media_references":
+			if refs, ok := m[key].(map[string]any); ok {
+				for _, name := range slices.Sorted(maps.Keys(refs)) {
+					if ref, ok := refs[name].(map[string]any); ok {
+						r.object(ref, joinPath(at, name), inferReference(ref))
+					}
+				}
+			}
+		case "enabled":
+			r.boolean(m, key, at)
+		case "color":
+			if color, ok := m[key].(map[string]any); ok {
+				for _, c := range []string{"r", "g", "b", "a"} {
+					r.number(color, c, joinPath(at, c))
+				}
+			}
+		default:
+			if numberKeys[key] {
+				r.number(m, key, at)
+			}
+		}
+	}
+}
+
+// list repairs the array of schema objects under key, reading null as
+// empty.
+func (r *repairer) list(m map[string]any, key, path, want string) {
+	switch v := m[key].(type) {
+	case nil:
+		m[key] = []any{}
+		r.warn(path, "null %s read as empty", key)
+	case []any:
+		for i, e := range v {
+			if child, ok := e.(map[string]any); ok {
+				r.object(child, joinPath(path, strconv.Itoa(i)), want)
+			}
+		}
+	}
+}
+
+// rationalTime repairs a serialized RationalTime.
+func (r *repairer) rationalTime(v any, path string) {
+	if m, ok := v.(map[string]any); ok {
+		r.number(m, "value", joinPath(path, "value"))
+		r.number(m, "rate", joinPath(path, "rate"))
+	}
+}
+
+// timeRange repairs a serialized TimeRange.
+func (r *repairer) timeRange(v any, path string) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return
+	}
+	for _, key := range []string{"start_time", "duration"} {
+		if _, ok := m[key].(map[string]any); !ok {
+			r.warn(path, "time range without %s ignored", key)
+			return
+		}
+		r.rationalTime(m[key], joinPath(path, key))
+	}
+}
+
+// metadata repairs times and ranges nested anywhere in a metadata value,
+// adding the OTIO_SCHEMA of those that lack it.
+func (r *repairer) metadata(v any, path string) {
+	switch v := v.(type) {
+	case map[string]any:
+		if _, ok := v["OTIO_SCHEMA"]; !ok {
+			switch {
+			case isTimeShape(v):
+				v["OTIO_SCHEMA"] = "RationalTime.1"
+				r.warn(path, "missing OTIO_SCHEMA, read as RationalTime.1")
+			case isRangeShape(v):
+				v["OTIO_SCHEMA"] = "TimeRange.1"
+				r.warn(path, "missing OTIO_SCHEMA, read as TimeRange.1")
+			}
+		}
+		switch v["OTIO_SCHEMA"] {
+		case "RationalTime.1":
+			r.rationalTime(v, path)
+			return
+		case "TimeRange.1":
+			for _, key := range []string{"start_time", "duration"} {
+				if t, ok := v[key].(map[string]any); ok && t["OTIO_SCHEMA"] == nil {
+					t["OTIO_SCHEMA"] = "RationalTime.1"
+				}
+				r.rationalTime(v[key], joinPath(path, key))
+			}
+			return
+		}
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			r.metadata(v[key], joinPath(path, key))
+		}
+	case []any:
+		for i, e := range v {
+			r.metadata(e, joinPath(path, strconv.Itoa(i)))
+		}
+	}
+}
+
+// number converts a number written as a string under key.
+func (r *repairer) number(m map[string]any, key, path string) {
+	s, ok := m[key].(string)
+	if !ok {
+		return
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		r.warn(path, "%q is not a number", s)
+		return
+	}
+	m[key] = f
+	r.warn(path, "string %q read as a number", s)
+}
+
+// boolean converts a boolean written as a string under key.
+func (r *repairer) boolean(m map[string]any, key, path string) {
+	s, ok := m[key].(string)
+	if !ok {
+		return
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(s))
+	if err != nil {
+		r.warn(path, "%q is not a boolean", s)
+		return
+	}
+	m[key] = b
+	r.warn(path, "string %q read as a boolean", s)
+}
+
+// isTimeShape reports whether m holds just a value and a rate.
+func isTimeShape(m map[string]any) bool {
+	_, value := m["value"]
+	_, rate := m["rate"]
+	return len(m) == 2 && value && rate
+}
+
+// isRangeShape reports whether m holds just a start time and a duration.
+func isRangeShape(m map[string]any) bool {
+	start, _ := m["start_time"].(map[string]any)
+	duration, _ := m["duration"].(map[string]any)
+	return len(m) == 2 && start != nil && duration != nil
+}
+
+// inferSchema returns the schema an object without OTIO_SCHEMA most likely
+// has, from its fields, or "" if there is no telling. An item with only a
+// source range is read as a gap.
+func inferSchema(m map[string]any) string {
+	has := func(key string) bool {
+		_, ok := m[key]
+		return ok
+	}
+	switch {
+	case has("tracks"):
+		return "Timeline.1"
+	case has("transition_type") || has("in_offset"):
+		return "Transition.1"
+	case has("media_references"):
+		return "Clip.2"
+	case has("media_reference"):
+		return "Clip.1"
+	case has("children") && has("kind"):
+		return "Track.1"
+	case has("children"):
+		return "Stack.1"
+	case has("source_range"):
+		return "Gap.1"
+	}
+	return ""
+}
+
+// inferReference returns the media reference schema implied by the fields
+// of m, a missing reference if none is.
+func inferReference(m map[string]any) string {
+	switch {
+	case m["target_url_base"] != nil:
+		return "ImageSequenceReference.1"
+	case m["target_url"] != nil:
+		return "ExternalReference.1"
+	case m["generator_kind"] != nil:
+		return "GeneratorReference.1"
+	}
+	return "MissingReference.1"
+}
+
+// joinPath appends key to a warning path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "/" + key
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"strings"
+	"testing"
+)
+
+const malformedTimeline = `{
+  "OTIO_SCHEMA": "Timeline.1",
+  "name": "export",
+  "global_start_time": {"value": "86400", "rate": "24"},
+  "tracks": {
+    "children": [
+      {
+        "OTIO_SCHEMA": "Sequence.1",
+        "kind": "Video",
+        "enabled": "true",
+        "children": [
+          {
+            "OTIO_SCHEMA": "Clip.1",
+            "name": "sh010",
+            "source_range": {"start_time": {"value": 0, "rate": 24}, "duration": {"value": "48", "rate": 24}},
+            "media_reference": {"target_url": "/media/sh010.mov"},
+            "markers": null,
+            "metadata": {"vendor": {"cut_in": {"value": 12, "rate": 24}}}
+          },
+          {"source_range": {"start_time": {"value": 0, "rate": 24}, "duration": {"value": 24, "rate": 24}}},
+          {"name": "mystery"}
+        ]
+      }
+    ]
+  }
+}`
+
+func TestFromJSONBytesLenient(t *testing.T) {
+	obj, warnings, err := FromJSONBytesLenient([]byte(malformedTimeline))
+	if err != nil {
+		t.Fatalf("FromJSONBytesLenient error: %v", err)
+	}
+	timeline := obj.(*Timeline)
+	if start := timeline.GlobalStartTime(); start == nil || start.Value() != 86400 || start.Rate() != 24 {
+		t.Errorf("global start time = %v", start)
+	}
+	tracks := timeline.Tracks().Children()
+	if len(tracks) != 1 || !tracks[0].(*Track).Enabled() || !timeline.Tracks().Enabled() {
+		t.Fatalf("tracks = %v", tracks)
+	}
+	children := tracks[0].(*Track).Children()
+	if len(children) != 2 {
+		t.Fatalf("expected a clip and a gap, got %v", children)
+	}
+	clip, ok := children[0].(*Clip)
+	if !ok || !clip.Enabled() {
+		t.Fatalf("first child = %v", children[0])
+	}
+	if ref, ok := clip.MediaReference().(*ExternalReference); !ok || ref.TargetURL() != "/media/sh010.mov" {
+		t.Errorf("media reference = %v", clip.MediaReference())
+	}
+	if d, _ := clip.Duration(); d.Value() != 48 {
+		t.Errorf("clip duration = %v, want 48 frames", d)
+	}
+	vendor, _ := clip.Metadata().GetDictionary("vendor")
+	if cutIn, ok := vendor.GetRationalTime("cut_in"); !ok || cutIn.Value() != 12 {
+		t.Errorf("metadata time not recognized: %v", vendor)
+	}
+	if _, ok := children[1].(*Gap); !ok {
+		t.Errorf("second child = %v, want a gap", children[1])
+	}
+
+	var text []string
+	for _, w := range warnings {
+		text = append(text, w.String())
+	}
+	all := strings.Join(text, "\n")
+	for _, want := range []string{
+		"global_start_time/rate: string \"24\" read as a number",
+		"tracks: missing OTIO_SCHEMA, read as Stack.1",
+		"tracks/children/0: Sequence.1 read as Track.1",
+		"tracks/children/0/children/0: Clip.1 read as Clip.2",
+		"tracks/children/0/children/0/markers: null markers read as empty",
+		"tracks/children/0/children/0/metadata/vendor/cut_in: missing OTIO_SCHEMA, read as RationalTime.1",
+		"tracks/children/0/children/2: object without OTIO_SCHEMA skipped",
+	} {
+		if !strings.Contains(all, want) {
+			t.Errorf("missing warning %q in:\n%s", want, all)
+		}
+	}
+
+	// A strict decode of the same file loses the clip and the start time.
+	obj, err = FromJSONBytes([]byte(malformedTimeline))
+	if err != nil {
+		t.Fatalf("FromJSONBytes error: %v", err)
+	}
+	if start := obj.(*Timeline).GlobalStartTime(); start != nil && start.Value() == 86400 {
+		t.Errorf("strict decoding converted string numbers")
+	}
+
+	if _, warnings, err := FromJSONBytesLenient([]byte(`{"OTIO_SCHEMA": "Gap.1", "enabled": true}`)); err != nil || len(warnings) != 0 {
+		t.Errorf("well-formed input: %v, %v", warnings, err)
+	}
+	obj, err = FromJSONBytesWithOptions([]byte(malformedTimeline), WithLenient())
+	if err != nil || len(obj.(*Timeline).Tracks().Children()) != 1 {
+		t.Errorf("WithLenient: %v, %v", obj, err)
+	}
+}
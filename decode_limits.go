@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/Avalanche-io/gotio/metrics"
+)
+
+// ErrDecodeLimit is matched by errors for input over a decode limit.
+var ErrDecodeLimit = errors.New("decode limit exceeded")
+
+// DefaultMaxNestingDepth is the nesting depth FromJSONBytes and the other
+// decoding functions allow unless configured otherwise. Each nested
+// composition takes two levels, one for the object and one for its
+// children.
+const DefaultMaxNestingDepth = 1000
+
+// DecodeConfig holds limits on the JSON accepted by the decoding functions,
+// checked before anything is decoded, and how decoded objects are
+// allocated. Zero means no limit.
+type DecodeConfig struct {
+	// MaxNestingDepth limits how deeply objects and arrays nest.
+	MaxNestingDepth int
+	// MaxChildren limits the elements of any array, such as the children
+	// of a composition.
+	MaxChildren int
+	// MaxStringLength limits the length in bytes of any string, including
+	// object keys, as written in the JSON.
+	MaxStringLength int
+	// MaxBytes limits the size of the input.
+	MaxBytes int64
+	// Arena allocates the times, ranges, clips and gaps of a document
+	// together in slabs instead of one by one, leaving the garbage
+	// collector fewer objects to track in a long-running service. A slab
+	// is only freed once nothing decoded into it is reachable, so keeping
+	// a few objects from a large document keeps memory of their
+	// neighbours alive. Use it when a document is used and dropped as a
+	// whole, and Clone objects that must outlive it.
+	Arena bool
+	// Lenient repairs common defects of third-party files, such as
+	// missing schemas or numbers written as strings, instead of misreading
+	// them. See FromJSONBytesLenient.
+	Lenient bool
+	// Logger, if set, receives an event for each document decoded or
+	// rejected, and for each repair of a lenient decode.
+	Logger *slog.Logger
+}
+
+// DecodeOption is a functional option for decoding JSON.
+type DecodeOption func(*DecodeConfig)
+
+// WithMaxNestingDepth limits how deeply objects and arrays nest.
+func WithMaxNestingDepth(depth int) DecodeOption {
+	return func(c *DecodeConfig) {
+		c.MaxNestingDepth = depth
+	}
+}
+
+// WithMaxChildren limits the number of elements in any array.
+func WithMaxChildren(n int) DecodeOption {
+	return func(c *DecodeConfig) {
+		c.MaxChildren = n
+	}
+}
+
+// WithMaxStringLength limits the length of any string.
+func WithMaxStringLength(n int) DecodeOption {
+	return func(c *DecodeConfig) {
+		c.MaxStringLength = n
+	}
+}
+
+// WithMaxBytes limits the size of the input.
+func WithMaxBytes(n int64) DecodeOption {
+	return func(c *DecodeConfig) {
+		c.MaxBytes = n
+	}
+}
+
+// WithArena allocates decoded objects from shared slabs. See
+// DecodeConfig.Arena for their lifetime.
+func WithArena() DecodeOption {
+	return func(c *DecodeConfig) {
+		c.Arena = true
+	}
+}
+
+// WithLogger sets the logger decoding reports each document to.
+func WithLogger(logger *slog.Logger) DecodeOption {
+	return func(c *DecodeConfig) {
+		c.Logger = logger
+	}
+}
+
+// arena returns a new arena if the configuration asks for one.
+func (cfg DecodeConfig) arena() *decodeArena {
+	if !cfg.Arena {
+		return nil
+	}
+	return &decodeArena{}
+}
+
+// NewDecodeConfig returns the default limits with opts applied.
+func NewDecodeConfig(opts ...DecodeOption) DecodeConfig {
+	cfg := DecodeConfig{MaxNestingDepth: DefaultMaxNestingDepth}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// DecodeLimitError reports input over a decode limit. It matches
+// ErrDecodeLimit with errors.Is.
+type DecodeLimitError struct {
+	// Limit names the limit: "nesting depth", "children", "string length"
+	// or "size".
+	Limit string
+	Max   int64
+	// Offset is the byte offset in the input where the limit was crossed.
+	Offset int64
+}
+
+func (e *DecodeLimitError) Error() string {
+	return fmt.Sprintf("%s exceeds the limit of %d at byte %d", e.Limit, e.Max, e.Offset)
+}
+
+// Is reports whether target is ErrDecodeLimit.
+func (e *DecodeLimitError) Is(target error) bool {
+	return target == ErrDecodeLimit
+}
+
+// FromJSONBytesWithOptions parses JSON bytes into a SerializableObject,
+// rejecting input over the configured limits.
+func FromJSONBytesWithOptions(data []byte, opts ...DecodeOption) (SerializableObject, error) {
+	return NewDecodeConfig(opts...).decode(data)
+}
+
+// FromJSONReader reads and parses JSON into a SerializableObject. With a
+// MaxBytes limit, no more than MaxBytes+1 bytes are read.
+func FromJSONReader(r io.Reader, opts ...DecodeOption) (SerializableObject, error) {
+	cfg := NewDecodeConfig(opts...)
+	if cfg.MaxBytes > 0 {
+		r = io.LimitReader(r, cfg.MaxBytes+1)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.decode(data)
+}
+
+// decode checks data against the limits and decodes it.
+func (cfg DecodeConfig) decode(data []byte) (SerializableObject, error) {
+	obj, _, _, err := cfg.decodeWithWarnings(data)
+	return obj, err
+}
+
+// decodeWithWarnings is decode, also returning the repairs of a lenient
+// decode and the warnings of the metadata validation.
+func (cfg DecodeConfig) decodeWithWarnings(data []byte) (SerializableObject, []DecodeWarning, []MetadataWarning, error) {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	start := time.Now()
+	if err := cfg.Check(data); err != nil {
+		logger.Warn("document rejected", "bytes", len(data), "error", err)
+		return nil, nil, nil, err
+	}
+	var obj SerializableObject
+	var warnings []DecodeWarning
+	var err error
+	if cfg.Lenient {
+		obj, warnings, err = fromJSONBytesLenient(data, cfg.arena())
+		for _, w := range warnings {
+			logger.Warn("document repaired", "path", w.Path, "problem", w.Message)
+		}
+	} else {
+		obj, err = fromJSONBytesSonic(data, cfg.arena())
+	}
+	if err != nil {
+		logger.Warn("document not decoded", "bytes", len(data), "error", err)
+		return nil, warnings, nil, err
+	}
+	metrics.Since(metrics.DecodeSeconds, start)
+	metrics.Add(metrics.DecodeBytes, float64(len(data)))
+	logger.Debug("document decoded", "bytes", len(data), "schema", obj.SchemaName())
+	metadataWarnings := reportMetadata(obj)
+	for _, w := range metadataWarnings {
+		logger.Warn("metadata invalid", "schema", w.Object.SchemaName(), "name", w.Object.Name(), "namespace", w.Namespace, "error", w.Err)
+	}
+	return obj, warnings, metadataWarnings, nil
+}
+
+// Check scans data and returns a DecodeLimitError for the first limit it
+// exceeds. It does not check that data is valid JSON.
+func (cfg DecodeConfig) Check(data []byte) error {
+	if cfg.MaxBytes > 0 && int64(len(data)) > cfg.MaxBytes {
+		return &DecodeLimitError{Limit: "size", Max: cfg.MaxBytes, Offset: cfg.MaxBytes}
+	}
+	if cfg.MaxNestingDepth <= 0 && cfg.MaxChildren <= 0 && cfg.MaxStringLength <= 0 {
+		return nil
+	}
+
+	// counts holds, for each open container, the elements seen so far in
+	// an array, or -1 for an object.
+	var counts []int
+	// opened is set after '[' until the first element or ']'.
+	opened := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch c {
+		case ' ', '\t', '\n', '\r':
+			continue
+		}
+		if opened {
+			opened = false
+			if c != ']' {
+				if err := cfg.countElement(counts, i); err != nil {
+					return err
+				}
+			}
+		}
+
+		switch c {
+		case '"':
+			start := i + 1
+			for i++; i < len(data) && data[i] != '"'; i++ {
+				if data[i] == '\\' {
+					i++
+				}
+			}
+			if cfg.MaxStringLength > 0 && i-start > cfg.MaxStringLength {
+				return &DecodeLimitError{Limit: "string length", Max: int64(cfg.MaxStringLength), Offset: int64(start)}
+			}
+		case '{', '[':
+			if c == '[' {
+				counts = append(counts, 0)
+				opened = true
+			} else {
+				counts = append(counts, -1)
+			}
+			if cfg.MaxNestingDepth > 0 && len(counts) > cfg.MaxNestingDepth {
+				return &DecodeLimitError{Limit: "nesting depth", Max: int64(cfg.MaxNestingDepth), Offset: int64(i)}
+			}
+		case '}', ']':
+			if len(counts) > 0 {
+				counts = counts[:len(counts)-1]
+			}
+		case ',':
+			if err := cfg.countElement(counts, i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// countElement counts an element of the innermost container if it is an
+// array.
+func (cfg DecodeConfig) countElement(counts []int, offset int) error {
+	if len(counts) == 0 || counts[len(counts)-1] < 0 {
+		return nil
+	}
+	counts[len(counts)-1]++
+	if cfg.MaxChildren > 0 && counts[len(counts)-1] > cfg.MaxChildren {
+		return &DecodeLimitError{Limit: "children", Max: int64(cfg.MaxChildren), Offset: int64(offset)}
+	}
+	return nil
+}
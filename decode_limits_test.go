@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func TestDecodeLimits(t *testing.T) {
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	for i := 0; i < 3; i++ {
+		sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(24, 24))
+		track.AppendChild(NewClip("clip", nil, &sr, nil, nil, nil, "", nil))
+	}
+	timeline := NewTimeline("limits", nil, nil)
+	timeline.Tracks().AppendChild(track)
+	data, err := ToJSONBytes(timeline)
+	if err != nil {
+		t.Fatalf("ToJSONBytes error: %v", err)
+	}
+
+	if _, err := FromJSONBytesWithOptions(data, WithMaxChildren(3), WithMaxStringLength(32)); err != nil {
+		t.Fatalf("expected the timeline to fit, got %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		opt   DecodeOption
+		limit string
+	}{
+		{"children", WithMaxChildren(2), "children"},
+		{"depth", WithMaxNestingDepth(4), "nesting depth"},
+		{"string", WithMaxStringLength(4), "string length"},
+		{"size", WithMaxBytes(int64(len(data) - 1)), "size"},
+	}
+	for _, tt := range tests {
+		_, err := FromJSONBytesWithOptions(data, tt.opt)
+		var limitErr *DecodeLimitError
+		if !errors.As(err, &limitErr) || !errors.Is(err, ErrDecodeLimit) {
+			t.Errorf("%s: expected a DecodeLimitError, got %v", tt.name, err)
+			continue
+		}
+		if limitErr.Limit != tt.limit {
+			t.Errorf("%s: Limit = %q, want %q", tt.name, limitErr.Limit, tt.limit)
+		}
+	}
+
+	if _, err := FromJSONReader(strings.NewReader(string(data)), WithMaxBytes(100)); !errors.Is(err, ErrDecodeLimit) {
+		t.Errorf("expected FromJSONReader to stop at the size limit, got %v", err)
+	}
+
+	// Deep nesting is refused by default, before it reaches the parser.
+	deep := `{"OTIO_SCHEMA": "Timeline.1", "metadata": ` + strings.Repeat("[", DefaultMaxNestingDepth) + strings.Repeat("]", DefaultMaxNestingDepth) + `}`
+	if _, err := FromJSONBytes([]byte(deep)); !errors.Is(err, ErrDecodeLimit) {
+		t.Errorf("expected the default depth limit, got %v", err)
+	}
+}
+
+func TestDecodeConfigCheck(t *testing.T) {
+	cfg := NewDecodeConfig(WithMaxChildren(2), WithMaxStringLength(3))
+	tests := []struct {
+		input string
+		ok    bool
+	}{
+		{`[]`, true},
+		{`[1, 2]`, true},
+		{`[1, 2, 3]`, false},
+		{`[[1, 2], [3, 4]]`, true},
+		{`{"a": 1, "b": 2, "c": 3}`, true},
+		{`["a,b,c,d"]`, false},
+		{`["a\""]`, true},
+		{`["abcd"]`, false},
+	}
+	for _, tt := range tests {
+		if err := cfg.Check([]byte(tt.input)); (err == nil) != tt.ok {
+			t.Errorf("Check(%s) = %v, want ok %v", tt.input, err, tt.ok)
+		}
+	}
+}
+
+func TestDecodeLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	if _, err := FromJSONBytesWithOptions([]byte(`{"OTIO_SCHEMA": "Gap.1"}`), WithLogger(logger)); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "document decoded") || !strings.Contains(buf.String(), "schema=Gap") {
+		t.Errorf("expected a decoded event, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if _, err := FromJSONBytesWithOptions([]byte(`[1, 2, 3]`), WithMaxChildren(2), WithLogger(logger)); err == nil {
+		t.Fatal("expected a limit error")
+	}
+	if !strings.Contains(buf.String(), "level=WARN") || !strings.Contains(buf.String(), "document rejected") {
+		t.Errorf("expected a rejected event, got %q", buf.String())
+	}
+}
+
+func FuzzFromJSONBytes(f *testing.F) {
+	track := NewTrack("V1", nil, TrackKindVideo, AnyDictionary{"key": "value"}, nil)
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(24, 24))
+	track.AppendChild(NewClip("clip", NewExternalReference("", "/media/a.mov", &sr, nil), &sr, nil, nil, nil, "", nil))
+	track.AppendChild(NewTransition("", TransitionTypeSMPTEDissolve, sr.Duration(), sr.Duration(), nil))
+	track.AppendChild(NewGap("", &sr, nil, nil, nil, nil))
+	timeline := NewTimeline("fuzz", nil, nil)
+	timeline.Tracks().AppendChild(track)
+	data, err := ToJSONBytes(timeline)
+	if err != nil {
+		f.Fatalf("ToJSONBytes error: %v", err)
+	}
+	f.Add(data)
+	f.Add([]byte(`{"OTIO_SCHEMA": "Clip.2", "source_range": {"OTIO_SCHEMA": "TimeRange.1"}}`))
+	f.Add([]byte(`{"OTIO_SCHEMA": "Stack.1", "children": [{"OTIO_SCHEMA": "Track.1", "children": [1, null, "x"]}]}`))
+	f.Add([]byte(`{"OTIO_SCHEMA": "Track.1", "children": [], "metadata": {"a": NaN, "b": -Infinity}}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		obj, err := FromJSONBytesWithOptions(data, WithMaxChildren(1000), WithMaxBytes(1<<20))
+		if err != nil || obj == nil {
+			return
+		}
+		if _, err := ToJSONBytes(obj); err != nil {
+			t.Skip()
+		}
+	})
+}
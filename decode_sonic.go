@@ -5,7 +5,6 @@ package gotio
 
 import (
 	"bytes"
-	"fmt"
 
 	"github.com/bytedance/sonic"
 	"github.com/Avalanche-io/gotio/opentime"
@@ -88,24 +87,28 @@ func decodeSonicMetadata(m map[string]any) AnyDictionary {
 }
 
 // decodeSonicEffects decodes effects array from a map.
-func decodeSonicEffects(m map[string]any) []Effect {
+func decodeSonicEffects(m map[string]any) ([]Effect, error) {
 	effs, ok := m["effects"].([]any)
 	if !ok {
-		return nil
+		return nil, nil
 	}
 	var effects []Effect
 	for _, effAny := range effs {
 		if effMap, ok := effAny.(map[string]any); ok {
-			if eff := decodeSonicEffect(effMap); eff != nil {
+			eff, err := decodeSonicEffect(effMap)
+			if err != nil {
+				return nil, err
+			}
+			if eff != nil {
 				effects = append(effects, eff)
 			}
 		}
 	}
-	return effects
+	return effects, nil
 }
 
 // decodeSonicMarkers decodes markers array from a map.
-func decodeSonicMarkers(m map[string]any) []*Marker {
+func decodeSonicMarkers(a *decodeArena, m map[string]any) []*Marker {
 	marks, ok := m["markers"].([]any)
 	if !ok {
 		return nil
@@ -113,7 +116,7 @@ func decodeSonicMarkers(m map[string]any) []*Marker {
 	var markers []*Marker
 	for _, markAny := range marks {
 		if markMap, ok := markAny.(map[string]any); ok {
-			if marker := decodeSonicMarker(markMap); marker != nil {
+			if marker := decodeSonicMarker(a, markMap); marker != nil {
 				markers = append(markers, marker)
 			}
 		}
@@ -122,124 +125,160 @@ func decodeSonicMarkers(m map[string]any) []*Marker {
 }
 
 // decodeSonicRationalTime decodes a RationalTime from a map.
-func decodeSonicRationalTime(v any) *opentime.RationalTime {
+func decodeSonicRationalTime(a *decodeArena, v any) *opentime.RationalTime {
 	m, ok := v.(map[string]any)
 	if !ok || m == nil {
 		return nil
 	}
 	value, _ := m["value"].(float64)
 	rate, _ := m["rate"].(float64)
-	rt := opentime.NewRationalTime(value, rate)
-	return &rt
+	return a.rationalTime(opentime.NewRationalTime(value, rate))
 }
 
 // decodeSonicTimeline decodes a Timeline from a sonic-parsed map.
-func decodeSonicTimeline(m map[string]any) (*Timeline, error) {
+func decodeSonicTimeline(a *decodeArena, m map[string]any) (*Timeline, error) {
 	name, _ := m["name"].(string)
 	metadata := decodeSonicMetadata(m)
-	globalStartTime := decodeSonicRationalTime(m["global_start_time"])
+	globalStartTime := decodeSonicRationalTime(a, m["global_start_time"])
 
 	timeline := NewTimeline(name, globalStartTime, metadata)
 
 	if tracks, ok := m["tracks"].(map[string]any); ok {
-		if stack, err := decodeSonicStack(tracks); err == nil {
-			timeline.SetTracks(stack)
+		stack, err := decodeSonicStack(a, tracks)
+		if err != nil {
+			return nil, err
 		}
+		timeline.SetTracks(stack)
 	}
 
 	return timeline, nil
 }
 
 // decodeSonicStack decodes a Stack from a sonic-parsed map.
-func decodeSonicStack(m map[string]any) (*Stack, error) {
+func decodeSonicStack(a *decodeArena, m map[string]any) (*Stack, error) {
 	name, _ := m["name"].(string)
 	enabled, _ := m["enabled"].(bool)
-	sourceRange := decodeSonicTimeRange(m["source_range"])
+	sourceRange := decodeSonicTimeRange(a, m["source_range"])
 	color := decodeSonicColor(m["color"])
 	metadata := decodeSonicMetadata(m)
-	effects := decodeSonicEffects(m)
-	markers := decodeSonicMarkers(m)
+	effects, err := decodeSonicEffects(m)
+	if err != nil {
+		return nil, err
+	}
+	markers := decodeSonicMarkers(a, m)
+	children, err := decodeSonicChildren(a, m)
+	if err != nil {
+		return nil, err
+	}
 
 	stack := NewStack(name, sourceRange, metadata, effects, markers, color)
 	stack.SetEnabled(enabled)
 
-	// Decode children (Tracks)
-	if children, ok := m["children"].([]any); ok {
-		for _, childAny := range children {
-			if childMap, ok := childAny.(map[string]any); ok {
-				schema, _ := childMap["OTIO_SCHEMA"].(string)
-				if schema == "Track.1" {
-					if track, err := decodeSonicTrack(childMap); err == nil {
-						stack.AppendChild(track)
-					}
-				}
-			}
-		}
+	for _, child := range children {
+		stack.AppendChild(child)
 	}
 
 	return stack, nil
 }
 
 // decodeSonicTrack decodes a Track from a sonic-parsed map.
-func decodeSonicTrack(m map[string]any) (*Track, error) {
+func decodeSonicTrack(a *decodeArena, m map[string]any) (*Track, error) {
 	name, _ := m["name"].(string)
 	kind, _ := m["kind"].(string)
 	enabled, _ := m["enabled"].(bool)
-	sourceRange := decodeSonicTimeRange(m["source_range"])
+	sourceRange := decodeSonicTimeRange(a, m["source_range"])
 	color := decodeSonicColor(m["color"])
 	metadata := decodeSonicMetadata(m)
+	effects, err := decodeSonicEffects(m)
+	if err != nil {
+		return nil, err
+	}
+	children, err := decodeSonicChildren(a, m)
+	if err != nil {
+		return nil, err
+	}
 
 	track := NewTrack(name, sourceRange, kind, metadata, color)
 	track.SetEnabled(enabled)
-	track.effects = decodeSonicEffects(m)
-	track.markers = decodeSonicMarkers(m)
+	track.effects = effects
+	track.markers = decodeSonicMarkers(a, m)
 
-	// Decode children (Clips, Gaps, Transitions)
-	if children, ok := m["children"].([]any); ok {
-		for _, childAny := range children {
-			if childMap, ok := childAny.(map[string]any); ok {
-				schema, _ := childMap["OTIO_SCHEMA"].(string)
-				switch schema {
-				case "Clip.2":
-					if clip, err := decodeSonicClip(childMap); err == nil {
-						track.AppendChild(clip)
-					}
-				case "Gap.1":
-					if gap := decodeSonicGap(childMap); gap != nil {
-						track.AppendChild(gap)
-					}
-				case "Transition.1":
-					if trans := decodeSonicTransition(childMap); trans != nil {
-						track.AppendChild(trans)
-					}
-				}
-			}
-		}
+	for _, child := range children {
+		track.AppendChild(child)
 	}
 
 	return track, nil
 }
 
+// decodeSonicChildren decodes the children of a composition, which may be
+// items of any kind including nested tracks and stacks.
+func decodeSonicChildren(a *decodeArena, m map[string]any) ([]Composable, error) {
+	children, ok := m["children"].([]any)
+	if !ok {
+		return nil, nil
+	}
+	var decoded []Composable
+	for _, childAny := range children {
+		childMap, ok := childAny.(map[string]any)
+		if !ok {
+			continue
+		}
+		var child Composable
+		var err error
+		schema, _ := childMap["OTIO_SCHEMA"].(string)
+		switch schema {
+		case "Clip.2":
+			child, err = decodeSonicClip(a, childMap)
+		case "Gap.1":
+			child, err = decodeSonicGap(a, childMap)
+		case "Transition.1":
+			child = decodeSonicTransition(a, childMap)
+		case "Track.1":
+			child, err = decodeSonicTrack(a, childMap)
+		case "Stack.1":
+			child, err = decodeSonicStack(a, childMap)
+		default:
+			var obj SerializableObject
+			obj, _, err = decodeSonicRegistered(childMap)
+			child, _ = obj.(Composable)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if child != nil {
+			decoded = append(decoded, child)
+		}
+	}
+	return decoded, nil
+}
+
 // decodeSonicClip decodes a Clip from a sonic-parsed map.
-func decodeSonicClip(m map[string]any) (*Clip, error) {
+func decodeSonicClip(a *decodeArena, m map[string]any) (*Clip, error) {
 	name, _ := m["name"].(string)
 	enabled, _ := m["enabled"].(bool)
-	sourceRange := decodeSonicTimeRange(m["source_range"])
+	sourceRange := decodeSonicTimeRange(a, m["source_range"])
 	color := decodeSonicColor(m["color"])
 	activeKey, _ := m["active_media_reference_key"].(string)
 	if activeKey == "" {
 		activeKey = DefaultMediaKey
 	}
 	metadata := decodeSonicMetadata(m)
-	effects := decodeSonicEffects(m)
-	markers := decodeSonicMarkers(m)
+	effects, err := decodeSonicEffects(m)
+	if err != nil {
+		return nil, err
+	}
+	markers := decodeSonicMarkers(a, m)
 
 	// Decode media references
 	mediaRefs := make(map[string]MediaReference)
 	if refs, ok := m["media_references"].(map[string]any); ok {
 		for key, refAny := range refs {
 			if refMap, ok := refAny.(map[string]any); ok {
-				if ref := decodeSonicMediaReference(refMap); ref != nil {
+				ref, err := decodeSonicMediaReference(a, refMap)
+				if err != nil {
+					return nil, err
+				}
+				if ref != nil {
 					mediaRefs[key] = ref
 				}
 			}
@@ -251,7 +290,7 @@ func decodeSonicClip(m map[string]any) (*Clip, error) {
 		initialRef = ref
 	}
 
-	clip := NewClip(name, initialRef, sourceRange, metadata, effects, markers, activeKey, color)
+	clip := initClip(a.clip(), name, initialRef, sourceRange, metadata, effects, markers, activeKey, color)
 
 	if len(mediaRefs) > 1 {
 		clip.SetMediaReferences(mediaRefs, activeKey)
@@ -262,28 +301,32 @@ func decodeSonicClip(m map[string]any) (*Clip, error) {
 }
 
 // decodeSonicGap decodes a Gap from a sonic-parsed map.
-func decodeSonicGap(m map[string]any) *Gap {
+func decodeSonicGap(a *decodeArena, m map[string]any) (*Gap, error) {
 	name, _ := m["name"].(string)
 	enabled, _ := m["enabled"].(bool)
-	sourceRange := decodeSonicTimeRange(m["source_range"])
+	sourceRange := decodeSonicTimeRange(a, m["source_range"])
 	metadata := decodeSonicMetadata(m)
+	effects, err := decodeSonicEffects(m)
+	if err != nil {
+		return nil, err
+	}
 
-	gap := NewGap(name, sourceRange, metadata, nil, nil, nil)
+	gap := initGap(a.gap(), name, sourceRange, metadata, effects, decodeSonicMarkers(a, m), nil)
 	gap.SetEnabled(enabled)
-	return gap
+	return gap, nil
 }
 
 // decodeSonicTransition decodes a Transition from a sonic-parsed map.
-func decodeSonicTransition(m map[string]any) *Transition {
+func decodeSonicTransition(a *decodeArena, m map[string]any) *Transition {
 	name, _ := m["name"].(string)
 	transitionType, _ := m["transition_type"].(string)
 	metadata := decodeSonicMetadata(m)
 
 	var inOffset, outOffset opentime.RationalTime
-	if rt := decodeSonicRationalTime(m["in_offset"]); rt != nil {
+	if rt := decodeSonicRationalTime(a, m["in_offset"]); rt != nil {
 		inOffset = *rt
 	}
-	if rt := decodeSonicRationalTime(m["out_offset"]); rt != nil {
+	if rt := decodeSonicRationalTime(a, m["out_offset"]); rt != nil {
 		outOffset = *rt
 	}
 
@@ -291,31 +334,39 @@ func decodeSonicTransition(m map[string]any) *Transition {
 }
 
 // decodeSonicMediaReference decodes a MediaReference from a sonic-parsed map.
-func decodeSonicMediaReference(m map[string]any) MediaReference {
+func decodeSonicMediaReference(a *decodeArena, m map[string]any) (MediaReference, error) {
 	schema, _ := m["OTIO_SCHEMA"].(string)
 	name, _ := m["name"].(string)
 	metadata := decodeSonicMetadata(m)
-	availRange := decodeSonicTimeRange(m["available_range"])
+	availRange := decodeSonicTimeRange(a, m["available_range"])
 
 	switch schema {
 	case "ExternalReference.1":
 		targetURL, _ := m["target_url"].(string)
-		return NewExternalReference(name, targetURL, availRange, metadata)
+		return NewExternalReference(name, targetURL, availRange, metadata), nil
 	case "MissingReference.1":
-		return NewMissingReference(name, availRange, metadata)
+		return NewMissingReference(name, availRange, metadata), nil
 	case "GeneratorReference.1":
 		generatorKind, _ := m["generator_kind"].(string)
 		var parameters AnyDictionary
 		if p, ok := m["parameters"].(map[string]any); ok {
 			parameters = p
 		}
-		return NewGeneratorReference(name, generatorKind, parameters, availRange, metadata)
+		return NewGeneratorReference(name, generatorKind, parameters, availRange, metadata), nil
+	case "TimelineReference.1":
+		targetURL, _ := m["target_url"].(string)
+		return NewTimelineReference(name, targetURL, availRange, metadata), nil
 	}
-	return nil
+	obj, _, err := decodeSonicRegistered(m)
+	if err != nil {
+		return nil, err
+	}
+	ref, _ := obj.(MediaReference)
+	return ref, nil
 }
 
 // decodeSonicEffect decodes an Effect from a sonic-parsed map.
-func decodeSonicEffect(m map[string]any) Effect {
+func decodeSonicEffect(m map[string]any) (Effect, error) {
 	schema, _ := m["OTIO_SCHEMA"].(string)
 	name, _ := m["name"].(string)
 	effectName, _ := m["effect_name"].(string)
@@ -323,35 +374,46 @@ func decodeSonicEffect(m map[string]any) Effect {
 
 	switch schema {
 	case "Effect.1":
-		return NewEffect(name, effectName, metadata)
+		return NewEffect(name, effectName, metadata), nil
 	case "LinearTimeWarp.1":
 		timeScalar, _ := m["time_scalar"].(float64)
-		return NewLinearTimeWarp(name, effectName, timeScalar, metadata)
+		return NewLinearTimeWarp(name, effectName, timeScalar, metadata), nil
 	case "FreezeFrame.1":
-		return NewFreezeFrame(name, metadata)
+		return NewFreezeFrame(name, metadata), nil
+	case "ASC_CDL.1":
+		return decodeSonicASCCDL(m), nil
+	case "LUT.1":
+		return decodeSonicLUT(m), nil
+	case "TimeCurveWarp.1":
+		return decodeSonicTimeCurveWarp(m), nil
 	}
-	return nil
+	obj, _, err := decodeSonicRegistered(m)
+	if err != nil {
+		return nil, err
+	}
+	eff, _ := obj.(Effect)
+	return eff, nil
 }
 
 // decodeSonicExternalReference decodes an ExternalReference for top-level decoding.
-func decodeSonicExternalReference(m map[string]any) *ExternalReference {
+func decodeSonicExternalReference(a *decodeArena, m map[string]any) *ExternalReference {
 	name, _ := m["name"].(string)
 	targetURL, _ := m["target_url"].(string)
 	metadata := decodeSonicMetadata(m)
-	availRange := decodeSonicTimeRange(m["available_range"])
+	availRange := decodeSonicTimeRange(a, m["available_range"])
 	return NewExternalReference(name, targetURL, availRange, metadata)
 }
 
 // decodeSonicMissingReference decodes a MissingReference for top-level decoding.
-func decodeSonicMissingReference(m map[string]any) *MissingReference {
+func decodeSonicMissingReference(a *decodeArena, m map[string]any) *MissingReference {
 	name, _ := m["name"].(string)
 	metadata := decodeSonicMetadata(m)
-	availRange := decodeSonicTimeRange(m["available_range"])
+	availRange := decodeSonicTimeRange(a, m["available_range"])
 	return NewMissingReference(name, availRange, metadata)
 }
 
 // decodeSonicGeneratorReference decodes a GeneratorReference for top-level decoding.
-func decodeSonicGeneratorReference(m map[string]any) *GeneratorReference {
+func decodeSonicGeneratorReference(a *decodeArena, m map[string]any) *GeneratorReference {
 	name, _ := m["name"].(string)
 	generatorKind, _ := m["generator_kind"].(string)
 	metadata := decodeSonicMetadata(m)
@@ -359,10 +421,19 @@ func decodeSonicGeneratorReference(m map[string]any) *GeneratorReference {
 	if p, ok := m["parameters"].(map[string]any); ok {
 		parameters = p
 	}
-	availRange := decodeSonicTimeRange(m["available_range"])
+	availRange := decodeSonicTimeRange(a, m["available_range"])
 	return NewGeneratorReference(name, generatorKind, parameters, availRange, metadata)
 }
 
+// decodeSonicTimelineReference decodes a TimelineReference for top-level decoding.
+func decodeSonicTimelineReference(a *decodeArena, m map[string]any) *TimelineReference {
+	name, _ := m["name"].(string)
+	targetURL, _ := m["target_url"].(string)
+	metadata := decodeSonicMetadata(m)
+	availRange := decodeSonicTimeRange(a, m["available_range"])
+	return NewTimelineReference(name, targetURL, availRange, metadata)
+}
+
 // decodeSonicEffectImpl decodes an Effect for top-level decoding.
 func decodeSonicEffectImpl(m map[string]any) *EffectImpl {
 	name, _ := m["name"].(string)
@@ -387,6 +458,60 @@ func decodeSonicFreezeFrame(m map[string]any) *FreezeFrame {
 	return NewFreezeFrame(name, metadata)
 }
 
+// decodeSonicASCCDL decodes an ASCCDL. Missing values are the identity.
+func decodeSonicASCCDL(m map[string]any) *ASCCDL {
+	name, _ := m["name"].(string)
+	cdl := NewIdentityASCCDL(name)
+	cdl.effectName, _ = m["effect_name"].(string)
+	cdl.metadata = decodeSonicMetadata(m)
+	if slope, ok := float3(m["slope"]); ok {
+		cdl.slope = slope
+	}
+	if offset, ok := float3(m["offset"]); ok {
+		cdl.offset = offset
+	}
+	if power, ok := float3(m["power"]); ok {
+		cdl.power = power
+	}
+	if saturation, ok := m["saturation"].(float64); ok {
+		cdl.saturation = saturation
+	}
+	return cdl
+}
+
+// decodeSonicLUT decodes a LUT.
+func decodeSonicLUT(m map[string]any) *LUT {
+	name, _ := m["name"].(string)
+	effectName, _ := m["effect_name"].(string)
+	targetURL, _ := m["target_url"].(string)
+	lut := NewLUT(name, targetURL, decodeSonicMetadata(m))
+	lut.effectName = effectName
+	return lut
+}
+
+// decodeSonicTimeCurveWarp decodes a TimeCurveWarp, skipping keyframes
+// without both times.
+func decodeSonicTimeCurveWarp(m map[string]any) *TimeCurveWarp {
+	name, _ := m["name"].(string)
+	list, _ := m["keyframes"].([]any)
+	keyframes := make([]TimeKeyframe, 0, len(list))
+	for _, v := range list {
+		km, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		input, output := decodeSonicRationalTime(nil, km["input"]), decodeSonicRationalTime(nil, km["output"])
+		if input == nil || output == nil {
+			continue
+		}
+		interpolation, _ := km["interpolation"].(string)
+		keyframes = append(keyframes, TimeKeyframe{Input: *input, Output: *output, Interpolation: TimeInterpolation(interpolation)})
+	}
+	warp := NewTimeCurveWarp(name, keyframes, decodeSonicMetadata(m))
+	warp.effectName, _ = m["effect_name"].(string)
+	return warp
+}
+
 // decodeSonicTimeEffect decodes a TimeEffect for top-level decoding.
 func decodeSonicTimeEffect(m map[string]any) *TimeEffectImpl {
 	name, _ := m["name"].(string)
@@ -396,7 +521,7 @@ func decodeSonicTimeEffect(m map[string]any) *TimeEffectImpl {
 }
 
 // decodeSonicImageSequenceReference decodes an ImageSequenceReference.
-func decodeSonicImageSequenceReference(m map[string]any) *ImageSequenceReference {
+func decodeSonicImageSequenceReference(a *decodeArena, m map[string]any) *ImageSequenceReference {
 	name, _ := m["name"].(string)
 	targetURLBase, _ := m["target_url_base"].(string)
 	namePrefix, _ := m["name_prefix"].(string)
@@ -407,7 +532,7 @@ func decodeSonicImageSequenceReference(m map[string]any) *ImageSequenceReference
 	frameZeroPadding, _ := m["frame_zero_padding"].(float64)
 	missingFramePolicy, _ := m["missing_frame_policy"].(string)
 	metadata := decodeSonicMetadata(m)
-	availRange := decodeSonicTimeRange(m["available_range"])
+	availRange := decodeSonicTimeRange(a, m["available_range"])
 
 	return NewImageSequenceReference(
 		name,
@@ -433,14 +558,14 @@ func decodeSonicUnknownSchema(schema string, m map[string]any) *UnknownSchema {
 }
 
 // decodeSonicMarker decodes a Marker from a sonic-parsed map.
-func decodeSonicMarker(m map[string]any) *Marker {
+func decodeSonicMarker(a *decodeArena, m map[string]any) *Marker {
 	name, _ := m["name"].(string)
 	comment, _ := m["comment"].(string)
 	color, _ := m["color"].(string)
 	metadata := decodeSonicMetadata(m)
 
 	var markedRange opentime.TimeRange
-	if tr := decodeSonicTimeRange(m["marked_range"]); tr != nil {
+	if tr := decodeSonicTimeRange(a, m["marked_range"]); tr != nil {
 		markedRange = *tr
 	}
 
@@ -448,7 +573,7 @@ func decodeSonicMarker(m map[string]any) *Marker {
 }
 
 // decodeSonicTimeRange decodes a TimeRange from a sonic-parsed map.
-func decodeSonicTimeRange(v any) *opentime.TimeRange {
+func decodeSonicTimeRange(a *decodeArena, v any) *opentime.TimeRange {
 	m, ok := v.(map[string]any)
 	if !ok || m == nil {
 		return nil
@@ -468,11 +593,10 @@ func decodeSonicTimeRange(v any) *opentime.TimeRange {
 	durValue, _ := dur["value"].(float64)
 	durRate, _ := dur["rate"].(float64)
 
-	tr := opentime.NewTimeRange(
+	return a.timeRange(opentime.NewTimeRange(
 		opentime.NewRationalTime(stValue, stRate),
 		opentime.NewRationalTime(durValue, durRate),
-	)
-	return &tr
+	))
 }
 
 // decodeSonicColor decodes a Color from a sonic-parsed map.
@@ -490,66 +614,104 @@ func decodeSonicColor(v any) *Color {
 
 // FromJSONBytesSonic parses JSON using sonic for high performance.
 func FromJSONBytesSonic(data []byte) (SerializableObject, error) {
+	return fromJSONBytesSonic(data, nil)
+}
+
+// fromJSONBytesSonic parses JSON using sonic, allocating from a if it is
+// not nil.
+func fromJSONBytesSonic(data []byte, a *decodeArena) (SerializableObject, error) {
+	m, err := parseSonicObject(data)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSonicObject(a, m)
+}
+
+// parseSonicObject parses a JSON object using sonic, after replacing
+// Python's non-standard values.
+func parseSonicObject(data []byte) (map[string]any, error) {
 	// Sanitize non-standard JSON values (Inf, NaN) from Python
 	data = SanitizeJSON(data)
 
 	var m map[string]any
 	if err := sonic.Unmarshal(data, &m); err != nil {
-		return nil, fmt.Errorf("sonic unmarshal: %w", err)
+		return nil, &JSONError{Message: err.Error(), Err: err}
 	}
-
-	return decodeSonicObject(m)
+	return m, nil
 }
 
 // decodeSonicObject decodes a map into a SerializableObject based on schema.
-func decodeSonicObject(m map[string]any) (SerializableObject, error) {
+func decodeSonicObject(a *decodeArena, m map[string]any) (SerializableObject, error) {
 	schema, _ := m["OTIO_SCHEMA"].(string)
 
 	switch schema {
 	// Container types
 	case "Timeline.1":
-		return decodeSonicTimeline(m)
+		return decodeSonicTimeline(a, m)
 	case "Stack.1":
-		return decodeSonicStack(m)
+		return decodeSonicStack(a, m)
 	case "Track.1", "Sequence.1": // Sequence is legacy name for Track
-		return decodeSonicTrack(m)
+		return decodeSonicTrack(a, m)
 	case "Clip.2":
-		return decodeSonicClip(m)
+		return decodeSonicClip(a, m)
 	case "SerializableCollection.1":
-		return decodeSonicSerializableCollection(m)
+		return decodeSonicSerializableCollection(a, m)
 	case "Gap.1":
-		return decodeSonicGap(m), nil
+		return decodeSonicGap(a, m)
 	case "Transition.1":
-		return decodeSonicTransition(m), nil
+		return decodeSonicTransition(a, m), nil
 
 	// Leaf types
 	case "Marker.2":
-		return decodeSonicMarker(m), nil
+		return decodeSonicMarker(a, m), nil
 	case "ExternalReference.1":
-		return decodeSonicExternalReference(m), nil
+		return decodeSonicExternalReference(a, m), nil
 	case "MissingReference.1":
-		return decodeSonicMissingReference(m), nil
+		return decodeSonicMissingReference(a, m), nil
 	case "GeneratorReference.1":
-		return decodeSonicGeneratorReference(m), nil
+		return decodeSonicGeneratorReference(a, m), nil
+	case "TimelineReference.1":
+		return decodeSonicTimelineReference(a, m), nil
 	case "Effect.1":
 		return decodeSonicEffectImpl(m), nil
 	case "LinearTimeWarp.1":
 		return decodeSonicLinearTimeWarp(m), nil
 	case "FreezeFrame.1":
 		return decodeSonicFreezeFrame(m), nil
+	case "ASC_CDL.1":
+		return decodeSonicASCCDL(m), nil
+	case "LUT.1":
+		return decodeSonicLUT(m), nil
+	case "TimeCurveWarp.1":
+		return decodeSonicTimeCurveWarp(m), nil
 	case "TimeEffect.1":
 		return decodeSonicTimeEffect(m), nil
 	case "ImageSequenceReference.1":
-		return decodeSonicImageSequenceReference(m), nil
+		return decodeSonicImageSequenceReference(a, m), nil
 
 	default:
+		if obj, ok, err := decodeSonicRegistered(m); ok {
+			return obj, err
+		}
 		// Handle unknown schemas for forward compatibility
 		return decodeSonicUnknownSchema(schema, m), nil
 	}
 }
 
+// decodeSonicRegistered decodes m with a registered decoder, if there is
+// one, reporting a failure of the decoder as a SchemaError.
+func decodeSonicRegistered(m map[string]any) (SerializableObject, bool, error) {
+	obj, ok, err := decodeRegisteredSchema(m)
+	if err != nil {
+		schema, _ := m["OTIO_SCHEMA"].(string)
+		name, version, _ := ParseSchema(schema)
+		return nil, true, &SchemaError{Schema: name, Version: version, Err: err}
+	}
+	return obj, ok, nil
+}
+
 // decodeSonicSerializableCollection decodes a SerializableCollection.
-func decodeSonicSerializableCollection(m map[string]any) (*SerializableCollection, error) {
+func decodeSonicSerializableCollection(a *decodeArena, m map[string]any) (*SerializableCollection, error) {
 	name, _ := m["name"].(string)
 	metadata := decodeSonicMetadata(m)
 
@@ -557,9 +719,11 @@ func decodeSonicSerializableCollection(m map[string]any) (*SerializableCollectio
 	if childs, ok := m["children"].([]any); ok {
 		for _, childAny := range childs {
 			if childMap, ok := childAny.(map[string]any); ok {
-				if child, err := decodeSonicObject(childMap); err == nil {
-					children = append(children, child)
+				child, err := decodeSonicObject(a, childMap)
+				if err != nil {
+					return nil, err
 				}
+				children = append(children, child)
 			}
 		}
 	}
@@ -0,0 +1,361 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package edit
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/algorithms"
+)
+
+// ErrInvalidPlacement is returned for a batched edit whose range is empty
+// or overlaps another range placed in the same call.
+var ErrInvalidPlacement = errors.New("invalid placement")
+
+// PlacedClip is an item and the range of the track it is placed at.
+type PlacedClip struct {
+	Item  gotio.Item
+	Range opentime.TimeRange
+}
+
+// BatchConfig holds configuration for Batch.
+type BatchConfig struct {
+	OverrideLocks bool
+}
+
+// BatchOption is a functional option for Batch.
+type BatchOption func(*BatchConfig)
+
+// WithBatchOverrideLocks sets whether batched edits may touch locked
+// tracks and regions.
+func WithBatchOverrideLocks(override bool) BatchOption {
+	return func(c *BatchConfig) {
+		c.OverrideLocks = override
+	}
+}
+
+// Batcher applies edits to a layout of a track: its children and their
+// ranges, computed once when the batch starts. Edits update the layout
+// without touching the track; the track is rebuilt from the layout once
+// when the batch commits.
+type Batcher struct {
+	track    *gotio.Track
+	config   BatchConfig
+	segments []segment
+	scratch  []segment
+}
+
+// segment is a child of the track in the layout. Transitions take no
+// time, so their segments are empty.
+type segment struct {
+	child       gotio.Composable
+	start, end  opentime.RationalTime
+	sourceRange opentime.TimeRange
+	// trimmed is set once the source range differs from the child's.
+	trimmed bool
+}
+
+// Batch runs fn with a Batcher for track and then rebuilds the track from
+// the edited layout in a single pass. Ranges are computed once up front
+// instead of after every edit, so thousands of edits, such as a conform
+// from an edit list, cost little more than one.
+//
+// The batch is all or nothing: if fn or any edit fails, the track is left
+// as it was. Items passed to the Batcher are cloned. As with the
+// algorithms package, edits touching a locked track or region return
+// algorithms.ErrLocked unless WithBatchOverrideLocks is set.
+func Batch(track *gotio.Track, fn func(b *Batcher) error, opts ...BatchOption) error {
+	b := &Batcher{track: track}
+	for _, opt := range opts {
+		opt(&b.config)
+	}
+	if err := b.layout(); err != nil {
+		return err
+	}
+	if err := fn(b); err != nil {
+		return err
+	}
+	return b.commit()
+}
+
+// Batch runs edit.Batch as an undoable command.
+func (s *Session) Batch(track *gotio.Track, fn func(b *Batcher) error, opts ...BatchOption) error {
+	return s.Do("batch", track, func() error {
+		return Batch(track, fn, opts...)
+	})
+}
+
+// layout computes the segments of the track's children.
+func (b *Batcher) layout() error {
+	children := b.track.Children()
+	b.segments = make([]segment, 0, len(children))
+	var t opentime.RationalTime
+	for _, child := range children {
+		seg := segment{child: child, start: t, end: t}
+		if item, ok := child.(gotio.Item); ok && child.Visible() {
+			sr, err := sourceRangeOf(item)
+			if err != nil {
+				return err
+			}
+			d, err := item.Duration()
+			if err != nil {
+				return err
+			}
+			seg.sourceRange = sr
+			if t.Rate() <= 0 {
+				seg.start = opentime.NewRationalTime(0, d.Rate())
+			}
+			seg.end = seg.start.Add(d)
+			t = seg.end
+		}
+		b.segments = append(b.segments, seg)
+	}
+	return nil
+}
+
+// commit replaces the children of the track with the layout. A child that
+// was split is used for its first part and cloned for the others.
+func (b *Batcher) commit() error {
+	children := make([]gotio.Composable, 0, len(b.segments))
+	used := make(map[gotio.Composable]bool, len(b.segments))
+	for _, seg := range b.segments {
+		child := seg.child
+		if used[child] {
+			child = child.Clone().(gotio.Composable)
+		}
+		used[seg.child] = true
+		if seg.trimmed {
+			sr := seg.sourceRange
+			child.(gotio.Item).SetSourceRange(&sr)
+		}
+		children = append(children, child)
+	}
+	return b.track.SetChildren(children)
+}
+
+// Duration returns the duration of the track as edited so far.
+func (b *Batcher) Duration() opentime.RationalTime {
+	if len(b.segments) == 0 {
+		return opentime.RationalTime{}
+	}
+	return b.segments[len(b.segments)-1].end
+}
+
+// Overwrite replaces the content in r with item, like
+// algorithms.Overwrite. The item keeps the start of its source range,
+// or of its available range if it has none, and takes the duration of r.
+func (b *Batcher) Overwrite(item gotio.Item, r opentime.TimeRange) error {
+	return b.OverwriteMany([]PlacedClip{{Item: item, Range: r}})
+}
+
+// OverwriteMany overwrites each range with its item in a single pass over
+// the track. The ranges must not overlap.
+func (b *Batcher) OverwriteMany(clips []PlacedClip) error {
+	placed := make([]segment, len(clips))
+	for i, clip := range clips {
+		if clip.Range.Duration().Value() <= 0 {
+			return ErrInvalidPlacement
+		}
+		if err := b.checkLocks(clip.Range.StartTime(), clip.Range.EndTimeExclusive()); err != nil {
+			return err
+		}
+		seg, err := newSegment(clip.Item, clip.Range)
+		if err != nil {
+			return err
+		}
+		placed[i] = seg
+	}
+	slices.SortStableFunc(placed, func(x, y segment) int {
+		return x.start.Cmp(y.start)
+	})
+	for i := 1; i < len(placed); i++ {
+		if placed[i].start.Cmp(placed[i-1].end) < 0 {
+			return ErrInvalidPlacement
+		}
+	}
+
+	out := b.scratch[:0]
+	rest := b.segments
+	for _, p := range placed {
+		out, rest = cutBefore(out, rest, p.start)
+		out = dropTransitionsAt(out, p.start)
+		if end := endOf(out); end.Rate() > 0 && end.Cmp(p.start) < 0 && len(rest) == 0 {
+			out = append(out, newGapSegment(end, p.start))
+		} else if len(out) == 0 && len(rest) == 0 && p.start.Value() > 0 {
+			out = append(out, newGapSegment(opentime.NewRationalTime(0, p.start.Rate()), p.start))
+		}
+		rest = skipBefore(rest, p.end)
+		for len(rest) > 0 && isTransition(rest[0]) && rest[0].start.Cmp(p.end) == 0 {
+			rest = rest[1:]
+		}
+		out = append(out, p)
+	}
+	out = append(out, rest...)
+	b.scratch, b.segments = b.segments, out
+	return nil
+}
+
+// Insert inserts item at t, moving everything after t later, like
+// algorithms.Insert.
+func (b *Batcher) Insert(item gotio.Item, t opentime.RationalTime) error {
+	end := b.Duration()
+	if end.Rate() <= 0 || end.Cmp(t) < 0 {
+		end = t
+	}
+	if err := b.checkLocks(t, end); err != nil {
+		return err
+	}
+	clone := item.Clone().(gotio.Item)
+	sr, err := sourceRangeOf(clone)
+	if err != nil {
+		return err
+	}
+	d := sr.Duration()
+	if d.Value() <= 0 {
+		return ErrInvalidPlacement
+	}
+
+	out := b.scratch[:0]
+	out, rest := cutBefore(out, b.segments, t)
+	out = dropTransitionsAt(out, t)
+	for len(rest) > 0 && isTransition(rest[0]) && rest[0].start.Cmp(t) == 0 {
+		rest = rest[1:]
+	}
+	if last := endOf(out); last.Rate() > 0 && last.Cmp(t) < 0 {
+		out = append(out, newGapSegment(last, t))
+	} else if len(out) == 0 && t.Value() > 0 {
+		out = append(out, newGapSegment(opentime.NewRationalTime(0, t.Rate()), t))
+	}
+	out = append(out, segment{child: clone, start: t, end: t.Add(d), sourceRange: sr, trimmed: clone.SourceRange() == nil})
+	for _, seg := range rest {
+		seg.start, seg.end = seg.start.Add(d), seg.end.Add(d)
+		out = append(out, seg)
+	}
+	b.scratch, b.segments = b.segments, out
+	return nil
+}
+
+// Remove replaces the content in r with a gap, like algorithms.RemoveRange
+// with fill.
+func (b *Batcher) Remove(r opentime.TimeRange) error {
+	return b.Overwrite(gotio.NewGapWithDuration(r.Duration()), r)
+}
+
+func (b *Batcher) checkLocks(start, end opentime.RationalTime) error {
+	if b.config.OverrideLocks {
+		return nil
+	}
+	if b.track.IsRangeLocked(opentime.RangeFromStartEndTime(start, end)) {
+		return algorithms.ErrLocked
+	}
+	return nil
+}
+
+// newSegment clones item and sets it to fill r.
+func newSegment(item gotio.Item, r opentime.TimeRange) (segment, error) {
+	clone := item.Clone().(gotio.Item)
+	sr, err := sourceRangeOf(clone)
+	if err != nil {
+		return segment{}, err
+	}
+	sr = opentime.NewTimeRange(sr.StartTime(), r.Duration())
+	return segment{
+		child:       clone,
+		start:       r.StartTime(),
+		end:         r.EndTimeExclusive(),
+		sourceRange: sr,
+		trimmed:     true,
+	}, nil
+}
+
+func newGapSegment(start, end opentime.RationalTime) segment {
+	gap := gotio.NewGapWithDuration(end.Sub(start))
+	return segment{child: gap, start: start, end: end, sourceRange: *gap.SourceRange()}
+}
+
+// sourceRangeOf returns the source range of item, or its available range,
+// or an empty range at zero if it has neither.
+func sourceRangeOf(item gotio.Item) (opentime.TimeRange, error) {
+	if sr := item.SourceRange(); sr != nil {
+		return *sr, nil
+	}
+	ar, err := item.AvailableRange()
+	if err != nil {
+		return opentime.TimeRange{}, nil
+	}
+	return ar, nil
+}
+
+// cutBefore appends to out the segments of rest before t, splitting a
+// segment that spans t, and returns the segments after t. The first of
+// them may be overwritten in place.
+func cutBefore(out, rest []segment, t opentime.RationalTime) ([]segment, []segment) {
+	n := endingBefore(rest, t)
+	out = append(out, rest[:n]...)
+	rest = rest[n:]
+	if len(rest) > 0 && rest[0].start.Cmp(t) < 0 {
+		left, right := split(rest[0], t)
+		out = append(out, left)
+		rest[0] = right
+	}
+	return out, rest
+}
+
+// skipBefore is cutBefore discarding the segments before t.
+func skipBefore(rest []segment, t opentime.RationalTime) []segment {
+	rest = rest[endingBefore(rest, t):]
+	if len(rest) > 0 && rest[0].start.Cmp(t) < 0 {
+		_, rest[0] = split(rest[0], t)
+	}
+	return rest
+}
+
+// endingBefore returns the number of segments that end at or before t.
+func endingBefore(segments []segment, t opentime.RationalTime) int {
+	n, _ := slices.BinarySearchFunc(segments, t, func(seg segment, t opentime.RationalTime) int {
+		if seg.end.Cmp(t) <= 0 {
+			return -1
+		}
+		return 1
+	})
+	return n
+}
+
+// split cuts a segment at t, which must be inside it.
+func split(seg segment, t opentime.RationalTime) (segment, segment) {
+	offset := t.Sub(seg.start).RescaledTo(seg.sourceRange.StartTime().Rate())
+	left, right := seg, seg
+	left.end, right.start = t, t
+	left.sourceRange = opentime.NewTimeRange(seg.sourceRange.StartTime(), offset)
+	right.sourceRange = opentime.NewTimeRange(
+		seg.sourceRange.StartTime().Add(offset),
+		seg.sourceRange.Duration().Sub(offset),
+	)
+	left.trimmed, right.trimmed = true, true
+	return left, right
+}
+
+// dropTransitionsAt removes transitions at t from the end of out, since
+// the item on one side of them is being replaced.
+func dropTransitionsAt(out []segment, t opentime.RationalTime) []segment {
+	for len(out) > 0 && isTransition(out[len(out)-1]) && out[len(out)-1].start.Cmp(t) == 0 {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+func isTransition(seg segment) bool {
+	_, ok := seg.child.(*gotio.Transition)
+	return ok
+}
+
+func endOf(segments []segment) opentime.RationalTime {
+	if len(segments) == 0 {
+		return opentime.RationalTime{}
+	}
+	return segments[len(segments)-1].end
+}
@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package edit
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/algorithms"
+)
+
+func batchTrack(durations ...float64) *gotio.Track {
+	_, track := newTestSession(durations...)
+	return track
+}
+
+func batchClip(name string, duration float64) gotio.Item {
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(duration, 24))
+	return gotio.NewClip(name, nil, &sr, nil, nil, nil, "", nil)
+}
+
+func batchRange(start, duration float64) opentime.TimeRange {
+	return opentime.NewTimeRange(opentime.NewRationalTime(start, 24), opentime.NewRationalTime(duration, 24))
+}
+
+// layoutOf describes the children of a track as name:duration pairs.
+func layoutOf(track *gotio.Track) string {
+	s := ""
+	for _, child := range track.Children() {
+		d := 0.0
+		if item, ok := child.(gotio.Item); ok {
+			dur, _ := item.Duration()
+			d = dur.Value()
+		}
+		s += fmt.Sprintf("%s:%g ", child.Name(), d)
+	}
+	return s
+}
+
+func TestBatchOverwriteManyMatchesOverwrite(t *testing.T) {
+	placements := func() []PlacedClip {
+		return []PlacedClip{
+			{Item: batchClip("X", 30), Range: batchRange(40, 30)},
+			{Item: batchClip("Y", 4), Range: batchRange(10, 4)},
+			{Item: batchClip("Z", 10), Range: batchRange(96, 10)},
+			{Item: batchClip("W", 6), Range: batchRange(14, 6)},
+		}
+	}
+
+	want := batchTrack(24, 24, 24)
+	for _, p := range placements() {
+		if err := algorithms.Overwrite(p.Item, want, p.Range); err != nil {
+			t.Fatalf("Overwrite failed: %v", err)
+		}
+	}
+
+	track := batchTrack(24, 24, 24)
+	batched := placements()
+	err := Batch(track, func(b *Batcher) error {
+		return b.OverwriteMany(batched)
+	})
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+	if got, exp := layoutOf(track), layoutOf(want); got != exp {
+		t.Errorf("layout = %q, want %q", got, exp)
+	}
+	for i, child := range track.Children() {
+		got := child.(gotio.Item).SourceRange()
+		exp := want.Children()[i].(gotio.Item).SourceRange()
+		if !got.StartTime().Equal(exp.StartTime()) {
+			t.Errorf("child %d starts at %v, want %v", i, got.StartTime(), exp.StartTime())
+		}
+	}
+	if batched[0].Item.Parent() != nil {
+		t.Error("placed item was not cloned")
+	}
+}
+
+func TestBatchInsertAndRemove(t *testing.T) {
+	track := batchTrack(24, 24)
+	err := Batch(track, func(b *Batcher) error {
+		if err := b.Insert(batchClip("X", 10), opentime.NewRationalTime(12, 24)); err != nil {
+			return err
+		}
+		if err := b.Remove(batchRange(34, 12)); err != nil {
+			return err
+		}
+		if got := b.Duration().Value(); got != 58 {
+			t.Errorf("Duration() = %v, want 58", got)
+		}
+		return b.Insert(batchClip("Y", 2), opentime.NewRationalTime(60, 24))
+	})
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+	want := "clip_A:12 X:10 clip_A:12 :12 clip_B:12 :2 Y:2 "
+	if got := layoutOf(track); got != want {
+		t.Errorf("layout = %q, want %q", got, want)
+	}
+	second := track.Children()[2].(gotio.Item).SourceRange()
+	if second.StartTime().Value() != 12 {
+		t.Errorf("split clip starts at %v, want 12", second.StartTime().Value())
+	}
+}
+
+func TestBatchIsAllOrNothing(t *testing.T) {
+	track := batchTrack(24, 24)
+	before := layoutOf(track)
+
+	err := Batch(track, func(b *Batcher) error {
+		if err := b.Overwrite(batchClip("X", 5), batchRange(0, 12)); err != nil {
+			return err
+		}
+		return b.OverwriteMany([]PlacedClip{
+			{Item: batchClip("Y", 5), Range: batchRange(20, 10)},
+			{Item: batchClip("Z", 5), Range: batchRange(25, 10)},
+		})
+	})
+	if err != ErrInvalidPlacement {
+		t.Errorf("expected ErrInvalidPlacement, got %v", err)
+	}
+	if got := layoutOf(track); got != before {
+		t.Errorf("failed batch changed the track: %q", got)
+	}
+
+	track.AddLockRegion(gotio.LockRegion{Range: batchRange(24, 24)})
+	edit := func(b *Batcher) error {
+		return b.Overwrite(batchClip("X", 5), batchRange(20, 10))
+	}
+	if err := Batch(track, edit); err != algorithms.ErrLocked {
+		t.Errorf("expected ErrLocked, got %v", err)
+	}
+	if err := Batch(track, edit, WithBatchOverrideLocks(true)); err != nil {
+		t.Errorf("override failed: %v", err)
+	}
+}
+
+func TestSessionBatchUndo(t *testing.T) {
+	session, track := newTestSession(24, 24, 24)
+	before := layoutOf(track)
+	err := session.Batch(track, func(b *Batcher) error {
+		return b.OverwriteMany([]PlacedClip{
+			{Item: batchClip("X", 5), Range: batchRange(12, 24)},
+			{Item: batchClip("Y", 5), Range: batchRange(60, 24)},
+		})
+	})
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+	if trackDuration(t, track) != 84 {
+		t.Errorf("duration = %v, want 84", trackDuration(t, track))
+	}
+	if err := session.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if got := layoutOf(track); got != before {
+		t.Errorf("after undo layout = %q, want %q", got, before)
+	}
+}
+
+func BenchmarkBatchOverwriteMany(b *testing.B) {
+	const clips = 500
+	durations := make([]float64, clips)
+	placements := make([]PlacedClip, clips)
+	for i := range durations {
+		durations[i] = 24
+		placements[i] = PlacedClip{Item: batchClip("X", 12), Range: batchRange(float64(i)*24+6, 12)}
+	}
+
+	b.Run("Batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			track := batchTrack(durations...)
+			err := Batch(track, func(bt *Batcher) error {
+				return bt.OverwriteMany(placements)
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Overwrite", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			track := batchTrack(durations...)
+			for _, p := range placements {
+				if err := algorithms.Overwrite(p.Item, track, p.Range); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
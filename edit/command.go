@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package edit
+
+import (
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// command is a journaled edit with the state on either side of it.
+type command struct {
+	name   string
+	before *snapshot
+	after  *snapshot
+}
+
+// snapshot records the children of a composition and the mutable edit
+// state of each child. Children are recorded by identity, so restoring a
+// snapshot keeps references held by callers valid.
+type snapshot struct {
+	composition gotio.Composition
+	children    []gotio.Composable
+	states      []childState
+}
+
+// childState is the part of a child that edit operations modify in place.
+type childState struct {
+	child       gotio.Composable
+	sourceRange *opentime.TimeRange
+	effects     []gotio.Effect
+	inOffset    opentime.RationalTime
+	outOffset   opentime.RationalTime
+}
+
+// capture records the state of a composition and, if given, an item that
+// may not belong to it.
+func capture(composition gotio.Composition, item gotio.Item) *snapshot {
+	snap := &snapshot{}
+	if composition != nil {
+		snap.composition = composition
+		snap.children = append([]gotio.Composable(nil), composition.Children()...)
+		for _, child := range snap.children {
+			snap.states = append(snap.states, captureChild(child))
+		}
+	}
+	if item != nil && (composition == nil || !composition.IsParentOf(item)) {
+		snap.states = append(snap.states, captureChild(item))
+	}
+	return snap
+}
+
+// captureChild records the edit state of a single child.
+func captureChild(child gotio.Composable) childState {
+	state := childState{child: child}
+	switch c := child.(type) {
+	case *gotio.Transition:
+		state.inOffset = c.InOffset()
+		state.outOffset = c.OutOffset()
+	case gotio.Item:
+		if sr := c.SourceRange(); sr != nil {
+			r := *sr
+			state.sourceRange = &r
+		}
+		state.effects = append([]gotio.Effect(nil), c.Effects()...)
+	}
+	return state
+}
+
+// restore puts the composition and its children back into the recorded state.
+func (s *snapshot) restore() {
+	if s.composition != nil {
+		s.composition.ClearChildren()
+		for _, child := range s.children {
+			s.composition.AppendChild(child)
+		}
+	}
+	for _, state := range s.states {
+		switch c := state.child.(type) {
+		case *gotio.Transition:
+			c.SetInOffset(state.inOffset)
+			c.SetOutOffset(state.outOffset)
+		case gotio.Item:
+			if state.sourceRange != nil {
+				r := *state.sourceRange
+				c.SetSourceRange(&r)
+			} else {
+				c.SetSourceRange(nil)
+			}
+			c.SetEffects(append([]gotio.Effect(nil), state.effects...))
+		}
+	}
+}
@@ -0,0 +1,263 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package edit provides an undoable editing session for OpenTimelineIO timelines.
+// A Session records every mutation made through it as an invertible command,
+// so interactive tools can undo and redo edits without deep-copying the
+// whole timeline.
+package edit
+
+import (
+	"errors"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/algorithms"
+)
+
+func init() {
+	gotio.RegisterFeature("edit")
+}
+
+// Session errors.
+var (
+	ErrNothingToUndo     = errors.New("nothing to undo")
+	ErrNothingToRedo     = errors.New("nothing to redo")
+	ErrInvalidCheckpoint = errors.New("invalid checkpoint")
+)
+
+// Checkpoint marks a position in a session's journal.
+type Checkpoint int
+
+// Session wraps a Timeline and journals the edits made to it.
+type Session struct {
+	timeline *gotio.Timeline
+	undo     []*command
+	redo     []*command
+}
+
+// NewSession creates a new Session for the given timeline.
+func NewSession(timeline *gotio.Timeline) *Session {
+	return &Session{timeline: timeline}
+}
+
+// Timeline returns the timeline being edited.
+func (s *Session) Timeline() *gotio.Timeline {
+	return s.timeline
+}
+
+// Do runs fn as a single undoable command named name.
+// The children of composition and their edit state are recorded before and
+// after fn runs. If fn fails, the composition is restored and nothing is
+// recorded.
+func (s *Session) Do(name string, composition gotio.Composition, fn func() error) error {
+	return s.do(name, composition, nil, fn)
+}
+
+// do runs fn as a command, additionally recording item when it may not
+// belong to a composition.
+func (s *Session) do(name string, composition gotio.Composition, item gotio.Item, fn func() error) error {
+	before := capture(composition, item)
+	if err := fn(); err != nil {
+		before.restore()
+		return err
+	}
+	s.undo = append(s.undo, &command{
+		name:   name,
+		before: before,
+		after:  capture(composition, item),
+	})
+	s.redo = nil
+	return nil
+}
+
+// Undo reverts the most recent command.
+func (s *Session) Undo() error {
+	if len(s.undo) == 0 {
+		return ErrNothingToUndo
+	}
+	cmd := s.undo[len(s.undo)-1]
+	s.undo = s.undo[:len(s.undo)-1]
+	cmd.before.restore()
+	s.redo = append(s.redo, cmd)
+	return nil
+}
+
+// Redo reapplies the most recently undone command.
+func (s *Session) Redo() error {
+	if len(s.redo) == 0 {
+		return ErrNothingToRedo
+	}
+	cmd := s.redo[len(s.redo)-1]
+	s.redo = s.redo[:len(s.redo)-1]
+	cmd.after.restore()
+	s.undo = append(s.undo, cmd)
+	return nil
+}
+
+// CanUndo returns whether there is a command to undo.
+func (s *Session) CanUndo() bool {
+	return len(s.undo) > 0
+}
+
+// CanRedo returns whether there is a command to redo.
+func (s *Session) CanRedo() bool {
+	return len(s.redo) > 0
+}
+
+// UndoName returns the name of the command Undo would revert.
+func (s *Session) UndoName() string {
+	if len(s.undo) == 0 {
+		return ""
+	}
+	return s.undo[len(s.undo)-1].name
+}
+
+// RedoName returns the name of the command Redo would reapply.
+func (s *Session) RedoName() string {
+	if len(s.redo) == 0 {
+		return ""
+	}
+	return s.redo[len(s.redo)-1].name
+}
+
+// Checkpoint returns a marker for the current position in the journal.
+func (s *Session) Checkpoint() Checkpoint {
+	return Checkpoint(len(s.undo))
+}
+
+// RollbackTo undoes every command recorded after the checkpoint.
+// The undone commands remain available to Redo.
+func (s *Session) RollbackTo(cp Checkpoint) error {
+	if cp < 0 || int(cp) > len(s.undo) {
+		return ErrInvalidCheckpoint
+	}
+	for len(s.undo) > int(cp) {
+		if err := s.Undo(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Insert runs algorithms.Insert as an undoable command.
+func (s *Session) Insert(
+	item gotio.Item,
+	composition gotio.Composition,
+	time opentime.RationalTime,
+	opts ...algorithms.InsertOption,
+) error {
+	return s.Do("insert", composition, func() error {
+		return algorithms.Insert(item, composition, time, opts...)
+	})
+}
+
+// Overwrite runs algorithms.Overwrite as an undoable command.
+func (s *Session) Overwrite(
+	item gotio.Item,
+	composition gotio.Composition,
+	timeRange opentime.TimeRange,
+	opts ...algorithms.OverwriteOption,
+) error {
+	return s.Do("overwrite", composition, func() error {
+		return algorithms.Overwrite(item, composition, timeRange, opts...)
+	})
+}
+
+// Slice runs algorithms.Slice as an undoable command.
+func (s *Session) Slice(
+	composition gotio.Composition,
+	time opentime.RationalTime,
+	opts ...algorithms.SliceOption,
+) error {
+	return s.Do("slice", composition, func() error {
+		return algorithms.Slice(composition, time, opts...)
+	})
+}
+
+// Trim runs algorithms.Trim as an undoable command.
+func (s *Session) Trim(
+	item gotio.Item,
+	composition gotio.Composition,
+	deltaIn opentime.RationalTime,
+	deltaOut opentime.RationalTime,
+	opts ...algorithms.TrimOption,
+) error {
+	return s.Do("trim", composition, func() error {
+		return algorithms.Trim(item, composition, deltaIn, deltaOut, opts...)
+	})
+}
+
+// Roll runs algorithms.Roll as an undoable command.
+func (s *Session) Roll(
+	item gotio.Item,
+	composition gotio.Composition,
+	deltaIn opentime.RationalTime,
+	deltaOut opentime.RationalTime,
+	opts ...algorithms.RollOption,
+) error {
+	return s.Do("roll", composition, func() error {
+		return algorithms.Roll(item, composition, deltaIn, deltaOut, opts...)
+	})
+}
+
+// Slide runs algorithms.Slide as an undoable command.
+func (s *Session) Slide(
+	item gotio.Item,
+	composition gotio.Composition,
+	delta opentime.RationalTime,
+	opts ...algorithms.EditOption,
+) error {
+	return s.Do("slide", composition, func() error {
+		return algorithms.Slide(item, composition, delta, opts...)
+	})
+}
+
+// Slip runs algorithms.Slip as an undoable command.
+func (s *Session) Slip(item gotio.Item, delta opentime.RationalTime, opts ...algorithms.EditOption) error {
+	return s.do("slip", item.Parent(), item, func() error {
+		return algorithms.Slip(item, delta, opts...)
+	})
+}
+
+// Ripple runs algorithms.Ripple as an undoable command.
+func (s *Session) Ripple(item gotio.Item, deltaIn, deltaOut opentime.RationalTime, opts ...algorithms.EditOption) error {
+	return s.do("ripple", item.Parent(), item, func() error {
+		return algorithms.Ripple(item, deltaIn, deltaOut, opts...)
+	})
+}
+
+// Remove runs algorithms.Remove as an undoable command.
+func (s *Session) Remove(
+	composition gotio.Composition,
+	time opentime.RationalTime,
+	opts ...algorithms.RemoveOption,
+) error {
+	return s.Do("remove", composition, func() error {
+		return algorithms.Remove(composition, time, opts...)
+	})
+}
+
+// RemoveRange runs algorithms.RemoveRange as an undoable command.
+func (s *Session) RemoveRange(
+	composition gotio.Composition,
+	timeRange opentime.TimeRange,
+	opts ...algorithms.RemoveOption,
+) error {
+	return s.Do("remove_range", composition, func() error {
+		return algorithms.RemoveRange(composition, timeRange, opts...)
+	})
+}
+
+// Fill runs algorithms.Fill as an undoable command.
+func (s *Session) Fill(
+	item gotio.Item,
+	composition gotio.Composition,
+	trackTime opentime.RationalTime,
+	referencePoint algorithms.ReferencePoint,
+	opts ...algorithms.EditOption,
+) error {
+	return s.Do("fill", composition, func() error {
+		return algorithms.Fill(item, composition, trackTime, referencePoint, opts...)
+	})
+}
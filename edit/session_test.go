@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package edit
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func newTestSession(durations ...float64) (*Session, *gotio.Track) {
+	timeline := gotio.NewTimeline("test", nil, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	for i, dur := range durations {
+		sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(dur, 24))
+		track.AppendChild(gotio.NewClip("clip_"+string(rune('A'+i)), nil, &sr, nil, nil, nil, "", nil))
+	}
+	timeline.Tracks().AppendChild(track)
+	return NewSession(timeline), track
+}
+
+func childNames(track *gotio.Track) []string {
+	var names []string
+	for _, child := range track.Children() {
+		names = append(names, child.Name())
+	}
+	return names
+}
+
+func trackDuration(t *testing.T, track *gotio.Track) float64 {
+	t.Helper()
+	dur, err := track.Duration()
+	if err != nil {
+		t.Fatalf("Duration failed: %v", err)
+	}
+	return dur.Value()
+}
+
+func TestSessionUndoRedoInsert(t *testing.T) {
+	session, track := newTestSession(24, 24)
+	clipA := track.Children()[0]
+
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(12, 24))
+	clip := gotio.NewClip("X", nil, &sr, nil, nil, nil, "", nil)
+	if err := session.Insert(clip, track, opentime.NewRationalTime(24, 24)); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if got := trackDuration(t, track); got != 60 {
+		t.Fatalf("expected duration 60 after insert, got %v", got)
+	}
+	if session.UndoName() != "insert" {
+		t.Errorf("expected undo name insert, got %q", session.UndoName())
+	}
+
+	if err := session.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if got := trackDuration(t, track); got != 48 {
+		t.Errorf("expected duration 48 after undo, got %v", got)
+	}
+	if len(track.Children()) != 2 {
+		t.Fatalf("expected 2 children after undo, got %d", len(track.Children()))
+	}
+	if track.Children()[0] != clipA {
+		t.Error("expected undo to restore the original clip instance")
+	}
+	if clipA.Parent() != gotio.Composition(track) {
+		t.Error("expected restored clip to be parented to the track")
+	}
+
+	if err := session.Redo(); err != nil {
+		t.Fatalf("Redo failed: %v", err)
+	}
+	names := childNames(track)
+	if len(names) != 3 || names[1] != "X" {
+		t.Errorf("expected [clip_A X clip_B] after redo, got %v", names)
+	}
+}
+
+func TestSessionUndoTrim(t *testing.T) {
+	session, track := newTestSession(48, 48)
+	clipB := track.Children()[1].(gotio.Item)
+
+	err := session.Trim(clipB, track, opentime.NewRationalTime(12, 24), opentime.RationalTime{})
+	if err != nil {
+		t.Fatalf("Trim failed: %v", err)
+	}
+	if clipB.SourceRange().StartTime().Value() != 12 {
+		t.Fatalf("expected clip_B start 12, got %v", clipB.SourceRange().StartTime().Value())
+	}
+
+	if err := session.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if clipB.SourceRange().StartTime().Value() != 0 {
+		t.Errorf("expected clip_B start 0 after undo, got %v", clipB.SourceRange().StartTime().Value())
+	}
+	clipA := track.Children()[0].(gotio.Item)
+	if clipA.SourceRange().Duration().Value() != 48 {
+		t.Errorf("expected clip_A duration 48 after undo, got %v", clipA.SourceRange().Duration().Value())
+	}
+}
+
+func TestSessionSlipWithoutComposition(t *testing.T) {
+	session := NewSession(gotio.NewTimeline("test", nil, nil))
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(10, 24), opentime.NewRationalTime(24, 24))
+	clip := gotio.NewClip("solo", nil, &sr, nil, nil, nil, "", nil)
+
+	if err := session.Slip(clip, opentime.NewRationalTime(5, 24)); err != nil {
+		t.Fatalf("Slip failed: %v", err)
+	}
+	if err := session.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if clip.SourceRange().StartTime().Value() != 10 {
+		t.Errorf("expected start 10 after undo, got %v", clip.SourceRange().StartTime().Value())
+	}
+}
+
+func TestSessionCheckpointRollback(t *testing.T) {
+	session, track := newTestSession(48)
+
+	if err := session.Slice(track, opentime.NewRationalTime(24, 24)); err != nil {
+		t.Fatalf("Slice failed: %v", err)
+	}
+	cp := session.Checkpoint()
+
+	if err := session.Slice(track, opentime.NewRationalTime(12, 24)); err != nil {
+		t.Fatalf("Slice failed: %v", err)
+	}
+	if err := session.Remove(track, opentime.NewRationalTime(30, 24)); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if len(track.Children()) != 3 {
+		t.Fatalf("expected 3 children, got %d", len(track.Children()))
+	}
+
+	if err := session.RollbackTo(cp); err != nil {
+		t.Fatalf("RollbackTo failed: %v", err)
+	}
+	if len(track.Children()) != 2 {
+		t.Errorf("expected 2 children after rollback, got %d", len(track.Children()))
+	}
+	if session.Checkpoint() != cp {
+		t.Errorf("expected checkpoint %d, got %d", cp, session.Checkpoint())
+	}
+	if !session.CanRedo() {
+		t.Error("expected rolled back commands to be redoable")
+	}
+
+	if err := session.RollbackTo(cp + 5); err != ErrInvalidCheckpoint {
+		t.Errorf("expected ErrInvalidCheckpoint, got %v", err)
+	}
+}
+
+func TestSessionNewCommandClearsRedo(t *testing.T) {
+	session, track := newTestSession(48)
+
+	if err := session.Slice(track, opentime.NewRationalTime(24, 24)); err != nil {
+		t.Fatalf("Slice failed: %v", err)
+	}
+	if err := session.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if err := session.Slice(track, opentime.NewRationalTime(12, 24)); err != nil {
+		t.Fatalf("Slice failed: %v", err)
+	}
+	if err := session.Redo(); err != ErrNothingToRedo {
+		t.Errorf("expected ErrNothingToRedo, got %v", err)
+	}
+}
+
+func TestSessionFailedCommandRestores(t *testing.T) {
+	session, track := newTestSession(24, 24)
+
+	err := session.Do("broken", track, func() error {
+		track.RemoveChild(0)
+		return ErrInvalidCheckpoint
+	})
+	if err != ErrInvalidCheckpoint {
+		t.Fatalf("expected command error, got %v", err)
+	}
+	if len(track.Children()) != 2 {
+		t.Errorf("expected failed command to be rolled back, got %d children", len(track.Children()))
+	}
+	if session.CanUndo() {
+		t.Error("expected failed command not to be journaled")
+	}
+	if err := session.Undo(); err != ErrNothingToUndo {
+		t.Errorf("expected ErrNothingToUndo, got %v", err)
+	}
+}
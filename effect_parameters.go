@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ParameterizedEffect is an effect with named, typed parameters, such as
+// the time scalar of a LinearTimeWarp. Renderers can read the parameters of
+// any known effect without switching on its type.
+type ParameterizedEffect interface {
+	Effect
+
+	// ParameterNames returns the names of the parameters, in order.
+	ParameterNames() []string
+
+	// Parameter returns the value of the named parameter.
+	Parameter(name string) (any, bool)
+
+	// SetParameter sets the named parameter. It returns an error matching
+	// ErrUnknownParameter for a name the effect does not have, and a
+	// TypeMismatchError for a value of the wrong type.
+	SetParameter(name string, value any) error
+}
+
+// IsTimeEffect reports whether the effect changes the timing of the item
+// it is on: a LinearTimeWarp, FreezeFrame, TimeCurveWarp, TimeEffect, or
+// any effect with a time scalar.
+func IsTimeEffect(effect Effect) bool {
+	switch effect.(type) {
+	case *LinearTimeWarp, *FreezeFrame, *TimeCurveWarp, *TimeEffectImpl:
+		return true
+	}
+	_, ok := effect.(interface{ TimeScalar() float64 })
+	return ok
+}
+
+// ItemTimeEffect returns the time effect of the item, or nil if it has
+// none.
+func ItemTimeEffect(item Item) Effect {
+	for _, effect := range item.Effects() {
+		if IsTimeEffect(effect) {
+			return effect
+		}
+	}
+	return nil
+}
+
+// effectsTimeScalar returns the combined speed of the LinearTimeWarp and
+// FreezeFrame effects among effects, 1 if there are none.
+func effectsTimeScalar(effects []Effect) float64 {
+	scalar := 1.0
+	for _, effect := range effects {
+		if e, ok := effect.(interface{ TimeScalar() float64 }); ok {
+			scalar *= e.TimeScalar()
+		}
+	}
+	return scalar
+}
+
+// EffectFloat returns a number parameter of the effect, and false if the
+// effect has no such parameter or it is not a number.
+func EffectFloat(effect Effect, name string) (float64, bool) {
+	pe, ok := effect.(ParameterizedEffect)
+	if !ok {
+		return 0, false
+	}
+	value, ok := pe.Parameter(name)
+	if !ok {
+		return 0, false
+	}
+	f, ok := value.(float64)
+	return f, ok
+}
+
+// unknownParameter returns the error for a parameter an effect lacks.
+func unknownParameter(effect Effect, name string) error {
+	return fmt.Errorf("%w: %s has no parameter %q", ErrUnknownParameter, effect.SchemaName(), name)
+}
+
+// parameterValue converts value to the type of target and stores it.
+func parameterValue[T any](target *T, value any) error {
+	v, ok := value.(T)
+	if !ok {
+		return &TypeMismatchError{Expected: reflect.TypeFor[T]().String(), Got: fmt.Sprintf("%T", value)}
+	}
+	*target = v
+	return nil
+}
+
+// ParameterNames returns the names of the parameters.
+func (l *LinearTimeWarp) ParameterNames() []string {
+	return []string{"time_scalar"}
+}
+
+// Parameter returns the value of the named parameter.
+func (l *LinearTimeWarp) Parameter(name string) (any, bool) {
+	if name == "time_scalar" {
+		return l.timeScalar, true
+	}
+	return nil, false
+}
+
+// SetParameter sets the named parameter.
+func (l *LinearTimeWarp) SetParameter(name string, value any) error {
+	if name != "time_scalar" {
+		return unknownParameter(l, name)
+	}
+	return parameterValue(&l.timeScalar, value)
+}
+
+// ParameterNames returns the names of the parameters.
+func (f *FreezeFrame) ParameterNames() []string {
+	return []string{"time_scalar"}
+}
+
+// Parameter returns the value of the named parameter.
+func (f *FreezeFrame) Parameter(name string) (any, bool) {
+	if name == "time_scalar" {
+		return f.TimeScalar(), true
+	}
+	return nil, false
+}
+
+// SetParameter returns an error, as the time scalar of a FreezeFrame is
+// always 0.
+func (f *FreezeFrame) SetParameter(name string, value any) error {
+	if name != "time_scalar" {
+		return unknownParameter(f, name)
+	}
+	return fmt.Errorf("the time scalar of a FreezeFrame cannot be set")
+}
+
+// ParameterNames returns the names of the parameters.
+func (c *ASCCDL) ParameterNames() []string {
+	return []string{"slope", "offset", "power", "saturation"}
+}
+
+// Parameter returns the value of the named parameter. Slope, offset and
+// power are [3]float64.
+func (c *ASCCDL) Parameter(name string) (any, bool) {
+	switch name {
+	case "slope":
+		return c.slope, true
+	case "offset":
+		return c.offset, true
+	case "power":
+		return c.power, true
+	case "saturation":
+		return c.saturation, true
+	}
+	return nil, false
+}
+
+// SetParameter sets the named parameter.
+func (c *ASCCDL) SetParameter(name string, value any) error {
+	switch name {
+	case "slope":
+		return parameterValue(&c.slope, value)
+	case "offset":
+		return parameterValue(&c.offset, value)
+	case "power":
+		return parameterValue(&c.power, value)
+	case "saturation":
+		return parameterValue(&c.saturation, value)
+	}
+	return unknownParameter(c, name)
+}
+
+// ParameterNames returns the names of the parameters.
+func (l *LUT) ParameterNames() []string {
+	return []string{"target_url"}
+}
+
+// Parameter returns the value of the named parameter.
+func (l *LUT) Parameter(name string) (any, bool) {
+	if name == "target_url" {
+		return l.targetURL, true
+	}
+	return nil, false
+}
+
+// SetParameter sets the named parameter.
+func (l *LUT) SetParameter(name string, value any) error {
+	if name != "target_url" {
+		return unknownParameter(l, name)
+	}
+	return parameterValue(&l.targetURL, value)
+}
@@ -5,6 +5,7 @@ package gotio
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 )
 
@@ -232,3 +233,75 @@ func TestEffectJSON(t *testing.T) {
 		t.Errorf("EffectName mismatch: got %s", effect2.EffectName())
 	}
 }
+
+func TestItemEffectStack(t *testing.T) {
+	clip := NewClip("shot", nil, nil, nil, nil, nil, "", nil)
+	blur := NewEffect("blur", "Blur", nil)
+	grade := NewIdentityASCCDL("grade")
+	if err := clip.InsertEffect(0, blur); err != nil {
+		t.Fatalf("InsertEffect error: %v", err)
+	}
+	if err := clip.InsertEffect(0, grade); err != nil {
+		t.Fatalf("InsertEffect error: %v", err)
+	}
+	if err := clip.InsertEffect(2, NewLinearTimeWarp("", "", 2, nil)); err != nil {
+		t.Fatalf("InsertEffect error: %v", err)
+	}
+	if effects := clip.Effects(); len(effects) != 3 || effects[0] != grade || effects[1] != blur {
+		t.Errorf("effects = %v", effects)
+	}
+	if err := clip.InsertEffect(0, NewFreezeFrame("", nil)); !errors.Is(err, ErrDuplicateTimeEffect) {
+		t.Errorf("second time effect: err = %v, want ErrDuplicateTimeEffect", err)
+	}
+	if err := clip.InsertEffect(4, blur); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("InsertEffect(4): err = %v, want ErrIndexOutOfRange", err)
+	}
+	if ItemTimeEffect(clip) == nil {
+		t.Error("ItemTimeEffect = nil")
+	}
+
+	removed, err := clip.RemoveEffect(2)
+	if err != nil || !IsTimeEffect(removed) {
+		t.Fatalf("RemoveEffect = %v, %v", removed, err)
+	}
+	if _, err := clip.RemoveEffect(2); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("RemoveEffect(2): err = %v, want ErrIndexOutOfRange", err)
+	}
+	if ItemTimeEffect(clip) != nil || IsTimeEffect(blur) {
+		t.Error("no time effect should remain")
+	}
+}
+
+func TestEffectParameters(t *testing.T) {
+	warp := NewLinearTimeWarp("", "", 2, nil)
+	if v, ok := EffectFloat(warp, "time_scalar"); !ok || v != 2 {
+		t.Errorf("time_scalar = %v, %v", v, ok)
+	}
+	if err := warp.SetParameter("time_scalar", 0.5); err != nil || warp.TimeScalar() != 0.5 {
+		t.Errorf("SetParameter = %v, scalar %v", err, warp.TimeScalar())
+	}
+	if err := warp.SetParameter("time_scalar", "fast"); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("wrong type: err = %v", err)
+	}
+	if err := warp.SetParameter("speed", 1.0); !errors.Is(err, ErrUnknownParameter) {
+		t.Errorf("unknown name: err = %v", err)
+	}
+	if v, ok := EffectFloat(NewFreezeFrame("", nil), "time_scalar"); !ok || v != 0 {
+		t.Errorf("freeze frame time_scalar = %v, %v", v, ok)
+	}
+
+	cdl := NewIdentityASCCDL("")
+	if err := cdl.SetParameter("slope", [3]float64{2, 2, 2}); err != nil || cdl.Slope() != [3]float64{2, 2, 2} {
+		t.Errorf("slope = %v, %v", cdl.Slope(), err)
+	}
+	if v, ok := EffectFloat(cdl, "saturation"); !ok || v != 1 {
+		t.Errorf("saturation = %v, %v", v, ok)
+	}
+	var pe ParameterizedEffect = NewLUT("", "show.cube", nil)
+	if v, _ := pe.Parameter("target_url"); v != "show.cube" {
+		t.Errorf("target_url = %v", v)
+	}
+	if _, ok := EffectFloat(NewEffect("", "Blur", nil), "radius"); ok {
+		t.Error("generic effects have no typed parameters")
+	}
+}
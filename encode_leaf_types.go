@@ -120,6 +120,36 @@ func encodeGeneratorReferenceFast(enc *jsonenc.Encoder, v any) error {
 	return nil
 }
 
+// encodeTimelineReferenceFast encodes a TimelineReference to JSON using the streaming encoder.
+func encodeTimelineReferenceFast(enc *jsonenc.Encoder, v any) error {
+	t := v.(*TimelineReference)
+	enc.BeginObject()
+	enc.WriteStringField("OTIO_SCHEMA", "TimelineReference.1")
+	enc.WriteStringField("name", t.Name())
+	if err := jsonenc.EncodeMetadata(enc, "metadata", t.Metadata()); err != nil {
+		return err
+	}
+	if ptr := t.AvailableRange(); ptr != nil {
+		enc.WriteKey("available_range")
+		if err := jsonenc.EncodeValue(enc, *ptr); err != nil {
+			return err
+		}
+	} else {
+		enc.WriteNullField("available_range")
+	}
+	if ptr := t.AvailableImageBounds(); ptr != nil {
+		enc.WriteKey("available_image_bounds")
+		if err := jsonenc.EncodeValue(enc, ptr); err != nil {
+			return err
+		}
+	} else {
+		enc.WriteNullField("available_image_bounds")
+	}
+	enc.WriteStringField("target_url", t.TargetURL())
+	enc.EndObject()
+	return nil
+}
+
 // encodeLinearTimeWarpFast encodes a LinearTimeWarp to JSON using the streaming encoder.
 func encodeLinearTimeWarpFast(enc *jsonenc.Encoder, v any) error {
 	t := v.(*LinearTimeWarp)
@@ -135,6 +165,85 @@ func encodeLinearTimeWarpFast(enc *jsonenc.Encoder, v any) error {
 	return nil
 }
 
+// encodeASCCDLFast encodes an ASCCDL to JSON using the streaming encoder.
+func encodeASCCDLFast(enc *jsonenc.Encoder, v any) error {
+	t := v.(*ASCCDL)
+	enc.BeginObject()
+	enc.WriteStringField("OTIO_SCHEMA", "ASC_CDL.1")
+	enc.WriteStringField("name", t.Name())
+	if err := jsonenc.EncodeMetadata(enc, "metadata", t.Metadata()); err != nil {
+		return err
+	}
+	enc.WriteStringField("effect_name", t.EffectName())
+	writeFloat3(enc, "slope", t.Slope())
+	writeFloat3(enc, "offset", t.Offset())
+	writeFloat3(enc, "power", t.Power())
+	enc.WriteFloat64Field("saturation", t.Saturation())
+	enc.EndObject()
+	return nil
+}
+
+// writeFloat3 writes a list of three numbers.
+func writeFloat3(enc *jsonenc.Encoder, key string, values [3]float64) {
+	enc.WriteKey(key)
+	enc.BeginArray()
+	for i, v := range values {
+		if i > 0 {
+			enc.WriteComma()
+		}
+		enc.WriteFloat64(v)
+	}
+	enc.EndArray()
+}
+
+// encodeLUTFast encodes a LUT to JSON using the streaming encoder.
+func encodeLUTFast(enc *jsonenc.Encoder, v any) error {
+	t := v.(*LUT)
+	enc.BeginObject()
+	enc.WriteStringField("OTIO_SCHEMA", "LUT.1")
+	enc.WriteStringField("name", t.Name())
+	if err := jsonenc.EncodeMetadata(enc, "metadata", t.Metadata()); err != nil {
+		return err
+	}
+	enc.WriteStringField("effect_name", t.EffectName())
+	enc.WriteStringField("target_url", t.TargetURL())
+	enc.EndObject()
+	return nil
+}
+
+// encodeTimeCurveWarpFast encodes a TimeCurveWarp to JSON using the streaming encoder.
+func encodeTimeCurveWarpFast(enc *jsonenc.Encoder, v any) error {
+	t := v.(*TimeCurveWarp)
+	enc.BeginObject()
+	enc.WriteStringField("OTIO_SCHEMA", "TimeCurveWarp.1")
+	enc.WriteStringField("name", t.Name())
+	if err := jsonenc.EncodeMetadata(enc, "metadata", t.Metadata()); err != nil {
+		return err
+	}
+	enc.WriteStringField("effect_name", t.EffectName())
+	enc.WriteKey("keyframes")
+	enc.BeginArray()
+	for i, k := range t.Keyframes() {
+		if i > 0 {
+			enc.WriteComma()
+		}
+		enc.BeginObject()
+		enc.WriteKey("input")
+		if err := jsonenc.EncodeValue(enc, k.Input); err != nil {
+			return err
+		}
+		enc.WriteKey("output")
+		if err := jsonenc.EncodeValue(enc, k.Output); err != nil {
+			return err
+		}
+		enc.WriteStringField("interpolation", string(k.Interpolation))
+		enc.EndObject()
+	}
+	enc.EndArray()
+	enc.EndObject()
+	return nil
+}
+
 // encodeFreezeFrameFast encodes a FreezeFrame to JSON using the streaming encoder.
 func encodeFreezeFrameFast(enc *jsonenc.Encoder, v any) error {
 	t := v.(*FreezeFrame)
@@ -335,6 +444,13 @@ func init() {
 		Encode:        encodeGeneratorReferenceFast,
 	})
 
+	jsonenc.Register(jsonenc.TypeInfo{
+		SchemaName:    "TimelineReference",
+		SchemaVersion: 1,
+		GoType:        reflect.TypeOf((*TimelineReference)(nil)),
+		Encode:        encodeTimelineReferenceFast,
+	})
+
 	jsonenc.Register(jsonenc.TypeInfo{
 		SchemaName:    "LinearTimeWarp",
 		SchemaVersion: 1,
@@ -349,6 +465,27 @@ func init() {
 		Encode:        encodeFreezeFrameFast,
 	})
 
+	jsonenc.Register(jsonenc.TypeInfo{
+		SchemaName:    "ASC_CDL",
+		SchemaVersion: 1,
+		GoType:        reflect.TypeOf((*ASCCDL)(nil)),
+		Encode:        encodeASCCDLFast,
+	})
+
+	jsonenc.Register(jsonenc.TypeInfo{
+		SchemaName:    "LUT",
+		SchemaVersion: 1,
+		GoType:        reflect.TypeOf((*LUT)(nil)),
+		Encode:        encodeLUTFast,
+	})
+
+	jsonenc.Register(jsonenc.TypeInfo{
+		SchemaName:    "TimeCurveWarp",
+		SchemaVersion: 1,
+		GoType:        reflect.TypeOf((*TimeCurveWarp)(nil)),
+		Encode:        encodeTimeCurveWarpFast,
+	})
+
 	jsonenc.Register(jsonenc.TypeInfo{
 		SchemaName:    "Transition",
 		SchemaVersion: 1,
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/Avalanche-io/gotio/internal/jsonenc"
+	"github.com/Avalanche-io/gotio/metrics"
+)
+
+// DefaultRateTolerance is the distance from a whole number within which
+// WithIntegralRates writes a rate as that number. It is far below the gap
+// between any two real frame rates.
+const DefaultRateTolerance = 1e-6
+
+// EncodeConfig holds options for how JSON is written. The zero value
+// writes the same output as ToJSONBytes.
+type EncodeConfig struct {
+	// Canonical sorts metadata keys and writes every float with a
+	// fraction or exponent, as ToJSONBytesCanonical does.
+	Canonical bool
+	// RateTolerance writes rates within this distance of a whole number,
+	// such as 24.000000004, as that number. Zero writes rates as they are.
+	RateTolerance float64
+	// ValuePrecision rounds the values of times to this many decimal
+	// places. Zero writes values as they are.
+	ValuePrecision int
+	// PointZero writes whole floats as reference OTIO does, such as 24.0
+	// and 172800000.0, without sorting metadata keys.
+	PointZero bool
+	// Indent, if set, indents the output with it.
+	Indent string
+}
+
+// EncodeOption is a functional option for encoding JSON.
+type EncodeOption func(*EncodeConfig)
+
+// WithCanonical sets whether the output is canonical.
+func WithCanonical(canonical bool) EncodeOption {
+	return func(c *EncodeConfig) {
+		c.Canonical = canonical
+	}
+}
+
+// WithIntegralRates writes rates within DefaultRateTolerance of a whole
+// number as that number.
+func WithIntegralRates() EncodeOption {
+	return WithRateTolerance(DefaultRateTolerance)
+}
+
+// WithRateTolerance writes rates within tolerance of a whole number as
+// that number.
+func WithRateTolerance(tolerance float64) EncodeOption {
+	return func(c *EncodeConfig) {
+		c.RateTolerance = tolerance
+	}
+}
+
+// WithValuePrecision rounds the values of times to the given number of
+// decimal places.
+func WithValuePrecision(places int) EncodeOption {
+	return func(c *EncodeConfig) {
+		c.ValuePrecision = places
+	}
+}
+
+// WithPointZero sets whether whole floats are written with a ".0"
+// fraction, as reference OTIO writes them.
+func WithPointZero(pointZero bool) EncodeOption {
+	return func(c *EncodeConfig) {
+		c.PointZero = pointZero
+	}
+}
+
+// WithIndent indents the output with indent.
+func WithIndent(indent string) EncodeOption {
+	return func(c *EncodeConfig) {
+		c.Indent = indent
+	}
+}
+
+// WithReferenceFormat writes numbers as reference OTIO does: whole floats
+// with a ".0" fraction and rates snapped to whole numbers, indented by
+// four spaces.
+func WithReferenceFormat() EncodeOption {
+	return func(c *EncodeConfig) {
+		c.PointZero = true
+		c.RateTolerance = DefaultRateTolerance
+		c.Indent = "    "
+	}
+}
+
+// NewEncodeConfig returns the default configuration with opts applied.
+func NewEncodeConfig(opts ...EncodeOption) EncodeConfig {
+	var cfg EncodeConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// ToJSONBytesWithOptions converts a SerializableObject to JSON bytes,
+// formatted as configured.
+func ToJSONBytesWithOptions(obj SerializableObject, opts ...EncodeOption) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := NewEncodeConfig(opts...).encode(obj, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ToJSONWriterWithOptions writes a SerializableObject to an io.Writer,
+// formatted as configured.
+func ToJSONWriterWithOptions(obj SerializableObject, w io.Writer, opts ...EncodeOption) error {
+	return NewEncodeConfig(opts...).encode(obj, w)
+}
+
+// encode writes obj to w as configured.
+func (cfg EncodeConfig) encode(obj SerializableObject, w io.Writer) error {
+	reportMetadata(obj)
+	var buf bytes.Buffer
+	enc := jsonenc.NewEncoder(&buf)
+	enc.SetCanonical(cfg.Canonical)
+	enc.SetNumberFormat(jsonenc.NumberFormat{
+		RateTolerance:  cfg.RateTolerance,
+		ValuePrecision: cfg.ValuePrecision,
+		PointZero:      cfg.PointZero,
+	})
+	defer enc.Release()
+
+	if err := jsonenc.EncodeValue(enc, obj); err != nil {
+		return err
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+
+	data := buf.Bytes()
+	if cfg.Indent != "" {
+		var indented bytes.Buffer
+		if err := jsonIndent(&indented, data, "", cfg.Indent); err != nil {
+			return err
+		}
+		data = indented.Bytes()
+	}
+	n, err := w.Write(data)
+	metrics.Add(metrics.EncodeBytes, float64(n))
+	return err
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func TestEncodeOptions(t *testing.T) {
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(86400.00012, 24.000000004), opentime.NewRationalTime(48, 24))
+	clip := NewClip("shot", nil, &sr, AnyDictionary{"gain": 2.0}, nil, nil, "", nil)
+
+	plain, err := ToJSONBytesWithOptions(clip)
+	if err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+	want, _ := ToJSONBytes(clip)
+	if !bytes.Equal(plain, want) {
+		t.Errorf("default options should match ToJSONBytes:\n%s\n%s", plain, want)
+	}
+
+	data, err := ToJSONBytesWithOptions(clip, WithIntegralRates(), WithValuePrecision(3))
+	if err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+	if s := string(data); !strings.Contains(s, `"value":86400,"rate":24}`) || strings.Contains(s, "24.000000004") {
+		t.Errorf("rates and values not rounded: %s", s)
+	}
+
+	var buf bytes.Buffer
+	if err := ToJSONWriterWithOptions(clip, &buf, WithReferenceFormat()); err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+	for _, want := range []string{`"rate": 24.0`, `"value": 48.0`, `"gain": 2.0`, "\n    \"name\": \"shot\""} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("reference format missing %q:\n%s", want, buf.String())
+		}
+	}
+	if _, err := FromJSONBytes(buf.Bytes()); err != nil {
+		t.Errorf("reference format does not decode: %v", err)
+	}
+}
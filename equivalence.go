@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// EquivalenceConfig holds options for Equivalent and Difference.
+type EquivalenceConfig struct {
+	// TimeEpsilon is the largest difference, in seconds, between two
+	// times that still counts as equal. It defaults to
+	// opentime.DefaultEpsilon.
+	TimeEpsilon float64
+	// IgnoreKeys are keys left out of the comparison, at any depth of any
+	// object or metadata dictionary.
+	IgnoreKeys []string
+}
+
+// EquivalenceOption is a functional option for Equivalent and Difference.
+type EquivalenceOption func(*EquivalenceConfig)
+
+// WithTimeEpsilon sets the tolerance, in seconds, for comparing times.
+func WithTimeEpsilon(epsilon float64) EquivalenceOption {
+	return func(c *EquivalenceConfig) {
+		c.TimeEpsilon = epsilon
+	}
+}
+
+// WithIgnoredKeys leaves the given keys out of the comparison, such as
+// "metadata" or a volatile metadata key.
+func WithIgnoredKeys(keys ...string) EquivalenceOption {
+	return func(c *EquivalenceConfig) {
+		c.IgnoreKeys = append(c.IgnoreKeys, keys...)
+	}
+}
+
+// Equivalent reports whether a and b serialize to the same document, up
+// to the tolerances of the options. Unlike IsEquivalentTo it compares every
+// field, including ranges, metadata and markers, but:
+//
+//   - dictionary key order does not matter,
+//   - a key that is missing on one side equals null or an empty list or
+//     dictionary on the other,
+//   - times are equal if they are within the time epsilon of each other in
+//     seconds, whatever their rates.
+//
+// Use it to check that an adapter round trip preserved a timeline.
+func Equivalent(a, b SerializableObject, opts ...EquivalenceOption) bool {
+	path, err := Difference(a, b, opts...)
+	return err == nil && path == ""
+}
+
+// Difference returns the JSON pointer of the first place where a and b
+// are not equivalent, such as "/tracks/children/0/source_range", or ""
+// if they are. It returns an error if either object cannot be serialized.
+func Difference(a, b SerializableObject, opts ...EquivalenceOption) (string, error) {
+	cfg := EquivalenceConfig{TimeEpsilon: opentime.DefaultEpsilon}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	docA, err := equivalenceDocument(a)
+	if err != nil {
+		return "", err
+	}
+	docB, err := equivalenceDocument(b)
+	if err != nil {
+		return "", err
+	}
+	path, _ := cfg.firstDifference("", docA, docB)
+	return path, nil
+}
+
+func equivalenceDocument(obj SerializableObject) (any, error) {
+	data, err := toJSONBytes(obj)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (c *EquivalenceConfig) firstDifference(path string, a, b any) (string, bool) {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			return path, !isEmptyValue(a) || !isEmptyValue(b)
+		}
+		if ta, ok := documentTime(av); ok {
+			if tb, ok := documentTime(bv); ok {
+				return path, !c.timesEqual(ta, tb)
+			}
+		}
+		keys := make([]string, 0, len(av)+len(bv))
+		for key := range av {
+			keys = append(keys, key)
+		}
+		for key := range bv {
+			if _, ok := av[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if slices.Contains(c.IgnoreKeys, key) {
+				continue
+			}
+			if p, differs := c.firstDifference(path+"/"+escapePointer(key), av[key], bv[key]); differs {
+				return p, true
+			}
+		}
+		return "", false
+	case []any:
+		bv, ok := b.([]any)
+		if !ok {
+			return path, !isEmptyValue(a) || !isEmptyValue(b)
+		}
+		if len(av) != len(bv) {
+			return path, true
+		}
+		for i := range av {
+			if p, differs := c.firstDifference(path+"/"+strconv.Itoa(i), av[i], bv[i]); differs {
+				return p, true
+			}
+		}
+		return "", false
+	}
+	if isEmptyValue(a) && isEmptyValue(b) {
+		return "", false
+	}
+	return path, !reflect.DeepEqual(a, b)
+}
+
+// documentTime returns the value and rate of a serialized RationalTime.
+func documentTime(m map[string]any) (opentime.RationalTime, bool) {
+	schema, _ := m["OTIO_SCHEMA"].(string)
+	if !strings.HasPrefix(schema, "RationalTime.") {
+		return opentime.RationalTime{}, false
+	}
+	value, ok1 := m["value"].(float64)
+	rate, ok2 := m["rate"].(float64)
+	return opentime.NewRationalTime(value, rate), ok1 && ok2
+}
+
+func (c *EquivalenceConfig) timesEqual(a, b opentime.RationalTime) bool {
+	if a.IsInvalidTime() || b.IsInvalidTime() {
+		return a.StrictlyEqual(b)
+	}
+	return math.Abs(a.ToSeconds()-b.ToSeconds()) <= c.TimeEpsilon
+}
+
+// isEmptyValue reports whether v is null or an empty list or dictionary,
+// which a missing key also decodes as.
+func isEmptyValue(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// escapePointer escapes a key for use in a JSON pointer.
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
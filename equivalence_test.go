@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func TestEquivalent(t *testing.T) {
+	makeTimeline := func(sr opentime.TimeRange, keys ...string) *Timeline {
+		md := AnyDictionary{}
+		for _, k := range keys {
+			md[k] = k
+		}
+		clip := NewClip("shot", nil, &sr, md, nil, nil, "", nil)
+		track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+		if err := track.AppendChild(clip); err != nil {
+			t.Fatalf("AppendChild error: %v", err)
+		}
+		timeline := NewTimeline("edit", nil, nil)
+		if err := timeline.Tracks().AppendChild(track); err != nil {
+			t.Fatalf("AppendChild error: %v", err)
+		}
+		return timeline
+	}
+	at24 := opentime.NewTimeRange(opentime.NewRationalTime(10, 24), opentime.NewRationalTime(48, 24))
+	at48 := opentime.NewTimeRange(opentime.NewRationalTime(20, 48), opentime.NewRationalTime(96, 48))
+	a := makeTimeline(at24, "z", "a", "m")
+
+	if !Equivalent(a, makeTimeline(at48, "m", "z", "a")) {
+		t.Error("expected equal times at different rates and reordered metadata to be equivalent")
+	}
+	if !Equivalent(a, a.Clone()) {
+		t.Error("expected a clone to be equivalent")
+	}
+
+	drifted := makeTimeline(opentime.NewTimeRange(opentime.NewRationalTime(10.0/24+0.0001, 1), at24.Duration()), "z", "a", "m")
+	path, err := Difference(a, drifted)
+	if err != nil {
+		t.Fatalf("Difference error: %v", err)
+	}
+	if want := "/tracks/children/0/children/0/source_range/start_time"; path != want {
+		t.Errorf("Difference = %q, want %q", path, want)
+	}
+	if !Equivalent(a, drifted, WithTimeEpsilon(0.001)) {
+		t.Error("expected times within the epsilon to be equivalent")
+	}
+
+	extra := makeTimeline(at24, "z", "a", "m", "saved_at")
+	if Equivalent(a, extra) {
+		t.Error("expected an extra metadata key to differ")
+	}
+	if !Equivalent(a, extra, WithIgnoredKeys("saved_at")) {
+		t.Error("expected ignored keys to be left out")
+	}
+	renamed := makeTimeline(at24, "z", "a", "m")
+	renamed.Tracks().Children()[0].SetName("V2")
+	if path, _ := Difference(a, renamed); path != "/tracks/children/0/name" {
+		t.Errorf("Difference = %q, want the track name", path)
+	}
+}
+
+func TestEquivalenceOptionalKeys(t *testing.T) {
+	cfg := EquivalenceConfig{TimeEpsilon: opentime.DefaultEpsilon}
+	tests := []struct {
+		a, b   map[string]any
+		differ bool
+	}{
+		{map[string]any{"effects": nil}, map[string]any{}, false},
+		{map[string]any{"effects": []any{}}, map[string]any{}, false},
+		{map[string]any{"metadata": map[string]any{}}, map[string]any{"metadata": nil}, false},
+		{map[string]any{"name": ""}, map[string]any{}, true},
+		{map[string]any{"effects": []any{1.0}}, map[string]any{}, true},
+	}
+	for _, tt := range tests {
+		if _, differs := cfg.firstDifference("", tt.a, tt.b); differs != tt.differ {
+			t.Errorf("firstDifference(%v, %v) differs = %v, want %v", tt.a, tt.b, differs, tt.differ)
+		}
+	}
+}
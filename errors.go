@@ -13,14 +13,36 @@ var (
 	ErrNotFound                    = errors.New("not found")
 	ErrMissingReference            = errors.New("missing reference")
 	ErrMediaReferenceNotFound      = errors.New("media reference not found")
+	ErrEmptyMediaReferenceKey      = errors.New("media reference key must not be empty")
+	ErrActiveMediaReference        = errors.New("cannot remove the active media reference")
 	ErrCannotComputeAvailableRange = errors.New("cannot compute available range")
 	ErrInvalidTimecode             = errors.New("invalid timecode")
-	ErrChildAlreadyHasParent       = errors.New("child already has a parent")
+	ErrChildAlreadyParented        = errors.New("child already has a parent")
 	ErrNotAChild                   = errors.New("item is not a child of a composition")
 	ErrNoCommonAncestor            = errors.New("items do not share a common ancestor")
+	ErrSplitOutOfRange             = errors.New("split time is outside the item's trimmed range")
+	ErrTimeOutOfRange              = errors.New("time is outside the available range")
+	ErrInvalidTimeRange            = errors.New("invalid time range")
+	ErrIndexOutOfRange             = errors.New("index out of range")
+	ErrTypeMismatch                = errors.New("type mismatch")
+	ErrSchemaNotRegistered         = errors.New("schema not registered")
+	ErrInvalidSchema               = errors.New("invalid schema")
+	ErrInvalidJSON                 = errors.New("invalid JSON")
+	ErrInvalidAudioChannels        = errors.New("invalid audio channels")
+	ErrDuplicateTimeEffect         = errors.New("item already has a time effect")
+	ErrUnknownParameter            = errors.New("unknown effect parameter")
+	ErrInvalidCursor               = errors.New("invalid page cursor")
+	ErrMetadataConflict            = errors.New("conflicting metadata values")
+	ErrTimelineReferenceCycle      = errors.New("timeline references itself")
 )
 
-// IndexError indicates an index out of bounds.
+// ErrChildAlreadyHasParent is the former name of ErrChildAlreadyParented.
+//
+// Deprecated: Use ErrChildAlreadyParented.
+var ErrChildAlreadyHasParent = ErrChildAlreadyParented
+
+// IndexError indicates an index out of bounds. It matches
+// ErrIndexOutOfRange with errors.Is.
 type IndexError struct {
 	Index int
 	Size  int
@@ -30,7 +52,13 @@ func (e *IndexError) Error() string {
 	return fmt.Sprintf("index %d out of bounds for size %d", e.Index, e.Size)
 }
 
-// TypeMismatchError indicates a type mismatch.
+// Is reports whether target is ErrIndexOutOfRange.
+func (e *IndexError) Is(target error) bool {
+	return target == ErrIndexOutOfRange
+}
+
+// TypeMismatchError indicates a type mismatch. It matches ErrTypeMismatch
+// with errors.Is.
 type TypeMismatchError struct {
 	Expected string
 	Got      string
@@ -40,21 +68,50 @@ func (e *TypeMismatchError) Error() string {
 	return fmt.Sprintf("expected %s, got %s", e.Expected, e.Got)
 }
 
-// SchemaError indicates a schema error.
+// Is reports whether target is ErrTypeMismatch.
+func (e *TypeMismatchError) Is(target error) bool {
+	return target == ErrTypeMismatch
+}
+
+// SchemaError indicates a schema error. Err holds the underlying cause,
+// such as ErrSchemaNotRegistered or an error from a registered decoder.
 type SchemaError struct {
 	Schema  string
+	Version int
 	Message string
+	Err     error
 }
 
 func (e *SchemaError) Error() string {
-	return fmt.Sprintf("schema %s: %s", e.Schema, e.Message)
+	msg := e.Message
+	if msg == "" && e.Err != nil {
+		msg = e.Err.Error()
+	}
+	return fmt.Sprintf("schema %s: %s", e.Schema, msg)
 }
 
-// JSONError indicates a JSON parsing error.
+// Unwrap returns the underlying cause.
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// JSONError indicates a JSON parsing error. It matches ErrInvalidJSON with
+// errors.Is, and Err holds the parser's error.
 type JSONError struct {
 	Message string
+	Err     error
 }
 
 func (e *JSONError) Error() string {
 	return fmt.Sprintf("JSON error: %s", e.Message)
 }
+
+// Unwrap returns the parser's error.
+func (e *JSONError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidJSON.
+func (e *JSONError) Is(target error) bool {
+	return target == ErrInvalidJSON
+}
@@ -4,7 +4,10 @@
 package gotio
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
 )
 
 func TestIndexError(t *testing.T) {
@@ -39,3 +42,68 @@ func TestErrNotFound(t *testing.T) {
 		t.Error("ErrNotFound should not be nil")
 	}
 }
+
+func TestErrorKinds(t *testing.T) {
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	err := track.RemoveChild(3)
+	if !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("RemoveChild error = %v, want ErrIndexOutOfRange", err)
+	}
+	var indexErr *IndexError
+	if !errors.As(err, &indexErr) || indexErr.Index != 3 {
+		t.Errorf("errors.As IndexError failed for %v", err)
+	}
+
+	if !errors.Is(&TypeMismatchError{Expected: "Clip", Got: "Gap"}, ErrTypeMismatch) {
+		t.Error("TypeMismatchError should match ErrTypeMismatch")
+	}
+	if !errors.Is(ErrChildAlreadyHasParent, ErrChildAlreadyParented) {
+		t.Error("ErrChildAlreadyHasParent should match ErrChildAlreadyParented")
+	}
+
+	_, err = CreateSchema("NoSuchSchema")
+	if !errors.Is(err, ErrSchemaNotRegistered) {
+		t.Errorf("CreateSchema error = %v, want ErrSchemaNotRegistered", err)
+	}
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) || schemaErr.Schema != "NoSuchSchema" {
+		t.Errorf("errors.As SchemaError failed for %v", err)
+	}
+}
+
+func TestDecodeErrorKinds(t *testing.T) {
+	_, err := FromJSONString(`{"OTIO_SCHEMA": "Clip.2",`)
+	if !errors.Is(err, ErrInvalidJSON) {
+		t.Errorf("FromJSONString error = %v, want ErrInvalidJSON", err)
+	}
+	var jsonErr *JSONError
+	if !errors.As(err, &jsonErr) || jsonErr.Err == nil {
+		t.Errorf("errors.As JSONError failed for %v", err)
+	}
+
+	errDecode := errors.New("bad shot")
+	RegisterSchemaCodec(Schema{Name: "FailingShot", Version: 3}, nil, nil,
+		func(SchemaFields) (SerializableObject, error) {
+			return nil, errDecode
+		})
+
+	_, err = FromJSONString(`{"OTIO_SCHEMA": "FailingShot.3"}`)
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("expected SchemaError, got %v", err)
+	}
+	if schemaErr.Schema != "FailingShot" || schemaErr.Version != 3 {
+		t.Errorf("SchemaError = %s.%d, want FailingShot.3", schemaErr.Schema, schemaErr.Version)
+	}
+	if !errors.Is(err, errDecode) {
+		t.Errorf("expected decoder error to be wrapped, got %v", err)
+	}
+}
+
+func TestSplitInvalidTimeRange(t *testing.T) {
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(10, 24), opentime.NewRationalTime(-5, 24))
+	clip := NewClip("c", nil, &sr, nil, nil, nil, "", nil)
+	if _, _, err := clip.Split(opentime.NewRationalTime(8, 24)); !errors.Is(err, ErrInvalidTimeRange) {
+		t.Errorf("Split error = %v, want ErrInvalidTimeRange", err)
+	}
+}
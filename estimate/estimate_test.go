@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package estimate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func testTimeline(clips int, metadata gotio.AnyDictionary) *gotio.Timeline {
+	start := opentime.NewRationalTime(86400, 24)
+	timeline := gotio.NewTimeline("estimate", &start, gotio.AnyDictionary{"show": "demo"})
+	v1 := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	for i := range clips {
+		sr := opentime.NewTimeRange(opentime.NewRationalTime(float64(i*24), 24), opentime.NewRationalTime(24, 24))
+		ref := gotio.NewExternalReference("", fmt.Sprintf("/media/sh%03d.mov", i), nil, nil)
+		marker := gotio.NewMarker("note", opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(1, 24)), gotio.MarkerColorRed, "", nil)
+		clip := gotio.NewClip(fmt.Sprintf("sh%03d", i), ref, &sr, metadata, nil, []*gotio.Marker{marker}, "", nil)
+		v1.AppendChild(clip)
+	}
+	v1.AppendChild(gotio.NewTransition("", gotio.TransitionTypeSMPTEDissolve, opentime.NewRationalTime(2, 24), opentime.NewRationalTime(2, 24), nil))
+	nested := gotio.NewStack("nested", nil, nil, []gotio.Effect{gotio.NewLinearTimeWarp("", "", 2, nil)}, nil, nil)
+	inner := gotio.NewTrack("inner", nil, gotio.TrackKindVideo, nil, nil)
+	inner.AppendChild(gotio.NewGapWithDuration(opentime.NewRationalTime(12, 24)))
+	nested.AppendChild(inner)
+	nested.AppendChild(gotio.NewTrack("empty", nil, gotio.TrackKindAudio, nil, nil))
+	v1.AppendChild(nested)
+	timeline.Tracks().AppendChild(v1)
+	return timeline
+}
+
+func TestDocumentSize(t *testing.T) {
+	for _, timeline := range []*gotio.Timeline{
+		testTimeline(5, gotio.AnyDictionary{"vendor": gotio.AnyDictionary{"id": 7}}),
+		gotio.NewTimeline("empty", nil, nil),
+	} {
+		data, err := gotio.ToJSONBytes(timeline)
+		if err != nil {
+			t.Fatalf("ToJSONBytes error: %v", err)
+		}
+		size, err := DocumentSize(timeline)
+		if err != nil {
+			t.Fatalf("DocumentSize error: %v", err)
+		}
+		if size != int64(len(data)) {
+			t.Errorf("%s: DocumentSize = %d, want %d", timeline.Name(), size, len(data))
+		}
+	}
+	if size, err := DocumentSize(nil); size != 0 || err != nil {
+		t.Errorf("DocumentSize(nil) = %d, %v", size, err)
+	}
+}
+
+func TestMemoryFootprint(t *testing.T) {
+	small := MemoryFootprint(testTimeline(10, nil))
+	large := MemoryFootprint(testTimeline(100, nil))
+	if small <= 0 || large < 5*small {
+		t.Errorf("footprints %d for 10 clips and %d for 100", small, large)
+	}
+
+	// Metadata shared by every clip is counted once.
+	big := make(gotio.AnyDictionary)
+	for i := range 100 {
+		big[fmt.Sprintf("key%03d", i)] = "a fairly long metadata value"
+	}
+	shared := MemoryFootprint(testTimeline(10, big))
+	timeline := testTimeline(10, nil)
+	for _, clip := range timeline.FindClips(nil, false) {
+		clip.SetMetadata(gotio.CloneAnyDictionary(big))
+	}
+	copied := MemoryFootprint(timeline)
+	if shared <= small || copied < shared+5*(shared-small) {
+		t.Errorf("footprints %d without metadata, %d shared, %d copied", small, shared, copied)
+	}
+}
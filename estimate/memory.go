@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package estimate
+
+import (
+	"reflect"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+// mapEntryOverhead and mapHeader approximate the bookkeeping of a Go map
+// beyond its keys and values: a control byte per slot, slots kept at most
+// seven-eighths full, and the map header itself.
+const (
+	mapEntryOverhead = 1
+	mapHeader        = 48
+)
+
+// MemoryFootprint returns an approximation of the bytes the timeline
+// holds in memory: every struct, string, slice backing array and map
+// reachable from it, each counted once however many times it is shared.
+// Allocator rounding and runtime internals are not counted, so the true
+// figure is somewhat higher.
+func MemoryFootprint(timeline *gotio.Timeline) int64 {
+	if timeline == nil {
+		return 0
+	}
+	m := &measurer{seen: make(map[uintptr]bool)}
+	v := reflect.ValueOf(timeline)
+	return m.pointer(v)
+}
+
+// measurer sums sizes, remembering the addresses already counted.
+type measurer struct {
+	seen map[uintptr]bool
+}
+
+// pointer counts what the pointer v points at, once.
+func (m *measurer) pointer(v reflect.Value) int64 {
+	if v.IsNil() {
+		return 0
+	}
+	addr := v.Pointer()
+	if m.seen[addr] {
+		return 0
+	}
+	m.seen[addr] = true
+	elem := v.Elem()
+	return int64(elem.Type().Size()) + m.indirect(elem)
+}
+
+// indirect returns the bytes v refers to beyond its own size.
+func (m *measurer) indirect(v reflect.Value) int64 {
+	switch v.Kind() {
+	case reflect.Pointer:
+		return m.pointer(v)
+	case reflect.String:
+		return int64(v.Len())
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		elem := v.Elem()
+		if elem.Kind() == reflect.Pointer {
+			return m.pointer(elem)
+		}
+		// A non-pointer value in an interface is boxed on the heap.
+		return int64(elem.Type().Size()) + m.indirect(elem)
+	case reflect.Struct:
+		var total int64
+		for i := range v.NumField() {
+			total += m.indirect(v.Field(i))
+		}
+		return total
+	case reflect.Array:
+		var total int64
+		for i := range v.Len() {
+			total += m.indirect(v.Index(i))
+		}
+		return total
+	case reflect.Slice:
+		if v.IsNil() || m.seen[v.Pointer()] {
+			return 0
+		}
+		m.seen[v.Pointer()] = true
+		total := int64(v.Cap()) * int64(v.Type().Elem().Size())
+		for i := range v.Len() {
+			total += m.indirect(v.Index(i))
+		}
+		return total
+	case reflect.Map:
+		if v.IsNil() || m.seen[v.Pointer()] {
+			return 0
+		}
+		m.seen[v.Pointer()] = true
+		entry := int64(v.Type().Key().Size()+v.Type().Elem().Size()) + mapEntryOverhead
+		total := mapHeader + int64(v.Len())*entry*8/7
+		iter := v.MapRange()
+		for iter.Next() {
+			total += m.indirect(iter.Key()) + m.indirect(iter.Value())
+		}
+		return total
+	}
+	return 0
+}
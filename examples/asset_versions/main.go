@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// asset_versions demonstrates updating the plates of a timeline to their
+// latest versions through the assets package.
+//
+// The "asset management system" here is a directory of plates laid out as
+// <root>/<shot>/<shot>_v<NNN>.mov. Real integrations implement the same two
+// interfaces against their database or API.
+//
+// Usage:
+//
+//	go run main.go input.otio plate_root output.otio
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/assets"
+)
+
+// plateLibrary is an asset resolver and version provider backed by a
+// directory of plates.
+type plateLibrary struct {
+	root string
+}
+
+// ResolveAsset returns a reference to the plate file of a version.
+func (p plateLibrary) ResolveAsset(_ context.Context, asset assets.Asset) (gotio.MediaReference, error) {
+	path := filepath.Join(p.root, asset.ID, asset.ID+"_"+asset.Version+".mov")
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	return gotio.NewExternalReference(asset.ID, path, nil, nil), nil
+}
+
+// IdentifyAsset recognizes references to files of the library.
+func (p plateLibrary) IdentifyAsset(_ context.Context, ref gotio.MediaReference) (assets.Asset, bool, error) {
+	ext, ok := ref.(*gotio.ExternalReference)
+	if !ok {
+		return assets.Asset{}, false, nil
+	}
+	rel, err := filepath.Rel(p.root, ext.TargetURL())
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return assets.Asset{}, false, nil
+	}
+	shot, file := filepath.Split(rel)
+	version, ok := strings.CutPrefix(strings.TrimSuffix(file, ".mov"), filepath.Clean(shot)+"_")
+	if !ok {
+		return assets.Asset{}, false, nil
+	}
+	return assets.Asset{ID: filepath.Clean(shot), Version: version}, true, nil
+}
+
+// Version returns the highest version on disk.
+func (p plateLibrary) Version(_ context.Context, current assets.Asset) (string, error) {
+	files, err := filepath.Glob(filepath.Join(p.root, current.ID, current.ID+"_v*.mov"))
+	if err != nil || len(files) == 0 {
+		return "", err
+	}
+	latest := slices.Max(files)
+	return strings.TrimSuffix(strings.TrimPrefix(filepath.Base(latest), current.ID+"_"), ".mov"), nil
+}
+
+func main() {
+	if len(os.Args) < 4 {
+		fmt.Println("Usage: go run main.go <input.otio> <plate_root> <output.otio>")
+		os.Exit(1)
+	}
+	inputPath, root, outputPath := os.Args[1], os.Args[2], os.Args[3]
+
+	obj, err := gotio.FromJSONFile(inputPath)
+	if err != nil {
+		log.Fatalf("Failed to load %s: %v", inputPath, err)
+	}
+	timeline, ok := obj.(*gotio.Timeline)
+	if !ok {
+		log.Fatalf("Expected Timeline, got %T", obj)
+	}
+
+	library := plateLibrary{root: root}
+	changes, err := assets.ApplyAssetVersions(context.Background(), timeline, library, library)
+	if err != nil {
+		log.Fatalf("Failed to update plates: %v", err)
+	}
+	for _, c := range changes {
+		fmt.Printf("%s: %s %s -> %s\n", c.Clip.Name(), c.To.ID, c.From.Version, c.To.Version)
+	}
+	fmt.Printf("Updated %d clips\n", len(changes))
+
+	if err := gotio.ToJSONFile(timeline, outputPath, "  "); err != nil {
+		log.Fatalf("Failed to write %s: %v", outputPath, err)
+	}
+}
@@ -5,7 +5,7 @@
 //
 // This example shows:
 // - Calculating total duration
-// - Finding gaps and their total duration
+// - Finding gaps and track coverage with algorithms.CoverageReport
 // - Listing clips with their timing information
 // - Converting between timecode and frames
 //
@@ -22,6 +22,7 @@ import (
 
 	"github.com/Avalanche-io/gotio/opentime"
 	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/algorithms"
 )
 
 func main() {
@@ -57,41 +58,41 @@ func main() {
 	// Analyze each track
 	fmt.Println("\n--- Track Analysis ---")
 
+	coverage, err := algorithms.CoverageReport(timeline)
+	if err != nil {
+		log.Fatalf("Failed to compute coverage: %v", err)
+	}
+
 	var totalClipDuration float64
 	var totalGapDuration float64
 	var clipCount int
 
-	for _, child := range timeline.Tracks().Children() {
-		track, ok := child.(*gotio.Track)
-		if !ok {
-			continue
-		}
+	for _, trackCoverage := range coverage.Tracks {
+		track := trackCoverage.Track
 
 		trackDur, _ := track.Duration()
 		fmt.Printf("\nTrack: %s (%s)\n", track.Name(), track.Kind())
 		fmt.Printf("  Duration: %s (%.2f seconds)\n",
 			formatTimecode(trackDur), trackDur.ToSeconds())
 
-		// Count clips and gaps
+		// Count clips; gaps come from the coverage report
 		trackClipDur := 0.0
-		trackGapDur := 0.0
 		trackClipCount := 0
 
 		for i, item := range track.Children() {
-			itemRange, _ := track.RangeOfChildAtIndex(i)
-
-			switch item.(type) {
-			case *gotio.Clip:
-				trackClipDur += itemRange.Duration().ToSeconds()
-				trackClipCount++
-			case *gotio.Gap:
-				trackGapDur += itemRange.Duration().ToSeconds()
+			if _, ok := item.(*gotio.Clip); !ok {
+				continue
 			}
+			itemRange, _ := track.RangeOfChildAtIndex(i)
+			trackClipDur += itemRange.Duration().ToSeconds()
+			trackClipCount++
 		}
+		trackGapDur := trackCoverage.Empty.ToSeconds()
 
 		fmt.Printf("  Clips: %d (%.2fs content)\n", trackClipCount, trackClipDur)
 		if trackGapDur > 0 {
-			fmt.Printf("  Gaps: %.2fs\n", trackGapDur)
+			fmt.Printf("  Gaps: %.2fs in %d ranges (%.0f%% filled)\n",
+				trackGapDur, len(trackCoverage.Gaps), trackCoverage.Ratio()*100)
 		}
 
 		totalClipDuration += trackClipDur
@@ -26,7 +26,20 @@ func NewGap(
 	markers []*Marker,
 	color *Color,
 ) *Gap {
-	gap := &Gap{
+	return initGap(new(Gap), name, sourceRange, metadata, effects, markers, color)
+}
+
+// initGap initializes gap in place, for gaps allocated by a decodeArena.
+func initGap(
+	gap *Gap,
+	name string,
+	sourceRange *opentime.TimeRange,
+	metadata AnyDictionary,
+	effects []Effect,
+	markers []*Marker,
+	color *Color,
+) *Gap {
+	*gap = Gap{
 		ItemBase: NewItemBase(name, sourceRange, metadata, effects, markers, true, color),
 	}
 	gap.SetSelf(gap)
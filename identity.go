@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"crypto/rand"
+	"fmt"
+	"maps"
+	"sync/atomic"
+)
+
+// IDMetadataKey is the metadata key holding the stable identifier of an
+// object. Keeping it in metadata means identifiers survive serialization
+// and are passed through unchanged by other OTIO implementations.
+const IDMetadataKey = "otio_id"
+
+var generateIDs atomic.Bool
+
+// SetGenerateIDs sets whether newly created objects with metadata are
+// given an identifier. It is off by default. Decoded objects keep the
+// identifier they were written with. Clone copies the identifier, so call
+// SetID with NewID on a clone that should have its own identity.
+func SetGenerateIDs(enabled bool) {
+	generateIDs.Store(enabled)
+}
+
+// NewID returns a new random identifier, a version 4 UUID.
+func NewID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// ID returns the identifier of obj, or "" if it has none.
+func ID(obj SerializableObjectWithMetadata) string {
+	id, _ := obj.Metadata().GetString(IDMetadataKey)
+	return id
+}
+
+// SetID sets the identifier of obj. An empty id removes it.
+func SetID(obj SerializableObjectWithMetadata, id string) {
+	md := obj.Metadata()
+	if id == "" {
+		delete(md, IDMetadataKey)
+		return
+	}
+	if md == nil {
+		md = AnyDictionary{}
+		obj.SetMetadata(md)
+	}
+	md[IDMetadataKey] = id
+}
+
+// EnsureID returns the identifier of obj, giving it a new one first if it
+// has none.
+func EnsureID(obj SerializableObjectWithMetadata) string {
+	id := ID(obj)
+	if id == "" {
+		id = NewID()
+		SetID(obj, id)
+	}
+	return id
+}
+
+// AssignIDs gives obj and every object with metadata beneath it, including
+// media references, effects and markers, an identifier if it has none.
+func AssignIDs(obj SerializableObject) {
+	walkMetadataObjects(obj, func(o SerializableObjectWithMetadata) {
+		EnsureID(o)
+	})
+}
+
+// FindByID returns the object in the timeline with the given identifier,
+// searching the timeline itself, its tracks and everything they hold in
+// document order. Returns nil if there is none.
+func (t *Timeline) FindByID(id string) SerializableObjectWithMetadata {
+	if id == "" {
+		return nil
+	}
+	var found SerializableObjectWithMetadata
+	walkMetadataObjects(t, func(o SerializableObjectWithMetadata) {
+		if found == nil && ID(o) == id {
+			found = o
+		}
+	})
+	return found
+}
+
+// withGeneratedID returns metadata with a new identifier added when
+// identifiers are generated on creation and it has none. The caller's
+// dictionary is not modified.
+func withGeneratedID(metadata AnyDictionary) AnyDictionary {
+	if !generateIDs.Load() {
+		return metadata
+	}
+	if _, ok := metadata[IDMetadataKey]; ok {
+		return metadata
+	}
+	metadata = maps.Clone(metadata)
+	if metadata == nil {
+		metadata = make(AnyDictionary)
+	}
+	metadata[IDMetadataKey] = NewID()
+	return metadata
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func TestNewID(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	seen := make(map[string]bool)
+	for range 100 {
+		id := NewID()
+		if !uuid.MatchString(id) {
+			t.Fatalf("NewID() = %q, not a version 4 UUID", id)
+		}
+		if seen[id] {
+			t.Fatalf("NewID() repeated %q", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestIDRoundTrip(t *testing.T) {
+	timeline := lazyTestTimeline()
+	clip := timeline.FindClips(nil, false)[0]
+	if ID(clip) != "" {
+		t.Fatal("expected no identifier by default")
+	}
+	AssignIDs(timeline)
+	id := ID(clip)
+	if id == "" || EnsureID(clip) != id {
+		t.Fatalf("AssignIDs did not give the clip a stable identifier: %q", id)
+	}
+	marker := NewMarker("note", opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(1, 24)), MarkerColorRed, "", nil)
+	clip.SetMarkers([]*Marker{marker})
+	markerID := EnsureID(marker)
+
+	data, err := ToJSONBytes(timeline)
+	if err != nil {
+		t.Fatalf("ToJSONBytes error: %v", err)
+	}
+	obj, err := FromJSONBytes(data)
+	if err != nil {
+		t.Fatalf("FromJSONBytes error: %v", err)
+	}
+	decoded := obj.(*Timeline)
+	if found, ok := decoded.FindByID(id).(*Clip); !ok || found.Name() != clip.Name() {
+		t.Errorf("FindByID(%q) = %v", id, decoded.FindByID(id))
+	}
+	if found := decoded.FindByID(markerID); found == nil || found.Name() != "note" {
+		t.Errorf("FindByID of marker = %v", found)
+	}
+	if decoded.FindByID(ID(timeline)) != decoded {
+		t.Error("FindByID did not find the timeline itself")
+	}
+	if decoded.FindByID(NewID()) != nil || decoded.FindByID("") != nil {
+		t.Error("FindByID found an unknown identifier")
+	}
+
+	SetID(clip, "")
+	if ID(clip) != "" {
+		t.Error("SetID with an empty id did not remove the identifier")
+	}
+}
+
+func TestSetGenerateIDs(t *testing.T) {
+	SetGenerateIDs(true)
+	defer SetGenerateIDs(false)
+
+	md := AnyDictionary{"scene": "12"}
+	a := NewClip("a", nil, nil, md, nil, nil, "", nil)
+	b := NewClip("b", nil, nil, md, nil, nil, "", nil)
+	if ID(a) == "" || ID(b) == "" || ID(a) == ID(b) {
+		t.Errorf("expected distinct identifiers, got %q and %q", ID(a), ID(b))
+	}
+	if _, ok := md[IDMetadataKey]; ok {
+		t.Error("generating an identifier modified the caller's metadata")
+	}
+
+	data, _ := ToJSONBytes(a)
+	obj, err := FromJSONBytes(data)
+	if err != nil {
+		t.Fatalf("FromJSONBytes error: %v", err)
+	}
+	if ID(obj.(*Clip)) != ID(a) {
+		t.Errorf("decoded identifier = %q, want %q", ID(obj.(*Clip)), ID(a))
+	}
+}
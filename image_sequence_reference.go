@@ -5,7 +5,9 @@ package gotio
 
 import (
 	"encoding/json"
-	"fmt"
+	"math"
+	"strconv"
+	"strings"
 
 	"github.com/Avalanche-io/gotio/opentime"
 )
@@ -152,9 +154,12 @@ func (i *ImageSequenceReference) SetMissingFramePolicy(policy MissingFramePolicy
 }
 
 // TargetURLForImageNumber returns the URL for a specific frame number.
+// The frame number is the number in the file name, not the image's index in
+// the sequence; see TargetURLForImage for index-based lookup.
+// The number is zero padded to FrameZeroPadding digits, with the sign of
+// negative numbers placed before the padding.
 func (i *ImageSequenceReference) TargetURLForImageNumber(frameNumber int) string {
-	format := fmt.Sprintf("%%s%%s%%0%dd%%s", i.frameZeroPadding)
-	return fmt.Sprintf(format, i.targetURLBase, i.namePrefix, frameNumber, i.nameSuffix)
+	return i.targetURLWithFrameString(formatFrameNumber(frameNumber, i.frameZeroPadding))
 }
 
 // TargetURLForFrame is an alias for TargetURLForImageNumber.
@@ -162,30 +167,129 @@ func (i *ImageSequenceReference) TargetURLForFrame(frameNumber int) string {
 	return i.TargetURLForImageNumber(frameNumber)
 }
 
+// TargetURLForImage returns the URL for the image at the given index in the
+// sequence, accounting for StartFrame and FrameStep.
+// Returns an IndexError if the index is outside the sequence.
+func (i *ImageSequenceReference) TargetURLForImage(imageNumber int) (string, error) {
+	if err := i.checkImageNumber(imageNumber); err != nil {
+		return "", err
+	}
+	return i.TargetURLForImageNumber(i.FrameForImageNumber(imageNumber)), nil
+}
+
+// AbstractTargetURL returns the URL with the frame number replaced by symbol,
+// e.g. "/path/frame_@.exr" or "/path/frame_####.exr".
+func (i *ImageSequenceReference) AbstractTargetURL(symbol string) string {
+	return i.targetURLWithFrameString(symbol)
+}
+
+// targetURLWithFrameString joins the URL parts around a frame string,
+// adding a path separator if the base does not end with one.
+func (i *ImageSequenceReference) targetURLWithFrameString(frame string) string {
+	sep := ""
+	if i.targetURLBase != "" && !strings.HasSuffix(i.targetURLBase, "/") {
+		sep = "/"
+	}
+	return i.targetURLBase + sep + i.namePrefix + frame + i.nameSuffix
+}
+
+// formatFrameNumber zero pads a frame number, keeping the sign in front.
+func formatFrameNumber(frameNumber, padding int) string {
+	sign := ""
+	if frameNumber < 0 {
+		sign = "-"
+		frameNumber = -frameNumber
+	}
+	digits := strconv.Itoa(frameNumber)
+	if len(digits) < padding {
+		digits = strings.Repeat("0", padding-len(digits)) + digits
+	}
+	return sign + digits
+}
+
+// FrameForImageNumber returns the file frame number of the image at the
+// given index in the sequence.
+func (i *ImageSequenceReference) FrameForImageNumber(imageNumber int) int {
+	return i.startFrame + imageNumber*i.frameStep
+}
+
 // FrameForTime converts a RationalTime to a frame number.
+// The time is measured from the start of the available range, or from zero
+// if there is none, and counted in frames at the sequence rate.
 func (i *ImageSequenceReference) FrameForTime(time opentime.RationalTime) int {
-	// Convert time to frames at the sequence rate
-	frameIndex := int(time.Value())
-	return i.startFrame + frameIndex*i.frameStep
+	offset := time
+	if i.availableRange != nil {
+		offset = time.Sub(i.availableRange.StartTime())
+	}
+	return i.startFrame + framesAtRate(offset, i.rate)
+}
+
+// TimeForFrame converts a frame number to the time it is presented at.
+// It is the inverse of FrameForTime.
+func (i *ImageSequenceReference) TimeForFrame(frameNumber int) opentime.RationalTime {
+	offset := opentime.NewRationalTime(float64(frameNumber-i.startFrame), i.rate)
+	if i.availableRange != nil {
+		return i.availableRange.StartTime().Add(offset)
+	}
+	return offset
+}
+
+// ImageNumberForTime returns the index of the image presented at the given
+// time. Times between images of a stepped sequence map to the preceding image.
+// Returns ErrTimeOutOfRange if the time is outside the available range.
+func (i *ImageSequenceReference) ImageNumberForTime(time opentime.RationalTime) (int, error) {
+	if i.availableRange == nil || !i.availableRange.Contains(time) {
+		return 0, ErrTimeOutOfRange
+	}
+	frames := framesAtRate(time.Sub(i.availableRange.StartTime()), i.rate)
+	return frames / i.frameStep, nil
+}
+
+// PresentationTimeForImageNumber returns the time at which the image at the
+// given index in the sequence is presented.
+// Returns an IndexError if the index is outside the sequence.
+func (i *ImageSequenceReference) PresentationTimeForImageNumber(imageNumber int) (opentime.RationalTime, error) {
+	if err := i.checkImageNumber(imageNumber); err != nil {
+		return opentime.RationalTime{}, err
+	}
+	offset := opentime.NewRationalTime(float64(imageNumber*i.frameStep), i.rate)
+	return i.availableRange.StartTime().Add(offset), nil
 }
 
-// EndFrame returns the ending frame number based on available range.
+// EndFrame returns the last frame number covered by the available range.
 func (i *ImageSequenceReference) EndFrame() int {
 	if i.availableRange == nil {
 		return i.startFrame
 	}
-	dur := i.availableRange.Duration()
-	frames := int(dur.Value() * dur.Rate() / i.rate)
-	return i.startFrame + (frames-1)*i.frameStep
+	frames := framesAtRate(i.availableRange.Duration(), i.rate)
+	return i.startFrame + frames - 1
 }
 
 // NumberOfImagesInSequence returns the number of images in the sequence.
+// With a FrameStep above one, only every FrameStep-th frame is an image.
 func (i *ImageSequenceReference) NumberOfImagesInSequence() int {
-	if i.availableRange == nil {
+	if i.availableRange == nil || i.rate <= 0 || i.frameStep <= 0 {
 		return 0
 	}
-	dur := i.availableRange.Duration()
-	return int(dur.Value() * dur.Rate() / i.rate)
+	return framesAtRate(i.availableRange.Duration(), i.rate/float64(i.frameStep))
+}
+
+// checkImageNumber validates an index into the sequence.
+func (i *ImageSequenceReference) checkImageNumber(imageNumber int) error {
+	count := i.NumberOfImagesInSequence()
+	if imageNumber < 0 || imageNumber >= count {
+		return &IndexError{Index: imageNumber, Size: count}
+	}
+	return nil
+}
+
+// framesAtRate returns the whole number of frames in t at the given rate,
+// tolerating floating point error from rate conversion.
+func framesAtRate(t opentime.RationalTime, rate float64) int {
+	if rate <= 0 || t.Rate() <= 0 {
+		return int(t.Value())
+	}
+	return int(math.Floor(t.ValueRescaledTo(rate) + 1e-9))
 }
 
 // SchemaName returns the schema name.
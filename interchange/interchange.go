@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package interchange keeps application fields that OTIO has no place for,
+// such as Premiere Pro label colors or Avid bin columns, so that they
+// survive a round trip through an adapter.
+//
+// A Profile names the fields one application writes. Adapters register
+// profiles under their own name. On import an adapter hands the fields it
+// has no mapping for to its profiles, and each profile stashes the fields
+// it knows in the object's metadata under the profile name. On export the
+// adapter asks the profiles for the fields to write back.
+//
+// Basic usage, in an adapter:
+//
+//	profiles := interchange.Profiles("xmeml")
+//
+//	// Reading a clip.
+//	interchange.Stash(profiles, clip, unmapped)
+//
+//	// Writing a clip.
+//	extra := interchange.Restore(profiles, clip)
+package interchange
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+// Fields are the application fields of one object, keyed by name. The
+// adapter chooses the names, such as element paths like "labels/label2"
+// for XML formats.
+type Fields map[string]string
+
+// Profile describes the fields one application writes.
+type Profile struct {
+	// Name identifies the profile and is the metadata key its fields are
+	// stored under, such as "premiere".
+	Name string
+	// Fields lists the field names kept. A name ending in "/" keeps every
+	// field under that prefix. An empty list keeps every field.
+	Fields []string
+	// Import, if set, is called after fields are stashed on an object, to
+	// map them onto OTIO as well, such as a label onto the clip color.
+	Import func(obj gotio.SerializableObjectWithMetadata, fields Fields)
+	// Export, if set, is called with the fields restored from an object,
+	// to fill in fields from OTIO that the object has none stored for.
+	Export func(obj gotio.SerializableObjectWithMetadata, fields Fields)
+}
+
+// Keeps reports whether field is one of the profile's fields.
+func (p *Profile) Keeps(field string) bool {
+	if len(p.Fields) == 0 {
+		return true
+	}
+	for _, f := range p.Fields {
+		if f == field || (strings.HasSuffix(f, "/") && strings.HasPrefix(field, f)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Stash moves the fields p keeps out of fields and into obj's metadata
+// under p.Name, merging with fields already stored there, then calls
+// p.Import. It reports whether any field was stashed.
+func (p *Profile) Stash(obj gotio.SerializableObjectWithMetadata, fields Fields) bool {
+	kept := Fields{}
+	for name, value := range fields {
+		if p.Keeps(name) {
+			kept[name] = value
+			delete(fields, name)
+		}
+	}
+	if len(kept) == 0 {
+		return false
+	}
+
+	metadata := obj.Metadata()
+	if metadata == nil {
+		metadata = gotio.AnyDictionary{}
+		obj.SetMetadata(metadata)
+	}
+	stored, ok := metadata.GetDictionary(p.Name)
+	if !ok {
+		stored = gotio.AnyDictionary{}
+		metadata[p.Name] = stored
+	}
+	for name, value := range kept {
+		stored[name] = value
+	}
+	if p.Import != nil {
+		p.Import(obj, kept)
+	}
+	return true
+}
+
+// Restore returns the fields stored in obj's metadata under p.Name, as
+// completed by p.Export.
+func (p *Profile) Restore(obj gotio.SerializableObjectWithMetadata) Fields {
+	fields := Fields{}
+	if stored, ok := obj.Metadata().GetDictionary(p.Name); ok {
+		for name, value := range stored {
+			if s, ok := value.(string); ok && p.Keeps(name) {
+				fields[name] = s
+			}
+		}
+	}
+	if p.Export != nil {
+		p.Export(obj, fields)
+	}
+	return fields
+}
+
+// Stash offers fields to each profile in turn. A field is stashed by the
+// first profile that keeps it; fields no profile keeps are left in fields.
+func Stash(profiles []*Profile, obj gotio.SerializableObjectWithMetadata, fields Fields) {
+	for _, p := range profiles {
+		if len(fields) == 0 {
+			return
+		}
+		p.Stash(obj, fields)
+	}
+}
+
+// Restore merges the fields each profile restores from obj. Earlier
+// profiles win when two restore the same field.
+func Restore(profiles []*Profile, obj gotio.SerializableObjectWithMetadata) Fields {
+	fields := Fields{}
+	for _, p := range profiles {
+		for name, value := range p.Restore(obj) {
+			if _, ok := fields[name]; !ok {
+				fields[name] = value
+			}
+		}
+	}
+	return fields
+}
+
+var (
+	registry   = make(map[string]map[string]*Profile)
+	registryMu sync.RWMutex
+)
+
+// Register adds a profile for the named adapter, replacing any profile of
+// the same name. Adapter packages register their built-in profiles from
+// init; programs may register their own.
+func Register(adapter string, p *Profile) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if registry[adapter] == nil {
+		registry[adapter] = make(map[string]*Profile)
+	}
+	registry[adapter][p.Name] = p
+}
+
+// Unregister removes the named profile of an adapter.
+func Unregister(adapter, name string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(registry[adapter], name)
+}
+
+// Lookup returns the named profile of an adapter.
+func Lookup(adapter, name string) (*Profile, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	p, ok := registry[adapter][name]
+	return p, ok
+}
+
+// Profiles returns the profiles registered for an adapter, sorted by name.
+func Profiles(adapter string) []*Profile {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	profiles := slices.Collect(maps.Values(registry[adapter]))
+	slices.SortFunc(profiles, func(a, b *Profile) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return profiles
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package interchange
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+func TestStashAndRestore(t *testing.T) {
+	avid := &Profile{
+		Name:   "avid",
+		Fields: []string{"Color", "bin/"},
+		Import: func(obj gotio.SerializableObjectWithMetadata, fields Fields) {
+			if fields["Color"] == "Red" {
+				obj.(*gotio.Clip).SetColor(gotio.ColorRed)
+			}
+		},
+	}
+	other := &Profile{Name: "other"}
+
+	clip := gotio.NewClip("sh010", nil, nil, nil, nil, nil, "", nil)
+	fields := Fields{"Color": "Red", "bin/column": "A", "binary": "x", "Scene": "12"}
+	Stash([]*Profile{avid, other}, clip, fields)
+
+	if len(fields) != 0 {
+		t.Errorf("unstashed fields %v", fields)
+	}
+	if v, ok := clip.Metadata().Lookup("avid.bin/column"); !ok || v != "A" {
+		t.Errorf("avid.bin/column = %v", v)
+	}
+	if _, ok := clip.Metadata().Lookup("avid.binary"); ok {
+		t.Error("binary should not match the bin/ prefix")
+	}
+	if v, ok := clip.Metadata().Lookup("other.Scene"); !ok || v != "12" {
+		t.Errorf("other.Scene = %v", v)
+	}
+	if clip.Color() == nil || clip.Color().Hex() != gotio.ColorRed.Hex() {
+		t.Errorf("Import did not set the color, got %v", clip.Color())
+	}
+
+	restored := Restore([]*Profile{avid, other}, clip)
+	want := Fields{"Color": "Red", "bin/column": "A", "binary": "x", "Scene": "12"}
+	if len(restored) != len(want) {
+		t.Fatalf("Restore = %v, want %v", restored, want)
+	}
+	for name, value := range want {
+		if restored[name] != value {
+			t.Errorf("Restore[%q] = %q, want %q", name, restored[name], value)
+		}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	b := &Profile{Name: "b"}
+	a := &Profile{Name: "a"}
+	Register("test", b)
+	Register("test", a)
+	defer Unregister("test", "a")
+	defer Unregister("test", "b")
+
+	profiles := Profiles("test")
+	if len(profiles) != 2 || profiles[0] != a || profiles[1] != b {
+		t.Errorf("Profiles = %v, want a, b", profiles)
+	}
+	if p, ok := Lookup("test", "b"); !ok || p != b {
+		t.Error("Lookup did not find b")
+	}
+	Unregister("test", "b")
+	if _, ok := Lookup("test", "b"); ok {
+		t.Error("b still registered")
+	}
+	if len(Profiles("missing")) != 0 {
+		t.Error("expected no profiles for an unknown adapter")
+	}
+}
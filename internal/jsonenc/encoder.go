@@ -6,6 +6,7 @@
 package jsonenc
 
 import (
+	"bytes"
 	"io"
 	"math"
 	"strconv"
@@ -21,6 +22,22 @@ type Encoder struct {
 	scratch   [64]byte
 	err       error
 	needComma bool
+	canonical bool
+	omitKeys  map[string]bool
+	numbers   NumberFormat
+}
+
+// NumberFormat controls how numbers are written beyond the defaults.
+type NumberFormat struct {
+	// RateTolerance writes "rate" fields within this distance of a whole
+	// number as that number. Zero leaves rates as they are.
+	RateTolerance float64
+	// ValuePrecision rounds "value" fields to this many decimal places.
+	// Zero leaves values as they are.
+	ValuePrecision int
+	// PointZero writes whole floats with a ".0" fraction, as reference
+	// OTIO does.
+	PointZero bool
 }
 
 // bufferPool provides reusable buffers for encoders
@@ -48,6 +65,35 @@ func (e *Encoder) Release() {
 	}
 }
 
+// SetCanonical enables or disables canonical output. In canonical mode
+// map keys are sorted and floats always carry a fraction or exponent, so
+// equal documents encode to identical bytes.
+func (e *Encoder) SetCanonical(canonical bool) {
+	e.canonical = canonical
+}
+
+// SetNumberFormat sets how numbers are written.
+func (e *Encoder) SetNumberFormat(format NumberFormat) {
+	e.numbers = format
+}
+
+// Canonical reports whether canonical output is enabled.
+func (e *Encoder) Canonical() bool {
+	return e.canonical
+}
+
+// SetOmittedMapKeys sets keys left out of every encoded map[string]any,
+// at any depth. Pass no keys to encode maps in full.
+func (e *Encoder) SetOmittedMapKeys(keys ...string) {
+	e.omitKeys = nil
+	if len(keys) > 0 {
+		e.omitKeys = make(map[string]bool, len(keys))
+		for _, k := range keys {
+			e.omitKeys[k] = true
+		}
+	}
+}
+
 // Flush writes any buffered data to the underlying writer.
 func (e *Encoder) Flush() error {
 	if e.err != nil {
@@ -174,6 +220,14 @@ func (e *Encoder) WriteInt64(v int64) {
 	e.needComma = true
 }
 
+// maxIntegralFloat bounds the whole numbers that 'g' formatting writes
+// without an exponent.
+const maxIntegralFloat = 1e6
+
+// maxPlainFloat bounds the whole numbers reference OTIO writes without an
+// exponent.
+const maxPlainFloat = 1e21
+
 // WriteFloat64 writes a float64 value.
 // Handles special values (Inf, NaN) for Python compatibility.
 func (e *Encoder) WriteFloat64(v float64) {
@@ -212,8 +266,24 @@ func (e *Encoder) WriteFloat64WithOptions(v float64, allowSpecial bool) {
 		return
 	}
 
-	// Use strconv for normal floats
-	b := strconv.AppendFloat(e.scratch[:0], v, 'g', -1, 64)
+	if e.canonical && v == 0 {
+		v = 0 // normalize negative zero
+	}
+	var b []byte
+	if v == math.Trunc(v) && v > -maxIntegralFloat && v < maxIntegralFloat && (v != 0 || !math.Signbit(v)) {
+		// Frame counts and rates are nearly always whole numbers, which
+		// format as integers far faster and with the same digits as 'g'.
+		b = strconv.AppendInt(e.scratch[:0], int64(v), 10)
+	} else if e.numbers.PointZero && v == math.Trunc(v) && math.Abs(v) < maxPlainFloat {
+		// Reference OTIO writes whole numbers below 1e21 without an exponent.
+		b = strconv.AppendFloat(e.scratch[:0], v, 'f', -1, 64)
+	} else {
+		// strconv finds the shortest representation with Ryū.
+		b = strconv.AppendFloat(e.scratch[:0], v, 'g', -1, 64)
+	}
+	if (e.canonical || e.numbers.PointZero) && !bytes.ContainsAny(b, ".e") {
+		b = append(b, '.', '0')
+	}
 	e.writeBytes(b)
 	e.needComma = true
 }
@@ -230,6 +300,13 @@ func (e *Encoder) WriteQuotedString(s string) {
 func (e *Encoder) writeEscapedString(s string) {
 	start := 0
 	for i := 0; i < len(s); {
+		// Skip eight bytes at a time while none needs escaping.
+		for i+8 <= len(s) && !needsEscape(s[i:i+8]) {
+			i += 8
+		}
+		if i == len(s) {
+			break
+		}
 		b := s[i]
 		if b < utf8.RuneSelf {
 			if htmlSafeSet[b] {
@@ -305,9 +382,19 @@ func (e *Encoder) WriteIntField(key string, value int) {
 	e.WriteInt(value)
 }
 
-// WriteFloat64Field writes a key-value pair where value is a float64
+// WriteFloat64Field writes a key-value pair where value is a float64,
+// with the rounding the number format sets for rate and value fields.
 func (e *Encoder) WriteFloat64Field(key string, value float64) {
 	e.WriteKey(key)
+	switch {
+	case key == "rate" && e.numbers.RateTolerance > 0:
+		if whole := math.Round(value); math.Abs(value-whole) <= e.numbers.RateTolerance {
+			value = whole
+		}
+	case key == "value" && e.numbers.ValuePrecision > 0 && !math.IsInf(value, 0):
+		scale := math.Pow10(e.numbers.ValuePrecision)
+		value = math.Round(value*scale) / scale
+	}
 	e.WriteFloat64(value)
 }
 
@@ -317,6 +404,24 @@ func (e *Encoder) WriteNullField(key string) {
 	e.WriteNull()
 }
 
+const (
+	lowBits  = 0x0101010101010101
+	highBits = 0x8080808080808080
+)
+
+// needsEscape reports whether any of the eight bytes of s is a control
+// character, a quote, a backslash or not ASCII.
+func needsEscape(s string) bool {
+	x := uint64(s[0]) | uint64(s[1])<<8 | uint64(s[2])<<16 | uint64(s[3])<<24 |
+		uint64(s[4])<<32 | uint64(s[5])<<40 | uint64(s[6])<<48 | uint64(s[7])<<56
+	quote := x ^ ('"' * lowBits)
+	backslash := x ^ ('\\' * lowBits)
+	return (x|
+		(x-0x20*lowBits)&^x|
+		(quote-lowBits)&^quote|
+		(backslash-lowBits)&^backslash)&highBits != 0
+}
+
 var hex = "0123456789abcdef"
 
 // htmlSafeSet marks characters that don't need escaping
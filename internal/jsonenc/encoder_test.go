@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package jsonenc
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func encodeFloat(v float64, canonical bool) string {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	enc.SetCanonical(canonical)
+	enc.WriteFloat64(v)
+	enc.Flush()
+	enc.Release()
+	return buf.String()
+}
+
+func TestWriteFloat64MatchesStrconv(t *testing.T) {
+	values := []float64{
+		0, 1, -1, 24, 23.976, 30000.0 / 1001, 86400, 999999, -999999, 1e6, 1e6 - 0.5,
+		1e21, 1e-7, 0.1, 123456.5, math.MaxFloat64, math.SmallestNonzeroFloat64,
+		math.Copysign(0, -1),
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 10000 {
+		values = append(values, float64(r.IntN(4e6)-2e6), (r.Float64()-0.5)*1e7, math.Float64frombits(r.Uint64()))
+	}
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		if got, want := encodeFloat(v, false), strconv.FormatFloat(v, 'g', -1, 64); got != want {
+			t.Fatalf("WriteFloat64(%v) = %s, want %s", v, got, want)
+		}
+	}
+}
+
+func TestWriteFloat64Canonical(t *testing.T) {
+	tests := map[float64]string{
+		24:                   "24.0",
+		math.Copysign(0, -1): "0.0",
+		1e6:                  "1e+06",
+		23.5:                 "23.5",
+	}
+	for v, want := range tests {
+		if got := encodeFloat(v, true); got != want {
+			t.Errorf("canonical WriteFloat64(%v) = %s, want %s", v, got, want)
+		}
+	}
+	if got := encodeFloat(math.Inf(-1), false); got != "-Infinity" {
+		t.Errorf("WriteFloat64(-Inf) = %s", got)
+	}
+}
+
+func TestWriteQuotedStringMatchesEncodingJSON(t *testing.T) {
+	values := []string{
+		"", "plain", "sixteen_bytes___", `quote " in the middle of a longer string`,
+		`back\slash after eight`, "tab\tand\nnewline and more text", "\x00\x01\x1f\x7f",
+		"<html> & ampersands stay", "naïve café 日本語 text",
+		strings.Repeat("a", 63) + "\"",
+	}
+	for _, s := range values {
+		var buf bytes.Buffer
+		enc := NewEncoder(&buf)
+		enc.WriteQuotedString(s)
+		enc.Flush()
+		enc.Release()
+
+		var want bytes.Buffer
+		std := json.NewEncoder(&want)
+		std.SetEscapeHTML(false)
+		std.Encode(s)
+		if got := buf.String(); got != strings.TrimSuffix(want.String(), "\n") {
+			t.Errorf("WriteQuotedString(%q) = %s, want %s", s, got, want.String())
+		}
+	}
+
+	// Invalid UTF-8 is replaced with an escaped replacement character.
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	enc.WriteQuotedString("bad \xff utf8 in a long string")
+	enc.Flush()
+	enc.Release()
+	if want := `"bad \ufffd utf8 in a long string"`; buf.String() != want {
+		t.Errorf("WriteQuotedString of invalid UTF-8 = %s, want %s", buf.String(), want)
+	}
+}
+
+func BenchmarkWriteFloat64(b *testing.B) {
+	enc := NewEncoder(&bytes.Buffer{})
+	defer enc.Release()
+	b.Run("Integral", func(b *testing.B) {
+		for i := 0; b.Loop(); i++ {
+			enc.buf = enc.buf[:0]
+			enc.WriteFloat64(float64(86400 + i%1000))
+		}
+	})
+	b.Run("Fractional", func(b *testing.B) {
+		for i := 0; b.Loop(); i++ {
+			enc.buf = enc.buf[:0]
+			enc.WriteFloat64(23.976 + float64(i%1000))
+		}
+	})
+}
+
+func BenchmarkWriteQuotedString(b *testing.B) {
+	enc := NewEncoder(&bytes.Buffer{})
+	defer enc.Release()
+	s := "file:///media/project/footage/clip_00042.mov"
+	b.SetBytes(int64(len(s)))
+	for b.Loop() {
+		enc.buf = enc.buf[:0]
+		enc.WriteQuotedString(s)
+	}
+}
+
+func TestNumberFormat(t *testing.T) {
+	encode := func(format NumberFormat, key string, v float64) string {
+		var buf bytes.Buffer
+		enc := NewEncoder(&buf)
+		enc.SetNumberFormat(format)
+		enc.BeginObject()
+		enc.WriteFloat64Field(key, v)
+		enc.EndObject()
+		enc.Flush()
+		enc.Release()
+		return buf.String()
+	}
+	tests := []struct {
+		format NumberFormat
+		key    string
+		v      float64
+		want   string
+	}{
+		{NumberFormat{}, "rate", 24.000000004, `{"rate":24.000000004}`},
+		{NumberFormat{RateTolerance: 1e-6}, "rate", 24.000000004, `{"rate":24}`},
+		{NumberFormat{RateTolerance: 1e-6}, "rate", 30000.0 / 1001, `{"rate":29.97002997002997}`},
+		{NumberFormat{RateTolerance: 1e-6}, "value", 24.000000004, `{"value":24.000000004}`},
+		{NumberFormat{ValuePrecision: 3}, "value", 10.00049, `{"value":10}`},
+		{NumberFormat{ValuePrecision: 3}, "value", 10.12345, `{"value":10.123}`},
+		{NumberFormat{PointZero: true}, "value", 24, `{"value":24.0}`},
+		{NumberFormat{PointZero: true}, "value", 172800000, `{"value":172800000.0}`},
+		{NumberFormat{PointZero: true}, "value", 1e21, `{"value":1e+21}`},
+		{NumberFormat{PointZero: true, RateTolerance: 1e-6}, "rate", 23.99999999, `{"rate":24.0}`},
+	}
+	for _, tt := range tests {
+		if got := encode(tt.format, tt.key, tt.v); got != tt.want {
+			t.Errorf("%+v %s %v = %s, want %s", tt.format, tt.key, tt.v, got, tt.want)
+		}
+	}
+}
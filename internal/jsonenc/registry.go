@@ -6,6 +6,7 @@ package jsonenc
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 )
 
@@ -50,8 +51,12 @@ func (r *Registry) Register(info TypeInfo) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	key := fmt.Sprintf("%s.%d", info.SchemaName, info.SchemaVersion)
-	r.bySchema[key] = &info
+	// Types without a schema name (such as named map types) are
+	// only looked up by Go type.
+	if info.SchemaName != "" {
+		key := fmt.Sprintf("%s.%d", info.SchemaName, info.SchemaVersion)
+		r.bySchema[key] = &info
+	}
 
 	if info.GoType != nil {
 		r.byType[info.GoType] = &info
@@ -105,14 +110,26 @@ func (r *Registry) EncodeValue(enc *Encoder, v any) error {
 		return nil
 	}
 
+	if info, found := r.lookup(v); found {
+		return info.Encode(enc, v)
+	}
+
+	// Fallback: encode as basic JSON value
+	return r.encodeBasicValue(enc, v)
+}
+
+// lookup finds the registered encoder for v.
+func (r *Registry) lookup(v any) (*TypeInfo, bool) {
 	// Fast path: check if value provides schema info
 	if sp, ok := v.(SchemaProvider); ok {
 		key := fmt.Sprintf("%s.%d", sp.SchemaName(), sp.SchemaVersion())
 		r.mu.RLock()
 		info, found := r.bySchema[key]
 		r.mu.RUnlock()
-		if found && info.Encode != nil {
-			return info.Encode(enc, v)
+		// A value may claim another type's schema, as an unknown schema
+		// named like a known one does.
+		if found && info.Encode != nil && (info.GoType == nil || info.GoType == reflect.TypeOf(v)) {
+			return info, true
 		}
 	}
 
@@ -122,15 +139,15 @@ func (r *Registry) EncodeValue(enc *Encoder, v any) error {
 	info, found := r.byType[t]
 	r.mu.RUnlock()
 	if found && info.Encode != nil {
-		return info.Encode(enc, v)
+		return info, true
 	}
-
-	// Fallback: encode as basic JSON value
-	return encodeBasicValue(enc, v)
+	return nil, false
 }
 
 // encodeBasicValue handles encoding of primitive types and maps.
-func encodeBasicValue(enc *Encoder, v any) error {
+// Values it does not know directly, such as OTIO objects or times stored
+// in metadata, are encoded through the registry.
+func (r *Registry) encodeBasicValue(enc *Encoder, v any) error {
 	switch val := v.(type) {
 	case nil:
 		enc.WriteNull()
@@ -138,8 +155,14 @@ func encodeBasicValue(enc *Encoder, v any) error {
 		enc.WriteBool(val)
 	case int:
 		enc.WriteInt(val)
+	case int32:
+		enc.WriteInt64(int64(val))
 	case int64:
 		enc.WriteInt64(val)
+	case uint32:
+		enc.WriteInt64(int64(val))
+	case float32:
+		enc.WriteFloat64(float64(val))
 	case float64:
 		enc.WriteFloat64(val)
 	case string:
@@ -147,27 +170,54 @@ func encodeBasicValue(enc *Encoder, v any) error {
 	case []byte:
 		enc.WriteRawJSON(val)
 	case map[string]any:
-		return encodeAnyMap(enc, val)
+		return r.encodeAnyMap(enc, val)
 	case []any:
-		return encodeAnySlice(enc, val)
+		return r.encodeAnySlice(enc, val)
+	case []string:
+		enc.BeginArray()
+		for i, s := range val {
+			if i > 0 {
+				enc.WriteComma()
+			}
+			enc.WriteQuotedString(s)
+		}
+		enc.EndArray()
 	default:
+		if info, found := r.lookup(v); found {
+			return info.Encode(enc, v)
+		}
 		return fmt.Errorf("jsonenc: unsupported type %T", v)
 	}
 	return nil
 }
 
 // encodeAnyMap encodes a map[string]any (for metadata).
-func encodeAnyMap(enc *Encoder, m map[string]any) error {
+// In canonical mode the keys are written in sorted order.
+func (r *Registry) encodeAnyMap(enc *Encoder, m map[string]any) error {
 	enc.BeginObject()
-	first := true
-	for k, v := range m {
-		if !first {
-			enc.needComma = true
+	if enc.canonical {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			if !enc.omitKeys[k] {
+				keys = append(keys, k)
+			}
 		}
-		first = false
-		enc.WriteKey(k)
-		if err := encodeBasicValue(enc, v); err != nil {
-			return err
+		sort.Strings(keys)
+		for _, k := range keys {
+			enc.WriteKey(k)
+			if err := r.encodeBasicValue(enc, m[k]); err != nil {
+				return err
+			}
+		}
+	} else {
+		for k, v := range m {
+			if enc.omitKeys[k] {
+				continue
+			}
+			enc.WriteKey(k)
+			if err := r.encodeBasicValue(enc, v); err != nil {
+				return err
+			}
 		}
 	}
 	enc.EndObject()
@@ -175,13 +225,13 @@ func encodeAnyMap(enc *Encoder, m map[string]any) error {
 }
 
 // encodeAnySlice encodes a []any.
-func encodeAnySlice(enc *Encoder, s []any) error {
+func (r *Registry) encodeAnySlice(enc *Encoder, s []any) error {
 	enc.BeginArray()
 	for i, v := range s {
 		if i > 0 {
 			enc.WriteComma()
 		}
-		if err := encodeBasicValue(enc, v); err != nil {
+		if err := r.encodeBasicValue(enc, v); err != nil {
 			return err
 		}
 	}
@@ -189,14 +239,19 @@ func encodeAnySlice(enc *Encoder, s []any) error {
 	return nil
 }
 
-// EncodeMetadata encodes a metadata map (map[string]any).
-// This is a common operation in OTIO types.
-func EncodeMetadata(enc *Encoder, key string, metadata map[string]any) error {
-	enc.WriteKey(key)
-	if metadata == nil {
+// EncodeMap encodes a map[string]any value, honoring canonical mode.
+func EncodeMap(enc *Encoder, m map[string]any) error {
+	if m == nil {
 		enc.BeginObject()
 		enc.EndObject()
 		return nil
 	}
-	return encodeAnyMap(enc, metadata)
+	return globalRegistry.encodeAnyMap(enc, m)
+}
+
+// EncodeMetadata encodes a metadata map (map[string]any).
+// This is a common operation in OTIO types.
+func EncodeMetadata(enc *Encoder, key string, metadata map[string]any) error {
+	enc.WriteKey(key)
+	return EncodeMap(enc, metadata)
 }
@@ -4,6 +4,8 @@
 package gotio
 
 import (
+	"slices"
+
 	"github.com/Avalanche-io/gotio/opentime"
 )
 
@@ -23,6 +25,12 @@ type Item interface {
 	// SetEffects sets the effects.
 	SetEffects(effects []Effect)
 
+	// InsertEffect inserts an effect at index in the effect stack.
+	InsertEffect(index int, effect Effect) error
+
+	// RemoveEffect removes and returns the effect at index.
+	RemoveEffect(index int) (Effect, error)
+
 	// Markers returns the markers.
 	Markers() []*Marker
 
@@ -35,6 +43,10 @@ type Item interface {
 	// SetEnabled sets whether the item is enabled.
 	SetEnabled(enabled bool)
 
+	// EnabledInHierarchy returns whether the item and every composition
+	// above it are enabled.
+	EnabledInHierarchy() bool
+
 	// ItemColor returns the item color.
 	ItemColor() *Color
 
@@ -116,6 +128,37 @@ func (i *ItemBase) SetEffects(effects []Effect) {
 	i.effects = effects
 }
 
+// InsertEffect inserts an effect at index, from 0 to the number of
+// effects. Effects apply in order, so index 0 applies first. An item may
+// hold one time effect; inserting a second returns ErrDuplicateTimeEffect.
+func (i *ItemBase) InsertEffect(index int, effect Effect) error {
+	if index < 0 || index > len(i.effects) {
+		return &IndexError{Index: index, Size: len(i.effects)}
+	}
+	if effect == nil {
+		return &TypeMismatchError{Expected: "Effect", Got: "nil"}
+	}
+	if IsTimeEffect(effect) {
+		for _, e := range i.effects {
+			if IsTimeEffect(e) {
+				return ErrDuplicateTimeEffect
+			}
+		}
+	}
+	i.effects = slices.Insert(i.effects, index, effect)
+	return nil
+}
+
+// RemoveEffect removes and returns the effect at index.
+func (i *ItemBase) RemoveEffect(index int) (Effect, error) {
+	if index < 0 || index >= len(i.effects) {
+		return nil, &IndexError{Index: index, Size: len(i.effects)}
+	}
+	effect := i.effects[index]
+	i.effects = slices.Delete(i.effects, index, index+1)
+	return effect, nil
+}
+
 // Markers returns the markers.
 func (i *ItemBase) Markers() []*Marker {
 	return i.markers
@@ -139,6 +182,20 @@ func (i *ItemBase) SetEnabled(enabled bool) {
 	i.enabled = enabled
 }
 
+// EnabledInHierarchy returns whether the item and all of its ancestors are
+// enabled.
+func (i *ItemBase) EnabledInHierarchy() bool {
+	if !i.enabled {
+		return false
+	}
+	for parent := i.Parent(); parent != nil; parent = parent.Parent() {
+		if !parent.Enabled() {
+			return false
+		}
+	}
+	return true
+}
+
 // ItemColor returns the color.
 func (i *ItemBase) ItemColor() *Color {
 	return i.color
@@ -170,16 +227,37 @@ func (i *ItemBase) TrimmedRange() (opentime.TimeRange, error) {
 	return i.AvailableRange()
 }
 
-// VisibleRange returns the visible range (trimmed range with effects).
+// VisibleRange returns the trimmed range extended by the handles the
+// transitions next to the item in its track use, as in OpenTimelineIO:
+// the media seen while dissolving in and out, not only between the cuts.
 func (i *ItemBase) VisibleRange() (opentime.TimeRange, error) {
-	if i.sourceRange != nil {
-		return *i.sourceRange, nil
+	trimmed, err := i.TrimmedRange()
+	if err != nil {
+		return opentime.TimeRange{}, err
 	}
-	// Use self reference for dynamic dispatch to concrete type's AvailableRange
-	if selfItem, ok := i.Self().(Item); ok {
-		return selfItem.AvailableRange()
+	track, ok := i.Parent().(*Track)
+	if !ok {
+		return trimmed, nil
 	}
-	return i.AvailableRange()
+	inHandle, outHandle, err := track.HandlesOfChild(i.Self())
+	if err != nil {
+		return opentime.TimeRange{}, err
+	}
+	return extendByHandles(trimmed, inHandle, outHandle), nil
+}
+
+// extendByHandles extends r by inHandle at its start and outHandle at its
+// end, where set.
+func extendByHandles(r opentime.TimeRange, inHandle, outHandle *opentime.RationalTime) opentime.TimeRange {
+	start, duration := r.StartTime(), r.Duration()
+	if inHandle != nil {
+		start = start.Sub(*inHandle)
+		duration = duration.Add(*inHandle)
+	}
+	if outHandle != nil {
+		duration = duration.Add(*outHandle)
+	}
+	return opentime.NewTimeRange(start, duration)
 }
 
 // TransformedTime transforms a time from this item's coordinate space to another item's
@@ -190,6 +268,10 @@ func (i *ItemBase) VisibleRange() (opentime.TimeRange, error) {
 //    to parent coordinates at each level
 // 2. Walking DOWN from the common ancestor to the target item, converting from parent
 //    coordinates to internal time at each level
+//
+// Time in a composition with a LinearTimeWarp or FreezeFrame effect moves at
+// its time scalar relative to its parent. The effects of clips are not
+// applied, as in OpenTimelineIO.
 func (i *ItemBase) TransformedTime(t opentime.RationalTime, toItem Item) (opentime.RationalTime, error) {
 	if toItem == nil {
 		return t, nil
@@ -227,6 +309,13 @@ func (i *ItemBase) TransformedTime(t opentime.RationalTime, toItem Item) (openti
 			return result, err
 		}
 		result = result.Sub(trimmedRange.StartTime())
+		if scalar := compositionTimeScalar(item); scalar != 1 {
+			if scalar == 0 {
+				result = opentime.NewRationalTime(0, result.Rate())
+			} else {
+				result = scaleTime(result, 1/scalar)
+			}
+		}
 
 		// Step 2: Add the parent's range_of_child start time
 		// Need to pass the Composable interface to RangeOfChild
@@ -261,6 +350,7 @@ func (i *ItemBase) TransformedTime(t opentime.RationalTime, toItem Item) (openti
 	type transform struct {
 		trimmedStart  opentime.RationalTime
 		rangeInParent opentime.RationalTime
+		scalar        float64
 	}
 	var transforms []transform
 
@@ -287,6 +377,7 @@ func (i *ItemBase) TransformedTime(t opentime.RationalTime, toItem Item) (openti
 		transforms = append(transforms, transform{
 			trimmedStart:  trimmedRange.StartTime(),
 			rangeInParent: rangeInParent.StartTime(),
+			scalar:        compositionTimeScalar(item),
 		})
 
 		if parentItem, ok := parent.(Item); ok {
@@ -301,7 +392,14 @@ func (i *ItemBase) TransformedTime(t opentime.RationalTime, toItem Item) (openti
 		tr := transforms[j]
 		// Convert from parent's coordinate system to item's internal time
 		// Step 1: Subtract the parent's range_of_child start time
-		result = result.Sub(tr.rangeInParent)
+		// The first child of a composition starts at a zero-rate time,
+		// which Add ignores but Sub does not.
+		if tr.rangeInParent.Rate() > 0 {
+			result = result.Sub(tr.rangeInParent)
+		}
+		if tr.scalar != 1 {
+			result = scaleTime(result, tr.scalar)
+		}
 		// Step 2: Add the item's trimmed_range start time
 		result = result.Add(tr.trimmedStart)
 	}
@@ -309,6 +407,15 @@ func (i *ItemBase) TransformedTime(t opentime.RationalTime, toItem Item) (openti
 	return result, nil
 }
 
+// compositionTimeScalar returns the time scalar of item if it is a
+// composition, and 1 otherwise.
+func compositionTimeScalar(item Item) float64 {
+	if _, ok := item.(Composition); !ok {
+		return 1
+	}
+	return effectsTimeScalar(item.Effects())
+}
+
 // TransformedTimeRange transforms a time range from this item's coordinate space
 // to another item's coordinate space. The duration is preserved; only the start
 // time is transformed.
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// ItemConfig holds the optional fields for the option-based constructors
+// such as NewClipWithOptions. Fields that do not apply to the type being
+// built are ignored.
+type ItemConfig struct {
+	SourceRange             *opentime.TimeRange
+	Metadata                AnyDictionary
+	Effects                 []Effect
+	Markers                 []*Marker
+	Color                   *Color
+	Disabled                bool
+	MediaReference          MediaReference
+	ActiveMediaReferenceKey string
+	Kind                    string
+	GlobalStartTime         *opentime.RationalTime
+}
+
+// ItemOption is a functional option for the option-based constructors.
+type ItemOption func(*ItemConfig)
+
+// WithSourceRange sets the source range.
+func WithSourceRange(sourceRange opentime.TimeRange) ItemOption {
+	return func(c *ItemConfig) {
+		c.SourceRange = &sourceRange
+	}
+}
+
+// WithMetadata sets the metadata.
+func WithMetadata(metadata AnyDictionary) ItemOption {
+	return func(c *ItemConfig) {
+		c.Metadata = metadata
+	}
+}
+
+// WithEffects appends effects.
+func WithEffects(effects ...Effect) ItemOption {
+	return func(c *ItemConfig) {
+		c.Effects = append(c.Effects, effects...)
+	}
+}
+
+// WithMarkers appends markers.
+func WithMarkers(markers ...*Marker) ItemOption {
+	return func(c *ItemConfig) {
+		c.Markers = append(c.Markers, markers...)
+	}
+}
+
+// WithColor sets the item color.
+func WithColor(color *Color) ItemOption {
+	return func(c *ItemConfig) {
+		c.Color = color
+	}
+}
+
+// WithEnabled sets whether the item is enabled. Items are enabled by default.
+func WithEnabled(enabled bool) ItemOption {
+	return func(c *ItemConfig) {
+		c.Disabled = !enabled
+	}
+}
+
+// WithMediaReference sets a clip's media reference.
+func WithMediaReference(ref MediaReference) ItemOption {
+	return func(c *ItemConfig) {
+		c.MediaReference = ref
+	}
+}
+
+// WithActiveMediaReferenceKey sets the key a clip's media reference is stored under.
+func WithActiveMediaReferenceKey(key string) ItemOption {
+	return func(c *ItemConfig) {
+		c.ActiveMediaReferenceKey = key
+	}
+}
+
+// WithKind sets a track's kind.
+func WithKind(kind string) ItemOption {
+	return func(c *ItemConfig) {
+		c.Kind = kind
+	}
+}
+
+// WithGlobalStartTime sets a timeline's global start time.
+func WithGlobalStartTime(globalStartTime opentime.RationalTime) ItemOption {
+	return func(c *ItemConfig) {
+		c.GlobalStartTime = &globalStartTime
+	}
+}
+
+func newItemConfig(opts []ItemOption) ItemConfig {
+	var cfg ItemConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// NewClipWithOptions creates a new Clip from options.
+//
+//	clip := NewClipWithOptions("shot",
+//		WithMediaReference(ref),
+//		WithSourceRange(sr),
+//	)
+func NewClipWithOptions(name string, opts ...ItemOption) *Clip {
+	cfg := newItemConfig(opts)
+	clip := NewClip(name, cfg.MediaReference, cfg.SourceRange, cfg.Metadata,
+		cfg.Effects, cfg.Markers, cfg.ActiveMediaReferenceKey, cfg.Color)
+	clip.SetEnabled(!cfg.Disabled)
+	return clip
+}
+
+// NewGapWithOptions creates a new Gap from options.
+func NewGapWithOptions(name string, opts ...ItemOption) *Gap {
+	cfg := newItemConfig(opts)
+	gap := NewGap(name, cfg.SourceRange, cfg.Metadata, cfg.Effects, cfg.Markers, cfg.Color)
+	gap.SetEnabled(!cfg.Disabled)
+	return gap
+}
+
+// NewTrackWithOptions creates a new Track from options. The kind
+// defaults to video.
+func NewTrackWithOptions(name string, opts ...ItemOption) *Track {
+	cfg := newItemConfig(opts)
+	track := NewTrack(name, cfg.SourceRange, cfg.Kind, cfg.Metadata, cfg.Color)
+	track.SetEffects(cfg.Effects)
+	track.SetMarkers(cfg.Markers)
+	track.SetEnabled(!cfg.Disabled)
+	return track
+}
+
+// NewStackWithOptions creates a new Stack from options.
+func NewStackWithOptions(name string, opts ...ItemOption) *Stack {
+	cfg := newItemConfig(opts)
+	stack := NewStack(name, cfg.SourceRange, cfg.Metadata, cfg.Effects, cfg.Markers, cfg.Color)
+	stack.SetEnabled(!cfg.Disabled)
+	return stack
+}
+
+// NewTimelineWithOptions creates a new Timeline from options. Only
+// WithGlobalStartTime and WithMetadata apply.
+func NewTimelineWithOptions(name string, opts ...ItemOption) *Timeline {
+	cfg := newItemConfig(opts)
+	return NewTimeline(name, cfg.GlobalStartTime, cfg.Metadata)
+}
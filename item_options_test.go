@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"testing"
+)
+
+func TestNewClipWithOptions(t *testing.T) {
+	sr := NewTimeRange(NewRationalTime(10, 24), NewRationalTime(48, 24))
+	ref := NewExternalReference("", "file:///shot.mov", nil, nil)
+	marker := NewMarker("note", NewTimeRange(NewRationalTime(12, 24), NewRationalTime(0, 24)), MarkerColorRed, "", nil)
+
+	clip := NewClipWithOptions("shot",
+		WithSourceRange(sr),
+		WithMediaReference(ref),
+		WithMetadata(AnyDictionary{"studio": "x"}),
+		WithMarkers(marker),
+		WithEnabled(false),
+	)
+
+	if clip.Name() != "shot" {
+		t.Errorf("Name = %q, want shot", clip.Name())
+	}
+	if got := clip.SourceRange(); got == nil || !got.Equal(sr) {
+		t.Errorf("SourceRange = %v, want %v", got, sr)
+	}
+	if clip.MediaReference() != ref {
+		t.Error("MediaReference not set")
+	}
+	if clip.ActiveMediaReferenceKey() != DefaultMediaKey {
+		t.Errorf("ActiveMediaReferenceKey = %q, want %q", clip.ActiveMediaReferenceKey(), DefaultMediaKey)
+	}
+	if v, _ := clip.Metadata().GetString("studio"); v != "x" {
+		t.Errorf("metadata studio = %q, want x", v)
+	}
+	if len(clip.Markers()) != 1 {
+		t.Errorf("expected 1 marker, got %d", len(clip.Markers()))
+	}
+	if clip.Enabled() {
+		t.Error("expected clip to be disabled")
+	}
+}
+
+func TestNewCompositionsWithOptions(t *testing.T) {
+	track := NewTrackWithOptions("A1", WithKind(TrackKindAudio), WithEffects(NewEffect("fx", "Blur", nil)))
+	if track.Kind() != TrackKindAudio {
+		t.Errorf("Kind = %s, want %s", track.Kind(), TrackKindAudio)
+	}
+	if len(track.Effects()) != 1 {
+		t.Errorf("expected 1 effect, got %d", len(track.Effects()))
+	}
+	if !track.Enabled() {
+		t.Error("expected track to be enabled by default")
+	}
+	if NewTrackWithOptions("V1").Kind() != TrackKindVideo {
+		t.Error("expected video kind by default")
+	}
+
+	gapRange := NewTimeRange(NewRationalTime(0, 24), NewRationalTime(24, 24))
+	gap := NewGapWithOptions("", WithSourceRange(gapRange))
+	if dur, _ := gap.Duration(); dur.Value() != 24 {
+		t.Errorf("gap duration = %v, want 24", dur)
+	}
+
+	stack := NewStackWithOptions("s", WithColor(ColorRed))
+	if stack.ItemColor() != ColorRed {
+		t.Error("stack color not set")
+	}
+
+	start := NewRationalTime(86400, 24)
+	timeline := NewTimelineWithOptions("cut", WithGlobalStartTime(start))
+	if got := timeline.GlobalStartTime(); got == nil || !got.Equal(start) {
+		t.Errorf("GlobalStartTime = %v, want %v", got, start)
+	}
+	if timeline.Tracks() == nil {
+		t.Error("expected tracks stack")
+	}
+}
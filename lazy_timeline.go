@@ -0,0 +1,347 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// LazyTimeline is a read-only view of a serialized timeline. Reading one
+// decodes only the tracks and the name, kind and ranges of their items;
+// metadata, media references, effects and markers stay as raw JSON until
+// asked for. Tools that only need the structure of a large file load it
+// faster and in less memory than with FromJSONBytes.
+//
+// Materialize decodes the whole document into a Timeline.
+type LazyTimeline struct {
+	name            string
+	globalStartTime *opentime.RationalTime
+	metadata        RawMessage
+	tracks          []*LazyTrack
+	data            []byte
+	opts            []DecodeOption
+}
+
+// LazyTrack is a read-only view of a track in a LazyTimeline.
+type LazyTrack struct {
+	name        string
+	kind        string
+	enabled     bool
+	sourceRange *opentime.TimeRange
+	items       []*LazyItem
+	raw         RawMessage
+	opts        []DecodeOption
+}
+
+// LazyItem is a read-only view of a child of a LazyTrack: a clip, gap,
+// transition, or nested composition.
+type LazyItem struct {
+	schema      string
+	name        string
+	enabled     bool
+	sourceRange *opentime.TimeRange
+	raw         RawMessage
+	opts        []DecodeOption
+}
+
+// lazyTimelineJSON is the part of a timeline read by ReadLazyTimeline.
+type lazyTimelineJSON struct {
+	Schema          string                 `json:"OTIO_SCHEMA"`
+	Name            string                 `json:"name"`
+	Metadata        RawMessage             `json:"metadata"`
+	GlobalStartTime *opentime.RationalTime `json:"global_start_time"`
+	Tracks          struct {
+		Children []RawMessage `json:"children"`
+	} `json:"tracks"`
+}
+
+// lazyItemJSON is the part of a track or item read by ReadLazyTimeline.
+type lazyItemJSON struct {
+	Schema      string              `json:"OTIO_SCHEMA"`
+	Name        string              `json:"name"`
+	Kind        string              `json:"kind"`
+	Enabled     bool                `json:"enabled"`
+	SourceRange *opentime.TimeRange `json:"source_range"`
+	Children    []RawMessage        `json:"children"`
+}
+
+// ReadLazyTimeline reads the structure of the timeline in data, checking
+// the decode limits of opts first. The timeline keeps a reference to data,
+// which must not be modified while it is in use; data may be a read-only
+// memory mapping.
+//
+// Returns a TypeMismatchError if data is not a timeline whose tracks are
+// all tracks.
+func ReadLazyTimeline(data []byte, opts ...DecodeOption) (*LazyTimeline, error) {
+	if err := NewDecodeConfig(opts...).Check(data); err != nil {
+		return nil, err
+	}
+	data = SanitizeJSON(data)
+
+	var j lazyTimelineJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, &JSONError{Message: err.Error(), Err: err}
+	}
+	if !strings.HasPrefix(j.Schema, "Timeline.") {
+		return nil, &TypeMismatchError{Expected: "Timeline", Got: j.Schema}
+	}
+
+	t := &LazyTimeline{
+		name:            j.Name,
+		globalStartTime: j.GlobalStartTime,
+		metadata:        j.Metadata,
+		data:            data,
+		opts:            opts,
+	}
+	for _, raw := range j.Tracks.Children {
+		track, err := readLazyTrack(raw, opts)
+		if err != nil {
+			return nil, err
+		}
+		t.tracks = append(t.tracks, track)
+	}
+	return t, nil
+}
+
+// ReadLazyTimelineFile reads the structure of the timeline in a file.
+func ReadLazyTimelineFile(filename string, opts ...DecodeOption) (*LazyTimeline, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ReadLazyTimeline(data, opts...)
+}
+
+func readLazyTrack(raw RawMessage, opts []DecodeOption) (*LazyTrack, error) {
+	var j lazyItemJSON
+	if err := json.Unmarshal(raw, &j); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(j.Schema, "Track.") {
+		return nil, &TypeMismatchError{Expected: "Track", Got: j.Schema}
+	}
+	kind := j.Kind
+	if kind == "" {
+		kind = TrackKindVideo
+	}
+
+	track := &LazyTrack{
+		name:        j.Name,
+		kind:        kind,
+		enabled:     j.Enabled,
+		sourceRange: j.SourceRange,
+		raw:         raw,
+		opts:        opts,
+	}
+	for _, child := range j.Children {
+		var c lazyItemJSON
+		if err := json.Unmarshal(child, &c); err != nil {
+			return nil, err
+		}
+		track.items = append(track.items, &LazyItem{
+			schema:      c.Schema,
+			name:        c.Name,
+			enabled:     c.Enabled,
+			sourceRange: c.SourceRange,
+			raw:         child,
+			opts:        opts,
+		})
+	}
+	return track, nil
+}
+
+// Name returns the timeline name.
+func (t *LazyTimeline) Name() string {
+	return t.name
+}
+
+// GlobalStartTime returns the global start time, or nil if unset.
+func (t *LazyTimeline) GlobalStartTime() *opentime.RationalTime {
+	return t.globalStartTime
+}
+
+// Metadata decodes and returns the timeline metadata.
+func (t *LazyTimeline) Metadata() (AnyDictionary, error) {
+	return decodeLazyMetadata(t.metadata)
+}
+
+// Tracks returns the tracks.
+func (t *LazyTimeline) Tracks() []*LazyTrack {
+	return t.tracks
+}
+
+// Duration returns the duration of the longest track.
+func (t *LazyTimeline) Duration() (opentime.RationalTime, error) {
+	var longest opentime.RationalTime
+	for i, track := range t.tracks {
+		d, err := track.Duration()
+		if err != nil {
+			return opentime.RationalTime{}, err
+		}
+		if i == 0 || d.ToSeconds() > longest.ToSeconds() {
+			longest = d
+		}
+	}
+	return longest, nil
+}
+
+// Materialize decodes the whole document into a Timeline.
+func (t *LazyTimeline) Materialize() (*Timeline, error) {
+	obj, err := FromJSONBytesWithOptions(t.data, t.opts...)
+	if err != nil {
+		return nil, err
+	}
+	timeline, ok := obj.(*Timeline)
+	if !ok {
+		return nil, &TypeMismatchError{Expected: "Timeline", Got: obj.SchemaName()}
+	}
+	return timeline, nil
+}
+
+// Name returns the track name.
+func (t *LazyTrack) Name() string {
+	return t.name
+}
+
+// Kind returns the track kind.
+func (t *LazyTrack) Kind() string {
+	return t.kind
+}
+
+// Enabled returns whether the track is enabled.
+func (t *LazyTrack) Enabled() bool {
+	return t.enabled
+}
+
+// SourceRange returns the source range, or nil if unset.
+func (t *LazyTrack) SourceRange() *opentime.TimeRange {
+	return t.sourceRange
+}
+
+// Items returns the children of the track.
+func (t *LazyTrack) Items() []*LazyItem {
+	return t.items
+}
+
+// Duration returns the duration of the track: its source range if set,
+// otherwise the total of its visible items.
+func (t *LazyTrack) Duration() (opentime.RationalTime, error) {
+	if t.sourceRange != nil {
+		return t.sourceRange.Duration(), nil
+	}
+	var total opentime.RationalTime
+	for _, item := range t.items {
+		if !item.Visible() {
+			continue
+		}
+		d, err := item.Duration()
+		if err != nil {
+			return opentime.RationalTime{}, err
+		}
+		if total.Rate() <= 0 {
+			total = d
+		} else {
+			total = total.Add(d)
+		}
+	}
+	return total, nil
+}
+
+// Materialize decodes the track and its items.
+func (t *LazyTrack) Materialize() (*Track, error) {
+	obj, err := FromJSONBytesWithOptions(t.raw, t.opts...)
+	if err != nil {
+		return nil, err
+	}
+	track, ok := obj.(*Track)
+	if !ok {
+		return nil, &TypeMismatchError{Expected: "Track", Got: obj.SchemaName()}
+	}
+	return track, nil
+}
+
+// SchemaName returns the schema name of the item, such as "Clip".
+func (i *LazyItem) SchemaName() string {
+	name, _, _ := strings.Cut(i.schema, ".")
+	return name
+}
+
+// Name returns the item name.
+func (i *LazyItem) Name() string {
+	return i.name
+}
+
+// Enabled returns whether the item is enabled.
+func (i *LazyItem) Enabled() bool {
+	return i.enabled
+}
+
+// SourceRange returns the source range, or nil if unset.
+func (i *LazyItem) SourceRange() *opentime.TimeRange {
+	return i.sourceRange
+}
+
+// Visible returns whether the item takes up time in its track, which is
+// false only for transitions.
+func (i *LazyItem) Visible() bool {
+	return i.SchemaName() != "Transition"
+}
+
+// Duration returns the duration of the item. Items without a source
+// range, such as clips that take their duration from media, are decoded
+// to find it.
+func (i *LazyItem) Duration() (opentime.RationalTime, error) {
+	if i.sourceRange != nil {
+		return i.sourceRange.Duration(), nil
+	}
+	obj, err := i.Materialize()
+	if err != nil {
+		return opentime.RationalTime{}, err
+	}
+	return obj.Duration()
+}
+
+// Metadata decodes and returns the item metadata.
+func (i *LazyItem) Metadata() (AnyDictionary, error) {
+	var j struct {
+		Metadata RawMessage `json:"metadata"`
+	}
+	if err := json.Unmarshal(i.raw, &j); err != nil {
+		return nil, err
+	}
+	return decodeLazyMetadata(j.Metadata)
+}
+
+// Materialize decodes the item.
+func (i *LazyItem) Materialize() (Composable, error) {
+	obj, err := FromJSONBytesWithOptions(i.raw, i.opts...)
+	if err != nil {
+		return nil, err
+	}
+	composable, ok := obj.(Composable)
+	if !ok {
+		return nil, &TypeMismatchError{Expected: "Composable", Got: obj.SchemaName()}
+	}
+	return composable, nil
+}
+
+// decodeLazyMetadata decodes a raw metadata dictionary, which may be
+// absent.
+func decodeLazyMetadata(raw RawMessage) (AnyDictionary, error) {
+	md := make(AnyDictionary)
+	if len(raw) == 0 {
+		return md, nil
+	}
+	if err := json.Unmarshal(raw, &md); err != nil {
+		return nil, err
+	}
+	if md == nil {
+		md = make(AnyDictionary)
+	}
+	return md, nil
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func lazyTestTimeline() *Timeline {
+	rt := func(v float64) opentime.RationalTime { return opentime.NewRationalTime(v, 24) }
+	start := rt(86400)
+	timeline := NewTimeline("lazy", &start, AnyDictionary{"project": "test"})
+
+	sr := opentime.NewTimeRange(rt(0), rt(48))
+	available := opentime.NewTimeRange(rt(0), rt(72))
+	v1 := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	v1.AppendChild(NewClip("a", nil, &sr, AnyDictionary{"scene": "12"}, nil, nil, "", nil))
+	v1.AppendChild(NewTransition("", TransitionTypeSMPTEDissolve, rt(6), rt(6), nil))
+	v1.AppendChild(NewClip("b", NewExternalReference("", "file:///b.mov", &available, nil), nil, nil, nil, nil, "", nil))
+	v1.AppendChild(NewGapWithDuration(rt(24)))
+	nested := NewStack("nest", nil, nil, nil, nil, nil)
+	inner := NewTrack("inner", nil, TrackKindVideo, nil, nil)
+	inner.AppendChild(NewClip("c", nil, &sr, nil, nil, nil, "", nil))
+	nested.AppendChild(inner)
+	v1.AppendChild(nested)
+	timeline.Tracks().AppendChild(v1)
+
+	a1 := NewTrack("A1", nil, TrackKindAudio, nil, nil)
+	a1.AppendChild(NewClip("music", nil, &sr, nil, nil, nil, "", nil))
+	timeline.Tracks().AppendChild(a1)
+	return timeline
+}
+
+func TestReadLazyTimeline(t *testing.T) {
+	timeline := lazyTestTimeline()
+	data, err := ToJSONBytes(timeline)
+	if err != nil {
+		t.Fatalf("ToJSONBytes error: %v", err)
+	}
+	lazy, err := ReadLazyTimeline(data)
+	if err != nil {
+		t.Fatalf("ReadLazyTimeline error: %v", err)
+	}
+
+	if lazy.Name() != "lazy" || lazy.GlobalStartTime() == nil || lazy.GlobalStartTime().Value() != 86400 {
+		t.Errorf("unexpected name %q and start %v", lazy.Name(), lazy.GlobalStartTime())
+	}
+	if md, err := lazy.Metadata(); err != nil || md["project"] != "test" {
+		t.Errorf("Metadata = %v, %v", md, err)
+	}
+
+	tracks := lazy.Tracks()
+	if len(tracks) != 2 || tracks[0].Name() != "V1" || tracks[1].Kind() != TrackKindAudio {
+		t.Fatalf("unexpected tracks %v", tracks)
+	}
+	items := tracks[0].Items()
+	if len(items) != 5 {
+		t.Fatalf("expected 5 items in V1, got %d", len(items))
+	}
+	wantSchemas := []string{"Clip", "Transition", "Clip", "Gap", "Stack"}
+	for i, item := range items {
+		if item.SchemaName() != wantSchemas[i] {
+			t.Errorf("item %d schema = %s, want %s", i, item.SchemaName(), wantSchemas[i])
+		}
+	}
+	if items[1].Visible() || !items[0].Visible() || !items[0].Enabled() {
+		t.Error("expected only the transition to be invisible")
+	}
+	if md, err := items[0].Metadata(); err != nil || md["scene"] != "12" {
+		t.Errorf("clip Metadata = %v, %v", md, err)
+	}
+	if d, err := items[2].Duration(); err != nil || d.Value() != 72 {
+		t.Errorf("clip duration from media = %v, %v; want 72", d, err)
+	}
+
+	want, _ := timeline.Duration()
+	if got, err := lazy.Duration(); err != nil || !got.Equal(want) {
+		t.Errorf("Duration = %v, %v; want %v", got, err, want)
+	}
+	wantTrack, _ := timeline.VideoTracks()[0].Duration()
+	if got, _ := tracks[0].Duration(); !got.Equal(wantTrack) {
+		t.Errorf("V1 Duration = %v, want %v", got, wantTrack)
+	}
+
+	materialized, err := lazy.Materialize()
+	if err != nil {
+		t.Fatalf("Materialize error: %v", err)
+	}
+	if !materialized.IsEquivalentTo(timeline) {
+		t.Error("materialized timeline is not equivalent")
+	}
+	track, err := tracks[0].Materialize()
+	if err != nil || len(track.Children()) != 5 {
+		t.Errorf("track Materialize = %v, %v", track, err)
+	}
+	if stack, err := items[4].Materialize(); err != nil || stack.(*Stack).Name() != "nest" {
+		t.Errorf("item Materialize = %v, %v", stack, err)
+	}
+}
+
+func TestReadLazyTimelineErrors(t *testing.T) {
+	clip := NewClip("a", nil, nil, nil, nil, nil, "", nil)
+	data, _ := ToJSONBytes(clip)
+	if _, err := ReadLazyTimeline(data); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("expected ErrTypeMismatch for a clip, got %v", err)
+	}
+	if _, err := ReadLazyTimeline([]byte("{")); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+	data, _ = ToJSONBytes(lazyTestTimeline())
+	if _, err := ReadLazyTimeline(data, WithMaxNestingDepth(3)); !errors.Is(err, ErrDecodeLimit) {
+		t.Errorf("expected ErrDecodeLimit, got %v", err)
+	}
+}
+
+func TestReadLazyTimelineFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lazy.otio")
+	if err := ToJSONFile(lazyTestTimeline(), path, "  "); err != nil {
+		t.Fatalf("ToJSONFile error: %v", err)
+	}
+	lazy, err := ReadLazyTimelineFile(path)
+	if err != nil {
+		t.Fatalf("ReadLazyTimelineFile error: %v", err)
+	}
+	if len(lazy.Tracks()) != 2 {
+		t.Errorf("expected 2 tracks, got %d", len(lazy.Tracks()))
+	}
+	if _, err := ReadLazyTimelineFile(filepath.Join(t.TempDir(), "missing.otio")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected ErrNotExist, got %v", err)
+	}
+}
+
+func BenchmarkReadLazyTimeline(b *testing.B) {
+	timeline := createBenchmarkTimeline(4, 4, 500)
+	for _, clip := range timeline.FindClips(nil, false) {
+		clip.SetMetadata(createBenchmarkClipWithMetadata().Metadata())
+	}
+	data, err := ToJSONBytes(timeline)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("Lazy", func(b *testing.B) {
+		b.SetBytes(int64(len(data)))
+		for b.Loop() {
+			if _, err := ReadLazyTimeline(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Full", func(b *testing.B) {
+		b.SetBytes(int64(len(data)))
+		for b.Loop() {
+			if _, err := FromJSONBytes(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
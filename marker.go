@@ -59,6 +59,34 @@ func (m *Marker) SetColor(color MarkerColor) {
 	m.color = color
 }
 
+// CustomColor returns the marker's exact color: the color stored under
+// CustomColorKey in its metadata if there is a valid one, else the color of
+// its marker color.
+func (m *Marker) CustomColor() *Color {
+	if hex, ok := m.metadata.GetString(CustomColorKey); ok {
+		if c, err := ParseColor(hex); err == nil {
+			return c
+		}
+	}
+	return cloneColor(m.color.ToColor())
+}
+
+// SetCustomColor sets the marker color to the standard color nearest to c.
+// If c is not a standard color, its hex form is kept in the metadata under
+// CustomColorKey so that adapters can write it back exactly.
+func (m *Marker) SetCustomColor(c *Color) {
+	if mc, ok := c.MarkerColor(); ok {
+		m.color = mc
+		delete(m.metadata, CustomColorKey)
+		return
+	}
+	m.color = c.NearestMarkerColor()
+	if m.metadata == nil {
+		m.metadata = make(AnyDictionary)
+	}
+	m.metadata[CustomColorKey] = c.Hex()
+}
+
 // Comment returns the comment.
 func (m *Marker) Comment() string {
 	return m.comment
@@ -5,6 +5,7 @@ package gotio
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/Avalanche-io/gotio/opentime"
@@ -132,3 +133,101 @@ func TestMarkerColors(t *testing.T) {
 		}
 	}
 }
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		input string
+		hex   string
+		ok    bool
+	}{
+		{"red", "#FF2121", true},
+		{" Blue ", "#218CFF", true},
+		{"#1A2B3C", "#1A2B3C", true},
+		{"#1a2b3c80", "#1A2B3C80", true},
+		{"#FFF", "", false},
+		{"#GGGGGG", "", false},
+		{"teal", "", false},
+	}
+	for _, tt := range tests {
+		c, err := ParseColor(tt.input)
+		if !tt.ok {
+			if !errors.Is(err, ErrInvalidColor) {
+				t.Errorf("ParseColor(%q) error = %v, want ErrInvalidColor", tt.input, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseColor(%q) error: %v", tt.input, err)
+			continue
+		}
+		if c.Hex() != tt.hex {
+			t.Errorf("ParseColor(%q).Hex() = %s, want %s", tt.input, c.Hex(), tt.hex)
+		}
+	}
+
+	// Parsing a standard color does not share the package variable.
+	c, _ := ParseColor("red")
+	c.R = 0
+	if ColorRed.R != 1.0 {
+		t.Error("ParseColor returned the shared ColorRed")
+	}
+}
+
+func TestMarkerColorValidation(t *testing.T) {
+	for _, mc := range MarkerColors() {
+		if !mc.IsValid() {
+			t.Errorf("%s should be valid", mc)
+		}
+		if got, ok := mc.ToColor().MarkerColor(); !ok || got != mc {
+			t.Errorf("%s round-tripped to %s, %v", mc, got, ok)
+		}
+	}
+	if MarkerColor("TEAL").IsValid() || MarkerColor("red").IsValid() {
+		t.Error("unknown and lower case names should be invalid")
+	}
+	if mc, err := ParseMarkerColor("magenta"); err != nil || mc != MarkerColorMagenta {
+		t.Errorf("ParseMarkerColor(magenta) = %s, %v", mc, err)
+	}
+	if _, err := ParseMarkerColor("teal"); !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("expected ErrInvalidColor, got %v", err)
+	}
+	if mc := NewColorRGB(0.9, 0.1, 0.15).NearestMarkerColor(); mc != MarkerColorRed {
+		t.Errorf("NearestMarkerColor = %s, want RED", mc)
+	}
+}
+
+func TestMarkerCustomColor(t *testing.T) {
+	mr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(1, 24))
+	marker := NewMarker("note", mr, "", "", nil)
+
+	custom, _ := ParseColor("#E01030")
+	marker.SetCustomColor(custom)
+	if marker.Color() != MarkerColorRed {
+		t.Errorf("Color = %s, want the nearest standard color RED", marker.Color())
+	}
+	if marker.Metadata()[CustomColorKey] != "#E01030" {
+		t.Errorf("expected the hex color in metadata, got %v", marker.Metadata())
+	}
+
+	// The custom color survives serialization.
+	data, err := ToJSONBytes(marker)
+	if err != nil {
+		t.Fatalf("ToJSONBytes error: %v", err)
+	}
+	obj, err := FromJSONBytes(data)
+	if err != nil {
+		t.Fatalf("FromJSONBytes error: %v", err)
+	}
+	if hex := obj.(*Marker).CustomColor().Hex(); hex != "#E01030" {
+		t.Errorf("CustomColor after round trip = %s, want #E01030", hex)
+	}
+
+	// A standard color clears the custom one.
+	marker.SetCustomColor(ColorBlue)
+	if marker.Color() != MarkerColorBlue || marker.Metadata()[CustomColorKey] != nil {
+		t.Errorf("expected BLUE without a custom color, got %s %v", marker.Color(), marker.Metadata())
+	}
+	if marker.CustomColor().Hex() != ColorBlue.Hex() {
+		t.Errorf("CustomColor = %s, want %s", marker.CustomColor().Hex(), ColorBlue.Hex())
+	}
+}
@@ -5,6 +5,7 @@ package gotio
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/Avalanche-io/gotio/opentime"
@@ -326,6 +327,85 @@ func TestImageSequenceReferenceFrameNumber(t *testing.T) {
 	}
 }
 
+func TestImageSequenceReferenceFrameStep(t *testing.T) {
+	ar := opentime.NewTimeRange(opentime.NewRationalTime(12, 24), opentime.NewRationalTime(48, 24))
+	ref := NewImageSequenceReference("", "/path", "f_", ".exr", 1, 2, 24, 4, &ar, nil, MissingFramePolicyError)
+
+	if n := ref.NumberOfImagesInSequence(); n != 24 {
+		t.Errorf("NumberOfImagesInSequence = %d, want 24", n)
+	}
+	if frame := ref.FrameForImageNumber(3); frame != 7 {
+		t.Errorf("FrameForImageNumber(3) = %d, want 7", frame)
+	}
+
+	url, err := ref.TargetURLForImage(3)
+	if err != nil {
+		t.Fatalf("TargetURLForImage error: %v", err)
+	}
+	if url != "/path/f_0007.exr" {
+		t.Errorf("TargetURLForImage(3) = %s, want /path/f_0007.exr", url)
+	}
+	if _, err := ref.TargetURLForImage(24); err == nil {
+		t.Error("expected error for image number past the end of the sequence")
+	}
+
+	pt, err := ref.PresentationTimeForImageNumber(3)
+	if err != nil {
+		t.Fatalf("PresentationTimeForImageNumber error: %v", err)
+	}
+	if pt.Value() != 18 || pt.Rate() != 24 {
+		t.Errorf("PresentationTimeForImageNumber(3) = %v, want 18@24", pt)
+	}
+	var idxErr *IndexError
+	if _, err := ref.PresentationTimeForImageNumber(-1); !errors.As(err, &idxErr) {
+		t.Errorf("expected IndexError, got %v", err)
+	}
+
+	// Times between stepped images map to the preceding image
+	n, err := ref.ImageNumberForTime(opentime.NewRationalTime(19, 24))
+	if err != nil {
+		t.Fatalf("ImageNumberForTime error: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("ImageNumberForTime(19) = %d, want 3", n)
+	}
+	if _, err := ref.ImageNumberForTime(opentime.NewRationalTime(60, 24)); !errors.Is(err, ErrTimeOutOfRange) {
+		t.Errorf("expected ErrTimeOutOfRange, got %v", err)
+	}
+
+	// FrameForTime and TimeForFrame are measured from the available range start
+	time := opentime.NewRationalTime(1, 1)
+	frame := ref.FrameForTime(time)
+	if frame != 13 {
+		t.Errorf("FrameForTime(1s) = %d, want 13", frame)
+	}
+	if back := ref.TimeForFrame(frame); back.Cmp(time) != 0 {
+		t.Errorf("TimeForFrame(%d) = %v, want %v", frame, back, time)
+	}
+}
+
+func TestImageSequenceReferenceURLFormatting(t *testing.T) {
+	ref := NewImageSequenceReference("", "file:///path", "frame_", ".exr", 0, 1, 24, 4, nil, nil, MissingFramePolicyError)
+
+	if url := ref.TargetURLForImageNumber(-5); url != "file:///path/frame_-0005.exr" {
+		t.Errorf("TargetURLForImageNumber(-5) = %s, want file:///path/frame_-0005.exr", url)
+	}
+	if url := ref.TargetURLForImageNumber(123456); url != "file:///path/frame_123456.exr" {
+		t.Errorf("TargetURLForImageNumber(123456) = %s, want file:///path/frame_123456.exr", url)
+	}
+	if url := ref.AbstractTargetURL("@"); url != "file:///path/frame_@.exr" {
+		t.Errorf("AbstractTargetURL = %s, want file:///path/frame_@.exr", url)
+	}
+
+	ref.SetTargetURLBase("")
+	if url := ref.TargetURLForImageNumber(1); url != "frame_0001.exr" {
+		t.Errorf("TargetURLForImageNumber with empty base = %s, want frame_0001.exr", url)
+	}
+	if _, err := ref.TargetURLForImage(0); err == nil {
+		t.Error("expected error without an available range")
+	}
+}
+
 func TestImageSequenceReferenceClone(t *testing.T) {
 	ref := NewImageSequenceReference("", "/path/", "f_", ".exr", 1001, 1, 24, 4, nil, nil, MissingFramePolicyError)
 
@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package mediainfo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// ErrNoStreams is returned for media with no video or audio stream.
+var ErrNoStreams = errors.New("mediainfo: no video or audio stream")
+
+// FFProbe probes media by running ffprobe.
+type FFProbe struct {
+	// Path is the ffprobe executable. Empty looks up "ffprobe" on the PATH.
+	Path string
+}
+
+// Probe runs ffprobe on the file at path.
+func (p FFProbe) Probe(ctx context.Context, path string) (Info, error) {
+	bin := p.Path
+	if bin == "" {
+		bin = "ffprobe"
+	}
+	cmd := exec.CommandContext(ctx, bin,
+		"-v", "error",
+		"-print_format", "json",
+		"-show_format",
+		"-show_streams",
+		path,
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return Info{}, fmt.Errorf("ffprobe: %s", msg)
+		}
+		return Info{}, fmt.Errorf("ffprobe: %w", err)
+	}
+	return ParseFFProbe(out)
+}
+
+// ffprobeOutput is the part of ffprobe's JSON output that is used.
+type ffprobeOutput struct {
+	Streams []ffprobeStream `json:"streams"`
+	Format  struct {
+		Duration string            `json:"duration"`
+		Tags     map[string]string `json:"tags"`
+	} `json:"format"`
+}
+
+type ffprobeStream struct {
+	CodecType    string            `json:"codec_type"`
+	CodecName    string            `json:"codec_name"`
+	Width        int               `json:"width"`
+	Height       int               `json:"height"`
+	AvgFrameRate string            `json:"avg_frame_rate"`
+	RFrameRate   string            `json:"r_frame_rate"`
+	SampleRate   string            `json:"sample_rate"`
+	NbFrames     string            `json:"nb_frames"`
+	Duration     string            `json:"duration"`
+	Tags         map[string]string `json:"tags"`
+}
+
+// ParseFFProbe reads the JSON output of
+// "ffprobe -print_format json -show_format -show_streams". The first
+// video stream is used, or the first audio stream if there is no video.
+func ParseFFProbe(data []byte) (Info, error) {
+	var out ffprobeOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return Info{}, fmt.Errorf("mediainfo: invalid ffprobe output: %w", err)
+	}
+	stream := findStream(out.Streams, "video")
+	if stream == nil {
+		stream = findStream(out.Streams, "audio")
+	}
+	if stream == nil {
+		return Info{}, ErrNoStreams
+	}
+
+	info := Info{Codec: stream.CodecName}
+	if stream.CodecType == "video" {
+		info.Width, info.Height = stream.Width, stream.Height
+		info.Rate = parseRate(stream.AvgFrameRate)
+		if info.Rate <= 0 {
+			info.Rate = parseRate(stream.RFrameRate)
+		}
+	} else {
+		info.Rate = parseRate(stream.SampleRate)
+	}
+	if info.Rate <= 0 {
+		return Info{}, fmt.Errorf("mediainfo: no rate for %s stream", stream.CodecType)
+	}
+
+	frames, _ := strconv.ParseFloat(stream.NbFrames, 64)
+	if frames <= 0 || stream.CodecType != "video" {
+		seconds, err := strconv.ParseFloat(stream.Duration, 64)
+		if err != nil {
+			seconds, err = strconv.ParseFloat(out.Format.Duration, 64)
+		}
+		if err != nil {
+			return Info{}, errors.New("mediainfo: no duration")
+		}
+		frames = math.Round(seconds * info.Rate)
+	}
+	info.Duration = opentime.NewRationalTime(frames, info.Rate)
+
+	info.StartTime = opentime.NewRationalTime(0, info.Rate)
+	timecode := stream.Tags["timecode"]
+	if timecode == "" {
+		timecode = out.Format.Tags["timecode"]
+	}
+	if timecode != "" && stream.CodecType == "video" {
+		if start, err := opentime.FromTimecode(timecode, info.Rate); err == nil {
+			info.StartTime = start
+		}
+	}
+	return info, nil
+}
+
+func findStream(streams []ffprobeStream, codecType string) *ffprobeStream {
+	for i := range streams {
+		if streams[i].CodecType == codecType {
+			return &streams[i]
+		}
+	}
+	return nil
+}
+
+// parseRate parses a rate such as "24", "48000" or "24000/1001".
+func parseRate(s string) float64 {
+	num, den, found := strings.Cut(s, "/")
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0
+	}
+	if !found {
+		return n
+	}
+	d, err := strconv.ParseFloat(den, 64)
+	if err != nil || d == 0 {
+		return 0
+	}
+	return n / d
+}
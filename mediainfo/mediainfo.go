@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package mediainfo probes local media files to fill in what timelines
+// often leave out: the available range of external references, and the
+// frame rate, resolution and codec of the media.
+//
+// Probing is done by a Prober. The default runs ffprobe, which must be on
+// the PATH; any other tool can be plugged in by implementing Prober.
+//
+// Basic usage:
+//
+//	n, err := mediainfo.PopulateAvailableRanges(timeline)
+//	if err != nil {
+//		log.Printf("some media could not be probed: %v", err)
+//	}
+//	log.Printf("filled in %d available ranges", n)
+package mediainfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/mediaresolver"
+)
+
+func init() {
+	gotio.RegisterFeature("mediainfo")
+}
+
+// MetadataKey is the metadata namespace probed stream information is
+// stored under on media references.
+const MetadataKey = "mediainfo"
+
+// Info is what a probe learns about a media file.
+type Info struct {
+	// Rate is the frame rate of the video stream, or the sample rate of
+	// the audio stream for audio only media.
+	Rate float64
+	// StartTime is the start timecode of the media, or zero if it has none.
+	StartTime opentime.RationalTime
+	// Duration is the length of the media at Rate.
+	Duration opentime.RationalTime
+	// Width and Height are the video resolution in pixels, zero for audio.
+	Width  int
+	Height int
+	// Codec names the codec of the probed stream.
+	Codec string
+}
+
+// AvailableRange returns the range of media the file holds.
+func (i Info) AvailableRange() opentime.TimeRange {
+	return opentime.NewTimeRange(i.StartTime, i.Duration)
+}
+
+// Prober reads stream information from a local media file.
+type Prober interface {
+	Probe(ctx context.Context, path string) (Info, error)
+}
+
+// ProberFunc adapts a function to the Prober interface.
+type ProberFunc func(ctx context.Context, path string) (Info, error)
+
+// Probe calls f.
+func (f ProberFunc) Probe(ctx context.Context, path string) (Info, error) {
+	return f(ctx, path)
+}
+
+// Config holds configuration for PopulateAvailableRanges.
+type Config struct {
+	// Prober probes the media files. Defaults to FFProbe.
+	Prober Prober
+	// Overwrite replaces available ranges that are already set.
+	Overwrite bool
+}
+
+// Option is a functional option for PopulateAvailableRanges.
+type Option func(*Config)
+
+// WithProber sets the prober used to read media files.
+func WithProber(prober Prober) Option {
+	return func(c *Config) {
+		c.Prober = prober
+	}
+}
+
+// WithOverwrite sets whether available ranges that are already set are
+// replaced by the probed ones.
+func WithOverwrite(overwrite bool) Option {
+	return func(c *Config) {
+		c.Overwrite = overwrite
+	}
+}
+
+// PopulateAvailableRanges probes the local media of every external
+// reference in the timeline, including the inactive references of clips,
+// and sets their available ranges and mediainfo metadata. References that
+// already have an available range are skipped unless WithOverwrite is
+// set, and references to remote URLs are always skipped. Each file is
+// probed once however many references share it.
+//
+// Returns the number of references updated. Files that fail to probe do
+// not stop the others; their errors are joined in the returned error.
+func PopulateAvailableRanges(timeline *gotio.Timeline, opts ...Option) (int, error) {
+	cfg := Config{Prober: FFProbe{}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	type probe struct {
+		info Info
+		err  error
+	}
+	probes := make(map[string]probe)
+	var errs []error
+	updated := 0
+
+	for _, clip := range timeline.FindClips(nil, false) {
+		refs := clip.MediaReferences()
+		for _, key := range slices.Sorted(maps.Keys(refs)) {
+			ref, ok := refs[key].(*gotio.ExternalReference)
+			if !ok || ref.TargetURL() == "" || (ref.AvailableRange() != nil && !cfg.Overwrite) {
+				continue
+			}
+			path, err := mediaresolver.LocalPath(ref.TargetURL())
+			if err != nil {
+				continue
+			}
+
+			p, seen := probes[path]
+			if !seen {
+				p.info, p.err = cfg.Prober.Probe(context.Background(), path)
+				probes[path] = p
+				if p.err != nil {
+					errs = append(errs, fmt.Errorf("mediainfo: %s: %w", path, p.err))
+				}
+			}
+			if p.err != nil {
+				continue
+			}
+			Apply(ref, p.info)
+			updated++
+		}
+	}
+	return updated, errors.Join(errs...)
+}
+
+// Apply sets the reference's available range from info and stores the
+// rate, resolution and codec in its mediainfo metadata.
+func Apply(ref gotio.MediaReference, info Info) {
+	ar := info.AvailableRange()
+	ref.SetAvailableRange(&ar)
+
+	md := ref.Metadata()
+	if md == nil {
+		md = gotio.AnyDictionary{}
+		ref.SetMetadata(md)
+	}
+	values := gotio.AnyDictionary{"rate": info.Rate}
+	if info.Width > 0 && info.Height > 0 {
+		values["width"] = info.Width
+		values["height"] = info.Height
+	}
+	if info.Codec != "" {
+		values["codec"] = info.Codec
+	}
+	md[MetadataKey] = values
+}
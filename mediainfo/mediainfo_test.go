@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package mediainfo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+const ffprobeVideo = `{
+	"streams": [
+		{"codec_type": "audio", "codec_name": "pcm_s24le", "sample_rate": "48000", "duration": "2.0"},
+		{"codec_type": "video", "codec_name": "prores", "width": 1920, "height": 1080,
+		 "avg_frame_rate": "24000/1001", "r_frame_rate": "24000/1001", "nb_frames": "48",
+		 "tags": {"timecode": "01:00:00:00"}}
+	],
+	"format": {"duration": "2.002"}
+}`
+
+func TestParseFFProbe(t *testing.T) {
+	info, err := ParseFFProbe([]byte(ffprobeVideo))
+	if err != nil {
+		t.Fatalf("ParseFFProbe error: %v", err)
+	}
+	if math.Abs(info.Rate-23.976) > 0.001 || info.Width != 1920 || info.Height != 1080 || info.Codec != "prores" {
+		t.Errorf("unexpected info %+v", info)
+	}
+	if info.Duration.Value() != 48 {
+		t.Errorf("Duration = %v, want 48 frames", info.Duration)
+	}
+	if info.StartTime.Value() != 86400 {
+		t.Errorf("StartTime = %v, want 01:00:00:00", info.StartTime)
+	}
+
+	audio := `{"streams": [{"codec_type": "audio", "codec_name": "aac", "sample_rate": "48000"}], "format": {"duration": "1.5"}}`
+	info, err = ParseFFProbe([]byte(audio))
+	if err != nil {
+		t.Fatalf("ParseFFProbe error: %v", err)
+	}
+	if info.Rate != 48000 || info.Duration.Value() != 72000 || info.Width != 0 {
+		t.Errorf("unexpected audio info %+v", info)
+	}
+
+	if _, err := ParseFFProbe([]byte(`{"streams": []}`)); !errors.Is(err, ErrNoStreams) {
+		t.Errorf("expected ErrNoStreams, got %v", err)
+	}
+	if _, err := ParseFFProbe([]byte(`not json`)); err == nil {
+		t.Error("expected an error for invalid output")
+	}
+}
+
+func TestPopulateAvailableRanges(t *testing.T) {
+	ar := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(10, 24))
+	timeline := gotio.NewTimeline("test", nil, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	for _, url := range []string{"/media/a.mov", "file:///media/a.mov", "/media/broken.mov", "https://example.com/b.mov"} {
+		ref := gotio.NewExternalReference("", url, nil, nil)
+		track.AppendChild(gotio.NewClip(url, ref, &ar, nil, nil, nil, "", nil))
+	}
+	known := gotio.NewExternalReference("", "/media/known.mov", &ar, nil)
+	track.AppendChild(gotio.NewClip("known", known, &ar, nil, nil, nil, "", nil))
+	timeline.Tracks().AppendChild(track)
+
+	probed := map[string]int{}
+	prober := ProberFunc(func(ctx context.Context, path string) (Info, error) {
+		probed[path]++
+		if path == "/media/broken.mov" {
+			return Info{}, errors.New("unreadable")
+		}
+		return Info{
+			Rate:      24,
+			StartTime: opentime.NewRationalTime(86400, 24),
+			Duration:  opentime.NewRationalTime(100, 24),
+			Width:     3840,
+			Height:    2160,
+			Codec:     "prores",
+		}, nil
+	})
+
+	n, err := PopulateAvailableRanges(timeline, WithProber(prober))
+	if n != 2 {
+		t.Errorf("expected 2 references updated, got %d", n)
+	}
+	if err == nil {
+		t.Error("expected the broken file's error")
+	}
+	if probed["/media/a.mov"] != 1 || probed["/media/broken.mov"] != 1 || len(probed) != 2 {
+		t.Errorf("unexpected probes %v", probed)
+	}
+
+	clips := timeline.FindClips(nil, false)
+	got := clips[1].MediaReference().AvailableRange()
+	if got == nil || got.StartTime().Value() != 86400 || got.Duration().Value() != 100 {
+		t.Errorf("AvailableRange = %v", got)
+	}
+	if v, ok := clips[0].MediaReference().Metadata().Lookup("mediainfo.width"); !ok || v != 3840 {
+		t.Errorf("expected width metadata, got %v", v)
+	}
+	if clips[2].MediaReference().AvailableRange() != nil || clips[3].MediaReference().AvailableRange() != nil {
+		t.Error("expected broken and remote references to be left alone")
+	}
+	if known.AvailableRange().Duration().Value() != 10 {
+		t.Error("expected an existing available range to be kept")
+	}
+
+	if n, _ := PopulateAvailableRanges(timeline, WithProber(prober), WithOverwrite(true)); n != 3 {
+		t.Errorf("expected 3 references updated with overwrite, got %d", n)
+	}
+	if known.AvailableRange().Duration().Value() != 100 {
+		t.Error("expected overwrite to replace the available range")
+	}
+}
@@ -4,13 +4,47 @@
 package medialinker
 
 import (
-	"os"
+	"context"
 	"path/filepath"
 	"strings"
 
 	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/mediaresolver"
 )
 
+// LinkerConfig holds configuration for the built-in file linkers.
+type LinkerConfig struct {
+	// Resolver checks candidate paths. If nil, every check stats the file.
+	Resolver *mediaresolver.Resolver
+}
+
+// LinkerOption is a functional option for the built-in file linkers.
+type LinkerOption func(*LinkerConfig)
+
+// WithResolver sets the resolver used to check candidate paths. A caching
+// resolver saves statting the same paths again on every link.
+func WithResolver(resolver *mediaresolver.Resolver) LinkerOption {
+	return func(c *LinkerConfig) {
+		c.Resolver = resolver
+	}
+}
+
+func newLinkerConfig(opts []LinkerOption) LinkerConfig {
+	var cfg LinkerConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.Resolver == nil {
+		cfg.Resolver = mediaresolver.New(mediaresolver.WithCacheSize(0))
+	}
+	return cfg
+}
+
+// exists reports whether path names media the resolver can find.
+func (c *LinkerConfig) exists(path string) bool {
+	return c.Resolver.Exists(context.Background(), path)
+}
+
 // PathTemplateLinker resolves media paths using a template.
 // The template can contain placeholders:
 //   - {name} - clip name
@@ -19,11 +53,12 @@ import (
 //   - {dir} - original file directory
 type PathTemplateLinker struct {
 	template string
+	config   LinkerConfig
 }
 
 // NewPathTemplateLinker creates a new PathTemplateLinker.
-func NewPathTemplateLinker(template string) *PathTemplateLinker {
-	return &PathTemplateLinker{template: template}
+func NewPathTemplateLinker(template string, opts ...LinkerOption) *PathTemplateLinker {
+	return &PathTemplateLinker{template: template, config: newLinkerConfig(opts)}
 }
 
 // Name returns the linker name.
@@ -74,7 +109,7 @@ func (l *PathTemplateLinker) LinkMediaReference(
 	path = strings.ReplaceAll(path, "{dir}", originalDir)
 
 	// Check if file exists
-	if _, err := os.Stat(path); err != nil {
+	if !l.config.exists(path) {
 		return nil, nil // File doesn't exist, leave reference unchanged
 	}
 
@@ -91,13 +126,15 @@ func (l *PathTemplateLinker) LinkMediaReference(
 type DirectoryLinker struct {
 	searchPaths []string
 	extensions  []string
+	config      LinkerConfig
 }
 
 // NewDirectoryLinker creates a new DirectoryLinker.
-func NewDirectoryLinker(searchPaths, extensions []string) *DirectoryLinker {
+func NewDirectoryLinker(searchPaths, extensions []string, opts ...LinkerOption) *DirectoryLinker {
 	return &DirectoryLinker{
 		searchPaths: searchPaths,
 		extensions:  extensions,
+		config:      newLinkerConfig(opts),
 	}
 }
 
@@ -136,7 +173,7 @@ func (l *DirectoryLinker) LinkMediaReference(
 	for _, dir := range l.searchPaths {
 		for _, ext := range l.extensions {
 			path := filepath.Join(dir, searchName+ext)
-			if _, err := os.Stat(path); err == nil {
+			if l.config.exists(path) {
 				return gotio.NewExternalReference(
 					ref.Name(),
 					path,
@@ -148,7 +185,7 @@ func (l *DirectoryLinker) LinkMediaReference(
 
 		// Also try without extension modification
 		path := filepath.Join(dir, searchName)
-		if _, err := os.Stat(path); err == nil {
+		if l.config.exists(path) {
 			return gotio.NewExternalReference(
 				ref.Name(),
 				path,
@@ -4,7 +4,10 @@
 package medialinker
 
 import (
+	"log/slog"
+
 	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/metrics"
 )
 
 // LinkConfig holds configuration for the linking operation.
@@ -13,6 +16,9 @@ type LinkConfig struct {
 	ContinueOnError bool
 	// Args are passed to the linker for each clip.
 	Args map[string]any
+	// Logger, if set, receives an event for each clip relinked, left
+	// alone or failed.
+	Logger *slog.Logger
 }
 
 // LinkOption is a functional option for LinkMedia.
@@ -32,6 +38,13 @@ func WithArgs(args map[string]any) LinkOption {
 	}
 }
 
+// WithLogger sets the logger linking reports each clip to.
+func WithLogger(logger *slog.Logger) LinkOption {
+	return func(c *LinkConfig) {
+		c.Logger = logger
+	}
+}
+
 // LinkMedia applies a media linker to all clips in a timeline.
 // Uses the named linker from the global registry.
 func LinkMedia(
@@ -72,6 +85,10 @@ func LinkMediaWithLinker(
 	for _, opt := range opts {
 		opt(config)
 	}
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
 
 	// Find all clips
 	clips := timeline.FindClips(nil, false)
@@ -87,6 +104,7 @@ func LinkMediaWithLinker(
 				Message:    "linking failed",
 				Cause:      err,
 			}
+			logger.Warn("linking failed", "linker", linker.Name(), "clip", clip.Name(), "error", err)
 			if !config.ContinueOnError {
 				return lastError
 			}
@@ -94,11 +112,17 @@ func LinkMediaWithLinker(
 		}
 
 		// Only update if a new reference was returned
-		if newRef != nil {
-			clip.SetMediaReference(newRef)
+		if newRef == nil {
+			logger.Debug("clip not relinked", "linker", linker.Name(), "clip", clip.Name())
+			continue
 		}
+		from, _ := targetURL(clip.MediaReference())
+		to, _ := targetURL(newRef)
+		logger.Info("clip relinked", "linker", linker.Name(), "clip", clip.Name(), "from", from, "to", to)
+		clip.SetMediaReference(newRef)
 	}
 
+	metrics.Add(metrics.ClipsProcessed, float64(len(clips)), metrics.Operation("link_media"))
 	return lastError
 }
 
@@ -10,6 +10,10 @@ import (
 	"github.com/Avalanche-io/gotio"
 )
 
+func init() {
+	gotio.RegisterFeature("medialinker")
+}
+
 // MediaLinker resolves media references for clips.
 // Implementations can use various strategies to find media files:
 // - Path templates (e.g., "/media/{name}.mov")
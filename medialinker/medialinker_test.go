@@ -4,12 +4,17 @@
 package medialinker
 
 import (
+	"bytes"
+	"errors"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Avalanche-io/gotio/opentime"
 	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/mediaresolver"
 )
 
 func createTestClip(name string, url string) *gotio.Clip {
@@ -141,6 +146,29 @@ func TestLinkMediaWithLinker(t *testing.T) {
 	}
 }
 
+func TestLinkMediaLogger(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.mov"), []byte("media"), 0644)
+	found := createTestClip("a", "/offline/a.mov")
+	lost := createTestClip("b", "/offline/b.mov")
+	timeline := createTestTimeline(found, lost)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	linker := NewDirectoryLinker([]string{dir}, []string{".mov"})
+	if err := LinkMediaWithLinker(timeline, linker, WithLogger(logger)); err != nil {
+		t.Fatalf("LinkMediaWithLinker failed: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "clip relinked") || !strings.Contains(out, "from=/offline/a.mov") {
+		t.Errorf("expected a relink event for a, got %q", out)
+	}
+	if !strings.Contains(out, "clip not relinked") || !strings.Contains(out, "clip=b") {
+		t.Errorf("expected an event for b, got %q", out)
+	}
+}
+
 func TestLinkClip(t *testing.T) {
 	clip := createTestClip("test", "")
 	clip.SetMediaReference(gotio.NewMissingReference("", nil, nil))
@@ -255,6 +283,35 @@ func TestDirectoryLinker(t *testing.T) {
 	}
 }
 
+func TestDirectoryLinkerWithResolver(t *testing.T) {
+	mediaDir := t.TempDir()
+	testFile := filepath.Join(mediaDir, "test_clip.mxf")
+	if err := os.WriteFile(testFile, []byte{}, 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	resolver := mediaresolver.New()
+	linker := NewDirectoryLinker(
+		[]string{mediaDir},
+		[]string{".mov", ".mxf"},
+		WithResolver(resolver),
+	)
+
+	for range 2 {
+		ref, err := linker.LinkMediaReference(createTestClip("test_clip", "/original/test_clip.mov"), nil)
+		if err != nil {
+			t.Fatalf("LinkMediaReference failed: %v", err)
+		}
+		if ref == nil || ref.(*gotio.ExternalReference).TargetURL() != testFile {
+			t.Fatalf("expected %s, got %v", testFile, ref)
+		}
+	}
+	// The .mov miss and the .mxf hit are statted once each.
+	if lookups, hits := resolver.Stats(); lookups != 2 || hits != 2 {
+		t.Errorf("expected 2 lookups and 2 cache hits, got %d and %d", lookups, hits)
+	}
+}
+
 func TestLinkMediaContinueOnError(t *testing.T) {
 	// Create timeline with multiple clips
 	clip1 := createTestClip("clip1", "")
@@ -670,3 +727,72 @@ func TestLinkMediaDefaultNoDefault(t *testing.T) {
 		t.Error("expected error when no default set")
 	}
 }
+
+func TestExpandURL(t *testing.T) {
+	vars := map[string]string{"shot": "sh010", "clip": "plate_v001"}
+	got, err := ExpandURL("file:///shots/{shot}/plates/{clip}.%04d.exr", vars)
+	if err != nil || got != "file:///shots/sh010/plates/plate_v001.%04d.exr" {
+		t.Errorf("ExpandURL = %q, %v", got, err)
+	}
+	if _, err := ExpandURL("file:///shots/{shot}/{seq}/{take}", vars); !errors.Is(err, ErrUnresolvedToken) {
+		t.Errorf("expected ErrUnresolvedToken, got %v", err)
+	}
+}
+
+func TestTokenizeURL(t *testing.T) {
+	vars := map[string]string{"shot": "010", "show": "demo", "root": "/mnt/projects/demo"}
+	tests := map[string]string{
+		"file:///mnt/projects/demo/010/sh0101/010_comp.exr": "file://{root}/{shot}/sh0101/{shot}_comp.exr",
+		"file:///other/path.mov":                            "file:///other/path.mov",
+	}
+	for url, want := range tests {
+		if got := TokenizeURL(url, vars); got != want {
+			t.Errorf("TokenizeURL(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+func TestExpandAndTokenizeURLs(t *testing.T) {
+	template := "file:///shots/{shot}/plates/{clip}.%04d.exr"
+	clip := createTestClip("plate", template)
+	plain := createTestClip("plain", "file:///media/plain.mov")
+	timeline := createTestTimeline(clip, plain)
+
+	if err := ExpandURLs(timeline, map[string]string{"shot": "sh010"}); err != nil {
+		t.Fatalf("ExpandURLs error: %v", err)
+	}
+	ref := clip.MediaReference().(*gotio.ExternalReference)
+	if ref.TargetURL() != "file:///shots/sh010/plates/plate.%04d.exr" {
+		t.Errorf("expanded URL = %q", ref.TargetURL())
+	}
+	if got, _ := ref.Metadata().GetString(URLTemplateMetadataKey); got != template {
+		t.Errorf("template in metadata = %q", got)
+	}
+	if _, ok := plain.MediaReference().Metadata()[URLTemplateMetadataKey]; ok {
+		t.Error("URL without tokens was given a template")
+	}
+
+	// Expanding again for another site uses the stored template.
+	if err := ExpandURLs(timeline, map[string]string{"shot": "sh020"}); err != nil {
+		t.Fatalf("ExpandURLs error: %v", err)
+	}
+	if ref.TargetURL() != "file:///shots/sh020/plates/plate.%04d.exr" {
+		t.Errorf("re-expanded URL = %q", ref.TargetURL())
+	}
+	if err := ExpandURLs(timeline, nil); !errors.Is(err, ErrUnresolvedToken) {
+		t.Errorf("expected ErrUnresolvedToken, got %v", err)
+	}
+
+	if n := TokenizeURLs(timeline, map[string]string{"media": "media"}); n != 2 {
+		t.Errorf("TokenizeURLs changed %d references, want 2", n)
+	}
+	if ref.TargetURL() != template {
+		t.Errorf("tokenized URL = %q, want %q", ref.TargetURL(), template)
+	}
+	if _, ok := ref.Metadata()[URLTemplateMetadataKey]; ok {
+		t.Error("TokenizeURLs left the template in metadata")
+	}
+	if got := plain.MediaReference().(*gotio.ExternalReference).TargetURL(); got != "file:///{media}/{clip}.mov" {
+		t.Errorf("tokenized plain URL = %q", got)
+	}
+}
@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package medialinker
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+// URLTemplateMetadataKey is the media reference metadata key holding the
+// template a target URL was expanded from.
+const URLTemplateMetadataKey = "url_template"
+
+// ErrUnresolvedToken is returned when a URL template uses a token with no
+// value.
+var ErrUnresolvedToken = errors.New("unresolved URL template token")
+
+// templateToken matches a {token} in a URL template. Other text, such as
+// a %04d frame pattern, is left alone.
+var templateToken = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ExpandURL replaces each {token} in template with its value in vars.
+func ExpandURL(template string, vars map[string]string) (string, error) {
+	var missing []string
+	url := templateToken.ReplaceAllStringFunc(template, func(token string) string {
+		name := token[1 : len(token)-1]
+		value, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+			return token
+		}
+		return value
+	})
+	if missing != nil {
+		return "", fmt.Errorf("%w: %s in %q", ErrUnresolvedToken, strings.Join(missing, ", "), template)
+	}
+	return url, nil
+}
+
+// TokenizeURL replaces the values of vars in url with their {token}, the
+// inverse of ExpandURL. A value is replaced only where it is bounded by
+// the start or end of url or by a character other than a letter or digit,
+// so a shot "010" does not match inside "sh0101". Longer values are
+// replaced first.
+func TokenizeURL(url string, vars map[string]string) string {
+	names := make([]string, 0, len(vars))
+	for name, value := range vars {
+		if value != "" {
+			names = append(names, name)
+		}
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		if d := len(vars[b]) - len(vars[a]); d != 0 {
+			return d
+		}
+		return strings.Compare(a, b)
+	})
+	for _, name := range names {
+		url = replaceBounded(url, vars[name], "{"+name+"}")
+	}
+	return url
+}
+
+// replaceBounded replaces the occurrences of old in s that are not part of
+// a longer run of letters and digits, and not inside an existing token.
+func replaceBounded(s, old, replacement string) string {
+	var b strings.Builder
+	for i := 0; ; {
+		j := strings.Index(s[i:], old)
+		if j < 0 {
+			b.WriteString(s[i:])
+			return b.String()
+		}
+		j += i
+		end := j + len(old)
+		if isBoundary(s, j-1) && isBoundary(s, end) && !inToken(s, j) {
+			b.WriteString(s[i:j])
+			b.WriteString(replacement)
+		} else {
+			b.WriteString(s[i:end])
+		}
+		i = end
+	}
+}
+
+func isBoundary(s string, i int) bool {
+	if i < 0 || i >= len(s) {
+		return true
+	}
+	c := s[i]
+	return !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9')
+}
+
+// inToken reports whether position i of s is inside a {token}.
+func inToken(s string, i int) bool {
+	open := strings.LastIndexByte(s[:i], '{')
+	return open >= 0 && strings.IndexByte(s[open:i], '}') < 0 && strings.IndexByte(s[i:], '}') >= 0
+}
+
+// ExpandURLs expands the target URLs of the external and image sequence
+// references in the timeline. The template of a reference is the one in
+// its metadata under URLTemplateMetadataKey, or else its target URL if
+// that contains a token; the template is kept in the metadata so the
+// reference can be expanded again for another site. References without
+// tokens are left alone.
+//
+// Besides vars, each clip's references can use {clip} for the clip name
+// and {track} for the name of the track holding it, unless vars sets them.
+func ExpandURLs(timeline *gotio.Timeline, vars map[string]string) error {
+	for _, clip := range timeline.FindClips(nil, false) {
+		clipVars := templateVars(clip, vars)
+		refs := clip.MediaReferences()
+		for _, key := range clip.MediaReferenceKeys() {
+			url, ok := targetURL(refs[key])
+			if !ok {
+				continue
+			}
+			template, _ := refs[key].Metadata().GetString(URLTemplateMetadataKey)
+			if template == "" {
+				if !templateToken.MatchString(url) {
+					continue
+				}
+				template = url
+			}
+			expanded, err := ExpandURL(template, clipVars)
+			if err != nil {
+				return fmt.Errorf("clip %q: %w", clip.Name(), err)
+			}
+			md := refs[key].Metadata()
+			if md == nil {
+				md = gotio.AnyDictionary{}
+				refs[key].SetMetadata(md)
+			}
+			md[URLTemplateMetadataKey] = template
+			setTargetURL(refs[key], expanded)
+		}
+	}
+	return nil
+}
+
+// TokenizeURLs turns the target URLs of the external and image sequence
+// references in the timeline back into templates, the inverse of
+// ExpandURLs. A reference with a template in its metadata gets that
+// template back and the metadata entry is removed; otherwise TokenizeURL
+// replaces the values of vars, and the clip and track names, in its URL.
+// Returns the number of references changed.
+func TokenizeURLs(timeline *gotio.Timeline, vars map[string]string) int {
+	changed := 0
+	for _, clip := range timeline.FindClips(nil, false) {
+		clipVars := templateVars(clip, vars)
+		refs := clip.MediaReferences()
+		for _, key := range clip.MediaReferenceKeys() {
+			url, ok := targetURL(refs[key])
+			if !ok {
+				continue
+			}
+			template, _ := refs[key].Metadata().GetString(URLTemplateMetadataKey)
+			if template != "" {
+				delete(refs[key].Metadata(), URLTemplateMetadataKey)
+			} else {
+				template = TokenizeURL(url, clipVars)
+			}
+			if template != url {
+				setTargetURL(refs[key], template)
+				changed++
+			}
+		}
+	}
+	return changed
+}
+
+// templateVars returns vars with the clip and track names of clip added.
+func templateVars(clip *gotio.Clip, vars map[string]string) map[string]string {
+	clipVars := make(map[string]string, len(vars)+2)
+	clipVars["clip"] = clip.Name()
+	if parent := clip.Parent(); parent != nil {
+		clipVars["track"] = parent.Name()
+	}
+	maps.Copy(clipVars, vars)
+	return clipVars
+}
+
+func targetURL(ref gotio.MediaReference) (string, bool) {
+	switch r := ref.(type) {
+	case *gotio.ExternalReference:
+		return r.TargetURL(), true
+	case *gotio.ImageSequenceReference:
+		return r.TargetURLBase(), true
+	}
+	return "", false
+}
+
+func setTargetURL(ref gotio.MediaReference, url string) {
+	switch r := ref.(type) {
+	case *gotio.ExternalReference:
+		r.SetTargetURL(url)
+	case *gotio.ImageSequenceReference:
+		r.SetTargetURLBase(url)
+	}
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package mediaresolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// LocalBackend stats local paths and file URLs. Directories do not count
+// as media.
+type LocalBackend struct{}
+
+// Resolve stats the file at rawURL.
+func (LocalBackend) Resolve(ctx context.Context, rawURL string) (Info, error) {
+	path, err := LocalPath(rawURL)
+	if err != nil {
+		return Info{}, err
+	}
+	stat, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Info{Size: -1}, nil
+	}
+	if err != nil {
+		return Info{}, err
+	}
+	if stat.IsDir() {
+		return Info{Size: -1}, nil
+	}
+	return Info{Exists: true, Size: stat.Size(), ModTime: stat.ModTime()}, nil
+}
+
+// LocalPath returns the file system path of a plain path or file URL.
+func LocalPath(rawURL string) (string, error) {
+	if isWindowsPath(rawURL) {
+		return rawURL, nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL, nil
+	}
+	switch u.Scheme {
+	case "":
+		return rawURL, nil
+	case "file":
+		path := u.Path
+		// file:///C:/media keeps a leading slash before the drive letter.
+		if len(path) > 2 && path[0] == '/' && isWindowsPath(path[1:]) {
+			path = path[1:]
+		}
+		return path, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedScheme, u.Scheme)
+	}
+}
+
+// HTTPBackend checks http and https URLs with a HEAD request. Responses of
+// 404 Not Found and 410 Gone report missing media; other failures are
+// errors.
+type HTTPBackend struct {
+	// Client sends the requests. Nil uses http.DefaultClient.
+	Client *http.Client
+}
+
+// Resolve sends a HEAD request for rawURL.
+func (b *HTTPBackend) Resolve(ctx context.Context, rawURL string) (Info, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, rawURL, nil)
+	if err != nil {
+		return Info{}, err
+	}
+	client := b.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Info{}, err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return Info{Size: -1}, nil
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return Info{}, fmt.Errorf("mediaresolver: HEAD %s: %s", rawURL, resp.Status)
+	}
+	info := Info{Exists: true, Size: resp.ContentLength}
+	if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.ModTime = modified
+	}
+	return info, nil
+}
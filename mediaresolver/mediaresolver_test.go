@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package mediaresolver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func TestResolveLocal(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "shot.mov")
+	if err := os.WriteFile(path, []byte("media"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	r := New()
+
+	for _, rawURL := range []string{path, "file://" + path} {
+		info, err := r.Resolve(ctx, rawURL)
+		if err != nil {
+			t.Fatalf("Resolve(%q) error: %v", rawURL, err)
+		}
+		if !info.Exists || info.Size != 5 || info.URL != rawURL {
+			t.Errorf("Resolve(%q) = %+v", rawURL, info)
+		}
+	}
+
+	for _, rawURL := range []string{filepath.Join(dir, "missing.mov"), dir} {
+		info, err := r.Resolve(ctx, rawURL)
+		if err != nil {
+			t.Fatalf("Resolve(%q) error: %v", rawURL, err)
+		}
+		if info.Exists {
+			t.Errorf("expected %q to be missing", rawURL)
+		}
+	}
+
+	if _, err := r.Resolve(ctx, "s3://bucket/shot.mov"); !errors.Is(err, ErrUnsupportedScheme) {
+		t.Errorf("expected ErrUnsupportedScheme, got %v", err)
+	}
+}
+
+func TestResolverCache(t *testing.T) {
+	calls := 0
+	backend := BackendFunc(func(ctx context.Context, rawURL string) (Info, error) {
+		calls++
+		ar := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(48, 24))
+		return Info{Exists: true, Size: 10, AvailableRange: &ar}, nil
+	})
+	ctx := context.Background()
+	r := New(WithBackend("asset", backend), WithCacheSize(2))
+
+	for range 3 {
+		info, err := r.Resolve(ctx, "asset://a")
+		if err != nil || !info.Exists || info.AvailableRange == nil {
+			t.Fatalf("Resolve = %+v, %v", info, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 backend call, got %d", calls)
+	}
+	if lookups, hits := r.Stats(); lookups != 1 || hits != 2 {
+		t.Errorf("Stats = %d, %d", lookups, hits)
+	}
+
+	// a is the least recently used once b and c are added.
+	r.Resolve(ctx, "asset://b")
+	r.Resolve(ctx, "asset://c")
+	r.Resolve(ctx, "asset://a")
+	if calls != 4 {
+		t.Errorf("expected a to be evicted, got %d calls", calls)
+	}
+
+	r.Invalidate("asset://a")
+	r.Resolve(ctx, "asset://a")
+	if calls != 5 {
+		t.Errorf("expected Invalidate to drop the result, got %d calls", calls)
+	}
+
+	now := time.Now()
+	r = New(WithBackend("asset", backend), WithTTL(time.Minute))
+	r.now = func() time.Time { return now }
+	r.Resolve(ctx, "asset://a")
+	now = now.Add(2 * time.Minute)
+	r.Resolve(ctx, "asset://a")
+	if calls != 7 {
+		t.Errorf("expected expired result to be resolved again, got %d calls", calls)
+	}
+
+	r = New(WithBackend("asset", backend), WithCacheSize(0))
+	r.Resolve(ctx, "asset://a")
+	r.Resolve(ctx, "asset://a")
+	if calls != 9 {
+		t.Errorf("expected no caching, got %d calls", calls)
+	}
+}
+
+func TestResolveHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodHead {
+			t.Errorf("expected HEAD, got %s", req.Method)
+		}
+		switch req.URL.Path {
+		case "/shot.mov":
+			w.Header().Set("Content-Length", "1234")
+			w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		case "/forbidden.mov":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	r := New()
+
+	info, err := r.Resolve(ctx, server.URL+"/shot.mov")
+	if err != nil {
+		t.Fatalf("Resolve error: %v", err)
+	}
+	if !info.Exists || info.Size != 1234 || info.ModTime.Year() != 2006 {
+		t.Errorf("Resolve = %+v", info)
+	}
+	if r.Exists(ctx, server.URL+"/missing.mov") {
+		t.Error("expected 404 to report missing media")
+	}
+	if _, err := r.Resolve(ctx, server.URL+"/forbidden.mov"); err == nil {
+		t.Error("expected an error for 403")
+	}
+}
@@ -0,0 +1,265 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package mediaresolver looks up the media behind ExternalReference URLs:
+// whether it exists, its size, and its available range when a backend can
+// tell. Lookups are made lazily, on first request, and kept in an LRU cache
+// so that operations touching the same media many times stat it once.
+//
+// Local paths and file URLs are statted, http and https URLs are checked
+// with a HEAD request, and any other scheme can be served by a custom
+// backend:
+//
+//	resolver := mediaresolver.New(
+//		mediaresolver.WithBackend("s3", mediaresolver.BackendFunc(lookupS3)),
+//	)
+//	info, err := resolver.Resolve(ctx, ref.TargetURL())
+//	if err == nil && !info.Exists {
+//		log.Printf("missing: %s", info.URL)
+//	}
+//
+// A Resolver is safe for concurrent use. Results are cached until evicted,
+// invalidated, or older than the configured TTL.
+package mediaresolver
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func init() {
+	gotio.RegisterFeature("mediaresolver")
+}
+
+// ErrUnsupportedScheme is returned for URLs with a scheme no backend serves.
+var ErrUnsupportedScheme = errors.New("mediaresolver: unsupported URL scheme")
+
+// DefaultCacheSize is the number of results a Resolver keeps by default.
+const DefaultCacheSize = 4096
+
+// Info describes the media behind a URL.
+type Info struct {
+	// URL is the URL that was resolved.
+	URL string
+	// Exists reports whether the media was found.
+	Exists bool
+	// Size is the size of the media in bytes, or -1 if unknown.
+	Size int64
+	// ModTime is the modification time of the media, if known.
+	ModTime time.Time
+	// AvailableRange is the media's available range, if the backend knows it.
+	AvailableRange *opentime.TimeRange
+}
+
+// Backend resolves URLs of the schemes it is registered for. Media that
+// does not exist is reported with Info.Exists false, not an error; errors
+// are for lookups that could not be made and are not cached.
+type Backend interface {
+	Resolve(ctx context.Context, rawURL string) (Info, error)
+}
+
+// BackendFunc adapts a function to the Backend interface.
+type BackendFunc func(ctx context.Context, rawURL string) (Info, error)
+
+// Resolve calls f.
+func (f BackendFunc) Resolve(ctx context.Context, rawURL string) (Info, error) {
+	return f(ctx, rawURL)
+}
+
+// Config holds configuration for a Resolver.
+type Config struct {
+	// CacheSize is the number of results kept. Zero disables caching.
+	CacheSize int
+	// TTL is how long results stay valid. Zero keeps them until evicted.
+	TTL time.Duration
+	// Backends maps URL schemes to backends. The empty scheme is used for
+	// plain paths.
+	Backends map[string]Backend
+}
+
+// Option is a functional option for New.
+type Option func(*Config)
+
+// WithCacheSize sets the number of results kept. Zero disables caching.
+func WithCacheSize(size int) Option {
+	return func(c *Config) {
+		c.CacheSize = size
+	}
+}
+
+// WithTTL sets how long results stay valid.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *Config) {
+		c.TTL = ttl
+	}
+}
+
+// WithBackend serves URLs of the given scheme with backend, replacing any
+// backend already set for it. Use "" for plain paths.
+func WithBackend(scheme string, backend Backend) Option {
+	return func(c *Config) {
+		c.Backends[strings.ToLower(scheme)] = backend
+	}
+}
+
+// Resolver resolves media URLs through its backends and caches the results.
+type Resolver struct {
+	mu        sync.Mutex
+	cfg       Config
+	entries   map[string]*list.Element
+	lru       *list.List
+	now       func() time.Time
+	lookups   int
+	cacheHits int
+}
+
+type entry struct {
+	info     Info
+	resolved time.Time
+}
+
+// New creates a Resolver. By default it caches DefaultCacheSize results
+// with no TTL, stats local paths and file URLs, and sends HEAD requests
+// for http and https URLs.
+func New(opts ...Option) *Resolver {
+	cfg := Config{
+		CacheSize: DefaultCacheSize,
+		Backends: map[string]Backend{
+			"":      LocalBackend{},
+			"file":  LocalBackend{},
+			"http":  &HTTPBackend{},
+			"https": &HTTPBackend{},
+		},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Resolver{
+		cfg:     cfg,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+// Resolve returns information about the media at rawURL, from the cache
+// if a valid result is held.
+func (r *Resolver) Resolve(ctx context.Context, rawURL string) (Info, error) {
+	if info, ok := r.cached(rawURL); ok {
+		return info, nil
+	}
+
+	backend, err := r.backend(rawURL)
+	if err != nil {
+		return Info{URL: rawURL, Size: -1}, err
+	}
+	info, err := backend.Resolve(ctx, rawURL)
+	if err != nil {
+		return Info{URL: rawURL, Size: -1}, err
+	}
+	info.URL = rawURL
+	r.store(rawURL, info)
+	return info, nil
+}
+
+// Exists reports whether the media at rawURL exists. Lookup errors count
+// as missing.
+func (r *Resolver) Exists(ctx context.Context, rawURL string) bool {
+	info, err := r.Resolve(ctx, rawURL)
+	return err == nil && info.Exists
+}
+
+// Invalidate drops the cached result for rawURL.
+func (r *Resolver) Invalidate(rawURL string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if el, ok := r.entries[rawURL]; ok {
+		r.lru.Remove(el)
+		delete(r.entries, rawURL)
+	}
+}
+
+// Clear drops every cached result.
+func (r *Resolver) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = make(map[string]*list.Element)
+	r.lru.Init()
+}
+
+// Stats returns the number of lookups made through backends and the
+// number served from the cache.
+func (r *Resolver) Stats() (lookups, cacheHits int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lookups, r.cacheHits
+}
+
+func (r *Resolver) cached(rawURL string) (Info, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	el, ok := r.entries[rawURL]
+	if !ok {
+		return Info{}, false
+	}
+	e := el.Value.(*entry)
+	if r.cfg.TTL > 0 && r.now().Sub(e.resolved) > r.cfg.TTL {
+		r.lru.Remove(el)
+		delete(r.entries, rawURL)
+		return Info{}, false
+	}
+	r.lru.MoveToFront(el)
+	r.cacheHits++
+	return e.info, true
+}
+
+func (r *Resolver) store(rawURL string, info Info) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups++
+	if r.cfg.CacheSize <= 0 {
+		return
+	}
+	if el, ok := r.entries[rawURL]; ok {
+		el.Value = &entry{info: info, resolved: r.now()}
+		r.lru.MoveToFront(el)
+		return
+	}
+	r.entries[rawURL] = r.lru.PushFront(&entry{info: info, resolved: r.now()})
+	for r.lru.Len() > r.cfg.CacheSize {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.entries, oldest.Value.(*entry).info.URL)
+	}
+}
+
+// backend returns the backend for the URL's scheme.
+func (r *Resolver) backend(rawURL string) (Backend, error) {
+	scheme := ""
+	if !isWindowsPath(rawURL) {
+		if u, err := url.Parse(rawURL); err == nil {
+			scheme = strings.ToLower(u.Scheme)
+		}
+	}
+	backend, ok := r.cfg.Backends[scheme]
+	if !ok || backend == nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedScheme, scheme)
+	}
+	return backend, nil
+}
+
+// isWindowsPath reports whether path starts with a drive letter, which
+// would otherwise parse as a URL scheme.
+func isWindowsPath(path string) bool {
+	return len(path) >= 2 && path[1] == ':' &&
+		(path[0] >= 'a' && path[0] <= 'z' || path[0] >= 'A' && path[0] <= 'Z')
+}
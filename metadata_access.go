@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MergePolicy determines how MergeMetadata combines nested dictionaries.
+type MergePolicy int
+
+const (
+	// MergeReplace replaces each top-level key with the merged value.
+	MergeReplace MergePolicy = iota
+	// MergeDeep merges nested dictionaries key by key; other values are
+	// replaced.
+	MergeDeep
+	// MergeErrorOnConflict merges like MergeDeep but fails with
+	// ErrMetadataConflict when a key holds different values on both sides.
+	MergeErrorOnConflict
+)
+
+// String returns the string representation of a MergePolicy.
+func (p MergePolicy) String() string {
+	switch p {
+	case MergeReplace:
+		return "Replace"
+	case MergeDeep:
+		return "Deep"
+	case MergeErrorOnConflict:
+		return "ErrorOnConflict"
+	default:
+		return fmt.Sprintf("MergePolicy(%d)", p)
+	}
+}
+
+// DeepCopy returns a copy of the dictionary sharing no nested dictionaries
+// or lists with it.
+func (d AnyDictionary) DeepCopy() AnyDictionary {
+	if d == nil {
+		return nil
+	}
+	return deepCopyValue(d).(AnyDictionary)
+}
+
+func deepCopyValue(value any) any {
+	switch v := value.(type) {
+	case AnyDictionary:
+		c := make(AnyDictionary, len(v))
+		for key, child := range v {
+			c[key] = deepCopyValue(child)
+		}
+		return c
+	case map[string]any:
+		return deepCopyValue(AnyDictionary(v))
+	case []any:
+		c := make([]any, len(v))
+		for i, child := range v {
+			c[i] = deepCopyValue(child)
+		}
+		return c
+	}
+	return value
+}
+
+// WithValue returns a copy of the dictionary with value stored at a
+// dot-separated path such as "a.b.c", creating nested dictionaries as
+// needed. Only the dictionaries along the path are copied; d is not
+// modified. Returns a TypeMismatchError if the path passes through a value
+// that is not a dictionary.
+func (d AnyDictionary) WithValue(path string, value any) (AnyDictionary, error) {
+	keys := strings.Split(path, ".")
+	result := CloneAnyDictionary(d)
+	if result == nil {
+		result = make(AnyDictionary)
+	}
+	current := result
+	for i, key := range keys[:len(keys)-1] {
+		next := AnyDictionary{}
+		if existing, ok := current[key]; ok && existing != nil {
+			m, ok := asDictionary(existing)
+			if !ok {
+				return nil, &TypeMismatchError{Expected: "dictionary at " + strings.Join(keys[:i+1], "."), Got: fmt.Sprintf("%T", existing)}
+			}
+			next = CloneAnyDictionary(m)
+		}
+		current[key] = next
+		current = next
+	}
+	current[keys[len(keys)-1]] = value
+	return result, nil
+}
+
+// Merged returns a copy of the dictionary with other merged into it under
+// policy. Values taken from other are deep copies; neither dictionary is
+// modified.
+func (d AnyDictionary) Merged(other AnyDictionary, policy MergePolicy) (AnyDictionary, error) {
+	result := CloneAnyDictionary(d)
+	if result == nil {
+		result = make(AnyDictionary)
+	}
+	if err := mergeInto(result, other, policy, ""); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// mergeInto merges other into dst, a fresh copy that may be modified, at
+// the dot-separated prefix.
+func mergeInto(dst, other AnyDictionary, policy MergePolicy, prefix string) error {
+	for key, value := range other {
+		existing, ok := dst[key]
+		if !ok || policy == MergeReplace {
+			dst[key] = deepCopyValue(value)
+			continue
+		}
+		a, aIsDict := asDictionary(existing)
+		b, bIsDict := asDictionary(value)
+		if aIsDict && bIsDict {
+			merged := CloneAnyDictionary(a)
+			if err := mergeInto(merged, b, policy, prefix+key+"."); err != nil {
+				return err
+			}
+			dst[key] = merged
+			continue
+		}
+		if policy == MergeErrorOnConflict && !reflect.DeepEqual(existing, value) {
+			return fmt.Errorf("%w at %s: %v and %v", ErrMetadataConflict, prefix+key, existing, value)
+		}
+		dst[key] = deepCopyValue(value)
+	}
+	return nil
+}
+
+// MetadataValue returns the metadata value at a dot-separated path such as
+// "a.b.c".
+func (s *SerializableObjectWithMetadataBase) MetadataValue(path string) (any, bool) {
+	return s.metadata.Lookup(path)
+}
+
+// MetadataCopy returns a deep copy of the metadata, which the caller may
+// keep or change without affecting the object.
+func (s *SerializableObjectWithMetadataBase) MetadataCopy() AnyDictionary {
+	return s.metadata.DeepCopy()
+}
+
+// SetMetadataValue stores value at a dot-separated path of the metadata,
+// creating nested dictionaries as needed.
+//
+// The change is copy on write: the object gets a new metadata dictionary
+// and the dictionaries returned earlier by Metadata are left untouched, so
+// a reader or serializer holding one never sees a partial change. Writers
+// still need to be serialized with each other.
+func (s *SerializableObjectWithMetadataBase) SetMetadataValue(path string, value any) error {
+	metadata, err := s.metadata.WithValue(path, value)
+	if err != nil {
+		return err
+	}
+	s.metadata = metadata
+	return nil
+}
+
+// MergeMetadata merges metadata into the object's metadata under policy,
+// copy on write as for SetMetadataValue. On error the metadata is
+// unchanged.
+func (s *SerializableObjectWithMetadataBase) MergeMetadata(metadata AnyDictionary, policy MergePolicy) error {
+	merged, err := s.metadata.Merged(metadata, policy)
+	if err != nil {
+		return err
+	}
+	s.metadata = merged
+	return nil
+}
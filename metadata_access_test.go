@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetMetadataValue(t *testing.T) {
+	clip := NewClip("A", nil, nil, AnyDictionary{"vfx": AnyDictionary{"shot": "010"}, "note": "x"}, nil, nil, "", nil)
+	clone := clip.Clone().(*Clip)
+	before := clip.Metadata()
+
+	if err := clip.SetMetadataValue("vfx.shot", "020"); err != nil {
+		t.Fatalf("SetMetadataValue error: %v", err)
+	}
+	if err := clip.SetMetadataValue("vfx.plate.take", 3); err != nil {
+		t.Fatalf("SetMetadataValue error: %v", err)
+	}
+	if v, ok := clip.MetadataValue("vfx.shot"); !ok || v != "020" {
+		t.Errorf("vfx.shot = %v, %v", v, ok)
+	}
+	if v, _ := clip.MetadataValue("vfx.plate.take"); v != 3 {
+		t.Errorf("vfx.plate.take = %v", v)
+	}
+	if v, _ := before.Lookup("vfx.shot"); v != "010" {
+		t.Errorf("earlier snapshot changed to %v", v)
+	}
+	if v, _ := clone.MetadataValue("vfx.shot"); v != "010" {
+		t.Errorf("clone changed to %v", v)
+	}
+	if err := clip.SetMetadataValue("note.text", "y"); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("path through a string: got %v, want ErrTypeMismatch", err)
+	}
+
+	copied := clip.MetadataCopy()
+	copied["vfx"].(AnyDictionary)["shot"] = "999"
+	if v, _ := clip.MetadataValue("vfx.shot"); v != "020" {
+		t.Errorf("MetadataCopy shares nested dictionaries: %v", v)
+	}
+}
+
+func TestMergeMetadata(t *testing.T) {
+	base := func() *Clip {
+		return NewClip("A", nil, nil, AnyDictionary{"vfx": AnyDictionary{"shot": "010", "seq": "A"}}, nil, nil, "", nil)
+	}
+	update := AnyDictionary{"vfx": AnyDictionary{"shot": "020"}, "new": true}
+
+	clip := base()
+	if err := clip.MergeMetadata(update, MergeReplace); err != nil {
+		t.Fatalf("MergeReplace error: %v", err)
+	}
+	if _, ok := clip.MetadataValue("vfx.seq"); ok || clip.Metadata()["new"] != true {
+		t.Errorf("MergeReplace = %v", clip.Metadata())
+	}
+
+	clip = base()
+	if err := clip.MergeMetadata(update, MergeDeep); err != nil {
+		t.Fatalf("MergeDeep error: %v", err)
+	}
+	if shot, _ := clip.MetadataValue("vfx.shot"); shot != "020" {
+		t.Errorf("MergeDeep shot = %v", shot)
+	}
+	if seq, _ := clip.MetadataValue("vfx.seq"); seq != "A" {
+		t.Errorf("MergeDeep seq = %v", seq)
+	}
+	update["vfx"].(AnyDictionary)["shot"] = "030"
+	if shot, _ := clip.MetadataValue("vfx.shot"); shot != "020" {
+		t.Errorf("merged values share the source dictionary: %v", shot)
+	}
+
+	clip = base()
+	if err := clip.MergeMetadata(AnyDictionary{"vfx": AnyDictionary{"seq": "A", "cam": "B"}}, MergeErrorOnConflict); err != nil {
+		t.Fatalf("MergeErrorOnConflict without conflict error: %v", err)
+	}
+	if err := clip.MergeMetadata(AnyDictionary{"vfx": AnyDictionary{"shot": "020", "x": 1}}, MergeErrorOnConflict); !errors.Is(err, ErrMetadataConflict) {
+		t.Errorf("conflict: got %v, want ErrMetadataConflict", err)
+	}
+	if _, ok := clip.MetadataValue("vfx.x"); ok {
+		t.Error("a failed merge changed the metadata")
+	}
+}
@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// MetadataValidator checks the value stored under a metadata namespace,
+// the top-level metadata key owned by a studio or tool.
+//
+// Registered validators run whenever a document is read or written, by
+// FromJSONBytes, FromJSONFile, ToJSONBytes, ToJSONFile and the other
+// read and write functions. Their warnings go to the handler set with
+// SetMetadataWarningHandler and to the logger of a decode, and never fail
+// the read or write. With no validator registered, reads and writes do no
+// validation work.
+type MetadataValidator func(value any) error
+
+var (
+	metadataValidators     = make(map[string]MetadataValidator)
+	metadataWarningHandler func([]MetadataWarning)
+	metadataValidatorsMu   sync.RWMutex
+	// metadataValidatorCount lets reads and writes skip validation
+	// without taking the lock.
+	metadataValidatorCount atomic.Int32
+)
+
+// RegisterMetadataValidator registers a validator for a metadata namespace.
+// Registering nil removes the validator.
+func RegisterMetadataValidator(namespace string, validator MetadataValidator) {
+	metadataValidatorsMu.Lock()
+	defer metadataValidatorsMu.Unlock()
+	if validator == nil {
+		delete(metadataValidators, namespace)
+	} else {
+		metadataValidators[namespace] = validator
+	}
+	metadataValidatorCount.Store(int32(len(metadataValidators)))
+}
+
+// SetMetadataWarningHandler sets the function that receives the warnings
+// of the metadata validation run by each read or write, once per document
+// with warnings. Setting nil discards them.
+func SetMetadataWarningHandler(handler func([]MetadataWarning)) {
+	metadataValidatorsMu.Lock()
+	defer metadataValidatorsMu.Unlock()
+	metadataWarningHandler = handler
+}
+
+// StructMetadataValidator returns a validator that requires the namespace
+// value to decode into the same type as prototype, rejecting unknown
+// fields. If the type has a Validate() error method it is called as well.
+func StructMetadataValidator(prototype any) MetadataValidator {
+	t := reflect.TypeOf(prototype)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return func(value any) error {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		target := reflect.New(t).Interface()
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(target); err != nil {
+			return err
+		}
+		if v, ok := target.(interface{ Validate() error }); ok {
+			return v.Validate()
+		}
+		return nil
+	}
+}
+
+// MetadataWarning reports a metadata namespace that failed validation.
+type MetadataWarning struct {
+	Object    SerializableObjectWithMetadata
+	Namespace string
+	Err       error
+}
+
+func (w MetadataWarning) String() string {
+	return fmt.Sprintf("%s %q: metadata %q: %v", w.Object.SchemaName(), w.Object.Name(), w.Namespace, w.Err)
+}
+
+// ValidateMetadata runs the registered metadata validators over obj and
+// every object it contains, returning the collected warnings.
+func ValidateMetadata(obj SerializableObject) []MetadataWarning {
+	if metadataValidatorCount.Load() == 0 {
+		return nil
+	}
+	metadataValidatorsMu.RLock()
+	validators := make(map[string]MetadataValidator, len(metadataValidators))
+	for namespace, validator := range metadataValidators {
+		validators[namespace] = validator
+	}
+	metadataValidatorsMu.RUnlock()
+	if len(validators) == 0 {
+		return nil
+	}
+
+	var warnings []MetadataWarning
+	walkMetadataObjects(obj, func(o SerializableObjectWithMetadata) {
+		md := o.Metadata()
+		for _, namespace := range md.Keys() {
+			validator, ok := validators[namespace]
+			if !ok {
+				continue
+			}
+			if err := validator(md[namespace]); err != nil {
+				warnings = append(warnings, MetadataWarning{Object: o, Namespace: namespace, Err: err})
+			}
+		}
+	})
+	return warnings
+}
+
+// FromJSONBytesValidated parses JSON bytes and returns the warnings of
+// the metadata validation as well.
+func FromJSONBytesValidated(data []byte) (SerializableObject, []MetadataWarning, error) {
+	obj, _, warnings, err := NewDecodeConfig().decodeWithWarnings(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return obj, warnings, nil
+}
+
+// ToJSONBytesValidated converts obj to JSON bytes and returns the warnings
+// of the metadata validation as well. Warnings do not prevent encoding.
+func ToJSONBytesValidated(obj SerializableObject) ([]byte, []MetadataWarning, error) {
+	warnings := reportMetadata(obj)
+	data, err := toJSONBytes(obj)
+	if err != nil {
+		return nil, warnings, err
+	}
+	return data, warnings, nil
+}
+
+// reportMetadata validates the metadata of a document read or written,
+// passes any warnings to the warning handler and returns them.
+func reportMetadata(obj SerializableObject) []MetadataWarning {
+	warnings := ValidateMetadata(obj)
+	if len(warnings) == 0 {
+		return nil
+	}
+	metadataValidatorsMu.RLock()
+	handler := metadataWarningHandler
+	metadataValidatorsMu.RUnlock()
+	if handler != nil {
+		handler(warnings)
+	}
+	return warnings
+}
+
+// walkMetadataObjects calls visit for obj and each object with metadata
+// beneath it, in document order.
+func walkMetadataObjects(obj SerializableObject, visit func(SerializableObjectWithMetadata)) {
+	if obj == nil {
+		return
+	}
+	if o, ok := obj.(SerializableObjectWithMetadata); ok {
+		visit(o)
+	}
+
+	switch o := obj.(type) {
+	case *Timeline:
+		if tracks := o.Tracks(); tracks != nil {
+			walkMetadataObjects(tracks, visit)
+		}
+	case *SerializableCollection:
+		for _, child := range o.Children() {
+			walkMetadataObjects(child, visit)
+		}
+	case *Clip:
+		refs := o.MediaReferences()
+		for _, key := range o.MediaReferenceKeys() {
+			if refs[key] != nil {
+				walkMetadataObjects(refs[key], visit)
+			}
+		}
+	}
+
+	if item, ok := obj.(Item); ok {
+		for _, effect := range item.Effects() {
+			walkMetadataObjects(effect, visit)
+		}
+		for _, marker := range item.Markers() {
+			walkMetadataObjects(marker, visit)
+		}
+	}
+	if comp, ok := obj.(Composition); ok {
+		for _, child := range comp.Children() {
+			walkMetadataObjects(child, visit)
+		}
+	}
+}
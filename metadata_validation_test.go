@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+type studioMetadata struct {
+	Shot string `json:"shot"`
+	Take int    `json:"take"`
+}
+
+func (s *studioMetadata) Validate() error {
+	if s.Shot == "" {
+		return errors.New("shot is required")
+	}
+	return nil
+}
+
+func TestValidateMetadata(t *testing.T) {
+	RegisterMetadataValidator("studioX", StructMetadataValidator(studioMetadata{}))
+	defer RegisterMetadataValidator("studioX", nil)
+
+	timeline := NewTimeline("edit", nil, AnyDictionary{"studioX": map[string]any{"shot": "sh010", "take": 1}})
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	timeline.Tracks().AppendChild(track)
+
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(24, 24))
+	ref := NewExternalReference("", "/a.mov", nil, AnyDictionary{"studioX": map[string]any{"shot": "sh010", "lens": "50mm"}})
+	clip := NewClip("a", ref, &sr, AnyDictionary{"studioX": map[string]any{"take": 2}}, nil, nil, "", nil)
+	clip.SetMarkers([]*Marker{NewMarker("m", sr, "", "", AnyDictionary{"studioX": "not an object", "other": 1})})
+	track.AppendChild(clip)
+
+	warnings := ValidateMetadata(timeline)
+	if len(warnings) != 3 {
+		for _, w := range warnings {
+			t.Log(w)
+		}
+		t.Fatalf("expected 3 warnings, got %d", len(warnings))
+	}
+	if warnings[0].Object != clip || warnings[0].Namespace != "studioX" {
+		t.Errorf("first warning should be for the clip, got %s", warnings[0])
+	}
+	if warnings[1].Object != SerializableObjectWithMetadata(ref) {
+		t.Errorf("second warning should be for the media reference, got %s", warnings[1])
+	}
+	if warnings[2].Object != SerializableObjectWithMetadata(clip.Markers()[0]) {
+		t.Errorf("third warning should be for the marker, got %s", warnings[2])
+	}
+
+	data, encodeWarnings, err := ToJSONBytesValidated(timeline)
+	if err != nil {
+		t.Fatalf("ToJSONBytesValidated error: %v", err)
+	}
+	if len(encodeWarnings) != 3 {
+		t.Errorf("expected 3 warnings on encode, got %d", len(encodeWarnings))
+	}
+
+	_, decodeWarnings, err := FromJSONBytesValidated(data)
+	if err != nil {
+		t.Fatalf("FromJSONBytesValidated error: %v", err)
+	}
+	if len(decodeWarnings) != 3 {
+		t.Errorf("expected 3 warnings on decode, got %d", len(decodeWarnings))
+	}
+}
+
+func TestMetadataWarningHandler(t *testing.T) {
+	var got []int
+	SetMetadataWarningHandler(func(warnings []MetadataWarning) {
+		got = append(got, len(warnings))
+	})
+	defer SetMetadataWarningHandler(nil)
+
+	timeline := NewTimeline("edit", nil, AnyDictionary{"studioX": map[string]any{"take": 1}})
+	path := filepath.Join(t.TempDir(), "edit.otio")
+	if err := ToJSONFile(timeline, path, "  "); err != nil {
+		t.Fatalf("ToJSONFile error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("handler called %d times without validators", len(got))
+	}
+
+	RegisterMetadataValidator("studioX", StructMetadataValidator(studioMetadata{}))
+	defer RegisterMetadataValidator("studioX", nil)
+	if err := ToJSONFile(timeline, path, "  "); err != nil {
+		t.Fatalf("ToJSONFile error: %v", err)
+	}
+	if _, err := FromJSONFile(path); err != nil {
+		t.Fatalf("FromJSONFile error: %v", err)
+	}
+	if _, warnings, err := FromJSONBytesValidated([]byte(`{"OTIO_SCHEMA": "Timeline.1", "metadata": {"studioX": {}}}`)); err != nil || len(warnings) != 1 {
+		t.Fatalf("FromJSONBytesValidated = %v, %v, want 1 warning", warnings, err)
+	}
+	if len(got) != 3 || got[0] != 1 || got[1] != 1 || got[2] != 1 {
+		t.Errorf("handler got %v, want one warning on each write and read", got)
+	}
+}
+
+func TestValidateMetadataWithoutValidators(t *testing.T) {
+	timeline := NewTimeline("edit", nil, AnyDictionary{"studioX": 1})
+	if warnings := ValidateMetadata(timeline); warnings != nil {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package metrics exposes counters and histograms measured inside gotio.
+//
+// Operations report to the Recorder set with SetRecorder. The default
+// Recorder discards everything, so measuring costs nothing until a caller
+// binds one, such as an adapter onto Prometheus counter and histogram
+// vectors keyed by the names below.
+package metrics
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Metric names. Counters are added to; histograms are observed.
+const (
+	// DecodeSeconds is a histogram of the time taken to decode a document.
+	DecodeSeconds = "gotio_decode_seconds"
+	// DecodeBytes counts the bytes of the documents decoded.
+	DecodeBytes = "gotio_decode_bytes"
+	// EncodeBytes counts the bytes of the documents encoded.
+	EncodeBytes = "gotio_encode_bytes"
+	// ClipsProcessed counts clips visited by an operation, labelled with
+	// the operation name.
+	ClipsProcessed = "gotio_clips_processed"
+	// BundleBytesCopied counts the media bytes copied into bundles,
+	// labelled with the bundle format.
+	BundleBytesCopied = "gotio_bundle_bytes_copied"
+)
+
+// Label names.
+const (
+	LabelOperation = "operation"
+	LabelFormat    = "format"
+)
+
+// Label is a name and value qualifying a measurement.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Recorder receives measurements. Implementations must be safe for
+// concurrent use.
+type Recorder interface {
+	// Add adds delta to the counter name.
+	Add(name string, delta float64, labels ...Label)
+	// Observe records value in the histogram name.
+	Observe(name string, value float64, labels ...Label)
+}
+
+// Nop is a Recorder that discards everything.
+type Nop struct{}
+
+// Add does nothing.
+func (Nop) Add(string, float64, ...Label) {}
+
+// Observe does nothing.
+func (Nop) Observe(string, float64, ...Label) {}
+
+type holder struct {
+	recorder Recorder
+}
+
+var current atomic.Pointer[holder]
+
+// SetRecorder sets the Recorder operations report to. Nil restores the
+// default, which discards everything.
+func SetRecorder(r Recorder) {
+	if r == nil {
+		current.Store(nil)
+		return
+	}
+	current.Store(&holder{r})
+}
+
+// Current returns the Recorder operations report to.
+func Current() Recorder {
+	if h := current.Load(); h != nil {
+		return h.recorder
+	}
+	return Nop{}
+}
+
+// Add adds delta to the counter name of the current Recorder.
+func Add(name string, delta float64, labels ...Label) {
+	if h := current.Load(); h != nil {
+		h.recorder.Add(name, delta, labels...)
+	}
+}
+
+// Observe records value in the histogram name of the current Recorder.
+func Observe(name string, value float64, labels ...Label) {
+	if h := current.Load(); h != nil {
+		h.recorder.Observe(name, value, labels...)
+	}
+}
+
+// Since records the seconds elapsed since start in the histogram name.
+func Since(name string, start time.Time, labels ...Label) {
+	if h := current.Load(); h != nil {
+		h.recorder.Observe(name, time.Since(start).Seconds(), labels...)
+	}
+}
+
+// Operation returns the operation label.
+func Operation(name string) Label {
+	return Label{LabelOperation, name}
+}
+
+// Format returns the format label.
+func Format(name string) Label {
+	return Label{LabelFormat, name}
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package metrics
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type testRecorder struct {
+	mu       sync.Mutex
+	counters map[string]float64
+	observed map[string]int
+}
+
+func (r *testRecorder) Add(name string, delta float64, labels ...Label) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, l := range labels {
+		name += "," + l.Name + "=" + l.Value
+	}
+	r.counters[name] += delta
+}
+
+func (r *testRecorder) Observe(name string, value float64, labels ...Label) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observed[name]++
+}
+
+func TestSetRecorder(t *testing.T) {
+	if _, ok := Current().(Nop); !ok {
+		t.Fatalf("expected the default to be Nop, got %T", Current())
+	}
+	Add(EncodeBytes, 10)
+
+	r := &testRecorder{counters: map[string]float64{}, observed: map[string]int{}}
+	SetRecorder(r)
+	defer SetRecorder(nil)
+	Add(EncodeBytes, 10)
+	Add(EncodeBytes, 5)
+	Add(ClipsProcessed, 3, Operation("link_media"))
+	Since(DecodeSeconds, time.Now())
+
+	if r.counters[EncodeBytes] != 15 {
+		t.Errorf("EncodeBytes = %v, want 15", r.counters[EncodeBytes])
+	}
+	if got := r.counters[ClipsProcessed+",operation=link_media"]; got != 3 {
+		t.Errorf("ClipsProcessed = %v, want 3", got)
+	}
+	if r.observed[DecodeSeconds] != 1 {
+		t.Errorf("expected one DecodeSeconds observation, got %d", r.observed[DecodeSeconds])
+	}
+
+	SetRecorder(nil)
+	Add(EncodeBytes, 10)
+	if r.counters[EncodeBytes] != 15 {
+		t.Error("expected nothing recorded after SetRecorder(nil)")
+	}
+}
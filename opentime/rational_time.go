@@ -206,33 +206,60 @@ func (rt RationalTime) ToSeconds() float64 {
 	return rt.ValueRescaledTo(1)
 }
 
+// ToSecondsOrZero returns the value in seconds, or zero for a time without
+// a positive rate, such as the zero RationalTime, which ToSeconds would
+// divide by.
+func (rt RationalTime) ToSecondsOrZero() float64 {
+	if rt.rate <= 0 {
+		return 0
+	}
+	return rt.ToSeconds()
+}
+
 // isDropFrameRate determines if a rate uses drop frame timecode.
 func isDropFrameRate(rate float64) bool {
 	// 29.97 and 59.94 use drop frame
 	return math.Abs(rate-29.97) < 0.01 || math.Abs(rate-59.94) < 0.01
 }
 
+// framesAtRate returns the nearest frame number of rt at rate. A time
+// already counted in frames of the same timecode rate, such as 30000/1001
+// for 29.97, keeps its frame number rather than being rescaled, which
+// would drift by a frame every few hours.
+func framesAtRate(rt RationalTime, rate float64) int64 {
+	if math.Abs(rt.rate-rate) < 0.01 {
+		return int64(math.Round(rt.value))
+	}
+	return int64(math.Round(rt.ValueRescaledTo(rate)))
+}
+
 // ToTimecode converts to timecode (e.g., "HH:MM:SS;FRAME").
 func (rt RationalTime) ToTimecode(rate float64, dropFrame IsDropFrameRate) (string, error) {
 	if rt.IsInvalidTime() {
 		return "", fmt.Errorf("invalid time")
 	}
 
-	useDropFrame := false
-	if dropFrame == ForceYes {
-		useDropFrame = true
-	} else if dropFrame == InferFromRate {
-		useDropFrame = isDropFrameRate(rate)
-	}
-
-	// Rescale to the target rate
-	rescaled := rt.RescaledTo(rate)
-	totalFrames := int64(math.Round(rescaled.value))
+	totalFrames := framesAtRate(rt, rate)
 
 	if totalFrames < 0 {
 		return "", fmt.Errorf("negative timecode not supported")
 	}
 
+	return formatTimecode(totalFrames, rate, useDropFrame(rate, dropFrame)), nil
+}
+
+// useDropFrame resolves a drop frame option for rate.
+func useDropFrame(rate float64, dropFrame IsDropFrameRate) bool {
+	if dropFrame == ForceYes {
+		return true
+	} else if dropFrame == InferFromRate {
+		return isDropFrameRate(rate)
+	}
+	return false
+}
+
+// formatTimecode formats a non-negative frame count as timecode.
+func formatTimecode(totalFrames int64, rate float64, useDropFrame bool) string {
 	nominalRate := int64(math.Round(rate))
 	if useDropFrame {
 		// Drop frame calculation
@@ -246,23 +273,22 @@ func (rt RationalTime) ToTimecode(rate float64, dropFrame IsDropFrameRate) (stri
 		framesPerMinute := nominalRate*60 - dropFrames
 		framesPer10Minutes := framesPerMinute*10 + dropFrames
 
+		// Add back the frame numbers skipped before this frame: all
+		// those of each full 10 minutes, then those of each minute
+		// after the first of the current 10.
 		d := totalFrames / framesPer10Minutes
 		m := totalFrames % framesPer10Minutes
-
-		if m < dropFrames {
-			m += dropFrames
+		frameCount := totalFrames + 9*dropFrames*d
+		if m > dropFrames {
+			frameCount += dropFrames * ((m - dropFrames) / framesPerMinute)
 		}
 
-		frameCount := d*framesPer10Minutes + (m-dropFrames)/framesPerMinute*(framesPerMinute+dropFrames) +
-			(m-dropFrames)%framesPerMinute + dropFrames
-
-		// Recalculate with adjusted frame count
 		frames := int(frameCount % nominalRate)
 		seconds := int((frameCount / nominalRate) % 60)
 		minutes := int((frameCount / nominalRate / 60) % 60)
 		hours := int(frameCount / nominalRate / 3600)
 
-		return fmt.Sprintf("%02d:%02d:%02d;%02d", hours, minutes, seconds, frames), nil
+		return fmt.Sprintf("%02d:%02d:%02d;%02d", hours, minutes, seconds, frames)
 	}
 
 	// Non-drop frame
@@ -271,7 +297,7 @@ func (rt RationalTime) ToTimecode(rate float64, dropFrame IsDropFrameRate) (stri
 	minutes := int((totalFrames / nominalRate / 60) % 60)
 	hours := int(totalFrames / nominalRate / 3600)
 
-	return fmt.Sprintf("%02d:%02d:%02d:%02d", hours, minutes, seconds, frames), nil
+	return fmt.Sprintf("%02d:%02d:%02d:%02d", hours, minutes, seconds, frames)
 }
 
 // ToTimecodeAuto converts to timecode using the current rate.
@@ -349,13 +375,10 @@ func FromTimecode(timecode string, rate float64) (RationalTime, error) {
 			dropFrames = 4
 		}
 
-		framesPerMinute := nominalRate*60 - dropFrames
-		framesPer10Minutes := framesPerMinute*10 + dropFrames
-
+		// Every minute skips its first frame numbers, except every 10th.
 		totalMinutes := int64(hours)*60 + int64(minutes)
-		totalFrames = int64(framesPer10Minutes)*(totalMinutes/10) +
-			int64(framesPerMinute)*(totalMinutes%10) +
-			int64(seconds*nominalRate) + int64(frames) -
+		totalFrames = (totalMinutes*60+int64(seconds))*int64(nominalRate) +
+			int64(frames) -
 			int64(dropFrames)*(totalMinutes-totalMinutes/10)
 	} else {
 		totalFrames = int64(hours)*3600*int64(nominalRate) +
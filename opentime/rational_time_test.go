@@ -245,6 +245,15 @@ func TestToSeconds(t *testing.T) {
 	}
 }
 
+func TestToSecondsOrZero(t *testing.T) {
+	if s := NewRationalTime(48, 24).ToSecondsOrZero(); s != 2.0 {
+		t.Errorf("Expected 2.0, got %g", s)
+	}
+	if s := NewRationalTime(48, 0).ToSecondsOrZero(); s != 0 {
+		t.Errorf("Expected 0 for a time without a rate, got %g", s)
+	}
+}
+
 func TestToTimecodeNDF(t *testing.T) {
 	// 1 hour at 24fps = 86400 frames
 	rt := NewRationalTime(86400, 24)
@@ -247,6 +247,13 @@ func (tr TimeRange) Equal(other TimeRange) bool {
 		math.Abs(duration.ToSeconds()) < DefaultEpsilon
 }
 
+// AlmostEqual returns whether the start times and the durations of two
+// time ranges are each within epsilon seconds of the other's.
+func (tr TimeRange) AlmostEqual(other TimeRange, epsilon float64) bool {
+	return math.Abs(tr.startTime.ToSeconds()-other.startTime.ToSeconds()) <= epsilon &&
+		math.Abs(tr.duration.ToSeconds()-other.duration.ToSeconds()) <= epsilon
+}
+
 // RangeFromStartEndTime creates a time range from a start time and exclusive end time.
 func RangeFromStartEndTime(startTime, endTimeExclusive RationalTime) TimeRange {
 	return TimeRange{
@@ -307,6 +307,22 @@ func TestTimeRangeEqual(t *testing.T) {
 	}
 }
 
+func TestTimeRangeAlmostEqual(t *testing.T) {
+	tr1 := NewTimeRangeFromValues(10, 20, 24)
+	tr2 := NewTimeRange(NewRationalTime(10.0/24+0.0005, 1), NewRationalTime(20.0/24, 1))
+	tr3 := NewTimeRangeFromValues(10, 21, 24)
+
+	if !tr1.AlmostEqual(tr2, 0.001) {
+		t.Error("Expected almost equal ranges")
+	}
+	if tr1.AlmostEqual(tr2, 0.0001) {
+		t.Error("Expected ranges outside epsilon to differ")
+	}
+	if tr1.AlmostEqual(tr3, 0.001) {
+		t.Error("Expected unequal ranges")
+	}
+}
+
 func TestRangeFromStartEndTime(t *testing.T) {
 	start := NewRationalTime(10, 24)
 	end := NewRationalTime(30, 24)
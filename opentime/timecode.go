@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package opentime
+
+import (
+	"fmt"
+	"math"
+)
+
+// FramesPerFoot is the number of frames in a foot of 35mm 4-perf film.
+const FramesPerFoot = 16
+
+// FormatRangeAsTimecode formats a range as its in and out timecodes at
+// rate, separated by " - ", such as "01:00:00;00 - 01:00:10;00". The out
+// point is exclusive, as for record times in an EDL.
+func FormatRangeAsTimecode(tr TimeRange, rate float64, drop IsDropFrameRate) (string, error) {
+	in, out, err := RangeToTimecodes(tr, rate, drop)
+	if err != nil {
+		return "", err
+	}
+	return in + " - " + out, nil
+}
+
+// RangeToTimecodes returns the in and out timecodes of a range at rate.
+// The out point is exclusive, as in an EDL.
+//
+// The out point is counted in whole frames from the in point, so it stays
+// frame accurate over long ranges, including drop frame ranges whose
+// times use 29.97 or 30000/1001 interchangeably.
+func RangeToTimecodes(tr TimeRange, rate float64, drop IsDropFrameRate) (in, out string, err error) {
+	start, duration := tr.StartTime(), tr.Duration()
+	if start.IsInvalidTime() || duration.IsInvalidTime() {
+		return "", "", fmt.Errorf("invalid time range")
+	}
+	inFrame := framesAtRate(start, rate)
+	outFrame := inFrame + framesAtRate(duration, rate)
+	if inFrame < 0 || outFrame < 0 {
+		return "", "", fmt.Errorf("negative timecode not supported")
+	}
+	useDrop := useDropFrame(rate, drop)
+	return formatTimecode(inFrame, rate, useDrop), formatTimecode(outFrame, rate, useDrop), nil
+}
+
+// DurationToFootageString formats a duration as 35mm 4-perf feet and
+// frames, such as "90+00" for a minute at 24 fps. The duration is counted
+// in frames at rate; a negative duration gets a leading "-".
+func DurationToFootageString(d RationalTime, rate float64) (string, error) {
+	if d.IsInvalidTime() || math.IsNaN(rate) || rate <= 0 {
+		return "", fmt.Errorf("invalid time")
+	}
+	frames := framesAtRate(d, rate)
+	sign := ""
+	if frames < 0 {
+		sign = "-"
+		frames = -frames
+	}
+	return fmt.Sprintf("%s%d+%02d", sign, frames/FramesPerFoot, frames%FramesPerFoot), nil
+}
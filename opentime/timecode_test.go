@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package opentime
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDropFrameTimecodeRoundTripAtHours(t *testing.T) {
+	tests := []struct {
+		rate          float64
+		framesPerHour float64
+		lastFrame     string
+	}{
+		{29.97, 107892, "59:59;29"},
+		{30000.0 / 1001, 107892, "59:59;29"},
+		{59.94, 215784, "59:59;59"},
+	}
+	for _, tt := range tests {
+		for hour := 1; hour < 24; hour++ {
+			frame := float64(hour) * tt.framesPerHour
+			checks := map[float64]string{
+				frame:     fmt.Sprintf("%02d:00:00;00", hour),
+				frame - 1: fmt.Sprintf("%02d:%s", hour-1, tt.lastFrame),
+			}
+			for value, want := range checks {
+				tc, err := NewRationalTime(value, tt.rate).ToTimecode(tt.rate, ForceYes)
+				if err != nil {
+					t.Fatalf("ToTimecode(%g @ %g) error: %v", value, tt.rate, err)
+				}
+				if tc != want {
+					t.Errorf("ToTimecode(%g @ %g) = %s, want %s", value, tt.rate, tc, want)
+				}
+				back, err := FromTimecode(tc, tt.rate)
+				if err != nil {
+					t.Fatalf("FromTimecode(%s) error: %v", tc, err)
+				}
+				if back.Value() != value {
+					t.Errorf("FromTimecode(%s) = %g, want %g", tc, back.Value(), value)
+				}
+			}
+		}
+	}
+}
+
+func TestDropFrameTimecodeSkipsFrameNumbers(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{0, "00:00:00;00"},
+		{1799, "00:00:59;29"},
+		{1800, "00:01:00;02"},
+		{17981, "00:09:59;29"},
+		{17982, "00:10:00;00"},
+		{17983, "00:10:00;01"},
+		{19781, "00:10:59;29"},
+		{19782, "00:11:00;02"},
+	}
+	for _, tt := range tests {
+		tc, err := NewRationalTime(tt.value, 29.97).ToTimecode(29.97, InferFromRate)
+		if err != nil {
+			t.Fatalf("ToTimecode(%g) error: %v", tt.value, err)
+		}
+		if tc != tt.want {
+			t.Errorf("ToTimecode(%g) = %s, want %s", tt.value, tc, tt.want)
+		}
+		back, _ := FromTimecode(tt.want, 29.97)
+		if back.Value() != tt.value {
+			t.Errorf("FromTimecode(%s) = %g, want %g", tt.want, back.Value(), tt.value)
+		}
+	}
+}
+
+func TestFormatRangeAsTimecode(t *testing.T) {
+	hour := NewRationalTime(107892, 30000.0/1001)
+	tests := []struct {
+		tr   TimeRange
+		rate float64
+		drop IsDropFrameRate
+		want string
+	}{
+		{NewTimeRange(hour, NewRationalTime(300, 29.97)), 29.97, InferFromRate, "01:00:00;00 - 01:00:10;00"},
+		{NewTimeRange(NewRationalTime(0, 29.97), NewRationalTime(10*107892, 29.97)), 29.97, ForceYes, "00:00:00;00 - 10:00:00;00"},
+		{NewTimeRange(hour, NewRationalTime(22*107892, 30000.0/1001)), 29.97, ForceYes, "01:00:00;00 - 23:00:00;00"},
+		{NewTimeRange(NewRationalTime(86400, 24), NewRationalTime(48, 24)), 24, InferFromRate, "01:00:00:00 - 01:00:02:00"},
+	}
+	for _, tt := range tests {
+		got, err := FormatRangeAsTimecode(tt.tr, tt.rate, tt.drop)
+		if err != nil {
+			t.Fatalf("FormatRangeAsTimecode(%v) error: %v", tt.tr, err)
+		}
+		if got != tt.want {
+			t.Errorf("FormatRangeAsTimecode(%v) = %s, want %s", tt.tr, got, tt.want)
+		}
+		in, out, _ := strings.Cut(got, " - ")
+		start, _ := FromTimecode(in, tt.rate)
+		end, _ := FromTimecode(out, tt.rate)
+		if int64(start.Value()) != framesAtRate(tt.tr.StartTime(), tt.rate) ||
+			int64(end.Value()-start.Value()) != framesAtRate(tt.tr.Duration(), tt.rate) {
+			t.Errorf("%s does not round trip to %v", got, tt.tr)
+		}
+	}
+
+	if _, err := FormatRangeAsTimecode(NewTimeRange(NewRationalTime(-1, 24), NewRationalTime(1, 24)), 24, ForceNo); err == nil {
+		t.Error("expected an error for a negative start")
+	}
+}
+
+func TestDurationToFootageString(t *testing.T) {
+	tests := []struct {
+		d    RationalTime
+		rate float64
+		want string
+	}{
+		{NewRationalTime(1440, 24), 24, "90+00"},
+		{NewRationalTime(17, 24), 24, "1+01"},
+		{NewRationalTime(1, 1), 24, "1+08"},
+		{NewRationalTime(107892, 30000.0/1001), 29.97, "6743+04"},
+		{NewRationalTime(-20, 24), 24, "-1+04"},
+	}
+	for _, tt := range tests {
+		got, err := DurationToFootageString(tt.d, tt.rate)
+		if err != nil {
+			t.Fatalf("DurationToFootageString(%v) error: %v", tt.d, err)
+		}
+		if got != tt.want {
+			t.Errorf("DurationToFootageString(%v) = %s, want %s", tt.d, got, tt.want)
+		}
+	}
+	if _, err := DurationToFootageString(NewRationalTime(1, 24), 0); err == nil {
+		t.Error("expected an error for a zero rate")
+	}
+}
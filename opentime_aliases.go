@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// The opentime types and constructors are re-exported so programs can
+// build and serialize timelines with a single import of this package.
+
+// RationalTime is an alias for opentime.RationalTime.
+type RationalTime = opentime.RationalTime
+
+// TimeRange is an alias for opentime.TimeRange.
+type TimeRange = opentime.TimeRange
+
+// TimeTransform is an alias for opentime.TimeTransform.
+type TimeTransform = opentime.TimeTransform
+
+// IsDropFrameRate is an alias for opentime.IsDropFrameRate.
+type IsDropFrameRate = opentime.IsDropFrameRate
+
+// Drop frame options for timecode conversion.
+const (
+	InferFromRate = opentime.InferFromRate
+	ForceNo       = opentime.ForceNo
+	ForceYes      = opentime.ForceYes
+)
+
+// NewRationalTime creates a RationalTime. See opentime.NewRationalTime.
+func NewRationalTime(value, rate float64) RationalTime {
+	return opentime.NewRationalTime(value, rate)
+}
+
+// FromFrames creates a RationalTime from a frame number. See opentime.FromFrames.
+func FromFrames(frame, rate float64) RationalTime {
+	return opentime.FromFrames(frame, rate)
+}
+
+// FromSeconds creates a RationalTime from seconds. See opentime.FromSeconds.
+func FromSeconds(seconds, rate float64) RationalTime {
+	return opentime.FromSeconds(seconds, rate)
+}
+
+// FromTimecode parses a timecode string. See opentime.FromTimecode.
+func FromTimecode(timecode string, rate float64) (RationalTime, error) {
+	return opentime.FromTimecode(timecode, rate)
+}
+
+// NewTimeRange creates a TimeRange. See opentime.NewTimeRange.
+func NewTimeRange(startTime, duration RationalTime) TimeRange {
+	return opentime.NewTimeRange(startTime, duration)
+}
+
+// RangeFromStartEndTime creates a TimeRange from an exclusive end time.
+// See opentime.RangeFromStartEndTime.
+func RangeFromStartEndTime(startTime, endTimeExclusive RationalTime) TimeRange {
+	return opentime.RangeFromStartEndTime(startTime, endTimeExclusive)
+}
+
+// NewTimeTransform creates a TimeTransform. See opentime.NewTimeTransform.
+func NewTimeTransform(offset RationalTime, scale, rate float64) TimeTransform {
+	return opentime.NewTimeTransform(offset, scale, rate)
+}
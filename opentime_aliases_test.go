@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func TestOpentimeAliases(t *testing.T) {
+	// A timeline built only through the root package.
+	start, err := FromTimecode("01:00:00:00", 24)
+	if err != nil {
+		t.Fatalf("FromTimecode error: %v", err)
+	}
+	sr := NewTimeRange(start, NewRationalTime(48, 24))
+	clip := NewClip("shot", nil, &sr, nil, nil, nil, "", nil)
+
+	// Aliases are interchangeable with the opentime types.
+	var tr opentime.TimeRange = *clip.SourceRange()
+	if !tr.StartTime().Equal(opentime.NewRationalTime(86400, 24)) {
+		t.Errorf("StartTime = %v, want 86400", tr.StartTime())
+	}
+	end := RangeFromStartEndTime(start, FromSeconds(3605, 24))
+	if end.Duration().Value() != 120 {
+		t.Errorf("Duration = %v, want 120", end.Duration().Value())
+	}
+	if tc, _ := start.ToTimecode(24, ForceNo); tc != "01:00:00:00" {
+		t.Errorf("ToTimecode = %s", tc)
+	}
+}
@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package otiotest
+
+import (
+	"errors"
+	"fmt"
+	mathrand "math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// CheckRoundTrip writes obj as OTIO JSON and reads it back, returning an
+// error if the result is not equivalent to obj, has a different duration
+// or, for timelines and compositions, a different structure.
+func CheckRoundTrip(obj gotio.SerializableObject) error {
+	data, err := gotio.ToJSONBytes(obj)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", obj.SchemaName(), err)
+	}
+	read, err := gotio.FromJSONBytes(data)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", obj.SchemaName(), err)
+	}
+	if read.SchemaName() != obj.SchemaName() {
+		return fmt.Errorf("round trip read a %s, want %s", read.SchemaName(), obj.SchemaName())
+	}
+
+	switch obj := obj.(type) {
+	case *gotio.Timeline:
+		if err := CheckStructure(obj.Tracks(), read.(*gotio.Timeline).Tracks()); err != nil {
+			return fmt.Errorf("round trip changed the timeline: %w", err)
+		}
+	case gotio.Composition:
+		if err := CheckStructure(obj, read.(gotio.Composition)); err != nil {
+			return fmt.Errorf("round trip changed the %s: %w", obj.SchemaName(), err)
+		}
+	case gotio.Composable:
+		if err := checkSameDuration(obj.SchemaName(), obj, read.(gotio.Composable)); err != nil {
+			return err
+		}
+	}
+	if !obj.IsEquivalentTo(read) {
+		return fmt.Errorf("round trip of %s is not equivalent", obj.SchemaName())
+	}
+	return nil
+}
+
+func checkSameDuration(name string, want, got interface {
+	Duration() (opentime.RationalTime, error)
+}) error {
+	wd, werr := want.Duration()
+	gd, gerr := got.Duration()
+	if (werr == nil) != (gerr == nil) {
+		return fmt.Errorf("%s duration error changed from %v to %v", name, werr, gerr)
+	}
+	if werr == nil && !sameTime(wd, gd) {
+		return fmt.Errorf("%s duration changed from %v to %v", name, wd, gd)
+	}
+	return nil
+}
+
+// CheckCompositionInvariants checks that a composition and every
+// composition nested in it are consistent:
+//
+//   - each child's parent is the composition;
+//   - the children of a track follow one another without gaps or
+//     overlaps, and its available range is their total duration;
+//   - a transition sits between two items that each cover its offsets;
+//   - the available range of a stack is its longest child.
+//
+// All violations found are returned joined into one error.
+func CheckCompositionInvariants(composition gotio.Composition) error {
+	var errs []error
+	checkComposition(composition, describe(composition), &errs)
+	return errors.Join(errs...)
+}
+
+func checkComposition(composition gotio.Composition, path string, errs *[]error) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+	}
+
+	children := composition.Children()
+	var total opentime.RationalTime
+	for i, child := range children {
+		if child.Parent() != composition {
+			fail("child %d %s has the wrong parent", i, describe(child))
+		}
+		duration, err := child.Duration()
+		if err != nil {
+			fail("child %d %s has no duration: %v", i, describe(child), err)
+			continue
+		}
+		if duration.Value() < 0 {
+			fail("child %d %s has negative duration %v", i, describe(child), duration)
+		}
+
+		if _, ok := composition.(*gotio.Stack); ok {
+			if i == 0 || duration.ToSeconds() > total.ToSeconds() {
+				total = duration
+			}
+		} else {
+			if r, err := composition.RangeOfChildAtIndex(i); err != nil {
+				fail("child %d %s has no range: %v", i, describe(child), err)
+			} else if i > 0 && !sameTime(r.StartTime(), total) {
+				fail("child %d %s starts at %v, want %v", i, describe(child), r.StartTime(), total)
+			}
+			if child.Visible() {
+				if total.Rate() <= 0 {
+					total = duration
+				} else {
+					total = total.Add(duration)
+				}
+			}
+		}
+
+		if transition, ok := child.(*gotio.Transition); ok {
+			checkTransition(children, i, transition, fail)
+		}
+		if nested, ok := child.(gotio.Composition); ok {
+			checkComposition(nested, path+"/"+describe(nested), errs)
+		}
+	}
+
+	if available, err := composition.AvailableRange(); err != nil {
+		fail("no available range: %v", err)
+	} else if !sameTime(available.Duration(), total) {
+		fail("available duration is %v, children total %v", available.Duration(), total)
+	}
+}
+
+// checkTransition checks the transition at index i of a track's children.
+func checkTransition(children []gotio.Composable, i int, transition *gotio.Transition, fail func(string, ...any)) {
+	if i == 0 || i == len(children)-1 {
+		fail("transition %d is at the end of the track", i)
+		return
+	}
+	before, after := children[i-1], children[i+1]
+	if _, ok := before.(*gotio.Transition); ok {
+		fail("transition %d follows another transition", i)
+		return
+	}
+	if _, ok := after.(*gotio.Transition); ok {
+		fail("transition %d precedes another transition", i)
+		return
+	}
+	if d, err := before.Duration(); err == nil && transition.InOffset().ToSeconds() > d.ToSeconds() {
+		fail("transition %d in offset %v exceeds %s", i, transition.InOffset(), describe(before))
+	}
+	if d, err := after.Duration(); err == nil && transition.OutOffset().ToSeconds() > d.ToSeconds() {
+		fail("transition %d out offset %v exceeds %s", i, transition.OutOffset(), describe(after))
+	}
+}
+
+// CheckStructure compares the structure of two compositions, such as a
+// timeline's tracks before and after an adapter round trip. The same kinds
+// of children must appear in the same order with the same durations;
+// names, metadata and media references are not compared.
+func CheckStructure(want, got gotio.Composition) error {
+	var errs []error
+	checkStructure(want, got, describe(want), &errs)
+	return errors.Join(errs...)
+}
+
+func checkStructure(want, got gotio.Composition, path string, errs *[]error) {
+	if err := checkSameDuration(path, want, got); err != nil {
+		*errs = append(*errs, err)
+	}
+	wc, gc := want.Children(), got.Children()
+	if len(wc) != len(gc) {
+		*errs = append(*errs, fmt.Errorf("%s: has %d children, want %d", path, len(gc), len(wc)))
+		return
+	}
+	for i := range wc {
+		if wc[i].SchemaName() != gc[i].SchemaName() {
+			*errs = append(*errs, fmt.Errorf("%s: child %d is a %s, want %s", path, i, gc[i].SchemaName(), wc[i].SchemaName()))
+			continue
+		}
+		childPath := fmt.Sprintf("%s/%d", path, i)
+		if wn, ok := wc[i].(gotio.Composition); ok {
+			checkStructure(wn, gc[i].(gotio.Composition), childPath, errs)
+		} else if err := checkSameDuration(childPath, wc[i], gc[i]); err != nil {
+			*errs = append(*errs, err)
+		}
+	}
+}
+
+// Check calls property with n timelines generated from seeds 1 to n and
+// the given options, reporting each error as a test failure along with
+// the seed that reproduces it.
+func Check(t testing.TB, n int, property func(*gotio.Timeline) error, opts ...Option) {
+	t.Helper()
+	for seed := uint64(1); seed <= uint64(n); seed++ {
+		if err := property(NewTimeline(append(opts, WithSeed(seed))...)); err != nil {
+			t.Errorf("seed %d: %v", seed, err)
+		}
+	}
+}
+
+// QuickTimeline is a generated timeline for use with testing/quick, whose
+// size sets the number of items per track:
+//
+//	quick.Check(func(q otiotest.QuickTimeline) bool {
+//		return otiotest.CheckRoundTrip(q.Timeline) == nil
+//	}, nil)
+type QuickTimeline struct {
+	*gotio.Timeline
+}
+
+// Generate implements quick.Generator.
+func (QuickTimeline) Generate(r *mathrand.Rand, size int) reflect.Value {
+	timeline := NewTimeline(
+		WithSeed(r.Uint64()),
+		WithTracks(1+r.Intn(3), r.Intn(3)),
+		WithClipsPerTrack(size),
+		WithClipDuration(Uniform(1, 1+r.Intn(240))),
+		WithMetadataKeys(r.Intn(8)),
+		WithGapProbability(r.Float64()/4),
+		WithTransitionProbability(r.Float64()/2),
+		WithNestedStackProbability(r.Float64()/8),
+		WithMarkerProbability(r.Float64()/2),
+	)
+	return reflect.ValueOf(QuickTimeline{timeline})
+}
+
+// describe names an object by schema and name for error messages.
+func describe(obj gotio.SerializableObjectWithMetadata) string {
+	return fmt.Sprintf("%s %q", obj.SchemaName(), obj.Name())
+}
+
+// sameTime reports whether two times are equal, treating times without a
+// rate as zero.
+func sameTime(a, b opentime.RationalTime) bool {
+	if a.Rate() <= 0 || b.Rate() <= 0 {
+		return a.Value() == 0 && b.Value() == 0
+	}
+	return a.ToSeconds() == b.ToSeconds()
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package otiotest
+
+import (
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+var fullOptions = []Option{
+	WithTracks(2, 1),
+	WithClipsPerTrack(30),
+	WithGapProbability(0.1),
+	WithTransitionProbability(0.3),
+	WithNestedStackProbability(0.1),
+	WithMarkerProbability(0.3),
+}
+
+func TestGeneratedTimelinesHoldInvariants(t *testing.T) {
+	Check(t, 25, func(timeline *gotio.Timeline) error {
+		if err := CheckRoundTrip(timeline); err != nil {
+			return err
+		}
+		if err := CheckCompositionInvariants(timeline.Tracks()); err != nil {
+			return err
+		}
+		return CheckStructure(timeline.Tracks(), timeline.Tracks().Clone().(*gotio.Stack))
+	}, fullOptions...)
+}
+
+func TestQuickTimeline(t *testing.T) {
+	property := func(q QuickTimeline) bool {
+		return CheckRoundTrip(q.Timeline) == nil && CheckCompositionInvariants(q.Tracks()) == nil
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 20}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckCompositionInvariantsFailures(t *testing.T) {
+	rt := func(v float64) opentime.RationalTime { return opentime.NewRationalTime(v, 24) }
+	sr := opentime.NewTimeRange(rt(0), rt(10))
+
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	track.AppendChild(gotio.NewTransition("open", gotio.TransitionTypeSMPTEDissolve, rt(2), rt(2), nil))
+	track.AppendChild(gotio.NewClip("a", nil, &sr, nil, nil, nil, "", nil))
+	track.AppendChild(gotio.NewTransition("long", gotio.TransitionTypeSMPTEDissolve, rt(20), rt(2), nil))
+	track.AppendChild(gotio.NewClip("b", nil, &sr, nil, nil, nil, "", nil))
+
+	err := CheckCompositionInvariants(track)
+	if err == nil {
+		t.Fatal("expected invariant violations")
+	}
+	for _, want := range []string{"transition 0 is at the end", "transition 2 in offset"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+}
+
+func TestCheckStructureFailures(t *testing.T) {
+	timeline := NewTimeline(fullOptions...)
+	changed := timeline.Clone().(*gotio.Timeline)
+	first := changed.VideoTracks()[0]
+	first.RemoveChild(len(first.Children()) - 1)
+
+	if err := CheckStructure(timeline.Tracks(), changed.Tracks()); err == nil || !strings.Contains(err.Error(), "children") {
+		t.Errorf("expected a child count error, got %v", err)
+	}
+	if err := CheckRoundTrip(gotio.NewClip("c", nil, nil, gotio.AnyDictionary{"k": "v"}, nil, nil, "", nil)); err != nil {
+		t.Errorf("CheckRoundTrip of a clip error: %v", err)
+	}
+}
@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package otiotest generates random timelines for tests and benchmarks.
+//
+// Timelines are built from a seeded generator, so the same options always
+// produce the same timeline. Clips reference media files, carry metadata
+// of a configurable size, and may be separated by gaps and transitions or
+// replaced by nested stacks, which gives fixtures shaped like real edits:
+//
+//	timeline := otiotest.NewTimeline(
+//		otiotest.WithSeed(42),
+//		otiotest.WithTracks(4, 2),
+//		otiotest.WithClipsPerTrack(500),
+//		otiotest.WithClipDuration(otiotest.Normal(48, 24, 1)),
+//		otiotest.WithTransitionProbability(0.1),
+//	)
+//
+// Generated timelines pass the checks of the validate package.
+// CheckRoundTrip, CheckCompositionInvariants and CheckStructure verify
+// that serialization and adapters preserve durations and structure, and
+// Check and QuickTimeline run them over many generated timelines.
+package otiotest
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// Distribution returns a random whole number of frames using r.
+type Distribution func(r *rand.Rand) int
+
+// Constant always returns n.
+func Constant(n int) Distribution {
+	return func(*rand.Rand) int {
+		return n
+	}
+}
+
+// Uniform returns values between min and max inclusive, with equal
+// probability.
+func Uniform(min, max int) Distribution {
+	if max < min {
+		min, max = max, min
+	}
+	return func(r *rand.Rand) int {
+		return min + r.IntN(max-min+1)
+	}
+}
+
+// Normal returns values normally distributed around mean, rounded and
+// clamped to at least min.
+func Normal(mean, stddev float64, min int) Distribution {
+	return func(r *rand.Rand) int {
+		return max(min, int(math.Round(mean+r.NormFloat64()*stddev)))
+	}
+}
+
+// Config holds configuration for generating timelines.
+type Config struct {
+	// Seed seeds the generator.
+	Seed uint64
+	// Name is the timeline name.
+	Name string
+	// Rate is the frame rate of every time in the timeline.
+	Rate float64
+	// VideoTracks and AudioTracks are the number of tracks of each kind.
+	VideoTracks, AudioTracks int
+	// ClipsPerTrack is the number of clips, gaps and nested stacks in each
+	// track, not counting transitions.
+	ClipsPerTrack int
+	// ClipDuration gives the duration of clips and gaps in frames. Values
+	// below one are raised to one.
+	ClipDuration Distribution
+	// MetadataKeys is the number of metadata entries on each clip.
+	MetadataKeys int
+	// GapProbability is the chance that an item is a gap.
+	GapProbability float64
+	// TransitionProbability is the chance of a transition between two
+	// adjacent clips.
+	TransitionProbability float64
+	// NestedStackProbability is the chance that an item is a nested stack
+	// of two tracks.
+	NestedStackProbability float64
+	// MarkerProbability is the chance that a clip has a marker.
+	MarkerProbability float64
+}
+
+// Option is a functional option for NewTimeline.
+type Option func(*Config)
+
+// WithSeed sets the seed of the generator.
+func WithSeed(seed uint64) Option {
+	return func(c *Config) {
+		c.Seed = seed
+	}
+}
+
+// WithName sets the timeline name.
+func WithName(name string) Option {
+	return func(c *Config) {
+		c.Name = name
+	}
+}
+
+// WithRate sets the frame rate.
+func WithRate(rate float64) Option {
+	return func(c *Config) {
+		c.Rate = rate
+	}
+}
+
+// WithTracks sets the number of video and audio tracks.
+func WithTracks(video, audio int) Option {
+	return func(c *Config) {
+		c.VideoTracks = video
+		c.AudioTracks = audio
+	}
+}
+
+// WithClipsPerTrack sets the number of items in each track.
+func WithClipsPerTrack(n int) Option {
+	return func(c *Config) {
+		c.ClipsPerTrack = n
+	}
+}
+
+// WithClipDuration sets the distribution of clip and gap durations.
+func WithClipDuration(d Distribution) Option {
+	return func(c *Config) {
+		c.ClipDuration = d
+	}
+}
+
+// WithMetadataKeys sets the number of metadata entries on each clip.
+func WithMetadataKeys(n int) Option {
+	return func(c *Config) {
+		c.MetadataKeys = n
+	}
+}
+
+// WithGapProbability sets the chance that an item is a gap.
+func WithGapProbability(p float64) Option {
+	return func(c *Config) {
+		c.GapProbability = p
+	}
+}
+
+// WithTransitionProbability sets the chance of a transition between two
+// adjacent clips.
+func WithTransitionProbability(p float64) Option {
+	return func(c *Config) {
+		c.TransitionProbability = p
+	}
+}
+
+// WithNestedStackProbability sets the chance that an item is a nested
+// stack.
+func WithNestedStackProbability(p float64) Option {
+	return func(c *Config) {
+		c.NestedStackProbability = p
+	}
+}
+
+// WithMarkerProbability sets the chance that a clip has a marker.
+func WithMarkerProbability(p float64) Option {
+	return func(c *Config) {
+		c.MarkerProbability = p
+	}
+}
+
+func newConfig(opts []Option) Config {
+	cfg := Config{
+		Seed:          1,
+		Name:          "generated",
+		Rate:          24,
+		VideoTracks:   1,
+		AudioTracks:   1,
+		ClipsPerTrack: 10,
+		ClipDuration:  Uniform(24, 240),
+		MetadataKeys:  4,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// NewTimeline generates a timeline. The defaults are one video and one
+// audio track of ten clips between one and ten seconds long at 24 fps,
+// with seed 1.
+func NewTimeline(opts ...Option) *gotio.Timeline {
+	cfg := newConfig(opts)
+	g := &generator{cfg: cfg, rng: rand.New(rand.NewPCG(cfg.Seed, cfg.Seed))}
+
+	start := opentime.NewRationalTime(86400, cfg.Rate)
+	timeline := gotio.NewTimeline(cfg.Name, &start, gotio.AnyDictionary{
+		"project": "generated",
+		"seed":    int64(cfg.Seed),
+	})
+	for i := range cfg.VideoTracks {
+		timeline.Tracks().AppendChild(g.track(fmt.Sprintf("V%d", i+1), gotio.TrackKindVideo))
+	}
+	for i := range cfg.AudioTracks {
+		timeline.Tracks().AppendChild(g.track(fmt.Sprintf("A%d", i+1), gotio.TrackKindAudio))
+	}
+	return timeline
+}
+
+// generator builds the parts of a timeline.
+type generator struct {
+	cfg   Config
+	rng   *rand.Rand
+	clips int
+}
+
+func (g *generator) frames(n int) opentime.RationalTime {
+	return opentime.NewRationalTime(float64(n), g.cfg.Rate)
+}
+
+func (g *generator) duration() int {
+	return max(1, g.cfg.ClipDuration(g.rng))
+}
+
+func (g *generator) chance(p float64) bool {
+	return p > 0 && g.rng.Float64() < p
+}
+
+// track generates a track, adding transitions only between clips.
+func (g *generator) track(name, kind string) *gotio.Track {
+	track := gotio.NewTrack(name, nil, kind, gotio.AnyDictionary{"locked": false, "muted": false}, nil)
+	var previous *gotio.Clip
+	for range g.cfg.ClipsPerTrack {
+		duration := g.duration()
+		switch {
+		case g.chance(g.cfg.GapProbability):
+			sr := opentime.NewTimeRange(g.frames(0), g.frames(duration))
+			track.AppendChild(gotio.NewGap("", &sr, nil, nil, nil, nil))
+			previous = nil
+		case g.chance(g.cfg.NestedStackProbability):
+			track.AppendChild(g.stack(kind, duration))
+			previous = nil
+		default:
+			clip := g.clip(duration)
+			if previous != nil && g.chance(g.cfg.TransitionProbability) {
+				prev, _ := previous.Duration()
+				// Each side of a transition fits in the clip beside it.
+				half := min(12, int(prev.Value())/2, duration/2)
+				if half > 0 {
+					track.AppendChild(gotio.NewTransition("", gotio.TransitionTypeSMPTEDissolve, g.frames(half), g.frames(half), nil))
+				}
+			}
+			track.AppendChild(clip)
+			previous = clip
+		}
+	}
+	return track
+}
+
+// stack generates a nested stack of two tracks holding one clip each.
+func (g *generator) stack(kind string, duration int) *gotio.Stack {
+	stack := gotio.NewStack(fmt.Sprintf("Nest_%04d", g.clips), nil, nil, nil, nil, nil)
+	for i := range 2 {
+		track := gotio.NewTrack(fmt.Sprintf("Nest_%d", i+1), nil, kind, nil, nil)
+		track.AppendChild(g.clip(duration))
+		stack.AppendChild(track)
+	}
+	return stack
+}
+
+// clip generates a clip with a media reference of some length beyond its
+// source range.
+func (g *generator) clip(duration int) *gotio.Clip {
+	index := g.clips
+	g.clips++
+
+	available := duration + g.rng.IntN(duration+1)
+	ar := opentime.NewTimeRange(g.frames(0), g.frames(available))
+	ref := gotio.NewExternalReference(fmt.Sprintf("media_%d", index),
+		fmt.Sprintf("file:///media/project/footage/clip_%05d.mov", index), &ar, gotio.AnyDictionary{
+			"codec":      "ProRes422HQ",
+			"resolution": "1920x1080",
+		})
+	sr := opentime.NewTimeRange(g.frames(g.rng.IntN(available-duration+1)), g.frames(duration))
+
+	var markers []*gotio.Marker
+	if g.chance(g.cfg.MarkerProbability) {
+		colors := gotio.MarkerColors()
+		at := sr.StartTime().Add(g.frames(g.rng.IntN(duration)))
+		markers = append(markers, gotio.NewMarker(fmt.Sprintf("note_%d", index),
+			opentime.NewTimeRange(at, g.frames(0)), colors[g.rng.IntN(len(colors))], "", nil))
+	}
+	return gotio.NewClip(fmt.Sprintf("Shot_%04d", index), ref, &sr, g.metadata(index), nil, markers, "", nil)
+}
+
+// metadata generates the configured number of metadata entries, cycling
+// through the value types found in real clip metadata.
+func (g *generator) metadata(index int) gotio.AnyDictionary {
+	md := make(gotio.AnyDictionary, g.cfg.MetadataKeys)
+	for i := range g.cfg.MetadataKeys {
+		key := fmt.Sprintf("field_%02d", i)
+		switch i % 4 {
+		case 0:
+			md[key] = fmt.Sprintf("Scene_%d", index/10)
+		case 1:
+			md[key] = int64(g.rng.IntN(100))
+		case 2:
+			md[key] = g.rng.IntN(2) == 1
+		default:
+			md[key] = math.Round(g.rng.Float64()*1000) / 1000
+		}
+	}
+	return md
+}
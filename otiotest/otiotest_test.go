@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package otiotest
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/validate"
+)
+
+func TestNewTimelineDeterministic(t *testing.T) {
+	opts := []Option{
+		WithTracks(2, 1),
+		WithClipsPerTrack(50),
+		WithGapProbability(0.1),
+		WithTransitionProbability(0.3),
+		WithNestedStackProbability(0.1),
+		WithMarkerProbability(0.2),
+	}
+	a := NewTimeline(append(opts, WithSeed(7))...)
+	if !a.IsEquivalentTo(NewTimeline(append(opts, WithSeed(7))...)) {
+		t.Error("expected the same seed to generate the same timeline")
+	}
+	if a.IsEquivalentTo(NewTimeline(append(opts, WithSeed(8))...)) {
+		t.Error("expected different seeds to generate different timelines")
+	}
+}
+
+func TestNewTimelineShape(t *testing.T) {
+	timeline := NewTimeline(
+		WithSeed(3),
+		WithTracks(3, 2),
+		WithClipsPerTrack(200),
+		WithClipDuration(Uniform(10, 20)),
+		WithMetadataKeys(6),
+		WithGapProbability(0.2),
+		WithTransitionProbability(0.5),
+		WithNestedStackProbability(0.1),
+		WithMarkerProbability(0.5),
+	)
+
+	if len(timeline.VideoTracks()) != 3 || len(timeline.AudioTracks()) != 2 {
+		t.Fatalf("expected 3 video and 2 audio tracks, got %d and %d",
+			len(timeline.VideoTracks()), len(timeline.AudioTracks()))
+	}
+
+	var gaps, stacks, transitions int
+	for _, child := range timeline.Tracks().Children() {
+		items := 0
+		for _, item := range child.(*gotio.Track).Children() {
+			switch item := item.(type) {
+			case *gotio.Gap:
+				gaps++
+			case *gotio.Stack:
+				stacks++
+			case *gotio.Transition:
+				transitions++
+				continue
+			case *gotio.Clip:
+				if d, _ := item.Duration(); d.Value() < 10 || d.Value() > 20 {
+					t.Errorf("clip %s duration %v outside 10-20 frames", item.Name(), d)
+				}
+				if len(item.Metadata()) != 6 {
+					t.Errorf("clip %s has %d metadata entries, want 6", item.Name(), len(item.Metadata()))
+				}
+			}
+			items++
+		}
+		if items != 200 {
+			t.Errorf("track %s has %d items, want 200", child.Name(), items)
+		}
+	}
+	if gaps == 0 || stacks == 0 || transitions == 0 {
+		t.Errorf("expected gaps, stacks and transitions, got %d, %d and %d", gaps, stacks, transitions)
+	}
+
+	for _, issue := range validate.Validate(timeline) {
+		if issue.Severity == validate.SeverityError {
+			t.Errorf("generated timeline has error %s: %s", issue.Rule, issue.Message)
+		}
+	}
+}
+
+func TestNewTimelineDefaults(t *testing.T) {
+	timeline := NewTimeline()
+	if timeline.Name() != "generated" || len(timeline.Tracks().Children()) != 2 {
+		t.Fatalf("unexpected default timeline %q with %d tracks", timeline.Name(), len(timeline.Tracks().Children()))
+	}
+	if clips := timeline.FindClips(nil, false); len(clips) != 20 {
+		t.Errorf("expected 20 clips, got %d", len(clips))
+	}
+	if start := timeline.GlobalStartTime(); start == nil || start.Value() != 86400 || start.Rate() != 24 {
+		t.Errorf("GlobalStartTime = %v, want 86400@24", start)
+	}
+}
+
+func TestDistributions(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 1))
+	if n := Constant(5)(r); n != 5 {
+		t.Errorf("Constant(5) = %d", n)
+	}
+	uniform := Uniform(20, 10)
+	normal := Normal(10, 50, 1)
+	for range 1000 {
+		if n := uniform(r); n < 10 || n > 20 {
+			t.Fatalf("Uniform returned %d outside 10-20", n)
+		}
+		if n := normal(r); n < 1 {
+			t.Fatalf("Normal returned %d below its minimum", n)
+		}
+	}
+}
+
+func BenchmarkNewTimeline(b *testing.B) {
+	for b.Loop() {
+		NewTimeline(WithTracks(4, 4), WithClipsPerTrack(500), WithTransitionProbability(0.2))
+	}
+}
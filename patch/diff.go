@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package patch
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+// Diff returns a JSON Patch that turns a into b when passed to Apply.
+// Items inserted into or removed from the middle of a track become single
+// add and remove operations; items changed in place become operations on
+// their fields.
+func Diff(a, b *gotio.Timeline) ([]byte, error) {
+	docA, err := toDocument(a)
+	if err != nil {
+		return nil, err
+	}
+	docB, err := toDocument(b)
+	if err != nil {
+		return nil, err
+	}
+	ops, err := diff("", docA, docB, []Operation{})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(ops)
+}
+
+func diff(path string, a, b any, ops []Operation) ([]Operation, error) {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(av))
+		for key := range av {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		var err error
+		for _, key := range keys {
+			child := path + "/" + escape(key)
+			if value, ok := bv[key]; ok {
+				ops, err = diff(child, av[key], value, ops)
+			} else {
+				ops = append(ops, Operation{Op: "remove", Path: child})
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		keys = keys[:0]
+		for key := range bv {
+			if _, ok := av[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if ops, err = appendValueOp(ops, "add", path+"/"+escape(key), bv[key]); err != nil {
+				return nil, err
+			}
+		}
+		return ops, nil
+	case []any:
+		if bv, ok := b.([]any); ok {
+			return diffArrays(path, av, bv, ops)
+		}
+	}
+	if reflect.DeepEqual(a, b) {
+		return ops, nil
+	}
+	return appendValueOp(ops, "replace", path, b)
+}
+
+// diffArrays skips the elements a and b share at the start and end, diffs
+// the remaining elements pairwise and removes or adds the rest.
+func diffArrays(path string, a, b []any, ops []Operation) ([]Operation, error) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && reflect.DeepEqual(a[prefix], b[prefix]) {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		reflect.DeepEqual(a[len(a)-1-suffix], b[len(b)-1-suffix]) {
+		suffix++
+	}
+	a, b = a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	common := min(len(a), len(b))
+	var err error
+	for i := range common {
+		if ops, err = diff(path+"/"+strconv.Itoa(prefix+i), a[i], b[i], ops); err != nil {
+			return nil, err
+		}
+	}
+	for i := len(a) - 1; i >= common; i-- {
+		ops = append(ops, Operation{Op: "remove", Path: path + "/" + strconv.Itoa(prefix+i)})
+	}
+	for i := common; i < len(b); i++ {
+		if ops, err = appendValueOp(ops, "add", path+"/"+strconv.Itoa(prefix+i), b[i]); err != nil {
+			return nil, err
+		}
+	}
+	return ops, nil
+}
+
+func appendValueOp(ops []Operation, op, path string, value any) ([]Operation, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return append(ops, Operation{Op: op, Path: path, Value: data}), nil
+}
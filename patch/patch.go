@@ -0,0 +1,415 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package patch applies JSON Patch (RFC 6902) and JSON Merge Patch
+// (RFC 7396) documents to timelines, and generates JSON Patches from the
+// differences between two timelines, so an editor can send small changes
+// instead of whole documents.
+//
+// Patches address the OTIO JSON of a timeline, for example
+// "/tracks/children/0/children/3/source_range/duration/value". The patched
+// document must still be a valid timeline: every object must decode with
+// its schema and encode back to the same JSON, so misspelled fields, wrong
+// value types and missing fields are rejected.
+//
+// Basic usage:
+//
+//	ops, err := patch.Diff(before, after)
+//	...
+//	updated, err := patch.Apply(before, ops)
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+func init() {
+	gotio.RegisterFeature("patch")
+}
+
+var (
+	// ErrInvalidPatch is returned for a patch that is not well formed or
+	// an operation whose path does not exist.
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrTestFailed is returned when a test operation does not match.
+	ErrTestFailed = errors.New("patch test failed")
+	// ErrInvalidResult is returned when the patched document is not a
+	// valid timeline.
+	ErrInvalidResult = errors.New("patched document is not a valid timeline")
+)
+
+// Operation is a JSON Patch operation.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Apply applies a JSON Patch to a copy of timeline and returns the result.
+// The operations are applied in order and the patch fails as a whole if
+// any of them fails.
+func Apply(timeline *gotio.Timeline, patch []byte) (*gotio.Timeline, error) {
+	var ops []Operation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	doc, err := toDocument(timeline)
+	if err != nil {
+		return nil, err
+	}
+	for i, op := range ops {
+		if doc, err = applyOperation(doc, op); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return fromDocument(doc)
+}
+
+// ApplyMerge applies a JSON Merge Patch to a copy of timeline and returns
+// the result. Merge patches replace arrays, such as the children of a
+// track, as a whole.
+func ApplyMerge(timeline *gotio.Timeline, patch []byte) (*gotio.Timeline, error) {
+	var p any
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	doc, err := toDocument(timeline)
+	if err != nil {
+		return nil, err
+	}
+	return fromDocument(mergePatch(doc, p))
+}
+
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any, len(p))
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+		} else {
+			t[key] = mergePatch(t[key], value)
+		}
+	}
+	return t
+}
+
+// toDocument returns the OTIO JSON of obj as generic values.
+func toDocument(obj gotio.SerializableObject) (any, error) {
+	data, err := gotio.ToJSONBytes(obj)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := json.Unmarshal(gotio.SanitizeJSON(data), &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// fromDocument decodes a patched document, checking that it is a timeline
+// that encodes back to the same document.
+func fromDocument(doc any) (*gotio.Timeline, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResult, err)
+	}
+	obj, err := gotio.FromJSONBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResult, err)
+	}
+	timeline, ok := obj.(*gotio.Timeline)
+	if !ok {
+		return nil, fmt.Errorf("%w: document is a %s", ErrInvalidResult, obj.SchemaName())
+	}
+	back, err := toDocument(timeline)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResult, err)
+	}
+	if path, differs := firstDifference("", doc, back); differs {
+		return nil, fmt.Errorf("%w: %q does not match its schema", ErrInvalidResult, path)
+	}
+	return timeline, nil
+}
+
+// firstDifference returns the pointer to the first place a and b differ.
+func firstDifference(path string, a, b any) (string, bool) {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			return path, true
+		}
+		keys := make([]string, 0, len(av)+len(bv))
+		for key := range av {
+			keys = append(keys, key)
+		}
+		for key := range bv {
+			if _, ok := av[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if p, differs := firstDifference(path+"/"+escape(key), av[key], bv[key]); differs {
+				return p, true
+			}
+		}
+		return "", false
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return path, true
+		}
+		for i := range av {
+			if p, differs := firstDifference(path+"/"+strconv.Itoa(i), av[i], bv[i]); differs {
+				return p, true
+			}
+		}
+		return "", false
+	}
+	return path, !reflect.DeepEqual(a, b)
+}
+
+func applyOperation(doc any, op Operation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("%w: missing value", ErrInvalidPatch)
+		}
+		var value any
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		switch op.Op {
+		case "add":
+			return add(doc, path, value)
+		case "replace":
+			if len(path) == 0 {
+				return value, nil
+			}
+			if _, err := get(doc, path); err != nil {
+				return nil, err
+			}
+			doc, _, err := remove(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			return add(doc, path, value)
+		default:
+			current, err := get(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, ErrTestFailed
+			}
+			return doc, nil
+		}
+	case "remove":
+		doc, _, err := remove(doc, path)
+		return doc, err
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			value, err := get(doc, from)
+			if err != nil {
+				return nil, err
+			}
+			return add(doc, path, deepCopy(value))
+		}
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("%w: cannot move %q into itself", ErrInvalidPatch, op.From)
+		}
+		doc, value, err := remove(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	default:
+		return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, op.Op)
+	}
+}
+
+// parsePointer splits a JSON Pointer into its unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("%w: path %q does not start with /", ErrInvalidPatch, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// escape escapes a key for use in a JSON Pointer.
+func escape(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// arrayIndex parses an array index token. With end set, "-" and len(a)
+// address the position after the last element.
+func arrayIndex(token string, a []any, end bool) (int, error) {
+	if end && token == "-" {
+		return len(a), nil
+	}
+	i, err := strconv.Atoi(token)
+	limit := len(a)
+	if end {
+		limit++
+	}
+	if err != nil || i < 0 || i >= limit || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, token)
+	}
+	return i, nil
+}
+
+func get(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch c := doc.(type) {
+		case map[string]any:
+			value, ok := c[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: no member %q", ErrInvalidPatch, token)
+			}
+			doc = value
+		case []any:
+			i, err := arrayIndex(token, c, false)
+			if err != nil {
+				return nil, err
+			}
+			doc = c[i]
+		default:
+			return nil, fmt.Errorf("%w: %q is not in a container", ErrInvalidPatch, token)
+		}
+	}
+	return doc, nil
+}
+
+// update calls fn with the container holding the last token of path and
+// returns doc with the container fn returns in its place.
+func update(doc any, path []string, fn func(container any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+	switch c := doc.(type) {
+	case map[string]any:
+		child, ok := c[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("%w: no member %q", ErrInvalidPatch, path[0])
+		}
+		updated, err := update(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		c[path[0]] = updated
+		return c, nil
+	case []any:
+		i, err := arrayIndex(path[0], c, false)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := update(c[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		c[i] = updated
+		return c, nil
+	default:
+		return nil, fmt.Errorf("%w: %q is not in a container", ErrInvalidPatch, path[0])
+	}
+}
+
+func add(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return update(doc, path, func(container any, token string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			c[token] = value
+			return c, nil
+		case []any:
+			i, err := arrayIndex(token, c, true)
+			if err != nil {
+				return nil, err
+			}
+			return slices.Insert(c, i, value), nil
+		default:
+			return nil, fmt.Errorf("%w: %q is not in a container", ErrInvalidPatch, token)
+		}
+	})
+}
+
+func remove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: cannot remove the document", ErrInvalidPatch)
+	}
+	var removed any
+	doc, err := update(doc, path, func(container any, token string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			value, ok := c[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: no member %q", ErrInvalidPatch, token)
+			}
+			removed = value
+			delete(c, token)
+			return c, nil
+		case []any:
+			i, err := arrayIndex(token, c, false)
+			if err != nil {
+				return nil, err
+			}
+			removed = c[i]
+			return slices.Delete(c, i, i+1), nil
+		default:
+			return nil, fmt.Errorf("%w: %q is not in a container", ErrInvalidPatch, token)
+		}
+	})
+	return doc, removed, err
+}
+
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(v))
+		for key, child := range v {
+			c[key] = deepCopy(child)
+		}
+		return c
+	case []any:
+		c := make([]any, len(v))
+		for i, child := range v {
+			c[i] = deepCopy(child)
+		}
+		return c
+	default:
+		return value
+	}
+}
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/otiotest"
+)
+
+func TestApply(t *testing.T) {
+	timeline := otiotest.NewTimeline(otiotest.WithClipsPerTrack(3), otiotest.WithGapProbability(0),
+		otiotest.WithTransitionProbability(0), otiotest.WithNestedStackProbability(0))
+	before, _ := gotio.ToJSONBytesCanonical(timeline)
+
+	patch := `[
+		{"op": "test", "path": "/tracks/children/0/kind", "value": "Video"},
+		{"op": "replace", "path": "/name", "value": "patched"},
+		{"op": "add", "path": "/metadata/review", "value": {"status": "approved"}},
+		{"op": "replace", "path": "/tracks/children/0/children/1/source_range/duration/value", "value": 12},
+		{"op": "move", "from": "/tracks/children/0/children/2", "path": "/tracks/children/0/children/0"},
+		{"op": "copy", "from": "/tracks/children/1/children/0", "path": "/tracks/children/1/children/-"},
+		{"op": "remove", "path": "/tracks/children/1/children/1"}
+	]`
+	got, err := Apply(timeline, []byte(patch))
+	if err != nil {
+		t.Fatalf("Apply error: %v", err)
+	}
+	if got.Name() != "patched" {
+		t.Errorf("name = %q", got.Name())
+	}
+	if review, ok := got.Metadata()["review"].(map[string]any); !ok || review["status"] != "approved" {
+		t.Errorf("metadata = %v", got.Metadata())
+	}
+
+	video := got.VideoTracks()[0].Children()
+	original := timeline.VideoTracks()[0].Children()
+	if video[0].Name() != original[2].Name() || video[1].Name() != original[0].Name() {
+		t.Errorf("move did not reorder clips: %s, %s", video[0].Name(), video[1].Name())
+	}
+	if d, _ := video[2].Duration(); d.Value() != 12 {
+		t.Errorf("patched duration = %v, want 12", d.Value())
+	}
+	audio := got.AudioTracks()[0].Children()
+	if len(audio) != 3 || audio[2].Name() != audio[0].Name() {
+		t.Errorf("unexpected audio track after copy and remove: %d items", len(audio))
+	}
+
+	after, _ := gotio.ToJSONBytesCanonical(timeline)
+	if string(before) != string(after) {
+		t.Error("Apply modified its input")
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	timeline := otiotest.NewTimeline(otiotest.WithClipsPerTrack(2))
+	tests := []struct {
+		patch string
+		want  error
+	}{
+		{`{"op": "add"}`, ErrInvalidPatch},
+		{`[{"op": "frobnicate", "path": "/name"}]`, ErrInvalidPatch},
+		{`[{"op": "remove", "path": "/missing"}]`, ErrInvalidPatch},
+		{`[{"op": "replace", "path": "/tracks/children/9", "value": {}}]`, ErrInvalidPatch},
+		{`[{"op": "add", "path": "/tracks/children/01", "value": {}}]`, ErrInvalidPatch},
+		{`[{"op": "move", "from": "/tracks", "path": "/tracks/children/0"}]`, ErrInvalidPatch},
+		{`[{"op": "test", "path": "/name", "value": "other"}]`, ErrTestFailed},
+		{`[{"op": "add", "path": "/naem", "value": "typo"}]`, ErrInvalidResult},
+		{`[{"op": "replace", "path": "/tracks/children/0/kind", "value": 3}]`, ErrInvalidResult},
+		{`[{"op": "remove", "path": "/tracks/children/0/children/0/source_range/duration/rate"}]`, ErrInvalidResult},
+		{`[{"op": "replace", "path": "", "value": {"OTIO_SCHEMA": "Gap.1"}}]`, ErrInvalidResult},
+	}
+	for _, tt := range tests {
+		if _, err := Apply(timeline, []byte(tt.patch)); !errors.Is(err, tt.want) {
+			t.Errorf("Apply(%s) error = %v, want %v", tt.patch, err, tt.want)
+		}
+	}
+
+	_, err := Apply(timeline, []byte(`[{"op": "add", "path": "/tracks/children/0/naem", "value": "typo"}]`))
+	if err == nil || !strings.Contains(err.Error(), "/tracks/children/0/naem") {
+		t.Errorf("expected the error to name the invalid field, got %v", err)
+	}
+}
+
+func TestApplyMerge(t *testing.T) {
+	timeline := otiotest.NewTimeline()
+	got, err := ApplyMerge(timeline, []byte(`{"name": "merged", "metadata": {"seed": null, "notes": "ok"}}`))
+	if err != nil {
+		t.Fatalf("ApplyMerge error: %v", err)
+	}
+	md := got.Metadata()
+	if got.Name() != "merged" || md["notes"] != "ok" {
+		t.Errorf("unexpected result %q %v", got.Name(), md)
+	}
+	if _, ok := md["seed"]; ok {
+		t.Error("null did not remove seed")
+	}
+	if _, ok := md["project"]; !ok {
+		t.Error("merge removed project")
+	}
+
+	if _, err := ApplyMerge(timeline, []byte(`{"tracks": null}`)); !errors.Is(err, ErrInvalidResult) {
+		t.Errorf("expected ErrInvalidResult removing tracks, got %v", err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	for seed := uint64(1); seed <= 20; seed++ {
+		a := otiotest.NewTimeline(otiotest.WithSeed(seed))
+		b := otiotest.NewTimeline(otiotest.WithSeed(seed + 100))
+		patch, err := Diff(a, b)
+		if err != nil {
+			t.Fatalf("seed %d: Diff error: %v", seed, err)
+		}
+		got, err := Apply(a, patch)
+		if err != nil {
+			t.Fatalf("seed %d: Apply error: %v", seed, err)
+		}
+		if !got.IsEquivalentTo(b) {
+			t.Fatalf("seed %d: applying the diff did not produce the second timeline", seed)
+		}
+	}
+
+	a := otiotest.NewTimeline()
+	if patch, err := Diff(a, a); err != nil || string(patch) != "[]" {
+		t.Errorf("Diff of identical timelines = %s, %v", patch, err)
+	}
+}
+
+func TestDiffInsertedClip(t *testing.T) {
+	a := otiotest.NewTimeline(otiotest.WithTracks(1, 0))
+	b := otiotest.NewTimeline(otiotest.WithTracks(1, 0))
+	track := b.VideoTracks()[0]
+	if err := track.InsertChild(4, gotio.NewGapWithDuration(opentime.NewRationalTime(24, 24))); err != nil {
+		t.Fatalf("InsertChild error: %v", err)
+	}
+
+	patch, err := Diff(a, b)
+	if err != nil {
+		t.Fatalf("Diff error: %v", err)
+	}
+	var ops []Operation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		t.Fatalf("invalid patch: %v", err)
+	}
+	if len(ops) != 1 || ops[0].Op != "add" || ops[0].Path != "/tracks/children/0/children/4" {
+		t.Errorf("expected a single add, got %s", patch)
+	}
+}
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DecodeFunc builds a step from its config entry, a JSON object with the
+// step's name under "step" and its settings as other fields.
+type DecodeFunc func(data []byte) (Step, error)
+
+var (
+	decoders   = make(map[string]DecodeFunc)
+	decodersMu sync.RWMutex
+)
+
+func init() {
+	Register("read", func(data []byte) (Step, error) { return decodeStep(data, &Read{}) })
+	Register("write", func(data []byte) (Step, error) { return decodeStep(data, &Write{}) })
+	Register("validate", func(data []byte) (Step, error) { return decodeStep(data, &Validate{}) })
+	Register("relink", func(data []byte) (Step, error) { return decodeStep(data, &Relink{}) })
+	Register("conform_rate", func(data []byte) (Step, error) { return decodeStep(data, &ConformRate{}) })
+	Register("handles", func(data []byte) (Step, error) { return decodeStep(data, &Handles{}) })
+	Register("bundle", func(data []byte) (Step, error) { return decodeStep(data, &Bundle{}) })
+}
+
+// Register makes a step available to configs under name, replacing any
+// step registered under it.
+func Register(name string, decode DecodeFunc) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	decoders[name] = decode
+}
+
+// Available returns the names of the registered steps, sorted.
+func Available() []string {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+	names := make([]string, 0, len(decoders))
+	for name := range decoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// decodeStep decodes data into step and checks its settings.
+func decodeStep(data []byte, step Step) (Step, error) {
+	if err := json.Unmarshal(data, step); err != nil {
+		return nil, err
+	}
+	if c, ok := step.(interface{ check() error }); ok {
+		if err := c.check(); err != nil {
+			return nil, err
+		}
+	}
+	return step, nil
+}
+
+// Config is the layout of a pipeline config:
+//
+//	{
+//	  "steps": [
+//	    {"step": "read", "rate": 24},
+//	    {"step": "validate", "fail_on": "error", "fix": true},
+//	    {"step": "relink", "search_paths": ["/media/plates"], "extensions": [".mov"]},
+//	    {"step": "conform_rate", "rate": 24, "policy": "nearest_frame"},
+//	    {"step": "handles", "frames": 8},
+//	    {"step": "write", "path": "/conformed/{name}.otio"},
+//	    {"step": "bundle", "path": "/conformed/{name}.otioz"}
+//	  ]
+//	}
+//
+// or the same in YAML:
+//
+//	steps:
+//	  - step: read
+//	    rate: 24
+//	  - step: relink
+//	    search_paths: [/media/plates]
+//	    extensions: [.mov]
+//	  - step: write
+//	    path: /conformed/{name}.otio
+type Config struct {
+	Steps []json.RawMessage `json:"steps"`
+}
+
+// Build returns the pipeline described by the config.
+func (c Config) Build() (*Pipeline, error) {
+	p := New()
+	for i, data := range c.Steps {
+		var entry struct {
+			Step string `json:"step"`
+		}
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("step %d: %w", i, err)
+		}
+		decodersMu.RLock()
+		decode, ok := decoders[entry.Step]
+		decodersMu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("step %d: %w %q", i, ErrUnknownStep, entry.Step)
+		}
+		step, err := decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("step %d (%s): %w", i, entry.Step, err)
+		}
+		p.Then(step)
+	}
+	return p, nil
+}
+
+// Parse returns the pipeline described by a JSON config.
+func Parse(data []byte) (*Pipeline, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return cfg.Build()
+}
+
+// ParseYAML returns the pipeline described by a YAML config, read as by
+// YAMLToJSON.
+func ParseYAML(data []byte) (*Pipeline, error) {
+	data, err := YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Load returns the pipeline described by the config file at path, read as
+// YAML if its suffix is .yaml or .yml and as JSON otherwise.
+func Load(path string) (*Pipeline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	parse := Parse
+	if IsYAML(path) {
+		parse = ParseYAML
+	}
+	p, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// IsYAML reports whether path has the .yaml or .yml suffix of a YAML
+// config.
+func IsYAML(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
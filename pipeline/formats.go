@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package pipeline
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"strings"
+
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/adapters"
+	"github.com/Avalanche-io/gotio/adapters/otioscript"
+	"github.com/Avalanche-io/gotio/adapters/shotlist"
+	"github.com/Avalanche-io/gotio/adapters/xmeml"
+)
+
+// ReadFile reads the timeline at path in the format chosen by its suffix:
+// .otio JSON, .otioscript, .csv shot lists or .xml Final Cut Pro 7 XML.
+// Other suffixes are read by external adapters found with
+// adapters.DiscoverExternal. Rate is the frame rate for formats that need
+// one. Adapter options, merged in order, configure the adapter reading
+// the file, and override rate.
+func ReadFile(path string, rate float64, opts ...adapters.Options) (*gotio.Timeline, error) {
+	o := mergeOptions(opts)
+	var obj gotio.SerializableObject
+	var err error
+	switch suffix(path) {
+	case ".otio":
+		obj, err = gotio.FromJSONFile(path)
+	case ".otioscript":
+		options, err := otioscript.FromOptions(o)
+		if err != nil {
+			return nil, err
+		}
+		return otioscript.ReadFile(path, append([]otioscript.Option{otioscript.WithRate(rate)}, options...)...)
+	case ".csv":
+		options, err := shotlist.FromOptions(o)
+		if err != nil {
+			return nil, err
+		}
+		return shotlist.ReadFile(path, append([]shotlist.Option{shotlist.WithRate(rate)}, options...)...)
+	case ".xml":
+		options, err := xmeml.FromOptions(o)
+		if err != nil {
+			return nil, err
+		}
+		return xmeml.ReadFile(path, append([]xmeml.Option{xmeml.WithRate(rate)}, options...)...)
+	default:
+		adapter, ok := adapters.LookupExternal(suffix(path))
+		if !ok {
+			return nil, fmt.Errorf("cannot read %s: unknown suffix", path)
+		}
+		obj, err = adapter.ReadWithOptions(path, o)
+	}
+	if err != nil {
+		return nil, err
+	}
+	timeline, ok := obj.(*gotio.Timeline)
+	if !ok {
+		return nil, fmt.Errorf("%s holds a %s, not a Timeline", path, obj.SchemaName())
+	}
+	return timeline, nil
+}
+
+// WriteFile writes the timeline to path in the format chosen by its
+// suffix, as for ReadFile.
+func WriteFile(timeline *gotio.Timeline, path string, rate float64, opts ...adapters.Options) error {
+	o := mergeOptions(opts)
+	switch suffix(path) {
+	case ".otio":
+		return gotio.ToJSONFile(timeline, path, "    ")
+	case ".otioscript":
+		options, err := otioscript.FromOptions(o)
+		if err != nil {
+			return err
+		}
+		return otioscript.WriteFile(timeline, path, append([]otioscript.Option{otioscript.WithRate(rate)}, options...)...)
+	case ".csv":
+		options, err := shotlist.FromOptions(o)
+		if err != nil {
+			return err
+		}
+		return shotlist.WriteFile(timeline, path, append([]shotlist.Option{shotlist.WithRate(rate)}, options...)...)
+	case ".xml":
+		options, err := xmeml.FromOptions(o)
+		if err != nil {
+			return err
+		}
+		return xmeml.WriteFile(timeline, path, append([]xmeml.Option{xmeml.WithRate(rate)}, options...)...)
+	}
+	adapter, ok := adapters.LookupExternal(suffix(path))
+	if !ok {
+		return fmt.Errorf("cannot write %s: unknown suffix", path)
+	}
+	return adapter.WriteWithOptions(timeline, path, o)
+}
+
+// mergeOptions merges adapter options, later ones winning.
+func mergeOptions(opts []adapters.Options) adapters.Options {
+	merged := make(adapters.Options)
+	for _, o := range opts {
+		maps.Copy(merged, o)
+	}
+	return merged
+}
+
+// Readable reports whether ReadFile reads files with the suffix of path
+// without an external adapter.
+func Readable(path string) bool {
+	switch suffix(path) {
+	case ".otio", ".otioscript", ".csv", ".xml":
+		return true
+	}
+	return false
+}
+
+func suffix(path string) string {
+	return strings.ToLower(filepath.Ext(path))
+}
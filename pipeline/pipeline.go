@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package pipeline composes the steps of a conform into one pipeline run on
+// each input: reading a timeline in any format gotio reads, validating it,
+// relinking its media, conforming its rate, adding handles, and writing or
+// bundling the result.
+//
+// Steps share a State holding the timeline and what each step reported, so
+// a pipeline built in Go and one loaded from a config run the same way.
+//
+// Basic usage:
+//
+//	p := pipeline.New(
+//		&pipeline.Read{Rate: 24},
+//		&pipeline.Validate{FailOn: "error"},
+//		&pipeline.Relink{SearchPaths: []string{"/media/plates"}},
+//		&pipeline.Write{Path: "/conformed/{name}.otio"},
+//	)
+//	state, err := p.Run(ctx, "/incoming/cut.xml")
+//
+// Or from a JSON or YAML config, as used by otiowatch:
+//
+//	p, err := pipeline.Load("conform.yaml")
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/algorithms"
+	"github.com/Avalanche-io/gotio/validate"
+)
+
+func init() {
+	gotio.RegisterFeature("pipeline")
+}
+
+var (
+	// ErrInvalid is returned by Validate for a timeline with an issue at or
+	// above its FailOn severity. Retrying cannot fix it.
+	ErrInvalid = errors.New("timeline failed validation")
+	// ErrNoTimeline is returned by a step that needs a timeline when no
+	// earlier step read one.
+	ErrNoTimeline = errors.New("no timeline to process")
+	// ErrUnknownStep is returned when loading a config naming a step that
+	// is not registered.
+	ErrUnknownStep = errors.New("unknown pipeline step")
+)
+
+// State is passed from step to step during a run.
+type State struct {
+	// Input is the path the pipeline was run on, empty for RunTimeline.
+	Input string
+	// Timeline is the timeline being processed.
+	Timeline *gotio.Timeline
+	// Issues are the issues found by Validate steps.
+	Issues []*validate.Issue
+	// Fixed counts the issues fixed by Validate steps.
+	Fixed int
+	// Relinked counts the clips given a new reference by Relink steps.
+	Relinked int
+	// Shortfalls are the clips Handles steps could not give full handles.
+	Shortfalls []algorithms.HandleShortfall
+	// Outputs are the files written, in order.
+	Outputs []string
+}
+
+// timeline returns the state's timeline, or ErrNoTimeline.
+func (s *State) timeline() (*gotio.Timeline, error) {
+	if s.Timeline == nil {
+		return nil, ErrNoTimeline
+	}
+	return s.Timeline, nil
+}
+
+// Step is one stage of a pipeline.
+type Step interface {
+	// Name returns the name of the step, as used in configs.
+	Name() string
+	// Run processes the state.
+	Run(ctx context.Context, state *State) error
+}
+
+type stepFunc struct {
+	name string
+	run  func(ctx context.Context, state *State) error
+}
+
+func (s stepFunc) Name() string {
+	return s.name
+}
+
+func (s stepFunc) Run(ctx context.Context, state *State) error {
+	return s.run(ctx, state)
+}
+
+// Func returns a step calling run.
+func Func(name string, run func(ctx context.Context, state *State) error) Step {
+	return stepFunc{name: name, run: run}
+}
+
+// StepError is returned by a run for a failed step.
+type StepError struct {
+	// Index is the position of the step in the pipeline.
+	Index int
+	// Step is the name of the step.
+	Step string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("step %d (%s): %v", e.Index, e.Step, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Pipeline is a sequence of steps.
+type Pipeline struct {
+	steps []Step
+}
+
+// New returns a pipeline running steps in order.
+func New(steps ...Step) *Pipeline {
+	return &Pipeline{steps: steps}
+}
+
+// Then appends steps to the pipeline and returns it.
+func (p *Pipeline) Then(steps ...Step) *Pipeline {
+	p.steps = append(p.steps, steps...)
+	return p
+}
+
+// Steps returns the steps of the pipeline.
+func (p *Pipeline) Steps() []Step {
+	return append([]Step(nil), p.steps...)
+}
+
+// Run runs the pipeline on the file at input, which Read steps without a
+// path read and output paths are named after. It returns the state reached,
+// and a *StepError for the first step that failed.
+func (p *Pipeline) Run(ctx context.Context, input string) (*State, error) {
+	state := &State{Input: input}
+	return state, p.run(ctx, state)
+}
+
+// RunTimeline runs the pipeline on a timeline already read.
+func (p *Pipeline) RunTimeline(ctx context.Context, timeline *gotio.Timeline) (*State, error) {
+	state := &State{Timeline: timeline}
+	return state, p.run(ctx, state)
+}
+
+func (p *Pipeline) run(ctx context.Context, state *State) error {
+	for i, step := range p.steps {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := step.Run(ctx, state); err != nil {
+			return &StepError{Index: i, Step: step.Name(), Err: err}
+		}
+	}
+	return nil
+}
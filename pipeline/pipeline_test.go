@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func TestPipelineFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "cut.otioscript")
+	os.WriteFile(input, []byte("timeline cut rate 24\ntrack V1\nclip sh010 media /plates/sh010.mov in 0 dur 48\n"), 0644)
+	os.MkdirAll(filepath.Join(dir, "media"), 0755)
+	os.WriteFile(filepath.Join(dir, "media", "sh010.mov"), []byte("media"), 0644)
+
+	p, err := Parse([]byte(`{"steps": [
+		{"step": "read", "rate": 24},
+		{"step": "validate", "fail_on": "error"},
+		{"step": "relink", "search_paths": ["` + filepath.ToSlash(filepath.Join(dir, "media")) + `"], "extensions": [".mov"]},
+		{"step": "conform_rate", "rate": 25},
+		{"step": "write", "path": "{dir}/out/{name}.otio"}
+	]}`))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if steps := p.Steps(); len(steps) != 5 || steps[3].Name() != "conform_rate" {
+		t.Fatalf("Steps = %v", steps)
+	}
+	state, err := p.Run(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Run error: %v", err)
+	}
+	out := filepath.Join(dir, "out", "cut.otio")
+	if state.Relinked != 1 || len(state.Outputs) != 1 || state.Outputs[0] != out {
+		t.Errorf("state = %+v", state)
+	}
+	timeline, err := ReadFile(out, 0)
+	if err != nil {
+		t.Fatalf("output not readable: %v", err)
+	}
+	if d, _ := timeline.Duration(); d.Rate() != 25 || d.Value() != 50 {
+		t.Errorf("conformed duration = %v, want 50 frames at 25", d)
+	}
+}
+
+func TestPipelineErrors(t *testing.T) {
+	for _, bad := range []string{
+		`{"steps": [{"step": "transcode"}]}`,
+		`{"steps": [{"step": "validate", "fail_on": "fatal"}]}`,
+		`{"steps": [{"step": "conform_rate"}]}`,
+		`{"steps": [{"step": "bundle", "path": "out.zip"}]}`,
+	} {
+		if _, err := Parse([]byte(bad)); err == nil {
+			t.Errorf("expected an error for %s", bad)
+		}
+	}
+	if _, err := Parse([]byte(`{"steps": [{"step": "transcode"}]}`)); !errors.Is(err, ErrUnknownStep) {
+		t.Errorf("expected ErrUnknownStep, got %v", err)
+	}
+
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(-24, 24))
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	track.AppendChild(gotio.NewClip("sh010", nil, &sr, nil, nil, nil, "", nil))
+	timeline := gotio.NewTimeline("cut", nil, nil)
+	timeline.Tracks().AppendChild(track)
+
+	ran := false
+	p := New(&Validate{FailOn: "error"}, Func("after", func(ctx context.Context, state *State) error {
+		ran = true
+		return nil
+	}))
+	state, err := p.RunTimeline(context.Background(), timeline)
+	var stepErr *StepError
+	if !errors.As(err, &stepErr) || stepErr.Index != 0 || !errors.Is(err, ErrInvalid) || len(state.Issues) == 0 || ran {
+		t.Errorf("expected the validate step to fail, got %v", err)
+	}
+
+	if _, err := New(&Write{Path: "out.otio"}).Run(context.Background(), ""); !errors.Is(err, ErrNoTimeline) {
+		t.Errorf("expected ErrNoTimeline, got %v", err)
+	}
+}
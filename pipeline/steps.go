@@ -0,0 +1,331 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/adapters"
+	"github.com/Avalanche-io/gotio/algorithms"
+	"github.com/Avalanche-io/gotio/bundle"
+	"github.com/Avalanche-io/gotio/medialinker"
+	"github.com/Avalanche-io/gotio/validate"
+)
+
+// Read reads a timeline, converting it from the format chosen by the
+// file's suffix.
+type Read struct {
+	// Path is the file to read. If empty, the pipeline's input is read.
+	Path string `json:"path"`
+	// Rate is the frame rate for formats that need one.
+	Rate float64 `json:"rate"`
+	// Options configure the adapter reading the file, such as
+	// {"xmeml.rate": 25}.
+	Options adapters.Options `json:"options"`
+}
+
+// Name returns "read".
+func (*Read) Name() string {
+	return "read"
+}
+
+// Run reads the timeline into the state.
+func (s *Read) Run(ctx context.Context, state *State) error {
+	path := s.Path
+	if path == "" {
+		path = state.Input
+	}
+	if path == "" {
+		return errors.New("no path to read")
+	}
+	timeline, err := ReadFile(expand(path, state), s.Rate, s.Options)
+	if err != nil {
+		return err
+	}
+	state.Timeline = timeline
+	return nil
+}
+
+// Write writes the timeline, converting it to the format chosen by the
+// file's suffix.
+type Write struct {
+	// Path is the file to write. "{name}" is replaced by the base name of
+	// the pipeline's input without its suffix, or the timeline's name, and
+	// "{dir}" by the folder of the input.
+	Path string `json:"path"`
+	// Rate is the frame rate for formats that need one.
+	Rate float64 `json:"rate"`
+	// Options configure the adapter writing the file.
+	Options adapters.Options `json:"options"`
+}
+
+// Name returns "write".
+func (*Write) Name() string {
+	return "write"
+}
+
+func (s *Write) check() error {
+	if s.Path == "" {
+		return errors.New("path is required")
+	}
+	return nil
+}
+
+// Run writes the timeline, creating the folder it goes in.
+func (s *Write) Run(ctx context.Context, state *State) error {
+	if err := s.check(); err != nil {
+		return err
+	}
+	timeline, err := state.timeline()
+	if err != nil {
+		return err
+	}
+	path := expand(s.Path, state)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := WriteFile(timeline, path, s.Rate, s.Options); err != nil {
+		return err
+	}
+	state.Outputs = append(state.Outputs, path)
+	return nil
+}
+
+// Validate checks the timeline against the rules of the validate package
+// and adds the issues found to the state.
+type Validate struct {
+	// FailOn is the name of the severity, such as "error", at or above
+	// which an issue fails the step with ErrInvalid. Empty or "off" never
+	// fails.
+	FailOn string `json:"fail_on"`
+	// Fix applies the fix of each fixable issue, then validates again, so
+	// only the issues left are added.
+	Fix bool `json:"fix"`
+	// Options select the rules run.
+	Options []validate.Option `json:"-"`
+}
+
+// Name returns "validate".
+func (*Validate) Name() string {
+	return "validate"
+}
+
+func (s *Validate) check() error {
+	_, _, err := s.failOn()
+	return err
+}
+
+// failOn returns the severity that fails the step, and false if none
+// does.
+func (s *Validate) failOn() (validate.Severity, bool, error) {
+	if s.FailOn == "" || s.FailOn == "off" {
+		return 0, false, nil
+	}
+	severity, err := validate.ParseSeverity(s.FailOn)
+	if err != nil {
+		return 0, false, fmt.Errorf("fail_on: %w", err)
+	}
+	return severity, true, nil
+}
+
+// Run validates the timeline.
+func (s *Validate) Run(ctx context.Context, state *State) error {
+	failOn, fail, err := s.failOn()
+	if err != nil {
+		return err
+	}
+	timeline, err := state.timeline()
+	if err != nil {
+		return err
+	}
+	issues := validate.Validate(timeline, s.Options...)
+	if s.Fix {
+		fixed, err := validate.FixAll(issues)
+		state.Fixed += fixed
+		if err != nil {
+			return err
+		}
+		if fixed > 0 {
+			issues = validate.Validate(timeline, s.Options...)
+		}
+	}
+	state.Issues = append(state.Issues, issues...)
+	for _, issue := range issues {
+		if fail && issue.Severity >= failOn {
+			return fmt.Errorf("%w at severity %s", ErrInvalid, failOn)
+		}
+	}
+	return nil
+}
+
+// Relink gives each clip the reference found by a media linker. A clip
+// the linker fails on keeps its reference.
+type Relink struct {
+	// SearchPaths and Extensions configure the directory linker used if
+	// Linker is nil: media is looked for by name in the search paths.
+	SearchPaths []string `json:"search_paths"`
+	Extensions  []string `json:"extensions"`
+	// Linker is the media linker to use.
+	Linker medialinker.MediaLinker `json:"-"`
+}
+
+// Name returns "relink".
+func (*Relink) Name() string {
+	return "relink"
+}
+
+// Run relinks the clips of the timeline.
+func (s *Relink) Run(ctx context.Context, state *State) error {
+	timeline, err := state.timeline()
+	if err != nil {
+		return err
+	}
+	linker := s.Linker
+	if linker == nil {
+		linker = medialinker.NewDirectoryLinker(s.SearchPaths, s.Extensions)
+	}
+	for _, clip := range timeline.FindClips(nil, false) {
+		before := clip.MediaReference()
+		if err := medialinker.LinkClip(clip, linker, nil); err == nil && clip.MediaReference() != before {
+			state.Relinked++
+		}
+	}
+	return nil
+}
+
+// ConformRate conforms the timeline to a frame rate with
+// algorithms.ConformRate.
+type ConformRate struct {
+	Rate float64 `json:"rate"`
+	// Policy is "exact", the default, or "nearest_frame".
+	Policy string `json:"policy"`
+}
+
+// Name returns "conform_rate".
+func (*ConformRate) Name() string {
+	return "conform_rate"
+}
+
+func (s *ConformRate) check() error {
+	if s.Rate <= 0 {
+		return errors.New("rate must be positive")
+	}
+	_, err := s.policy()
+	return err
+}
+
+func (s *ConformRate) policy() (algorithms.ConformPolicy, error) {
+	switch s.Policy {
+	case "", "exact":
+		return algorithms.ConformExact, nil
+	case "nearest_frame":
+		return algorithms.ConformNearestFrame, nil
+	}
+	return 0, fmt.Errorf("unknown conform policy %q", s.Policy)
+}
+
+// Run conforms the timeline.
+func (s *ConformRate) Run(ctx context.Context, state *State) error {
+	if err := s.check(); err != nil {
+		return err
+	}
+	timeline, err := state.timeline()
+	if err != nil {
+		return err
+	}
+	policy, _ := s.policy()
+	_, err = algorithms.ConformRate(timeline, s.Rate, policy)
+	return err
+}
+
+// Handles extends every clip by a number of frames at each end with
+// algorithms.AddHandles, adding the clips short of media to the state.
+type Handles struct {
+	Frames float64 `json:"frames"`
+}
+
+// Name returns "handles".
+func (*Handles) Name() string {
+	return "handles"
+}
+
+// Run adds the handles.
+func (s *Handles) Run(ctx context.Context, state *State) error {
+	timeline, err := state.timeline()
+	if err != nil {
+		return err
+	}
+	shortfalls, err := algorithms.AddHandles(timeline, s.Frames)
+	state.Shortfalls = append(state.Shortfalls, shortfalls...)
+	return err
+}
+
+// Bundle writes the timeline and its media to an .otioz or .otiod bundle,
+// chosen by the suffix of Path. An existing bundle is replaced.
+type Bundle struct {
+	// Path is the bundle to write, expanded as for Write.
+	Path string `json:"path"`
+	// Strict fails the step for media that is not a local file. Otherwise
+	// such references are replaced with missing references.
+	Strict bool `json:"strict"`
+}
+
+// Name returns "bundle".
+func (*Bundle) Name() string {
+	return "bundle"
+}
+
+func (s *Bundle) check() error {
+	switch suffix(s.Path) {
+	case ".otioz", ".otiod":
+		return nil
+	}
+	return fmt.Errorf("bundle path %q must end in .otioz or .otiod", s.Path)
+}
+
+// Run writes the bundle.
+func (s *Bundle) Run(ctx context.Context, state *State) error {
+	if err := s.check(); err != nil {
+		return err
+	}
+	timeline, err := state.timeline()
+	if err != nil {
+		return err
+	}
+	path := expand(s.Path, state)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	policy := bundle.MissingIfNotFile
+	if s.Strict {
+		policy = bundle.ErrorIfNotFile
+	}
+	if suffix(path) == ".otioz" {
+		err = bundle.WriteOTIOZ(timeline, path, policy)
+	} else {
+		err = bundle.WriteOTIOD(timeline, path, policy)
+	}
+	if err != nil {
+		return err
+	}
+	state.Outputs = append(state.Outputs, path)
+	return nil
+}
+
+// expand replaces the placeholders of an output path.
+func expand(path string, state *State) string {
+	name := ""
+	if state.Input != "" {
+		base := filepath.Base(state.Input)
+		name = strings.TrimSuffix(base, filepath.Ext(base))
+	} else if state.Timeline != nil {
+		name = state.Timeline.Name()
+	}
+	return strings.NewReplacer("{name}", name, "{dir}", filepath.Dir(state.Input)).Replace(path)
+}
@@ -0,0 +1,400 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrYAML is matched by errors for configs outside the YAML that
+// YAMLToJSON reads.
+var ErrYAML = errors.New("unsupported YAML")
+
+// YAMLToJSON converts a YAML config to JSON, so configs can be written in
+// either. It reads the block mappings and sequences, flow [lists] and
+// {maps}, quoted and plain scalars and comments that configs use, and
+// returns an error matching ErrYAML for anchors, aliases, tags, block
+// scalars and multiple documents.
+func YAMLToJSON(data []byte) ([]byte, error) {
+	p, err := newYAMLParser(string(data))
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if len(p.lines) > 0 {
+		if v, err = p.node(p.lines[0].indent); err != nil {
+			return nil, err
+		}
+		if p.pos < len(p.lines) {
+			return nil, p.errorf("unexpected indentation")
+		}
+	}
+	return json.Marshal(v)
+}
+
+// yamlLine is a line of YAML with its indentation and comment removed.
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func newYAMLParser(src string) (*yamlParser, error) {
+	p := &yamlParser{}
+	for i, raw := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: %w: tab indentation", i+1, ErrYAML)
+		}
+		text := strings.TrimSpace(stripYAMLComment(trimmed))
+		switch {
+		case text == "":
+			continue
+		case text == "---" && len(p.lines) == 0:
+			continue
+		case text == "---" || text == "...":
+			return nil, fmt.Errorf("line %d: %w: multiple documents", i+1, ErrYAML)
+		}
+		p.lines = append(p.lines, yamlLine{number: i + 1, indent: len(raw) - len(trimmed), text: text})
+	}
+	return p, nil
+}
+
+// stripYAMLComment removes a # comment that starts the line or follows a
+// space, outside quotes.
+func stripYAMLComment(s string) string {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || s[i-1] == ' '):
+			return s[:i]
+		}
+	}
+	return s
+}
+
+func (p *yamlParser) errorf(format string, args ...any) error {
+	line := 0
+	if p.pos < len(p.lines) {
+		line = p.lines[p.pos].number
+	} else if len(p.lines) > 0 {
+		line = p.lines[len(p.lines)-1].number
+	}
+	return fmt.Errorf("line %d: %w: "+format, append([]any{line, ErrYAML}, args...)...)
+}
+
+// node parses the mapping or sequence whose lines start at indent.
+func (p *yamlParser) node(indent int) (any, error) {
+	if isYAMLItem(p.lines[p.pos].text) {
+		return p.sequence(indent)
+	}
+	if _, _, ok := splitYAMLKey(p.lines[p.pos].text); ok {
+		return p.mapping(indent)
+	}
+	v, err := p.scalarOrFlow(p.lines[p.pos].text)
+	if err != nil {
+		return nil, err
+	}
+	p.pos++
+	return v, nil
+}
+
+func (p *yamlParser) sequence(indent int) ([]any, error) {
+	seq := []any{}
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isYAMLItem(p.lines[p.pos].text) {
+		line := p.lines[p.pos]
+		rest := strings.TrimLeft(line.text[1:], " ")
+		if rest == "" {
+			p.pos++
+			v, err := p.nested(indent, false)
+			if err != nil {
+				return nil, err
+			}
+			seq = append(seq, v)
+			continue
+		}
+		// The item is a node of its own, indented past the dash
+		p.lines[p.pos] = yamlLine{number: line.number, indent: indent + len(line.text) - len(rest), text: rest}
+		v, err := p.node(p.lines[p.pos].indent)
+		if err != nil {
+			return nil, err
+		}
+		seq = append(seq, v)
+	}
+	return seq, nil
+}
+
+func (p *yamlParser) mapping(indent int) (map[string]any, error) {
+	m := make(map[string]any)
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && !isYAMLItem(p.lines[p.pos].text) {
+		key, value, ok := splitYAMLKey(p.lines[p.pos].text)
+		if !ok {
+			return nil, p.errorf("expected a key")
+		}
+		if key == "" {
+			return nil, p.errorf("empty key")
+		}
+		k, err := p.scalarOrFlow(key)
+		if err != nil {
+			return nil, err
+		}
+		name := fmt.Sprint(k)
+		if _, dup := m[name]; dup {
+			return nil, p.errorf("duplicate key %q", name)
+		}
+		if value != "" {
+			if m[name], err = p.scalarOrFlow(value); err != nil {
+				return nil, err
+			}
+			p.pos++
+			continue
+		}
+		p.pos++
+		// A sequence may sit at the indentation of its key
+		if m[name], err = p.nested(indent, true); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// nested parses the node below a line at indent, or returns nil if there
+// is none.
+func (p *yamlParser) nested(indent int, sameIndentItems bool) (any, error) {
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+	next := p.lines[p.pos]
+	if next.indent > indent || (sameIndentItems && next.indent == indent && isYAMLItem(next.text)) {
+		return p.node(next.indent)
+	}
+	return nil, nil
+}
+
+func isYAMLItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// splitYAMLKey splits "key: value" at the first ": " or final ":" outside
+// quotes and flow collections.
+func splitYAMLKey(text string) (key, value string, ok bool) {
+	if text == "" || strings.ContainsRune("[{", rune(text[0])) {
+		return "", "", false
+	}
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case (c == '"' || c == '\'') && i == 0:
+			quote = c
+		case c == ':' && (i == len(text)-1 || text[i+1] == ' '):
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+func (p *yamlParser) scalarOrFlow(s string) (any, error) {
+	if s == "" {
+		return nil, p.errorf("empty value")
+	}
+	switch s[0] {
+	case '&', '*', '!':
+		return nil, p.errorf("anchors, aliases and tags are not supported")
+	case '|', '>':
+		return nil, p.errorf("block scalars are not supported")
+	case '[', '{':
+		f := &yamlFlow{src: s}
+		v, err := f.value()
+		if err == nil {
+			f.skipSpace()
+			if f.pos < len(f.src) {
+				err = fmt.Errorf("unexpected %q", f.src[f.pos:])
+			}
+		}
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		return v, nil
+	}
+	v, err := yamlScalar(s)
+	if err != nil {
+		return nil, p.errorf("%v", err)
+	}
+	return v, nil
+}
+
+// yamlScalar converts a quoted or plain scalar.
+func yamlScalar(s string) (any, error) {
+	switch s[0] {
+	case '"':
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quoted string %s", s)
+		}
+		return v, nil
+	case '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' {
+			return nil, fmt.Errorf("invalid quoted string %s", s)
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+	switch s {
+	case "null", "Null", "NULL", "~":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	}
+	if isYAMLNumber(s) {
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			return n, nil
+		}
+	}
+	return s, nil
+}
+
+// isYAMLNumber reports whether s is written as a decimal number.
+func isYAMLNumber(s string) bool {
+	digits := false
+	for i, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			digits = true
+		case (c == '-' || c == '+') && (i == 0 || s[i-1] == 'e' || s[i-1] == 'E'):
+		case c == '.' || c == 'e' || c == 'E':
+		default:
+			return false
+		}
+	}
+	return digits
+}
+
+// yamlFlow parses a flow collection on one line.
+type yamlFlow struct {
+	src string
+	pos int
+}
+
+func (f *yamlFlow) skipSpace() {
+	for f.pos < len(f.src) && f.src[f.pos] == ' ' {
+		f.pos++
+	}
+}
+
+func (f *yamlFlow) value() (any, error) {
+	f.skipSpace()
+	if f.pos >= len(f.src) {
+		return nil, errors.New("unterminated flow collection")
+	}
+	switch f.src[f.pos] {
+	case '[':
+		return f.collection(']')
+	case '{':
+		return f.collection('}')
+	}
+	return f.scalar(",]}")
+}
+
+// collection parses a [list] or a {map} ending with end.
+func (f *yamlFlow) collection(end byte) (any, error) {
+	f.pos++
+	list := []any{}
+	m := make(map[string]any)
+	for {
+		f.skipSpace()
+		if f.pos < len(f.src) && f.src[f.pos] == end {
+			f.pos++
+			if end == ']' {
+				return list, nil
+			}
+			return m, nil
+		}
+		if end == ']' {
+			v, err := f.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		} else {
+			k, err := f.scalar(":,}")
+			if err != nil {
+				return nil, err
+			}
+			if f.pos >= len(f.src) || f.src[f.pos] != ':' {
+				return nil, fmt.Errorf("expected ':' after key %v", k)
+			}
+			f.pos++
+			v, err := f.value()
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprint(k)] = v
+		}
+		f.skipSpace()
+		if f.pos >= len(f.src) {
+			return nil, errors.New("unterminated flow collection")
+		}
+		switch f.src[f.pos] {
+		case ',':
+			f.pos++
+		case end:
+		default:
+			return nil, fmt.Errorf("unexpected %q in flow collection", f.src[f.pos])
+		}
+	}
+}
+
+// scalar parses a quoted scalar, or a plain one up to any of stops.
+func (f *yamlFlow) scalar(stops string) (any, error) {
+	f.skipSpace()
+	start := f.pos
+	if f.pos < len(f.src) && (f.src[f.pos] == '"' || f.src[f.pos] == '\'') {
+		quote := f.src[f.pos]
+		for f.pos++; f.pos < len(f.src); f.pos++ {
+			if f.src[f.pos] == '\\' && quote == '"' {
+				f.pos++
+			} else if f.src[f.pos] == quote {
+				if quote == '\'' && f.pos+1 < len(f.src) && f.src[f.pos+1] == '\'' {
+					f.pos++
+					continue
+				}
+				f.pos++
+				return yamlScalar(f.src[start:f.pos])
+			}
+		}
+		return nil, errors.New("unterminated quoted string")
+	}
+	for f.pos < len(f.src) && !strings.ContainsRune(stops, rune(f.src[f.pos])) {
+		f.pos++
+	}
+	s := strings.TrimSpace(f.src[start:f.pos])
+	if s == "" {
+		return nil, errors.New("empty value in flow collection")
+	}
+	return yamlScalar(s)
+}
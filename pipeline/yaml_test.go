@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestYAMLToJSON(t *testing.T) {
+	cases := map[string]string{
+		"steps:\n  - step: read\n    rate: 24\n  - step: handles\n    frames: 8\n":                                               `{"steps": [{"step": "read", "rate": 24}, {"step": "handles", "frames": 8}]}`,
+		"# conform\nsteps:\n- step: relink # media\n  search_paths: [/media/plates, '/media/it''s']\n  extensions: [\".mov\"]\n": `{"steps": [{"step": "relink", "search_paths": ["/media/plates", "/media/it's"], "extensions": [".mov"]}]}`,
+		"---\nwatch: /incoming\ninterval: 5s\nfix: true\nmissing: ~\nrate: 23.976\n":                                             `{"watch": "/incoming", "interval": "5s", "fix": true, "missing": null, "rate": 23.976}`,
+		"step: write\npath: \"/out/{name}.otio\"\nopts: {flat: yes, depth: 2}\n":                                                 `{"step": "write", "path": "/out/{name}.otio", "opts": {"flat": "yes", "depth": 2}}`,
+		"list:\n  -\n    - a\n    - b\n  - c\nempty:\n":                                                                          `{"list": [["a", "b"], "c"], "empty": null}`,
+	}
+	for src, want := range cases {
+		data, err := YAMLToJSON([]byte(src))
+		if err != nil {
+			t.Errorf("YAMLToJSON(%q) error: %v", src, err)
+			continue
+		}
+		var got, wantValue any
+		json.Unmarshal(data, &got)
+		json.Unmarshal([]byte(want), &wantValue)
+		if !reflect.DeepEqual(got, wantValue) {
+			t.Errorf("YAMLToJSON(%q) = %s, want %s", src, data, want)
+		}
+	}
+
+	for _, bad := range []string{
+		"steps:\n  - step: read\n     rate: 24\n",
+		"base: &base\n  rate: 24\n",
+		"notes: |\n  line\n",
+		"a: 1\na: 2\n",
+		"a: [1, 2\n",
+		"a: 1\n---\nb: 2\n",
+		"a:\n\tb: 1\n",
+		": x\n",
+		"a:\n  : b\n",
+		"- : x\n",
+		"a: {: 1}\n",
+	} {
+		if _, err := YAMLToJSON([]byte(bad)); !errors.Is(err, ErrYAML) {
+			t.Errorf("YAMLToJSON(%q) error = %v, want ErrYAML", bad, err)
+		}
+	}
+}
+
+func TestLoadYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "conform.yml")
+	os.WriteFile(path, []byte("steps:\n  - step: read\n    rate: 24\n  - step: conform_rate\n    rate: 25\n    policy: nearest_frame\n"), 0644)
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if steps := p.Steps(); len(steps) != 2 || steps[1].Name() != "conform_rate" {
+		t.Errorf("Steps = %v", steps)
+	}
+
+	os.WriteFile(path, []byte("steps:\n  - step: transcode\n"), 0644)
+	if _, err := Load(path); !errors.Is(err, ErrUnknownStep) {
+		t.Errorf("expected ErrUnknownStep, got %v", err)
+	}
+}
+
+func FuzzYAMLToJSON(f *testing.F) {
+	for _, seed := range []string{
+		"steps:\n  - step: read\n    rate: 24\n",
+		"a: [1, 'b', {c: d}]\n",
+		"- - a\n  - b\n- c: 1\n  d: ~\n",
+		": x\n",
+		"a:\n  : b\n",
+		"\"q\": \"a # b\"\n",
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		out, err := YAMLToJSON(data)
+		if err != nil {
+			if !errors.Is(err, ErrYAML) {
+				t.Fatalf("YAMLToJSON(%q) error %v does not match ErrYAML", data, err)
+			}
+			return
+		}
+		if !json.Valid(out) {
+			t.Fatalf("YAMLToJSON(%q) = %q, not valid JSON", data, out)
+		}
+	})
+}
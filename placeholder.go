@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// PlaceholderMetadataKey is the metadata namespace marking a gap as the
+// placeholder for a slot of a template timeline. The gap's duration is
+// the duration the slot's content must fill.
+const PlaceholderMetadataKey = "placeholder"
+
+// NewPlaceholder creates a placeholder gap named after slot with the
+// given duration.
+func NewPlaceholder(slot string, duration opentime.RationalTime) *Gap {
+	gap := NewGapWithDuration(duration)
+	gap.SetName(slot)
+	gap.SetPlaceholderSlot(slot)
+	return gap
+}
+
+// PlaceholderSlot returns the slot the gap is a placeholder for, and false
+// if it is not a placeholder.
+func (g *Gap) PlaceholderSlot() (string, bool) {
+	values, ok := g.Metadata().GetDictionary(PlaceholderMetadataKey)
+	if !ok {
+		return "", false
+	}
+	slot, ok := values.GetString("slot")
+	return slot, ok && slot != ""
+}
+
+// SetPlaceholderSlot marks the gap as the placeholder for slot. An empty
+// slot removes the mark. Other keys in the placeholder namespace are kept.
+func (g *Gap) SetPlaceholderSlot(slot string) {
+	md := g.Metadata()
+	values, ok := md.GetDictionary(PlaceholderMetadataKey)
+	if slot == "" {
+		if ok {
+			delete(values, "slot")
+			if len(values) == 0 {
+				delete(md, PlaceholderMetadataKey)
+			}
+		}
+		return
+	}
+	if md == nil {
+		md = AnyDictionary{}
+		g.SetMetadata(md)
+	}
+	if !ok {
+		values = AnyDictionary{}
+	}
+	values["slot"] = slot
+	md[PlaceholderMetadataKey] = values
+}
@@ -0,0 +1,397 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package qc checks conformed timelines against editorial. CompareCutOrder
+// lines up the events of two timelines in record order, typically an OTIO
+// conform and the EDL it was conformed from, and reports each event that
+// matches, differs in record or source in and out or reel, or is missing
+// from one side, to the frame.
+//
+// Basic usage:
+//
+//	report, err := qc.CompareCutOrder(edl, conform, opentime.NewRationalTime(0, 24))
+//	if !report.OK() {
+//		report.WriteText(os.Stderr)
+//	}
+package qc
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func init() {
+	gotio.RegisterFeature("qc")
+}
+
+// Status is the outcome of comparing an event.
+type Status int
+
+const (
+	// Match is an event the same on both sides, within the tolerance.
+	Match Status = iota
+	// Mismatch is an event in the same place on both sides that differs.
+	Mismatch
+	// OnlyInA is an event of the first timeline with none in the second.
+	OnlyInA
+	// OnlyInB is an event of the second timeline with none in the first.
+	OnlyInB
+)
+
+func (s Status) String() string {
+	switch s {
+	case Match:
+		return "match"
+	case Mismatch:
+		return "mismatch"
+	case OnlyInA:
+		return "only in A"
+	case OnlyInB:
+		return "only in B"
+	}
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+// Field names what differs between two events.
+type Field string
+
+const (
+	FieldRecordIn  Field = "record_in"
+	FieldRecordOut Field = "record_out"
+	FieldSourceIn  Field = "source_in"
+	FieldSourceOut Field = "source_out"
+	FieldReel      Field = "reel"
+)
+
+// Event is a cut of a timeline: the span of record time over which one
+// clip is seen on top.
+type Event struct {
+	// Number counts the events of the timeline from 1, in record order.
+	Number int
+	// Clip is the clip seen.
+	Clip *gotio.Clip
+	// Reel is the clip's reel, or "" if it has none.
+	Reel string
+	// Record is the span of the event in record time, offset by the
+	// timeline's global start time.
+	Record opentime.TimeRange
+	// Source is the media of the clip shown over Record.
+	Source opentime.TimeRange
+}
+
+// Difference is one field in which two events differ.
+type Difference struct {
+	Field Field
+	// A and B are the values of the field on each side, as timecode for
+	// times.
+	A, B string
+	// Frames is how far B is from A for times, in frames at the rate of A.
+	Frames float64
+}
+
+// EventComparison is the outcome for one event, or a pair of events in the
+// same place.
+type EventComparison struct {
+	Status Status
+	// A and B are the events of each timeline; one is nil for OnlyInA and
+	// OnlyInB.
+	A, B *Event
+	// Differences lists what differs for a Mismatch.
+	Differences []Difference
+}
+
+// Report is the result of CompareCutOrder.
+type Report struct {
+	// Events holds every event of both timelines in record order.
+	Events []EventComparison
+	// Matched, Mismatched, OnlyInA and OnlyInB count Events by status.
+	Matched, Mismatched, OnlyInA, OnlyInB int
+}
+
+// OK reports whether every event matched.
+func (r *Report) OK() bool {
+	return r.Mismatched == 0 && r.OnlyInA == 0 && r.OnlyInB == 0
+}
+
+// Config holds options for CompareCutOrder.
+type Config struct {
+	// TrackKind is the kind of the tracks compared, video by default. The
+	// tracks of the kind are flattened, so the events are the clips seen.
+	TrackKind string
+	// Reel returns the reel of a clip. The default is the reel recorded by
+	// the cmx_3600 EDL adapter. Reels are compared only when both events
+	// have one.
+	Reel func(clip *gotio.Clip) string
+	// RecordOffset is added to the record times of the second timeline,
+	// for a conform that starts at another record time than the EDL.
+	RecordOffset opentime.RationalTime
+}
+
+// Option is a functional option for CompareCutOrder.
+type Option func(*Config)
+
+// WithTrackKind compares the tracks of kind instead of the video tracks.
+func WithTrackKind(kind string) Option {
+	return func(c *Config) {
+		c.TrackKind = kind
+	}
+}
+
+// WithReel sets the function returning the reel of a clip.
+func WithReel(reel func(clip *gotio.Clip) string) Option {
+	return func(c *Config) {
+		c.Reel = reel
+	}
+}
+
+// WithRecordOffset shifts the record times of the second timeline by
+// offset before comparing.
+func WithRecordOffset(offset opentime.RationalTime) Option {
+	return func(c *Config) {
+		c.RecordOffset = offset
+	}
+}
+
+func newConfig(opts []Option) Config {
+	cfg := Config{TrackKind: gotio.TrackKindVideo, Reel: cmxReel}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// cmxReel returns the reel recorded by the cmx_3600 EDL adapter.
+func cmxReel(clip *gotio.Clip) string {
+	if cmx, ok := clip.Metadata().GetDictionary("cmx_3600"); ok {
+		reel, _ := cmx.GetString("reel")
+		return reel
+	}
+	return ""
+}
+
+// CompareCutOrder compares the events of a and b. Events are paired in
+// record order by the record time they share, so an event added or
+// removed on one side is reported alone rather than shifting the events
+// after it. Paired events match if their record and source in and out
+// points are within tolerance of each other and their reels are the same.
+// A zero tolerance compares to the frame.
+func CompareCutOrder(a, b *gotio.Timeline, tolerance opentime.RationalTime, opts ...Option) (*Report, error) {
+	cfg := newConfig(opts)
+	eventsA, err := Events(a, opts...)
+	if err != nil {
+		return nil, err
+	}
+	eventsB, err := Events(b, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.RecordOffset.Rate() > 0 {
+		for _, e := range eventsB {
+			e.Record = opentime.NewTimeRange(e.Record.StartTime().Add(cfg.RecordOffset), e.Record.Duration())
+		}
+	}
+
+	report := &Report{}
+	add := func(c EventComparison) {
+		switch c.Status {
+		case Match:
+			report.Matched++
+		case Mismatch:
+			report.Mismatched++
+		case OnlyInA:
+			report.OnlyInA++
+		case OnlyInB:
+			report.OnlyInB++
+		}
+		report.Events = append(report.Events, c)
+	}
+
+	i, j := 0, 0
+	for i < len(eventsA) && j < len(eventsB) {
+		ea, eb := eventsA[i], eventsB[j]
+		shared := overlap(ea.Record, eb.Record)
+		switch {
+		case shared <= 0 && ea.Record.StartTime().Cmp(eb.Record.StartTime()) <= 0:
+			add(EventComparison{Status: OnlyInA, A: ea})
+			i++
+		case shared <= 0:
+			add(EventComparison{Status: OnlyInB, B: eb})
+			j++
+		// An event sharing more with the next event of the other side
+		// is the extra one
+		case j+1 < len(eventsB) && overlap(ea.Record, eventsB[j+1].Record) > shared:
+			add(EventComparison{Status: OnlyInB, B: eb})
+			j++
+		case i+1 < len(eventsA) && overlap(eventsA[i+1].Record, eb.Record) > shared:
+			add(EventComparison{Status: OnlyInA, A: ea})
+			i++
+		default:
+			add(compareEvents(ea, eb, tolerance, cfg))
+			i++
+			j++
+		}
+	}
+	for ; i < len(eventsA); i++ {
+		add(EventComparison{Status: OnlyInA, A: eventsA[i]})
+	}
+	for ; j < len(eventsB); j++ {
+		add(EventComparison{Status: OnlyInB, B: eventsB[j]})
+	}
+	return report, nil
+}
+
+// compareEvents compares two events in the same place.
+func compareEvents(a, b *Event, tolerance opentime.RationalTime, cfg Config) EventComparison {
+	c := EventComparison{Status: Match, A: a, B: b}
+	times := []struct {
+		field Field
+		a, b  opentime.RationalTime
+	}{
+		{FieldRecordIn, a.Record.StartTime(), b.Record.StartTime()},
+		{FieldRecordOut, a.Record.EndTimeExclusive(), b.Record.EndTimeExclusive()},
+		{FieldSourceIn, a.Source.StartTime(), b.Source.StartTime()},
+		{FieldSourceOut, a.Source.EndTimeExclusive(), b.Source.EndTimeExclusive()},
+	}
+	for _, t := range times {
+		frames := t.b.ValueRescaledTo(t.a.Rate()) - t.a.Value()
+		limit := 0.0
+		if tolerance.Rate() > 0 {
+			limit = math.Abs(tolerance.ValueRescaledTo(t.a.Rate()))
+		}
+		if math.Abs(frames) > limit+1e-6 {
+			c.Differences = append(c.Differences, Difference{
+				Field: t.field, A: timecode(t.a), B: timecode(t.b), Frames: frames,
+			})
+		}
+	}
+	if a.Reel != "" && b.Reel != "" && a.Reel != b.Reel {
+		c.Differences = append(c.Differences, Difference{Field: FieldReel, A: a.Reel, B: b.Reel})
+	}
+	if len(c.Differences) > 0 {
+		c.Status = Mismatch
+	}
+	return c
+}
+
+// Events returns the events of timeline in record order: the tracks of
+// the kind compared are flattened, topmost first, so each event is the
+// span over which one clip is seen. Disabled items are left out.
+func Events(timeline *gotio.Timeline, opts ...Option) ([]*Event, error) {
+	cfg := newConfig(opts)
+	resolved, err := timeline.ResolvedClips(gotio.WithTrackKinds(cfg.TrackKind))
+	if err != nil {
+		return nil, err
+	}
+
+	// Cut record time at every clip's in and out, and give each span to
+	// the clip on the highest track over it
+	var cuts []opentime.RationalTime
+	for _, rc := range resolved {
+		cuts = append(cuts, rc.GlobalRange.StartTime(), rc.GlobalRange.EndTimeExclusive())
+	}
+	slices.SortFunc(cuts, opentime.RationalTime.Cmp)
+	cuts = slices.CompactFunc(cuts, func(x, y opentime.RationalTime) bool { return x.Cmp(y) == 0 })
+
+	var events []*Event
+	for k := 0; k+1 < len(cuts); k++ {
+		start, end := cuts[k], cuts[k+1]
+		top := -1
+		for n, rc := range resolved {
+			if rc.GlobalRange.StartTime().Cmp(start) > 0 {
+				break
+			}
+			if rc.GlobalRange.EndTimeExclusive().Cmp(end) >= 0 && (top < 0 || rc.TrackIndex >= resolved[top].TrackIndex) {
+				top = n
+			}
+		}
+		if top < 0 {
+			continue
+		}
+		rc := resolved[top]
+		if last := len(events) - 1; last >= 0 && events[last].Clip == rc.Clip &&
+			events[last].Record.EndTimeExclusive().Cmp(start) == 0 {
+			events[last].Record = opentime.NewTimeRange(events[last].Record.StartTime(), end.Sub(events[last].Record.StartTime()))
+			continue
+		}
+		events = append(events, &Event{
+			Number: len(events) + 1,
+			Clip:   rc.Clip,
+			Reel:   cfg.Reel(rc.Clip),
+			Record: opentime.NewTimeRange(start, end.Sub(start)),
+		})
+	}
+
+	// Map each event's record span to the media shown
+	for _, e := range events {
+		for _, rc := range resolved {
+			if rc.Clip != e.Clip || !rc.GlobalRange.Contains(e.Record.StartTime()) {
+				continue
+			}
+			speed := rc.MediaRange.Duration().ToSeconds() / rc.GlobalRange.Duration().ToSeconds()
+			rate := rc.MediaRange.StartTime().Rate()
+			offset := e.Record.StartTime().Sub(rc.GlobalRange.StartTime()).ToSeconds() * speed
+			e.Source = opentime.NewTimeRange(
+				rc.MediaRange.StartTime().Add(opentime.FromSeconds(offset, rate)),
+				opentime.FromSeconds(e.Record.Duration().ToSeconds()*speed, rate),
+			)
+			break
+		}
+	}
+	return events, nil
+}
+
+// overlap returns the seconds that a and b share.
+func overlap(a, b opentime.TimeRange) float64 {
+	start := math.Max(a.StartTime().ToSeconds(), b.StartTime().ToSeconds())
+	end := math.Min(a.EndTimeExclusive().ToSeconds(), b.EndTimeExclusive().ToSeconds())
+	return end - start
+}
+
+// timecode returns t as timecode at its own rate, or as a time string if
+// it cannot be.
+func timecode(t opentime.RationalTime) string {
+	if tc, err := t.ToNearestTimecode(t.Rate(), opentime.InferFromRate); err == nil {
+		return tc
+	}
+	return t.ToTimeString()
+}
+
+// WriteText writes the events that did not match, one per line, followed
+// by the counts of each status.
+func (r *Report) WriteText(w io.Writer) error {
+	for _, c := range r.Events {
+		var line string
+		switch c.Status {
+		case Match:
+			continue
+		case OnlyInA:
+			line = fmt.Sprintf("A %03d %s only in A at %s", c.A.Number, c.A.Clip.Name(), timecodeRange(c.A.Record))
+		case OnlyInB:
+			line = fmt.Sprintf("B %03d %s only in B at %s", c.B.Number, c.B.Clip.Name(), timecodeRange(c.B.Record))
+		case Mismatch:
+			line = fmt.Sprintf("A %03d / B %03d %s:", c.A.Number, c.B.Number, c.A.Clip.Name())
+			for _, d := range c.Differences {
+				line += fmt.Sprintf(" %s %s != %s", d.Field, d.A, d.B)
+				if d.Field != FieldReel {
+					line += fmt.Sprintf(" (%+g)", d.Frames)
+				}
+				line += ";"
+			}
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d matched, %d mismatched, %d only in A, %d only in B\n",
+		r.Matched, r.Mismatched, r.OnlyInA, r.OnlyInB)
+	return err
+}
+
+func timecodeRange(r opentime.TimeRange) string {
+	return timecode(r.StartTime()) + " - " + timecode(r.EndTimeExclusive())
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package qc
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+type testEvent struct {
+	name   string
+	reel   string
+	source float64
+	frames float64
+}
+
+func rt(v float64) opentime.RationalTime { return opentime.NewRationalTime(v, 24) }
+
+// newTestClip returns a clip with the cmx_3600 reel an EDL would have.
+func newTestClip(e testEvent) *gotio.Clip {
+	sr := opentime.NewTimeRange(rt(e.source), rt(e.frames))
+	metadata := gotio.AnyDictionary{"cmx_3600": gotio.AnyDictionary{"reel": e.reel}}
+	return gotio.NewClip(e.name, nil, &sr, metadata, nil, nil, "", nil)
+}
+
+// newTestTimeline returns a timeline of one video track of the events,
+// starting at 01:00:00:00 like an EDL.
+func newTestTimeline(events ...testEvent) *gotio.Timeline {
+	start := rt(86400)
+	timeline := gotio.NewTimeline("edl", &start, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	for _, e := range events {
+		track.AppendChild(newTestClip(e))
+	}
+	timeline.Tracks().AppendChild(track)
+	return timeline
+}
+
+var editorial = []testEvent{
+	{"sh010", "A001", 1000, 48},
+	{"sh020", "A002", 2000, 24},
+	{"sh030", "A001", 1200, 72},
+}
+
+func TestCompareCutOrderMatch(t *testing.T) {
+	edl := newTestTimeline(editorial...)
+	conform := newTestTimeline(editorial...)
+	report, err := CompareCutOrder(edl, conform, opentime.RationalTime{})
+	if err != nil {
+		t.Fatalf("CompareCutOrder error: %v", err)
+	}
+	if !report.OK() || report.Matched != 3 {
+		t.Errorf("report = %+v, want 3 matches", report)
+	}
+	if e := report.Events[1].B; e.Number != 2 || e.Reel != "A002" || e.Record.StartTime().Value() != 86448 {
+		t.Errorf("second event = %+v", e)
+	}
+}
+
+func TestCompareCutOrderMismatch(t *testing.T) {
+	edl := newTestTimeline(editorial...)
+	slipped := append([]testEvent(nil), editorial...)
+	slipped[1].source = 2001
+	slipped[2].reel = "B001"
+	conform := newTestTimeline(slipped...)
+
+	report, err := CompareCutOrder(edl, conform, opentime.RationalTime{})
+	if err != nil {
+		t.Fatalf("CompareCutOrder error: %v", err)
+	}
+	if report.Matched != 1 || report.Mismatched != 2 {
+		t.Fatalf("report = %+v, want 1 match and 2 mismatches", report)
+	}
+	diffs := report.Events[1].Differences
+	if len(diffs) != 2 || diffs[0].Field != FieldSourceIn || diffs[0].Frames != 1 || diffs[1].Field != FieldSourceOut {
+		t.Errorf("differences = %+v, want source in and out 1 frame late", diffs)
+	}
+	if diffs := report.Events[2].Differences; len(diffs) != 1 || diffs[0].Field != FieldReel {
+		t.Errorf("differences = %+v, want the reel", diffs)
+	}
+
+	// A frame of tolerance passes the slip but not the reel
+	report, _ = CompareCutOrder(edl, conform, rt(1))
+	if report.Matched != 2 || report.Mismatched != 1 {
+		t.Errorf("with tolerance report = %+v, want 2 matches", report)
+	}
+
+	var text bytes.Buffer
+	if err := report.WriteText(&text); err != nil {
+		t.Fatalf("WriteText error: %v", err)
+	}
+	if !strings.Contains(text.String(), "reel A001 != B001") || !strings.Contains(text.String(), "2 matched, 1 mismatched") {
+		t.Errorf("WriteText =\n%s", text.String())
+	}
+}
+
+func TestCompareCutOrderLayers(t *testing.T) {
+	edl := newTestTimeline(editorial...)
+	conform := newTestTimeline(editorial...)
+
+	// A VFX shot over the middle of sh030 splits it into three events, the
+	// second of which only the conform has
+	vfx := gotio.NewTrack("V2", nil, gotio.TrackKindVideo, nil, nil)
+	vfx.AppendChild(gotio.NewGapWithDuration(rt(96)))
+	vfx.AppendChild(newTestClip(testEvent{"sh030_comp", "", 0, 24}))
+	conform.Tracks().AppendChild(vfx)
+
+	events, err := Events(conform)
+	if err != nil {
+		t.Fatalf("Events error: %v", err)
+	}
+	if len(events) != 5 || events[3].Clip.Name() != "sh030_comp" {
+		t.Fatalf("events = %d, want the comp over sh030", len(events))
+	}
+	if tail := events[4]; tail.Clip.Name() != "sh030" || tail.Source.StartTime().Value() != 1224+24 || tail.Source.Duration().Value() != 24 {
+		t.Errorf("tail of sh030 = %v, want source 1248 for 24", tail.Source)
+	}
+
+	report, err := CompareCutOrder(edl, conform, opentime.RationalTime{})
+	if err != nil {
+		t.Fatalf("CompareCutOrder error: %v", err)
+	}
+	if report.Matched != 2 || report.Mismatched != 1 || report.OnlyInB != 2 {
+		t.Errorf("report = %d matched, %d mismatched, %d only in B", report.Matched, report.Mismatched, report.OnlyInB)
+	}
+	if c := report.Events[2]; c.Status != Mismatch || c.B.Clip.Name() != "sh030" || c.Differences[0].Field != FieldRecordOut {
+		t.Errorf("third event = %+v, want sh030 cut short", c)
+	}
+
+	// A conform starting at zero lines up with an offset
+	zero := newTestTimeline(editorial...)
+	zero.SetGlobalStartTime(nil)
+	report, _ = CompareCutOrder(edl, zero, opentime.RationalTime{})
+	if report.Matched != 0 {
+		t.Errorf("without an offset report = %+v, want no matches", report)
+	}
+	report, _ = CompareCutOrder(edl, zero, opentime.RationalTime{}, WithRecordOffset(rt(86400)))
+	if !report.OK() {
+		t.Errorf("with an offset report = %+v, want all matched", report)
+	}
+}
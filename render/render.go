@@ -0,0 +1,278 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package render splits a timeline into frame ranges for render farm
+// tasks. Each chunk is a range of record frames with the clips seen in it:
+// their media and the source frames each task reads.
+//
+// Record frames count from the timeline's global start time, so a
+// timeline starting at frame 1001 gives chunks from 1001. Source frames
+// are at the rate of each clip's media.
+//
+// Basic usage:
+//
+//	for chunk, err := range render.Chunks(timeline, 50, render.WithSplitOnClips(true)) {
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		submit(chunk.FirstFrame, chunk.LastFrame, chunk.Segments)
+//	}
+package render
+
+import (
+	"errors"
+	"iter"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func init() {
+	gotio.RegisterFeature("render")
+}
+
+// ErrInvalidChunkSize is returned for a chunk size below one frame.
+var ErrInvalidChunkSize = errors.New("chunk size must be at least one frame")
+
+// Chunk is a range of record frames, one render task.
+type Chunk struct {
+	// Index counts chunks from zero.
+	Index int `json:"index"`
+	// FirstFrame and LastFrame are the record frames of the chunk,
+	// inclusive.
+	FirstFrame int `json:"first_frame"`
+	LastFrame  int `json:"last_frame"`
+	// Segments are the clips seen in the chunk, bottom track first. A
+	// chunk over a gap has none.
+	Segments []Segment `json:"segments"`
+}
+
+// Frames returns the number of frames in the chunk.
+func (c Chunk) Frames() int {
+	return c.LastFrame - c.FirstFrame + 1
+}
+
+// Segment is the part of a clip seen in a chunk.
+type Segment struct {
+	Clip       *gotio.Clip          `json:"-"`
+	ClipName   string               `json:"clip"`
+	TrackName  string               `json:"track,omitempty"`
+	TrackIndex int                  `json:"track_index"`
+	Media      gotio.MediaReference `json:"-"`
+	// MediaURL is the target URL of an external reference, or the URL of
+	// an image sequence with the frame number as a run of '#', one per
+	// digit of padding.
+	MediaURL string `json:"media_url,omitempty"`
+	// FirstFrame and LastFrame are the record frames the clip is seen on,
+	// inclusive.
+	FirstFrame int `json:"first_frame"`
+	LastFrame  int `json:"last_frame"`
+	// SourceFirst and SourceLast are the frames of the media shown, at
+	// SourceRate, inclusive. For an image sequence they are the frame
+	// numbers of its files.
+	SourceFirst int     `json:"source_first"`
+	SourceLast  int     `json:"source_last"`
+	SourceRate  float64 `json:"source_rate"`
+	// Disabled marks a disabled clip, only seen WithSkipDisabled(false).
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// Config holds configuration for chunking.
+type Config struct {
+	// Rate is the frame rate of record frames. Zero uses the rate of the
+	// timeline's global start time, else of its first clip, else 24.
+	Rate float64
+	// SplitOnClips ends chunks at every cut, so no chunk spans two clips
+	// of the same track.
+	SplitOnClips bool
+	// SkipDisabled leaves out disabled items. It is set by default.
+	SkipDisabled bool
+	// TrackKind selects the tracks clips are taken from, video by default.
+	TrackKind string
+}
+
+// Option is a functional option for chunking.
+type Option func(*Config)
+
+// WithRate sets the frame rate of record frames.
+func WithRate(rate float64) Option {
+	return func(c *Config) {
+		c.Rate = rate
+	}
+}
+
+// WithSplitOnClips sets whether chunks end at every cut.
+func WithSplitOnClips(split bool) Option {
+	return func(c *Config) {
+		c.SplitOnClips = split
+	}
+}
+
+// WithSkipDisabled sets whether disabled items are left out.
+func WithSkipDisabled(skip bool) Option {
+	return func(c *Config) {
+		c.SkipDisabled = skip
+	}
+}
+
+// WithTrackKind selects the tracks clips are taken from.
+func WithTrackKind(kind string) Option {
+	return func(c *Config) {
+		c.TrackKind = kind
+	}
+}
+
+func newConfig(opts []Option) Config {
+	cfg := Config{
+		SkipDisabled: true,
+		TrackKind:    gotio.TrackKindVideo,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// span is a resolved clip in record frames.
+type span struct {
+	clip        gotio.ResolvedClip
+	first, end  int // record frames, end exclusive
+	sourceFirst float64
+	sourceRate  float64
+}
+
+// Chunks returns an iterator over chunks of at most chunkSize frames
+// covering the timeline, in order. An error stops the iteration.
+func Chunks(timeline *gotio.Timeline, chunkSize int, opts ...Option) iter.Seq2[Chunk, error] {
+	return func(yield func(Chunk, error) bool) {
+		if chunkSize < 1 {
+			yield(Chunk{}, ErrInvalidChunkSize)
+			return
+		}
+		cfg := newConfig(opts)
+		searchOpts := []gotio.SearchOption{gotio.WithTrackKinds(cfg.TrackKind)}
+		if !cfg.SkipDisabled {
+			searchOpts = append(searchOpts, gotio.WithIncludeDisabled())
+		}
+		resolved, err := timeline.ResolvedClips(searchOpts...)
+		if err != nil {
+			yield(Chunk{}, err)
+			return
+		}
+		duration, err := timeline.Duration()
+		if err != nil {
+			yield(Chunk{}, err)
+			return
+		}
+
+		rate := cfg.Rate
+		globalStart := timeline.GlobalStartTime()
+		if rate <= 0 && globalStart != nil {
+			rate = globalStart.Rate()
+		}
+		if rate <= 0 && len(resolved) > 0 {
+			rate = resolved[0].GlobalRange.StartTime().Rate()
+		}
+		if rate <= 0 {
+			rate = 24
+		}
+		first := 0
+		if globalStart != nil {
+			first = toFrame(*globalStart, rate)
+		}
+		end := first + duration.ToFramesAtRate(rate)
+
+		spans := make([]span, 0, len(resolved))
+		cuts := []int{end}
+		for _, rc := range resolved {
+			s := span{
+				clip:       rc,
+				first:      toFrame(rc.GlobalRange.StartTime(), rate),
+				end:        toFrame(rc.GlobalRange.EndTimeExclusive(), rate),
+				sourceRate: rc.MediaRange.StartTime().Rate(),
+			}
+			if s.sourceRate <= 0 {
+				s.sourceRate = rate
+			}
+			s.sourceFirst = rc.MediaRange.StartTime().RescaledTo(s.sourceRate).Value()
+			if s.end > s.first {
+				spans = append(spans, s)
+				cuts = append(cuts, s.first, s.end)
+			}
+		}
+		slices.Sort(cuts)
+
+		for index, start := 0, first; start < end; index++ {
+			stop := min(start+chunkSize, end)
+			if cfg.SplitOnClips {
+				if i, _ := slices.BinarySearch(cuts, start+1); i < len(cuts) {
+					stop = min(stop, cuts[i])
+				}
+			}
+			chunk := Chunk{Index: index, FirstFrame: start, LastFrame: stop - 1}
+			for _, s := range spans {
+				if s.first < stop && s.end > start {
+					chunk.Segments = append(chunk.Segments, s.segment(max(s.first, start), min(s.end, stop), rate))
+				}
+			}
+			slices.SortStableFunc(chunk.Segments, func(a, b Segment) int {
+				return a.TrackIndex - b.TrackIndex
+			})
+			if !yield(chunk, nil) {
+				return
+			}
+			start = stop
+		}
+	}
+}
+
+// segment returns the part of the span from record frame first to end,
+// exclusive.
+func (s span) segment(first, end int, rate float64) Segment {
+	ratio := s.sourceRate / rate
+	sourceFirst := int(math.Round(s.sourceFirst + float64(first-s.first)*ratio))
+	sourceEnd := int(math.Round(s.sourceFirst + float64(end-s.first)*ratio))
+	sourceLast := max(sourceEnd-1, sourceFirst)
+	clip := s.clip.Clip
+	if seq, ok := clip.MediaReference().(*gotio.ImageSequenceReference); ok {
+		sourceFirst = seq.FrameForTime(opentime.NewRationalTime(float64(sourceFirst), s.sourceRate))
+		sourceLast = seq.FrameForTime(opentime.NewRationalTime(float64(sourceLast), s.sourceRate))
+	}
+	return Segment{
+		Clip:        clip,
+		ClipName:    clip.Name(),
+		TrackName:   s.clip.TrackName,
+		TrackIndex:  s.clip.TrackIndex,
+		Media:       clip.MediaReference(),
+		MediaURL:    mediaURL(clip.MediaReference()),
+		FirstFrame:  first,
+		LastFrame:   end - 1,
+		SourceFirst: sourceFirst,
+		SourceLast:  sourceLast,
+		SourceRate:  s.sourceRate,
+		Disabled:    !clip.Enabled(),
+	}
+}
+
+// toFrame returns t as a whole frame at rate.
+func toFrame(t opentime.RationalTime, rate float64) int {
+	if t.Rate() <= 0 {
+		return 0
+	}
+	return int(math.Round(t.RescaledTo(rate).Value()))
+}
+
+// mediaURL returns the URL of an external reference or the pattern URL of
+// an image sequence.
+func mediaURL(ref gotio.MediaReference) string {
+	switch r := ref.(type) {
+	case *gotio.ExternalReference:
+		return r.TargetURL()
+	case *gotio.ImageSequenceReference:
+		return r.AbstractTargetURL(strings.Repeat("#", max(r.FrameZeroPadding(), 1)))
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package render
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func frames(start, duration float64) *opentime.TimeRange {
+	r := opentime.NewTimeRange(opentime.NewRationalTime(start, 24), opentime.NewRationalTime(duration, 24))
+	return &r
+}
+
+// farmTimeline starts at frame 1001 with a movie, a gap and a disabled
+// clip on V1, and an image sequence over the cut on V2.
+func farmTimeline() *gotio.Timeline {
+	timeline := gotio.NewTimeline("farm", nil, nil)
+	gst := opentime.NewRationalTime(1001, 24)
+	timeline.SetGlobalStartTime(&gst)
+
+	v1 := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	v1.AppendChild(gotio.NewClip("A", gotio.NewExternalReference("", "/media/a.mov", nil, nil), frames(100, 30), nil, nil, nil, "", nil))
+	v1.AppendChild(gotio.NewGap("", frames(0, 10), nil, nil, nil, nil))
+	b := gotio.NewClip("B", nil, frames(0, 20), nil, nil, nil, "", nil)
+	b.SetEnabled(false)
+	v1.AppendChild(b)
+
+	seq := gotio.NewImageSequenceReference("", "/renders/", "c.", ".exr", 1001, 1, 24, 4, frames(0, 48), nil, gotio.MissingFramePolicyError)
+	v2 := gotio.NewTrack("V2", nil, gotio.TrackKindVideo, nil, nil)
+	v2.AppendChild(gotio.NewGap("", frames(0, 35), nil, nil, nil, nil))
+	v2.AppendChild(gotio.NewClip("C", seq, frames(2, 10), nil, nil, nil, "", nil))
+
+	timeline.Tracks().AppendChild(v1)
+	timeline.Tracks().AppendChild(v2)
+	return timeline
+}
+
+func collect(t *testing.T, timeline *gotio.Timeline, size int, opts ...Option) []Chunk {
+	t.Helper()
+	var chunks []Chunk
+	for chunk, err := range Chunks(timeline, size, opts...) {
+		if err != nil {
+			t.Fatalf("Chunks error: %v", err)
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+func TestChunks(t *testing.T) {
+	chunks := collect(t, farmTimeline(), 25)
+	if len(chunks) != 3 || chunks[0].FirstFrame != 1001 || chunks[1].FirstFrame != 1026 || chunks[2].LastFrame != 1060 {
+		t.Fatalf("chunks = %+v, want 1001-1025, 1026-1050 and 1051-1060", chunks)
+	}
+	if chunks[2].Frames() != 10 || len(chunks[2].Segments) != 0 {
+		t.Errorf("last chunk = %+v, want 10 frames of the gap and disabled clip", chunks[2])
+	}
+
+	a := chunks[0].Segments[0]
+	if a.ClipName != "A" || a.MediaURL != "/media/a.mov" || a.FirstFrame != 1001 || a.LastFrame != 1025 || a.SourceFirst != 100 || a.SourceLast != 124 {
+		t.Errorf("first segment = %+v", a)
+	}
+	segments := chunks[1].Segments
+	if len(segments) != 2 {
+		t.Fatalf("second chunk segments = %+v, want A and C", segments)
+	}
+	if a := segments[0]; a.ClipName != "A" || a.LastFrame != 1030 || a.SourceFirst != 125 || a.SourceLast != 129 {
+		t.Errorf("tail of A = %+v", a)
+	}
+	c := segments[1]
+	if c.TrackName != "V2" || c.MediaURL != "/renders/c.####.exr" || c.FirstFrame != 1036 || c.LastFrame != 1045 || c.SourceFirst != 1003 || c.SourceLast != 1012 {
+		t.Errorf("image sequence segment = %+v", c)
+	}
+}
+
+func TestChunksSplitOnClips(t *testing.T) {
+	chunks := collect(t, farmTimeline(), 25, WithSplitOnClips(true), WithSkipDisabled(false))
+	want := [][2]int{{1001, 1025}, {1026, 1030}, {1031, 1035}, {1036, 1040}, {1041, 1045}, {1046, 1060}}
+	if len(chunks) != len(want) {
+		t.Fatalf("got %d chunks, want %d: %+v", len(chunks), len(want), chunks)
+	}
+	for i, w := range want {
+		if chunks[i].Index != i || chunks[i].FirstFrame != w[0] || chunks[i].LastFrame != w[1] {
+			t.Errorf("chunk %d = %d-%d, want %d-%d", i, chunks[i].FirstFrame, chunks[i].LastFrame, w[0], w[1])
+		}
+	}
+	b := chunks[5].Segments
+	if len(b) != 1 || b[0].ClipName != "B" || !b[0].Disabled || b[0].SourceFirst != 5 || b[0].SourceLast != 19 {
+		t.Errorf("last chunk segments = %+v, want the disabled B from source frame 5", b)
+	}
+
+	for _, err := range Chunks(farmTimeline(), 0) {
+		if !errors.Is(err, ErrInvalidChunkSize) {
+			t.Errorf("expected ErrInvalidChunkSize, got %v", err)
+		}
+	}
+}
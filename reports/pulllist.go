@@ -0,0 +1,317 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package reports builds production reports from timelines, such as the
+// pull list of media ranges to extract for VFX.
+//
+// Basic usage:
+//
+//	records, err := reports.PullList(timeline, reports.WithHandles(8))
+//	err = reports.WritePullListCSV(os.Stdout, records)
+package reports
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func init() {
+	gotio.RegisterFeature("reports")
+}
+
+// PullRecord is the media a timeline uses from one source.
+type PullRecord struct {
+	// Media is the target URL of the media. Image sequences use their
+	// abstract URL with "#" for the frame number.
+	Media string `json:"media"`
+	// Rate is the rate the frames of the ranges are counted at.
+	Rate float64 `json:"rate"`
+	// Ranges are the used ranges with handles, merged and in order.
+	Ranges []PullRange `json:"ranges"`
+	// Frames is the number of frames in all ranges.
+	Frames float64 `json:"frames"`
+	// Clips names the clips using the media, in timeline order.
+	Clips []string `json:"clips"`
+	// Record holds the record in and out timecode of each clip, parallel
+	// to Clips, when PullList is given WithRecordTimecode.
+	Record []gotio.TimecodeRange `json:"record,omitempty"`
+	// ShortHandles is set if the available range of the media could not
+	// give some range its full handles.
+	ShortHandles bool `json:"short_handles,omitempty"`
+}
+
+// PullRange is one range of media to pull, in frames of media time.
+type PullRange struct {
+	// Name is the target file name of the pull.
+	Name string `json:"name"`
+	// Start and End are the first and last frames, inclusive.
+	Start  float64 `json:"start"`
+	End    float64 `json:"end"`
+	Frames float64 `json:"frames"`
+}
+
+// PullConfig holds options for PullList.
+type PullConfig struct {
+	// Handles is the number of frames added at each end of every used
+	// range, as far as the media's available range allows.
+	Handles float64
+	// MergeGap merges used ranges of the same media separated by at most
+	// this many frames. Overlapping and touching ranges are always merged.
+	MergeGap float64
+	// Naming returns the target file name of the range at index of the
+	// record. The default is the media's base name without its extension,
+	// followed by the first and last frames.
+	Naming func(record *PullRecord, index int) string
+	// RecordTimecode adds the record timecode of each clip, offset by the
+	// timeline's global start time.
+	RecordTimecode bool
+	// Rate is the record timecode rate; zero uses the rate of each clip's
+	// range in the timeline.
+	Rate float64
+	// DropFrame selects drop-frame record timecode.
+	DropFrame opentime.IsDropFrameRate
+}
+
+// PullOption is a functional option for PullList.
+type PullOption func(*PullConfig)
+
+// WithHandles sets the frames added at each end of every used range.
+func WithHandles(frames float64) PullOption {
+	return func(c *PullConfig) {
+		c.Handles = frames
+	}
+}
+
+// WithMergeGap sets the largest gap in frames between used ranges that
+// are merged.
+func WithMergeGap(frames float64) PullOption {
+	return func(c *PullConfig) {
+		c.MergeGap = frames
+	}
+}
+
+// WithNaming sets the function naming each range.
+func WithNaming(naming func(record *PullRecord, index int) string) PullOption {
+	return func(c *PullConfig) {
+		c.Naming = naming
+	}
+}
+
+// WithRecordTimecode adds the record timecode of each clip at rate, zero
+// for the rate of the clip's range in the timeline.
+func WithRecordTimecode(rate float64, drop opentime.IsDropFrameRate) PullOption {
+	return func(c *PullConfig) {
+		c.RecordTimecode = true
+		c.Rate = rate
+		c.DropFrame = drop
+	}
+}
+
+// PullList returns a record for each media source the timeline's enabled
+// clips use, in the order the timeline first uses them. The used range of
+// a clip is its source range, stretched by the speed of LinearTimeWarp
+// and FreezeFrame effects. Clips with missing or generated media are left
+// out.
+func PullList(timeline *gotio.Timeline, opts ...PullOption) ([]PullRecord, error) {
+	if timeline == nil {
+		return nil, fmt.Errorf("reports: timeline is nil")
+	}
+	cfg := PullConfig{Naming: defaultPullName}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.Handles < 0 || cfg.MergeGap < 0 {
+		return nil, fmt.Errorf("reports: handles and merge gap must not be negative")
+	}
+
+	var records []*PullRecord
+	byMedia := make(map[string]*PullRecord)
+	used := make(map[*PullRecord][]opentime.TimeRange)
+	available := make(map[*PullRecord]*opentime.TimeRange)
+	for _, clip := range timeline.FindClips(nil, false) {
+		if !clip.Enabled() {
+			continue
+		}
+		media, ok := mediaURL(clip.MediaReference())
+		if !ok {
+			continue
+		}
+		usedRange, err := usedRange(clip)
+		if err != nil {
+			return nil, fmt.Errorf("reports: clip %q: %w", clip.Name(), err)
+		}
+
+		record := byMedia[media]
+		if record == nil {
+			record = &PullRecord{Media: media, Rate: usedRange.StartTime().Rate()}
+			if ar := clip.MediaReference().AvailableRange(); ar != nil {
+				record.Rate = ar.StartTime().Rate()
+				available[record] = ar
+			}
+			byMedia[media] = record
+			records = append(records, record)
+		}
+		record.Clips = append(record.Clips, clip.Name())
+		if cfg.RecordTimecode {
+			tc, err := timeline.RecordTimecodeRange(clip, cfg.Rate, cfg.DropFrame)
+			if err != nil {
+				return nil, fmt.Errorf("reports: clip %q: %w", clip.Name(), err)
+			}
+			record.Record = append(record.Record, tc)
+		}
+		used[record] = append(used[record], usedRange)
+	}
+
+	result := make([]PullRecord, 0, len(records))
+	for _, record := range records {
+		ranges := frameRanges(used[record], record.Rate)
+		short := false
+		for i := range ranges {
+			ranges[i][0] -= cfg.Handles
+			ranges[i][1] += cfg.Handles
+			if ar := available[record]; ar != nil {
+				first := math.Round(ar.StartTime().RescaledTo(record.Rate).Value())
+				last := first + math.Round(ar.Duration().RescaledTo(record.Rate).Value()) - 1
+				if ranges[i][0] < first || ranges[i][1] > last {
+					short = true
+					ranges[i][0] = math.Max(ranges[i][0], first)
+					ranges[i][1] = math.Min(ranges[i][1], last)
+				}
+			}
+		}
+		record.ShortHandles = short
+		for _, r := range mergeFrameRanges(ranges, cfg.MergeGap) {
+			frames := r[1] - r[0] + 1
+			record.Ranges = append(record.Ranges, PullRange{Start: r[0], End: r[1], Frames: frames})
+			record.Frames += frames
+		}
+		for i := range record.Ranges {
+			record.Ranges[i].Name = cfg.Naming(record, i)
+		}
+		result = append(result, *record)
+	}
+	return result, nil
+}
+
+// mediaURL returns the URL identifying the media of ref, and false for
+// references without media to pull.
+func mediaURL(ref gotio.MediaReference) (string, bool) {
+	switch r := ref.(type) {
+	case *gotio.ExternalReference:
+		return r.TargetURL(), r.TargetURL() != ""
+	case *gotio.ImageSequenceReference:
+		return r.AbstractTargetURL("#"), r.TargetURLBase() != ""
+	}
+	return "", false
+}
+
+// usedRange returns the range of media the clip plays.
+func usedRange(clip *gotio.Clip) (opentime.TimeRange, error) {
+	sourceRange, err := clip.TrimmedRange()
+	if err != nil {
+		return opentime.TimeRange{}, err
+	}
+	scalar := 1.0
+	for _, effect := range clip.Effects() {
+		if e, ok := effect.(interface{ TimeScalar() float64 }); ok {
+			scalar *= e.TimeScalar()
+		}
+	}
+	duration := sourceRange.Duration()
+	frames := math.Max(1, math.Abs(duration.Value()*scalar))
+	return opentime.NewTimeRange(sourceRange.StartTime(), opentime.NewRationalTime(frames, duration.Rate())), nil
+}
+
+// frameRanges returns the first and last frames of the ranges at rate.
+func frameRanges(ranges []opentime.TimeRange, rate float64) [][2]float64 {
+	result := make([][2]float64, 0, len(ranges))
+	for _, r := range ranges {
+		first := math.Floor(r.StartTime().RescaledTo(rate).Value() + opentime.DefaultEpsilon)
+		end := math.Ceil(r.EndTimeExclusive().RescaledTo(rate).Value() - opentime.DefaultEpsilon)
+		result = append(result, [2]float64{first, math.Max(first, end-1)})
+	}
+	return result
+}
+
+// mergeFrameRanges sorts the ranges and merges those overlapping or
+// separated by at most gap frames.
+func mergeFrameRanges(ranges [][2]float64, gap float64) [][2]float64 {
+	slices.SortFunc(ranges, func(a, b [2]float64) int { return cmp.Compare(a[0], b[0]) })
+	var merged [][2]float64
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r[0] <= merged[n-1][1]+1+gap {
+			merged[n-1][1] = math.Max(merged[n-1][1], r[1])
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// defaultPullName names a range after the media and its frames, such as
+// A001C003_1001-1096.
+func defaultPullName(record *PullRecord, index int) string {
+	base := path.Base(strings.ReplaceAll(record.Media, "\\", "/"))
+	base = strings.TrimSuffix(base, path.Ext(base))
+	base = strings.TrimSuffix(strings.TrimSuffix(base, "#"), ".")
+	r := record.Ranges[index]
+	return fmt.Sprintf("%s_%s-%s", base, formatFrame(r.Start), formatFrame(r.End))
+}
+
+func formatFrame(frame float64) string {
+	return strconv.FormatFloat(frame, 'f', -1, 64)
+}
+
+// WritePullListJSON writes the records as indented JSON.
+func WritePullListJSON(w io.Writer, records []PullRecord) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
+
+// WritePullListCSV writes the records as CSV with a header row and a row
+// for each range. Records with record timecodes add a record column
+// listing the in and out of each clip.
+func WritePullListCSV(w io.Writer, records []PullRecord) error {
+	withRecord := slices.ContainsFunc(records, func(r PullRecord) bool { return len(r.Record) > 0 })
+	cw := csv.NewWriter(w)
+	header := []string{"media", "name", "start", "end", "frames", "rate", "clips"}
+	if withRecord {
+		header = append(header, "record")
+	}
+	cw.Write(header)
+	for _, record := range records {
+		for _, r := range record.Ranges {
+			row := []string{
+				record.Media,
+				r.Name,
+				formatFrame(r.Start),
+				formatFrame(r.End),
+				formatFrame(r.Frames),
+				formatFrame(record.Rate),
+				strings.Join(record.Clips, " "),
+			}
+			if withRecord {
+				tcs := make([]string, len(record.Record))
+				for i, tc := range record.Record {
+					tcs[i] = tc.In + "-" + tc.Out
+				}
+				row = append(row, strings.Join(tcs, " "))
+			}
+			cw.Write(row)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package reports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func pullClip(name, url string, start, duration float64, effects ...gotio.Effect) *gotio.Clip {
+	available := opentime.NewTimeRange(opentime.NewRationalTime(1000, 24), opentime.NewRationalTime(200, 24))
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(start, 24), opentime.NewRationalTime(duration, 24))
+	var ref gotio.MediaReference = gotio.NewMissingReference("", nil, nil)
+	if url != "" {
+		ref = gotio.NewExternalReference("", url, &available, nil)
+	}
+	return gotio.NewClip(name, ref, &sr, nil, effects, nil, "", nil)
+}
+
+func TestPullList(t *testing.T) {
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	track.AppendChild(pullClip("sh010", "/plates/A001C003.mov", 1010, 20))
+	track.AppendChild(pullClip("sh020", "/plates/B002C001.mov", 1100, 10, gotio.NewLinearTimeWarp("", "", 2, nil)))
+	track.AppendChild(pullClip("sh030", "/plates/A001C003.mov", 1025, 20))
+	track.AppendChild(pullClip("sh040", "/plates/A001C003.mov", 1150, 10))
+	track.AppendChild(pullClip("sh050", "", 0, 10))
+	disabled := pullClip("sh060", "/plates/C003C002.mov", 1000, 10)
+	disabled.SetEnabled(false)
+	track.AppendChild(disabled)
+	timeline := gotio.NewTimeline("pulls", nil, nil)
+	timeline.Tracks().AppendChild(track)
+
+	records, err := PullList(timeline, WithHandles(8))
+	if err != nil {
+		t.Fatalf("PullList error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %+v", records)
+	}
+
+	a := records[0]
+	if a.Media != "/plates/A001C003.mov" || len(a.Clips) != 3 || a.Rate != 24 {
+		t.Errorf("unexpected record %+v", a)
+	}
+	want := []PullRange{
+		{Name: "A001C003_1002-1052", Start: 1002, End: 1052, Frames: 51},
+		{Name: "A001C003_1142-1167", Start: 1142, End: 1167, Frames: 26},
+	}
+	if len(a.Ranges) != 2 || a.Ranges[0] != want[0] || a.Ranges[1] != want[1] {
+		t.Errorf("ranges = %+v, want %+v", a.Ranges, want)
+	}
+	if a.Frames != 77 || a.ShortHandles {
+		t.Errorf("Frames = %v, ShortHandles = %v", a.Frames, a.ShortHandles)
+	}
+	if b := records[1]; b.Ranges[0].Start != 1092 || b.Ranges[0].End != 1127 {
+		t.Errorf("expected the speed change to double the range, got %+v", b.Ranges)
+	}
+
+	records, err = PullList(timeline, WithHandles(12), WithMergeGap(100))
+	if err != nil {
+		t.Fatalf("PullList error: %v", err)
+	}
+	if a := records[0]; len(a.Ranges) != 1 || a.Ranges[0].Start != 1000 || !a.ShortHandles {
+		t.Errorf("expected one range clamped to the media, got %+v", a)
+	}
+
+	var buf bytes.Buffer
+	if err := WritePullListCSV(&buf, records); err != nil {
+		t.Fatalf("WritePullListCSV error: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(rows) != 3 || rows[1][1] != records[0].Ranges[0].Name {
+		t.Errorf("unexpected CSV %v (%v)", rows, err)
+	}
+
+	buf.Reset()
+	if err := WritePullListJSON(&buf, records); err != nil {
+		t.Fatalf("WritePullListJSON error: %v", err)
+	}
+	var decoded []PullRecord
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || len(decoded) != 2 {
+		t.Errorf("invalid JSON %s (%v)", buf.String(), err)
+	}
+
+	hour := opentime.NewRationalTime(86400, 24)
+	timeline.SetGlobalStartTime(&hour)
+	records, err = PullList(timeline, WithRecordTimecode(0, opentime.ForceNo))
+	if err != nil {
+		t.Fatalf("PullList with record timecode: %v", err)
+	}
+	if r := records[0].Record; len(r) != 3 || r[0].String() != "01:00:00:00 - 01:00:00:20" || r[1].In != "01:00:01:06" {
+		t.Errorf("record timecodes = %v", r)
+	}
+	buf.Reset()
+	WritePullListCSV(&buf, records)
+	if rows, _ := csv.NewReader(&buf).ReadAll(); len(rows) < 2 || rows[0][7] != "record" || rows[1][7] == "" {
+		t.Errorf("expected a record column, got %v", rows)
+	}
+
+	if _, err := PullList(timeline, WithHandles(-1)); err == nil {
+		t.Error("expected an error for negative handles")
+	}
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"slices"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// ResolvedClip is a clip placed in timeline time, as returned by
+// Timeline.ResolvedClips.
+type ResolvedClip struct {
+	Clip *Clip
+	// TrackName is the name of the innermost track holding the clip.
+	TrackName string
+	// TrackIndex is the index in the timeline's stack of the top-level
+	// track holding the clip; higher indexes are composited on top.
+	TrackIndex int
+	// GlobalRange is the visible part of the clip in timeline time,
+	// offset by the timeline's global start time.
+	GlobalRange opentime.TimeRange
+	// MediaRange is the part of the clip's media shown during GlobalRange.
+	MediaRange opentime.TimeRange
+	// ActiveEffects are the clip's effects followed by those of its
+	// enclosing compositions, innermost first.
+	ActiveEffects []Effect
+}
+
+// ResolvedClips returns every clip that is seen in the timeline, placed in
+// timeline time and sorted by start time, with clips starting together in
+// track order. Disabled items and anything trimmed out of view are left
+// out, unless WithIncludeDisabled is given, and clips cut short by a nested
+// composition are narrowed to their visible part. Of the other search
+// options only WithTrackKinds applies.
+//
+// The result is a snapshot; editing the timeline does not update it.
+func (t *Timeline) ResolvedClips(opts ...SearchOption) ([]ResolvedClip, error) {
+	cfg := newSearchConfig(false, opts)
+	if t.tracks == nil || !t.tracks.Enabled() && !cfg.IncludeDisabled {
+		return nil, nil
+	}
+
+	window, err := t.tracks.TrimmedRange()
+	if err != nil {
+		return nil, err
+	}
+	offset := opentime.NewRationalTime(0, window.StartTime().Rate()).Sub(window.StartTime())
+	if t.globalStartTime != nil {
+		offset = offset.Add(*t.globalStartTime)
+	}
+
+	r := &clipResolver{cfg: &cfg}
+	if err := r.resolve(t.tracks, window, offset, "", -1, t.tracks.Effects()); err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(r.result, func(a, b ResolvedClip) int {
+		return a.GlobalRange.StartTime().Cmp(b.GlobalRange.StartTime())
+	})
+	return r.result, nil
+}
+
+type clipResolver struct {
+	cfg    *SearchConfig
+	result []ResolvedClip
+}
+
+// resolve walks comp, whose children are seen through window (in the
+// children's coordinates) and shifted by offset into timeline time. A
+// negative trackIndex marks the timeline's stack.
+func (r *clipResolver) resolve(comp Composition, window opentime.TimeRange, offset opentime.RationalTime, trackName string, trackIndex int, effects []Effect) error {
+	for i, child := range comp.Children() {
+		index := trackIndex
+		if index < 0 {
+			index = i
+		}
+		item, ok := child.(Item)
+		if !ok || !item.Enabled() && !r.cfg.IncludeDisabled {
+			continue
+		}
+		if _, ok := child.(*Transition); ok {
+			continue
+		}
+		childRange, err := comp.RangeOfChildAtIndex(i)
+		if err != nil {
+			return err
+		}
+		visible, ok := intersectRange(childRange, window)
+		if !ok {
+			continue
+		}
+		trimmed, err := item.TrimmedRange()
+		if err != nil {
+			return err
+		}
+		// Maps a time in comp's child coordinates to the child's own.
+		toChild := trimmed.StartTime().Sub(childRange.StartTime())
+
+		switch c := child.(type) {
+		case *Clip:
+			r.result = append(r.result, ResolvedClip{
+				Clip:          c,
+				TrackName:     trackName,
+				TrackIndex:    index,
+				GlobalRange:   opentime.NewTimeRange(visible.StartTime().Add(offset), visible.Duration()),
+				MediaRange:    opentime.NewTimeRange(visible.StartTime().Add(toChild), visible.Duration()),
+				ActiveEffects: append(slices.Clone(c.Effects()), effects...),
+			})
+		case Composition:
+			name := trackName
+			if track, ok := c.(*Track); ok {
+				if len(r.cfg.TrackKinds) > 0 && !slices.Contains(r.cfg.TrackKinds, track.Kind()) {
+					continue
+				}
+				name = track.Name()
+			}
+			nestedWindow := opentime.NewTimeRange(visible.StartTime().Add(toChild), visible.Duration())
+			nestedEffects := append(slices.Clone(c.Effects()), effects...)
+			if err := r.resolve(c, nestedWindow, offset.Sub(toChild), name, index, nestedEffects); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// intersectRange returns the overlap of a and b, and false if they do not
+// overlap.
+func intersectRange(a, b opentime.TimeRange) (opentime.TimeRange, bool) {
+	start := a.StartTime()
+	if b.StartTime().Cmp(start) > 0 {
+		start = b.StartTime()
+	}
+	end := a.EndTimeExclusive()
+	if b.EndTimeExclusive().Cmp(end) < 0 {
+		end = b.EndTimeExclusive()
+	}
+	if end.Cmp(start) <= 0 {
+		return opentime.TimeRange{}, false
+	}
+	return opentime.RangeFromStartEndTime(start, end), true
+}
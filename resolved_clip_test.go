@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func TestTimelineResolvedClips(t *testing.T) {
+	// V1: a[0,24) (dissolve) b[24,48) nested[48,72){ c[0,24) e trimmed out }
+	// A1: gap[0,24) d[24,48)
+	timeline := searchTestTimeline(t)
+	start := opentime.NewRationalTime(86400, 24)
+	timeline.SetGlobalStartTime(&start)
+
+	v1 := timeline.Tracks().Children()[0].(*Track)
+	v1.SetEffects([]Effect{NewEffect("grade", "ColorCorrect", nil)})
+
+	resolved, err := timeline.ResolvedClips()
+	if err != nil {
+		t.Fatalf("ResolvedClips error: %v", err)
+	}
+
+	want := []struct {
+		name  string
+		track string
+		start float64
+	}{
+		{"a", "V1", 86400},
+		{"b", "V1", 86424},
+		{"d", "A1", 86424},
+		{"c", "nested", 86448},
+	}
+	if len(resolved) != len(want) {
+		t.Fatalf("expected %d clips, got %d", len(want), len(resolved))
+	}
+	for i, w := range want {
+		rc := resolved[i]
+		if rc.Clip.Name() != w.name || rc.TrackName != w.track || rc.GlobalRange.StartTime().Value() != w.start {
+			t.Errorf("clip %d = %s on %s at %v, want %s on %s at %v",
+				i, rc.Clip.Name(), rc.TrackName, rc.GlobalRange.StartTime().Value(), w.name, w.track, w.start)
+		}
+	}
+	if resolved[2].TrackIndex != 1 || resolved[3].TrackIndex != 0 {
+		t.Errorf("TrackIndex of d, c = %d, %d, want 1, 0", resolved[2].TrackIndex, resolved[3].TrackIndex)
+	}
+	if n := len(resolved[0].ActiveEffects); n != 1 {
+		t.Errorf("expected the track effect to be active on a, got %d effects", n)
+	}
+	if n := len(resolved[2].ActiveEffects); n != 0 {
+		t.Errorf("expected no effects on d, got %d", n)
+	}
+
+	audio, err := timeline.ResolvedClips(WithTrackKinds(TrackKindAudio))
+	if err != nil {
+		t.Fatalf("ResolvedClips error: %v", err)
+	}
+	if len(audio) != 1 || audio[0].Clip.Name() != "d" {
+		t.Errorf("expected only d for audio, got %d clips", len(audio))
+	}
+}
+
+func TestResolvedClipsTrimmed(t *testing.T) {
+	// The track shows frames 10-40 of a 24 frame clip followed by a 24 frame clip.
+	track := NewTrack("V1", searchTestRange(10, 30), TrackKindVideo, nil, nil)
+	first := searchTestClip("first", 24)
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(100, 24), opentime.NewRationalTime(24, 24))
+	first.SetSourceRange(&sr)
+	track.AppendChild(first)
+	track.AppendChild(searchTestClip("second", 24))
+	disabled := searchTestClip("disabled", 24)
+	disabled.SetEnabled(false)
+	track.AppendChild(disabled)
+
+	timeline := NewTimeline("trimmed", nil, nil)
+	timeline.Tracks().AppendChild(track)
+
+	resolved, err := timeline.ResolvedClips()
+	if err != nil {
+		t.Fatalf("ResolvedClips error: %v", err)
+	}
+	if len(resolved) != 2 {
+		t.Fatalf("expected 2 clips, got %d", len(resolved))
+	}
+
+	a := resolved[0]
+	if a.GlobalRange.StartTime().Value() != 0 || a.GlobalRange.Duration().Value() != 14 {
+		t.Errorf("first GlobalRange = %v, want 0 for 14 frames", a.GlobalRange)
+	}
+	if a.MediaRange.StartTime().Value() != 110 || a.MediaRange.Duration().Value() != 14 {
+		t.Errorf("first MediaRange = %v, want 110 for 14 frames", a.MediaRange)
+	}
+	b := resolved[1]
+	if b.GlobalRange.StartTime().Value() != 14 || b.GlobalRange.Duration().Value() != 16 {
+		t.Errorf("second GlobalRange = %v, want 14 for 16 frames", b.GlobalRange)
+	}
+	if b.MediaRange.StartTime().Value() != 0 {
+		t.Errorf("second MediaRange = %v, want start 0", b.MediaRange)
+	}
+
+	b.Clip.SetEnabled(false)
+	if resolved, _ := timeline.ResolvedClips(); len(resolved) != 1 {
+		t.Errorf("expected the disabled clip left out, got %d clips", len(resolved))
+	}
+	if resolved, _ := timeline.ResolvedClips(WithIncludeDisabled()); len(resolved) != 2 || resolved[1].Clip != b.Clip {
+		t.Errorf("expected the disabled clip included, got %d clips", len(resolved))
+	}
+}
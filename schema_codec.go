@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/Avalanche-io/gotio/internal/jsonenc"
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// SchemaEncoder writes the fields of a custom schema object.
+// The enclosing braces and OTIO_SCHEMA field are written by the caller.
+type SchemaEncoder func(w *SchemaWriter, obj SerializableObject) error
+
+// SchemaDecoder builds a custom schema object from its decoded fields.
+type SchemaDecoder func(fields SchemaFields) (SerializableObject, error)
+
+var (
+	schemaDecoders   = make(map[string]SchemaDecoder)
+	schemaCodecs     = make(map[string]Schema)
+	schemaDecodersMu sync.RWMutex
+)
+
+// RegisterSchemaCodec registers an encoder and decoder for a custom schema,
+// so it is serialized without going through UnknownSchema. goType is the
+// type the encoder accepts, typically a pointer such as (*MyType)(nil).
+// Either function may be nil. Code generated by otiogen calls this from init.
+func RegisterSchemaCodec(schema Schema, goType any, encode SchemaEncoder, decode SchemaDecoder) {
+	if encode != nil {
+		info := jsonenc.TypeInfo{
+			SchemaName:    schema.Name,
+			SchemaVersion: schema.Version,
+			Encode: func(enc *jsonenc.Encoder, v any) error {
+				obj, ok := v.(SerializableObject)
+				if !ok {
+					return &TypeMismatchError{Expected: "SerializableObject", Got: reflect.TypeOf(v).String()}
+				}
+				enc.BeginObject()
+				enc.WriteStringField("OTIO_SCHEMA", schema.String())
+				if err := encode(&SchemaWriter{enc: enc}, obj); err != nil {
+					return err
+				}
+				enc.EndObject()
+				return nil
+			},
+		}
+		if goType != nil {
+			info.GoType = reflect.TypeOf(goType)
+		}
+		jsonenc.Register(info)
+	}
+
+	schemaDecodersMu.Lock()
+	schemaCodecs[schema.String()] = schema
+	if decode != nil {
+		schemaDecoders[schema.String()] = decode
+	}
+	schemaDecodersMu.Unlock()
+}
+
+// lookupSchemaDecoder returns the registered decoder for a schema string.
+func lookupSchemaDecoder(schema string) (SchemaDecoder, bool) {
+	schemaDecodersMu.RLock()
+	defer schemaDecodersMu.RUnlock()
+	decode, ok := schemaDecoders[schema]
+	return decode, ok
+}
+
+// decodeRegisteredSchema decodes m with a registered decoder, if there is one.
+func decodeRegisteredSchema(m map[string]any) (SerializableObject, bool, error) {
+	schema, _ := m["OTIO_SCHEMA"].(string)
+	decode, ok := lookupSchemaDecoder(schema)
+	if !ok {
+		return nil, false, nil
+	}
+	obj, err := decode(SchemaFields(m))
+	return obj, true, err
+}
+
+// SchemaWriter writes fields of a custom schema object.
+type SchemaWriter struct {
+	enc *jsonenc.Encoder
+}
+
+// WriteString writes a string field.
+func (w *SchemaWriter) WriteString(key, value string) {
+	w.enc.WriteStringField(key, value)
+}
+
+// WriteInt writes an integer field.
+func (w *SchemaWriter) WriteInt(key string, value int) {
+	w.enc.WriteIntField(key, value)
+}
+
+// WriteFloat64 writes a number field.
+func (w *SchemaWriter) WriteFloat64(key string, value float64) {
+	w.enc.WriteFloat64Field(key, value)
+}
+
+// WriteBool writes a boolean field.
+func (w *SchemaWriter) WriteBool(key string, value bool) {
+	w.enc.WriteBoolField(key, value)
+}
+
+// WriteRationalTime writes a RationalTime field, or null if t is nil.
+func (w *SchemaWriter) WriteRationalTime(key string, t *opentime.RationalTime) error {
+	if t == nil {
+		w.enc.WriteNullField(key)
+		return nil
+	}
+	return w.WriteValue(key, *t)
+}
+
+// WriteTimeRange writes a TimeRange field, or null if tr is nil.
+func (w *SchemaWriter) WriteTimeRange(key string, tr *opentime.TimeRange) error {
+	if tr == nil {
+		w.enc.WriteNullField(key)
+		return nil
+	}
+	return w.WriteValue(key, *tr)
+}
+
+// WriteMetadata writes an AnyDictionary field.
+func (w *SchemaWriter) WriteMetadata(key string, metadata AnyDictionary) error {
+	return jsonenc.EncodeMetadata(w.enc, key, metadata)
+}
+
+// WriteObject writes a SerializableObject field, or null if obj is nil.
+func (w *SchemaWriter) WriteObject(key string, obj SerializableObject) error {
+	if obj == nil {
+		w.enc.WriteNullField(key)
+		return nil
+	}
+	return w.WriteValue(key, obj)
+}
+
+// WriteObjects writes a list of SerializableObjects.
+func (w *SchemaWriter) WriteObjects(key string, objs []SerializableObject) error {
+	w.enc.WriteKey(key)
+	w.enc.BeginArray()
+	for i, obj := range objs {
+		if i > 0 {
+			w.enc.WriteComma()
+		}
+		if err := jsonenc.EncodeValue(w.enc, obj); err != nil {
+			return err
+		}
+	}
+	w.enc.EndArray()
+	return nil
+}
+
+// WriteValue writes any value supported by the encoder registry.
+func (w *SchemaWriter) WriteValue(key string, v any) error {
+	w.enc.WriteKey(key)
+	return jsonenc.EncodeValue(w.enc, v)
+}
+
+// SchemaFields gives typed access to the fields of a decoded object.
+// Missing or mistyped fields decode as zero values.
+type SchemaFields map[string]any
+
+// String returns a string field.
+func (f SchemaFields) String(key string) string {
+	s, _ := f[key].(string)
+	return s
+}
+
+// Int returns an integer field.
+func (f SchemaFields) Int(key string) int {
+	return int(f.Float64(key))
+}
+
+// Float64 returns a number field.
+func (f SchemaFields) Float64(key string) float64 {
+	switch v := f[key].(type) {
+	case float64:
+		return v
+	case int64:
+		return float64(v)
+	case int:
+		return float64(v)
+	}
+	return 0
+}
+
+// Bool returns a boolean field.
+func (f SchemaFields) Bool(key string) bool {
+	b, _ := f[key].(bool)
+	return b
+}
+
+// RationalTime returns a RationalTime field, or nil if it is missing.
+func (f SchemaFields) RationalTime(key string) *opentime.RationalTime {
+	return decodeSonicRationalTime(nil, f[key])
+}
+
+// TimeRange returns a TimeRange field, or nil if it is missing.
+func (f SchemaFields) TimeRange(key string) *opentime.TimeRange {
+	return decodeSonicTimeRange(nil, f[key])
+}
+
+// Metadata returns an AnyDictionary field.
+func (f SchemaFields) Metadata(key string) AnyDictionary {
+	if md, ok := f[key].(map[string]any); ok {
+		return md
+	}
+	return nil
+}
+
+// Object returns a SerializableObject field, or nil if it is missing.
+func (f SchemaFields) Object(key string) (SerializableObject, error) {
+	m, ok := f[key].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	return decodeSonicObject(nil, m)
+}
+
+// Objects returns a list of SerializableObjects.
+func (f SchemaFields) Objects(key string) ([]SerializableObject, error) {
+	items, ok := f[key].([]any)
+	if !ok {
+		return nil, nil
+	}
+	var result []SerializableObject
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		obj, err := decodeSonicObject(nil, m)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, obj)
+	}
+	return result, nil
+}
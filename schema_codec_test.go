@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// codecShot is a third-party schema type used to exercise RegisterSchemaCodec.
+type codecShot struct {
+	SerializableObjectWithMetadataBase
+	shot     string
+	take     int
+	cutRange *opentime.TimeRange
+	plate    SerializableObject
+}
+
+func (s *codecShot) SchemaName() string { return "CodecShot" }
+func (s *codecShot) SchemaVersion() int { return 1 }
+func (s *codecShot) Clone() SerializableObject {
+	clone := *s
+	return &clone
+}
+func (s *codecShot) IsEquivalentTo(other SerializableObject) bool {
+	o, ok := other.(*codecShot)
+	return ok && o.shot == s.shot && o.take == s.take
+}
+
+// encodeCodecShot and decodeCodecShot mirror what otiogen generates.
+func encodeCodecShot(w *SchemaWriter, obj SerializableObject) error {
+	t := obj.(*codecShot)
+	w.WriteString("name", t.Name())
+	if err := w.WriteMetadata("metadata", t.Metadata()); err != nil {
+		return err
+	}
+	w.WriteString("shot", t.shot)
+	w.WriteInt("take", t.take)
+	if err := w.WriteTimeRange("cut_range", t.cutRange); err != nil {
+		return err
+	}
+	return w.WriteObject("plate", t.plate)
+}
+
+func decodeCodecShot(fields SchemaFields) (SerializableObject, error) {
+	t := &codecShot{}
+	t.SetName(fields.String("name"))
+	t.SetMetadata(fields.Metadata("metadata"))
+	t.shot = fields.String("shot")
+	t.take = fields.Int("take")
+	if t.take < 0 {
+		return nil, fmt.Errorf("take %d is negative", t.take)
+	}
+	t.cutRange = fields.TimeRange("cut_range")
+	plate, err := fields.Object("plate")
+	if err != nil {
+		return nil, err
+	}
+	t.plate = plate
+	return t, nil
+}
+
+func init() {
+	RegisterSchemaCodec(Schema{Name: "CodecShot", Version: 1}, (*codecShot)(nil), encodeCodecShot, decodeCodecShot)
+}
+
+func TestSchemaCodecRoundTrip(t *testing.T) {
+	cut := opentime.NewTimeRange(opentime.NewRationalTime(1001, 24), opentime.NewRationalTime(48, 24))
+	shot := &codecShot{
+		SerializableObjectWithMetadataBase: NewSerializableObjectWithMetadataBase("sh010", AnyDictionary{"vendor": "A"}),
+		shot:                               "sh010",
+		take:                               3,
+		cutRange:                           &cut,
+		plate:                              NewExternalReference("", "/plates/sh010.exr", nil, nil),
+	}
+
+	data, err := ToJSONBytes(shot)
+	if err != nil {
+		t.Fatalf("ToJSONBytes error: %v", err)
+	}
+	if !strings.Contains(string(data), `"OTIO_SCHEMA":"CodecShot.1"`) {
+		t.Errorf("expected schema in output, got %s", data)
+	}
+
+	obj, err := FromJSONBytes(data)
+	if err != nil {
+		t.Fatalf("FromJSONBytes error: %v", err)
+	}
+	decoded, ok := obj.(*codecShot)
+	if !ok {
+		t.Fatalf("expected *codecShot, got %T", obj)
+	}
+	if decoded.Name() != "sh010" || decoded.shot != "sh010" || decoded.take != 3 {
+		t.Errorf("unexpected decoded shot %+v", decoded)
+	}
+	if decoded.Metadata()["vendor"] != "A" {
+		t.Errorf("metadata = %v, want vendor A", decoded.Metadata())
+	}
+	if decoded.cutRange == nil || decoded.cutRange.StartTime().Value() != 1001 {
+		t.Errorf("cut range = %v, want start 1001", decoded.cutRange)
+	}
+	plate, ok := decoded.plate.(*ExternalReference)
+	if !ok || plate.TargetURL() != "/plates/sh010.exr" {
+		t.Errorf("plate = %v, want ExternalReference", decoded.plate)
+	}
+}
+
+func TestSchemaCodecInCollection(t *testing.T) {
+	collection := NewSerializableCollection("shots", nil, nil)
+	collection.AppendChild(&codecShot{shot: "sh020"})
+
+	data, err := ToJSONBytes(collection)
+	if err != nil {
+		t.Fatalf("ToJSONBytes error: %v", err)
+	}
+	obj, err := FromJSONBytes(data)
+	if err != nil {
+		t.Fatalf("FromJSONBytes error: %v", err)
+	}
+	children := obj.(*SerializableCollection).Children()
+	if len(children) != 1 {
+		t.Fatalf("expected 1 child, got %d", len(children))
+	}
+	if shot, ok := children[0].(*codecShot); !ok || shot.shot != "sh020" {
+		t.Errorf("expected decoded codecShot, got %T", children[0])
+	}
+}
+
+func TestSchemaCodecDecodeErrors(t *testing.T) {
+	const bad = `{"OTIO_SCHEMA": "CodecShot.1", "shot": "sh010", "take": -1}`
+	docs := map[string]string{
+		"child": `{"OTIO_SCHEMA": "Track.1", "name": "V1", "children": [` + bad + `]}`,
+		"media reference": `{"OTIO_SCHEMA": "Clip.2", "name": "sh010",
+			"media_references": {"DEFAULT_MEDIA": ` + bad + `}}`,
+		"effect": `{"OTIO_SCHEMA": "Gap.1", "effects": [` + bad + `]}`,
+		"nested": `{"OTIO_SCHEMA": "Timeline.1", "tracks": {"OTIO_SCHEMA": "Stack.1", "children": [
+			{"OTIO_SCHEMA": "Track.1", "children": [{"OTIO_SCHEMA": "Clip.2", "effects": [` + bad + `]}]}]}}`,
+	}
+	for name, doc := range docs {
+		t.Run(name, func(t *testing.T) {
+			_, err := FromJSONString(doc)
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) || schemaErr.Schema != "CodecShot" || !strings.Contains(err.Error(), "take -1 is negative") {
+				t.Errorf("expected a CodecShot SchemaError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSchemaFields(t *testing.T) {
+	fields := SchemaFields{
+		"s":   "text",
+		"n":   float64(7),
+		"b":   true,
+		"rt":  map[string]any{"OTIO_SCHEMA": "RationalTime.1", "value": float64(12), "rate": float64(24)},
+		"bad": []any{"x"},
+	}
+	if fields.String("s") != "text" || fields.Int("n") != 7 || !fields.Bool("b") {
+		t.Error("unexpected basic field values")
+	}
+	if rt := fields.RationalTime("rt"); rt == nil || rt.Value() != 12 {
+		t.Errorf("RationalTime = %v, want 12", rt)
+	}
+	if fields.String("missing") != "" || fields.TimeRange("missing") != nil {
+		t.Error("expected zero values for missing fields")
+	}
+	if objs, err := fields.Objects("bad"); err != nil || len(objs) != 0 {
+		t.Errorf("expected non-object entries to be skipped, got %v, %v", objs, err)
+	}
+}
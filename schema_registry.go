@@ -26,6 +26,7 @@ type SchemaFactory func() SerializableObject
 
 var (
 	schemaRegistry = make(map[string]SchemaFactory)
+	schemaVersions = make(map[string]int)
 	schemaAliases  = make(map[string]string) // alias -> canonical name
 	schemaLock     sync.RWMutex
 )
@@ -35,6 +36,7 @@ func RegisterSchema(schema Schema, factory SchemaFactory) {
 	schemaLock.Lock()
 	defer schemaLock.Unlock()
 	schemaRegistry[schema.Name] = factory
+	schemaVersions[schema.Name] = schema.Version
 }
 
 // RegisterSchemaAlias registers an alias name that maps to a canonical schema name.
@@ -62,7 +64,7 @@ func CreateSchema(schemaName string) (SerializableObject, error) {
 	resolved := resolveSchemaName(schemaName)
 	factory, ok := schemaRegistry[resolved]
 	if !ok {
-		return nil, &SchemaError{Schema: schemaName, Message: "schema not registered"}
+		return nil, &SchemaError{Schema: schemaName, Err: ErrSchemaNotRegistered}
 	}
 	return factory(), nil
 }
@@ -80,7 +82,7 @@ func IsSchemaRegistered(schemaName string) bool {
 // ParseSchema parses a schema string (e.g., "Clip.2") into name and version.
 func ParseSchema(schemaStr string) (name string, version int, err error) {
 	if schemaStr == "" {
-		return "", 0, &SchemaError{Schema: schemaStr, Message: "empty schema string"}
+		return "", 0, &SchemaError{Schema: schemaStr, Message: "empty schema string", Err: ErrInvalidSchema}
 	}
 
 	// Try to split on the last dot
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"fmt"
+	"iter"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// OTIOFileExtension is the extension of OTIO JSON files.
+const OTIOFileExtension = ".otio"
+
+// CollectionDirFiles returns the paths of the .otio files in dir, sorted by name.
+func CollectionDirFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), OTIOFileExtension) {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, entry.Name()))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// IterCollectionDir reads the .otio files in dir one at a time, so large
+// deliveries need not be held in memory at once. Iteration stops after
+// the first error.
+func IterCollectionDir(dir string) iter.Seq2[SerializableObject, error] {
+	return func(yield func(SerializableObject, error) bool) {
+		paths, err := CollectionDirFiles(dir)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for _, path := range paths {
+			obj, err := FromJSONFile(path)
+			if err != nil {
+				yield(nil, fmt.Errorf("%s: %w", path, err))
+				return
+			}
+			if !yield(obj, nil) {
+				return
+			}
+		}
+	}
+}
+
+// ReadCollectionDir builds a SerializableCollection from the .otio files
+// in dir. The collection is named after the directory.
+func ReadCollectionDir(dir string) (*SerializableCollection, error) {
+	collection := NewSerializableCollection(filepath.Base(dir), nil, nil)
+	for obj, err := range IterCollectionDir(dir) {
+		if err != nil {
+			return nil, err
+		}
+		collection.AppendChild(obj)
+	}
+	return collection, nil
+}
+
+// All iterates over the children depth first, descending into nested
+// collections. Nested collections are yielded before their children.
+func (s *SerializableCollection) All() iter.Seq[SerializableObject] {
+	return func(yield func(SerializableObject) bool) {
+		s.walk(yield)
+	}
+}
+
+// walk yields each descendant, returning false if iteration was stopped.
+func (s *SerializableCollection) walk(yield func(SerializableObject) bool) bool {
+	for _, child := range s.children {
+		if !yield(child) {
+			return false
+		}
+		if nested, ok := child.(*SerializableCollection); ok {
+			if !nested.walk(yield) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ChildByName returns the first descendant with the given name.
+func (s *SerializableCollection) ChildByName(name string) (SerializableObject, bool) {
+	for child := range s.All() {
+		if named, ok := child.(SerializableObjectWithMetadata); ok && named.Name() == name {
+			return child, true
+		}
+	}
+	return nil, false
+}
+
+// Timelines returns all timelines in the collection, including those in
+// nested collections.
+func (s *SerializableCollection) Timelines() []*Timeline {
+	return CollectionChildrenOfType[*Timeline](s)
+}
+
+// CollectionChildrenOfType returns all descendants of s with type T.
+func CollectionChildrenOfType[T SerializableObject](s *SerializableCollection) []T {
+	var result []T
+	for child := range s.All() {
+		if t, ok := child.(T); ok {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// WriteAll writes each child of the collection to its own .otio file in
+// dir, creating dir if needed. Files are named after the children, with
+// a numeric suffix for duplicates. It returns the written paths.
+func (s *SerializableCollection) WriteAll(dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	paths := make([]string, 0, len(s.children))
+	for i, child := range s.children {
+		base := ""
+		if named, ok := child.(SerializableObjectWithMetadata); ok {
+			base = collectionFileName(named.Name())
+		}
+		if base == "" {
+			base = fmt.Sprintf("item_%03d", i+1)
+		}
+		name := base
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			name = fmt.Sprintf("%s_%d", base, n)
+		}
+		used[strings.ToLower(name)] = true
+
+		path := filepath.Join(dir, name+OTIOFileExtension)
+		if err := ToJSONFile(child, path, "    "); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// collectionFileName turns an object name into a safe file name.
+func collectionFileName(name string) string {
+	name = strings.TrimSuffix(name, OTIOFileExtension)
+	return strings.Trim(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, name), "._")
+}
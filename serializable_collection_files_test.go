@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSerializableCollectionWriteAllAndRead(t *testing.T) {
+	episode := NewSerializableCollection("ep102", []SerializableObject{
+		NewTimeline("ep102 reel 1", nil, nil),
+	}, nil)
+	collection := NewSerializableCollection("delivery", []SerializableObject{
+		NewTimeline("ep101/reel 1", nil, nil),
+		NewTimeline("ep101/reel 1", nil, nil),
+		NewTimeline("", nil, nil),
+		episode,
+	}, nil)
+
+	dir := filepath.Join(t.TempDir(), "delivery")
+	paths, err := collection.WriteAll(dir)
+	if err != nil {
+		t.Fatalf("WriteAll error: %v", err)
+	}
+	want := []string{"ep101_reel_1.otio", "ep101_reel_1_2.otio", "item_003.otio", "ep102.otio"}
+	if len(paths) != len(want) {
+		t.Fatalf("expected %d files, got %v", len(want), paths)
+	}
+	for i, path := range paths {
+		if filepath.Base(path) != want[i] {
+			t.Errorf("path %d = %s, want %s", i, filepath.Base(path), want[i])
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("skip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := ReadCollectionDir(dir)
+	if err != nil {
+		t.Fatalf("ReadCollectionDir error: %v", err)
+	}
+	if read.Name() != "delivery" || len(read.Children()) != 4 {
+		t.Fatalf("unexpected collection %s with %d children", read.Name(), len(read.Children()))
+	}
+	if got := len(read.Timelines()); got != 4 {
+		t.Errorf("Timelines() = %d, want 4 including nested", got)
+	}
+	if _, ok := read.ChildByName("ep102 reel 1"); !ok {
+		t.Error("ChildByName should find nested timeline")
+	}
+	if got := len(CollectionChildrenOfType[*SerializableCollection](read)); got != 1 {
+		t.Errorf("expected 1 nested collection, got %d", got)
+	}
+}
+
+func TestIterCollectionDir(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.otio", "b.otio", "c.otio"} {
+		if err := ToJSONFile(NewTimeline(name, nil, nil), filepath.Join(dir, name), ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var names []string
+	for obj, err := range IterCollectionDir(dir) {
+		if err != nil {
+			t.Fatalf("IterCollectionDir error: %v", err)
+		}
+		names = append(names, obj.(*Timeline).Name())
+		if len(names) == 2 {
+			break
+		}
+	}
+	if len(names) != 2 || names[0] != "a.otio" || names[1] != "b.otio" {
+		t.Errorf("unexpected iteration order %v", names)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "d.otio"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadCollectionDir(dir); err == nil {
+		t.Error("expected error for invalid file")
+	}
+}
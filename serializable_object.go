@@ -9,6 +9,7 @@ import (
 	"os"
 
 	"github.com/Avalanche-io/gotio/internal/jsonenc"
+	"github.com/Avalanche-io/gotio/metrics"
 )
 
 // SerializableObject is the base interface for all serializable types.
@@ -31,9 +32,10 @@ func FromJSONString(jsonStr string) (SerializableObject, error) {
 	return FromJSONBytes([]byte(jsonStr))
 }
 
-// FromJSONBytes parses JSON bytes into a SerializableObject.
+// FromJSONBytes parses JSON bytes into a SerializableObject, with the
+// default decode limits.
 func FromJSONBytes(data []byte) (SerializableObject, error) {
-	return FromJSONBytesSonic(data)
+	return FromJSONBytesWithOptions(data)
 }
 
 // FromJSONFile reads a JSON file into a SerializableObject.
@@ -61,10 +63,41 @@ func ToJSONString(obj SerializableObject, indent string) (string, error) {
 	return string(data), nil
 }
 
-// ToJSONBytes converts a SerializableObject to JSON bytes.
+// ToJSONBytes converts a SerializableObject to JSON bytes. Any object can
+// be written, including a track or clip inside a timeline: the output is a
+// standalone document of obj and what it holds, never its parents, and
+// reads back with FromJSONBytes.
 func ToJSONBytes(obj SerializableObject) ([]byte, error) {
+	reportMetadata(obj)
+	return toJSONBytes(obj)
+}
+
+// toJSONBytes is ToJSONBytes without metadata validation.
+func toJSONBytes(obj SerializableObject) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := jsonenc.NewEncoder(&buf)
+	defer enc.Release()
+
+	if err := jsonenc.EncodeValue(enc, obj); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+
+	metrics.Add(metrics.EncodeBytes, float64(buf.Len()))
+	return buf.Bytes(), nil
+}
+
+// ToJSONBytesCanonical converts a SerializableObject to canonical JSON bytes.
+// Metadata keys are sorted and numbers use a stable format, so equal
+// objects always produce identical output for diffing and caching.
+func ToJSONBytesCanonical(obj SerializableObject) ([]byte, error) {
+	reportMetadata(obj)
 	var buf bytes.Buffer
 	enc := jsonenc.NewEncoder(&buf)
+	enc.SetCanonical(true)
 	defer enc.Release()
 
 	if err := jsonenc.EncodeValue(enc, obj); err != nil {
@@ -75,19 +108,38 @@ func ToJSONBytes(obj SerializableObject) ([]byte, error) {
 		return nil, err
 	}
 
+	metrics.Add(metrics.EncodeBytes, float64(buf.Len()))
 	return buf.Bytes(), nil
 }
 
 // ToJSONWriter writes a SerializableObject to an io.Writer.
 func ToJSONWriter(obj SerializableObject, w io.Writer) error {
-	enc := jsonenc.NewEncoder(w)
+	reportMetadata(obj)
+	cw := &countingWriter{w: w}
+	enc := jsonenc.NewEncoder(cw)
 	defer enc.Release()
 
 	if err := jsonenc.EncodeValue(enc, obj); err != nil {
 		return err
 	}
 
-	return enc.Flush()
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+	metrics.Add(metrics.EncodeBytes, float64(cw.n))
+	return nil
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
 }
 
 // ToJSONBytesIndent converts a SerializableObject to indented JSON bytes.
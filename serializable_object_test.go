@@ -4,11 +4,14 @@
 package gotio
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
+	"github.com/Avalanche-io/gotio/metrics"
 	"github.com/Avalanche-io/gotio/opentime"
 )
 
@@ -673,3 +676,51 @@ func TestToJSONFile(t *testing.T) {
 		t.Error("file should not be empty")
 	}
 }
+
+// metricsRecorder sums counters and counts observations by name.
+type metricsRecorder struct {
+	mu       sync.Mutex
+	counters map[string]float64
+	observed map[string]int
+}
+
+func (r *metricsRecorder) Add(name string, delta float64, labels ...metrics.Label) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counters[name] += delta
+}
+
+func (r *metricsRecorder) Observe(name string, value float64, labels ...metrics.Label) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observed[name]++
+}
+
+func TestSerializationMetrics(t *testing.T) {
+	r := &metricsRecorder{counters: map[string]float64{}, observed: map[string]int{}}
+	metrics.SetRecorder(r)
+	defer metrics.SetRecorder(nil)
+
+	timeline := NewTimeline("metrics", nil, nil)
+	data, err := ToJSONBytes(timeline)
+	if err != nil {
+		t.Fatalf("ToJSONBytes error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := ToJSONWriter(timeline, &buf); err != nil {
+		t.Fatalf("ToJSONWriter error: %v", err)
+	}
+	if _, err := FromJSONBytes(data); err != nil {
+		t.Fatalf("FromJSONBytes error: %v", err)
+	}
+
+	if got, want := r.counters[metrics.EncodeBytes], float64(len(data)+buf.Len()); got != want {
+		t.Errorf("EncodeBytes = %v, want %v", got, want)
+	}
+	if got := r.counters[metrics.DecodeBytes]; got != float64(len(data)) {
+		t.Errorf("DecodeBytes = %v, want %d", got, len(data))
+	}
+	if r.observed[metrics.DecodeSeconds] != 1 {
+		t.Errorf("expected one DecodeSeconds observation, got %d", r.observed[metrics.DecodeSeconds])
+	}
+}
@@ -26,8 +26,10 @@ type SerializableObjectWithMetadataBase struct {
 	metadata AnyDictionary
 }
 
-// NewSerializableObjectWithMetadataBase creates a new base.
+// NewSerializableObjectWithMetadataBase creates a new base, with an
+// identifier in its metadata if SetGenerateIDs is on.
 func NewSerializableObjectWithMetadataBase(name string, metadata AnyDictionary) SerializableObjectWithMetadataBase {
+	metadata = withGeneratedID(metadata)
 	if metadata == nil {
 		metadata = make(AnyDictionary)
 	}
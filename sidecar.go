@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+// StripMedia returns a copy of the timeline with every media reference
+// replaced by a MissingReference that keeps only its available range, so
+// the copy carries structure and timing but no paths, URLs or media
+// metadata. Objects of the timeline are given identifiers first if they
+// lack them, so Reattach can match the copy to it.
+func StripMedia(timeline *Timeline) *Timeline {
+	AssignIDs(timeline)
+	stripped := timeline.Clone().(*Timeline)
+	var clips []*Clip
+	walkMetadataObjects(stripped, func(o SerializableObjectWithMetadata) {
+		if clip, ok := o.(*Clip); ok {
+			clips = append(clips, clip)
+		}
+	})
+	for _, clip := range clips {
+		refs := make(map[string]MediaReference)
+		for key, ref := range clip.MediaReferences() {
+			missing := NewMissingReference("", cloneAvailableRange(ref.AvailableRange()), nil)
+			SetID(missing, ID(ref))
+			refs[key] = missing
+		}
+		clip.SetMediaReferences(refs, clip.ActiveMediaReferenceKey())
+	}
+	return stripped
+}
+
+// StripMetadata returns a copy of the timeline with the metadata of every
+// object removed except the top-level keys in keepNamespaces and the
+// identifier. Objects of the timeline are given identifiers first if they
+// lack them, so Reattach can match the copy to it.
+func StripMetadata(timeline *Timeline, keepNamespaces ...string) *Timeline {
+	AssignIDs(timeline)
+	stripped := timeline.Clone().(*Timeline)
+	keep := append([]string{IDMetadataKey}, keepNamespaces...)
+	walkMetadataObjects(stripped, func(o SerializableObjectWithMetadata) {
+		kept := AnyDictionary{}
+		for _, key := range keep {
+			if v, ok := o.Metadata()[key]; ok {
+				kept[key] = v
+			}
+		}
+		o.SetMetadata(kept)
+	})
+	return stripped
+}
+
+// Reattach returns a copy of stripped, a timeline made by StripMedia or
+// StripMetadata from full and possibly edited since, with what stripping
+// removed restored from the objects of full with the same identifier. The
+// structure and timing of stripped are kept. Metadata is merged deeply,
+// the values of stripped winning; clips whose references are all missing
+// get the media references of their counterpart in full. Objects without
+// a counterpart, such as clips added to the stripped copy, are left as
+// they are.
+func Reattach(full, stripped *Timeline) *Timeline {
+	originals := make(map[string]SerializableObjectWithMetadata)
+	walkMetadataObjects(full, func(o SerializableObjectWithMetadata) {
+		if id := ID(o); id != "" {
+			if _, ok := originals[id]; !ok {
+				originals[id] = o
+			}
+		}
+	})
+
+	result := stripped.Clone().(*Timeline)
+	type match struct {
+		obj, original SerializableObjectWithMetadata
+	}
+	var matches []match
+	walkMetadataObjects(result, func(o SerializableObjectWithMetadata) {
+		if original, ok := originals[ID(o)]; ok {
+			matches = append(matches, match{o, original})
+		}
+	})
+	for _, m := range matches {
+		if merged, err := m.original.Metadata().DeepCopy().Merged(m.obj.Metadata(), MergeDeep); err == nil {
+			m.obj.SetMetadata(merged)
+		}
+		clip, ok := m.obj.(*Clip)
+		original, ok2 := m.original.(*Clip)
+		if ok && ok2 && onlyMissingReferences(clip) {
+			refs := make(map[string]MediaReference)
+			for key, ref := range original.MediaReferences() {
+				refs[key] = ref.Clone().(MediaReference)
+			}
+			clip.SetMediaReferences(refs, original.ActiveMediaReferenceKey())
+		}
+	}
+	return result
+}
+
+// onlyMissingReferences reports whether all media references of the clip
+// are missing references.
+func onlyMissingReferences(clip *Clip) bool {
+	for _, ref := range clip.MediaReferences() {
+		if _, ok := ref.(*MissingReference); !ok {
+			return false
+		}
+	}
+	return true
+}
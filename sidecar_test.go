@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"testing"
+)
+
+func sidecarTimeline() *Timeline {
+	timeline := NewTimeline("show", nil, AnyDictionary{"studio": AnyDictionary{"budget": 1}})
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	ref := NewExternalReference("plate", "/secret/sh010.exr", searchTestRange(0, 100), AnyDictionary{"vendor": "x"})
+	track.AppendChild(NewClip("sh010", ref, searchTestRange(10, 48), AnyDictionary{"studio": AnyDictionary{"cost": 5}, "vfx": AnyDictionary{"shot": "010"}}, nil, nil, "", nil))
+	track.AppendChild(NewClip("sh020", NewExternalReference("", "/secret/sh020.exr", nil, nil), searchTestRange(0, 24), nil, nil, nil, "", nil))
+	timeline.Tracks().AppendChild(track)
+	return timeline
+}
+
+func TestStripAndReattach(t *testing.T) {
+	full := sidecarTimeline()
+	stripped := StripMetadata(StripMedia(full), "vfx")
+
+	clip := stripped.FindClips(nil, false)[0]
+	ref, ok := clip.MediaReference().(*MissingReference)
+	if !ok || ref.Name() != "" || ref.AvailableRange().Duration().Value() != 100 {
+		t.Fatalf("stripped reference = %#v", clip.MediaReference())
+	}
+	if _, ok := clip.MetadataValue("studio"); ok || ID(clip) == "" {
+		t.Errorf("stripped clip metadata = %v", clip.Metadata())
+	}
+	if shot, _ := clip.MetadataValue("vfx.shot"); shot != "010" {
+		t.Errorf("kept namespace vfx lost: %v", clip.Metadata())
+	}
+	if len(stripped.Metadata()) != 1 {
+		t.Errorf("stripped timeline metadata = %v", stripped.Metadata())
+	}
+	if ID(full.FindClips(nil, false)[0]) != ID(clip) {
+		t.Error("the full timeline was not given the stripped copy's identifiers")
+	}
+
+	// The vendor retimes sh010, renumbers its shot, and removes sh020.
+	clip.SetSourceRange(searchTestRange(20, 30))
+	clip.SetMetadataValue("vfx.shot", "011")
+	stripped.Tracks().Children()[0].(*Track).RemoveChild(1)
+
+	merged := Reattach(full, stripped)
+	clips := merged.FindClips(nil, false)
+	if len(clips) != 1 || clips[0].SourceRange().Duration().Value() != 30 {
+		t.Fatalf("merged clips = %v", clips)
+	}
+	ext, ok := clips[0].MediaReference().(*ExternalReference)
+	if !ok || ext.TargetURL() != "/secret/sh010.exr" {
+		t.Errorf("merged reference = %#v", clips[0].MediaReference())
+	}
+	if cost, _ := clips[0].MetadataValue("studio.cost"); cost != 5 {
+		t.Errorf("studio metadata not restored: %v", clips[0].Metadata())
+	}
+	if shot, _ := clips[0].MetadataValue("vfx.shot"); shot != "011" {
+		t.Errorf("vendor edit lost: %v", clips[0].Metadata())
+	}
+	if budget, _ := merged.MetadataValue("studio.budget"); budget != 1 {
+		t.Errorf("timeline metadata = %v", merged.Metadata())
+	}
+}
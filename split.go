@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// Split splits the clip at a time in its media (source) coordinates.
+// It returns two new clips covering the source range before and after the
+// split point. Markers are partitioned by their marked range and effects are
+// duplicated onto both halves. The clip itself is not modified.
+func (c *Clip) Split(atMediaTime opentime.RationalTime) (*Clip, *Clip, error) {
+	first, second, err := splitItem(c, atMediaTime)
+	if err != nil {
+		return nil, nil, err
+	}
+	return first.(*Clip), second.(*Clip), nil
+}
+
+// SplitAt splits item at a time in its parent's coordinates. It returns
+// two new items as described for Clip.Split. The item itself is not
+// modified and stays in its parent.
+func SplitAt(item Item, parentTime opentime.RationalTime) (Item, Item, error) {
+	if item == nil {
+		return nil, nil, ErrNotAChild
+	}
+	parent := item.Parent()
+	if parent == nil {
+		return nil, nil, ErrNotAChild
+	}
+
+	rangeInParent, err := parent.RangeOfChild(item)
+	if err != nil {
+		return nil, nil, err
+	}
+	trimmedRange, err := item.TrimmedRange()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	offset := parentTime.Sub(rangeInParent.StartTime())
+	return splitItem(item, trimmedRange.StartTime().Add(offset.RescaledTo(trimmedRange.StartTime().Rate())))
+}
+
+// splitItem splits an item at a time in its internal coordinates.
+func splitItem(item Item, at opentime.RationalTime) (Item, Item, error) {
+	trimmedRange, err := item.TrimmedRange()
+	if err != nil {
+		return nil, nil, err
+	}
+	if trimmedRange.Duration().Value() < 0 {
+		return nil, nil, ErrInvalidTimeRange
+	}
+	start := trimmedRange.StartTime()
+	end := trimmedRange.EndTimeExclusive()
+	if at.Cmp(start) <= 0 || at.Cmp(end) >= 0 {
+		return nil, nil, ErrSplitOutOfRange
+	}
+
+	first := item.Clone().(Item)
+	firstRange := opentime.RangeFromStartEndTime(start, at)
+	first.SetSourceRange(&firstRange)
+
+	second := item.Clone().(Item)
+	secondRange := opentime.RangeFromStartEndTime(at, end)
+	second.SetSourceRange(&secondRange)
+
+	first.SetMarkers(markersInRange(item.Markers(), firstRange))
+	second.SetMarkers(markersInRange(item.Markers(), secondRange))
+
+	return first, second, nil
+}
+
+// markersInRange returns clones of the markers that intersect the range,
+// with their marked ranges clipped to it.
+func markersInRange(markers []*Marker, r opentime.TimeRange) []*Marker {
+	var result []*Marker
+	for _, m := range markers {
+		marked := m.MarkedRange()
+		start := marked.StartTime()
+		end := marked.EndTimeExclusive()
+
+		// Zero-length markers belong to the range containing their start
+		if marked.Duration().Value() == 0 {
+			if start.Cmp(r.StartTime()) >= 0 && start.Cmp(r.EndTimeExclusive()) < 0 {
+				result = append(result, m.Clone().(*Marker))
+			}
+			continue
+		}
+		if end.Cmp(r.StartTime()) <= 0 || start.Cmp(r.EndTimeExclusive()) >= 0 {
+			continue
+		}
+
+		clone := m.Clone().(*Marker)
+		clone.SetMarkedRange(opentime.RangeFromStartEndTime(
+			maxTime(start, r.StartTime()),
+			minTime(end, r.EndTimeExclusive()),
+		))
+		result = append(result, clone)
+	}
+	return result
+}
+
+// maxTime returns the later of two times.
+func maxTime(a, b opentime.RationalTime) opentime.RationalTime {
+	if a.Cmp(b) > 0 {
+		return a
+	}
+	return b
+}
+
+// minTime returns the earlier of two times.
+func minTime(a, b opentime.RationalTime) opentime.RationalTime {
+	if a.Cmp(b) < 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func newSplitTestClip() *Clip {
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(100, 24), opentime.NewRationalTime(48, 24))
+	markers := []*Marker{
+		NewMarker("early", opentime.NewTimeRange(opentime.NewRationalTime(104, 24), opentime.NewRationalTime(4, 24)), MarkerColorRed, "", nil),
+		NewMarker("spanning", opentime.NewTimeRange(opentime.NewRationalTime(120, 24), opentime.NewRationalTime(8, 24)), MarkerColorGreen, "", nil),
+		NewMarker("late", opentime.NewTimeRange(opentime.NewRationalTime(130, 24), opentime.NewRationalTime(0, 24)), MarkerColorBlue, "", nil),
+	}
+	effects := []Effect{NewEffect("blur", "Blur", nil)}
+	return NewClip("shot", nil, &sr, nil, effects, markers, "", nil)
+}
+
+func TestClipSplit(t *testing.T) {
+	clip := newSplitTestClip()
+
+	first, second, err := clip.Split(opentime.NewRationalTime(124, 24))
+	if err != nil {
+		t.Fatalf("Split error: %v", err)
+	}
+
+	if got := *first.SourceRange(); got.StartTime().Value() != 100 || got.Duration().Value() != 24 {
+		t.Errorf("first source range = %v, want 100+24", got)
+	}
+	if got := *second.SourceRange(); got.StartTime().Value() != 124 || got.Duration().Value() != 24 {
+		t.Errorf("second source range = %v, want 124+24", got)
+	}
+
+	if len(first.Markers()) != 2 || first.Markers()[0].Name() != "early" || first.Markers()[1].Name() != "spanning" {
+		t.Fatalf("unexpected first markers: %d", len(first.Markers()))
+	}
+	if d := first.Markers()[1].MarkedRange().Duration().Value(); d != 4 {
+		t.Errorf("spanning marker in first half duration = %v, want 4", d)
+	}
+	if len(second.Markers()) != 2 || second.Markers()[0].Name() != "spanning" || second.Markers()[1].Name() != "late" {
+		t.Fatalf("unexpected second markers: %d", len(second.Markers()))
+	}
+	if s := second.Markers()[0].MarkedRange().StartTime().Value(); s != 124 {
+		t.Errorf("spanning marker in second half start = %v, want 124", s)
+	}
+
+	if len(first.Effects()) != 1 || len(second.Effects()) != 1 {
+		t.Fatal("expected effects on both halves")
+	}
+	if first.Effects()[0] == second.Effects()[0] {
+		t.Error("expected effects to be duplicated, not shared")
+	}
+
+	// The original clip is unchanged
+	if clip.SourceRange().Duration().Value() != 48 || len(clip.Markers()) != 3 {
+		t.Error("expected original clip to be unchanged")
+	}
+}
+
+func TestClipSplitOutOfRange(t *testing.T) {
+	clip := newSplitTestClip()
+
+	for _, at := range []float64{100, 148, 50, 200} {
+		_, _, err := clip.Split(opentime.NewRationalTime(at, 24))
+		if !errors.Is(err, ErrSplitOutOfRange) {
+			t.Errorf("Split(%v) error = %v, want ErrSplitOutOfRange", at, err)
+		}
+	}
+}
+
+func TestSplitAt(t *testing.T) {
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	track.AppendChild(NewGapWithDuration(opentime.NewRationalTime(24, 24)))
+	clip := newSplitTestClip()
+	track.AppendChild(clip)
+
+	// Track time 36 is 12 frames into the clip, i.e. media frame 112
+	first, second, err := SplitAt(clip, opentime.NewRationalTime(36, 24))
+	if err != nil {
+		t.Fatalf("SplitAt error: %v", err)
+	}
+	if first.SourceRange().Duration().Value() != 12 {
+		t.Errorf("first duration = %v, want 12", first.SourceRange().Duration().Value())
+	}
+	if second.SourceRange().StartTime().Value() != 112 {
+		t.Errorf("second start = %v, want 112", second.SourceRange().StartTime().Value())
+	}
+	if _, ok := first.(*Clip); !ok {
+		t.Errorf("expected *Clip, got %T", first)
+	}
+
+	orphan := newSplitTestClip()
+	if _, _, err := SplitAt(orphan, opentime.NewRationalTime(10, 24)); !errors.Is(err, ErrNotAChild) {
+		t.Errorf("SplitAt without parent error = %v, want ErrNotAChild", err)
+	}
+}
@@ -43,8 +43,13 @@ func (s *Stack) InsertChild(index int, child Composable) error {
 	if index < 0 || index > len(s.children) {
 		return &IndexError{Index: index, Size: len(s.children)}
 	}
+	child, err := s.adoptChild(child)
+	if err != nil {
+		return err
+	}
 	child.SetParent(s)
 	s.children = append(s.children[:index], append([]Composable{child}, s.children[index:]...)...)
+	s.touch()
 	return nil
 }
 
@@ -55,13 +60,29 @@ func (s *Stack) AppendChild(child Composable) error {
 
 // SetChild sets the child at the given index.
 func (s *Stack) SetChild(index int, child Composable) error {
+	_, err := s.ReplaceChild(index, child)
+	return err
+}
+
+// ReplaceChild replaces the child at the given index and returns the
+// detached previous child.
+func (s *Stack) ReplaceChild(index int, child Composable) (Composable, error) {
 	if index < 0 || index >= len(s.children) {
-		return &IndexError{Index: index, Size: len(s.children)}
+		return nil, &IndexError{Index: index, Size: len(s.children)}
 	}
-	s.children[index].SetParent(nil)
+	old := s.children[index]
+	if old == child {
+		return old, nil
+	}
+	child, err := s.adoptChild(child)
+	if err != nil {
+		return nil, err
+	}
+	old.SetParent(nil)
 	child.SetParent(s)
 	s.children[index] = child
-	return nil
+	s.touch()
+	return old, nil
 }
 
 // RemoveChild removes the child at the given index.
@@ -71,6 +92,7 @@ func (s *Stack) RemoveChild(index int) error {
 	}
 	s.children[index].SetParent(nil)
 	s.children = append(s.children[:index], s.children[index+1:]...)
+	s.touch()
 	return nil
 }
 
@@ -309,6 +309,42 @@ func TestStackJSON(t *testing.T) {
 	}
 }
 
+func TestNestedStackJSON(t *testing.T) {
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(24, 24))
+	inner := NewStack("inner", nil, nil, nil, nil, nil)
+	inner.AppendChild(NewClip("layer", nil, &sr, nil, nil, nil, "", nil))
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	track.AppendChild(inner)
+	marker := NewMarker("note", sr, MarkerColorRed, "", nil)
+	track.AppendChild(NewGap("", &sr, nil, nil, []*Marker{marker}, nil))
+	outer := NewStack("outer", nil, nil, nil, nil, nil)
+	outer.AppendChild(track)
+	outer.AppendChild(NewClip("direct", nil, &sr, nil, nil, nil, "", nil))
+
+	data, err := ToJSONBytes(outer)
+	if err != nil {
+		t.Fatalf("ToJSONBytes error: %v", err)
+	}
+	obj, err := FromJSONBytes(data)
+	if err != nil {
+		t.Fatalf("FromJSONBytes error: %v", err)
+	}
+	decoded := obj.(*Stack)
+	if !decoded.IsEquivalentTo(outer) {
+		t.Fatal("decoded stack is not equivalent")
+	}
+	if len(decoded.Children()) != 2 {
+		t.Fatalf("expected a track and a clip in the stack, got %d children", len(decoded.Children()))
+	}
+	children := decoded.Children()[0].(*Track).Children()
+	if _, ok := children[0].(*Stack); !ok {
+		t.Errorf("expected a nested stack in the track, got %T", children[0])
+	}
+	if markers := children[1].(*Gap).Markers(); len(markers) != 1 {
+		t.Errorf("expected the gap marker to be decoded, got %d markers", len(markers))
+	}
+}
+
 func TestStackAvailableImageBounds(t *testing.T) {
 	stack := NewStack("test", nil, nil, nil, nil, nil)
 
@@ -0,0 +1,225 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package stats summarizes a timeline for reports: how many objects of each
+// schema it holds, its durations, the range of clip durations, the rates
+// clips use, and how media references, markers and effects are used.
+//
+// Basic usage:
+//
+//	s := stats.Collect(timeline)
+//	fmt.Println(s.Schemas["Clip"], s.Duration.ToSeconds())
+//
+//	err := stats.WriteJSON(os.Stdout, timeline)
+//
+// WithRecordTimecode adds the record timecodes of the timeline and its
+// tracks, counted from the timeline's global start time as in broadcast
+// deliverables.
+package stats
+
+import (
+	"cmp"
+	"encoding/json"
+	"io"
+	"slices"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func init() {
+	gotio.RegisterFeature("stats")
+}
+
+// Stats summarizes a timeline.
+type Stats struct {
+	Name string `json:"name"`
+	// Duration is the duration of the timeline, zero if it cannot be
+	// computed.
+	Duration opentime.RationalTime `json:"duration"`
+	// Record is the record in and out timecode of the timeline, set with
+	// WithRecordTimecode.
+	Record *gotio.TimecodeRange `json:"record,omitempty"`
+	// Tracks summarizes the top-level tracks, in order.
+	Tracks []TrackStats `json:"tracks"`
+	// Schemas counts the compositions, items and transitions by schema
+	// name, such as "Clip" or "Gap". The timeline itself is not counted.
+	Schemas map[string]int `json:"schemas"`
+	// MinClipDuration and MaxClipDuration bound the durations of the clips,
+	// compared in seconds. They are nil without clips of known duration.
+	MinClipDuration *opentime.RationalTime `json:"min_clip_duration,omitempty"`
+	MaxClipDuration *opentime.RationalTime `json:"max_clip_duration,omitempty"`
+	// UnknownDurations counts the clips whose duration cannot be computed,
+	// such as those with neither a source range nor an available range.
+	UnknownDurations int `json:"unknown_durations,omitempty"`
+	// Rates counts the clips at each rate, ordered by rate.
+	Rates []RateCount `json:"rates"`
+	// MediaReferences counts the active media references of the clips by
+	// schema name, such as "ExternalReference" or "MissingReference".
+	MediaReferences map[string]int `json:"media_references"`
+	// Markers counts the markers on the timeline and its items by color.
+	Markers map[gotio.MarkerColor]int `json:"markers"`
+	// Effects counts the effects on items by effect name, or by schema name
+	// for effects without one.
+	Effects map[string]int `json:"effects"`
+}
+
+// TrackStats summarizes a top-level track.
+type TrackStats struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	// Duration is the duration of the track, zero if it cannot be computed.
+	Duration opentime.RationalTime `json:"duration"`
+	// Record is the record in and out timecode of the track, set with
+	// WithRecordTimecode.
+	Record      *gotio.TimecodeRange `json:"record,omitempty"`
+	Clips       int                  `json:"clips"`
+	Gaps        int                  `json:"gaps"`
+	Transitions int                  `json:"transitions"`
+	// Enabled is false for a disabled track.
+	Enabled bool `json:"enabled"`
+}
+
+// RateCount is the number of clips at a rate.
+type RateCount struct {
+	Rate  float64 `json:"rate"`
+	Count int     `json:"count"`
+}
+
+// Config holds options for Collect.
+type Config struct {
+	// RecordTimecode adds record timecodes, offset by the timeline's
+	// global start time.
+	RecordTimecode bool
+	// Rate is the timecode rate; zero uses the rate of each range.
+	Rate float64
+	// DropFrame selects drop-frame timecode.
+	DropFrame opentime.IsDropFrameRate
+}
+
+// Option is a functional option for Collect.
+type Option func(*Config)
+
+// WithRecordTimecode adds the record timecodes of the timeline and its
+// tracks at rate, zero for the rate of each range.
+func WithRecordTimecode(rate float64, drop opentime.IsDropFrameRate) Option {
+	return func(c *Config) {
+		c.RecordTimecode = true
+		c.Rate = rate
+		c.DropFrame = drop
+	}
+}
+
+// Collect summarizes the timeline. Durations and timecodes that cannot be
+// computed are left out rather than failing the whole summary.
+func Collect(timeline *gotio.Timeline, opts ...Option) *Stats {
+	var cfg Config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	s := &Stats{
+		Name:            timeline.Name(),
+		Schemas:         make(map[string]int),
+		MediaReferences: make(map[string]int),
+		Markers:         make(map[gotio.MarkerColor]int),
+		Effects:         make(map[string]int),
+	}
+	if d, err := timeline.Duration(); err == nil {
+		s.Duration = d
+	}
+	if cfg.RecordTimecode {
+		if tc, err := timeline.RecordTimecode(cfg.Rate, cfg.DropFrame); err == nil {
+			s.Record = &tc
+		}
+	}
+
+	stack := timeline.Tracks()
+	if stack == nil {
+		return s
+	}
+	rates := make(map[float64]int)
+	s.collect(stack, rates)
+
+	for _, child := range stack.Children() {
+		track, ok := child.(*gotio.Track)
+		if !ok {
+			continue
+		}
+		ts := TrackStats{Name: track.Name(), Kind: track.Kind(), Enabled: track.Enabled()}
+		if d, err := track.Duration(); err == nil {
+			ts.Duration = d
+		}
+		if cfg.RecordTimecode {
+			if tc, err := timeline.RecordTimecodeRange(track, cfg.Rate, cfg.DropFrame); err == nil {
+				ts.Record = &tc
+			}
+		}
+		for _, c := range track.Children() {
+			switch c.(type) {
+			case *gotio.Clip:
+				ts.Clips++
+			case *gotio.Gap:
+				ts.Gaps++
+			case *gotio.Transition:
+				ts.Transitions++
+			}
+		}
+		s.Tracks = append(s.Tracks, ts)
+	}
+
+	for rate, count := range rates {
+		s.Rates = append(s.Rates, RateCount{Rate: rate, Count: count})
+	}
+	slices.SortFunc(s.Rates, func(a, b RateCount) int {
+		return cmp.Compare(a.Rate, b.Rate)
+	})
+	return s
+}
+
+// collect counts c and everything below it.
+func (s *Stats) collect(c gotio.Composable, rates map[float64]int) {
+	s.Schemas[c.SchemaName()]++
+
+	if item, ok := c.(gotio.Item); ok {
+		for _, marker := range item.Markers() {
+			s.Markers[marker.Color()]++
+		}
+		for _, effect := range item.Effects() {
+			name := effect.EffectName()
+			if name == "" {
+				name = effect.SchemaName()
+			}
+			s.Effects[name]++
+		}
+	}
+
+	switch c := c.(type) {
+	case *gotio.Clip:
+		if ref := c.MediaReference(); ref != nil {
+			s.MediaReferences[ref.SchemaName()]++
+		}
+		d, err := c.Duration()
+		if err != nil {
+			s.UnknownDurations++
+			return
+		}
+		rates[d.Rate()]++
+		if s.MinClipDuration == nil || d.ToSeconds() < s.MinClipDuration.ToSeconds() {
+			s.MinClipDuration = &d
+		}
+		if s.MaxClipDuration == nil || d.ToSeconds() > s.MaxClipDuration.ToSeconds() {
+			s.MaxClipDuration = &d
+		}
+	case gotio.Composition:
+		for _, child := range c.Children() {
+			s.collect(child, rates)
+		}
+	}
+}
+
+// WriteJSON writes the summary of the timeline as indented JSON.
+func WriteJSON(w io.Writer, timeline *gotio.Timeline, opts ...Option) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(Collect(timeline, opts...))
+}
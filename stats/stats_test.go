@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+func frames(duration, rate float64) *opentime.TimeRange {
+	r := opentime.NewTimeRange(opentime.NewRationalTime(0, rate), opentime.NewRationalTime(duration, rate))
+	return &r
+}
+
+// testTimeline builds
+//
+//	V1: a[24@24] transition gap[12@24] b[48@24]
+//	A1: music[30@30] (disabled)
+//
+// with a red marker on a, a green marker on the stack, a time warp on b,
+// and a nested stack on A1 holding one clip without a duration.
+func testTimeline() *gotio.Timeline {
+	timeline := gotio.NewTimeline("cut", nil, nil)
+
+	red := gotio.NewMarker("fix", *frames(1, 24), gotio.MarkerColorRed, "", nil)
+	v1 := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	v1.AppendChild(gotio.NewClip("a", gotio.NewExternalReference("", "/media/a.mov", nil, nil), frames(24, 24), nil, nil, []*gotio.Marker{red}, "", nil))
+	v1.AppendChild(gotio.NewCrossDissolve(opentime.NewRationalTime(4, 24)))
+	v1.AppendChild(gotio.NewGap("", frames(12, 24), nil, nil, nil, nil))
+	warp := gotio.NewLinearTimeWarp("", "", 2, nil)
+	v1.AppendChild(gotio.NewClip("b", nil, frames(48, 24), nil, []gotio.Effect{warp}, nil, "", nil))
+
+	a1 := gotio.NewTrack("A1", nil, gotio.TrackKindAudio, nil, nil)
+	a1.AppendChild(gotio.NewClip("music", gotio.NewExternalReference("", "/media/m.wav", nil, nil), frames(30, 30), nil, nil, nil, "", nil))
+	nested := gotio.NewStack("nested", nil, nil, nil, nil, nil)
+	nested.AppendChild(gotio.NewClip("unknown", nil, nil, nil, nil, nil, "", nil))
+	a1.AppendChild(nested)
+	a1.SetEnabled(false)
+
+	timeline.Tracks().AppendChild(v1)
+	timeline.Tracks().AppendChild(a1)
+	timeline.Tracks().SetMarkers([]*gotio.Marker{gotio.NewMarker("chapter", *frames(1, 24), "", "", nil)})
+	return timeline
+}
+
+func TestCollect(t *testing.T) {
+	s := Collect(testTimeline())
+
+	if s.Name != "cut" {
+		t.Errorf("Name = %q, want cut", s.Name)
+	}
+	wantSchemas := map[string]int{"Stack": 2, "Track": 2, "Clip": 4, "Gap": 1, "Transition": 1}
+	for name, want := range wantSchemas {
+		if s.Schemas[name] != want {
+			t.Errorf("Schemas[%s] = %d, want %d", name, s.Schemas[name], want)
+		}
+	}
+	if len(s.Schemas) != len(wantSchemas) {
+		t.Errorf("unexpected schemas %v", s.Schemas)
+	}
+
+	if len(s.Tracks) != 2 {
+		t.Fatalf("expected 2 tracks, got %d", len(s.Tracks))
+	}
+	v1 := s.Tracks[0]
+	if v1.Name != "V1" || v1.Clips != 2 || v1.Gaps != 1 || v1.Transitions != 1 || !v1.Enabled {
+		t.Errorf("unexpected V1 stats %+v", v1)
+	}
+	if v1.Duration.ToFrames() != 84 {
+		t.Errorf("V1 duration = %v, want 84 frames", v1.Duration)
+	}
+	if a1 := s.Tracks[1]; a1.Kind != gotio.TrackKindAudio || a1.Enabled {
+		t.Errorf("unexpected A1 stats %+v", a1)
+	}
+
+	if s.MinClipDuration == nil || s.MinClipDuration.ToSeconds() != 1 {
+		t.Errorf("MinClipDuration = %v, want 1s", s.MinClipDuration)
+	}
+	if s.MaxClipDuration == nil || s.MaxClipDuration.ToSeconds() != 2 {
+		t.Errorf("MaxClipDuration = %v, want 2s", s.MaxClipDuration)
+	}
+	if s.UnknownDurations != 1 {
+		t.Errorf("UnknownDurations = %d, want 1", s.UnknownDurations)
+	}
+	wantRates := []RateCount{{Rate: 24, Count: 2}, {Rate: 30, Count: 1}}
+	if len(s.Rates) != len(wantRates) || s.Rates[0] != wantRates[0] || s.Rates[1] != wantRates[1] {
+		t.Errorf("Rates = %v, want %v", s.Rates, wantRates)
+	}
+
+	if s.MediaReferences["ExternalReference"] != 2 || s.MediaReferences["MissingReference"] != 2 {
+		t.Errorf("unexpected media references %v", s.MediaReferences)
+	}
+	if s.Markers[gotio.MarkerColorRed] != 1 || s.Markers[gotio.MarkerColorGreen] != 1 {
+		t.Errorf("unexpected markers %v", s.Markers)
+	}
+	if len(s.Effects) != 1 || s.Effects["LinearTimeWarp"] != 1 {
+		t.Errorf("unexpected effects %v", s.Effects)
+	}
+}
+
+func TestCollectEmpty(t *testing.T) {
+	s := Collect(gotio.NewTimeline("empty", nil, nil))
+	if len(s.Tracks) != 0 || s.MinClipDuration != nil || len(s.Rates) != 0 {
+		t.Errorf("unexpected stats for an empty timeline %+v", s)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, testTimeline()); err != nil {
+		t.Fatalf("WriteJSON error: %v", err)
+	}
+	var decoded struct {
+		Name    string         `json:"name"`
+		Schemas map[string]int `json:"schemas"`
+		Markers map[string]int `json:"markers"`
+		Rates   []RateCount    `json:"rates"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if decoded.Name != "cut" || decoded.Schemas["Clip"] != 4 || decoded.Markers["RED"] != 1 || len(decoded.Rates) != 2 {
+		t.Errorf("unexpected JSON %s", buf.String())
+	}
+}
+
+func TestCollectRecordTimecode(t *testing.T) {
+	timeline := testTimeline()
+	if s := Collect(timeline); s.Record != nil || s.Tracks[0].Record != nil {
+		t.Errorf("record timecode without WithRecordTimecode")
+	}
+	// A1 holds a clip without a duration, so the timeline has none either.
+	if err := timeline.Tracks().RemoveChild(1); err != nil {
+		t.Fatal(err)
+	}
+	hour := opentime.NewRationalTime(86400, 24)
+	timeline.SetGlobalStartTime(&hour)
+	s := Collect(timeline, WithRecordTimecode(24, opentime.ForceNo))
+	if s.Record == nil || s.Record.String() != "01:00:00:00 - 01:00:03:12" {
+		t.Errorf("Record = %v, want 01:00:00:00 - 01:00:03:12", s.Record)
+	}
+	if r := s.Tracks[0].Record; r == nil || r.String() != "01:00:00:00 - 01:00:03:12" {
+		t.Errorf("V1 Record = %v", r)
+	}
+}
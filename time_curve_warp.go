@@ -0,0 +1,262 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// TimeCurveWarpSchema is the schema for TimeCurveWarp.
+var TimeCurveWarpSchema = Schema{Name: "TimeCurveWarp", Version: 1}
+
+// TimeInterpolation is how a TimeCurveWarp moves from one keyframe to the
+// next.
+type TimeInterpolation string
+
+const (
+	// TimeInterpolationLinear plays at constant speed between keyframes.
+	TimeInterpolationLinear TimeInterpolation = "linear"
+	// TimeInterpolationHold holds the keyframe's output until the next.
+	TimeInterpolationHold TimeInterpolation = "hold"
+	// TimeInterpolationEase ramps the speed up from and down to zero at
+	// the keyframes, as NLE speed ramps do.
+	TimeInterpolationEase TimeInterpolation = "ease"
+)
+
+// TimeKeyframe maps a time of the item to a time of its media. Both are
+// offsets from the start of the item's source range.
+type TimeKeyframe struct {
+	Input  opentime.RationalTime
+	Output opentime.RationalTime
+	// Interpolation applies from this keyframe to the next.
+	Interpolation TimeInterpolation
+}
+
+// TimeCurveWarp is a time effect mapping item time to media time through
+// keyframes, for speed ramps and other non-linear retimes.
+type TimeCurveWarp struct {
+	EffectBase
+	keyframes []TimeKeyframe
+}
+
+// NewTimeCurveWarp creates a new TimeCurveWarp. The keyframes are sorted
+// by input time.
+func NewTimeCurveWarp(name string, keyframes []TimeKeyframe, metadata AnyDictionary) *TimeCurveWarp {
+	w := &TimeCurveWarp{EffectBase: NewEffectBase(name, TimeCurveWarpSchema.Name, metadata)}
+	w.SetKeyframes(keyframes)
+	return w
+}
+
+// Keyframes returns the keyframes in input order.
+func (w *TimeCurveWarp) Keyframes() []TimeKeyframe {
+	return w.keyframes
+}
+
+// SetKeyframes sets the keyframes, sorting them by input time.
+func (w *TimeCurveWarp) SetKeyframes(keyframes []TimeKeyframe) {
+	w.keyframes = slices.Clone(keyframes)
+	slices.SortStableFunc(w.keyframes, func(a, b TimeKeyframe) int {
+		return a.Input.Cmp(b.Input)
+	})
+}
+
+// Validate returns an error if the keyframes cannot be evaluated: there
+// are none, two share an input time, or one has an unknown interpolation.
+func (w *TimeCurveWarp) Validate() error {
+	if len(w.keyframes) == 0 {
+		return fmt.Errorf("time curve warp %q has no keyframes", w.name)
+	}
+	for i, k := range w.keyframes {
+		switch k.Interpolation {
+		case "", TimeInterpolationLinear, TimeInterpolationHold, TimeInterpolationEase:
+		default:
+			return fmt.Errorf("time curve warp %q keyframe %d: unknown interpolation %q", w.name, i, k.Interpolation)
+		}
+		if i > 0 && k.Input.ToSeconds()-w.keyframes[i-1].Input.ToSeconds() <= opentime.DefaultEpsilon {
+			return fmt.Errorf("time curve warp %q keyframes %d and %d share an input time", w.name, i-1, i)
+		}
+	}
+	return nil
+}
+
+// MapTime returns the media offset shown at the item offset t, at the
+// rate of t. Before the first and after the last keyframe, time moves at
+// the average speed of the nearest segment, or at normal speed if there is
+// only one keyframe. An empty curve returns t.
+func (w *TimeCurveWarp) MapTime(t opentime.RationalTime) opentime.RationalTime {
+	seconds := w.mapSeconds(t.ToSeconds())
+	return opentime.FromSeconds(seconds, t.Rate())
+}
+
+// MapRange returns the media range the item range tr shows: from the
+// earliest to the latest media time over tr, at the rate of tr's start.
+func (w *TimeCurveWarp) MapRange(tr opentime.TimeRange) opentime.TimeRange {
+	start, end := tr.StartTime().ToSeconds(), tr.EndTimeExclusive().ToSeconds()
+	low, high := w.mapSeconds(start), w.mapSeconds(end)
+	if low > high {
+		low, high = high, low
+	}
+	// Extremes inside the range are at keyframes.
+	for _, k := range w.keyframes {
+		if s := k.Input.ToSeconds(); s > start && s < end {
+			out := k.Output.ToSeconds()
+			low, high = math.Min(low, out), math.Max(high, out)
+		}
+	}
+	rate := tr.StartTime().Rate()
+	return opentime.NewTimeRange(opentime.FromSeconds(low, rate), opentime.FromSeconds(high-low, rate))
+}
+
+// Speed returns the playback speed from the item offset t onwards: 1 is
+// normal speed, 0 a hold, and negative values play backwards.
+func (w *TimeCurveWarp) Speed(t opentime.RationalTime) float64 {
+	const h = 1e-6
+	s := t.ToSeconds()
+	return (w.mapSeconds(s+h) - w.mapSeconds(s)) / h
+}
+
+// mapSeconds maps an item offset to a media offset, in seconds.
+func (w *TimeCurveWarp) mapSeconds(s float64) float64 {
+	keys := w.keyframes
+	switch len(keys) {
+	case 0:
+		return s
+	case 1:
+		return keys[0].Output.ToSeconds() + s - keys[0].Input.ToSeconds()
+	}
+	last := len(keys) - 1
+	if s <= keys[0].Input.ToSeconds() {
+		return extrapolate(keys[0], keys[1], s)
+	}
+	if s >= keys[last].Input.ToSeconds() {
+		return extrapolate(keys[last], keys[last-1], s)
+	}
+
+	i, _ := slices.BinarySearchFunc(keys, s, func(k TimeKeyframe, s float64) int {
+		return cmpFloat(k.Input.ToSeconds(), s)
+	})
+	if i > 0 && keys[i].Input.ToSeconds() > s {
+		i--
+	}
+	from, to := keys[i], keys[i+1]
+	in0, in1 := from.Input.ToSeconds(), to.Input.ToSeconds()
+	out0, out1 := from.Output.ToSeconds(), to.Output.ToSeconds()
+	u := (s - in0) / (in1 - in0)
+	switch from.Interpolation {
+	case TimeInterpolationHold:
+		return out0
+	case TimeInterpolationEase:
+		u = u * u * (3 - 2*u)
+	}
+	return out0 + (out1-out0)*u
+}
+
+// extrapolate continues the segment between key and other past key at
+// its average speed.
+func extrapolate(key, other TimeKeyframe, s float64) float64 {
+	in, out := key.Input.ToSeconds(), key.Output.ToSeconds()
+	speed := (other.Output.ToSeconds() - out) / (other.Input.ToSeconds() - in)
+	return out + (s-in)*speed
+}
+
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// SchemaName returns the schema name.
+func (w *TimeCurveWarp) SchemaName() string {
+	return TimeCurveWarpSchema.Name
+}
+
+// SchemaVersion returns the schema version.
+func (w *TimeCurveWarp) SchemaVersion() int {
+	return TimeCurveWarpSchema.Version
+}
+
+// Clone creates a deep copy.
+func (w *TimeCurveWarp) Clone() SerializableObject {
+	clone := *w
+	clone.metadata = CloneAnyDictionary(w.metadata)
+	clone.keyframes = slices.Clone(w.keyframes)
+	return &clone
+}
+
+// IsEquivalentTo returns true if equivalent.
+func (w *TimeCurveWarp) IsEquivalentTo(other SerializableObject) bool {
+	o, ok := other.(*TimeCurveWarp)
+	if !ok {
+		return false
+	}
+	return w.name == o.name && w.effectName == o.effectName && slices.EqualFunc(w.keyframes, o.keyframes, func(a, b TimeKeyframe) bool {
+		return a.Input.StrictlyEqual(b.Input) && a.Output.StrictlyEqual(b.Output) && a.Interpolation == b.Interpolation
+	})
+}
+
+// timeKeyframeJSON is the JSON representation of a keyframe.
+type timeKeyframeJSON struct {
+	Input         opentime.RationalTime `json:"input"`
+	Output        opentime.RationalTime `json:"output"`
+	Interpolation TimeInterpolation     `json:"interpolation"`
+}
+
+// timeCurveWarpJSON is the JSON representation.
+type timeCurveWarpJSON struct {
+	Schema     string             `json:"OTIO_SCHEMA"`
+	Name       string             `json:"name"`
+	Metadata   AnyDictionary      `json:"metadata"`
+	EffectName string             `json:"effect_name"`
+	Keyframes  []timeKeyframeJSON `json:"keyframes"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (w *TimeCurveWarp) MarshalJSON() ([]byte, error) {
+	keyframes := make([]timeKeyframeJSON, len(w.keyframes))
+	for i, k := range w.keyframes {
+		keyframes[i] = timeKeyframeJSON(k)
+	}
+	return json.Marshal(&timeCurveWarpJSON{
+		Schema:     TimeCurveWarpSchema.String(),
+		Name:       w.name,
+		Metadata:   w.metadata,
+		EffectName: w.effectName,
+		Keyframes:  keyframes,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (w *TimeCurveWarp) UnmarshalJSON(data []byte) error {
+	var j timeCurveWarpJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	w.name = j.Name
+	w.metadata = j.Metadata
+	if w.metadata == nil {
+		w.metadata = make(AnyDictionary)
+	}
+	w.effectName = j.EffectName
+	keyframes := make([]TimeKeyframe, len(j.Keyframes))
+	for i, k := range j.Keyframes {
+		keyframes[i] = TimeKeyframe(k)
+	}
+	w.SetKeyframes(keyframes)
+	return nil
+}
+
+func init() {
+	RegisterSchema(TimeCurveWarpSchema, func() SerializableObject {
+		return NewTimeCurveWarp("", nil, nil)
+	})
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func rampKeyframes() []TimeKeyframe {
+	rt := func(v float64) opentime.RationalTime { return opentime.NewRationalTime(v, 24) }
+	// Out of order on purpose: normal speed for 24 frames, a 2x ramp
+	// eased over the next 24, then a hold.
+	return []TimeKeyframe{
+		{Input: rt(24), Output: rt(24), Interpolation: TimeInterpolationEase},
+		{Input: rt(0), Output: rt(0), Interpolation: TimeInterpolationLinear},
+		{Input: rt(48), Output: rt(72), Interpolation: TimeInterpolationHold},
+		{Input: rt(60), Output: rt(80)},
+	}
+}
+
+func TestTimeCurveWarpMapTime(t *testing.T) {
+	warp := NewTimeCurveWarp("ramp", rampKeyframes(), nil)
+	if err := warp.Validate(); err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	if got := warp.Keyframes()[0].Input.Value(); got != 0 {
+		t.Fatalf("keyframes not sorted, first input = %v", got)
+	}
+
+	tests := []struct {
+		input, want float64
+	}{
+		{-12, -12}, // before the first keyframe, at the first segment's speed
+		{12, 12},
+		{24, 24},
+		{36, 48}, // ease midpoint
+		{48, 72},
+		{54, 72}, // hold
+		{72, 88}, // after the last keyframe, at (80-72)/(60-48)
+	}
+	for _, tt := range tests {
+		got := warp.MapTime(opentime.NewRationalTime(tt.input, 24))
+		if math.Abs(got.Value()-tt.want) > 1e-9 || got.Rate() != 24 {
+			t.Errorf("MapTime(%v) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	speeds := []struct {
+		input, want float64
+	}{
+		{12, 1},
+		{24, 0}, // eases out of the keyframe
+		{36, 3}, // smoothstep peaks at 1.5 times the average speed of 2
+		{54, 0},
+	}
+	for _, tt := range speeds {
+		if got := warp.Speed(opentime.NewRationalTime(tt.input, 24)); math.Abs(got-tt.want) > 1e-3 {
+			t.Errorf("Speed(%v) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	got := warp.MapRange(opentime.NewTimeRange(opentime.NewRationalTime(12, 24), opentime.NewRationalTime(48, 24)))
+	if got.StartTime().Value() != 12 || math.Abs(got.Duration().Value()-68) > 1e-9 {
+		t.Errorf("MapRange = %v, want 12 for 68", got)
+	}
+
+	reverse := NewTimeCurveWarp("reverse", []TimeKeyframe{
+		{Input: opentime.NewRationalTime(0, 24), Output: opentime.NewRationalTime(48, 24)},
+		{Input: opentime.NewRationalTime(24, 24), Output: opentime.NewRationalTime(0, 24)},
+	}, nil)
+	got = reverse.MapRange(opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(12, 24)))
+	if got.StartTime().Value() != 24 || got.Duration().Value() != 24 {
+		t.Errorf("reverse MapRange = %v, want 24 for 24", got)
+	}
+
+	single := NewTimeCurveWarp("", rampKeyframes()[2:3], nil)
+	if got := single.MapTime(opentime.NewRationalTime(50, 24)); math.Abs(got.Value()-74) > 1e-9 {
+		t.Errorf("single keyframe MapTime = %v, want 74", got)
+	}
+	if got := NewTimeCurveWarp("", nil, nil).MapTime(opentime.NewRationalTime(5, 24)); got.Value() != 5 {
+		t.Errorf("empty MapTime = %v, want 5", got)
+	}
+}
+
+func TestTimeCurveWarpValidate(t *testing.T) {
+	if err := NewTimeCurveWarp("", nil, nil).Validate(); err == nil {
+		t.Error("expected error for no keyframes")
+	}
+	keys := rampKeyframes()
+	keys[1].Interpolation = "bezier"
+	if err := NewTimeCurveWarp("", keys, nil).Validate(); err == nil {
+		t.Error("expected error for unknown interpolation")
+	}
+	keys = rampKeyframes()
+	keys[3].Input = keys[2].Input
+	if err := NewTimeCurveWarp("", keys, nil).Validate(); err == nil {
+		t.Error("expected error for a shared input time")
+	}
+}
+
+func TestTimeCurveWarpRoundTrip(t *testing.T) {
+	warp := NewTimeCurveWarp("ramp", rampKeyframes(), AnyDictionary{"source": "nle"})
+	clip := NewClip("shot", nil, nil, nil, []Effect{warp}, nil, "", nil)
+	if !IsTimeEffect(warp) || ItemTimeEffect(clip) != warp {
+		t.Error("TimeCurveWarp should be the clip's time effect")
+	}
+	if err := clip.InsertEffect(0, NewLinearTimeWarp("", "", 2, nil)); err == nil {
+		t.Error("expected error adding a second time effect")
+	}
+
+	for name, encode := range map[string]func(SerializableObject) (string, error){
+		"fast":   func(o SerializableObject) (string, error) { return ToJSONString(o, "") },
+		"stdlib": func(o SerializableObject) (string, error) { b, err := o.(*Clip).MarshalJSON(); return string(b), err },
+	} {
+		data, err := encode(clip)
+		if err != nil {
+			t.Fatalf("%s: encode error: %v", name, err)
+		}
+		decoded, err := FromJSONString(data)
+		if err != nil {
+			t.Fatalf("%s: decode error: %v", name, err)
+		}
+		effects := decoded.(*Clip).Effects()
+		if len(effects) != 1 || !effects[0].IsEquivalentTo(warp) {
+			t.Errorf("%s: effects = %v", name, effects)
+		}
+	}
+
+	clone := warp.Clone().(*TimeCurveWarp)
+	clone.Keyframes()[0].Interpolation = TimeInterpolationHold
+	if warp.Keyframes()[0].Interpolation != TimeInterpolationLinear {
+		t.Error("Clone shares keyframes with the original")
+	}
+}
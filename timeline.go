@@ -70,16 +70,17 @@ func (t *Timeline) AvailableRange() (opentime.TimeRange, error) {
 
 // VideoTracks returns all video tracks.
 func (t *Timeline) VideoTracks() []*Track {
-	return t.tracksByKind(TrackKindVideo)
+	return t.TracksOfKind(TrackKindVideo)
 }
 
 // AudioTracks returns all audio tracks.
 func (t *Timeline) AudioTracks() []*Track {
-	return t.tracksByKind(TrackKindAudio)
+	return t.TracksOfKind(TrackKindAudio)
 }
 
-// tracksByKind returns tracks of the given kind.
-func (t *Timeline) tracksByKind(kind string) []*Track {
+// TracksOfKind returns the top-level tracks of the given kind, such as
+// TrackKindSubtitle or a registered custom kind.
+func (t *Timeline) TracksOfKind(kind string) []*Track {
 	var result []*Track
 	if t.tracks == nil {
 		return result
@@ -94,20 +95,88 @@ func (t *Timeline) tracksByKind(kind string) []*Track {
 	return result
 }
 
+// ExtractTrack returns a standalone timeline holding a copy of the track
+// at index i, with the timeline's name, metadata and global start time
+// copied, so the track can be processed and serialized on its own. The
+// source range, markers and effects of the timeline's stack are copied as
+// NewTimelineFromTrack does.
+func (t *Timeline) ExtractTrack(i int) (*Timeline, error) {
+	var children []Composable
+	if t.tracks != nil {
+		children = t.tracks.Children()
+	}
+	if i < 0 || i >= len(children) {
+		return nil, &IndexError{Index: i, Size: len(children)}
+	}
+	track, ok := children[i].(*Track)
+	if !ok {
+		return nil, &TypeMismatchError{Expected: TrackSchema.Name, Got: children[i].SchemaName()}
+	}
+
+	extracted := NewTimelineFromTrack(track)
+	extracted.name = t.name
+	extracted.metadata = CloneAnyDictionary(t.metadata)
+	if t.globalStartTime != nil {
+		gst := *t.globalStartTime
+		extracted.globalStartTime = &gst
+	}
+	return extracted, nil
+}
+
+// NewTimelineFromTrack returns a new timeline, named after the track,
+// holding a copy of it. The track itself is left in place. If the track
+// is in a stack, the stack's source range, markers and effects are copied
+// onto the new timeline's stack, so the track keeps the timing they give
+// it.
+func NewTimelineFromTrack(track *Track) *Timeline {
+	timeline := NewTimeline(track.Name(), nil, nil)
+	if stack, ok := track.Parent().(*Stack); ok {
+		timeline.tracks.sourceRange = cloneSourceRange(stack.sourceRange)
+		timeline.tracks.markers = cloneMarkers(stack.markers)
+		timeline.tracks.effects = cloneEffects(stack.effects)
+	}
+	timeline.tracks.AppendChild(track.Clone().(*Track))
+	return timeline
+}
+
 // FindClips finds all clips in the timeline.
-func (t *Timeline) FindClips(searchRange *opentime.TimeRange, shallowSearch bool) []*Clip {
+func (t *Timeline) FindClips(searchRange *opentime.TimeRange, shallowSearch bool, opts ...SearchOption) []*Clip {
 	if t.tracks == nil {
 		return nil
 	}
-	return t.tracks.FindClips(searchRange, shallowSearch)
+	return t.tracks.FindClips(searchRange, shallowSearch, opts...)
 }
 
 // FindChildren finds children matching the given filter.
-func (t *Timeline) FindChildren(searchRange *opentime.TimeRange, shallowSearch bool, filter func(Composable) bool) []Composable {
+func (t *Timeline) FindChildren(searchRange *opentime.TimeRange, shallowSearch bool, filter func(Composable) bool, opts ...SearchOption) []Composable {
+	if t.tracks == nil {
+		return nil
+	}
+	return t.tracks.FindChildren(searchRange, shallowSearch, filter, opts...)
+}
+
+// FindGaps finds all gaps in the timeline.
+func (t *Timeline) FindGaps(searchRange *opentime.TimeRange, shallowSearch bool, opts ...SearchOption) []*Gap {
+	if t.tracks == nil {
+		return nil
+	}
+	return t.tracks.FindGaps(searchRange, shallowSearch, opts...)
+}
+
+// FindTransitions finds all transitions in the timeline.
+func (t *Timeline) FindTransitions(searchRange *opentime.TimeRange, shallowSearch bool, opts ...SearchOption) []*Transition {
+	if t.tracks == nil {
+		return nil
+	}
+	return t.tracks.FindTransitions(searchRange, shallowSearch, opts...)
+}
+
+// FindStacks finds all stacks nested in the timeline's tracks.
+func (t *Timeline) FindStacks(searchRange *opentime.TimeRange, shallowSearch bool, opts ...SearchOption) []*Stack {
 	if t.tracks == nil {
 		return nil
 	}
-	return t.tracks.FindChildren(searchRange, shallowSearch, filter)
+	return t.tracks.FindStacks(searchRange, shallowSearch, opts...)
 }
 
 // AvailableImageBounds returns the union of all clips' image bounds.
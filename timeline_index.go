@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"strconv"
+	"sync"
+)
+
+// TimelineIndex maps clip names, media URLs and metadata values to the
+// items of a timeline, for repeated lookups in interactive tools.
+//
+// The index rebuilds itself on the next lookup after children are added,
+// removed or replaced anywhere in the timeline, or its tracks are swapped.
+// Renaming, relinking or editing the metadata of an item in place does not
+// change the structure: lookups never return an item that no longer
+// matches, but call Rebuild for such an item to be found under its new
+// value. A TimelineIndex is safe for concurrent lookups.
+type TimelineIndex struct {
+	timeline *Timeline
+
+	mu         sync.Mutex
+	tracks     *Stack
+	generation uint64
+	names      map[string][]*Clip
+	urls       map[string][]*Clip
+	metadata   map[metadataEntry][]Item
+}
+
+type metadataEntry struct {
+	key, value string
+}
+
+// BuildIndex returns an index of the timeline's clips by name and media
+// URL, and of its items by metadata value.
+func (t *Timeline) BuildIndex() *TimelineIndex {
+	idx := &TimelineIndex{timeline: t}
+	idx.Rebuild()
+	return idx
+}
+
+// Rebuild indexes the timeline again.
+func (idx *TimelineIndex) Rebuild() {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.rebuild()
+}
+
+// ClipsByName returns the clips named name, depth first.
+func (idx *TimelineIndex) ClipsByName(name string) []*Clip {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.refresh()
+	var clips []*Clip
+	for _, clip := range idx.names[name] {
+		if clip.Name() == name {
+			clips = append(clips, clip)
+		}
+	}
+	return clips
+}
+
+// ClipsByMediaURL returns the clips whose active media reference is an
+// external reference to url, depth first.
+func (idx *TimelineIndex) ClipsByMediaURL(url string) []*Clip {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.refresh()
+	var clips []*Clip
+	for _, clip := range idx.urls[url] {
+		if clipMediaURL(clip) == url {
+			clips = append(clips, clip)
+		}
+	}
+	return clips
+}
+
+// ItemsByMetadata returns the items, clips, gaps and compositions alike,
+// whose metadata holds value under key, depth first. Strings, booleans and
+// numbers are indexed; a number matches its shortest decimal form, so "24"
+// finds both 24 and 24.0.
+func (idx *TimelineIndex) ItemsByMetadata(key, value string) []Item {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.refresh()
+	var items []Item
+	for _, item := range idx.metadata[metadataEntry{key, value}] {
+		if v, ok := metadataValue(item.Metadata()[key]); ok && v == value {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// refresh rebuilds the index if the timeline's structure has changed.
+func (idx *TimelineIndex) refresh() {
+	tracks := idx.timeline.Tracks()
+	if tracks != idx.tracks || tracks != nil && tracks.Generation() != idx.generation {
+		idx.rebuild()
+	}
+}
+
+func (idx *TimelineIndex) rebuild() {
+	idx.names = make(map[string][]*Clip)
+	idx.urls = make(map[string][]*Clip)
+	idx.metadata = make(map[metadataEntry][]Item)
+	idx.tracks = idx.timeline.Tracks()
+	if idx.tracks == nil {
+		return
+	}
+	idx.generation = idx.tracks.Generation()
+	for _, child := range idx.tracks.Children() {
+		idx.add(child)
+	}
+}
+
+// add indexes child and everything in it.
+func (idx *TimelineIndex) add(child Composable) {
+	item, ok := child.(Item)
+	if !ok {
+		return
+	}
+	for key, v := range item.Metadata() {
+		if value, ok := metadataValue(v); ok {
+			entry := metadataEntry{key, value}
+			idx.metadata[entry] = append(idx.metadata[entry], item)
+		}
+	}
+	switch c := child.(type) {
+	case *Clip:
+		idx.names[c.Name()] = append(idx.names[c.Name()], c)
+		if url := clipMediaURL(c); url != "" {
+			idx.urls[url] = append(idx.urls[url], c)
+		}
+	case Composition:
+		for _, grandchild := range c.Children() {
+			idx.add(grandchild)
+		}
+	}
+}
+
+// clipMediaURL returns the target URL of the clip's active media reference
+// if it is an external reference.
+func clipMediaURL(clip *Clip) string {
+	if ref, ok := clip.MediaReference().(*ExternalReference); ok {
+		return ref.TargetURL()
+	}
+	return ""
+}
+
+// metadataValue returns the indexed form of a metadata value, and false for
+// values that are not indexed.
+func metadataValue(v any) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case int:
+		return strconv.Itoa(v), true
+	case int32:
+		return strconv.FormatInt(int64(v), 10), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), true
+	}
+	return "", false
+}
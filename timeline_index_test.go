@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"testing"
+)
+
+func TestTimelineIndex(t *testing.T) {
+	timeline := NewTimeline("index", nil, nil)
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	a := NewClip("A", NewExternalReference("", "/media/a.mov", nil, nil), nil, AnyDictionary{"shot": "010", "rate": 24.0}, nil, nil, "", nil)
+	b := NewClip("B", NewExternalReference("", "/media/a.mov", nil, nil), nil, AnyDictionary{"shot": "020"}, nil, nil, "", nil)
+	nested := NewStack("nested", nil, AnyDictionary{"shot": "010"}, nil, nil, nil)
+	inner := NewTrack("inner", nil, TrackKindVideo, nil, nil)
+	c := NewClip("A", nil, nil, AnyDictionary{"rate": 24}, nil, nil, "", nil)
+	inner.AppendChild(c)
+	nested.AppendChild(inner)
+	track.AppendChild(a)
+	track.AppendChild(b)
+	track.AppendChild(nested)
+	timeline.Tracks().AppendChild(track)
+
+	idx := timeline.BuildIndex()
+	if clips := idx.ClipsByName("A"); len(clips) != 2 || clips[0] != a || clips[1] != c {
+		t.Errorf("ClipsByName(A) = %v, want a and the nested clip", clips)
+	}
+	if clips := idx.ClipsByMediaURL("/media/a.mov"); len(clips) != 2 {
+		t.Errorf("ClipsByMediaURL = %v, want a and b", clips)
+	}
+	if items := idx.ItemsByMetadata("shot", "010"); len(items) != 2 || items[0] != a || items[1] != nested {
+		t.Errorf("ItemsByMetadata(shot, 010) = %v, want a and the nested stack", items)
+	}
+	if items := idx.ItemsByMetadata("rate", "24"); len(items) != 2 {
+		t.Errorf("ItemsByMetadata(rate, 24) = %v, want the float and int values", items)
+	}
+
+	// Structural edits anywhere in the timeline are picked up.
+	d := NewClip("D", nil, nil, nil, nil, nil, "", nil)
+	inner.AppendChild(d)
+	if clips := idx.ClipsByName("D"); len(clips) != 1 || clips[0] != d {
+		t.Errorf("ClipsByName(D) after append = %v", clips)
+	}
+	track.RemoveChild(0)
+	if clips := idx.ClipsByName("A"); len(clips) != 1 || clips[0] != c {
+		t.Errorf("ClipsByName(A) after remove = %v", clips)
+	}
+
+	// In-place edits drop stale matches and are found after Rebuild.
+	b.SetName("renamed")
+	if clips := idx.ClipsByName("B"); len(clips) != 0 {
+		t.Errorf("ClipsByName(B) after rename = %v", clips)
+	}
+	if clips := idx.ClipsByName("renamed"); len(clips) != 0 {
+		t.Errorf("ClipsByName(renamed) before Rebuild = %v", clips)
+	}
+	idx.Rebuild()
+	if clips := idx.ClipsByName("renamed"); len(clips) != 1 || clips[0] != b {
+		t.Errorf("ClipsByName(renamed) after Rebuild = %v", clips)
+	}
+
+	timeline.SetTracks(NewStack("empty", nil, nil, nil, nil, nil))
+	if clips := idx.ClipsByName("renamed"); len(clips) != 0 {
+		t.Errorf("ClipsByName after SetTracks = %v", clips)
+	}
+}
+
+func TestCompositionGeneration(t *testing.T) {
+	stack := NewStack("", nil, nil, nil, nil, nil)
+	track := NewTrack("", nil, TrackKindVideo, nil, nil)
+	stack.AppendChild(track)
+	before := stack.Generation()
+	track.AppendChild(NewGap("", nil, nil, nil, nil, nil))
+	if stack.Generation() == before {
+		t.Error("appending to a track did not change its parent's generation")
+	}
+	before = track.Generation()
+	track.ClearChildren()
+	if track.Generation() == before {
+		t.Error("ClearChildren did not change the generation")
+	}
+}
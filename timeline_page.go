@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ClipPage is one page of the clips of a timeline.
+type ClipPage struct {
+	Clips []*Clip `json:"-"`
+	// NextCursor resumes after the last clip of the page, and is empty on
+	// the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// FindClipsPage returns up to limit clips of the timeline, in FindClips
+// order, starting after cursor. An empty cursor starts at the first clip,
+// and a limit below one returns all remaining clips.
+//
+// The cursor is an opaque string anchored on the stable identifier of the
+// last clip of the page, so it stays valid across serialization and edits
+// elsewhere in the timeline. That clip is given an identifier if it has
+// none; call AssignIDs before saving a timeline whose cursors must
+// survive reloading. A cursor whose clip has been removed returns
+// ErrInvalidCursor. Pass the same options for every page.
+func (t *Timeline) FindClipsPage(cursor string, limit int, opts ...SearchOption) (ClipPage, error) {
+	clips := t.FindClips(nil, false, opts...)
+	start := 0
+	if cursor != "" {
+		index, err := resumeIndex(clips, cursor)
+		if err != nil {
+			return ClipPage{}, err
+		}
+		start = index + 1
+	}
+	end := len(clips)
+	if limit > 0 {
+		end = min(start+limit, end)
+	}
+	page := ClipPage{Clips: clips[start:end]}
+	if end < len(clips) {
+		page.NextCursor = encodeCursor(end-1, EnsureID(clips[end-1]))
+	}
+	return page, nil
+}
+
+func encodeCursor(index int, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(index) + ":" + id))
+}
+
+// resumeIndex returns the index of the clip cursor is anchored on. The
+// index recorded in the cursor is tried first.
+func resumeIndex(clips []*Clip, cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	hint, id, ok := strings.Cut(string(raw), ":")
+	index, err := strconv.Atoi(hint)
+	if !ok || err != nil || id == "" {
+		return 0, fmt.Errorf("%w: malformed", ErrInvalidCursor)
+	}
+	if index >= 0 && index < len(clips) && ID(clips[index]) == id {
+		return index, nil
+	}
+	for i, clip := range clips {
+		if ID(clip) == id {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: clip %s is no longer in the timeline", ErrInvalidCursor, id)
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindClipsPage(t *testing.T) {
+	timeline := NewTimeline("paged", nil, nil)
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		track.AppendChild(NewClip(name, nil, nil, nil, nil, nil, "", nil))
+	}
+	timeline.Tracks().AppendChild(track)
+
+	page, err := timeline.FindClipsPage("", 2)
+	if err != nil || len(page.Clips) != 2 || page.Clips[1].Name() != "b" || page.NextCursor == "" {
+		t.Fatalf("first page = %+v, %v", page, err)
+	}
+
+	// The cursor survives a round trip through JSON and an insert before it.
+	data, err := ToJSONBytes(timeline)
+	if err != nil {
+		t.Fatalf("ToJSONBytes error: %v", err)
+	}
+	obj, err := FromJSONBytes(data)
+	if err != nil {
+		t.Fatalf("FromJSONBytes error: %v", err)
+	}
+	reloaded := obj.(*Timeline)
+	reloaded.Tracks().Children()[0].(*Track).InsertChild(0, NewClip("new", nil, nil, nil, nil, nil, "", nil))
+	page, err = reloaded.FindClipsPage(page.NextCursor, 2)
+	if err != nil || len(page.Clips) != 2 || page.Clips[0].Name() != "c" || page.Clips[1].Name() != "d" {
+		t.Fatalf("second page = %+v, %v", page, err)
+	}
+	page, err = reloaded.FindClipsPage(page.NextCursor, 2)
+	if err != nil || len(page.Clips) != 1 || page.Clips[0].Name() != "e" || page.NextCursor != "" {
+		t.Fatalf("last page = %+v, %v", page, err)
+	}
+
+	page, _ = timeline.FindClipsPage("", 1)
+	track.RemoveChild(0)
+	if _, err := timeline.FindClipsPage(page.NextCursor, 1); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("cursor of a removed clip: got %v, want ErrInvalidCursor", err)
+	}
+	if _, err := timeline.FindClipsPage("not a cursor!", 1); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("malformed cursor: got %v, want ErrInvalidCursor", err)
+	}
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// RecordTime returns a time of the timeline, counted from the start of its
+// tracks, as absolute record time: offset by the global start time.
+func (t *Timeline) RecordTime(offset opentime.RationalTime) opentime.RationalTime {
+	if t.globalStartTime == nil {
+		return offset
+	}
+	return offset.Add(*t.globalStartTime)
+}
+
+// RecordRange returns the range of the whole timeline in absolute record
+// time, from its global start time for its duration.
+func (t *Timeline) RecordRange() (opentime.TimeRange, error) {
+	duration, err := t.Duration()
+	if err != nil {
+		return opentime.TimeRange{}, err
+	}
+	return opentime.NewTimeRange(t.RecordTime(opentime.NewRationalTime(0, duration.Rate())), duration), nil
+}
+
+// RecordRangeOfChild returns the range of child, anywhere in the timeline,
+// in absolute record time: its range in its parent projected to the
+// timeline's tracks and offset by the global start time. Returns
+// ErrNoCommonAncestor if child is not in the timeline.
+func (t *Timeline) RecordRangeOfChild(child Composable) (opentime.TimeRange, error) {
+	if t.tracks == nil || child == nil {
+		return opentime.TimeRange{}, ErrNoCommonAncestor
+	}
+	root := child
+	for root.Parent() != nil {
+		root = root.Parent()
+	}
+	parent := child.Parent()
+	if parent == nil || root != Composable(t.tracks) {
+		return opentime.TimeRange{}, ErrNoCommonAncestor
+	}
+	r, err := parent.RangeOfChild(child)
+	if err != nil {
+		return opentime.TimeRange{}, err
+	}
+	if parent != Composition(t.tracks) {
+		if r, err = parent.TransformedTimeRange(r, t.tracks); err != nil {
+			return opentime.TimeRange{}, err
+		}
+	}
+	return opentime.NewTimeRange(t.RecordTime(r.StartTime()), r.Duration()), nil
+}
+
+// RecordTimecodeRange returns the record in and out timecodes of child at
+// rate. A rate of zero uses the rate of the record range.
+func (t *Timeline) RecordTimecodeRange(child Composable, rate float64, drop opentime.IsDropFrameRate) (TimecodeRange, error) {
+	record, err := t.RecordRangeOfChild(child)
+	if err != nil {
+		return TimecodeRange{}, err
+	}
+	return timecodeRange(record, rate, drop)
+}
+
+// RecordTimecode returns the record in and out timecodes of the whole
+// timeline at rate. A rate of zero uses the rate of its duration.
+func (t *Timeline) RecordTimecode(rate float64, drop opentime.IsDropFrameRate) (TimecodeRange, error) {
+	record, err := t.RecordRange()
+	if err != nil {
+		return TimecodeRange{}, err
+	}
+	return timecodeRange(record, rate, drop)
+}
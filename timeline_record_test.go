@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func TestTimelineRecordRanges(t *testing.T) {
+	timeline := NewTimeline("record", nil, nil)
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	gap := NewGap("", searchTestRange(0, 48), nil, nil, nil, nil)
+	nested := NewStack("nested", nil, nil, nil, nil, nil)
+	inner := NewTrack("inner", nil, TrackKindVideo, nil, nil)
+	inner.AppendChild(NewGap("", searchTestRange(0, 12), nil, nil, nil, nil))
+	clip := NewClip("A", nil, searchTestRange(0, 24), nil, nil, nil, "", nil)
+	inner.AppendChild(clip)
+	nested.AppendChild(inner)
+	track.AppendChild(gap)
+	track.AppendChild(nested)
+	timeline.Tracks().AppendChild(track)
+
+	// Without a global start time record time counts from zero.
+	if r, err := timeline.RecordRangeOfChild(clip); err != nil || r.StartTime().Value() != 60 {
+		t.Errorf("RecordRangeOfChild without a global start = %v, %v", r, err)
+	}
+
+	hour := opentime.NewRationalTime(86400, 24)
+	timeline.SetGlobalStartTime(&hour)
+	if tc, err := timeline.RecordTimecodeRange(clip, 0, opentime.ForceNo); err != nil || tc.String() != "01:00:02:12 - 01:00:03:12" {
+		t.Errorf("RecordTimecodeRange(clip) = %v, %v", tc, err)
+	}
+	if tc, err := timeline.RecordTimecodeRange(nested, 0, opentime.ForceNo); err != nil || tc.In != "01:00:02:00" {
+		t.Errorf("RecordTimecodeRange(nested) = %v, %v", tc, err)
+	}
+	if r, err := timeline.RecordRange(); err != nil || r.StartTime().Value() != 86400 || r.Duration().Value() != 84 {
+		t.Errorf("RecordRange = %v, %v", r, err)
+	}
+	if tc, err := timeline.RecordTimecode(0, opentime.ForceNo); err != nil || tc.Out != "01:00:03:12" {
+		t.Errorf("RecordTimecode = %v, %v", tc, err)
+	}
+	if rt := timeline.RecordTime(opentime.NewRationalTime(24, 24)); rt.Value() != 86424 {
+		t.Errorf("RecordTime = %v", rt)
+	}
+	if _, err := timeline.RecordRangeOfChild(NewGap("", nil, nil, nil, nil, nil)); !errors.Is(err, ErrNoCommonAncestor) {
+		t.Errorf("detached gap: got %v, want ErrNoCommonAncestor", err)
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"encoding/json"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// TimelineReferenceSchema is the schema for TimelineReference.
+var TimelineReferenceSchema = Schema{Name: "TimelineReference", Version: 1}
+
+// TimelineReference is a media reference to a timeline in another .otio
+// file, such as a scene assembled into the master timeline of an episode.
+// A clip using it plays the referenced timeline's tracks, its source
+// range counted from their start. The available range, if set, caches
+// the referenced timeline's duration so the clip can be timed without
+// loading it; see CacheDuration. ResolveTimelineReferences inlines the
+// referenced timelines.
+type TimelineReference struct {
+	MediaReferenceBase
+	targetURL string
+}
+
+// NewTimelineReference creates a new TimelineReference to the .otio file
+// at targetURL, a path or file URL. Relative paths are resolved against
+// the directory given with WithBaseDir.
+func NewTimelineReference(
+	name string,
+	targetURL string,
+	availableRange *opentime.TimeRange,
+	metadata AnyDictionary,
+) *TimelineReference {
+	return &TimelineReference{
+		MediaReferenceBase: NewMediaReferenceBase(name, availableRange, metadata, nil),
+		targetURL:          targetURL,
+	}
+}
+
+// TargetURL returns the target URL.
+func (t *TimelineReference) TargetURL() string {
+	return t.targetURL
+}
+
+// SetTargetURL sets the target URL.
+func (t *TimelineReference) SetTargetURL(targetURL string) {
+	t.targetURL = targetURL
+}
+
+// SchemaName returns the schema name.
+func (t *TimelineReference) SchemaName() string {
+	return TimelineReferenceSchema.Name
+}
+
+// SchemaVersion returns the schema version.
+func (t *TimelineReference) SchemaVersion() int {
+	return TimelineReferenceSchema.Version
+}
+
+// Clone creates a deep copy.
+func (t *TimelineReference) Clone() SerializableObject {
+	return &TimelineReference{
+		MediaReferenceBase: MediaReferenceBase{
+			SerializableObjectWithMetadataBase: SerializableObjectWithMetadataBase{
+				name:     t.name,
+				metadata: CloneAnyDictionary(t.metadata),
+			},
+			availableRange:       cloneAvailableRange(t.availableRange),
+			availableImageBounds: cloneBox2d(t.availableImageBounds),
+		},
+		targetURL: t.targetURL,
+	}
+}
+
+// IsEquivalentTo returns true if equivalent.
+func (t *TimelineReference) IsEquivalentTo(other SerializableObject) bool {
+	otherT, ok := other.(*TimelineReference)
+	if !ok {
+		return false
+	}
+	return t.name == otherT.name && t.targetURL == otherT.targetURL
+}
+
+// timelineReferenceJSON is the JSON representation.
+type timelineReferenceJSON struct {
+	Schema               string              `json:"OTIO_SCHEMA"`
+	Name                 string              `json:"name"`
+	Metadata             AnyDictionary       `json:"metadata"`
+	AvailableRange       *opentime.TimeRange `json:"available_range"`
+	AvailableImageBounds *Box2d              `json:"available_image_bounds"`
+	TargetURL            string              `json:"target_url"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t *TimelineReference) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&timelineReferenceJSON{
+		Schema:               TimelineReferenceSchema.String(),
+		Name:                 t.name,
+		Metadata:             t.metadata,
+		AvailableRange:       t.availableRange,
+		AvailableImageBounds: t.availableImageBounds,
+		TargetURL:            t.targetURL,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *TimelineReference) UnmarshalJSON(data []byte) error {
+	var j timelineReferenceJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	t.name = j.Name
+	t.metadata = j.Metadata
+	if t.metadata == nil {
+		t.metadata = make(AnyDictionary)
+	}
+	t.availableRange = j.AvailableRange
+	t.availableImageBounds = j.AvailableImageBounds
+	t.targetURL = j.TargetURL
+	return nil
+}
+
+func init() {
+	RegisterSchema(TimelineReferenceSchema, func() SerializableObject {
+		return NewTimelineReference("", "", nil, nil)
+	})
+}
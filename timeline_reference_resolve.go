@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// ReferenceConfig holds options for loading and resolving timeline
+// references.
+type ReferenceConfig struct {
+	// BaseDir is the directory relative target URLs are resolved against.
+	BaseDir string
+	// Loader reads the timeline at a resolved path. The default reads the
+	// .otio file with FromJSONFile. A loader caching timelines lets a
+	// master timeline share the files of repeated scenes.
+	Loader func(path string) (*Timeline, error)
+}
+
+// ReferenceOption is a functional option for loading and resolving
+// timeline references.
+type ReferenceOption func(*ReferenceConfig)
+
+// WithBaseDir sets the directory relative target URLs are resolved
+// against, usually that of the file holding the references.
+func WithBaseDir(dir string) ReferenceOption {
+	return func(c *ReferenceConfig) {
+		c.BaseDir = dir
+	}
+}
+
+// WithTimelineLoader sets the function reading referenced timelines.
+func WithTimelineLoader(loader func(path string) (*Timeline, error)) ReferenceOption {
+	return func(c *ReferenceConfig) {
+		c.Loader = loader
+	}
+}
+
+func newReferenceConfig(opts []ReferenceOption) ReferenceConfig {
+	var cfg ReferenceConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Path returns the file path the reference points at: the path of a file
+// URL, or the target URL itself, joined to baseDir if it is relative.
+func (t *TimelineReference) Path(baseDir string) string {
+	path := t.targetURL
+	if u, err := url.Parse(path); err == nil && u.Scheme == "file" {
+		path = filepath.FromSlash(u.Path)
+	}
+	if !filepath.IsAbs(path) && baseDir != "" {
+		path = filepath.Join(baseDir, path)
+	}
+	return filepath.Clean(path)
+}
+
+// Load reads the referenced timeline.
+func (t *TimelineReference) Load(opts ...ReferenceOption) (*Timeline, error) {
+	cfg := newReferenceConfig(opts)
+	return cfg.load(t.Path(cfg.BaseDir))
+}
+
+// CacheDuration loads the referenced timeline and sets the available
+// range to its duration, starting at zero.
+func (t *TimelineReference) CacheDuration(opts ...ReferenceOption) error {
+	timeline, err := t.Load(opts...)
+	if err != nil {
+		return err
+	}
+	duration, err := timeline.Duration()
+	if err != nil {
+		return err
+	}
+	available := opentime.NewTimeRange(opentime.NewRationalTime(0, duration.Rate()), duration)
+	t.SetAvailableRange(&available)
+	return nil
+}
+
+// load reads the timeline at path.
+func (cfg ReferenceConfig) load(path string) (*Timeline, error) {
+	if cfg.Loader != nil {
+		return cfg.Loader(path)
+	}
+	obj, err := FromJSONFile(path)
+	if err != nil {
+		return nil, err
+	}
+	timeline, ok := obj.(*Timeline)
+	if !ok {
+		return nil, fmt.Errorf("%s holds a %s, not a Timeline: %w", path, obj.SchemaName(), ErrTypeMismatch)
+	}
+	return timeline, nil
+}
+
+// ResolveTimelineReferences replaces each clip of the timeline whose
+// active media reference is a TimelineReference with a stack holding
+// copies of the referenced timeline's tracks, resolving the references of
+// that timeline in turn. The stack takes the clip's name, source range,
+// metadata, markers, effects, color and enabled state. Relative target
+// URLs in a referenced file are resolved against that file's directory.
+// It returns the number of clips replaced, and ErrTimelineReferenceCycle
+// for a timeline referencing itself, directly or through others.
+func ResolveTimelineReferences(timeline *Timeline, opts ...ReferenceOption) (int, error) {
+	cfg := newReferenceConfig(opts)
+	return cfg.resolve(timeline, cfg.BaseDir, nil)
+}
+
+// resolve inlines the references of timeline, whose relative target URLs
+// are relative to baseDir. loading holds the paths being resolved.
+func (cfg ReferenceConfig) resolve(timeline *Timeline, baseDir string, loading []string) (int, error) {
+	resolved := 0
+	for _, clip := range timeline.FindClips(nil, false) {
+		ref, ok := clip.MediaReference().(*TimelineReference)
+		if !ok {
+			continue
+		}
+		path := ref.Path(baseDir)
+		if slices.Contains(loading, path) {
+			return resolved, fmt.Errorf("%s: %w", path, ErrTimelineReferenceCycle)
+		}
+		loaded, err := cfg.load(path)
+		if err != nil {
+			return resolved, fmt.Errorf("clip %q: %w", clip.Name(), err)
+		}
+		nested := loaded.Clone().(*Timeline)
+		n, err := cfg.resolve(nested, filepath.Dir(path), append(loading, path))
+		resolved += n
+		if err != nil {
+			return resolved, err
+		}
+
+		stack, err := inlineTimeline(clip, nested)
+		if err != nil {
+			return resolved, err
+		}
+		parent := clip.Parent()
+		index, err := parent.IndexOfChild(clip)
+		if err != nil {
+			return resolved, err
+		}
+		if err := parent.SetChild(index, stack); err != nil {
+			return resolved, err
+		}
+		resolved++
+	}
+	return resolved, nil
+}
+
+// inlineTimeline returns a stack standing in for clip, holding the tracks
+// of nested.
+func inlineTimeline(clip *Clip, nested *Timeline) (*Stack, error) {
+	var markers []*Marker
+	for _, marker := range clip.Markers() {
+		markers = append(markers, marker.Clone().(*Marker))
+	}
+	var effects []Effect
+	for _, effect := range clip.Effects() {
+		effects = append(effects, effect.Clone().(Effect))
+	}
+	var sourceRange *opentime.TimeRange
+	if sr := clip.SourceRange(); sr != nil {
+		r := *sr
+		sourceRange = &r
+	}
+	var color *Color
+	if c := clip.Color(); c != nil {
+		cc := *c
+		color = &cc
+	}
+	stack := NewStack(clip.Name(), sourceRange, CloneAnyDictionary(clip.Metadata()), effects, markers, color)
+	stack.SetEnabled(clip.Enabled())
+	if nested.Tracks() == nil {
+		return stack, nil
+	}
+	tracks := slices.Clone(nested.Tracks().Children())
+	nested.Tracks().ClearChildren()
+	for _, track := range tracks {
+		if err := stack.AppendChild(track); err != nil {
+			return nil, err
+		}
+	}
+	return stack, nil
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// writeSceneFile writes a timeline with one track holding clips to path.
+func writeSceneFile(t *testing.T, path string, clips ...*Clip) {
+	t.Helper()
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	for _, clip := range clips {
+		track.AppendChild(clip)
+	}
+	timeline := NewTimeline(filepath.Base(path), nil, nil)
+	timeline.Tracks().AppendChild(track)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ToJSONFile(timeline, path, "  "); err != nil {
+		t.Fatalf("ToJSONFile error: %v", err)
+	}
+}
+
+func TestTimelineReference(t *testing.T) {
+	dir := t.TempDir()
+	shot := NewClip("sh010", NewExternalReference("", "/media/sh010.mov", nil, nil), searchTestRange(0, 48), nil, nil, nil, "", nil)
+	writeSceneFile(t, filepath.Join(dir, "scenes", "sc02.otio"), shot)
+	// sc01 references sc02 relative to its own directory.
+	nested := NewClip("sc02", NewTimelineReference("", "sc02.otio", nil, nil), searchTestRange(12, 24), nil, nil, nil, "", nil)
+	writeSceneFile(t, filepath.Join(dir, "scenes", "sc01.otio"), nested)
+
+	ref := NewTimelineReference("scene 1", "scenes/sc01.otio", nil, nil)
+	if err := ref.CacheDuration(WithBaseDir(dir)); err != nil {
+		t.Fatalf("CacheDuration error: %v", err)
+	}
+	if ar := ref.AvailableRange(); ar == nil || ar.Duration().Value() != 24 {
+		t.Errorf("cached duration = %v, want 24 frames", ar)
+	}
+
+	master := NewTimeline("episode", nil, nil)
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	scene := NewClip("sc01", ref, nil, nil, nil, nil, "", nil)
+	if d, err := scene.Duration(); err != nil || d.Value() != 24 {
+		t.Errorf("clip duration from the cached range = %v, %v", d, err)
+	}
+	track.AppendChild(scene)
+	master.Tracks().AppendChild(track)
+
+	data, err := ToJSONString(master, "")
+	if err != nil {
+		t.Fatalf("ToJSONString error: %v", err)
+	}
+	obj, err := FromJSONString(data)
+	if err != nil {
+		t.Fatalf("FromJSONString error: %v", err)
+	}
+	decoded := obj.(*Timeline)
+	if !decoded.IsEquivalentTo(master) {
+		t.Errorf("round trip differs:\n%s", data)
+	}
+
+	n, err := ResolveTimelineReferences(decoded, WithBaseDir(dir))
+	if err != nil || n != 2 {
+		t.Fatalf("ResolveTimelineReferences = %d, %v", n, err)
+	}
+	clips := decoded.FindClips(nil, false)
+	if len(clips) != 1 || clips[0].Name() != "sh010" {
+		t.Fatalf("clips after resolving = %v", clips)
+	}
+	if r, err := decoded.RecordRangeOfChild(clips[0]); err != nil || r.StartTime().Value() != -12 {
+		t.Errorf("sh010 in the master = %v, %v; want from -12", r, err)
+	}
+	if d, err := decoded.Duration(); err != nil || d.Value() != 24 {
+		t.Errorf("master duration = %v, %v", d, err)
+	}
+
+	// A scene referencing itself is a cycle.
+	loop := NewClip("loop", NewTimelineReference("", "loop.otio", nil, nil), nil, nil, nil, nil, "", nil)
+	writeSceneFile(t, filepath.Join(dir, "loop.otio"), loop)
+	cyclic := NewTimeline("cyclic", nil, nil)
+	cyclicTrack := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	cyclicTrack.AppendChild(NewClip("loop", NewTimelineReference("", filepath.Join(dir, "loop.otio"), nil, nil), nil, nil, nil, nil, "", nil))
+	cyclic.Tracks().AppendChild(cyclicTrack)
+	if _, err := ResolveTimelineReferences(cyclic); !errors.Is(err, ErrTimelineReferenceCycle) {
+		t.Errorf("got %v, want ErrTimelineReferenceCycle", err)
+	}
+
+	loads := 0
+	loader := func(path string) (*Timeline, error) {
+		loads++
+		timeline := NewTimeline("stub", nil, nil)
+		stub := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+		stub.AppendChild(NewGapWithDuration(opentime.NewRationalTime(10, 24)))
+		timeline.Tracks().AppendChild(stub)
+		return timeline, nil
+	}
+	if _, err := NewTimelineReference("", "file:///shows/sc09.otio", nil, nil).Load(WithTimelineLoader(loader)); err != nil || loads != 1 {
+		t.Errorf("Load with a loader: %v, %d loads", err, loads)
+	}
+	if got := NewTimelineReference("", "file:///shows/sc09.otio", nil, nil).Path("/ignored"); got != filepath.FromSlash("/shows/sc09.otio") {
+		t.Errorf("Path of a file URL = %q", got)
+	}
+}
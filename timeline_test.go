@@ -4,6 +4,7 @@
 package gotio
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/Avalanche-io/gotio/opentime"
@@ -131,6 +132,141 @@ func TestTimelineClone(t *testing.T) {
 	}
 }
 
+func TestTimelineExtractTrack(t *testing.T) {
+	gst := opentime.NewRationalTime(86400, 24)
+	timeline := NewTimeline("show", &gst, AnyDictionary{"project": "demo"})
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(24, 24))
+	for _, name := range []string{"V1", "V2"} {
+		track := NewTrack(name, nil, TrackKindVideo, nil, nil)
+		track.AppendChild(NewClip(name+"_clip", nil, &sr, nil, nil, nil, "", nil))
+		timeline.Tracks().AppendChild(track)
+	}
+	timeline.Tracks().AppendChild(NewStack("nested", nil, nil, nil, nil, nil))
+
+	extracted, err := timeline.ExtractTrack(1)
+	if err != nil {
+		t.Fatalf("ExtractTrack error: %v", err)
+	}
+	if extracted.Name() != "show" || extracted.Metadata()["project"] != "demo" {
+		t.Errorf("expected the timeline name and metadata, got %s %v", extracted.Name(), extracted.Metadata())
+	}
+	if extracted.GlobalStartTime() == nil || extracted.GlobalStartTime().Value() != 86400 {
+		t.Errorf("expected the global start time, got %v", extracted.GlobalStartTime())
+	}
+	children := extracted.Tracks().Children()
+	if len(children) != 1 || children[0].Name() != "V2" {
+		t.Fatalf("expected only V2, got %v", children)
+	}
+
+	// The copy is independent of the original.
+	extracted.Metadata()["project"] = "changed"
+	children[0].SetName("changed")
+	if timeline.Metadata()["project"] != "demo" || timeline.Tracks().Children()[1].Name() != "V2" {
+		t.Error("modifying the extracted timeline affected the original")
+	}
+
+	data, err := ToJSONBytes(extracted)
+	if err != nil {
+		t.Fatalf("ToJSONBytes error: %v", err)
+	}
+	obj, err := FromJSONBytes(data)
+	if err != nil {
+		t.Fatalf("FromJSONBytes error: %v", err)
+	}
+	if !obj.IsEquivalentTo(extracted) {
+		t.Error("extracted timeline did not round-trip")
+	}
+
+	if _, err := timeline.ExtractTrack(3); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("expected ErrIndexOutOfRange, got %v", err)
+	}
+	if _, err := timeline.ExtractTrack(2); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("expected ErrTypeMismatch, got %v", err)
+	}
+}
+
+func TestTimelineExtractTrackKeepsStackTiming(t *testing.T) {
+	timeline := NewTimeline("show", nil, nil)
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(48, 24))
+	track.AppendChild(NewClip("shot", nil, &sr, nil, nil, nil, "", nil))
+	timeline.Tracks().AppendChild(track)
+
+	// The stack trims the track to its second half and marks a frame
+	trim := opentime.NewTimeRange(opentime.NewRationalTime(24, 24), opentime.NewRationalTime(24, 24))
+	timeline.Tracks().SetSourceRange(&trim)
+	marked := opentime.NewTimeRange(opentime.NewRationalTime(30, 24), opentime.NewRationalTime(0, 24))
+	timeline.Tracks().SetMarkers([]*Marker{NewMarker("note", marked, MarkerColorRed, "", nil)})
+	timeline.Tracks().SetEffects([]Effect{NewEffect("grade", "Color", nil)})
+
+	extracted, err := timeline.ExtractTrack(0)
+	if err != nil {
+		t.Fatalf("ExtractTrack error: %v", err)
+	}
+	stack := extracted.Tracks()
+	if got := stack.SourceRange(); got == nil || *got != trim {
+		t.Errorf("source range = %v, want %v", got, trim)
+	}
+	if d, _ := extracted.Duration(); d.Value() != 24 {
+		t.Errorf("duration = %v, want the trimmed 24 frames", d.Value())
+	}
+	if len(stack.Markers()) != 1 || stack.Markers()[0].Name() != "note" || stack.Markers()[0] == timeline.Tracks().Markers()[0] {
+		t.Errorf("markers = %v, want a copy of the stack's marker", stack.Markers())
+	}
+	if len(stack.Effects()) != 1 || stack.Effects()[0].EffectName() != "Color" {
+		t.Errorf("effects = %v, want the stack's effect", stack.Effects())
+	}
+
+	// Changing the copy leaves the original stack alone
+	stack.Markers()[0].SetName("changed")
+	if timeline.Tracks().Markers()[0].Name() != "note" {
+		t.Error("modifying the extracted marker affected the original")
+	}
+}
+
+func TestNewTimelineFromTrack(t *testing.T) {
+	timeline := NewTimeline("show", nil, nil)
+	track := NewTrack("A1", nil, TrackKindAudio, nil, nil)
+	timeline.Tracks().AppendChild(track)
+
+	standalone := NewTimelineFromTrack(track)
+	if standalone.Name() != "A1" || len(standalone.Tracks().Children()) != 1 {
+		t.Fatalf("unexpected timeline %s with %d tracks", standalone.Name(), len(standalone.Tracks().Children()))
+	}
+	if standalone.Tracks().Children()[0] == Composable(track) {
+		t.Error("expected a copy of the track")
+	}
+	if track.Parent() != Composition(timeline.Tracks()) {
+		t.Error("the original track should stay in place")
+	}
+}
+
+func TestToJSONBytesChild(t *testing.T) {
+	timeline := NewTimeline("show", nil, AnyDictionary{"project": "demo"})
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(24, 24))
+	clip := NewClip("shot", nil, &sr, nil, nil, nil, "", nil)
+	track.AppendChild(clip)
+	timeline.Tracks().AppendChild(track)
+
+	for _, child := range []SerializableObject{track, clip} {
+		data, err := ToJSONBytes(child)
+		if err != nil {
+			t.Fatalf("ToJSONBytes(%s) error: %v", child.SchemaName(), err)
+		}
+		obj, err := FromJSONBytes(data)
+		if err != nil {
+			t.Fatalf("FromJSONBytes(%s) error: %v", child.SchemaName(), err)
+		}
+		if !obj.IsEquivalentTo(child) {
+			t.Errorf("%s did not round-trip", child.SchemaName())
+		}
+		if composable, ok := obj.(Composable); ok && composable.Parent() != nil {
+			t.Errorf("%s decoded with a parent", child.SchemaName())
+		}
+	}
+}
+
 func TestTimelineIsEquivalentTo(t *testing.T) {
 	timeline1 := NewTimeline("test", nil, nil)
 	timeline2 := NewTimeline("test", nil, nil)
@@ -9,10 +9,14 @@ import (
 	"github.com/Avalanche-io/gotio/opentime"
 )
 
-// Track kinds.
+// Track kinds. Kinds other than video and audio hold timed data that is
+// not composited, such as captions; see RegisterTrackKind.
 const (
-	TrackKindVideo = "Video"
-	TrackKindAudio = "Audio"
+	TrackKindVideo    = "Video"
+	TrackKindAudio    = "Audio"
+	TrackKindSubtitle = "Subtitle"
+	TrackKindData     = "Data"
+	TrackKindMetadata = "Metadata"
 )
 
 // NeighborGapPolicy defines policies for inserting gaps.
@@ -73,8 +77,13 @@ func (t *Track) InsertChild(index int, child Composable) error {
 	if index < 0 || index > len(t.children) {
 		return &IndexError{Index: index, Size: len(t.children)}
 	}
+	child, err := t.adoptChild(child)
+	if err != nil {
+		return err
+	}
 	child.SetParent(t)
 	t.children = append(t.children[:index], append([]Composable{child}, t.children[index:]...)...)
+	t.touch()
 	return nil
 }
 
@@ -85,13 +94,29 @@ func (t *Track) AppendChild(child Composable) error {
 
 // SetChild sets the child at the given index.
 func (t *Track) SetChild(index int, child Composable) error {
+	_, err := t.ReplaceChild(index, child)
+	return err
+}
+
+// ReplaceChild replaces the child at the given index and returns the
+// detached previous child.
+func (t *Track) ReplaceChild(index int, child Composable) (Composable, error) {
 	if index < 0 || index >= len(t.children) {
-		return &IndexError{Index: index, Size: len(t.children)}
+		return nil, &IndexError{Index: index, Size: len(t.children)}
 	}
-	t.children[index].SetParent(nil)
+	old := t.children[index]
+	if old == child {
+		return old, nil
+	}
+	child, err := t.adoptChild(child)
+	if err != nil {
+		return nil, err
+	}
+	old.SetParent(nil)
 	child.SetParent(t)
 	t.children[index] = child
-	return nil
+	t.touch()
+	return old, nil
 }
 
 // RemoveChild removes the child at the given index.
@@ -101,6 +126,7 @@ func (t *Track) RemoveChild(index int) error {
 	}
 	t.children[index].SetParent(nil)
 	t.children = append(t.children[:index], t.children[index+1:]...)
+	t.touch()
 	return nil
 }
 
@@ -146,6 +172,61 @@ func (t *Track) TrimmedRangeOfChildAtIndex(index int) (opentime.TimeRange, error
 	return *trimmed, nil
 }
 
+// VisibleRangeOfChildAtIndex returns the range of the track during which
+// the child at the given index is on screen, trimmed to the track's
+// source range as TrimmedRangeOfChildAtIndex is. Unlike
+// RangeOfChildAtIndex, which gives an item the span between its cuts and a
+// transition the span from its cut, it extends an item by the handles of
+// the transitions next to it and gives a transition the span it overlaps
+// its neighbors, from InOffset before its cut to OutOffset after it.
+func (t *Track) VisibleRangeOfChildAtIndex(index int) (opentime.TimeRange, error) {
+	childRange, err := t.RangeOfChildAtIndex(index)
+	if err != nil {
+		return opentime.TimeRange{}, err
+	}
+	if tr, ok := t.children[index].(*Transition); ok {
+		childRange = opentime.NewTimeRange(childRange.StartTime().Sub(tr.InOffset()), childRange.Duration())
+	} else {
+		inHandle, outHandle, err := t.HandlesOfChild(t.children[index])
+		if err != nil {
+			return opentime.TimeRange{}, err
+		}
+		childRange = extendByHandles(childRange, inHandle, outHandle)
+	}
+	trimmed := t.trimChildRange(childRange)
+	if trimmed == nil {
+		return opentime.TimeRange{}, nil
+	}
+	return *trimmed, nil
+}
+
+// VisibleRangeOfChild returns the visible range of the given child, as
+// VisibleRangeOfChildAtIndex does.
+func (t *Track) VisibleRangeOfChild(child Composable) (opentime.TimeRange, error) {
+	index, err := t.IndexOfChild(child)
+	if err != nil {
+		return opentime.TimeRange{}, err
+	}
+	return t.VisibleRangeOfChildAtIndex(index)
+}
+
+// VisibleChildrenAtTime returns the children on screen at the given time
+// by their visible ranges: one item between transitions, and within a
+// transition the transition and the items on either side of it.
+func (t *Track) VisibleChildrenAtTime(searchTime opentime.RationalTime) ([]Composable, error) {
+	var result []Composable
+	for i, child := range t.children {
+		visible, err := t.VisibleRangeOfChildAtIndex(i)
+		if err != nil {
+			return nil, err
+		}
+		if visible.Contains(searchTime) {
+			result = append(result, child)
+		}
+	}
+	return result, nil
+}
+
 // AvailableRange returns the available range of the track.
 func (t *Track) AvailableRange() (opentime.TimeRange, error) {
 	if len(t.children) == 0 {
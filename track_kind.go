@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+var (
+	trackKindRegistry = map[string]bool{
+		TrackKindVideo:    true,
+		TrackKindAudio:    true,
+		TrackKindSubtitle: true,
+		TrackKindData:     true,
+		TrackKindMetadata: true,
+	}
+	trackKindLock sync.RWMutex
+)
+
+// RegisterTrackKind records a custom track kind, such as "Depth" or
+// "Telemetry", so Capabilities lists it and validation accepts it. Any
+// string can be a track's kind; registering one declares that a program
+// means to use it. Algorithms composite only video and audio tracks and
+// carry tracks of other kinds through unchanged.
+func RegisterTrackKind(kind string) {
+	trackKindLock.Lock()
+	defer trackKindLock.Unlock()
+	trackKindRegistry[kind] = true
+}
+
+// IsTrackKindRegistered reports whether kind is built in or registered.
+func IsTrackKindRegistered(kind string) bool {
+	trackKindLock.RLock()
+	defer trackKindLock.RUnlock()
+	return trackKindRegistry[kind]
+}
+
+// TrackKinds returns the built-in and registered track kinds, sorted.
+func TrackKinds() []string {
+	trackKindLock.RLock()
+	defer trackKindLock.RUnlock()
+	return slices.Sorted(maps.Keys(trackKindRegistry))
+}
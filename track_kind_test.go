@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRegisterTrackKind(t *testing.T) {
+	if !IsTrackKindRegistered(TrackKindSubtitle) || IsTrackKindRegistered("Telemetry") {
+		t.Fatal("unexpected built-in track kinds")
+	}
+	RegisterTrackKind("Telemetry")
+	if !IsTrackKindRegistered("Telemetry") {
+		t.Error("Telemetry not registered")
+	}
+	kinds := TrackKinds()
+	if !slices.IsSorted(kinds) || !slices.Contains(kinds, "Telemetry") || !slices.Contains(kinds, TrackKindData) {
+		t.Errorf("TrackKinds = %v", kinds)
+	}
+	if !slices.Equal(Capabilities().TrackKinds, kinds) {
+		t.Errorf("Capabilities().TrackKinds = %v", Capabilities().TrackKinds)
+	}
+}
+
+func TestTracksOfKind(t *testing.T) {
+	timeline := NewTimeline("kinds", nil, nil)
+	for _, kind := range []string{TrackKindVideo, TrackKindSubtitle, TrackKindAudio, TrackKindSubtitle, "Telemetry"} {
+		timeline.Tracks().AppendChild(NewTrack(kind, nil, kind, nil, nil))
+	}
+	if subtitles := timeline.TracksOfKind(TrackKindSubtitle); len(subtitles) != 2 {
+		t.Errorf("TracksOfKind(Subtitle) = %d tracks, want 2", len(subtitles))
+	}
+	if custom := timeline.TracksOfKind("Telemetry"); len(custom) != 1 || custom[0].Name() != "Telemetry" {
+		t.Errorf("TracksOfKind(Telemetry) = %v", custom)
+	}
+	if len(timeline.VideoTracks()) != 1 || len(timeline.AudioTracks()) != 1 {
+		t.Error("VideoTracks and AudioTracks should count only their kinds")
+	}
+	if data := timeline.TracksOfKind(TrackKindData); len(data) != 0 {
+		t.Errorf("TracksOfKind(Data) = %v", data)
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import "github.com/Avalanche-io/gotio/opentime"
+
+// LockMetadataKey is the track metadata namespace holding the lock flag
+// and locked regions of a track.
+const LockMetadataKey = "lock"
+
+// LockRegion is a locked range of a track, in the track's own time. The
+// edit functions of the algorithms package refuse to change content in a
+// locked region.
+type LockRegion struct {
+	Range opentime.TimeRange
+	// Reason optionally says why the region is locked, such as
+	// "approved by client".
+	Reason string
+}
+
+// Locked returns whether the whole track is locked.
+func (t *Track) Locked() bool {
+	values, ok := t.Metadata().GetDictionary(LockMetadataKey)
+	if !ok {
+		return false
+	}
+	locked, _ := values.GetBool("locked")
+	return locked
+}
+
+// SetLocked sets whether the whole track is locked.
+func (t *Track) SetLocked(locked bool) {
+	if locked {
+		lockNamespace(t)["locked"] = true
+		return
+	}
+	if values, ok := t.Metadata().GetDictionary(LockMetadataKey); ok {
+		delete(values, "locked")
+		pruneLockNamespace(t, values)
+	}
+}
+
+// LockRegions returns the locked regions of the track.
+func (t *Track) LockRegions() []LockRegion {
+	values, ok := t.Metadata().GetDictionary(LockMetadataKey)
+	if !ok {
+		return nil
+	}
+	entries, _ := values["regions"].([]any)
+	regions := make([]LockRegion, 0, len(entries))
+	for _, entry := range entries {
+		d, ok := asDictionary(entry)
+		if !ok {
+			continue
+		}
+		r, ok := d.GetTimeRange("range")
+		if !ok {
+			continue
+		}
+		reason, _ := d.GetString("reason")
+		regions = append(regions, LockRegion{Range: r, Reason: reason})
+	}
+	return regions
+}
+
+// SetLockRegions replaces the locked regions of the track. An empty list
+// unlocks them all.
+func (t *Track) SetLockRegions(regions []LockRegion) {
+	if len(regions) == 0 {
+		if values, ok := t.Metadata().GetDictionary(LockMetadataKey); ok {
+			delete(values, "regions")
+			pruneLockNamespace(t, values)
+		}
+		return
+	}
+	entries := make([]any, len(regions))
+	for i, region := range regions {
+		entry := AnyDictionary{"range": region.Range}
+		if region.Reason != "" {
+			entry["reason"] = region.Reason
+		}
+		entries[i] = entry
+	}
+	lockNamespace(t)["regions"] = entries
+}
+
+// AddLockRegion adds a locked region to the track.
+func (t *Track) AddLockRegion(region LockRegion) {
+	t.SetLockRegions(append(t.LockRegions(), region))
+}
+
+// IsRangeLocked reports whether changing r, in the track's time, would
+// touch locked content: the whole track is locked or r overlaps a locked
+// region. A range with no duration is a cut point, which touches a region
+// only if it falls strictly inside it.
+func (t *Track) IsRangeLocked(r opentime.TimeRange) bool {
+	if t.Locked() {
+		return true
+	}
+	start, end := r.StartTime(), r.EndTimeExclusive()
+	for _, region := range t.LockRegions() {
+		regionStart, regionEnd := region.Range.StartTime(), region.Range.EndTimeExclusive()
+		if start.Cmp(regionEnd) < 0 && regionStart.Cmp(end) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// lockNamespace returns the lock dictionary of the track, creating it if
+// needed.
+func lockNamespace(t *Track) AnyDictionary {
+	md := t.Metadata()
+	if values, ok := md.GetDictionary(LockMetadataKey); ok {
+		md[LockMetadataKey] = values
+		return values
+	}
+	values := AnyDictionary{}
+	md[LockMetadataKey] = values
+	return values
+}
+
+// pruneLockNamespace removes the lock dictionary once it is empty.
+func pruneLockNamespace(t *Track, values AnyDictionary) {
+	if len(values) == 0 {
+		delete(t.Metadata(), LockMetadataKey)
+	}
+}
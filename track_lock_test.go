@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func TestTrackLocks(t *testing.T) {
+	tr := func(start, duration float64) opentime.TimeRange {
+		return opentime.NewTimeRange(opentime.NewRationalTime(start, 24), opentime.NewRationalTime(duration, 24))
+	}
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	if track.Locked() || len(track.LockRegions()) != 0 || track.IsRangeLocked(tr(0, 100)) {
+		t.Fatal("new track should have no locks")
+	}
+
+	track.AddLockRegion(LockRegion{Range: tr(24, 24), Reason: "approved"})
+	track.AddLockRegion(LockRegion{Range: tr(96, 12)})
+	tests := []struct {
+		r    opentime.TimeRange
+		want bool
+	}{
+		{tr(0, 24), false},
+		{tr(0, 25), true},
+		{tr(47, 10), true},
+		{tr(48, 48), false},
+		{tr(24, 0), false},
+		{tr(30, 0), true},
+		{tr(100, 0), true},
+	}
+	for _, tt := range tests {
+		if got := track.IsRangeLocked(tt.r); got != tt.want {
+			t.Errorf("IsRangeLocked(%v) = %v, want %v", tt.r, got, tt.want)
+		}
+	}
+
+	data, err := ToJSONBytes(track)
+	if err != nil {
+		t.Fatalf("ToJSONBytes error: %v", err)
+	}
+	obj, err := FromJSONBytes(data)
+	if err != nil {
+		t.Fatalf("FromJSONBytes error: %v", err)
+	}
+	decoded := obj.(*Track)
+	regions := decoded.LockRegions()
+	if len(regions) != 2 || !regions[0].Range.Equal(tr(24, 24)) || regions[0].Reason != "approved" {
+		t.Errorf("decoded regions = %v", regions)
+	}
+
+	decoded.SetLocked(true)
+	if !decoded.Locked() || !decoded.IsRangeLocked(tr(0, 1)) {
+		t.Error("SetLocked(true) did not lock the track")
+	}
+	decoded.SetLocked(false)
+	decoded.SetLockRegions(nil)
+	if _, ok := decoded.Metadata()[LockMetadataKey]; ok {
+		t.Error("unlocking left the lock namespace in metadata")
+	}
+}
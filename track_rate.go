@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+// RateMetadataKey is the track metadata key tagging the frame rate all of
+// the track's items are meant to use.
+const RateMetadataKey = "rate"
+
+// TaggedRate returns the rate the track is tagged with, and false if it
+// has none.
+func (t *Track) TaggedRate() (float64, bool) {
+	rate, ok := t.Metadata().GetFloat64(RateMetadataKey)
+	return rate, ok && rate > 0
+}
+
+// SetTaggedRate tags the track with a rate. A rate of zero removes the tag.
+func (t *Track) SetTaggedRate(rate float64) {
+	if rate <= 0 {
+		delete(t.Metadata(), RateMetadataKey)
+		return
+	}
+	if t.Metadata() == nil {
+		t.SetMetadata(AnyDictionary{})
+	}
+	t.Metadata()[RateMetadataKey] = rate
+}
+
+// DominantRate returns the rate of most of the track's item duration, and
+// false if no item has a rate. On a tie the rate found first wins.
+func (t *Track) DominantRate() (float64, bool) {
+	var rates []float64
+	seconds := make(map[float64]float64)
+	for _, child := range t.Children() {
+		item, ok := child.(Item)
+		if !ok {
+			continue
+		}
+		duration, err := item.Duration()
+		if err != nil || duration.Rate() <= 0 {
+			continue
+		}
+		if _, ok := seconds[duration.Rate()]; !ok {
+			rates = append(rates, duration.Rate())
+		}
+		seconds[duration.Rate()] += duration.ToSeconds()
+	}
+	if len(rates) == 0 {
+		return 0, false
+	}
+	dominant := rates[0]
+	for _, rate := range rates[1:] {
+		if seconds[rate] > seconds[dominant] {
+			dominant = rate
+		}
+	}
+	return dominant, true
+}
+
+// Rate returns the tagged rate of the track, or its dominant rate if it is
+// not tagged.
+func (t *Track) Rate() (float64, bool) {
+	if rate, ok := t.TaggedRate(); ok {
+		return rate, true
+	}
+	return t.DominantRate()
+}
+
+// RateOutliers returns the items of the track whose rate differs from the
+// track's Rate.
+func (t *Track) RateOutliers() []Item {
+	rate, ok := t.Rate()
+	if !ok {
+		return nil
+	}
+	var outliers []Item
+	for _, child := range t.Children() {
+		item, ok := child.(Item)
+		if !ok {
+			continue
+		}
+		duration, err := item.Duration()
+		if err == nil && duration.Rate() > 0 && duration.Rate() != rate {
+			outliers = append(outliers, item)
+		}
+	}
+	return outliers
+}
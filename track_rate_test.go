@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+func TestTrackRates(t *testing.T) {
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	if _, ok := track.DominantRate(); ok {
+		t.Error("expected no dominant rate for an empty track")
+	}
+	for _, d := range []opentime.RationalTime{
+		opentime.NewRationalTime(24, 24),
+		opentime.NewRationalTime(50, 25), // two seconds at 25
+		opentime.NewRationalTime(24, 24),
+		opentime.NewRationalTime(30, 30),
+	} {
+		sr := opentime.NewTimeRange(opentime.NewRationalTime(0, d.Rate()), d)
+		track.AppendChild(NewClip("", nil, &sr, nil, nil, nil, "", nil))
+	}
+	track.AppendChild(NewTransition("", "", opentime.RationalTime{}, opentime.RationalTime{}, nil))
+
+	// 24 and 25 tie at two seconds; 24 comes first.
+	if rate, ok := track.DominantRate(); !ok || rate != 24 {
+		t.Errorf("DominantRate = %v, %v, want 24", rate, ok)
+	}
+	if outliers := track.RateOutliers(); len(outliers) != 2 || outliers[0] != track.Children()[1] {
+		t.Errorf("RateOutliers = %v, want the 25 and 30 clips", outliers)
+	}
+
+	track.SetTaggedRate(30)
+	if rate, ok := track.Rate(); !ok || rate != 30 {
+		t.Errorf("Rate = %v, want the tagged 30", rate)
+	}
+	if len(track.RateOutliers()) != 3 {
+		t.Errorf("expected 3 outliers of the tagged rate, got %d", len(track.RateOutliers()))
+	}
+	track.SetTaggedRate(0)
+	if _, ok := track.TaggedRate(); ok {
+		t.Error("expected the tag removed")
+	}
+}
@@ -146,6 +146,62 @@ func TestTrackHandlesOfChild(t *testing.T) {
 	}
 }
 
+func TestTrackVisibleRangeOfChild(t *testing.T) {
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(48, 24))
+	clip1 := NewClip("clip1", nil, &sr, nil, nil, nil, "", nil)
+	transition := NewTransition("", TransitionTypeSMPTEDissolve,
+		opentime.NewRationalTime(6, 24),
+		opentime.NewRationalTime(4, 24), nil)
+	clip2 := NewClip("clip2", nil, &sr, nil, nil, nil, "", nil)
+
+	track.AppendChild(clip1)
+	track.AppendChild(transition)
+	track.AppendChild(clip2)
+
+	for _, tc := range []struct {
+		child           Composable
+		start, duration float64
+	}{
+		{clip1, 0, 52},
+		{transition, 42, 10},
+		{clip2, 42, 54},
+	} {
+		r, err := track.VisibleRangeOfChild(tc.child)
+		if err != nil {
+			t.Fatalf("VisibleRangeOfChild(%s) error: %v", tc.child.SchemaName(), err)
+		}
+		if r.StartTime().Value() != tc.start || r.Duration().Value() != tc.duration {
+			t.Errorf("VisibleRangeOfChild(%s) = %v, want %v+%v", tc.child.SchemaName(), r, tc.start, tc.duration)
+		}
+	}
+
+	// The cut ranges are unchanged
+	if r, _ := track.RangeOfChildAtIndex(2); r.StartTime().Value() != 48 || r.Duration().Value() != 48 {
+		t.Errorf("RangeOfChildAtIndex(2) = %v, want 48+48", r)
+	}
+
+	onScreen, err := track.VisibleChildrenAtTime(opentime.NewRationalTime(45, 24))
+	if err != nil || len(onScreen) != 3 {
+		t.Errorf("VisibleChildrenAtTime(45) = %v, %v, want both clips and the transition", onScreen, err)
+	}
+	if onScreen, _ := track.VisibleChildrenAtTime(opentime.NewRationalTime(60, 24)); len(onScreen) != 1 || onScreen[0] != clip2 {
+		t.Errorf("VisibleChildrenAtTime(60) = %v, want clip2", onScreen)
+	}
+
+	vr, err := clip2.VisibleRange()
+	if err != nil || vr.StartTime().Value() != -6 || vr.Duration().Value() != 54 {
+		t.Errorf("clip2.VisibleRange() = %v, %v, want -6+54", vr, err)
+	}
+
+	trimmed := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(45, 24))
+	track.SetSourceRange(&trimmed)
+	if r, _ := track.VisibleRangeOfChild(clip1); r.Duration().Value() != 45 {
+		t.Errorf("trimmed VisibleRangeOfChild(clip1) = %v, want 0+45", r)
+	}
+}
+
 func TestTrackNeighborsOfWithGapPolicy(t *testing.T) {
 	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
 
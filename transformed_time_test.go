@@ -290,3 +290,49 @@ func TestTransformedTime_MultipleClipsInTrack(t *testing.T) {
 		t.Errorf("clip time 25 -> track (after gap): expected %v, got %v", expected, result)
 	}
 }
+
+func TestTransformedTime_CompositionTimeEffects(t *testing.T) {
+	// A stack at track time [24, 48) playing its track of two 24 frame
+	// clips at double speed.
+	inner := NewTrack("inner", nil, TrackKindVideo, nil, nil)
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(100, 24), opentime.NewRationalTime(24, 24))
+	first := NewClip("first", nil, &sr, nil, nil, nil, "", nil)
+	second := NewClip("second", nil, &sr, nil, nil, nil, "", nil)
+	inner.AppendChild(first)
+	inner.AppendChild(second)
+	stackRange := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(24, 24))
+	stack := NewStack("nested", &stackRange, nil, []Effect{NewLinearTimeWarp("", "", 2, nil)}, nil, nil)
+	stack.AppendChild(inner)
+
+	track := NewTrack("V1", nil, TrackKindVideo, nil, nil)
+	lead := NewClip("lead", nil, &sr, nil, nil, nil, "", nil)
+	track.AppendChild(lead)
+	track.AppendChild(stack)
+
+	// The first frame of second is 24 frames into the stack's content,
+	// which plays 12 frames after the stack starts.
+	got, err := second.TransformedTime(opentime.NewRationalTime(100, 24), lead)
+	if err != nil {
+		t.Fatalf("TransformedTime error: %v", err)
+	}
+	if got.Value() != 136 {
+		t.Errorf("second to lead = %v, want 136", got)
+	}
+	got, err = lead.TransformedTime(opentime.NewRationalTime(136, 24), second)
+	if err != nil {
+		t.Fatalf("TransformedTime error: %v", err)
+	}
+	if got.Value() != 100 {
+		t.Errorf("lead to second = %v, want 100", got)
+	}
+
+	// Clip effects are not applied.
+	lead.SetEffects([]Effect{NewLinearTimeWarp("", "", 4, nil)})
+	got, err = lead.TransformedTime(opentime.NewRationalTime(110, 24), track)
+	if err != nil {
+		t.Fatalf("TransformedTime error: %v", err)
+	}
+	if got.Value() != 10 {
+		t.Errorf("lead to track = %v, want 10", got)
+	}
+}
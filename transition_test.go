@@ -5,6 +5,8 @@ package gotio
 
 import (
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/Avalanche-io/gotio/opentime"
@@ -155,3 +157,82 @@ func TestTransitionJSON(t *testing.T) {
 		t.Errorf("OutOffset mismatch: got %v", transition2.OutOffset().Value())
 	}
 }
+
+func TestTransitionHelpers(t *testing.T) {
+	dissolve := NewCrossDissolve(opentime.NewRationalTime(24, 24))
+	if dissolve.TransitionType() != TransitionTypeSMPTEDissolve ||
+		dissolve.InOffset().Value() != 12 || dissolve.OutOffset().Value() != 12 {
+		t.Errorf("unexpected cross dissolve %s %v/%v", dissolve.TransitionType(), dissolve.InOffset(), dissolve.OutOffset())
+	}
+	if err := dissolve.ValidateParameters(); err != nil {
+		t.Errorf("ValidateParameters error: %v", err)
+	}
+
+	wipe := NewSMPTEWipe(1, opentime.NewRationalTime(10, 24))
+	if n, ok := wipe.Parameters().GetInt(TransitionParamWipeNumber); !ok || n != 1 {
+		t.Errorf("wipe number = %v, want 1", wipe.Parameters()[TransitionParamWipeNumber])
+	}
+	if err := wipe.ValidateParameters(); err != nil {
+		t.Errorf("ValidateParameters error: %v", err)
+	}
+
+	// Parameters survive a round trip, with the wipe number decoded as a
+	// float.
+	data, err := json.Marshal(wipe)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	decoded := &Transition{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if err := decoded.ValidateParameters(); err != nil {
+		t.Errorf("ValidateParameters after round trip error: %v", err)
+	}
+
+	crossfade := NewAudioCrossfade(opentime.NewRationalTime(4800, 48000))
+	if curve, _ := crossfade.Parameters().GetString(TransitionParamCurve); curve != CrossfadeCurveEqualPower {
+		t.Errorf("curve = %q, want %q", curve, CrossfadeCurveEqualPower)
+	}
+}
+
+func TestTransitionValidateParameters(t *testing.T) {
+	wipe := NewTransition("", TransitionTypeSMPTEWipe, opentime.RationalTime{}, opentime.RationalTime{}, nil)
+	if err := wipe.ValidateParameters(); !errors.Is(err, ErrInvalidTransitionParameter) {
+		t.Errorf("expected a missing wipe number error, got %v", err)
+	}
+	wipe.SetParameter(TransitionParamWipeNumber, 1000)
+	if err := wipe.ValidateParameters(); !errors.Is(err, ErrInvalidTransitionParameter) {
+		t.Errorf("expected an out of range error, got %v", err)
+	}
+	wipe.SetParameter(TransitionParamWipeNumber, 5)
+	wipe.SetParameter(TransitionParamReverse, "yes")
+	wipe.SetParameter("angle", 45)
+	err := wipe.ValidateParameters()
+	if err == nil || !strings.Contains(err.Error(), "reverse") || !strings.Contains(err.Error(), "angle") {
+		t.Errorf("expected type and unknown parameter errors, got %v", err)
+	}
+
+	crossfade := NewAudioCrossfade(opentime.NewRationalTime(12, 24))
+	crossfade.SetParameter(TransitionParamCurve, "log")
+	if err := crossfade.ValidateParameters(); !errors.Is(err, ErrInvalidTransitionParameter) {
+		t.Errorf("expected an invalid curve error, got %v", err)
+	}
+
+	custom := NewTransition("", "Studio_Push", opentime.RationalTime{}, opentime.RationalTime{}, nil)
+	custom.SetParameter("direction", "up")
+	if err := custom.ValidateParameters(); err != nil {
+		t.Errorf("expected unregistered types to pass, got %v", err)
+	}
+	RegisterTransitionType("Studio_Push", []TransitionParameter{
+		{Name: "direction", Kind: TransitionParameterString, Values: []string{"left", "right"}},
+	})
+	defer func() {
+		transitionParametersMu.Lock()
+		delete(transitionParameters, "Studio_Push")
+		transitionParametersMu.Unlock()
+	}()
+	if err := custom.ValidateParameters(); err == nil {
+		t.Error("expected the registered schema to reject the direction")
+	}
+}
@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package gotio
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/Avalanche-io/gotio/opentime"
+)
+
+// Transition types beyond those defined by OpenTimelineIO, for adapters
+// mapping to formats such as EDL and FCPXML.
+const (
+	// TransitionTypeSMPTEWipe is a wipe identified by its SMPTE wipe number,
+	// as written in EDL wipe codes such as W001.
+	TransitionTypeSMPTEWipe TransitionType = "SMPTE_Wipe"
+	// TransitionTypeDipToColor fades out to a solid color and back in.
+	TransitionTypeDipToColor TransitionType = "Dip_To_Color"
+	// TransitionTypeAudioCrossfade is a crossfade between two audio items.
+	TransitionTypeAudioCrossfade TransitionType = "Audio_Crossfade"
+)
+
+// TransitionParametersKey is the metadata key a transition's parameters are
+// stored under.
+const TransitionParametersKey = "parameters"
+
+// Parameter names used by the built-in transition types.
+const (
+	// TransitionParamWipeNumber is the SMPTE wipe number of a wipe.
+	TransitionParamWipeNumber = "wipe_number"
+	// TransitionParamReverse plays a wipe in the opposite direction.
+	TransitionParamReverse = "reverse"
+	// TransitionParamColor is the color of a dip, as a hex string such as
+	// "#000000".
+	TransitionParamColor = "color"
+	// TransitionParamCurve is the fade curve of an audio crossfade.
+	TransitionParamCurve = "curve"
+)
+
+// Audio crossfade curves.
+const (
+	CrossfadeCurveLinear     = "linear"
+	CrossfadeCurveEqualPower = "equal_power"
+)
+
+// ErrInvalidTransitionParameter is returned for transition parameters that
+// do not match the schema of their transition type.
+var ErrInvalidTransitionParameter = errors.New("invalid transition parameter")
+
+// TransitionParameterKind is the type of a transition parameter value.
+type TransitionParameterKind string
+
+const (
+	TransitionParameterInt    TransitionParameterKind = "int"
+	TransitionParameterFloat  TransitionParameterKind = "float"
+	TransitionParameterBool   TransitionParameterKind = "bool"
+	TransitionParameterString TransitionParameterKind = "string"
+)
+
+// TransitionParameter describes a parameter of a transition type.
+type TransitionParameter struct {
+	Name     string
+	Kind     TransitionParameterKind
+	Required bool
+	// Min and Max bound int and float values when Max > Min.
+	Min, Max float64
+	// Values lists the allowed string values. Empty allows any string.
+	Values []string
+}
+
+var (
+	transitionParameters = map[TransitionType][]TransitionParameter{
+		TransitionTypeSMPTEDissolve: nil,
+		TransitionTypeSMPTEWipe: {
+			{Name: TransitionParamWipeNumber, Kind: TransitionParameterInt, Required: true, Min: 0, Max: 999},
+			{Name: TransitionParamReverse, Kind: TransitionParameterBool},
+		},
+		TransitionTypeDipToColor: {
+			{Name: TransitionParamColor, Kind: TransitionParameterString},
+		},
+		TransitionTypeAudioCrossfade: {
+			{Name: TransitionParamCurve, Kind: TransitionParameterString,
+				Values: []string{CrossfadeCurveLinear, CrossfadeCurveEqualPower}},
+		},
+	}
+	transitionParametersMu sync.RWMutex
+)
+
+// RegisterTransitionType registers the parameters of a transition type,
+// replacing any registered before.
+func RegisterTransitionType(transitionType TransitionType, params []TransitionParameter) {
+	transitionParametersMu.Lock()
+	defer transitionParametersMu.Unlock()
+	transitionParameters[transitionType] = slices.Clone(params)
+}
+
+// TransitionParameters returns the parameters of a transition type, and
+// false if the type is not registered.
+func TransitionParameters(transitionType TransitionType) ([]TransitionParameter, bool) {
+	transitionParametersMu.RLock()
+	defer transitionParametersMu.RUnlock()
+	params, ok := transitionParameters[transitionType]
+	return slices.Clone(params), ok
+}
+
+// NewCrossDissolve creates a SMPTE dissolve of the given duration centered
+// on the cut.
+func NewCrossDissolve(duration opentime.RationalTime) *Transition {
+	half := opentime.NewRationalTime(duration.Value()/2, duration.Rate())
+	return NewTransition("", TransitionTypeSMPTEDissolve, half, half, nil)
+}
+
+// NewSMPTEWipe creates a wipe with the given SMPTE wipe number and
+// duration, centered on the cut.
+func NewSMPTEWipe(wipeNumber int, duration opentime.RationalTime) *Transition {
+	half := opentime.NewRationalTime(duration.Value()/2, duration.Rate())
+	t := NewTransition("", TransitionTypeSMPTEWipe, half, half, nil)
+	t.SetParameter(TransitionParamWipeNumber, wipeNumber)
+	return t
+}
+
+// NewAudioCrossfade creates an equal power audio crossfade of the given
+// duration, centered on the cut.
+func NewAudioCrossfade(duration opentime.RationalTime) *Transition {
+	half := opentime.NewRationalTime(duration.Value()/2, duration.Rate())
+	t := NewTransition("", TransitionTypeAudioCrossfade, half, half, nil)
+	t.SetParameter(TransitionParamCurve, CrossfadeCurveEqualPower)
+	return t
+}
+
+// Parameters returns the transition's parameters, or nil if it has none.
+func (t *Transition) Parameters() AnyDictionary {
+	params, _ := t.metadata.GetDictionary(TransitionParametersKey)
+	return params
+}
+
+// SetParameter sets a parameter of the transition.
+func (t *Transition) SetParameter(name string, value any) {
+	if t.metadata == nil {
+		t.metadata = make(AnyDictionary)
+	}
+	params, ok := t.metadata.GetDictionary(TransitionParametersKey)
+	if !ok {
+		params = make(AnyDictionary)
+		t.metadata[TransitionParametersKey] = params
+	}
+	params[name] = value
+}
+
+// ValidateParameters checks the transition's parameters against those
+// registered for its type. Unregistered types, such as custom transitions,
+// are not checked.
+func (t *Transition) ValidateParameters() error {
+	schema, ok := TransitionParameters(t.transitionType)
+	if !ok {
+		return nil
+	}
+	params := t.Parameters()
+	var errs []error
+	known := make(map[string]bool, len(schema))
+	for _, p := range schema {
+		known[p.Name] = true
+		if _, ok := params[p.Name]; !ok {
+			if p.Required {
+				errs = append(errs, fmt.Errorf("%w: %s requires %q", ErrInvalidTransitionParameter, t.transitionType, p.Name))
+			}
+			continue
+		}
+		if err := p.check(params); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, name := range params.Keys() {
+		if !known[name] {
+			errs = append(errs, fmt.Errorf("%w: %s has no parameter %q", ErrInvalidTransitionParameter, t.transitionType, name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// check validates the parameter's value in params.
+func (p TransitionParameter) check(params AnyDictionary) error {
+	var number float64
+	ok := false
+	switch p.Kind {
+	case TransitionParameterInt:
+		var n int
+		n, ok = params.GetInt(p.Name)
+		number = float64(n)
+	case TransitionParameterFloat:
+		number, ok = params.GetFloat64(p.Name)
+	case TransitionParameterBool:
+		_, ok = params.GetBool(p.Name)
+	case TransitionParameterString:
+		var s string
+		s, ok = params.GetString(p.Name)
+		if ok && len(p.Values) > 0 && !slices.Contains(p.Values, s) {
+			return fmt.Errorf("%w: %q must be one of %v, got %q", ErrInvalidTransitionParameter, p.Name, p.Values, s)
+		}
+	}
+	if !ok {
+		return fmt.Errorf("%w: %q must be %s, got %v", ErrInvalidTransitionParameter, p.Name, p.Kind, params[p.Name])
+	}
+	if (p.Kind == TransitionParameterInt || p.Kind == TransitionParameterFloat) &&
+		p.Max > p.Min && (number < p.Min || number > p.Max) {
+		return fmt.Errorf("%w: %q must be between %v and %v, got %v", ErrInvalidTransitionParameter, p.Name, p.Min, p.Max, number)
+	}
+	return nil
+}
@@ -0,0 +1,458 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package validate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/mediaresolver"
+)
+
+// epsilon is the tolerance, in seconds, used when comparing times.
+const epsilon = 1e-9
+
+// SourceRangeRule reports clips whose source range extends outside the
+// available range of their media reference.
+func SourceRangeRule() Rule {
+	return NewRule("source_range", checkSourceRange)
+}
+
+func checkSourceRange(composition gotio.Composition) []*Issue {
+	var issues []*Issue
+	for _, child := range composition.Children() {
+		clip, ok := child.(*gotio.Clip)
+		if !ok || clip.SourceRange() == nil || clip.MediaReference() == nil {
+			continue
+		}
+		ar := clip.MediaReference().AvailableRange()
+		if ar == nil {
+			continue
+		}
+		sr := *clip.SourceRange()
+		if sr.StartTime().ToSecondsOrZero() < ar.StartTime().ToSecondsOrZero()-epsilon ||
+			sr.EndTimeExclusive().ToSecondsOrZero() > ar.EndTimeExclusive().ToSecondsOrZero()+epsilon {
+			issues = append(issues, NewIssue(SeverityWarning, clip,
+				fmt.Sprintf("source range %s extends outside available range %s", sr, *ar), nil))
+		}
+	}
+	return issues
+}
+
+// TransitionLengthRule reports transitions whose offsets are negative or
+// longer than the items next to them, less any part of those items taken by
+// the transitions on their far side. The fix shortens the offsets to fit.
+func TransitionLengthRule() Rule {
+	return NewRule("transition_length", checkTransitionLength)
+}
+
+func checkTransitionLength(composition gotio.Composition) []*Issue {
+	if _, ok := composition.(*gotio.Track); !ok {
+		return nil
+	}
+	var issues []*Issue
+	for _, child := range composition.Children() {
+		transition, ok := child.(*gotio.Transition)
+		if !ok {
+			continue
+		}
+		if transition.InOffset().ToSecondsOrZero() < 0 || transition.OutOffset().ToSecondsOrZero() < 0 {
+			issues = append(issues, NewIssue(SeverityError, transition,
+				fmt.Sprintf("offsets %s/%s must not be negative", transition.InOffset(), transition.OutOffset()),
+				func() error { return fitTransition(transition) }))
+		}
+		prev, next := neighborDurations(composition, transition)
+		if transition.InOffset().ToSecondsOrZero() > prev.ToSecondsOrZero()+epsilon {
+			issues = append(issues, NewIssue(SeverityError, transition,
+				fmt.Sprintf("in offset %s is longer than the previous item", transition.InOffset()),
+				func() error { return fitTransition(transition) }))
+		}
+		if transition.OutOffset().ToSecondsOrZero() > next.ToSecondsOrZero()+epsilon {
+			issues = append(issues, NewIssue(SeverityError, transition,
+				fmt.Sprintf("out offset %s is longer than the next item", transition.OutOffset()),
+				func() error { return fitTransition(transition) }))
+		}
+	}
+	return issues
+}
+
+// neighborDurations returns how much of the items before and after the
+// transition it may cover: their durations less the offsets of the
+// transitions on their far side. Missing neighbors have zero duration.
+func neighborDurations(composition gotio.Composition, transition *gotio.Transition) (opentime.RationalTime, opentime.RationalTime) {
+	var prev, next opentime.RationalTime
+	index, err := composition.IndexOfChild(transition)
+	if err != nil {
+		return prev, next
+	}
+	children := composition.Children()
+	if index > 0 {
+		if item, ok := children[index-1].(gotio.Item); ok {
+			prev, _ = item.Duration()
+			if index > 1 {
+				if before, ok := children[index-2].(*gotio.Transition); ok {
+					prev = prev.Sub(before.OutOffset())
+				}
+			}
+		}
+	}
+	if index < len(children)-1 {
+		if item, ok := children[index+1].(gotio.Item); ok {
+			next, _ = item.Duration()
+			if index < len(children)-2 {
+				if after, ok := children[index+2].(*gotio.Transition); ok {
+					next = next.Sub(after.InOffset())
+				}
+			}
+		}
+	}
+	return prev, next
+}
+
+// fitTransition clamps the transition's offsets to zero and to what its
+// neighbors leave free.
+func fitTransition(transition *gotio.Transition) error {
+	parent := transition.Parent()
+	if parent == nil {
+		return nil
+	}
+	if transition.InOffset().ToSecondsOrZero() < 0 {
+		transition.SetInOffset(opentime.NewRationalTime(0, transition.InOffset().Rate()))
+	}
+	if transition.OutOffset().ToSecondsOrZero() < 0 {
+		transition.SetOutOffset(opentime.NewRationalTime(0, transition.OutOffset().Rate()))
+	}
+	prev, next := neighborDurations(parent, transition)
+	if transition.InOffset().ToSecondsOrZero() > prev.ToSecondsOrZero()+epsilon {
+		transition.SetInOffset(rescale(maxTime(prev), transition.InOffset()))
+	}
+	if transition.OutOffset().ToSecondsOrZero() > next.ToSecondsOrZero()+epsilon {
+		transition.SetOutOffset(rescale(maxTime(next), transition.OutOffset()))
+	}
+	return nil
+}
+
+// maxTime returns t, or zero if t is negative.
+func maxTime(t opentime.RationalTime) opentime.RationalTime {
+	if t.ToSecondsOrZero() < 0 {
+		return opentime.NewRationalTime(0, t.Rate())
+	}
+	return t
+}
+
+// TransitionParametersRule reports transitions whose parameters do not
+// match the schema registered for their type with
+// gotio.RegisterTransitionType.
+func TransitionParametersRule() Rule {
+	return NewRule("transition_parameters", checkTransitionParameters)
+}
+
+func checkTransitionParameters(composition gotio.Composition) []*Issue {
+	var issues []*Issue
+	for _, child := range composition.Children() {
+		transition, ok := child.(*gotio.Transition)
+		if !ok {
+			continue
+		}
+		if err := transition.ValidateParameters(); err != nil {
+			issues = append(issues, NewIssue(SeverityWarning, transition, err.Error(), nil))
+		}
+	}
+	return issues
+}
+
+// MarkerColorRule reports markers whose color is not a standard marker
+// color. The fix corrects names that differ only in case, such as "red".
+func MarkerColorRule() Rule {
+	return NewRule("marker_color", checkMarkerColor)
+}
+
+func checkMarkerColor(composition gotio.Composition) []*Issue {
+	var issues []*Issue
+	check := func(item gotio.Item) {
+		for _, marker := range item.Markers() {
+			if marker.Color().IsValid() {
+				continue
+			}
+			var fix func() error
+			if mc, err := gotio.ParseMarkerColor(string(marker.Color())); err == nil {
+				fix = func() error {
+					marker.SetColor(mc)
+					return nil
+				}
+			}
+			issues = append(issues, NewIssue(SeverityWarning, item,
+				fmt.Sprintf("marker %q has unknown color %q", marker.Name(), marker.Color()), fix))
+		}
+	}
+	// Markers on the top-level composition are checked here, since it is
+	// no other composition's child.
+	if composition.Parent() == nil {
+		check(composition)
+	}
+	for _, child := range composition.Children() {
+		if item, ok := child.(gotio.Item); ok {
+			check(item)
+		}
+	}
+	return issues
+}
+
+// TrackKindRule reports tracks whose kind is neither built in nor
+// registered with gotio.RegisterTrackKind, usually a misspelling such as
+// "video". The fix corrects kinds that differ from a known kind only in
+// case.
+func TrackKindRule() Rule {
+	return NewRule("track_kind", checkTrackKind)
+}
+
+func checkTrackKind(composition gotio.Composition) []*Issue {
+	var issues []*Issue
+	for _, child := range composition.Children() {
+		track, ok := child.(*gotio.Track)
+		if !ok || gotio.IsTrackKindRegistered(track.Kind()) {
+			continue
+		}
+		var fix func() error
+		for _, kind := range gotio.TrackKinds() {
+			if strings.EqualFold(kind, track.Kind()) {
+				fix = func() error {
+					track.SetKind(kind)
+					return nil
+				}
+				break
+			}
+		}
+		issues = append(issues, NewIssue(SeverityWarning, track,
+			fmt.Sprintf("unknown track kind %q", track.Kind()), fix))
+	}
+	return issues
+}
+
+// DurationRule reports items whose source range has a zero or negative
+// duration. The fix removes zero duration items; negative durations must be
+// repaired by hand.
+func DurationRule() Rule {
+	return NewRule("duration", checkDuration)
+}
+
+func checkDuration(composition gotio.Composition) []*Issue {
+	var issues []*Issue
+	for _, child := range composition.Children() {
+		item, ok := child.(gotio.Item)
+		if !ok || item.SourceRange() == nil {
+			continue
+		}
+		duration := item.SourceRange().Duration()
+		switch d := duration.ToSecondsOrZero(); {
+		case d < -epsilon:
+			issues = append(issues, NewIssue(SeverityError, item,
+				fmt.Sprintf("negative duration %s", duration), nil))
+		case d <= epsilon:
+			issues = append(issues, NewIssue(SeverityError, item,
+				"zero duration", func() error { return removeFromParent(item) }))
+		}
+	}
+	return issues
+}
+
+// RateMismatchRule reports items in a track whose rate differs from the
+// track's rate: its tagged rate, or else the rate of most of its duration.
+// The fix rescales the item's source range and markers to the track rate.
+func RateMismatchRule() Rule {
+	return NewRule("rate_mismatch", checkRateMismatch)
+}
+
+func checkRateMismatch(composition gotio.Composition) []*Issue {
+	track, ok := composition.(*gotio.Track)
+	if !ok {
+		return nil
+	}
+	trackRate, _ := track.Rate()
+	var issues []*Issue
+	for _, item := range track.RateOutliers() {
+		duration, _ := item.Duration()
+		issues = append(issues, NewIssue(SeverityWarning, item,
+			fmt.Sprintf("rate %g differs from track rate %g", duration.Rate(), trackRate),
+			func() error { return rescaleItem(item, trackRate) }))
+	}
+	return issues
+}
+
+// rescaleItem rescales the item's source range and markers to rate. An
+// item without a source range is given its trimmed range at rate.
+func rescaleItem(item gotio.Item, rate float64) error {
+	like := opentime.NewRationalTime(0, rate)
+	sr, err := item.TrimmedRange()
+	if err != nil {
+		return err
+	}
+	rescaled := opentime.NewTimeRange(rescale(sr.StartTime(), like), rescale(sr.Duration(), like))
+	item.SetSourceRange(&rescaled)
+	for _, marker := range item.Markers() {
+		mr := marker.MarkedRange()
+		marker.SetMarkedRange(opentime.NewTimeRange(rescale(mr.StartTime(), like), rescale(mr.Duration(), like)))
+	}
+	return nil
+}
+
+// MissingMediaRule reports clips whose active media reference is missing or
+// has no target URL.
+func MissingMediaRule() Rule {
+	return NewRule("missing_media", checkMissingMedia)
+}
+
+func checkMissingMedia(composition gotio.Composition) []*Issue {
+	var issues []*Issue
+	for _, child := range composition.Children() {
+		clip, ok := child.(*gotio.Clip)
+		if !ok {
+			continue
+		}
+		switch ref := clip.MediaReference().(type) {
+		case nil:
+			issues = append(issues, NewIssue(SeverityWarning, clip, "no media reference", nil))
+		case *gotio.ExternalReference:
+			if ref.TargetURL() == "" {
+				issues = append(issues, NewIssue(SeverityWarning, clip, "external reference has no target URL", nil))
+			}
+		default:
+			if ref.IsMissingReference() {
+				issues = append(issues, NewIssue(SeverityWarning, clip, "missing media reference", nil))
+			}
+		}
+	}
+	return issues
+}
+
+// MediaExistsRule reports clips whose external reference points at media
+// that cannot be found. Lookups go through resolver, so media shared by
+// many clips is checked once; if resolver is nil the rule makes its own.
+// The rule is not one of the default rules since it touches the file
+// system or network.
+func MediaExistsRule(resolver *mediaresolver.Resolver) Rule {
+	if resolver == nil {
+		resolver = mediaresolver.New()
+	}
+	return NewRule("media_exists", func(composition gotio.Composition) []*Issue {
+		return checkMediaExists(composition, resolver)
+	})
+}
+
+func checkMediaExists(composition gotio.Composition, resolver *mediaresolver.Resolver) []*Issue {
+	var issues []*Issue
+	for _, child := range composition.Children() {
+		clip, ok := child.(*gotio.Clip)
+		if !ok {
+			continue
+		}
+		ref, ok := clip.MediaReference().(*gotio.ExternalReference)
+		if !ok || ref.TargetURL() == "" {
+			continue
+		}
+		info, err := resolver.Resolve(context.Background(), ref.TargetURL())
+		switch {
+		case err != nil:
+			issues = append(issues, NewIssue(SeverityWarning, clip,
+				fmt.Sprintf("cannot check media %s: %v", ref.TargetURL(), err), nil))
+		case !info.Exists:
+			issues = append(issues, NewIssue(SeverityError, clip,
+				fmt.Sprintf("media not found: %s", ref.TargetURL()), nil))
+		}
+	}
+	return issues
+}
+
+// TimeEffectRule reports items with more than one time effect, whose
+// combined timing renderers disagree on. There is no fix, as which effect
+// to keep is an editorial choice.
+func TimeEffectRule() Rule {
+	return NewRule("time_effects", checkTimeEffects)
+}
+
+func checkTimeEffects(composition gotio.Composition) []*Issue {
+	var issues []*Issue
+	for _, child := range composition.Children() {
+		item, ok := child.(gotio.Item)
+		if !ok {
+			continue
+		}
+		count := 0
+		for _, effect := range item.Effects() {
+			if gotio.IsTimeEffect(effect) {
+				count++
+			}
+		}
+		if count > 1 {
+			issues = append(issues, NewIssue(SeverityError, item,
+				fmt.Sprintf("%d time effects, at most one is allowed", count), nil))
+		}
+	}
+	return issues
+}
+
+// EmptyStackRule reports nested stacks with no children. The fix replaces
+// the stack with a gap of the same duration, or removes it if it has none.
+func EmptyStackRule() Rule {
+	return NewRule("empty_stack", checkEmptyStack)
+}
+
+func checkEmptyStack(composition gotio.Composition) []*Issue {
+	var issues []*Issue
+	for _, child := range composition.Children() {
+		stack, ok := child.(*gotio.Stack)
+		if !ok || len(stack.Children()) > 0 {
+			continue
+		}
+		issues = append(issues, NewIssue(SeverityWarning, stack,
+			"nested stack has no children", func() error { return replaceEmptyStack(stack) }))
+	}
+	return issues
+}
+
+// replaceEmptyStack replaces an empty stack with a gap of its duration.
+func replaceEmptyStack(stack *gotio.Stack) error {
+	duration, err := stack.Duration()
+	if err != nil || duration.ToSecondsOrZero() <= epsilon {
+		return removeFromParent(stack)
+	}
+	parent := stack.Parent()
+	if parent == nil {
+		return nil
+	}
+	index, err := parent.IndexOfChild(stack)
+	if err != nil {
+		return err
+	}
+	return parent.SetChild(index, gotio.NewGapWithDuration(duration))
+}
+
+// removeFromParent removes the composable from its parent composition.
+// Composables that were already removed by another fix are left alone.
+func removeFromParent(child gotio.Composable) error {
+	parent := child.Parent()
+	if parent == nil {
+		return nil
+	}
+	index, err := parent.IndexOfChild(child)
+	if err != nil {
+		return err
+	}
+	return parent.RemoveChild(index)
+}
+
+
+// rescale returns t at the rate of like. Times without a rate are zero.
+func rescale(t, like opentime.RationalTime) opentime.RationalTime {
+	if t.Rate() <= 0 {
+		return opentime.NewRationalTime(0, like.Rate())
+	}
+	if like.Rate() <= 0 {
+		return t
+	}
+	return t.RescaledTo(like.Rate())
+}
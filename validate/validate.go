@@ -0,0 +1,246 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package validate checks OpenTimelineIO timelines for structural problems.
+// Each Rule inspects the direct children of a composition and reports Issues
+// with a severity. Issues that can be repaired without changing the timing of
+// the timeline carry a fix that can be applied with Issue.Fix.
+package validate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+func init() {
+	gotio.RegisterFeature("validate")
+}
+
+// ErrNotFixable is returned when fixing an issue that has no automatic repair.
+var ErrNotFixable = errors.New("issue cannot be fixed automatically")
+
+// Severity indicates how serious an issue is.
+type Severity int
+
+const (
+	// SeverityInfo marks issues that are worth knowing about but harmless.
+	SeverityInfo Severity = iota
+	// SeverityWarning marks issues that may produce unexpected results.
+	SeverityWarning
+	// SeverityError marks issues that make the timeline invalid.
+	SeverityError
+)
+
+// String returns the string representation of a Severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", s)
+	}
+}
+
+// ParseSeverity returns the severity named s, as returned by
+// Severity.String.
+func ParseSeverity(s string) (Severity, error) {
+	for _, severity := range []Severity{SeverityInfo, SeverityWarning, SeverityError} {
+		if s == severity.String() {
+			return severity, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", s)
+}
+
+// Issue is a single problem found by a rule.
+type Issue struct {
+	Rule     string
+	Severity Severity
+	Message  string
+	Object   gotio.Composable
+	fix      func() error
+}
+
+// Fixable returns whether the issue can be repaired automatically.
+func (i *Issue) Fixable() bool {
+	return i.fix != nil
+}
+
+// Fix repairs the issue in place.
+// Returns ErrNotFixable if the issue has no automatic repair.
+func (i *Issue) Fix() error {
+	if i.fix == nil {
+		return ErrNotFixable
+	}
+	return i.fix()
+}
+
+// String returns a one-line description of the issue.
+func (i *Issue) String() string {
+	if i.Object != nil && i.Object.Name() != "" {
+		return fmt.Sprintf("%s [%s] %s: %s", i.Severity, i.Rule, i.Object.Name(), i.Message)
+	}
+	return fmt.Sprintf("%s [%s] %s", i.Severity, i.Rule, i.Message)
+}
+
+// Rule checks the direct children of a composition.
+type Rule interface {
+	// Name returns the rule's identifier, used as Issue.Rule.
+	Name() string
+
+	// Check returns the issues found among the composition's children.
+	Check(composition gotio.Composition) []*Issue
+}
+
+// ruleFunc adapts a function to the Rule interface.
+type ruleFunc struct {
+	name  string
+	check func(gotio.Composition) []*Issue
+}
+
+func (r ruleFunc) Name() string {
+	return r.name
+}
+
+func (r ruleFunc) Check(composition gotio.Composition) []*Issue {
+	issues := r.check(composition)
+	for _, issue := range issues {
+		issue.Rule = r.name
+	}
+	return issues
+}
+
+// NewRule creates a Rule from a check function.
+// The Rule field of the returned issues is set to name.
+func NewRule(name string, check func(composition gotio.Composition) []*Issue) Rule {
+	return ruleFunc{name: name, check: check}
+}
+
+// NewIssue creates an Issue for use in custom rules.
+// fix may be nil if the issue cannot be repaired automatically.
+func NewIssue(severity Severity, object gotio.Composable, message string, fix func() error) *Issue {
+	return &Issue{
+		Severity: severity,
+		Message:  message,
+		Object:   object,
+		fix:      fix,
+	}
+}
+
+// Config holds configuration for Validate.
+type Config struct {
+	Rules       []Rule
+	MinSeverity Severity
+}
+
+// Option is a functional option for Validate.
+type Option func(*Config)
+
+// WithRules sets the rules to check, replacing the default rules.
+func WithRules(rules ...Rule) Option {
+	return func(c *Config) {
+		c.Rules = rules
+	}
+}
+
+// WithExtraRules adds rules to check in addition to the configured ones.
+func WithExtraRules(rules ...Rule) Option {
+	return func(c *Config) {
+		c.Rules = append(append([]Rule(nil), c.Rules...), rules...)
+	}
+}
+
+// WithMinSeverity drops issues below the given severity.
+func WithMinSeverity(severity Severity) Option {
+	return func(c *Config) {
+		c.MinSeverity = severity
+	}
+}
+
+// DefaultRules returns the built-in rules.
+func DefaultRules() []Rule {
+	return []Rule{
+		SourceRangeRule(),
+		TransitionLengthRule(),
+		TransitionParametersRule(),
+		MarkerColorRule(),
+		TrackKindRule(),
+		DurationRule(),
+		RateMismatchRule(),
+		MissingMediaRule(),
+		EmptyStackRule(),
+		TimeEffectRule(),
+	}
+}
+
+// Validate checks every composition in the timeline and returns the issues
+// found, in depth-first order.
+func Validate(timeline *gotio.Timeline, opts ...Option) []*Issue {
+	if timeline == nil || timeline.Tracks() == nil {
+		return nil
+	}
+	return ValidateComposition(timeline.Tracks(), opts...)
+}
+
+// ValidateComposition checks the composition and every composition nested
+// inside it.
+func ValidateComposition(composition gotio.Composition, opts ...Option) []*Issue {
+	config := &Config{Rules: DefaultRules()}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	var issues []*Issue
+	walk(composition, func(comp gotio.Composition) {
+		for _, rule := range config.Rules {
+			for _, issue := range rule.Check(comp) {
+				if issue.Severity >= config.MinSeverity {
+					issues = append(issues, issue)
+				}
+			}
+		}
+	})
+	return issues
+}
+
+// FixAll applies the fix of every fixable issue in order.
+// Returns the number of issues fixed and the first error encountered.
+func FixAll(issues []*Issue) (int, error) {
+	fixed := 0
+	for _, issue := range issues {
+		if !issue.Fixable() {
+			continue
+		}
+		if err := issue.Fix(); err != nil {
+			return fixed, err
+		}
+		fixed++
+	}
+	return fixed, nil
+}
+
+// HasErrors returns whether any issue has SeverityError.
+func HasErrors(issues []*Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity >= SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// walk calls fn for the composition and every composition nested inside it.
+func walk(composition gotio.Composition, fn func(gotio.Composition)) {
+	fn(composition)
+	for _, child := range composition.Children() {
+		if comp, ok := child.(gotio.Composition); ok {
+			walk(comp, fn)
+		}
+	}
+}
@@ -0,0 +1,394 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+	"github.com/Avalanche-io/gotio/mediaresolver"
+)
+
+func newTestClip(name string, start, dur, rate float64, ref gotio.MediaReference) *gotio.Clip {
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(start, rate), opentime.NewRationalTime(dur, rate))
+	return gotio.NewClip(name, ref, &sr, nil, nil, nil, "", nil)
+}
+
+func newTestReference(dur float64) gotio.MediaReference {
+	ar := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(dur, 24))
+	return gotio.NewExternalReference("", "/media/clip.mov", &ar, nil)
+}
+
+func newTestTimeline(children ...gotio.Composable) (*gotio.Timeline, *gotio.Track) {
+	timeline := gotio.NewTimeline("test", nil, nil)
+	track := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	for _, child := range children {
+		track.AppendChild(child)
+	}
+	timeline.Tracks().AppendChild(track)
+	return timeline, track
+}
+
+func issuesForRule(issues []*Issue, rule string) []*Issue {
+	var result []*Issue
+	for _, issue := range issues {
+		if issue.Rule == rule {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+func TestValidateCleanTimeline(t *testing.T) {
+	timeline, _ := newTestTimeline(
+		newTestClip("A", 0, 24, 24, newTestReference(48)),
+		newTestClip("B", 24, 24, 24, newTestReference(48)),
+	)
+	if issues := Validate(timeline); len(issues) != 0 {
+		t.Errorf("expected no issues, got %v", issues)
+	}
+	if issues := Validate(nil); issues != nil {
+		t.Errorf("expected no issues for nil timeline, got %v", issues)
+	}
+}
+
+func TestSourceRangeRule(t *testing.T) {
+	timeline, _ := newTestTimeline(newTestClip("A", 36, 24, 24, newTestReference(48)))
+
+	issues := issuesForRule(Validate(timeline), "source_range")
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 source_range issue, got %d", len(issues))
+	}
+	if issues[0].Severity != SeverityWarning {
+		t.Errorf("Severity = %s, want warning", issues[0].Severity)
+	}
+	if issues[0].Fixable() {
+		t.Error("expected source_range issue not to be fixable")
+	}
+	if err := issues[0].Fix(); err != ErrNotFixable {
+		t.Errorf("expected ErrNotFixable, got %v", err)
+	}
+}
+
+func TestTransitionLengthRule(t *testing.T) {
+	transition := gotio.NewTransition("dissolve", gotio.TransitionTypeSMPTEDissolve,
+		opentime.NewRationalTime(30, 24), opentime.NewRationalTime(6, 24), nil)
+	timeline, _ := newTestTimeline(
+		newTestClip("A", 0, 24, 24, newTestReference(48)),
+		transition,
+		newTestClip("B", 0, 24, 24, newTestReference(48)),
+	)
+
+	issues := issuesForRule(Validate(timeline), "transition_length")
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 transition_length issue, got %d", len(issues))
+	}
+	if issues[0].Severity != SeverityError || !HasErrors(issues) {
+		t.Error("expected transition_length issue to be an error")
+	}
+	if err := issues[0].Fix(); err != nil {
+		t.Fatalf("Fix error: %v", err)
+	}
+	if transition.InOffset().Value() != 24 || transition.OutOffset().Value() != 6 {
+		t.Errorf("offsets = %v/%v, want 24/6", transition.InOffset(), transition.OutOffset())
+	}
+	if issues := issuesForRule(Validate(timeline), "transition_length"); len(issues) != 0 {
+		t.Errorf("expected no issues after fix, got %v", issues)
+	}
+}
+
+func TestTransitionLengthRuleSharedItem(t *testing.T) {
+	// B is 24 frames long; the transitions either side of it take 16 and 16.
+	first := gotio.NewTransition("", gotio.TransitionTypeSMPTEDissolve,
+		opentime.NewRationalTime(4, 24), opentime.NewRationalTime(16, 24), nil)
+	second := gotio.NewTransition("", gotio.TransitionTypeSMPTEDissolve,
+		opentime.NewRationalTime(16, 24), opentime.NewRationalTime(4, 24), nil)
+	timeline, _ := newTestTimeline(
+		newTestClip("A", 0, 24, 24, newTestReference(48)),
+		first,
+		newTestClip("B", 0, 24, 24, newTestReference(48)),
+		second,
+		newTestClip("C", 0, 24, 24, newTestReference(48)),
+	)
+
+	issues := issuesForRule(Validate(timeline), "transition_length")
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 transition_length issues, got %d", len(issues))
+	}
+	if _, err := FixAll(issues); err != nil {
+		t.Fatalf("FixAll error: %v", err)
+	}
+	if total := first.OutOffset().Value() + second.InOffset().Value(); total > 24 {
+		t.Errorf("offsets into B total %v, want at most 24", total)
+	}
+	if issues := issuesForRule(Validate(timeline), "transition_length"); len(issues) != 0 {
+		t.Errorf("expected no issues after fix, got %v", issues)
+	}
+
+	edge := gotio.NewTransition("", gotio.TransitionTypeSMPTEDissolve,
+		opentime.NewRationalTime(-2, 24), opentime.NewRationalTime(6, 24), nil)
+	timeline, _ = newTestTimeline(edge, newTestClip("A", 0, 24, 24, newTestReference(48)))
+	issues = issuesForRule(Validate(timeline), "transition_length")
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue for a negative offset, got %d", len(issues))
+	}
+	if err := issues[0].Fix(); err != nil || edge.InOffset().Value() != 0 {
+		t.Errorf("expected the in offset to be clamped to 0, got %v (%v)", edge.InOffset(), err)
+	}
+}
+
+func TestTransitionParametersRule(t *testing.T) {
+	wipe := gotio.NewTransition("wipe", gotio.TransitionTypeSMPTEWipe,
+		opentime.NewRationalTime(6, 24), opentime.NewRationalTime(6, 24), nil)
+	timeline, _ := newTestTimeline(
+		newTestClip("A", 0, 24, 24, newTestReference(48)),
+		wipe,
+		newTestClip("B", 0, 24, 24, newTestReference(48)),
+	)
+
+	issues := issuesForRule(Validate(timeline), "transition_parameters")
+	if len(issues) != 1 || issues[0].Severity != SeverityWarning {
+		t.Fatalf("expected 1 transition_parameters warning, got %v", issues)
+	}
+	wipe.SetParameter(gotio.TransitionParamWipeNumber, 1)
+	if issues := issuesForRule(Validate(timeline), "transition_parameters"); len(issues) != 0 {
+		t.Errorf("expected no issues with a wipe number, got %v", issues)
+	}
+}
+
+func TestMarkerColorRule(t *testing.T) {
+	mr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(1, 24))
+	lower := gotio.NewMarker("lower", mr, "red", "", nil)
+	unknown := gotio.NewMarker("unknown", mr, "TEAL", "", nil)
+	clip := newTestClip("A", 0, 24, 24, newTestReference(48))
+	clip.SetMarkers([]*gotio.Marker{lower, gotio.NewMarker("ok", mr, gotio.MarkerColorBlue, "", nil)})
+	timeline, _ := newTestTimeline(clip)
+	timeline.Tracks().SetMarkers([]*gotio.Marker{unknown})
+
+	issues := issuesForRule(Validate(timeline), "marker_color")
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 marker_color warnings, got %v", issues)
+	}
+	for _, issue := range issues {
+		if err := issue.Fix(); err != nil && issue.Fixable() {
+			t.Errorf("fix failed: %v", err)
+		}
+	}
+	if lower.Color() != gotio.MarkerColorRed {
+		t.Errorf("expected red to be fixed to RED, got %s", lower.Color())
+	}
+	if issues := issuesForRule(Validate(timeline), "marker_color"); len(issues) != 1 || issues[0].Fixable() {
+		t.Errorf("expected only the unfixable TEAL marker, got %v", issues)
+	}
+}
+
+func TestTrackKindRule(t *testing.T) {
+	timeline, video := newTestTimeline()
+	video.SetKind("video")
+	for _, kind := range []string{gotio.TrackKindSubtitle, "Depth"} {
+		timeline.Tracks().AppendChild(gotio.NewTrack(kind, nil, kind, nil, nil))
+	}
+
+	issues := issuesForRule(Validate(timeline), "track_kind")
+	if len(issues) != 2 || !issues[0].Fixable() || issues[1].Fixable() {
+		t.Fatalf("expected fixable video and unfixable Depth warnings, got %v", issues)
+	}
+	if err := issues[0].Fix(); err != nil || video.Kind() != gotio.TrackKindVideo {
+		t.Errorf("fix = %v, kind %q", err, video.Kind())
+	}
+
+	gotio.RegisterTrackKind("Depth")
+	if issues := issuesForRule(Validate(timeline), "track_kind"); len(issues) != 0 {
+		t.Errorf("expected no issues once Depth is registered, got %v", issues)
+	}
+}
+
+func TestDurationRule(t *testing.T) {
+	zero := newTestClip("zero", 0, 0, 24, newTestReference(48))
+	negative := newTestClip("negative", 0, -5, 24, newTestReference(48))
+	timeline, track := newTestTimeline(newTestClip("A", 0, 24, 24, newTestReference(48)), zero, negative)
+
+	issues := issuesForRule(Validate(timeline), "duration")
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 duration issues, got %d", len(issues))
+	}
+
+	fixed, err := FixAll(issues)
+	if err != nil {
+		t.Fatalf("FixAll error: %v", err)
+	}
+	if fixed != 1 {
+		t.Errorf("fixed = %d, want 1", fixed)
+	}
+	if len(track.Children()) != 2 || track.Children()[1] != gotio.Composable(negative) {
+		t.Errorf("expected zero duration clip to be removed, got %d children", len(track.Children()))
+	}
+}
+
+func TestRateMismatchRule(t *testing.T) {
+	timeline, _ := newTestTimeline(
+		newTestClip("A", 0, 24, 24, nil),
+		newTestClip("B", 0, 25, 25, nil),
+	)
+
+	issues := issuesForRule(Validate(timeline), "rate_mismatch")
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 rate_mismatch issue, got %d", len(issues))
+	}
+	if issues[0].Object.Name() != "B" {
+		t.Errorf("expected issue on B, got %s", issues[0].Object.Name())
+	}
+
+	// The track rate is the rate of most of its duration, not its first item.
+	timeline, track := newTestTimeline(
+		newTestClip("A", 0, 24, 24, nil),
+		newTestClip("B", 0, 50, 25, nil),
+		newTestClip("C", 10, 25, 25, nil),
+	)
+	issues = issuesForRule(Validate(timeline), "rate_mismatch")
+	if len(issues) != 1 || issues[0].Object.Name() != "A" {
+		t.Fatalf("expected 1 issue on A, got %v", issues)
+	}
+	if _, err := FixAll(issues); err != nil {
+		t.Fatalf("FixAll error: %v", err)
+	}
+	if sr := track.Children()[0].(*gotio.Clip).SourceRange(); sr.Duration().Rate() != 25 || sr.Duration().Value() != 25 {
+		t.Errorf("fixed range = %v, want 25@25", sr)
+	}
+	if issues := issuesForRule(Validate(timeline), "rate_mismatch"); len(issues) != 0 {
+		t.Errorf("expected no issues after fixing, got %v", issues)
+	}
+}
+
+func TestMissingMediaRule(t *testing.T) {
+	timeline, _ := newTestTimeline(
+		newTestClip("missing", 0, 24, 24, nil),
+		newTestClip("empty_url", 0, 24, 24, gotio.NewExternalReference("", "", nil, nil)),
+		newTestClip("ok", 0, 24, 24, newTestReference(48)),
+	)
+
+	issues := issuesForRule(Validate(timeline), "missing_media")
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 missing_media issues, got %d", len(issues))
+	}
+	if !strings.Contains(issues[0].String(), "missing") {
+		t.Errorf("unexpected issue string %q", issues[0].String())
+	}
+}
+
+func TestMediaExistsRule(t *testing.T) {
+	dir := t.TempDir()
+	present := filepath.Join(dir, "present.mov")
+	if err := os.WriteFile(present, []byte("media"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ref := func(url string) gotio.MediaReference {
+		return gotio.NewExternalReference("", url, nil, nil)
+	}
+	timeline, _ := newTestTimeline(
+		newTestClip("present", 0, 24, 24, ref(present)),
+		newTestClip("present_again", 0, 24, 24, ref(present)),
+		newTestClip("absent", 0, 24, 24, ref(filepath.Join(dir, "absent.mov"))),
+		newTestClip("unsupported", 0, 24, 24, ref("s3://bucket/shot.mov")),
+	)
+
+	resolver := mediaresolver.New()
+	issues := Validate(timeline, WithRules(MediaExistsRule(resolver)))
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %v", issues)
+	}
+	if issues[0].Object.Name() != "absent" || issues[0].Severity != SeverityError {
+		t.Errorf("unexpected issue %v", issues[0])
+	}
+	if issues[1].Object.Name() != "unsupported" || issues[1].Severity != SeverityWarning {
+		t.Errorf("unexpected issue %v", issues[1])
+	}
+	if lookups, hits := resolver.Stats(); lookups != 2 || hits != 1 {
+		t.Errorf("expected shared media to be looked up once, got %d lookups, %d hits", lookups, hits)
+	}
+}
+
+func TestEmptyStackRule(t *testing.T) {
+	sr := opentime.NewTimeRange(opentime.NewRationalTime(0, 24), opentime.NewRationalTime(12, 24))
+	empty := gotio.NewStack("empty", &sr, nil, nil, nil, nil)
+	timeline, track := newTestTimeline(newTestClip("A", 0, 24, 24, newTestReference(48)), empty)
+
+	issues := issuesForRule(Validate(timeline), "empty_stack")
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 empty_stack issue, got %d", len(issues))
+	}
+	if err := issues[0].Fix(); err != nil {
+		t.Fatalf("Fix error: %v", err)
+	}
+	gap, ok := track.Children()[1].(*gotio.Gap)
+	if !ok {
+		t.Fatal("expected empty stack to be replaced with a gap")
+	}
+	if dur, _ := gap.Duration(); dur.Value() != 12 {
+		t.Errorf("gap duration = %v, want 12", dur.Value())
+	}
+}
+
+func TestTimeEffectRule(t *testing.T) {
+	clip := newTestClip("A", 0, 24, 24, newTestReference(48))
+	clip.SetEffects([]gotio.Effect{
+		gotio.NewLinearTimeWarp("", "LinearTimeWarp", 2, nil),
+		gotio.NewEffect("blur", "Blur", nil),
+		gotio.NewFreezeFrame("", nil),
+	})
+	timeline, _ := newTestTimeline(clip, newTestClip("B", 0, 24, 24, newTestReference(48)))
+
+	issues := issuesForRule(Validate(timeline), "time_effects")
+	if len(issues) != 1 || issues[0].Object != clip || issues[0].Severity != SeverityError {
+		t.Fatalf("expected 1 time_effects error on A, got %v", issues)
+	}
+}
+
+func TestValidateOptions(t *testing.T) {
+	timeline, _ := newTestTimeline(
+		newTestClip("missing", 0, 24, 24, nil),
+		newTestClip("zero", 0, 0, 24, newTestReference(48)),
+	)
+
+	issues := Validate(timeline, WithMinSeverity(SeverityError))
+	if len(issues) != 1 || issues[0].Rule != "duration" {
+		t.Errorf("expected only the duration error, got %v", issues)
+	}
+
+	custom := NewRule("named", func(composition gotio.Composition) []*Issue {
+		var issues []*Issue
+		for _, child := range composition.Children() {
+			if child.Name() == "" {
+				issues = append(issues, NewIssue(SeverityInfo, child, "unnamed", nil))
+			}
+		}
+		return issues
+	})
+	timeline.Tracks().Children()[0].(*gotio.Track).AppendChild(newTestClip("", 0, 24, 24, newTestReference(48)))
+
+	issues = Validate(timeline, WithRules(custom))
+	if len(issues) != 1 || issues[0].Rule != "named" {
+		t.Errorf("expected only the custom issue, got %v", issues)
+	}
+
+	issues = Validate(timeline, WithExtraRules(custom))
+	if len(issuesForRule(issues, "named")) != 1 || len(issuesForRule(issues, "missing_media")) != 1 {
+		t.Errorf("expected default and custom issues, got %v", issues)
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	for _, severity := range []Severity{SeverityInfo, SeverityWarning, SeverityError} {
+		if got, err := ParseSeverity(severity.String()); err != nil || got != severity {
+			t.Errorf("ParseSeverity(%q) = %v, %v", severity.String(), got, err)
+		}
+	}
+	if _, err := ParseSeverity("fatal"); err == nil {
+		t.Error("expected an error for an unknown severity")
+	}
+}
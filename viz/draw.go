@@ -0,0 +1,274 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package viz
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+const (
+	// gutterWidth is the width of the track labels left of the lanes.
+	gutterWidth = 110
+	// rulerHeight is the height of the timecode ruler above the lanes.
+	rulerHeight = 28
+	// minTickSpacing is the least distance in pixels between ruler ticks.
+	minTickSpacing = 90
+)
+
+// tickSteps are the ruler tick intervals in seconds, from which the
+// shortest at least minTickSpacing apart is used.
+var tickSteps = []float64{1, 2, 5, 10, 15, 30, 60, 120, 300, 600, 900, 1800, 3600}
+
+// style is the stylesheet embedded in every drawing, so an SVG file looks
+// the same on its own as in a page.
+const style = `text { font-family: sans-serif; font-size: 11px; fill: #222; }
+.ruler line { stroke: #999; }
+.ruler text { fill: #555; }
+.lane { fill: #eee; }
+.lane.alt { fill: #e4e4e4; }
+.label { font-weight: bold; }
+.clip rect { fill: #7a9cc6; stroke: #3c5a80; }
+.clip.audio rect { fill: #78b58a; stroke: #3d7a4f; }
+.stack rect { fill: #b39ddb; stroke: #6a4f9c; }
+.gap rect { fill: none; stroke: #bbb; stroke-dasharray: 3 3; }
+.disabled { opacity: 0.35; }
+.transition { fill: #fff; fill-opacity: 0.6; stroke: #444; }
+.marker { stroke-width: 2; }
+`
+
+// canvas accumulates the SVG of a drawing.
+type canvas struct {
+	b     strings.Builder
+	cfg   Config
+	scale float64
+	rate  float64
+}
+
+// x returns the horizontal position of t, in the timeline's time.
+func (c *canvas) x(t opentime.RationalTime) float64 {
+	return gutterWidth + t.ToSeconds()*c.scale
+}
+
+// width returns the width of a span of duration d.
+func (c *canvas) width(d opentime.RationalTime) float64 {
+	return d.ToSeconds() * c.scale
+}
+
+func (c *canvas) printf(format string, args ...any) {
+	fmt.Fprintf(&c.b, format, args...)
+}
+
+// draw returns the SVG drawing of timeline.
+func draw(timeline *gotio.Timeline, cfg Config) (string, error) {
+	duration, err := timeline.Duration()
+	if err != nil {
+		return "", err
+	}
+	c := &canvas{cfg: cfg, scale: cfg.Scale, rate: duration.Rate()}
+	if c.rate <= 0 {
+		c.rate = 24
+	}
+	if c.scale <= 0 {
+		c.scale = float64(cfg.Width) / math.Max(duration.ToSeconds(), 1)
+	}
+
+	var lanes []*gotio.Track
+	video := timeline.VideoTracks()
+	for i := len(video) - 1; i >= 0; i-- {
+		lanes = append(lanes, video[i])
+	}
+	for _, child := range timeline.Tracks().Children() {
+		if track, ok := child.(*gotio.Track); ok && track.Kind() != gotio.TrackKindVideo {
+			lanes = append(lanes, track)
+		}
+	}
+
+	width := gutterWidth + int(math.Ceil(c.width(duration))) + 10
+	height := rulerHeight + len(lanes)*cfg.LaneHeight + 1
+	c.printf("<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		width, height, width, height)
+	c.printf("<title>%s</title>\n<style>\n%s</style>\n", html.EscapeString(timeline.Name()), style)
+
+	c.drawRuler(timeline, duration, height)
+	for i, track := range lanes {
+		if err := c.drawTrack(track, rulerHeight+i*cfg.LaneHeight, i%2 == 1, width); err != nil {
+			return "", err
+		}
+	}
+	// Markers on the stack span every lane
+	for _, marker := range timeline.Tracks().Markers() {
+		c.drawMarker(marker, marker.MarkedRange().StartTime(), 0, height)
+	}
+	c.printf("</svg>\n")
+	return c.b.String(), nil
+}
+
+// drawRuler draws timecode ticks along the top, counted from the global
+// start time of timeline.
+func (c *canvas) drawRuler(timeline *gotio.Timeline, duration opentime.RationalTime, height int) {
+	step := tickSteps[len(tickSteps)-1]
+	for _, s := range tickSteps {
+		if s*c.scale >= minTickSpacing {
+			step = s
+			break
+		}
+	}
+	start := opentime.NewRationalTime(0, c.rate)
+	if global := timeline.GlobalStartTime(); global != nil {
+		start = *global
+	}
+	c.printf("<g class=\"ruler\">\n")
+	for s := 0.0; s <= duration.ToSeconds(); s += step {
+		t := opentime.FromSeconds(s, c.rate)
+		x := c.x(t)
+		label, err := start.Add(t).ToNearestTimecode(c.rate, opentime.InferFromRate)
+		if err != nil {
+			label = start.Add(t).ToTimeString()
+		}
+		c.printf("<line x1=\"%.1f\" y1=\"%d\" x2=\"%.1f\" y2=\"%d\"/>\n", x, rulerHeight-8, x, height)
+		c.printf("<text x=\"%.1f\" y=\"%d\">%s</text>\n", x+3, rulerHeight-12, label)
+	}
+	c.printf("</g>\n")
+}
+
+// drawTrack draws the lane of track with its top at y.
+func (c *canvas) drawTrack(track *gotio.Track, y int, alt bool, width int) error {
+	h := c.cfg.LaneHeight
+	class := "lane"
+	if alt {
+		class += " alt"
+	}
+	c.printf("<g class=\"track\">\n<rect class=\"%s\" x=\"0\" y=\"%d\" width=\"%d\" height=\"%d\"/>\n", class, y, width, h)
+	name := track.Name()
+	if name == "" {
+		name = track.Kind()
+	}
+	c.printf("<text class=\"label\" x=\"6\" y=\"%d\">%s</text>\n", y+h/2+4, html.EscapeString(name))
+
+	for i, child := range track.Children() {
+		item, ok := child.(gotio.Item)
+		if !ok {
+			continue
+		}
+		r, err := track.RangeOfChildAtIndex(i)
+		if err != nil {
+			return err
+		}
+		c.drawItem(track, item, r, y+2, h-4)
+	}
+
+	// Transitions go over the clips they join
+	for i, child := range track.Children() {
+		transition, ok := child.(*gotio.Transition)
+		if !ok {
+			continue
+		}
+		r, err := track.VisibleRangeOfChildAtIndex(i)
+		if err != nil {
+			return err
+		}
+		x, w := c.x(r.StartTime()), c.width(r.Duration())
+		c.printf("<path class=\"transition\" d=\"M%.1f %d L%.1f %d L%.1f %d L%.1f %d Z\"><title>%s</title></path>\n",
+			x, y+h-2, x+w, y+2, x+w, y+h-2, x, y+2, html.EscapeString(describe(transition.Name(), string(transition.TransitionType()), r)))
+	}
+
+	for _, marker := range track.Markers() {
+		c.drawMarker(marker, marker.MarkedRange().StartTime(), y, y+h)
+	}
+	c.printf("</g>\n")
+	return nil
+}
+
+// drawItem draws item at r, in the track's time, within the band of
+// height h starting at y.
+func (c *canvas) drawItem(track *gotio.Track, item gotio.Item, r opentime.TimeRange, y, h int) {
+	x, w := c.x(r.StartTime()), c.width(r.Duration())
+	class, fill := "gap", ""
+	var thumbnail string
+	switch item := item.(type) {
+	case *gotio.Gap:
+	case *gotio.Clip:
+		class = "clip"
+		if track.Kind() == gotio.TrackKindAudio {
+			class += " audio"
+		}
+		if color := item.Color(); color != nil {
+			fill = color.Hex()
+		}
+		if c.cfg.Thumbnail != nil {
+			thumbnail = c.cfg.Thumbnail(item)
+		}
+	default:
+		class = "stack"
+		if color := item.ItemColor(); color != nil {
+			fill = color.Hex()
+		}
+	}
+	if !item.Enabled() {
+		class += " disabled"
+	}
+
+	// A nested svg clips the name to the block
+	c.printf("<svg class=\"%s\" x=\"%.1f\" y=\"%d\" width=\"%.1f\" height=\"%d\">\n", class, x, y, w, h)
+	c.printf("<title>%s</title>\n", html.EscapeString(describe(item.Name(), item.SchemaName(), r)))
+	c.printf("<rect x=\"0.5\" y=\"0.5\" width=\"%.1f\" height=\"%d\" rx=\"3\"", math.Max(w-1, 0), h-1)
+	if fill != "" {
+		c.printf(" style=\"fill: %s\"", fill)
+	}
+	c.printf("/>\n")
+	textX := 4
+	if thumbnail != "" {
+		thumbWidth := (h - 4) * 16 / 9
+		c.printf("<image href=\"%s\" x=\"2\" y=\"2\" width=\"%d\" height=\"%d\" preserveAspectRatio=\"xMidYMid slice\"/>\n",
+			html.EscapeString(thumbnail), thumbWidth, h-4)
+		textX += thumbWidth + 2
+	}
+	if _, isGap := item.(*gotio.Gap); !isGap && item.Name() != "" {
+		c.printf("<text x=\"%d\" y=\"%d\">%s</text>\n", textX, h/2+4, html.EscapeString(item.Name()))
+	}
+	c.printf("</svg>\n")
+
+	// Item markers are in the item's source time
+	trimmed, err := item.TrimmedRange()
+	if err != nil {
+		return
+	}
+	for _, marker := range item.Markers() {
+		offset := marker.MarkedRange().StartTime().Sub(trimmed.StartTime())
+		if offset.ToSeconds() < 0 || offset.ToSeconds() > trimmed.Duration().ToSeconds() {
+			continue
+		}
+		c.drawMarker(marker, r.StartTime().Add(offset), y, y+h)
+	}
+}
+
+// drawMarker draws marker at t, in the timeline's time, from y1 to y2.
+func (c *canvas) drawMarker(marker *gotio.Marker, t opentime.RationalTime, y1, y2 int) {
+	x := c.x(t)
+	color := marker.CustomColor().Hex()
+	c.printf("<g class=\"marker\" stroke=\"%s\" fill=\"%s\"><title>%s</title>", color, color,
+		html.EscapeString(describe(marker.Name(), "Marker", marker.MarkedRange())))
+	c.printf("<line x1=\"%.1f\" y1=\"%d\" x2=\"%.1f\" y2=\"%d\"/>", x, y1, x, y2)
+	c.printf("<path d=\"M%.1f %d l4 -6 h-8 Z\"/></g>\n", x, y1+6)
+}
+
+// describe returns the tooltip of an object named name of kind at r.
+func describe(name, kind string, r opentime.TimeRange) string {
+	if name == "" {
+		name = kind
+	} else {
+		name += " (" + kind + ")"
+	}
+	start, err := r.StartTime().ToTimecodeAuto()
+	if err != nil {
+		start = r.StartTime().ToTimeString()
+	}
+	return fmt.Sprintf("%s\n%s, %g frames", name, start, r.Duration().Value())
+}
@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+// Package viz draws timelines as static pictures, for debugging adapters
+// and for lightweight review pages. WriteSVG draws a ruler and a lane per
+// track, video tracks above audio as in an editor: clips are named blocks
+// in their clip color, gaps are left open, transitions are drawn over the
+// span they overlap both clips, and markers are ticks in their colors.
+// WriteHTML wraps the drawing in a self-contained page.
+//
+// Basic usage:
+//
+//	err := viz.WriteHTML(w, timeline, viz.WithThumbnails(func(clip *gotio.Clip) string {
+//		return thumbnailURL(clip)
+//	}))
+package viz
+
+import (
+	"fmt"
+	"html"
+	"io"
+
+	"github.com/Avalanche-io/gotio"
+)
+
+func init() {
+	gotio.RegisterFeature("viz")
+}
+
+// DefaultWidth is the width in pixels of the timeline area when neither
+// WithWidth nor WithScale is given.
+const DefaultWidth = 1200
+
+// DefaultLaneHeight is the height in pixels of a track lane.
+const DefaultLaneHeight = 40
+
+// Config holds options for drawing timelines.
+type Config struct {
+	// Width is the width in pixels the timeline is fitted to.
+	Width int
+	// Scale, if positive, is the horizontal scale in pixels per second,
+	// overriding Width.
+	Scale float64
+	// LaneHeight is the height in pixels of a track lane.
+	LaneHeight int
+	// Thumbnail, if set, returns the URL of an image drawn at the head of
+	// a clip, or "" for none. Data URLs keep an HTML page self-contained.
+	Thumbnail func(clip *gotio.Clip) string
+}
+
+// Option is a functional option for drawing timelines.
+type Option func(*Config)
+
+// WithWidth sets the width in pixels the timeline is fitted to.
+func WithWidth(width int) Option {
+	return func(c *Config) {
+		c.Width = width
+	}
+}
+
+// WithScale sets the horizontal scale in pixels per second, for drawings
+// of different timelines to compare.
+func WithScale(pixelsPerSecond float64) Option {
+	return func(c *Config) {
+		c.Scale = pixelsPerSecond
+	}
+}
+
+// WithLaneHeight sets the height in pixels of a track lane.
+func WithLaneHeight(height int) Option {
+	return func(c *Config) {
+		c.LaneHeight = height
+	}
+}
+
+// WithThumbnails sets the function returning the image drawn at the head
+// of each clip.
+func WithThumbnails(thumbnail func(clip *gotio.Clip) string) Option {
+	return func(c *Config) {
+		c.Thumbnail = thumbnail
+	}
+}
+
+func newConfig(opts []Option) Config {
+	cfg := Config{Width: DefaultWidth, LaneHeight: DefaultLaneHeight}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.Width <= 0 {
+		cfg.Width = DefaultWidth
+	}
+	if cfg.LaneHeight <= 0 {
+		cfg.LaneHeight = DefaultLaneHeight
+	}
+	return cfg
+}
+
+// WriteSVG draws the timeline to w as an SVG document.
+func WriteSVG(w io.Writer, timeline *gotio.Timeline, opts ...Option) error {
+	svg, err := draw(timeline, newConfig(opts))
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, svg)
+	return err
+}
+
+// WriteHTML writes w a self-contained HTML page titled with the timeline
+// name, holding a summary and the drawing of WriteSVG.
+func WriteHTML(w io.Writer, timeline *gotio.Timeline, opts ...Option) error {
+	svg, err := draw(timeline, newConfig(opts))
+	if err != nil {
+		return err
+	}
+	duration, err := timeline.Duration()
+	if err != nil {
+		return err
+	}
+	timecode, err := duration.ToTimecodeAuto()
+	if err != nil {
+		timecode = fmt.Sprintf("%gs", duration.ToSeconds())
+	}
+	name := html.EscapeString(timeline.Name())
+	_, err = fmt.Fprintf(w, `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+body { font-family: sans-serif; margin: 1.5em; background: #f4f4f4; color: #222; }
+h1 { font-size: 1.3em; margin: 0 0 .3em; }
+p { margin: 0 0 1em; color: #555; }
+.timeline { overflow-x: auto; background: #fff; border: 1px solid #ccc; }
+</style>
+</head>
+<body>
+<h1>%s</h1>
+<p>%s, %d tracks, %d clips</p>
+<div class="timeline">
+%s</div>
+</body>
+</html>
+`, name, name, timecode, len(timeline.Tracks().Children()), len(timeline.FindClips(nil, false)), svg)
+	return err
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Contributors to the OpenTimelineIO project
+
+package viz
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Avalanche-io/gotio/opentime"
+	"github.com/Avalanche-io/gotio"
+)
+
+// newTestTimeline returns a timeline with a video track of two clips
+// joined by a dissolve and an audio track starting with a gap.
+func newTestTimeline() *gotio.Timeline {
+	rt := func(v float64) opentime.RationalTime { return opentime.NewRationalTime(v, 24) }
+	clip := func(name string, start, dur float64) *gotio.Clip {
+		sr := opentime.NewTimeRange(rt(start), rt(dur))
+		return gotio.NewClip(name, nil, &sr, nil, nil, nil, "", nil)
+	}
+
+	timeline := gotio.NewTimeline("reel <1>", nil, nil)
+	a := clip("A & B", 100, 48)
+	a.SetMarkers([]*gotio.Marker{
+		gotio.NewMarker("note", opentime.NewTimeRange(rt(110), rt(0)), gotio.MarkerColorRed, "", nil),
+	})
+	b := clip("C", 0, 48)
+	b.SetColor(gotio.NewColorRGB(1, 0.5, 0))
+	video := gotio.NewTrack("V1", nil, gotio.TrackKindVideo, nil, nil)
+	video.AppendChild(a)
+	video.AppendChild(gotio.NewTransition("dissolve", gotio.TransitionTypeSMPTEDissolve, rt(6), rt(6), nil))
+	video.AppendChild(b)
+	audio := gotio.NewTrack("A1", nil, gotio.TrackKindAudio, nil, nil)
+	audio.AppendChild(gotio.NewGapWithDuration(rt(24)))
+	audio.AppendChild(clip("music", 0, 72))
+	timeline.Tracks().AppendChild(audio)
+	timeline.Tracks().AppendChild(video)
+	return timeline
+}
+
+// checkXML fails the test if data is not well-formed XML.
+func checkXML(t *testing.T, data []byte) {
+	t.Helper()
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = false
+	d.Entity = xml.HTMLEntity
+	for {
+		_, err := d.Token()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatalf("malformed output: %v", err)
+		}
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteSVG(&buf, newTestTimeline(), WithScale(100), WithThumbnails(func(clip *gotio.Clip) string {
+		if clip.Name() == "C" {
+			return "thumbs/c.jpg"
+		}
+		return ""
+	}))
+	if err != nil {
+		t.Fatalf("WriteSVG error: %v", err)
+	}
+	checkXML(t, buf.Bytes())
+	svg := buf.String()
+
+	// 4 seconds at 100 pixels a second, after the labels
+	if !strings.Contains(svg, `width="520"`) {
+		t.Errorf("expected a drawing 520 pixels wide:\n%s", svg)
+	}
+	for _, want := range []string{
+		"A &amp; B", "reel &lt;1&gt;", ">music<",
+		`class="transition"`, "SMPTE_Dissolve",
+		`style="fill: #FF8000"`, `href="thumbs/c.jpg"`,
+		`stroke="` + gotio.MarkerColorRed.ToColor().Hex() + `"`, `class="clip audio"`, `class="gap"`,
+		">00:00:02:00<",
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG missing %q", want)
+		}
+	}
+	if strings.Count(svg, "<image") != 1 {
+		t.Errorf("expected one thumbnail, got %d", strings.Count(svg, "<image"))
+	}
+	if strings.Index(svg, ">V1<") > strings.Index(svg, ">A1<") {
+		t.Error("video tracks should be drawn above audio tracks")
+	}
+	// The marker 10 frames into A is drawn at 110 + 10/24 * 100 pixels
+	if !strings.Contains(svg, `x1="151.7"`) {
+		t.Errorf("marker not drawn at its record time:\n%s", svg)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, newTestTimeline()); err != nil {
+		t.Fatalf("WriteHTML error: %v", err)
+	}
+	page := buf.String()
+	if !strings.HasPrefix(page, "<!DOCTYPE html>") || !strings.Contains(page, "<title>reel &lt;1&gt;</title>") {
+		t.Errorf("unexpected page head:\n%s", page[:min(len(page), 200)])
+	}
+	if !strings.Contains(page, "2 tracks, 3 clips") || !strings.Contains(page, "<svg") {
+		t.Errorf("page missing summary or drawing:\n%s", page)
+	}
+	// Fitted to the default width
+	if !strings.Contains(page, `width="1320"`) {
+		t.Errorf("expected a drawing fitted to %d pixels", DefaultWidth)
+	}
+}